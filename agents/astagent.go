@@ -373,9 +373,23 @@ func (*AsteriskAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err
 	return utils.ErrNotImplemented
 }
 
-// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
+// V1WarnDisconnect plays the low balance announcement on the channel
 func (sma *AsteriskAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
-	return utils.ErrNotImplemented
+	if sma.cgrCfg.AsteriskAgentCfg().LowBalanceAnnFile == utils.EmptyString {
+		*reply = utils.OK
+		return
+	}
+	channelID := engine.NewMapEvent(args).GetStringIgnoreErrors(utils.OriginID)
+	if _, err = sma.astConn.Call(aringo.HTTP_POST, fmt.Sprintf("http://%s/ari/channels/%s/play",
+		sma.cgrCfg.AsteriskAgentCfg().AsteriskConns[sma.astConnIdx].Address, channelID),
+		url.Values{"media": {sma.cgrCfg.AsteriskAgentCfg().LowBalanceAnnFile}}); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> failed playing the low balance announcement on channel <%s>, err: %s",
+				utils.AsteriskAgent, channelID, err.Error()))
+		return
+	}
+	*reply = utils.OK
+	return
 }
 
 // CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
@@ -20,9 +20,21 @@ package agents
 import (
 	"testing"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
 )
 
 func TestAAsSessionSClientIface(t *testing.T) {
 	_ = sessions.BiRPClient(new(AsteriskAgent))
 }
+
+func TestAAV1WarnDisconnectNoAnnFile(t *testing.T) {
+	sma := &AsteriskAgent{cgrCfg: config.NewDefaultCGRConfig()}
+	var reply string
+	if err := sma.V1WarnDisconnect(map[string]interface{}{utils.OriginID: "1234"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %q, received %q", utils.OK, reply)
+	}
+}
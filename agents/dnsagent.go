@@ -39,28 +39,49 @@ func NewDNSAgent(cgrCfg *config.CGRConfig, fltrS *engine.FilterS,
 
 // DNSAgent translates DNS requests towards CGRateS infrastructure
 type DNSAgent struct {
-	cgrCfg  *config.CGRConfig // loaded CGRateS configuration
-	fltrS   *engine.FilterS   // connection towards FilterS
-	server  *dns.Server
-	connMgr *engine.ConnManager
+	cgrCfg   *config.CGRConfig // loaded CGRateS configuration
+	fltrS    *engine.FilterS   // connection towards FilterS
+	servers  []*dns.Server     // one server for each of the listeners
+	upstream *dnsUpstream      // forwards the queries not handled by the request processors
+	connMgr  *engine.ConnManager
 }
 
-// initDNSServer instantiates the DNS server
-func (da *DNSAgent) initDNSServer() (_ error) {
-	da.server = &dns.Server{
-		Addr: da.cgrCfg.DNSAgentCfg().Listen,
-		Net:  da.cgrCfg.DNSAgentCfg().ListenNet,
+// initDNSServer instantiates the DNS servers
+func (da *DNSAgent) initDNSServer() (err error) {
+	daCfg := da.cgrCfg.DNSAgentCfg()
+	servers := make([]*dns.Server, 0, len(daCfg.Listeners)+1)
+	var srv *dns.Server
+	if srv, err = da.newDNSServer(daCfg.Listen, daCfg.ListenNet); err != nil {
+		return
+	}
+	servers = append(servers, srv)
+	for _, lstn := range daCfg.Listeners {
+		if srv, err = da.newDNSServer(lstn.Address, lstn.Network); err != nil {
+			return
+		}
+		servers = append(servers, srv)
+	}
+	da.servers = servers
+	da.upstream = newDnsUpstream(daCfg)
+	return
+}
+
+// newDNSServer creates the server for one listener
+func (da *DNSAgent) newDNSServer(addr, net string) (srv *dns.Server, err error) {
+	srv = &dns.Server{
+		Addr: addr,
+		Net:  net,
 		Handler: dns.HandlerFunc(func(w dns.ResponseWriter, m *dns.Msg) {
 			go da.handleMessage(w, m)
 		}),
 	}
-	if strings.HasSuffix(da.cgrCfg.DNSAgentCfg().ListenNet, utils.TLSNoCaps) {
-		cert, err := tls.LoadX509KeyPair(da.cgrCfg.TLSCfg().ServerCerificate, da.cgrCfg.TLSCfg().ServerKey)
-		if err != nil {
-			return err
+	if strings.HasSuffix(net, utils.TLSNoCaps) {
+		var cert tls.Certificate
+		if cert, err = tls.LoadX509KeyPair(da.cgrCfg.TLSCfg().ServerCerificate, da.cgrCfg.TLSCfg().ServerKey); err != nil {
+			return
 		}
-		da.server.Net = "tcp-tls"
-		da.server.TLSConfig = &tls.Config{
+		srv.Net = "tcp-tls"
+		srv.TLSConfig = &tls.Config{
 			Certificates: []tls.Certificate{cert},
 		}
 	}
@@ -68,10 +89,17 @@ func (da *DNSAgent) initDNSServer() (_ error) {
 }
 
 // ListenAndServe will run the DNS handler doing also the connection to listen address
+// returns when the first of the servers stops
 func (da *DNSAgent) ListenAndServe() (err error) {
-	utils.Logger.Info(fmt.Sprintf("<%s> start listening on <%s:%s>",
-		utils.DNSAgent, da.cgrCfg.DNSAgentCfg().ListenNet, da.cgrCfg.DNSAgentCfg().Listen))
-	return da.server.ListenAndServe()
+	errChan := make(chan error, len(da.servers))
+	for _, srv := range da.servers {
+		go func(srv *dns.Server) {
+			utils.Logger.Info(fmt.Sprintf("<%s> start listening on <%s:%s>",
+				utils.DNSAgent, srv.Net, srv.Addr))
+			errChan <- srv.ListenAndServe()
+		}(srv)
+	}
+	return <-errChan
 }
 
 // Reload will reinitialize the server
@@ -88,8 +116,13 @@ func (da *DNSAgent) handleMessage(w dns.ResponseWriter, req *dns.Msg) {
 	rply := newDnsReply(req)
 	rmtAddr := w.RemoteAddr().String()
 	for _, q := range req.Question {
-		if processed, err := da.handleQuestion(dnsDP, rply, &q, rmtAddr); err != nil ||
-			!processed {
+		processed, err := da.handleQuestion(dnsDP, rply, &q, rmtAddr)
+		if err == nil && !processed &&
+			da.upstream != nil { // none of the processors matched, let the upstream servers answer
+			da.forwardMessage(w, req, rmtAddr)
+			return
+		}
+		if err != nil || !processed {
 			rply := newDnsReply(req)
 			rply.Rcode = dns.RcodeServerFailure
 			dnsWriteMsg(w, rply)
@@ -104,9 +137,27 @@ func (da *DNSAgent) handleMessage(w dns.ResponseWriter, req *dns.Msg) {
 	}
 }
 
-// Shutdown stops the DNS server
-func (da *DNSAgent) Shutdown() error {
-	return da.server.Shutdown()
+// forwardMessage sends the request to the upstream servers and writes back their reply
+func (da *DNSAgent) forwardMessage(w dns.ResponseWriter, req *dns.Msg, rmtAddr string) {
+	rply, err := da.upstream.exchange(req)
+	if err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: %s forwarding message: %s from %s",
+				utils.DNSAgent, err.Error(), utils.ToJSON(req), rmtAddr))
+		rply = newDnsReply(req)
+		rply.Rcode = dns.RcodeServerFailure
+	}
+	dnsWriteMsg(w, rply)
+}
+
+// Shutdown stops the DNS servers
+func (da *DNSAgent) Shutdown() (err error) {
+	for _, srv := range da.servers {
+		if errShdn := srv.Shutdown(); errShdn != nil {
+			err = errShdn
+		}
+	}
+	return
 }
 
 // handleMessage is the entry point of all DNS requests
@@ -149,9 +200,11 @@ func (da *DNSAgent) handleQuestion(dnsDP utils.DataProvider, rply *dns.Msg, q *d
 		}
 	}
 	if !processed {
-		utils.Logger.Warning(
-			fmt.Sprintf("<%s> no request processor enabled, ignoring message %s from %s",
-				utils.DNSAgent, dnsDP, rmtAddr))
+		if da.upstream == nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> no request processor enabled, ignoring message %s from %s",
+					utils.DNSAgent, dnsDP, rmtAddr))
+		}
 		return
 	}
 	if err = updateDNSMsgFromNM(rply, rplyNM, q.Qtype, q.Name); err != nil {
@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"encoding/json"
 	"errors"
 	"fmt"
 	"log"
@@ -43,13 +44,17 @@ var (
 	kamDlgListRegexp       = regexp.MustCompile(CGR_DLG_LIST)
 	kamProcessMessageRegex = regexp.MustCompile(CGR_PROCESS_MESSAGE)
 	kamProcessCDRRegex     = regexp.MustCompile(CGR_PROCESS_CDR)
+	kamJSONEventRegexp     = regexp.MustCompile(`.`) // any event in *json mode
 )
 
 func NewKamailioAgent(kaCfg *config.KamAgentCfg,
-	connMgr *engine.ConnManager, timezone string) (ka *KamailioAgent) {
+	connMgr *engine.ConnManager, fltrS *engine.FilterS,
+	dfltTnt, timezone string) (ka *KamailioAgent) {
 	ka = &KamailioAgent{
 		cfg:              kaCfg,
 		connMgr:          connMgr,
+		fltrS:            fltrS,
+		dfltTnt:          dfltTnt,
 		timezone:         timezone,
 		conns:            make([]*kamevapi.KamEvapi, len(kaCfg.EvapiConns)),
 		activeSessionIDs: make(chan []*sessions.SessionID),
@@ -60,6 +65,8 @@ func NewKamailioAgent(kaCfg *config.KamAgentCfg,
 type KamailioAgent struct {
 	cfg              *config.KamAgentCfg
 	connMgr          *engine.ConnManager
+	fltrS            *engine.FilterS
+	dfltTnt          string
 	timezone         string
 	conns            []*kamevapi.KamEvapi
 	activeSessionIDs chan []*sessions.SessionID
@@ -74,6 +81,12 @@ func (self *KamailioAgent) Connect() (err error) {
 		kamProcessMessageRegex: {self.onCgrProcessMessage},
 		kamProcessCDRRegex:     {self.onCgrProcessCDR},
 	}
+	if self.cfg.Mode == utils.MetaJSON {
+		eventHandlers = map[*regexp.Regexp][]func([]byte, int){
+			kamDlgListRegexp:   {self.onDlgList},
+			kamJSONEventRegexp: {self.onJSONEvent},
+		}
+	}
 	errChan := make(chan error)
 	for connIdx, connCfg := range self.cfg.EvapiConns {
 		logger := log.New(utils.Logger, "kamevapi:", 2)
@@ -360,6 +373,78 @@ func (ka *KamailioAgent) onCgrProcessCDR(evData []byte, connIdx int) {
 	}
 }
 
+// onJSONEvent is called in *json mode for the dialog and accounting events
+// the event is processed based on the configured request_processors
+func (ka *KamailioAgent) onJSONEvent(evData []byte, connIdx int) {
+	if kamDlgListRegexp.Match(evData) { // handled by onDlgList
+		return
+	}
+	if connIdx >= len(ka.conns) { // protection against index out of range panic
+		err := fmt.Errorf("Index out of range[0,%v): %v ", len(ka.conns), connIdx)
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.KamailioAgent, err.Error()))
+		return
+	}
+	var ev map[string]interface{}
+	if err := json.Unmarshal(evData, &ev); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> unmarshalling event data: %s, error: %s",
+			utils.KamailioAgent, evData, err.Error()))
+		return
+	}
+	remoteHost := ka.conns[connIdx].RemoteAddr().String()
+	reqVars := &utils.DataNode{
+		Type: utils.NMMapType,
+		Map: map[string]*utils.DataNode{
+			utils.OriginHost: utils.NewLeafNode(utils.FirstNonEmpty(ka.cfg.EvapiConns[connIdx].Alias, remoteHost)),
+			utils.RemoteHost: utils.NewLeafNode(remoteHost),
+			EvapiConnID:      utils.NewLeafNode(connIdx),
+		},
+	}
+	cgrRplyNM := &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
+	rplyNM := utils.NewOrderedNavigableMap() // share it among different processors
+	opts := utils.MapStorage{}
+	var processed bool
+	for _, reqProcessor := range ka.cfg.RequestProcessors {
+		agReq := NewAgentRequest(
+			utils.MapStorage(ev), reqVars, cgrRplyNM, rplyNM,
+			opts, reqProcessor.Tenant, ka.dfltTnt,
+			utils.FirstNonEmpty(reqProcessor.Timezone, ka.timezone),
+			ka.fltrS, nil)
+		// attach the connection ID so we can properly disconnect later
+		agReq.CGRRequest.Set(&utils.FullPath{Path: EvapiConnID, PathSlice: []string{EvapiConnID}},
+			utils.NewLeafNode(connIdx))
+		lclProcessed, err := processRequest(reqProcessor, agReq,
+			utils.KamailioAgent, ka.connMgr, ka.cfg.SessionSConns,
+			ka, ka.fltrS)
+		if err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s processing event: %s from %s",
+					utils.KamailioAgent, err.Error(), evData, remoteHost))
+			return
+		}
+		if !lclProcessed {
+			continue
+		}
+		processed = true
+		if !reqProcessor.Flags.GetBool(utils.MetaContinue) {
+			break
+		}
+	}
+	if !processed {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> no request processor enabled, ignoring event %s from %s",
+				utils.KamailioAgent, evData, remoteHost))
+		return
+	}
+	rply := replyFromNM(rplyNM)
+	if len(rply) == 0 {
+		return
+	}
+	if err := ka.conns[connIdx].Send(utils.ToJSON(rply)); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed sending reply for event: %s, error: %s",
+			utils.KamailioAgent, evData, err.Error()))
+	}
+}
+
 func (self *KamailioAgent) disconnectSession(connIdx int, dscEv *KamSessionDisconnect) (err error) {
 	if err = self.conns[connIdx].Send(dscEv.String()); err != nil {
 		utils.Logger.Err(fmt.Sprintf("<%s> failed sending disconnect request: %s,  connection id: %v, error %s",
@@ -389,6 +474,16 @@ func (ka *KamailioAgent) V1DisconnectSession(args utils.AttrDisconnectSession, r
 		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.KamailioAgent, err.Error()))
 		return
 	}
+	if ka.cfg.Mode == utils.MetaJSON {
+		dlgEnd := NewKamDlgEndRequest(args.EventStart[KamHashEntry], args.EventStart[KamHashID])
+		if err = ka.conns[connIdx].Send(dlgEnd.String()); err != nil {
+			utils.Logger.Err(fmt.Sprintf("<%s> failed sending disconnect request: %s,  connection id: %v, error %s",
+				utils.KamailioAgent, dlgEnd, connIdx, err.Error()))
+			return
+		}
+		*reply = utils.OK
+		return
+	}
 	if err = ka.disconnectSession(int(connIdx),
 		NewKamSessionDisconnect(hEntry, hID,
 			utils.ErrInsufficientCredit.Error())); err != nil {
@@ -445,9 +540,36 @@ func (*KamailioAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err
 	return utils.ErrNotImplemented
 }
 
-// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
-func (*KamailioAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
-	return utils.ErrNotImplemented
+// V1WarnDisconnect sends the low balance warning towards Kamailio
+func (ka *KamailioAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
+	hEntry := utils.IfaceAsString(args[KamHashEntry])
+	hID := utils.IfaceAsString(args[KamHashID])
+	connIdxIface, has := args[EvapiConnID]
+	if !has {
+		utils.Logger.Err(
+			fmt.Sprintf("<%s> error: <%s:%s> when attempting to warn <%s:%s> and <%s:%s>",
+				utils.KamailioAgent, utils.ErrNotFound.Error(), EvapiConnID,
+				KamHashEntry, hEntry, KamHashID, hID))
+		return utils.ErrNotFound
+	}
+	connIdx, err := utils.IfaceAsTInt64(connIdxIface)
+	if err != nil {
+		return err
+	}
+	if int(connIdx) >= len(ka.conns) { // protection against index out of range panic
+		err = fmt.Errorf("Index out of range[0,%v): %v ", len(ka.conns), connIdx)
+		utils.Logger.Err(fmt.Sprintf("<%s> %s", utils.KamailioAgent, err.Error()))
+		return
+	}
+	wrnEv := NewKamSessionWarn(hEntry, hID,
+		utils.IfaceAsString(args[utils.LowBalanceThreshold]))
+	if err = ka.conns[connIdx].Send(wrnEv.String()); err != nil {
+		utils.Logger.Err(fmt.Sprintf("<%s> failed sending low balance warning: %s,  connection id: %v, error %s",
+			utils.KamailioAgent, utils.ToJSON(wrnEv), connIdx, err.Error()))
+		return
+	}
+	*reply = utils.OK
+	return
 }
 
 // CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
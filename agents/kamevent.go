@@ -34,6 +34,7 @@ const (
 	CGR_AUTH_REQUEST       = "CGR_AUTH_REQUEST"
 	CGR_AUTH_REPLY         = "CGR_AUTH_REPLY"
 	CGR_SESSION_DISCONNECT = "CGR_SESSION_DISCONNECT"
+	CGR_LOW_BALANCE        = "CGR_LOW_BALANCE"
 	CGR_CALL_START         = "CGR_CALL_START"
 	CGR_CALL_END           = "CGR_CALL_END"
 	CGR_PROCESS_MESSAGE    = "CGR_PROCESS_MESSAGE"
@@ -45,6 +46,7 @@ const (
 	KamReplyRoute          = "reply_route"
 	EvapiConnID            = "EvapiConnID" // used to share connID info in event for remote disconnects
 	CGR_DLG_LIST           = "CGR_DLG_LIST"
+	KamDlgEndMethod        = "dlg.end_dlg" // RPC command terminating the dialog in Kamailio
 )
 
 var (
@@ -71,6 +73,58 @@ func (ksd *KamSessionDisconnect) String() string {
 	return utils.ToJSON(ksd)
 }
 
+// NewKamSessionWarn returns the event sent to Kamailio when the session reached a low balance threshold
+func NewKamSessionWarn(hEntry, hID, threshold string) *KamSessionWarn {
+	return &KamSessionWarn{
+		Event:     CGR_LOW_BALANCE,
+		HashEntry: hEntry,
+		HashId:    hID,
+		Threshold: threshold}
+}
+
+// KamSessionWarn is the low balance warning sent to Kamailio
+type KamSessionWarn struct {
+	Event     string
+	HashEntry string
+	HashId    string
+	Threshold string
+}
+
+func (ksw *KamSessionWarn) String() string {
+	return utils.ToJSON(ksw)
+}
+
+// NewKamDlgEndRequest returns the JSON-RPC request ending the dialog in Kamailio
+// the hash entry and id are sent as integers if possible since this is what the dialog module expects
+func NewKamDlgEndRequest(hEntry, hID interface{}) *KamJSONRPCRequest {
+	params := make([]interface{}, 2)
+	for i, prm := range []interface{}{hEntry, hID} {
+		if iPrm, err := utils.IfaceAsTInt64(prm); err == nil {
+			params[i] = iPrm
+			continue
+		}
+		params[i] = utils.IfaceAsString(prm)
+	}
+	return &KamJSONRPCRequest{
+		JSONRPC: "2.0",
+		Method:  KamDlgEndMethod,
+		Params:  params,
+		ID:      utils.GenUUID(),
+	}
+}
+
+// KamJSONRPCRequest is the JSON-RPC command sent over evapi and executed by Kamailio with jsonrpcs module
+type KamJSONRPCRequest struct {
+	JSONRPC string        `json:"jsonrpc"`
+	Method  string        `json:"method"`
+	Params  []interface{} `json:"params"`
+	ID      string        `json:"id"`
+}
+
+func (kjr *KamJSONRPCRequest) String() string {
+	return utils.ToJSON(kjr)
+}
+
 // NewKamEvent parses bytes received over the wire from Kamailio into KamEvent
 func NewKamEvent(kamEvData []byte, alias, adress string) (KamEvent, error) {
 	kev := make(map[string]string)
@@ -504,3 +504,28 @@ func TestKamEvAsKamProcessEventReply(t *testing.T) {
 		t.Errorf("Expecting: %+v, received: %+v", expected, rcv)
 	}
 }
+
+func TestNewKamSessionWarn(t *testing.T) {
+	exp := `{"Event":"CGR_LOW_BALANCE","HashEntry":"3039","HashId":"4061","Threshold":"1m0s"}`
+	if rcv := NewKamSessionWarn("3039", "4061", "1m0s").String(); rcv != exp {
+		t.Errorf("Expecting: %s, received: %s", exp, rcv)
+	}
+}
+
+func TestNewKamDlgEndRequest(t *testing.T) {
+	rcv := NewKamDlgEndRequest("3039", 4061)
+	if rcv.ID == utils.EmptyString {
+		t.Error("Expected the request ID to be populated")
+	}
+	rcv.ID = "1"
+	exp := `{"jsonrpc":"2.0","method":"dlg.end_dlg","params":[3039,4061],"id":"1"}`
+	if rcv.String() != exp {
+		t.Errorf("Expecting: %s, received: %s", exp, rcv.String())
+	}
+	rcv = NewKamDlgEndRequest("h_entry", nil)
+	rcv.ID = "1"
+	exp = `{"jsonrpc":"2.0","method":"dlg.end_dlg","params":["h_entry",""],"id":"1"}`
+	if rcv.String() != exp {
+		t.Errorf("Expecting: %s, received: %s", exp, rcv.String())
+	}
+}
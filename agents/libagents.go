@@ -20,6 +20,7 @@ package agents
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
@@ -202,3 +203,15 @@ func processRequest(reqProcessor *config.RequestProcessor, agReq *AgentRequest,
 	}
 	return true, nil
 }
+
+// replyFromNM flattens the reply into a JSON object with the paths as keys
+func replyFromNM(nM *utils.OrderedNavigableMap) (rply map[string]interface{}) {
+	rply = make(map[string]interface{})
+	for el := nM.GetFirstElement(); el != nil; el = el.Next() {
+		path := el.Value
+		nmIt, _ := nM.Field(path) // safe to ignore the error, the path always exists
+		path = path[:len(path)-1] // remove the last index
+		rply[strings.Join(path, utils.NestingSep)] = nmIt.Data
+	}
+	return
+}
@@ -23,9 +23,11 @@ import (
 	"net"
 	"strconv"
 	"strings"
+	"time"
 
 	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
 	"github.com/miekg/dns"
 )
 
@@ -168,8 +170,33 @@ func updateDNSMsgFromNM(msg *dns.Msg, nm *utils.OrderedNavigableMap, qType uint1
 			if msg.Answer, err = updateDnsAnswer(msg.Answer, qType, qName, path[1:len(path)-1], itm.Data, newBranch); err != nil {
 				return fmt.Errorf("item: <%s>, err: %s", path[:len(path)-1], err.Error())
 			}
-		case utils.DNSNs: //ToDO
-		case utils.DNSExtra: //ToDO
+		case utils.DNSNs:
+			newBranch := itm.NewBranch ||
+				len(msg.Ns) == 0 ||
+				msgFields.Has(path[0])
+			if newBranch { // force append if the same path was already used
+				msgFields = make(utils.StringSet)      // reset the fields inside since we have a new message
+				msgFields.Add(strings.Join(path, ".")) // detect new branch
+			}
+			if msg.Ns, err = updateDnsAnswer(msg.Ns, dns.TypeNS, qName, path[1:len(path)-1], itm.Data, newBranch); err != nil {
+				return fmt.Errorf("item: <%s>, err: %s", path[:len(path)-1], err.Error())
+			}
+		case utils.DNSExtra:
+			extra, opt := splitDnsOPT(msg.Extra) // the OPT record is handled through Option
+			newBranch := itm.NewBranch ||
+				len(extra) == 0 ||
+				msgFields.Has(path[0])
+			if newBranch { // force append if the same path was already used
+				msgFields = make(utils.StringSet)      // reset the fields inside since we have a new message
+				msgFields.Add(strings.Join(path, ".")) // detect new branch
+			}
+			if extra, err = updateDnsAnswer(extra, dns.TypeA, qName, path[1:len(path)-1], itm.Data, newBranch); err != nil {
+				return fmt.Errorf("item: <%s>, err: %s", path[:len(path)-1], err.Error())
+			}
+			if opt != nil {
+				extra = append(extra, opt)
+			}
+			msg.Extra = extra
 		case utils.DNSOption:
 			opts := msg.IsEdns0()
 			if opts == nil {
@@ -185,6 +212,19 @@ func updateDNSMsgFromNM(msg *dns.Msg, nm *utils.OrderedNavigableMap, qType uint1
 	return
 }
 
+// splitDnsOPT separates the OPT pseudo-record from the additional records
+func splitDnsOPT(extra []dns.RR) (rrs []dns.RR, opt *dns.OPT) {
+	rrs = make([]dns.RR, 0, len(extra))
+	for _, rr := range extra {
+		if o, isOPT := rr.(*dns.OPT); isOPT {
+			opt = o
+			continue
+		}
+		rrs = append(rrs, rr)
+	}
+	return
+}
+
 // updateDnsQuestion
 func updateDnsQuestions(q []dns.Question, path []string, value interface{}, newBranch bool) (_ []dns.Question, err error) {
 	var idx int
@@ -557,6 +597,8 @@ func createDnsOption(field string, value interface{}) (o dns.EDNS0, err error) {
 	return
 }
 
+// updateDnsAnswer updates the resource records of one of the message sections(Answer, Ns or Extra)
+// qType is the type of the records created by default, changed by populating the Hdr.Rrtype
 func updateDnsAnswer(q []dns.RR, qType uint16, qName string, path []string, value interface{}, newBranch bool) (_ []dns.RR, err error) {
 	var idx int
 	if lPath := len(path); lPath == 0 {
@@ -591,10 +633,32 @@ func updateDnsAnswer(q []dns.RR, qType uint16, qName string, path []string, valu
 			path = path[1:]
 		}
 	}
+	if len(path) == 2 &&
+		path[0] == utils.DNSHdr &&
+		path[1] == utils.DNSRrtype { // the type of the record changes so we need a new payload
+		var rrType uint16
+		if rrType, err = dnsTypeFromValue(value); err != nil {
+			return
+		}
+		hdr := q[idx].Header()
+		if hdr.Rrtype == rrType {
+			return q, nil
+		}
+		var a dns.RR
+		if a, err = newDNSAnswer(rrType, hdr.Name); err != nil {
+			return
+		}
+		a.Header().Class = hdr.Class
+		a.Header().Ttl = hdr.Ttl
+		q[idx] = a
+		return q, nil
+	}
 
 	switch v := q[idx].(type) {
 	case *dns.NAPTR:
 		err = updateDnsNAPTRAnswer(v, path, value)
+	case *dns.SRV:
+		err = updateDnsSRVAnswer(v, path, value)
 	case *dns.A:
 		if len(path) < 1 ||
 			(path[0] != utils.DNSHdr && len(path) != 1) ||
@@ -606,7 +670,82 @@ func updateDnsAnswer(q []dns.RR, qType uint16, qName string, path []string, valu
 		case utils.DNSHdr:
 			err = updateDnsRRHeader(&v.Hdr, path[1:], value)
 		case utils.DNSA:
-			v.A = net.IP(utils.IfaceAsString(value))
+			v.A = net.ParseIP(utils.IfaceAsString(value))
+		default:
+			err = utils.ErrWrongPath
+		}
+	case *dns.AAAA:
+		if len(path) < 1 ||
+			(path[0] != utils.DNSHdr && len(path) != 1) ||
+			(path[0] == utils.DNSHdr && len(path) != 2) {
+			err = utils.ErrWrongPath
+			return
+		}
+		switch path[0] {
+		case utils.DNSHdr:
+			err = updateDnsRRHeader(&v.Hdr, path[1:], value)
+		case utils.DNSAAAA:
+			v.AAAA = net.ParseIP(utils.IfaceAsString(value))
+		default:
+			err = utils.ErrWrongPath
+		}
+	case *dns.NS:
+		if len(path) < 1 ||
+			(path[0] != utils.DNSHdr && len(path) != 1) ||
+			(path[0] == utils.DNSHdr && len(path) != 2) {
+			err = utils.ErrWrongPath
+			return
+		}
+		switch path[0] {
+		case utils.DNSHdr:
+			err = updateDnsRRHeader(&v.Hdr, path[1:], value)
+		case utils.DNSNs:
+			v.Ns = dns.Fqdn(utils.IfaceAsString(value))
+		default:
+			err = utils.ErrWrongPath
+		}
+	case *dns.CNAME:
+		if len(path) < 1 ||
+			(path[0] != utils.DNSHdr && len(path) != 1) ||
+			(path[0] == utils.DNSHdr && len(path) != 2) {
+			err = utils.ErrWrongPath
+			return
+		}
+		switch path[0] {
+		case utils.DNSHdr:
+			err = updateDnsRRHeader(&v.Hdr, path[1:], value)
+		case utils.DNSTarget:
+			v.Target = dns.Fqdn(utils.IfaceAsString(value))
+		default:
+			err = utils.ErrWrongPath
+		}
+	case *dns.PTR:
+		if len(path) < 1 ||
+			(path[0] != utils.DNSHdr && len(path) != 1) ||
+			(path[0] == utils.DNSHdr && len(path) != 2) {
+			err = utils.ErrWrongPath
+			return
+		}
+		switch path[0] {
+		case utils.DNSHdr:
+			err = updateDnsRRHeader(&v.Hdr, path[1:], value)
+		case utils.DNSPtr:
+			v.Ptr = dns.Fqdn(utils.IfaceAsString(value))
+		default:
+			err = utils.ErrWrongPath
+		}
+	case *dns.TXT:
+		if len(path) < 1 ||
+			(path[0] != utils.DNSHdr && len(path) != 1) ||
+			(path[0] == utils.DNSHdr && len(path) != 2) {
+			err = utils.ErrWrongPath
+			return
+		}
+		switch path[0] {
+		case utils.DNSHdr:
+			err = updateDnsRRHeader(&v.Hdr, path[1:], value)
+		case utils.DNSTxt: // every populated value is a new string inside the record
+			v.Txt = append(v.Txt, utils.IfaceAsString(value))
 		default:
 			err = utils.ErrWrongPath
 		}
@@ -630,14 +769,74 @@ func newDNSAnswer(qType uint16, qName string) (a dns.RR, err error) {
 	switch qType {
 	case dns.TypeA:
 		a = &dns.A{Hdr: hdr}
+	case dns.TypeAAAA:
+		a = &dns.AAAA{Hdr: hdr}
 	case dns.TypeNAPTR:
 		a = &dns.NAPTR{Hdr: hdr}
+	case dns.TypeSRV:
+		a = &dns.SRV{Hdr: hdr}
+	case dns.TypeNS:
+		a = &dns.NS{Hdr: hdr}
+	case dns.TypeCNAME:
+		a = &dns.CNAME{Hdr: hdr}
+	case dns.TypePTR:
+		a = &dns.PTR{Hdr: hdr}
+	case dns.TypeTXT:
+		a = &dns.TXT{Hdr: hdr}
 	default:
 		err = fmt.Errorf("unsupported DNS type: <%v>", dns.TypeToString[qType])
 	}
 	return
 }
 
+// dnsTypeFromValue accepts the record type either as number or as name(ie. NAPTR)
+func dnsTypeFromValue(value interface{}) (rrType uint16, err error) {
+	strVal := utils.IfaceAsString(value)
+	if typ, has := dns.StringToType[strings.ToUpper(strVal)]; has {
+		return typ, nil
+	}
+	var vItm int64
+	if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+		return
+	}
+	return uint16(vItm), nil
+}
+
+func updateDnsSRVAnswer(v *dns.SRV, path []string, value interface{}) (err error) {
+	if len(path) < 1 ||
+		(path[0] != utils.DNSHdr && len(path) != 1) ||
+		(path[0] == utils.DNSHdr && len(path) != 2) {
+		return utils.ErrWrongPath
+	}
+	switch path[0] {
+	case utils.DNSHdr:
+		return updateDnsRRHeader(&v.Hdr, path[1:], value)
+	case utils.DNSPriority:
+		var vItm int64
+		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+			return
+		}
+		v.Priority = uint16(vItm)
+	case utils.DNSWeight:
+		var vItm int64
+		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+			return
+		}
+		v.Weight = uint16(vItm)
+	case utils.DNSPort:
+		var vItm int64
+		if vItm, err = utils.IfaceAsTInt64(value); err != nil {
+			return
+		}
+		v.Port = uint16(vItm)
+	case utils.DNSTarget:
+		v.Target = dns.Fqdn(utils.IfaceAsString(value))
+	default:
+		return utils.ErrWrongPath
+	}
+	return
+}
+
 func updateDnsNAPTRAnswer(v *dns.NAPTR, path []string, value interface{}) (err error) {
 	if len(path) < 1 ||
 		(path[0] != utils.DNSHdr && len(path) != 1) ||
@@ -709,3 +908,150 @@ func updateDnsRRHeader(v *dns.RR_Header, path []string, value interface{}) (err
 	}
 	return
 }
+
+// newDnsUpstream returns the forwarder of the queries not handled by the request processors
+// nil is returned if no upstream servers are configured
+func newDnsUpstream(cfg *config.DNSAgentCfg) (up *dnsUpstream) {
+	if len(cfg.UpstreamServers) == 0 {
+		return
+	}
+	up = &dnsUpstream{
+		servers: utils.CloneStringSlice(cfg.UpstreamServers),
+		clnt: &dns.Client{
+			Net:     cfg.UpstreamNet,
+			Timeout: cfg.UpstreamTimeout,
+		},
+	}
+	if cfg.UpstreamCacheLimit != 0 {
+		up.cache = ltcache.NewCache(cfg.UpstreamCacheLimit, 0, false, nil)
+	}
+	return
+}
+
+// dnsUpstream forwards the DNS queries towards the upstream servers
+type dnsUpstream struct {
+	servers []string
+	clnt    *dns.Client
+	cache   *ltcache.Cache // nil if caching is disabled
+}
+
+// dnsCachedReply is an upstream reply kept in cache until the lowest TTL expires
+type dnsCachedReply struct {
+	msg     *dns.Msg
+	stored  time.Time
+	expires time.Time
+}
+
+// exchange sends the query towards the upstream servers in order, returning the first reply received
+func (up *dnsUpstream) exchange(req *dns.Msg) (rply *dns.Msg, err error) {
+	cacheKey := dnsCacheKey(req)
+	if up.cache != nil && cacheKey != utils.EmptyString {
+		if rply = up.getCached(cacheKey, time.Now()); rply != nil {
+			rply.Id = req.Id
+			return
+		}
+	}
+	for _, srv := range up.servers {
+		if rply, _, err = up.clnt.Exchange(req, srv); err == nil &&
+			rply.Truncated && up.clnt.Net == utils.UDP { // retry over TCP to receive the full reply
+			tcpClnt := &dns.Client{Net: utils.TCP, Timeout: up.clnt.Timeout}
+			rply, _, err = tcpClnt.Exchange(req, srv)
+		}
+		if err == nil {
+			break
+		}
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> error: <%s> forwarding query to upstream server <%s>",
+				utils.DNSAgent, err.Error(), srv))
+	}
+	if err != nil {
+		return
+	}
+	if up.cache != nil && cacheKey != utils.EmptyString {
+		up.setCached(cacheKey, rply, time.Now())
+	}
+	return
+}
+
+// getCached returns a copy of the cached reply with the TTLs decreased by the time spent in cache
+func (up *dnsUpstream) getCached(key string, now time.Time) (rply *dns.Msg) {
+	itm, has := up.cache.Get(key)
+	if !has {
+		return
+	}
+	cr := itm.(*dnsCachedReply)
+	if !now.Before(cr.expires) {
+		up.cache.Remove(key)
+		return
+	}
+	rply = cr.msg.Copy()
+	elapsed := uint32(now.Sub(cr.stored) / time.Second)
+	for _, rrs := range [][]dns.RR{rply.Answer, rply.Ns, rply.Extra} {
+		for _, rr := range rrs {
+			if _, isOPT := rr.(*dns.OPT); isOPT {
+				continue
+			}
+			rr.Header().Ttl -= elapsed
+		}
+	}
+	return
+}
+
+// setCached stores the reply in cache if its TTL permits it
+func (up *dnsUpstream) setCached(key string, rply *dns.Msg, now time.Time) {
+	ttl := dnsReplyTTL(rply)
+	if ttl == 0 {
+		return
+	}
+	up.cache.Set(key, &dnsCachedReply{
+		msg:     rply.Copy(),
+		stored:  now,
+		expires: now.Add(time.Duration(ttl) * time.Second),
+	}, nil)
+}
+
+// dnsCacheKey builds the cache key out of the query
+// only queries with one question are cached
+func dnsCacheKey(req *dns.Msg) string {
+	if len(req.Question) != 1 {
+		return utils.EmptyString
+	}
+	q := req.Question[0]
+	return utils.ConcatenatedKey(strings.ToLower(q.Name),
+		dns.TypeToString[q.Qtype], dns.ClassToString[q.Qclass],
+		strconv.FormatBool(req.CheckingDisabled))
+}
+
+// dnsReplyTTL returns the number of seconds the reply can be cached
+// negative replies are cached based on the SOA record from the authority section
+func dnsReplyTTL(rply *dns.Msg) (ttl uint32) {
+	if rply.Rcode != dns.RcodeSuccess &&
+		rply.Rcode != dns.RcodeNameError {
+		return
+	}
+	if rply.Rcode == dns.RcodeNameError ||
+		len(rply.Answer) == 0 {
+		for _, rr := range rply.Ns {
+			if soa, isSOA := rr.(*dns.SOA); isSOA {
+				if ttl = soa.Hdr.Ttl; soa.Minttl < ttl {
+					ttl = soa.Minttl
+				}
+				return
+			}
+		}
+		return
+	}
+	var hasTTL bool
+	for _, rrs := range [][]dns.RR{rply.Answer, rply.Ns, rply.Extra} {
+		for _, rr := range rrs {
+			if _, isOPT := rr.(*dns.OPT); isOPT {
+				continue
+			}
+			if !hasTTL || rr.Header().Ttl < ttl {
+				ttl = rr.Header().Ttl
+				hasTTL = true
+			}
+		}
+	}
+	return
+}
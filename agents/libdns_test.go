@@ -19,9 +19,12 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package agents
 
 import (
+	"reflect"
 	"strings"
 	"testing"
+	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/miekg/dns"
 )
@@ -209,3 +212,128 @@ func TestUpdateDNSMsgFromNM(t *testing.T) {
 	}
 
 }
+
+func TestUpdateDNSMsgFromNMNsExtra(t *testing.T) {
+	m := new(dns.Msg)
+	m.SetQuestion("3.6.9.4.7.1.7.1.5.6.8.9.4.e164.arpa.", dns.TypeNAPTR)
+	m.SetEdns0(4096, false)
+
+	nM := utils.NewOrderedNavigableMap()
+	for _, fld := range []struct {
+		path []string
+		val  interface{}
+	}{
+		{[]string{utils.DNSNs, utils.DNSNs}, "ns1.e164.arpa"},
+		{[]string{utils.DNSExtra, utils.DNSHdr, utils.DNSRrtype}, "SRV"},
+		{[]string{utils.DNSExtra, utils.DNSPriority}, 10},
+		{[]string{utils.DNSExtra, utils.DNSWeight}, 20},
+		{[]string{utils.DNSExtra, utils.DNSPort}, 5060},
+		{[]string{utils.DNSExtra, utils.DNSTarget}, "sip.cgrates.org"},
+	} {
+		nM.SetAsSlice(&utils.FullPath{
+			Path:      strings.Join(fld.path, utils.NestingSep),
+			PathSlice: fld.path,
+		}, []*utils.DataNode{{Type: utils.NMDataType, Value: &utils.DataLeaf{Data: fld.val}}})
+	}
+	if err := updateDNSMsgFromNM(m, nM, m.Question[0].Qtype, m.Question[0].Name); err != nil {
+		t.Fatal(err)
+	}
+	if len(m.Ns) != 1 {
+		t.Fatalf("expecting one NS record, received: %s", utils.ToJSON(m.Ns))
+	} else if ns, canCast := m.Ns[0].(*dns.NS); !canCast {
+		t.Errorf("expecting NS record, received: <%T>", m.Ns[0])
+	} else if ns.Ns != "ns1.e164.arpa." {
+		t.Errorf("expecting: <ns1.e164.arpa.>, received: <%s>", ns.Ns)
+	}
+	if len(m.Extra) != 2 {
+		t.Fatalf("expecting SRV and OPT records, received: %s", utils.ToJSON(m.Extra))
+	}
+	eSRV := &dns.SRV{
+		Hdr: dns.RR_Header{
+			Name:   "3.6.9.4.7.1.7.1.5.6.8.9.4.e164.arpa.",
+			Rrtype: dns.TypeSRV,
+			Class:  dns.ClassINET,
+			Ttl:    60,
+		},
+		Priority: 10,
+		Weight:   20,
+		Port:     5060,
+		Target:   "sip.cgrates.org.",
+	}
+	if !reflect.DeepEqual(eSRV, m.Extra[0]) {
+		t.Errorf("expecting: %s, received: %s", utils.ToJSON(eSRV), utils.ToJSON(m.Extra[0]))
+	}
+	if _, isOPT := m.Extra[1].(*dns.OPT); !isOPT {
+		t.Errorf("expecting the OPT record to be last, received: <%T>", m.Extra[1])
+	}
+}
+
+func TestDnsTypeFromValue(t *testing.T) {
+	if rrType, err := dnsTypeFromValue("naptr"); err != nil {
+		t.Error(err)
+	} else if rrType != dns.TypeNAPTR {
+		t.Errorf("expecting: <%d>, received: <%d>", dns.TypeNAPTR, rrType)
+	}
+	if rrType, err := dnsTypeFromValue(33); err != nil {
+		t.Error(err)
+	} else if rrType != dns.TypeSRV {
+		t.Errorf("expecting: <%d>, received: <%d>", dns.TypeSRV, rrType)
+	}
+	if _, err := dnsTypeFromValue("notAType"); err == nil {
+		t.Error("expecting error")
+	}
+}
+
+func TestDnsReplyTTL(t *testing.T) {
+	rply := new(dns.Msg)
+	rply.Answer = []dns.RR{
+		&dns.NAPTR{Hdr: dns.RR_Header{Rrtype: dns.TypeNAPTR, Ttl: 300}},
+		&dns.NAPTR{Hdr: dns.RR_Header{Rrtype: dns.TypeNAPTR, Ttl: 120}},
+	}
+	rply.SetEdns0(4096, false)
+	if ttl := dnsReplyTTL(rply); ttl != 120 {
+		t.Errorf("expecting: <120>, received: <%d>", ttl)
+	}
+	rply = new(dns.Msg)
+	rply.Rcode = dns.RcodeNameError
+	rply.Ns = []dns.RR{&dns.SOA{Hdr: dns.RR_Header{Rrtype: dns.TypeSOA, Ttl: 3600}, Minttl: 30}}
+	if ttl := dnsReplyTTL(rply); ttl != 30 {
+		t.Errorf("expecting: <30>, received: <%d>", ttl)
+	}
+	rply.Rcode = dns.RcodeServerFailure
+	if ttl := dnsReplyTTL(rply); ttl != 0 {
+		t.Errorf("expecting: <0>, received: <%d>", ttl)
+	}
+}
+
+func TestDnsUpstreamCache(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	if up := newDnsUpstream(cfg.DNSAgentCfg()); up != nil {
+		t.Fatalf("expecting no upstream, received: %+v", up)
+	}
+	cfg.DNSAgentCfg().UpstreamServers = []string{"127.0.0.1:53"}
+	up := newDnsUpstream(cfg.DNSAgentCfg())
+	req := new(dns.Msg)
+	req.SetQuestion("3.6.9.4.7.1.7.1.5.6.8.9.4.e164.arpa.", dns.TypeNAPTR)
+	rply := new(dns.Msg)
+	rply.SetReply(req)
+	rply.Answer = []dns.RR{&dns.NAPTR{Hdr: dns.RR_Header{Rrtype: dns.TypeNAPTR, Ttl: 60}}}
+
+	key := dnsCacheKey(req)
+	now := time.Now()
+	up.setCached(key, rply, now)
+	if cached := up.getCached(key, now.Add(20*time.Second)); cached == nil {
+		t.Fatal("expecting the reply from cache")
+	} else if ttl := cached.Answer[0].Header().Ttl; ttl != 40 {
+		t.Errorf("expecting: <40>, received: <%d>", ttl)
+	}
+	if rply.Answer[0].Header().Ttl != 60 {
+		t.Errorf("expecting the original reply to be unchanged, received: %s", utils.ToJSON(rply))
+	}
+	if cached := up.getCached(key, now.Add(time.Minute)); cached != nil {
+		t.Errorf("expecting expired reply, received: %s", utils.ToJSON(cached))
+	}
+	if up.cache.HasItem(key) {
+		t.Error("expecting the expired reply to be removed from cache")
+	}
+}
@@ -0,0 +1,74 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"sync"
+
+	"github.com/cgrates/cgrates/utils"
+	"golang.org/x/net/websocket"
+)
+
+// wsNotification is the message pushed towards the client on requests coming from SessionS
+// the format follows the JSON-RPC notifications so the clients can tell it apart from the replies
+type wsNotification struct {
+	Method string        `json:"method"`
+	Params []interface{} `json:"params"`
+	ID     interface{}   `json:"id"`
+}
+
+func newWsConn(ws *websocket.Conn) *wsConn {
+	return &wsConn{
+		ws:         ws,
+		remoteAddr: ws.Request().RemoteAddr,
+	}
+}
+
+// wsConn wraps the WebSocket connection so the replies and the notifications are not written concurrently
+type wsConn struct {
+	sync.Mutex
+	ws         *websocket.Conn
+	remoteAddr string
+	closed     bool
+}
+
+// send writes one JSON message on the connection
+func (c *wsConn) send(msg interface{}) (err error) {
+	c.Lock()
+	defer c.Unlock()
+	if c.closed {
+		return utils.ErrDisconnected
+	}
+	return websocket.JSON.Send(c.ws, msg)
+}
+
+func (c *wsConn) close() {
+	c.Lock()
+	if !c.closed {
+		c.closed = true
+		c.ws.Close()
+	}
+	c.Unlock()
+}
+
+func (c *wsConn) isClosed() bool {
+	c.Lock()
+	defer c.Unlock()
+	return c.closed
+}
@@ -0,0 +1,381 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package agents
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/cenkalti/rpc2"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/sessions"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+	"golang.org/x/net/websocket"
+)
+
+// NewWebSocketAgent is the constructor for WebSocketAgent
+func NewWebSocketAgent(cgrCfg *config.CGRConfig, fltrS *engine.FilterS,
+	connMgr *engine.ConnManager) *WebSocketAgent {
+	return &WebSocketAgent{
+		cgrCfg:   cgrCfg,
+		fltrS:    fltrS,
+		connMgr:  connMgr,
+		conns:    make(map[*wsConn]struct{}),
+		sessions: make(map[string]*wsConn),
+	}
+}
+
+// WebSocketAgent translates the JSON messages received over WebSockets towards CGRateS infrastructure
+// the connections are kept open so SessionS can push notifications back to the clients
+type WebSocketAgent struct {
+	cgrCfg  *config.CGRConfig // loaded CGRateS configuration
+	fltrS   *engine.FilterS   // connection towards FilterS
+	connMgr *engine.ConnManager
+
+	connsLck sync.RWMutex
+	conns    map[*wsConn]struct{} // active connections
+	sessions map[string]*wsConn   // connections indexed by the OriginID of the sessions created over them
+	stopped  bool
+}
+
+// ServeHTTP implements http.Handler interface
+func (wa *WebSocketAgent) ServeHTTP(w http.ResponseWriter, req *http.Request) {
+	if !wa.authorized(req) {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> unauthorized connection from %s",
+				utils.WebSocketAgent, req.RemoteAddr))
+		http.Error(w, "Not authorized", http.StatusUnauthorized)
+		return
+	}
+	websocket.Server{
+		Handshake: wa.checkOrigin,
+		Handler:   wa.handleConn,
+	}.ServeHTTP(w, req)
+}
+
+// authorized checks the credentials of the connection if the HTTP basic auth or the API auth are enabled
+// the browsers not being able to set headers on WebSockets, the API key is accepted as *authKey URL query parameter
+func (wa *WebSocketAgent) authorized(req *http.Request) bool {
+	httpCfg := wa.cgrCfg.HTTPCfg()
+	apiAuthCfg := wa.cgrCfg.APIAuthCfg()
+	if !httpCfg.HTTPUseBasicAuth && !apiAuthCfg.Enabled {
+		return true
+	}
+	if httpCfg.HTTPUseBasicAuth {
+		if user, pass, has := req.BasicAuth(); has &&
+			httpCfg.VerifyCredential(user, pass) {
+			return true
+		}
+	}
+	if apiAuthCfg.Enabled {
+		if _, has := apiAuthCfg.UserByKey(req.URL.Query().Get(utils.OptsAuthKey)); has {
+			return true
+		}
+	}
+	return false
+}
+
+// checkOrigin rejects the browser connections (with Origin header) not coming from one of the allowed_origins
+// the connections without Origin header are coming from other clients than the browsers and are accepted
+func (wa *WebSocketAgent) checkOrigin(cfg *websocket.Config, req *http.Request) (err error) {
+	if cfg.Origin, err = websocket.Origin(cfg, req); err != nil ||
+		cfg.Origin == nil {
+		return
+	}
+	origin := cfg.Origin.Scheme + "://" + cfg.Origin.Host
+	allowedOrigins := wa.cgrCfg.WebSocketAgentCfg().AllowedOrigins
+	if len(allowedOrigins) == 0 {
+		if cfg.Origin.Host == req.Host {
+			return
+		}
+	} else if utils.IsSliceMember(allowedOrigins, utils.MetaAny) ||
+		utils.IsSliceMember(allowedOrigins, origin) {
+		return
+	}
+	err = fmt.Errorf("origin <%s> not allowed", origin)
+	utils.Logger.Warning(
+		fmt.Sprintf("<%s> error: %s for connection from %s",
+			utils.WebSocketAgent, err.Error(), req.RemoteAddr))
+	return
+}
+
+// handleConn is the entry point of a WebSocket connection
+// the messages received on the same connection are processed in order
+func (wa *WebSocketAgent) handleConn(ws *websocket.Conn) {
+	c := newWsConn(ws)
+	if !wa.addConn(c) {
+		ws.Close()
+		return
+	}
+	defer wa.removeConn(c)
+	for {
+		var msg map[string]interface{}
+		if err := websocket.JSON.Receive(ws, &msg); err != nil {
+			if !c.isClosed() && err.Error() != "EOF" {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: %s reading message from %s",
+						utils.WebSocketAgent, err.Error(), c.remoteAddr))
+			}
+			return
+		}
+		rply, err := wa.handleMessage(c, utils.MapStorage(msg))
+		if err != nil {
+			rply = map[string]interface{}{utils.Error: err.Error()}
+		}
+		if err = c.send(rply); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s sending reply to %s",
+					utils.WebSocketAgent, err.Error(), c.remoteAddr))
+			return
+		}
+	}
+}
+
+// handleMessage processes one message and returns the reply to be sent back
+func (wa *WebSocketAgent) handleMessage(c *wsConn, msg utils.MapStorage) (rply map[string]interface{}, err error) {
+	reqVars := &utils.DataNode{
+		Type: utils.NMMapType,
+		Map: map[string]*utils.DataNode{
+			utils.RemoteHost: utils.NewLeafNode(c.remoteAddr),
+		},
+	}
+	cgrRplyNM := &utils.DataNode{Type: utils.NMMapType, Map: make(map[string]*utils.DataNode)}
+	rplyNM := utils.NewOrderedNavigableMap() // share it among different processors
+	opts := utils.MapStorage{}
+	var processed bool
+	for _, reqProcessor := range wa.cgrCfg.WebSocketAgentCfg().RequestProcessors {
+		agReq := NewAgentRequest(
+			msg, reqVars, cgrRplyNM, rplyNM,
+			opts, reqProcessor.Tenant,
+			wa.cgrCfg.GeneralCfg().DefaultTenant,
+			utils.FirstNonEmpty(reqProcessor.Timezone,
+				wa.cgrCfg.WebSocketAgentCfg().Timezone,
+				wa.cgrCfg.GeneralCfg().DefaultTimezone),
+			wa.fltrS, nil)
+		var lclProcessed bool
+		if lclProcessed, err = processRequest(reqProcessor, agReq,
+			utils.WebSocketAgent, wa.connMgr,
+			wa.cgrCfg.WebSocketAgentCfg().SessionSConns,
+			wa, wa.fltrS); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> error: %s processing message: %s from %s",
+					utils.WebSocketAgent, err.Error(), msg, c.remoteAddr))
+			return
+		}
+		if !lclProcessed {
+			continue
+		}
+		processed = true
+		wa.trackSession(c, agReq, reqProcessor.Flags)
+		if !reqProcessor.Flags.GetBool(utils.MetaContinue) {
+			break
+		}
+	}
+	if !processed {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> no request processor enabled, ignoring message %s from %s",
+				utils.WebSocketAgent, msg, c.remoteAddr))
+	}
+	return replyFromNM(rplyNM), nil
+}
+
+// trackSession indexes the connection based on the OriginID of the session
+// so the notifications coming from SessionS can be pushed over it
+func (wa *WebSocketAgent) trackSession(c *wsConn, agReq *AgentRequest, flags utils.FlagsWithParams) {
+	cgrEv := utils.NMAsCGREvent(agReq.CGRRequest, agReq.Tenant, utils.NestingSep, agReq.Opts)
+	originID := utils.IfaceAsString(cgrEv.Event[utils.OriginID])
+	if originID == utils.EmptyString {
+		return
+	}
+	wa.connsLck.Lock()
+	if flags.GetBool(utils.MetaTerminate) {
+		if wa.sessions[originID] == c {
+			delete(wa.sessions, originID)
+		}
+	} else {
+		wa.sessions[originID] = c
+	}
+	wa.connsLck.Unlock()
+}
+
+// addConn registers a new connection, returns false if the agent was stopped
+func (wa *WebSocketAgent) addConn(c *wsConn) bool {
+	wa.connsLck.Lock()
+	defer wa.connsLck.Unlock()
+	if wa.stopped {
+		return false
+	}
+	wa.conns[c] = struct{}{}
+	return true
+}
+
+// removeConn closes the connection and removes it together with its sessions
+func (wa *WebSocketAgent) removeConn(c *wsConn) {
+	wa.connsLck.Lock()
+	delete(wa.conns, c)
+	for originID, sc := range wa.sessions {
+		if sc == c {
+			delete(wa.sessions, originID)
+		}
+	}
+	wa.connsLck.Unlock()
+	c.close()
+}
+
+// getSessionConn returns the connection where the session with originID was created
+func (wa *WebSocketAgent) getSessionConn(originID string) (c *wsConn, has bool) {
+	wa.connsLck.RLock()
+	c, has = wa.sessions[originID]
+	wa.connsLck.RUnlock()
+	return
+}
+
+// Shutdown closes the active connections and refuses the new ones
+func (wa *WebSocketAgent) Shutdown() (err error) {
+	wa.connsLck.Lock()
+	wa.stopped = true
+	conns := make([]*wsConn, 0, len(wa.conns))
+	for c := range wa.conns {
+		conns = append(conns, c)
+	}
+	wa.conns = make(map[*wsConn]struct{})
+	wa.sessions = make(map[string]*wsConn)
+	wa.connsLck.Unlock()
+	for _, c := range conns {
+		c.close()
+	}
+	return
+}
+
+// notify pushes the notification towards the connection where the session with originID was created
+func (wa *WebSocketAgent) notify(originID, method string, params interface{}) (err error) {
+	c, has := wa.getSessionConn(originID)
+	if !has {
+		return utils.ErrNotFound
+	}
+	return c.send(&wsNotification{Method: method, Params: []interface{}{params}})
+}
+
+// Call implements rpcclient.ClientConnector interface
+func (wa *WebSocketAgent) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.RPCCall(wa, serviceMethod, args, reply)
+}
+
+// V1DisconnectSession is part of the sessions.BiRPClient
+func (wa *WebSocketAgent) V1DisconnectSession(args utils.AttrDisconnectSession, reply *string) (err error) {
+	originID := utils.IfaceAsString(args.EventStart[utils.OriginID])
+	if originID == utils.EmptyString {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot disconnect session, missing OriginID in event: %s",
+				utils.WebSocketAgent, utils.ToJSON(args.EventStart)))
+		return utils.ErrMandatoryIeMissing
+	}
+	if err = wa.notify(originID, utils.SessionSv1DisconnectSession, args); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1GetActiveSessionIDs is part of the sessions.BiRPClient
+func (*WebSocketAgent) V1GetActiveSessionIDs(ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return utils.ErrNotImplemented
+}
+
+// V1ReAuthorize is used to implement the sessions.BiRPClient interface
+func (*WebSocketAgent) V1ReAuthorize(originID string, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// V1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (*WebSocketAgent) V1DisconnectPeer(args *utils.DPRArgs, reply *string) (err error) {
+	return utils.ErrNotImplemented
+}
+
+// V1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (wa *WebSocketAgent) V1WarnDisconnect(args map[string]interface{}, reply *string) (err error) {
+	originID := utils.IfaceAsString(args[utils.OriginID])
+	if originID == utils.EmptyString {
+		utils.Logger.Info(
+			fmt.Sprintf("<%s> cannot warn session, missing OriginID in event: %s",
+				utils.WebSocketAgent, utils.ToJSON(args)))
+		return utils.ErrMandatoryIeMissing
+	}
+	if err = wa.notify(originID, utils.SessionSv1WarnDisconnect, args); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// CallBiRPC is part of utils.BiRPCServer interface to help internal connections do calls over rpcclient.ClientConnector interface
+func (wa *WebSocketAgent) CallBiRPC(clnt rpcclient.ClientConnector, serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.BiRPCCall(wa, clnt, serviceMethod, args, reply)
+}
+
+// BiRPCv1DisconnectSession is used to implement the sessions.BiRPClient interface
+func (wa *WebSocketAgent) BiRPCv1DisconnectSession(clnt rpcclient.ClientConnector, args utils.AttrDisconnectSession, reply *string) error {
+	return wa.V1DisconnectSession(args, reply)
+}
+
+// BiRPCv1GetActiveSessionIDs is used to implement the sessions.BiRPClient interface
+func (wa *WebSocketAgent) BiRPCv1GetActiveSessionIDs(clnt rpcclient.ClientConnector, ignParam string,
+	sessionIDs *[]*sessions.SessionID) error {
+	return wa.V1GetActiveSessionIDs(ignParam, sessionIDs)
+}
+
+// BiRPCv1ReAuthorize is used to implement the sessions.BiRPClient interface
+func (wa *WebSocketAgent) BiRPCv1ReAuthorize(clnt rpcclient.ClientConnector, originID string, reply *string) (err error) {
+	return wa.V1ReAuthorize(originID, reply)
+}
+
+// BiRPCv1DisconnectPeer is used to implement the sessions.BiRPClient interface
+func (wa *WebSocketAgent) BiRPCv1DisconnectPeer(clnt rpcclient.ClientConnector, args *utils.DPRArgs, reply *string) (err error) {
+	return wa.V1DisconnectPeer(args, reply)
+}
+
+// BiRPCv1WarnDisconnect is used to implement the sessions.BiRPClient interface
+func (wa *WebSocketAgent) BiRPCv1WarnDisconnect(clnt rpcclient.ClientConnector, args map[string]interface{}, reply *string) (err error) {
+	return wa.V1WarnDisconnect(args, reply)
+}
+
+// Handlers is used to implement the rpcclient.BiRPCConector interface
+func (wa *WebSocketAgent) Handlers() map[string]interface{} {
+	return map[string]interface{}{
+		utils.SessionSv1DisconnectSession: func(clnt *rpc2.Client, args utils.AttrDisconnectSession, rply *string) error {
+			return wa.BiRPCv1DisconnectSession(clnt, args, rply)
+		},
+		utils.SessionSv1GetActiveSessionIDs: func(clnt *rpc2.Client, args string, rply *[]*sessions.SessionID) error {
+			return wa.BiRPCv1GetActiveSessionIDs(clnt, args, rply)
+		},
+		utils.SessionSv1ReAuthorize: func(clnt *rpc2.Client, args string, rply *string) (err error) {
+			return wa.BiRPCv1ReAuthorize(clnt, args, rply)
+		},
+		utils.SessionSv1DisconnectPeer: func(clnt *rpc2.Client, args *utils.DPRArgs, rply *string) (err error) {
+			return wa.BiRPCv1DisconnectPeer(clnt, args, rply)
+		},
+		utils.SessionSv1WarnDisconnect: func(clnt *rpc2.Client, args map[string]interface{}, rply *string) (err error) {
+			return wa.BiRPCv1WarnDisconnect(clnt, args, rply)
+		},
+	}
+}
@@ -0,0 +1,225 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package agents
+
+import (
+	"encoding/base64"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"golang.org/x/net/websocket"
+)
+
+func TestWebSocketAgentRequestAndNotifications(t *testing.T) {
+	cfg, err := config.NewCGRConfigFromJSONStringWithDefaults(`{
+"websocket_agent": {
+	"enabled": true,
+	"request_processors": [
+		{
+			"id": "WSEvent",
+			"filters": ["*string:~*req.Type:event"],
+			"flags": ["*none"],
+			"request_fields": [
+				{"tag": "OriginID", "path": "*cgreq.OriginID", "type": "*variable", "value": "~*req.Session.ID"},
+			],
+			"reply_fields": [
+				{"tag": "Status", "path": "*rep.Status", "type": "*constant", "value": "OK"},
+				{"tag": "Account", "path": "*rep.Account", "type": "*variable", "value": "~*req.Account"},
+			],
+		},
+	],
+},
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	wa := NewWebSocketAgent(cfg, engine.NewFilterS(cfg, nil, dm), nil)
+	srv := httptest.NewServer(wa)
+	defer srv.Close()
+
+	ws, err := websocket.Dial("ws"+strings.TrimPrefix(srv.URL, "http"), "", srv.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer ws.Close()
+	ws.SetDeadline(time.Now().Add(5 * time.Second))
+
+	if err = websocket.JSON.Send(ws, map[string]interface{}{
+		"Type":    "event",
+		"Account": "1001",
+		"Session": map[string]interface{}{"ID": "sess1"},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	var rply map[string]interface{}
+	if err = websocket.JSON.Receive(ws, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if exp := map[string]interface{}{"Status": "OK", "Account": "1001"}; !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+
+	// not matching any processor returns an empty reply
+	if err = websocket.JSON.Send(ws, map[string]interface{}{"Type": "other"}); err != nil {
+		t.Fatal(err)
+	}
+	rply = nil
+	if err = websocket.JSON.Receive(ws, &rply); err != nil {
+		t.Fatal(err)
+	}
+	if len(rply) != 0 {
+		t.Errorf("Expected empty reply, received %s", utils.ToJSON(rply))
+	}
+
+	var reply string
+	if err = wa.V1DisconnectSession(utils.AttrDisconnectSession{
+		EventStart: map[string]interface{}{utils.OriginID: "sess1"},
+		Reason:     "FORCED",
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %q, received %q", utils.OK, reply)
+	}
+	var ntf wsNotification
+	if err = websocket.JSON.Receive(ws, &ntf); err != nil {
+		t.Fatal(err)
+	}
+	if ntf.Method != utils.SessionSv1DisconnectSession || ntf.ID != nil || len(ntf.Params) != 1 {
+		t.Errorf("Unexpected notification: %s", utils.ToJSON(ntf))
+	}
+
+	if err = wa.V1WarnDisconnect(map[string]interface{}{utils.OriginID: "sess1"}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err = websocket.JSON.Receive(ws, &ntf); err != nil {
+		t.Fatal(err)
+	}
+	if ntf.Method != utils.SessionSv1WarnDisconnect {
+		t.Errorf("Unexpected notification: %s", utils.ToJSON(ntf))
+	}
+
+	if err = wa.V1WarnDisconnect(map[string]interface{}{utils.OriginID: "sess2"}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received %v", utils.ErrNotFound, err)
+	}
+	if err = wa.V1DisconnectSession(utils.AttrDisconnectSession{}, &reply); err != utils.ErrMandatoryIeMissing {
+		t.Errorf("Expected %v, received %v", utils.ErrMandatoryIeMissing, err)
+	}
+
+	if err = wa.Shutdown(); err != nil {
+		t.Error(err)
+	}
+	if err = websocket.JSON.Receive(ws, &rply); err == nil {
+		t.Errorf("Expected the connection to be closed")
+	}
+	if _, has := wa.getSessionConn("sess1"); has {
+		t.Errorf("Expected the session to be removed with the connection")
+	}
+}
+
+func TestWebSocketAgentOriginAndAuth(t *testing.T) {
+	cfg, err := config.NewCGRConfigFromJSONStringWithDefaults(`{
+"http": {
+	"use_basic_auth": true,
+	"auth_users": {"ws": "c2VjcmV0"},
+},
+"api_auth": {
+	"enabled": true,
+	"users": {"ws": {"key": "k3y", "roles": []}},
+},
+"websocket_agent": {
+	"enabled": true,
+	"allowed_origins": ["https://cgrates.org"],
+},
+}`)
+	if err != nil {
+		t.Fatal(err)
+	}
+	wa := NewWebSocketAgent(cfg, nil, nil)
+	srv := httptest.NewServer(wa)
+	defer srv.Close()
+	wsURL := "ws" + strings.TrimPrefix(srv.URL, "http")
+
+	dial := func(url, origin, user, pass string) error {
+		wsCfg, err := websocket.NewConfig(url, origin)
+		if err != nil {
+			return err
+		}
+		if user != utils.EmptyString {
+			wsCfg.Header.Set("Authorization", "Basic "+
+				base64.StdEncoding.EncodeToString([]byte(user+":"+pass)))
+		}
+		ws, err := websocket.DialConfig(wsCfg)
+		if err != nil {
+			return err
+		}
+		return ws.Close()
+	}
+	if err = dial(wsURL, "https://cgrates.org", utils.EmptyString, utils.EmptyString); err == nil {
+		t.Error("Expected the connection without credentials to be refused")
+	}
+	if err = dial(wsURL, "https://cgrates.org", "ws", "wrong"); err == nil {
+		t.Error("Expected the connection with the wrong password to be refused")
+	}
+	if err = dial(wsURL, "https://cgrates.org", "ws", "secret"); err != nil {
+		t.Errorf("Expected the connection authorized by basic auth to be accepted, received: %v", err)
+	}
+	if err = dial(wsURL+"?*authKey=k3y", "https://cgrates.org", utils.EmptyString, utils.EmptyString); err != nil {
+		t.Errorf("Expected the connection authorized by *authKey to be accepted, received: %v", err)
+	}
+	if err = dial(wsURL+"?*authKey=k3y", "https://cgrates.net", utils.EmptyString, utils.EmptyString); err == nil {
+		t.Error("Expected the connection from an origin not allowed to be refused")
+	}
+	if err = wa.checkOrigin(new(websocket.Config), httptest.NewRequest("GET", srv.URL, nil)); err != nil {
+		t.Errorf("Expected the connection without Origin to be accepted, received: %v", err)
+	}
+
+	cfg.WebSocketAgentCfg().AllowedOrigins = nil // same origin only
+	if err = dial(wsURL+"?*authKey=k3y", "https://cgrates.org", utils.EmptyString, utils.EmptyString); err == nil {
+		t.Error("Expected the connection from another origin to be refused")
+	}
+	if err = dial(wsURL+"?*authKey=k3y", srv.URL, utils.EmptyString, utils.EmptyString); err != nil {
+		t.Errorf("Expected the connection from the same origin to be accepted, received: %v", err)
+	}
+	cfg.WebSocketAgentCfg().AllowedOrigins = []string{utils.MetaAny}
+	if err = dial(wsURL+"?*authKey=k3y", "https://cgrates.net", utils.EmptyString, utils.EmptyString); err != nil {
+		t.Errorf("Expected the connection from any origin to be accepted, received: %v", err)
+	}
+}
+
+func TestWsReplyFromNM(t *testing.T) {
+	nM := utils.NewOrderedNavigableMap()
+	nM.SetAsSlice(&utils.FullPath{PathSlice: []string{"Status"}, Path: "Status"},
+		[]*utils.DataNode{{Type: utils.NMDataType, Value: &utils.DataLeaf{Data: "OK"}}})
+	nM.SetAsSlice(&utils.FullPath{PathSlice: []string{"Balance", "Value"}, Path: "Balance.Value"},
+		[]*utils.DataNode{{Type: utils.NMDataType, Value: &utils.DataLeaf{Data: 10.5}}})
+	exp := map[string]interface{}{
+		"Status":        "OK",
+		"Balance.Value": 10.5,
+	}
+	if rcv := replyFromNM(nM); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+}
@@ -189,3 +189,188 @@ func (aS *AnalyzerService) V1StringQuery(args *QueryArgs, reply *[]map[string]in
 	*reply = rply
 	return nil
 }
+
+// TrafficFilters the structured filters of the API calls
+type TrafficFilters struct {
+	QueryArgs
+	Methods []string           // the API methods, all if empty
+	Time    utils.TimeInterval // the interval of the RequestStartTime
+}
+
+// SearchArgs the arguments of V1Search
+type SearchArgs struct {
+	TrafficFilters
+	OrderBy string // RequestStartTime(default), RequestDuration, RequestMethod or RequestID, followed by ;desc for descending order
+	utils.Paginator
+}
+
+// AggregateArgs the arguments of V1Aggregate
+type AggregateArgs struct {
+	TrafficFilters
+	GroupBy string // *method(default) or *connection
+}
+
+// ExportArgs the arguments of V1Export
+type ExportArgs struct {
+	SearchArgs
+	ExportPath string // the file written with one TrafficRecord per line
+}
+
+// trafficQuery builds the bleve query out of the header, method and time filters
+func trafficQuery(args *TrafficFilters) (q query.Query) {
+	q = bleve.NewMatchAllQuery()
+	if args.HeaderFilters != utils.EmptyString {
+		q = bleve.NewQueryStringQuery(args.HeaderFilters)
+	}
+	if len(args.Methods) != 0 {
+		mqs := make([]query.Query, len(args.Methods))
+		for i, method := range args.Methods {
+			// the method is indexed as the subsystem and method terms so the phrase matches it as a whole
+			mq := bleve.NewMatchPhraseQuery(method)
+			mq.SetField(utils.RequestMethod)
+			mqs[i] = mq
+		}
+		q = bleve.NewConjunctionQuery(q, bleve.NewDisjunctionQuery(mqs...))
+	}
+	if args.Time.Begin != nil || args.Time.End != nil {
+		var tStart, tEnd time.Time
+		if args.Time.Begin != nil {
+			tStart = *args.Time.Begin
+		}
+		if args.Time.End != nil {
+			tEnd = *args.Time.End
+		}
+		tq := bleve.NewDateRangeQuery(tStart, tEnd)
+		tq.SetField(utils.RequestStartTime)
+		q = bleve.NewConjunctionQuery(q, tq)
+	}
+	return
+}
+
+// searchTraffic returns the API calls matching the filters
+func (aS *AnalyzerService) searchTraffic(args *TrafficFilters) (hits []*trafficHit, err error) {
+	var docCount uint64
+	if docCount, err = aS.db.DocCount(); err != nil {
+		return
+	}
+	return aS.searchHits(bleve.NewSearchRequestOptions(trafficQuery(args),
+		int(docCount), 0, false), args.ContentFilters)
+}
+
+// searchHits runs the search request and returns the hits passing the content filters
+func (aS *AnalyzerService) searchHits(s *bleve.SearchRequest, contentFltrs []string) (hits []*trafficHit, err error) {
+	s.Fields = []string{utils.Meta} // return all fields
+	var searchResults *bleve.SearchResult
+	if searchResults, err = aS.db.Search(s); err != nil {
+		return
+	}
+	hits = make([]*trafficHit, 0, searchResults.Hits.Len())
+	for _, obj := range searchResults.Hits {
+		hit := newTrafficHit(obj.Fields)
+		if len(contentFltrs) != 0 {
+			dp, err := getDPFromSearchresult(hit.params, hit.reply, obj.Fields)
+			if err != nil {
+				return nil, err
+			}
+			if pass, err := aS.filterS.Pass(aS.cfg.GeneralCfg().DefaultTenant,
+				contentFltrs, dp); err != nil {
+				return nil, err
+			} else if !pass {
+				continue
+			}
+		}
+		hits = append(hits, hit)
+	}
+	return
+}
+
+// searchOrdered returns the page of API calls matching the filters in the requested order
+func (aS *AnalyzerService) searchOrdered(args *SearchArgs) (hits []*trafficHit, err error) {
+	fld, desc, err := parseTrafficOrderBy(args.OrderBy)
+	if err != nil {
+		return
+	}
+	// the content filters are checked after the search and the method is indexed
+	// as multiple terms so in these cases we sort and paginate the hits ourselves
+	if len(args.ContentFilters) != 0 || fld == utils.RequestMethod {
+		if hits, err = aS.searchTraffic(&args.TrafficFilters); err != nil {
+			return
+		}
+		if err = sortTrafficHits(hits, args.OrderBy); err != nil {
+			return
+		}
+		return paginateTrafficHits(hits, args.Paginator), nil
+	}
+	var from int
+	if args.Paginator.Offset != nil && *args.Paginator.Offset > 0 {
+		from = *args.Paginator.Offset
+	}
+	var size int
+	if args.Paginator.Limit != nil && *args.Paginator.Limit > 0 {
+		size = *args.Paginator.Limit
+	} else {
+		var docCount uint64
+		if docCount, err = aS.db.DocCount(); err != nil {
+			return
+		}
+		size = int(docCount)
+	}
+	s := bleve.NewSearchRequestOptions(trafficQuery(&args.TrafficFilters), size, from, false)
+	if desc {
+		fld = "-" + fld // bleve sorts descending the fields prefixed with -
+	}
+	s.SortBy([]string{fld})
+	return aS.searchHits(s, nil)
+}
+
+// V1Search returns the API calls matching the structured filters, ordered and paginated
+func (aS *AnalyzerService) V1Search(args *SearchArgs, reply *[]map[string]interface{}) (err error) {
+	var hits []*trafficHit
+	if hits, err = aS.searchOrdered(args); err != nil {
+		return
+	}
+	rply := make([]map[string]interface{}, len(hits))
+	for i, hit := range hits {
+		rply[i] = hit.asMapInterface()
+	}
+	*reply = rply
+	return
+}
+
+// V1Aggregate returns the statistics of the API calls matching the filters grouped on method or connection
+func (aS *AnalyzerService) V1Aggregate(args *AggregateArgs, reply *[]*TrafficStats) (err error) {
+	groupBy := args.GroupBy
+	if groupBy == utils.EmptyString {
+		groupBy = utils.MetaAPIMethod
+	}
+	if groupBy != utils.MetaAPIMethod && groupBy != utils.MetaConnection {
+		return fmt.Errorf("unsupported GroupBy <%s>", groupBy)
+	}
+	var hits []*trafficHit
+	if hits, err = aS.searchTraffic(&args.TrafficFilters); err != nil {
+		return
+	}
+	*reply = aggregateTrafficHits(hits, groupBy)
+	return
+}
+
+// V1Export writes the API calls matching the filters as JSON lines, to be replayed via cgr-tester
+func (aS *AnalyzerService) V1Export(args *ExportArgs, reply *string) (err error) {
+	if args.ExportPath == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing("ExportPath")
+	}
+	var hits []*trafficHit
+	if hits, err = aS.searchOrdered(&args.SearchArgs); err != nil {
+		return
+	}
+	var f *os.File
+	if f, err = os.Create(args.ExportPath); err != nil {
+		return
+	}
+	defer f.Close()
+	if err = writeTrafficRecords(f, hits); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
@@ -21,6 +21,7 @@ package analyzers
 import (
 	"encoding/json"
 	"os"
+	"path"
 	"reflect"
 	"runtime"
 	"strconv"
@@ -486,3 +487,182 @@ func TestAnalyzerSLogTrafficInternalDB(t *testing.T) {
 		t.Errorf("Expected error: %v,received: %+v", bleve.ErrorIndexClosed, err)
 	}
 }
+
+func TestAnalyzersV1SearchAggregateExport(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AnalyzerSCfg().DBPath = utils.EmptyString
+	cfg.AnalyzerSCfg().IndexType = utils.MetaInternal
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	anz, err := NewAnalyzerService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	anz.SetFilterS(engine.NewFilterS(cfg, nil, dm))
+	t1 := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	for i, dur := range []time.Duration{time.Second, 3 * time.Second, 2 * time.Second} {
+		sTime := t1.Add(time.Duration(i) * time.Minute)
+		if err = anz.logTrafic(uint64(i), utils.CoreSv1Ping,
+			&utils.CGREvent{Tenant: "cgrates.org"}, utils.Pong, nil, utils.MetaJSON,
+			"127.0.0.1:5565", "127.0.0.1:2012", sTime, sTime.Add(dur)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if err = anz.logTrafic(3, utils.CoreSv1Status,
+		&utils.TenantWithAPIOpts{Tenant: "cgrates.org"}, nil, utils.ErrNotFound, utils.MetaGOB,
+		"127.0.0.1:5566", "127.0.0.1:2013", t1.Add(time.Hour), t1.Add(time.Hour+4*time.Second)); err != nil {
+		t.Fatal(err)
+	}
+
+	var reply []map[string]interface{}
+	if err = anz.V1Search(&SearchArgs{
+		TrafficFilters: TrafficFilters{Methods: []string{utils.CoreSv1Ping}},
+		OrderBy:        utils.RequestDuration + utils.InfieldSep + "desc",
+		Paginator:      utils.Paginator{Limit: utils.IntPointer(2)},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if len(reply) != 2 ||
+		reply[0][utils.RequestDuration] != "3s" || reply[1][utils.RequestDuration] != "2s" {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(reply))
+	}
+	tEnd := t1.Add(2 * time.Minute)
+	if err = anz.V1Search(&SearchArgs{
+		TrafficFilters: TrafficFilters{Time: utils.TimeInterval{End: &tEnd}},
+		Paginator:      utils.Paginator{Offset: utils.IntPointer(1)},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if len(reply) != 1 || reply[0][utils.RequestID] != 1. {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(reply))
+	}
+	if err = anz.V1Search(&SearchArgs{
+		TrafficFilters: TrafficFilters{Methods: []string{utils.CoreSv1Status, utils.CoreSv1Sleep}},
+		OrderBy:        utils.RequestMethod,
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if len(reply) != 1 || reply[0][utils.RequestID] != 3. {
+		t.Errorf("Unexpected reply: %s", utils.ToJSON(reply))
+	}
+	if err = anz.V1Search(&SearchArgs{OrderBy: utils.Reply}, &reply); err == nil ||
+		err.Error() != "unsupported OrderBy field <Reply>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	var stats []*TrafficStats
+	if err = anz.V1Aggregate(&AggregateArgs{}, &stats); err != nil {
+		t.Fatal(err)
+	}
+	expStats := []*TrafficStats{
+		{
+			Group:       utils.CoreSv1Ping,
+			Count:       3,
+			MinDuration: time.Second,
+			AvgDuration: 2 * time.Second,
+			MaxDuration: 3 * time.Second,
+			P50Duration: 2 * time.Second,
+			P90Duration: 3 * time.Second,
+			P95Duration: 3 * time.Second,
+			P99Duration: 3 * time.Second,
+		},
+		{
+			Group:       utils.CoreSv1Status,
+			Count:       1,
+			Errors:      1,
+			MinDuration: 4 * time.Second,
+			AvgDuration: 4 * time.Second,
+			MaxDuration: 4 * time.Second,
+			P50Duration: 4 * time.Second,
+			P90Duration: 4 * time.Second,
+			P95Duration: 4 * time.Second,
+			P99Duration: 4 * time.Second,
+		},
+	}
+	if !reflect.DeepEqual(expStats, stats) {
+		t.Errorf("Expected %s received: %s", utils.ToJSON(expStats), utils.ToJSON(stats))
+	}
+	if err = anz.V1Aggregate(&AggregateArgs{
+		TrafficFilters: TrafficFilters{QueryArgs: QueryArgs{HeaderFilters: "RequestEncoding:*gob"}},
+		GroupBy:        utils.MetaConnection,
+	}, &stats); err != nil {
+		t.Fatal(err)
+	} else if len(stats) != 1 || stats[0].Group != "*gob:127.0.0.1:5566:127.0.0.1:2013" {
+		t.Errorf("Unexpected stats: %s", utils.ToJSON(stats))
+	}
+	if err = anz.V1Aggregate(&AggregateArgs{GroupBy: utils.MetaTenant}, &stats); err == nil ||
+		err.Error() != "unsupported GroupBy <*tenant>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+
+	expPath := path.Join(t.TempDir(), "traffic.jsonl")
+	var rply string
+	if err = anz.V1Export(&ExportArgs{ExportPath: expPath}, &rply); err != nil {
+		t.Fatal(err)
+	} else if rply != utils.OK {
+		t.Errorf("Expected OK received: %s", rply)
+	}
+	expRecs := []*TrafficRecord{
+		{
+			RequestID:          0,
+			RequestMethod:      utils.CoreSv1Ping,
+			RequestParams:      json.RawMessage(`{"Tenant":"cgrates.org","ID":"","Time":null,"Event":null,"APIOpts":null}`),
+			Reply:              json.RawMessage(`"Pong"`),
+			RequestEncoding:    utils.MetaJSON,
+			RequestSource:      "127.0.0.1:5565",
+			RequestDestination: "127.0.0.1:2012",
+			RequestStartTime:   t1,
+			RequestDuration:    time.Second,
+		},
+	}
+	f, err := os.Open(expPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer f.Close()
+	recs, err := ReadTrafficRecords(f)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 4 {
+		t.Fatalf("Expected 4 records received: %s", utils.ToJSON(recs))
+	}
+	if !reflect.DeepEqual(expRecs[0], recs[0]) {
+		t.Errorf("Expected %s received: %s", utils.ToJSON(expRecs[0]), utils.ToJSON(recs[0]))
+	}
+	if recs[3].ReplyError != utils.ErrNotFound.Error() {
+		t.Errorf("Expected the error reply, received: %s", utils.ToJSON(recs[3]))
+	}
+	if err = anz.V1Export(new(ExportArgs), &rply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing("ExportPath").Error() {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
+
+func TestAnalyzersReadTrafficIndex(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AnalyzerSCfg().DBPath = t.TempDir()
+	anz, err := NewAnalyzerService(cfg)
+	if err != nil {
+		t.Fatal(err)
+	}
+	t1 := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	if err = anz.logTrafic(1, utils.CoreSv1Status, &utils.TenantWithAPIOpts{}, nil, utils.ErrNotFound,
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", t1.Add(time.Minute), t1.Add(time.Minute+time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	if err = anz.logTrafic(0, utils.CoreSv1Ping, &utils.CGREvent{}, utils.Pong, nil,
+		utils.MetaJSON, "127.0.0.1:5565", "127.0.0.1:2012", t1, t1.Add(time.Second)); err != nil {
+		t.Fatal(err)
+	}
+	anz.Shutdown()
+	recs, err := ReadTrafficIndex(path.Join(cfg.AnalyzerSCfg().DBPath, utils.AnzDBDir))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 2 ||
+		recs[0].RequestMethod != utils.CoreSv1Ping || string(recs[0].Reply) != `"Pong"` ||
+		recs[1].RequestMethod != utils.CoreSv1Status || recs[1].ReplyError != utils.ErrNotFound.Error() ||
+		!recs[1].RequestStartTime.Equal(t1.Add(time.Minute)) {
+		t.Errorf("Unexpected records: %s", utils.ToJSON(recs))
+	}
+	if _, err = ReadTrafficIndex(path.Join(cfg.AnalyzerSCfg().DBPath, "nonexistent")); err == nil {
+		t.Error("Expected error for the missing index")
+	}
+}
@@ -20,9 +20,15 @@ package analyzers
 
 import (
 	"encoding/json"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
 	"strconv"
+	"strings"
 	"time"
 
+	"github.com/blevesearch/bleve"
 	"github.com/blevesearch/bleve/index/scorch"
 	"github.com/blevesearch/bleve/index/store/boltdb"
 	"github.com/blevesearch/bleve/index/store/goleveldb"
@@ -152,3 +158,298 @@ func getDPFromSearchresult(req, rep json.RawMessage, hdr utils.MapStorage) (util
 		utils.MetaHdr:  hdr,
 	}, nil
 }
+
+// trafficHit is one API call out of the index
+type trafficHit struct {
+	fields    map[string]interface{} // the fields as replied by the APIs
+	id        uint64
+	method    string
+	conn      string // <encoding>:<source>:<destination>
+	startTime time.Time
+	duration  time.Duration
+	params    json.RawMessage
+	reply     json.RawMessage
+	replyErr  string
+}
+
+// newTrafficHit parses the fields of a search hit, formatting them for the API replies
+func newTrafficHit(fields map[string]interface{}) (hit *trafficHit) {
+	hit = &trafficHit{
+		fields: fields,
+		method: utils.IfaceAsString(fields[utils.RequestMethod]),
+		conn: utils.ConcatenatedKey(utils.IfaceAsString(fields[utils.RequestEncoding]),
+			utils.IfaceAsString(fields[utils.RequestSource]),
+			utils.IfaceAsString(fields[utils.RequestDestination])),
+		params: json.RawMessage(utils.IfaceAsString(fields[utils.RequestParams])),
+		reply:  json.RawMessage(utils.IfaceAsString(fields[utils.Reply])),
+	}
+	if id, err := utils.IfaceAsTInt64(fields[utils.RequestID]); err == nil {
+		hit.id = uint64(id)
+	}
+	hit.startTime, _ = utils.IfaceAsTime(fields[utils.RequestStartTime], utils.EmptyString)
+	// make sure that the result is corectly marshaled
+	fields[utils.Reply] = hit.reply
+	fields[utils.RequestParams] = hit.params
+	// try to pretty print the duration
+	if dur, err := utils.IfaceAsDuration(fields[utils.RequestDuration]); err == nil {
+		hit.duration = dur
+		fields[utils.RequestDuration] = dur.String()
+	}
+	if val, has := fields[utils.ReplyError]; !has || len(utils.IfaceAsString(val)) == 0 {
+		fields[utils.ReplyError] = nil
+	} else {
+		hit.replyErr = utils.IfaceAsString(val)
+	}
+	return
+}
+
+func (hit *trafficHit) asMapInterface() map[string]interface{} {
+	return hit.fields
+}
+
+// parseTrafficOrderBy returns the field and the direction out of the field, optionally followed by ;desc
+func parseTrafficOrderBy(orderBy string) (fld string, desc bool, err error) {
+	fld = utils.RequestStartTime
+	if orderBy != utils.EmptyString {
+		ordSplt := strings.Split(orderBy, utils.InfieldSep)
+		fld = ordSplt[0]
+		desc = len(ordSplt) == 2 && ordSplt[1] == "desc"
+	}
+	switch fld {
+	case utils.RequestStartTime, utils.RequestDuration,
+		utils.RequestMethod, utils.RequestID:
+	default:
+		err = fmt.Errorf("unsupported OrderBy field <%s>", fld)
+	}
+	return
+}
+
+// sortTrafficHits orders the hits based on the field, optionally followed by ;desc
+func sortTrafficHits(hits []*trafficHit, orderBy string) (err error) {
+	fld, desc, err := parseTrafficOrderBy(orderBy)
+	if err != nil {
+		return
+	}
+	var less func(i, j int) bool
+	switch fld {
+	case utils.RequestStartTime:
+		less = func(i, j int) bool { return hits[i].startTime.Before(hits[j].startTime) }
+	case utils.RequestDuration:
+		less = func(i, j int) bool { return hits[i].duration < hits[j].duration }
+	case utils.RequestMethod:
+		less = func(i, j int) bool { return hits[i].method < hits[j].method }
+	default: // utils.RequestID
+		less = func(i, j int) bool { return hits[i].id < hits[j].id }
+	}
+	if desc {
+		sort.SliceStable(hits, func(i, j int) bool { return less(j, i) })
+	} else {
+		sort.SliceStable(hits, less)
+	}
+	return
+}
+
+// paginateTrafficHits returns the page of hits selected by the paginator
+func paginateTrafficHits(hits []*trafficHit, pgnt utils.Paginator) []*trafficHit {
+	if pgnt.Offset != nil && *pgnt.Offset > 0 {
+		if *pgnt.Offset >= len(hits) {
+			return []*trafficHit{}
+		}
+		hits = hits[*pgnt.Offset:]
+	}
+	if pgnt.Limit != nil && *pgnt.Limit > 0 && *pgnt.Limit < len(hits) {
+		hits = hits[:*pgnt.Limit]
+	}
+	return hits
+}
+
+// TrafficStats the statistics of a group of API calls
+type TrafficStats struct {
+	Group       string // the method or the connection as <encoding>:<source>:<destination>
+	Count       int
+	Errors      int // the calls replied with error
+	MinDuration time.Duration
+	AvgDuration time.Duration
+	MaxDuration time.Duration
+	P50Duration time.Duration
+	P90Duration time.Duration
+	P95Duration time.Duration
+	P99Duration time.Duration
+}
+
+// aggregateTrafficHits computes the statistics of the hits grouped on *method or *connection, ordered on group
+func aggregateTrafficHits(hits []*trafficHit, groupBy string) (stats []*TrafficStats) {
+	durs := make(map[string][]time.Duration)
+	errs := make(map[string]int)
+	for _, hit := range hits {
+		grp := hit.method
+		if groupBy == utils.MetaConnection {
+			grp = hit.conn
+		}
+		durs[grp] = append(durs[grp], hit.duration)
+		if hit.replyErr != utils.EmptyString {
+			errs[grp]++
+		}
+	}
+	stats = make([]*TrafficStats, 0, len(durs))
+	for grp, grpDurs := range durs {
+		sort.Slice(grpDurs, func(i, j int) bool { return grpDurs[i] < grpDurs[j] })
+		var sum time.Duration
+		for _, dur := range grpDurs {
+			sum += dur
+		}
+		stats = append(stats, &TrafficStats{
+			Group:       grp,
+			Count:       len(grpDurs),
+			Errors:      errs[grp],
+			MinDuration: grpDurs[0],
+			AvgDuration: sum / time.Duration(len(grpDurs)),
+			MaxDuration: grpDurs[len(grpDurs)-1],
+			P50Duration: percentile(grpDurs, 50),
+			P90Duration: percentile(grpDurs, 90),
+			P95Duration: percentile(grpDurs, 95),
+			P99Duration: percentile(grpDurs, 99),
+		})
+	}
+	sort.Slice(stats, func(i, j int) bool { return stats[i].Group < stats[j].Group })
+	return
+}
+
+// percentile returns the nearest-rank percentile out of the sorted durations
+func percentile(sorted []time.Duration, p int) time.Duration {
+	rank := (p*len(sorted) + 99) / 100 // ceil(p/100*n)
+	if rank < 1 {
+		rank = 1
+	}
+	return sorted[rank-1]
+}
+
+// TrafficRecord is one API call as exported in JSON lines by AnalyzerSv1.Export
+type TrafficRecord struct {
+	RequestID          uint64
+	RequestMethod      string
+	RequestParams      json.RawMessage
+	Reply              json.RawMessage
+	ReplyError         string
+	RequestEncoding    string
+	RequestSource      string
+	RequestDestination string
+	RequestStartTime   time.Time
+	RequestDuration    time.Duration
+}
+
+func (hit *trafficHit) asTrafficRecord() *TrafficRecord {
+	return &TrafficRecord{
+		RequestID:          hit.id,
+		RequestMethod:      hit.method,
+		RequestParams:      hit.params,
+		Reply:              hit.reply,
+		ReplyError:         hit.replyErr,
+		RequestEncoding:    utils.IfaceAsString(hit.fields[utils.RequestEncoding]),
+		RequestSource:      utils.IfaceAsString(hit.fields[utils.RequestSource]),
+		RequestDestination: utils.IfaceAsString(hit.fields[utils.RequestDestination]),
+		RequestStartTime:   hit.startTime,
+		RequestDuration:    hit.duration,
+	}
+}
+
+// writeTrafficRecords writes the hits as one JSON encoded TrafficRecord per line
+func writeTrafficRecords(w io.Writer, hits []*trafficHit) (err error) {
+	enc := json.NewEncoder(w)
+	for _, hit := range hits {
+		if err = enc.Encode(hit.asTrafficRecord()); err != nil {
+			return
+		}
+	}
+	return
+}
+
+// ReadTrafficRecords reads the JSON lines written by AnalyzerSv1.Export
+func ReadTrafficRecords(r io.Reader) (recs []*TrafficRecord, err error) {
+	dec := json.NewDecoder(r)
+	for dec.More() {
+		rec := new(TrafficRecord)
+		if err = dec.Decode(rec); err != nil {
+			return nil, err
+		}
+		recs = append(recs, rec)
+	}
+	return
+}
+
+// ReadTrafficIndex reads all the API calls out of the AnalyzerS index found at dbPath, ordered on RequestStartTime
+// the index is locked by the running engine so it needs to be stopped or the index copied before
+func ReadTrafficIndex(dbPath string) (recs []*TrafficRecord, err error) {
+	var db bleve.Index
+	if db, err = bleve.Open(dbPath); err != nil {
+		return
+	}
+	defer db.Close()
+	var docCount uint64
+	if docCount, err = db.DocCount(); err != nil {
+		return
+	}
+	s := bleve.NewSearchRequestOptions(bleve.NewMatchAllQuery(), int(docCount), 0, false)
+	s.Fields = []string{utils.Meta} // return all fields
+	var searchResults *bleve.SearchResult
+	if searchResults, err = db.Search(s); err != nil {
+		return
+	}
+	hits := make([]*trafficHit, len(searchResults.Hits))
+	for i, obj := range searchResults.Hits {
+		hits[i] = newTrafficHit(obj.Fields)
+	}
+	if err = sortTrafficHits(hits, utils.RequestStartTime); err != nil {
+		return
+	}
+	recs = make([]*TrafficRecord, len(hits))
+	for i, hit := range hits {
+		recs[i] = hit.asTrafficRecord()
+	}
+	return
+}
+
+// CompareReply checks the reply received when replaying the call against the recorded one
+// the fields found in ignore are not compared at any depth(e.g. IDs or timestamps generated by the engine)
+func (tr *TrafficRecord) CompareReply(reply interface{}, replyErr error, ignore utils.StringSet) (err error) {
+	var rplyErr string
+	if replyErr != nil {
+		rplyErr = replyErr.Error()
+	}
+	if rplyErr != tr.ReplyError {
+		return fmt.Errorf("expected error <%s>, received <%s>", tr.ReplyError, rplyErr)
+	}
+	if tr.ReplyError != utils.EmptyString {
+		return
+	}
+	var expReply interface{}
+	if len(tr.Reply) != 0 {
+		if err = json.Unmarshal(tr.Reply, &expReply); err != nil {
+			return
+		}
+	}
+	expReply = stripTrafficFields(expReply, ignore)
+	if reply = stripTrafficFields(reply, ignore); !reflect.DeepEqual(expReply, reply) {
+		return fmt.Errorf("expected reply <%s>, received <%s>", utils.ToJSON(expReply), utils.ToJSON(reply))
+	}
+	return
+}
+
+// stripTrafficFields removes recursively the ignored keys out of a decoded JSON value
+func stripTrafficFields(v interface{}, ignore utils.StringSet) interface{} {
+	switch val := v.(type) {
+	case map[string]interface{}:
+		for k, fldVal := range val {
+			if ignore.Has(k) {
+				delete(val, k)
+				continue
+			}
+			val[k] = stripTrafficFields(fldVal, ignore)
+		}
+	case []interface{}:
+		for i, fldVal := range val {
+			val[i] = stripTrafficFields(fldVal, ignore)
+		}
+	}
+	return v
+}
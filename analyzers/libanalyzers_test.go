@@ -156,3 +156,56 @@ func TestUnmarshalJSON(t *testing.T) {
 		t.Errorf("Expected: %s,received %s", utils.ToJSON(exp), utils.ToJSON(val))
 	}
 }
+
+func TestPercentile(t *testing.T) {
+	durs := make([]time.Duration, 100)
+	for i := range durs {
+		durs[i] = time.Duration(i+1) * time.Millisecond
+	}
+	for p, exp := range map[int]time.Duration{
+		0:   time.Millisecond,
+		50:  50 * time.Millisecond,
+		90:  90 * time.Millisecond,
+		99:  99 * time.Millisecond,
+		100: 100 * time.Millisecond,
+	} {
+		if rcv := percentile(durs, p); rcv != exp {
+			t.Errorf("Expected P%d %v, received: %v", p, exp, rcv)
+		}
+	}
+	if rcv := percentile([]time.Duration{time.Second}, 99); rcv != time.Second {
+		t.Errorf("Expected %v, received: %v", time.Second, rcv)
+	}
+}
+
+func TestTrafficRecordCompareReply(t *testing.T) {
+	tr := &TrafficRecord{
+		Reply: json.RawMessage(`[{"ID":"ev1","Event":{"Cost":1.5,"OrderID":1}}]`),
+	}
+	ignore := utils.NewStringSet([]string{"OrderID"})
+	rply := []interface{}{map[string]interface{}{
+		"ID": "ev1",
+		"Event": map[string]interface{}{
+			"Cost":    1.5,
+			"OrderID": 2.,
+		},
+	}}
+	if err := tr.CompareReply(rply, nil, ignore); err != nil {
+		t.Error(err)
+	}
+	rply = []interface{}{map[string]interface{}{
+		"ID":    "ev1",
+		"Event": map[string]interface{}{"Cost": 2.},
+	}}
+	if err := tr.CompareReply(rply, nil, ignore); err == nil {
+		t.Error("Expected reply mismatch")
+	}
+	if err := tr.CompareReply(nil, utils.ErrNotFound, ignore); err == nil ||
+		err.Error() != "expected error <>, received <NOT_FOUND>" {
+		t.Errorf("Expected error mismatch, received: %v", err)
+	}
+	tr = &TrafficRecord{ReplyError: utils.ErrNotFound.Error()}
+	if err := tr.CompareReply(nil, errors.New(utils.ErrNotFound.Error()), nil); err != nil {
+		t.Error(err)
+	}
+}
@@ -85,6 +85,7 @@ type AttrRemoveActionTiming struct {
 	Tenant          string // Tenant the account belongs to
 	Account         string // Account name
 	ReloadScheduler bool   // If set it will reload the scheduler after adding
+	APIOpts         map[string]interface{}
 }
 
 // Removes an ActionTimings or parts of it depending on filters being set
@@ -115,7 +116,7 @@ func (apierSv1 *APIerSv1) RemoveActionTiming(attrs *AttrRemoveActionTiming, repl
 		if accID != "" {
 			delete(ap.AccountIDs, accID)
 			remAcntAPids = append(remAcntAPids, accID)
-			err = apierSv1.DataManager.SetActionPlan(ap.Id, ap, true, utils.NonTransactional)
+			err = apierSv1.auditedDM(utils.APIerSv1RemoveActionTiming, attrs.APIOpts).SetActionPlan(ap.Id, ap, true, utils.NonTransactional)
 			goto UPDATE
 		}
 		if attrs.ActionTimingId != "" { // delete only a action timing from action plan
@@ -126,7 +127,7 @@ func (apierSv1 *APIerSv1) RemoveActionTiming(attrs *AttrRemoveActionTiming, repl
 					break
 				}
 			}
-			err = apierSv1.DataManager.SetActionPlan(ap.Id, ap, true, utils.NonTransactional)
+			err = apierSv1.auditedDM(utils.APIerSv1RemoveActionTiming, attrs.APIOpts).SetActionPlan(ap.Id, ap, true, utils.NonTransactional)
 			goto UPDATE
 		}
 		if attrs.ActionPlanId != "" { // delete the entire action plan
@@ -134,7 +135,7 @@ func (apierSv1 *APIerSv1) RemoveActionTiming(attrs *AttrRemoveActionTiming, repl
 			for acntID := range ap.AccountIDs { // Make sure we clear indexes for all accounts
 				remAcntAPids = append(remAcntAPids, acntID)
 			}
-			err = apierSv1.DataManager.SetActionPlan(ap.Id, ap, true, utils.NonTransactional)
+			err = apierSv1.auditedDM(utils.APIerSv1RemoveActionTiming, attrs.APIOpts).SetActionPlan(ap.Id, ap, true, utils.NonTransactional)
 			goto UPDATE
 		}
 
@@ -246,13 +247,13 @@ func (apierSv1 *APIerSv1) SetAccount(attr *utils.AttrSetAccount, reply *string)
 				apIDs := make([]string, len(dirtyActionPlans))
 				i := 0
 				for actionPlanID, ap := range dirtyActionPlans {
-					if err := apierSv1.DataManager.SetActionPlan(actionPlanID, ap, true, utils.NonTransactional); err != nil {
+					if err := apierSv1.auditedDM(utils.APIerSv1SetAccount, attr.APIOpts).SetActionPlan(actionPlanID, ap, true, utils.NonTransactional); err != nil {
 						return err
 					}
 					apIDs[i] = actionPlanID
 					i++
 				}
-				if err := apierSv1.DataManager.SetAccountActionPlans(accID, acntAPids, true); err != nil {
+				if err := apierSv1.auditedDM(utils.APIerSv1SetAccount, attr.APIOpts).SetAccountActionPlans(accID, acntAPids, true); err != nil {
 					return err
 				}
 				return apierSv1.ConnMgr.Call(apierSv1.Config.ApierCfg().CachesConns, nil,
@@ -281,7 +282,7 @@ func (apierSv1 *APIerSv1) SetAccount(attr *utils.AttrSetAccount, reply *string)
 			ub.Disabled = dis
 		}
 		// All prepared, save account
-		return apierSv1.DataManager.SetAccount(ub)
+		return apierSv1.auditedDM(utils.APIerSv1SetAccount, attr.APIOpts).SetAccount(ub)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -326,7 +327,7 @@ func (apierSv1 *APIerSv1) RemoveAccount(attr *utils.AttrRemoveAccount, reply *st
 			}
 
 			for actionPlanID, ap := range dirtyActionPlans {
-				if err := apierSv1.DataManager.SetActionPlan(actionPlanID, ap, true,
+				if err := apierSv1.auditedDM(utils.APIerSv1RemoveAccount, attr.APIOpts).SetActionPlan(actionPlanID, ap, true,
 					utils.NonTransactional); err != nil {
 					return err
 				}
@@ -335,7 +336,7 @@ func (apierSv1 *APIerSv1) RemoveAccount(attr *utils.AttrRemoveAccount, reply *st
 		}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.ActionPlanPrefix); err != nil {
 			return err
 		}
-		return apierSv1.DataManager.RemoveAccount(accID)
+		return apierSv1.auditedDM(utils.APIerSv1RemoveAccount, attr.APIOpts).RemoveAccount(accID)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -425,6 +426,7 @@ type AttrAddBalance struct {
 	ActionExtraData *map[string]interface{}
 	Overwrite       bool // When true it will reset if the balance is already there
 	Cdrlog          bool
+	APIOpts         map[string]interface{}
 }
 
 func (apierSv1 *APIerSv1) AddBalance(attr *AttrAddBalance, reply *string) error {
@@ -451,12 +453,16 @@ func (apierSv1 *APIerSv1) modifyBalance(aType string, attr *AttrAddBalance, repl
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
 	accID := utils.ConcatenatedKey(tnt, attr.Account)
+	src := utils.APIerSv1AddBalance
+	if aType == utils.MetaDebit {
+		src = utils.APIerSv1DebitBalance
+	}
 	if _, err = apierSv1.DataManager.GetAccount(accID); err != nil {
 		// create account if does not exist
 		account := &engine.Account{
 			ID: accID,
 		}
-		if err = apierSv1.DataManager.SetAccount(account); err != nil {
+		if err = apierSv1.auditedDM(src, attr.APIOpts).SetAccount(account); err != nil {
 			return
 		}
 	}
@@ -502,9 +508,11 @@ func (apierSv1 *APIerSv1) modifyBalance(aType string, attr *AttrAddBalance, repl
 		}}
 	}
 	at.SetActions(acts)
+	ac := apierSv1.auditedDM(src, attr.APIOpts).NewAuditChange(utils.AccountPrefix, accID)
 	if err := at.Execute(apierSv1.FilterS); err != nil {
 		return err
 	}
+	ac.Record()
 	*reply = utils.OK
 	return nil
 }
@@ -538,7 +546,7 @@ func (apierSv1 *APIerSv1) SetBalance(attr *utils.AttrSetBalance, reply *string)
 		account := &engine.Account{
 			ID: accID,
 		}
-		if err = apierSv1.DataManager.SetAccount(account); err != nil {
+		if err = apierSv1.auditedDM(utils.APIerSv1SetBalance, attr.APIOpts).SetAccount(account); err != nil {
 			return
 		}
 	}
@@ -580,9 +588,11 @@ func (apierSv1 *APIerSv1) SetBalance(attr *utils.AttrSetBalance, reply *string)
 		}}
 	}
 	at.SetActions(acts)
+	ac := apierSv1.auditedDM(utils.APIerSv1SetBalance, attr.APIOpts).NewAuditChange(utils.AccountPrefix, accID)
 	if err = at.Execute(apierSv1.FilterS); err != nil {
 		return
 	}
+	ac.Record()
 	*reply = utils.OK
 	return
 }
@@ -604,7 +614,7 @@ func (apierSv1 *APIerSv1) SetBalances(attr *utils.AttrSetBalances, reply *string
 		account := &engine.Account{
 			ID: accID,
 		}
-		if err = apierSv1.DataManager.SetAccount(account); err != nil {
+		if err = apierSv1.auditedDM(utils.APIerSv1SetBalances, attr.APIOpts).SetAccount(account); err != nil {
 			return
 		}
 	}
@@ -662,9 +672,11 @@ func (apierSv1 *APIerSv1) SetBalances(attr *utils.AttrSetBalances, reply *string
 			}}
 		}
 		at.SetActions(acts)
+		ac := apierSv1.auditedDM(utils.APIerSv1SetBalances, attr.APIOpts).NewAuditChange(utils.AccountPrefix, accID)
 		if err = at.Execute(apierSv1.FilterS); err != nil {
 			return
 		}
+		ac.Record()
 	}
 
 	*reply = utils.OK
@@ -702,10 +714,12 @@ func (apierSv1 *APIerSv1) RemoveBalances(attr *utils.AttrSetBalance, reply *stri
 		Balance:    balance,
 	}
 	at.SetActions(engine.Actions{a})
+	ac := apierSv1.auditedDM(utils.APIerSv1RemoveBalances, attr.APIOpts).NewAuditChange(utils.AccountPrefix, accID)
 	if err := at.Execute(apierSv1.FilterS); err != nil {
 		*reply = err.Error()
 		return err
 	}
+	ac.Record()
 	*reply = utils.OK
 	return nil
 }
@@ -49,3 +49,18 @@ func (aSv1 *AnalyzerSv1) Ping(ign *utils.CGREvent, reply *string) error {
 func (aSv1 *AnalyzerSv1) StringQuery(search *analyzers.QueryArgs, reply *[]map[string]interface{}) error {
 	return aSv1.aS.V1StringQuery(search, reply)
 }
+
+// Search returns the API calls matching the structured filters, ordered and paginated
+func (aSv1 *AnalyzerSv1) Search(args *analyzers.SearchArgs, reply *[]map[string]interface{}) error {
+	return aSv1.aS.V1Search(args, reply)
+}
+
+// Aggregate returns the statistics of the API calls grouped on method or connection
+func (aSv1 *AnalyzerSv1) Aggregate(args *analyzers.AggregateArgs, reply *[]*analyzers.TrafficStats) error {
+	return aSv1.aS.V1Aggregate(args, reply)
+}
+
+// Export writes the API calls matching the filters as JSON lines into the ExportPath file
+func (aSv1 *AnalyzerSv1) Export(args *analyzers.ExportArgs, reply *string) error {
+	return aSv1.aS.V1Export(args, reply)
+}
@@ -73,6 +73,7 @@ func (apierSv1 *APIerSv1) GetDestination(dstId *string, reply *engine.Destinatio
 type AttrRemoveDestination struct {
 	DestinationIDs []string
 	Prefixes       []string
+	APIOpts        map[string]interface{}
 }
 
 func (apierSv1 *APIerSv1) RemoveDestination(attr *AttrRemoveDestination, reply *string) (err error) {
@@ -94,7 +95,7 @@ func (apierSv1 *APIerSv1) RemoveDestination(attr *AttrRemoveDestination, reply *
 				}
 			}
 			if len(newDst.Prefixes) != 0 { // only update the current destination
-				if err = apierSv1.DataManager.SetDestination(newDst, utils.NonTransactional); err != nil {
+				if err = apierSv1.auditedDM(utils.APIerSv1RemoveDestination, attr.APIOpts).SetDestination(newDst, utils.NonTransactional); err != nil {
 					return
 				}
 				if err = apierSv1.DataManager.UpdateReverseDestination(oldDst, newDst, utils.NonTransactional); err != nil {
@@ -110,7 +111,7 @@ func (apierSv1 *APIerSv1) RemoveDestination(attr *AttrRemoveDestination, reply *
 				continue
 			}
 		}
-		if err = apierSv1.DataManager.RemoveDestination(dstID, utils.NonTransactional); err != nil {
+		if err = apierSv1.auditedDM(utils.APIerSv1RemoveDestination, attr.APIOpts).RemoveDestination(dstID, utils.NonTransactional); err != nil {
 			return
 		}
 		if err = apierSv1.ConnMgr.Call(apierSv1.Config.ApierCfg().CachesConns, nil,
@@ -184,7 +185,7 @@ func (apierSv1 *APIerSv1) SetDestination(attrs *utils.AttrSetDestination, reply
 	} else if !attrs.Overwrite {
 		return utils.ErrExists
 	}
-	if err := apierSv1.DataManager.SetDestination(dest, utils.NonTransactional); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1SetDestination, attrs.APIOpts).SetDestination(dest, utils.NonTransactional); err != nil {
 		return utils.NewErrServerError(err)
 	}
 	if err = apierSv1.DataManager.UpdateReverseDestination(oldDest, dest, utils.NonTransactional); err != nil {
@@ -217,7 +218,7 @@ func (apierSv1 *APIerSv1) RemoveRatingPlan(ID *string, reply *string) error {
 	if len(*ID) == 0 {
 		return utils.NewErrMandatoryIeMissing("ID")
 	}
-	err := apierSv1.DataManager.RemoveRatingPlan(*ID, utils.NonTransactional)
+	err := apierSv1.auditedDM(utils.APIerSv1RemoveRatingPlan, nil).RemoveRatingPlan(*ID, utils.NonTransactional)
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -243,20 +244,25 @@ func (apierSv1 *APIerSv1) ExecuteAction(attr *utils.AttrExecuteAction, reply *st
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
+	var ac *engine.AuditChange
 	if attr.Account != "" {
-		at.SetAccountIDs(utils.StringMap{utils.ConcatenatedKey(tnt, attr.Account): true})
+		accID := utils.ConcatenatedKey(tnt, attr.Account)
+		at.SetAccountIDs(utils.StringMap{accID: true})
+		ac = apierSv1.auditedDM(utils.APIerSv1ExecuteAction, attr.APIOpts).NewAuditChange(utils.AccountPrefix, accID)
 	}
 	if err := at.Execute(apierSv1.FilterS); err != nil {
 		*reply = err.Error()
 		return err
 	}
+	ac.Record()
 	*reply = utils.OK
 	return nil
 }
 
 type AttrLoadDestination struct {
-	TPid string
-	ID   string
+	TPid    string
+	ID      string
+	APIOpts map[string]interface{}
 }
 
 // Load destinations from storDb into dataDb.
@@ -271,6 +277,7 @@ func (apierSv1 *APIerSv1) LoadDestination(attrs *AttrLoadDestination, reply *str
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	dbReader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv1LoadDestination)
 	if loaded, err := dbReader.LoadDestinationsFiltered(attrs.ID); err != nil {
 		return utils.NewErrServerError(err)
 	} else if !loaded {
@@ -289,6 +296,7 @@ func (apierSv1 *APIerSv1) LoadDestination(attrs *AttrLoadDestination, reply *str
 type AttrLoadRatingPlan struct {
 	TPid         string
 	RatingPlanId string
+	APIOpts      map[string]interface{}
 }
 
 // Process dependencies and load a specific rating plan from storDb into dataDb.
@@ -303,6 +311,7 @@ func (apierSv1 *APIerSv1) LoadRatingPlan(attrs *AttrLoadRatingPlan, reply *strin
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	dbReader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv1LoadRatingPlan)
 	if loaded, err := dbReader.LoadRatingPlansFiltered(attrs.RatingPlanId); err != nil {
 		return utils.NewErrServerError(err)
 	} else if !loaded {
@@ -313,8 +322,8 @@ func (apierSv1 *APIerSv1) LoadRatingPlan(attrs *AttrLoadRatingPlan, reply *strin
 }
 
 // Process dependencies and load a specific rating profile from storDb into dataDb.
-func (apierSv1 *APIerSv1) LoadRatingProfile(attrs *utils.TPRatingProfile, reply *string) error {
-	if len(attrs.TPid) == 0 {
+func (apierSv1 *APIerSv1) LoadRatingProfile(attrs *utils.TPRatingProfileWithAPIOpts, reply *string) error {
+	if attrs.TPRatingProfile == nil || len(attrs.TPid) == 0 {
 		return utils.NewErrMandatoryIeMissing("TPid")
 	}
 	if attrs.Tenant == utils.EmptyString {
@@ -327,7 +336,8 @@ func (apierSv1 *APIerSv1) LoadRatingProfile(attrs *utils.TPRatingProfile, reply
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
-	if err := dbReader.LoadRatingProfilesFiltered(attrs); err != nil {
+	dbReader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv1LoadRatingProfile)
+	if err := dbReader.LoadRatingProfilesFiltered(attrs.TPRatingProfile); err != nil {
 		return utils.NewErrServerError(err)
 	}
 	if err := apierSv1.DataManager.SetLoadIDs(map[string]int64{utils.CacheRatingProfiles: time.Now().UnixNano()}); err != nil {
@@ -343,6 +353,7 @@ func (apierSv1 *APIerSv1) LoadRatingProfile(attrs *utils.TPRatingProfile, reply
 type AttrLoadSharedGroup struct {
 	TPid          string
 	SharedGroupId string
+	APIOpts       map[string]interface{}
 }
 
 // Load destinations from storDb into dataDb.
@@ -357,6 +368,7 @@ func (apierSv1 *APIerSv1) LoadSharedGroup(attrs *AttrLoadSharedGroup, reply *str
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	dbReader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv1LoadSharedGroup)
 	if err := dbReader.LoadSharedGroupsFiltered(attrs.SharedGroupId, true); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -384,6 +396,7 @@ func (apierSv1 *APIerSv1) LoadTariffPlanFromStorDb(attrs *AttrLoadTpFromStorDb,
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	dbReader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv1LoadTariffPlanFromStorDb)
 	if err := dbReader.LoadAll(); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -496,7 +509,7 @@ func (apierSv1 *APIerSv1) SetRatingProfile(attrs *utils.AttrSetRatingProfile, re
 				FallbackKeys: utils.FallbackSubjKeys(tnt,
 					attrs.Category, ra.FallbackSubjects)})
 	}
-	if err := apierSv1.DataManager.SetRatingProfile(rpfl); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1SetRatingProfile, attrs.APIOpts).SetRatingProfile(rpfl); err != nil {
 		return utils.NewErrServerError(err)
 	}
 
@@ -554,6 +567,7 @@ type V1AttrSetActions struct {
 	ActionsId string        // Actions id
 	Overwrite bool          // If previously defined, will be overwritten
 	Actions   []*V1TPAction // Set of actions this Actions profile will perform
+	APIOpts   map[string]interface{}
 }
 type V1TPActions struct {
 	TPid      string        // Tariff plan id
@@ -644,7 +658,7 @@ func (apierSv1 *APIerSv1) SetActions(attrs *V1AttrSetActions, reply *string) (er
 		}
 		storeActions[idx] = a
 	}
-	if err := apierSv1.DataManager.SetActions(attrs.ActionsId, storeActions); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1SetActions, attrs.APIOpts).SetActions(attrs.ActionsId, storeActions); err != nil {
 		return utils.NewErrServerError(err)
 	}
 	//CacheReload
@@ -705,6 +719,7 @@ type AttrSetActionPlan struct {
 	ActionPlan      []*AttrActionPlan // Set of actions this Actions profile will perform
 	Overwrite       bool              // If previously defined, will be overwritten
 	ReloadScheduler bool              // Enables automatic reload of the scheduler (eg: useful when adding a single action timing)
+	APIOpts         map[string]interface{}
 }
 
 type AttrActionPlan struct {
@@ -792,7 +807,7 @@ func (apierSv1 *APIerSv1) SetActionPlan(attrs *AttrSetActionPlan, reply *string)
 				ActionsID: apiAtm.ActionsId,
 			})
 		}
-		if err := apierSv1.DataManager.SetActionPlan(ap.Id, ap, true, utils.NonTransactional); err != nil {
+		if err := apierSv1.auditedDM(utils.APIerSv1SetActionPlan, attrs.APIOpts).SetActionPlan(ap.Id, ap, true, utils.NonTransactional); err != nil {
 			return utils.NewErrServerError(err)
 		}
 		if err := apierSv1.ConnMgr.Call(apierSv1.Config.ApierCfg().CachesConns, nil,
@@ -947,7 +962,8 @@ func checkDefaultTiming(tStr string) (rTm *engine.RITiming, isDefault bool) {
 }
 
 type AttrGetActionPlan struct {
-	ID string
+	ID      string
+	APIOpts map[string]interface{}
 }
 
 func (apierSv1 *APIerSv1) GetActionPlan(attr *AttrGetActionPlan, reply *[]*engine.ActionPlan) error {
@@ -983,7 +999,7 @@ func (apierSv1 *APIerSv1) RemoveActionPlan(attr *AttrGetActionPlan, reply *strin
 		} else if prevAP != nil {
 			prevAccountIDs = prevAP.AccountIDs
 		}
-		if err := apierSv1.DataManager.RemoveActionPlan(attr.ID, utils.NonTransactional); err != nil {
+		if err := apierSv1.auditedDM(utils.APIerSv1RemoveActionPlan, attr.APIOpts).RemoveActionPlan(attr.ID, utils.NonTransactional); err != nil {
 			return err
 		}
 		for acntID := range prevAccountIDs {
@@ -1008,8 +1024,8 @@ func (apierSv1 *APIerSv1) RemoveActionPlan(attr *AttrGetActionPlan, reply *strin
 }
 
 // Process dependencies and load a specific AccountActions profile from storDb into dataDb.
-func (apierSv1 *APIerSv1) LoadAccountActions(attrs *utils.TPAccountActions, reply *string) error {
-	if len(attrs.TPid) == 0 {
+func (apierSv1 *APIerSv1) LoadAccountActions(attrs *utils.TPAccountActionsWithAPIOpts, reply *string) error {
+	if attrs.TPAccountActions == nil || len(attrs.TPid) == 0 {
 		return utils.NewErrMandatoryIeMissing("TPid")
 	}
 	dbReader, err := engine.NewTpReader(apierSv1.DataManager.DataDB(), apierSv1.StorDb,
@@ -1019,8 +1035,9 @@ func (apierSv1 *APIerSv1) LoadAccountActions(attrs *utils.TPAccountActions, repl
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	dbReader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv1LoadAccountActions)
 	if err := guardian.Guardian.Guard(func() error {
-		return dbReader.LoadAccountActionsFiltered(attrs)
+		return dbReader.LoadAccountActionsFiltered(attrs.TPAccountActions)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, attrs.LoadId); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -1058,6 +1075,7 @@ func (apierSv1 *APIerSv1) LoadTariffPlanFromFolder(attrs *utils.AttrLoadTpFromFo
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	loader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv1LoadTariffPlanFromFolder)
 	//Load the data
 	if err := loader.LoadAll(); err != nil {
 		return utils.NewErrServerError(err)
@@ -1124,6 +1142,7 @@ func (apierSv1 *APIerSv1) RemoveTPFromFolder(attrs *utils.AttrLoadTpFromFolder,
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	loader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv1RemoveTPFromFolder)
 	//Load the data
 	if err := loader.LoadAll(); err != nil {
 		return utils.NewErrServerError(err)
@@ -1178,6 +1197,7 @@ func (apierSv1 *APIerSv1) RemoveTPFromStorDB(attrs *AttrLoadTpFromStorDb, reply
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	dbReader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv1RemoveTPFromStorDB)
 	if err := dbReader.LoadAll(); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -1253,7 +1273,7 @@ func (apierSv1 *APIerSv1) RemoveRatingProfile(attr *AttrRemoveRatingProfile, rep
 	}
 	keyID := attr.GetId()
 	err := guardian.Guardian.Guard(func() error {
-		return apierSv1.DataManager.RemoveRatingProfile(keyID)
+		return apierSv1.auditedDM(utils.APIerSv1RemoveRatingProfile, attr.APIOpts).RemoveRatingProfile(keyID)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, "RemoveRatingProfile")
 	if err != nil {
 		*reply = err.Error()
@@ -1300,6 +1320,7 @@ func (apierSv1 *APIerSv1) GetLoadHistory(attrs *utils.Paginator, reply *[]*utils
 
 type AttrRemoveActions struct {
 	ActionIDs []string
+	APIOpts   map[string]interface{}
 }
 
 func (apierSv1 *APIerSv1) RemoveActions(attr *AttrRemoveActions, reply *string) error {
@@ -1347,7 +1368,7 @@ func (apierSv1 *APIerSv1) RemoveActions(attr *AttrRemoveActions, reply *string)
 		}
 	*/
 	for _, aID := range attr.ActionIDs {
-		if err := apierSv1.DataManager.RemoveActions(aID); err != nil {
+		if err := apierSv1.auditedDM(utils.APIerSv1RemoveActions, attr.APIOpts).RemoveActions(aID); err != nil {
 			*reply = err.Error()
 			return err
 		}
@@ -547,9 +547,10 @@ func testAPIerLoadRatingProfile(t *testing.T) {
 		t.Error(err)
 	}
 	// load the TPRatingProfile into dataDB
-	argsRPrf := &utils.TPRatingProfile{
-		TPid: "TP_SAMPLE", LoadId: "TP_SAMPLE",
-		Tenant: "cgrates.org", Category: "call", Subject: "*any"}
+	argsRPrf := &utils.TPRatingProfileWithAPIOpts{
+		TPRatingProfile: &utils.TPRatingProfile{
+			TPid: "TP_SAMPLE", LoadId: "TP_SAMPLE",
+			Tenant: "cgrates.org", Category: "call", Subject: "*any"}}
 	if err := apierRPC.Call(utils.APIerSv1LoadRatingProfile, argsRPrf, &reply); err != nil {
 		t.Error(err)
 	}
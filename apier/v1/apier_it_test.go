@@ -822,10 +822,11 @@ func testApierLoadRatingPlan(t *testing.T) {
 // Test here LoadRatingProfile
 func testApierLoadRatingProfile(t *testing.T) {
 	var reply string
-	rpf := &utils.TPRatingProfile{
-		TPid: utils.TestSQL, LoadId: utils.TestSQL,
-		Tenant: "cgrates.org", Category: "call", Subject: "*any"}
-	if err := rater.Call(utils.APIerSv1LoadRatingProfile, &rpf, &reply); err != nil {
+	rpf := &utils.TPRatingProfileWithAPIOpts{
+		TPRatingProfile: &utils.TPRatingProfile{
+			TPid: utils.TestSQL, LoadId: utils.TestSQL,
+			Tenant: "cgrates.org", Category: "call", Subject: "*any"}}
+	if err := rater.Call(utils.APIerSv1LoadRatingProfile, rpf, &reply); err != nil {
 		t.Error("Got error on APIerSv1.LoadRatingProfile: ", err.Error())
 	} else if reply != utils.OK {
 		t.Error("Calling APIerSv1.LoadRatingProfile got reply: ", reply)
@@ -834,10 +835,11 @@ func testApierLoadRatingProfile(t *testing.T) {
 
 func testApierLoadRatingProfileWithoutTenant(t *testing.T) {
 	var reply string
-	rpf := &utils.TPRatingProfile{
-		TPid: utils.TestSQL, LoadId: utils.TestSQL,
-		Category: "call", Subject: "*any"}
-	if err := rater.Call(utils.APIerSv1LoadRatingProfile, &rpf, &reply); err != nil {
+	rpf := &utils.TPRatingProfileWithAPIOpts{
+		TPRatingProfile: &utils.TPRatingProfile{
+			TPid: utils.TestSQL, LoadId: utils.TestSQL,
+			Category: "call", Subject: "*any"}}
+	if err := rater.Call(utils.APIerSv1LoadRatingProfile, rpf, &reply); err != nil {
 		t.Error("Got error on APIerSv1.LoadRatingProfile: ", err.Error())
 	} else if reply != utils.OK {
 		t.Error("Calling APIerSv1.LoadRatingProfile got reply: ", reply)
@@ -856,7 +858,8 @@ func testApierLoadAccountActions(t *testing.T) {
 		t.Errorf("Calling CacheSv1.GetCacheStats expected: %+v,\n received: %+v", utils.ToJSON(expectedStats), utils.ToJSON(rcvStats))
 	}
 	var reply string
-	aa1 := &utils.TPAccountActions{TPid: utils.TestSQL, LoadId: utils.TestSQL, Tenant: "cgrates.org", Account: "1001"}
+	aa1 := &utils.TPAccountActionsWithAPIOpts{
+		TPAccountActions: &utils.TPAccountActions{TPid: utils.TestSQL, LoadId: utils.TestSQL, Tenant: "cgrates.org", Account: "1001"}}
 	if err := rater.Call(utils.APIerSv1LoadAccountActions, aa1, &reply); err != nil {
 		t.Error("Got error on APIerSv1.LoadAccountActions: ", err.Error())
 	} else if reply != utils.OK {
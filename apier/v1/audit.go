@@ -0,0 +1,83 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AttrGetAuditRecords filters the records of the audit log
+type AttrGetAuditRecords struct {
+	ItemTypes []string // the cache partitions of the items, e.g. *attribute_profiles
+	Tenants   []string
+	ItemIDs   []string
+	Users     []string
+	Actions   []string // *store or *remove
+	TimeStart string   // records starting with this time, inclusive
+	TimeEnd   string   // records older than this time
+	utils.Paginator
+	APIOpts map[string]interface{}
+}
+
+// AsAuditRecordsFilter converts the arguments into the StorDB filter
+func (attr *AttrGetAuditRecords) AsAuditRecordsFilter(timezone string) (fltr *utils.AuditRecordsFilter, err error) {
+	fltr = &utils.AuditRecordsFilter{
+		ItemTypes: attr.ItemTypes,
+		Tenants:   attr.Tenants,
+		ItemIDs:   attr.ItemIDs,
+		Users:     attr.Users,
+		Actions:   attr.Actions,
+		Paginator: attr.Paginator,
+	}
+	if len(attr.TimeStart) != 0 {
+		var tStart time.Time
+		if tStart, err = utils.ParseTimeDetectLayout(attr.TimeStart, timezone); err != nil {
+			return
+		}
+		fltr.Time.Begin = &tStart
+	}
+	if len(attr.TimeEnd) != 0 {
+		var tEnd time.Time
+		if tEnd, err = utils.ParseTimeDetectLayout(attr.TimeEnd, timezone); err != nil {
+			return
+		}
+		fltr.Time.End = &tEnd
+	}
+	return
+}
+
+// GetAuditRecords queries the audit log of the changes done to the DataDB items, sorted on time
+func (apierSv1 *APIerSv1) GetAuditRecords(attr *AttrGetAuditRecords, reply *[]*engine.AuditRecord) (err error) {
+	var fltr *utils.AuditRecordsFilter
+	if fltr, err = attr.AsAuditRecordsFilter(apierSv1.Config.GeneralCfg().DefaultTimezone); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	var recs []*engine.AuditRecord
+	if recs, err = apierSv1.CdrDb.GetAuditRecords(fltr); err != nil {
+		if err != utils.ErrNotFound {
+			err = utils.NewErrServerError(err)
+		}
+		return
+	}
+	*reply = recs
+	return
+}
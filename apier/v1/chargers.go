@@ -69,7 +69,7 @@ type ChargerWithAPIOpts struct {
 	APIOpts map[string]interface{}
 }
 
-//SetChargerProfile add/update a new Charger Profile
+// SetChargerProfile add/update a new Charger Profile
 func (apierSv1 *APIerSv1) SetChargerProfile(arg *ChargerWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(arg.ChargerProfile, []string{utils.ID}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -77,7 +77,7 @@ func (apierSv1 *APIerSv1) SetChargerProfile(arg *ChargerWithAPIOpts, reply *stri
 	if arg.Tenant == utils.EmptyString {
 		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.SetChargerProfile(arg.ChargerProfile, true); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1SetChargerProfile, arg.APIOpts).SetChargerProfile(arg.ChargerProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheChargerProfiles and store it in database
@@ -93,7 +93,7 @@ func (apierSv1 *APIerSv1) SetChargerProfile(arg *ChargerWithAPIOpts, reply *stri
 	return nil
 }
 
-//RemoveChargerProfile remove a specific Charger Profile
+// RemoveChargerProfile remove a specific Charger Profile
 func (apierSv1 *APIerSv1) RemoveChargerProfile(arg *utils.TenantIDWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -102,7 +102,7 @@ func (apierSv1 *APIerSv1) RemoveChargerProfile(arg *utils.TenantIDWithAPIOpts, r
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveChargerProfile(tnt,
+	if err := apierSv1.auditedDM(utils.APIerSv1RemoveChargerProfile, arg.APIOpts).RemoveChargerProfile(tnt,
 		arg.ID, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
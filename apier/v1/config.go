@@ -58,6 +58,26 @@ func (cSv1 *ConfigSv1) GetConfigAsJSON(args *config.SectionWithAPIOpts, reply *s
 	return cSv1.cfg.V1GetConfigAsJSON(args, reply)
 }
 
+// SetAPIAuthRole adds or replaces a role of the API authorization
+func (cSv1 *ConfigSv1) SetAPIAuthRole(args *config.APIAuthRoleWithAPIOpts, reply *string) (err error) {
+	return cSv1.cfg.V1SetAPIAuthRole(args, reply)
+}
+
+// RemoveAPIAuthRole removes a role of the API authorization
+func (cSv1 *ConfigSv1) RemoveAPIAuthRole(args *config.APIAuthIDWithAPIOpts, reply *string) (err error) {
+	return cSv1.cfg.V1RemoveAPIAuthRole(args, reply)
+}
+
+// SetAPIAuthUser adds or replaces an user of the API authorization
+func (cSv1 *ConfigSv1) SetAPIAuthUser(args *config.APIAuthUserWithAPIOpts, reply *string) (err error) {
+	return cSv1.cfg.V1SetAPIAuthUser(args, reply)
+}
+
+// RemoveAPIAuthUser removes an user of the API authorization
+func (cSv1 *ConfigSv1) RemoveAPIAuthUser(args *config.APIAuthIDWithAPIOpts, reply *string) (err error) {
+	return cSv1.cfg.V1RemoveAPIAuthUser(args, reply)
+}
+
 // Call implements rpcclient.ClientConnector interface for internal RPC
 func (cSv1 *ConfigSv1) Call(serviceMethod string,
 	args interface{}, reply interface{}) error {
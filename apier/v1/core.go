@@ -23,6 +23,7 @@ import (
 	"time"
 
 	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -45,6 +46,11 @@ func (cS *CoreSv1) Status(arg *utils.TenantWithAPIOpts, reply *map[string]interf
 	return cS.cS.Status(arg, reply)
 }
 
+// GetTenantQuotas returns the usage of the quotas of the tenant
+func (cS *CoreSv1) GetTenantQuotas(arg *utils.TenantWithAPIOpts, reply *engine.TenantQuotaStatus) error {
+	return cS.cS.GetTenantQuotas(arg, reply)
+}
+
 // Ping used to determinate if component is active
 func (cS *CoreSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
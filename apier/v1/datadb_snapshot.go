@@ -0,0 +1,79 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"fmt"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AttrDataDBSnapshot are the arguments of the DataDB snapshot APIs
+type AttrDataDBSnapshot struct {
+	Path    string   // the archive file on the engine host
+	Tenants []string // only the items of these tenants, all if empty
+	Flush   bool     // flush the DataDB before restoring
+	APIOpts map[string]interface{}
+}
+
+// ExportDataDBSnapshot writes a snapshot of the DataDB into the archive at Path
+func (apierSv1 *APIerSv1) ExportDataDBSnapshot(args *AttrDataDBSnapshot, reply *engine.DataDBSnapshotInfo) (err error) {
+	if len(args.Path) == 0 {
+		return fmt.Errorf("%s:%s", utils.ErrMandatoryIeMissing.Error(), "Path")
+	}
+	var info *engine.DataDBSnapshotInfo
+	if info, err = engine.ExportDataDBSnapshotToFile(apierSv1.DataManager.DataDB(),
+		args.Path, args.Tenants); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	*reply = *info
+	return
+}
+
+// RestoreDataDBSnapshot restores the archive at Path into the DataDB, clearing the
+// caches and reloading the scheduler afterwards
+func (apierSv1 *APIerSv1) RestoreDataDBSnapshot(args *AttrDataDBSnapshot, reply *engine.DataDBSnapshotInfo) (err error) {
+	if len(args.Path) == 0 {
+		return fmt.Errorf("%s:%s", utils.ErrMandatoryIeMissing.Error(), "Path")
+	}
+	var info *engine.DataDBSnapshotInfo
+	if info, err = engine.RestoreDataDBSnapshotFromFile(apierSv1.DataManager,
+		args.Path, args.Tenants, args.Flush); err != nil {
+		return utils.NewErrServerError(err)
+	}
+	var rply string
+	if len(apierSv1.Config.ApierCfg().CachesConns) != 0 {
+		if err = apierSv1.ConnMgr.Call(apierSv1.Config.ApierCfg().CachesConns, nil,
+			utils.CacheSv1Clear, &utils.AttrCacheIDsWithAPIOpts{
+				Tenant:  apierSv1.Config.GeneralCfg().DefaultTenant,
+				APIOpts: args.APIOpts,
+			}, &rply); err != nil {
+			return
+		}
+	}
+	if len(apierSv1.Config.ApierCfg().SchedulerConns) != 0 {
+		if err = apierSv1.ConnMgr.Call(apierSv1.Config.ApierCfg().SchedulerConns, nil,
+			utils.SchedulerSv1Reload, &utils.CGREvent{APIOpts: args.APIOpts}, &rply); err != nil {
+			return
+		}
+	}
+	*reply = *info
+	return
+}
@@ -73,7 +73,7 @@ type DispatcherWithAPIOpts struct {
 	APIOpts map[string]interface{}
 }
 
-//SetDispatcherProfile add/update a new Dispatcher Profile
+// SetDispatcherProfile add/update a new Dispatcher Profile
 func (apierSv1 *APIerSv1) SetDispatcherProfile(args *DispatcherWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(args.DispatcherProfile, []string{utils.ID, utils.Subsystems}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -81,7 +81,7 @@ func (apierSv1 *APIerSv1) SetDispatcherProfile(args *DispatcherWithAPIOpts, repl
 	if args.Tenant == utils.EmptyString {
 		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.SetDispatcherProfile(args.DispatcherProfile, true); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1SetDispatcherProfile, args.APIOpts).SetDispatcherProfile(args.DispatcherProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheDispatcherProfiles and store it in database
@@ -97,7 +97,7 @@ func (apierSv1 *APIerSv1) SetDispatcherProfile(args *DispatcherWithAPIOpts, repl
 	return nil
 }
 
-//RemoveDispatcherProfile remove a specific Dispatcher Profile
+// RemoveDispatcherProfile remove a specific Dispatcher Profile
 func (apierSv1 *APIerSv1) RemoveDispatcherProfile(arg *utils.TenantIDWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -106,7 +106,7 @@ func (apierSv1 *APIerSv1) RemoveDispatcherProfile(arg *utils.TenantIDWithAPIOpts
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveDispatcherProfile(tnt,
+	if err := apierSv1.auditedDM(utils.APIerSv1RemoveDispatcherProfile, arg.APIOpts).RemoveDispatcherProfile(tnt,
 		arg.ID, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
@@ -162,7 +162,7 @@ func (apierSv1 *APIerSv1) GetDispatcherHostIDs(tenantArg *utils.PaginatorWithTen
 	return nil
 }
 
-//SetDispatcherHost add/update a new Dispatcher Host
+// SetDispatcherHost add/update a new Dispatcher Host
 func (apierSv1 *APIerSv1) SetDispatcherHost(args *engine.DispatcherHostWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(args.DispatcherHost, []string{utils.ID}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -170,7 +170,7 @@ func (apierSv1 *APIerSv1) SetDispatcherHost(args *engine.DispatcherHostWithAPIOp
 	if args.Tenant == utils.EmptyString {
 		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.SetDispatcherHost(args.DispatcherHost); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1SetDispatcherHost, args.APIOpts).SetDispatcherHost(args.DispatcherHost); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheDispatcherHosts and store it in database
@@ -186,7 +186,7 @@ func (apierSv1 *APIerSv1) SetDispatcherHost(args *engine.DispatcherHostWithAPIOp
 	return nil
 }
 
-//RemoveDispatcherHost remove a specific Dispatcher Host
+// RemoveDispatcherHost remove a specific Dispatcher Host
 func (apierSv1 *APIerSv1) RemoveDispatcherHost(arg *utils.TenantIDWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -195,7 +195,7 @@ func (apierSv1 *APIerSv1) RemoveDispatcherHost(arg *utils.TenantIDWithAPIOpts, r
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveDispatcherHost(tnt,
+	if err := apierSv1.auditedDM(utils.APIerSv1RemoveDispatcherHost, arg.APIOpts).RemoveDispatcherHost(tnt,
 		arg.ID); err != nil {
 		return utils.APIErrorHandler(err)
 	}
@@ -248,6 +248,57 @@ func (dT *DispatcherThresholdSv1) GetThreshold(args *utils.TenantIDWithAPIOpts,
 	return dT.dS.ThresholdSv1GetThreshold(args, th)
 }
 
+// GetRaisedAlarms implements ThresholdSv1GetRaisedAlarms
+func (dT *DispatcherThresholdSv1) GetRaisedAlarms(args *utils.TenantWithAPIOpts,
+	alarms *[]*engine.ThresholdAlarm) error {
+	return dT.dS.ThresholdSv1GetRaisedAlarms(args, alarms)
+}
+
+func NewDispatcherFraudSv1(dps *dispatchers.DispatcherService) *DispatcherFraudSv1 {
+	return &DispatcherFraudSv1{dS: dps}
+}
+
+// DispatcherFraudSv1 exports RPC from FraudS
+type DispatcherFraudSv1 struct {
+	dS *dispatchers.DispatcherService
+}
+
+// Ping implements FraudSv1Ping
+func (dF *DispatcherFraudSv1) Ping(args *utils.CGREvent, reply *string) error {
+	return dF.dS.FraudSv1Ping(args, reply)
+}
+
+// AuthorizeEvent implements FraudSv1AuthorizeEvent
+func (dF *DispatcherFraudSv1) AuthorizeEvent(args *utils.CGREvent, reply *string) error {
+	return dF.dS.FraudSv1AuthorizeEvent(args, reply)
+}
+
+// AllocateCall implements FraudSv1AllocateCall
+func (dF *DispatcherFraudSv1) AllocateCall(args *utils.CGREvent, reply *string) error {
+	return dF.dS.FraudSv1AllocateCall(args, reply)
+}
+
+// ReleaseCall implements FraudSv1ReleaseCall
+func (dF *DispatcherFraudSv1) ReleaseCall(args *utils.CGREvent, reply *string) error {
+	return dF.dS.FraudSv1ReleaseCall(args, reply)
+}
+
+// ProcessEvent implements FraudSv1ProcessEvent
+func (dF *DispatcherFraudSv1) ProcessEvent(args *utils.CGREvent, reply *[]string) error {
+	return dF.dS.FraudSv1ProcessEvent(args, reply)
+}
+
+// GetBlockedAccounts implements FraudSv1GetBlockedAccounts
+func (dF *DispatcherFraudSv1) GetBlockedAccounts(args *utils.TenantWithAPIOpts,
+	reply *[]*engine.FraudBlock) error {
+	return dF.dS.FraudSv1GetBlockedAccounts(args, reply)
+}
+
+// UnblockAccount implements FraudSv1UnblockAccount
+func (dF *DispatcherFraudSv1) UnblockAccount(args *utils.TenantIDWithAPIOpts, reply *string) error {
+	return dF.dS.FraudSv1UnblockAccount(args, reply)
+}
+
 func NewDispatcherStatSv1(dps *dispatchers.DispatcherService) *DispatcherStatSv1 {
 	return &DispatcherStatSv1{dS: dps}
 }
@@ -278,6 +329,11 @@ func (dSts *DispatcherStatSv1) GetQueueFloatMetrics(args *utils.TenantIDWithAPIO
 	return dSts.dS.StatSv1GetQueueFloatMetrics(args, reply)
 }
 
+func (dSts *DispatcherStatSv1) GetQueueChildren(args *utils.TenantIDWithAPIOpts,
+	reply *[]string) error {
+	return dSts.dS.StatSv1GetQueueChildren(args, reply)
+}
+
 func (dSts *DispatcherStatSv1) GetQueueIDs(args *utils.TenantWithAPIOpts,
 	reply *[]string) error {
 	return dSts.dS.StatSv1GetQueueIDs(args, reply)
@@ -360,6 +416,11 @@ func (dRoute *DispatcherRouteSv1) GetRoutesList(args *utils.CGREvent, reply *[]s
 	return dRoute.dRoute.RouteSv1GetRoutesList(args, reply)
 }
 
+// ProcessFeedback implements RouteSv1ProcessFeedback
+func (dRoute *DispatcherRouteSv1) ProcessFeedback(args *utils.CGREvent, reply *string) error {
+	return dRoute.dRoute.RouteSv1ProcessFeedback(args, reply)
+}
+
 func NewDispatcherAttributeSv1(dps *dispatchers.DispatcherService) *DispatcherAttributeSv1 {
 	return &DispatcherAttributeSv1{dA: dps}
 }
@@ -766,6 +827,21 @@ func (dS *DispatcherSv1) RemoteSleep(args *utils.DurationArgs, reply *string) (e
 	return dS.dS.DispatcherSv1RemoteSleep(args, reply)
 }
 
+// GetHostsHealth returns the health of the DispatcherHosts
+func (dS *DispatcherSv1) GetHostsHealth(args *utils.TenantWithAPIOpts, reply *[]*dispatchers.HostHealth) (err error) {
+	return dS.dS.DispatcherSv1GetHostsHealth(args, reply)
+}
+
+// DrainHost stops dispatching to the DispatcherHost, e.g. for maintenance
+func (dS *DispatcherSv1) DrainHost(args *utils.TenantIDWithAPIOpts, reply *string) (err error) {
+	return dS.dS.DispatcherSv1DrainHost(args, reply)
+}
+
+// ResumeHost restarts dispatching to a drained DispatcherHost
+func (dS *DispatcherSv1) ResumeHost(args *utils.TenantIDWithAPIOpts, reply *string) (err error) {
+	return dS.dS.DispatcherSv1ResumeHost(args, reply)
+}
+
 /*
 func (dSv1 DispatcherSv1) Apier(args *utils.MethodParameters, reply *interface{}) (err error) {
 	return dSv1.dS.V1Apier(new(APIerSv1), args, reply)
@@ -870,6 +946,22 @@ func (dS *DispatcherConfigSv1) GetConfigAsJSON(args *config.SectionWithAPIOpts,
 	return dS.dS.ConfigSv1GetConfigAsJSON(args, reply)
 }
 
+func (dS *DispatcherConfigSv1) SetAPIAuthRole(args *config.APIAuthRoleWithAPIOpts, reply *string) (err error) {
+	return dS.dS.ConfigSv1SetAPIAuthRole(args, reply)
+}
+
+func (dS *DispatcherConfigSv1) RemoveAPIAuthRole(args *config.APIAuthIDWithAPIOpts, reply *string) (err error) {
+	return dS.dS.ConfigSv1RemoveAPIAuthRole(args, reply)
+}
+
+func (dS *DispatcherConfigSv1) SetAPIAuthUser(args *config.APIAuthUserWithAPIOpts, reply *string) (err error) {
+	return dS.dS.ConfigSv1SetAPIAuthUser(args, reply)
+}
+
+func (dS *DispatcherConfigSv1) RemoveAPIAuthUser(args *config.APIAuthIDWithAPIOpts, reply *string) (err error) {
+	return dS.dS.ConfigSv1RemoveAPIAuthUser(args, reply)
+}
+
 func NewDispatcherRALsV1(dps *dispatchers.DispatcherService) *DispatcherRALsV1 {
 	return &DispatcherRALsV1{dS: dps}
 }
@@ -25,7 +25,7 @@ import (
 	"github.com/cgrates/cgrates/utils"
 )
 
-//SetFilter add a new Filter
+// SetFilter add a new Filter
 func (apierSv1 *APIerSv1) SetFilter(arg *engine.FilterWithAPIOpts, reply *string) (err error) {
 	if missing := utils.MissingStructFields(arg.Filter, []string{utils.ID}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -42,7 +42,7 @@ func (apierSv1 *APIerSv1) SetFilter(arg *engine.FilterWithAPIOpts, reply *string
 	} else if argC, err = composeCacheArgsForFilter(apierSv1.DataManager, fltr, fltr.Tenant, tntID, argC); err != nil {
 		return utils.APIErrorHandler(err)
 	}
-	if err := apierSv1.DataManager.SetFilter(arg.Filter, true); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1SetFilter, arg.APIOpts).SetFilter(arg.Filter, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 
@@ -103,7 +103,35 @@ func (apierSv1 *APIerSv1) GetFilterIDs(args *utils.PaginatorWithTenant, fltrIDs
 	return nil
 }
 
-//RemoveFilter  remove a specific filter
+// ExplainFilters returns the evaluation details of each filter rule for the event
+func (apierSv1 *APIerSv1) ExplainFilters(args *engine.ArgsExplainFilters, reply *engine.FiltersExplain) (err error) {
+	if args.CGREvent == nil {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	if len(args.FilterIDs) == 0 {
+		return utils.NewErrMandatoryIeMissing(utils.FilterIDs)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
+	}
+	evNm := utils.MapStorage{
+		utils.MetaReq:  args.Event,
+		utils.MetaOpts: args.APIOpts,
+	}
+	var fltrsExpl []*engine.FilterExplain
+	var pass bool
+	if fltrsExpl, pass, err = apierSv1.FilterS.PassWithExplain(tnt, args.FilterIDs, evNm); err != nil {
+		return utils.APIErrorHandler(err)
+	}
+	*reply = engine.FiltersExplain{
+		Pass:    pass,
+		Filters: fltrsExpl,
+	}
+	return
+}
+
+// RemoveFilter  remove a specific filter
 func (apierSv1 *APIerSv1) RemoveFilter(arg *utils.TenantIDWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -112,7 +140,7 @@ func (apierSv1 *APIerSv1) RemoveFilter(arg *utils.TenantIDWithAPIOpts, reply *st
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveFilter(tnt, arg.ID, true); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1RemoveFilter, arg.APIOpts).RemoveFilter(tnt, arg.ID, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheFilters and store it in database
@@ -419,3 +419,64 @@ func TestFiltersSetFilterClearCache(t *testing.T) {
 	dm.DataDB().Flush(utils.EmptyString)
 	engine.Cache.Clear(nil)
 }
+
+func TestFiltersExplainFilters(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dataDB := engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	engine.Cache.Clear(nil)
+	apierSv1 := &APIerSv1{
+		Config:      cfg,
+		DataManager: dm,
+		FilterS:     engine.NewFilterS(cfg, nil, dm),
+	}
+	args := &engine.ArgsExplainFilters{
+		FilterIDs: []string{"*string:~*req.Subject:~*req.Account", "*gte:~*req.Usage:1m"},
+		CGREvent: &utils.CGREvent{
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+				utils.Subject:      "1001",
+				utils.Usage:        "30s",
+			},
+		},
+	}
+	exp := engine.FiltersExplain{
+		Filters: []*engine.FilterExplain{
+			{
+				FilterID: "*string:~*req.Subject:~*req.Account",
+				Active:   true,
+				Pass:     true,
+				Rules: []*engine.FilterRuleExplain{{
+					Type:           utils.MetaString,
+					Element:        "~*req.Subject",
+					Values:         []string{"~*req.Account"},
+					ElementValue:   "1001",
+					ResolvedValues: []string{"1001"},
+					Pass:           true,
+				}},
+			},
+			{
+				FilterID: "*gte:~*req.Usage:1m",
+				Active:   true,
+				Rules: []*engine.FilterRuleExplain{{
+					Type:         utils.MetaGreaterOrEqual,
+					Element:      "~*req.Usage",
+					Values:       []string{"1m"},
+					ElementValue: "30s",
+				}},
+			},
+		},
+	}
+	var rply engine.FiltersExplain
+	if err := apierSv1.ExplainFilters(args, &rply); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+
+	args.FilterIDs = nil
+	if err := apierSv1.ExplainFilters(args, &rply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing(utils.FilterIDs).Error() {
+		t.Errorf("expected %s, received: %v", utils.NewErrMandatoryIeMissing(utils.FilterIDs), err)
+	}
+}
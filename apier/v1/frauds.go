@@ -0,0 +1,75 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package v1
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewFraudSv1 initializes FraudSv1
+func NewFraudSv1(fS *engine.FraudService) *FraudSv1 {
+	return &FraudSv1{fS: fS}
+}
+
+// FraudSv1 exports RPC from FraudS
+type FraudSv1 struct {
+	fS *engine.FraudService
+}
+
+// Call implements rpcclient.ClientConnector interface for internal RPC
+func (fSv1 *FraudSv1) Call(serviceMethod string, args interface{}, reply interface{}) error {
+	return utils.APIerRPCCall(fSv1, serviceMethod, args, reply)
+}
+
+// AuthorizeEvent checks if the account of the event is allowed to start a new call
+func (fSv1 *FraudSv1) AuthorizeEvent(args *utils.CGREvent, reply *string) error {
+	return fSv1.fS.V1AuthorizeEvent(args, reply)
+}
+
+// AllocateCall starts tracking a call of the account
+func (fSv1 *FraudSv1) AllocateCall(args *utils.CGREvent, reply *string) error {
+	return fSv1.fS.V1AllocateCall(args, reply)
+}
+
+// ReleaseCall stops tracking a call of the account
+func (fSv1 *FraudSv1) ReleaseCall(args *utils.CGREvent, reply *string) error {
+	return fSv1.fS.V1ReleaseCall(args, reply)
+}
+
+// ProcessEvent records the cost of a finished call, returning the IDs of the triggered rules
+func (fSv1 *FraudSv1) ProcessEvent(args *utils.CGREvent, reply *[]string) error {
+	return fSv1.fS.V1ProcessEvent(args, reply)
+}
+
+// GetBlockedAccounts returns the accounts currently blocked for a tenant
+func (fSv1 *FraudSv1) GetBlockedAccounts(args *utils.TenantWithAPIOpts, reply *[]*engine.FraudBlock) error {
+	return fSv1.fS.V1GetBlockedAccounts(args.Tenant, reply)
+}
+
+// UnblockAccount removes the block of an account
+func (fSv1 *FraudSv1) UnblockAccount(args *utils.TenantIDWithAPIOpts, reply *string) error {
+	return fSv1.fS.V1UnblockAccount(args.TenantID, reply)
+}
+
+// Ping .
+func (fSv1 *FraudSv1) Ping(ign *utils.CGREvent, reply *string) error {
+	*reply = utils.Pong
+	return nil
+}
@@ -339,3 +339,9 @@ func callCacheForFilter(connMgr *engine.ConnManager, cacheConns []string, cacheo
 	}
 	return connMgr.Call(cacheConns, nil, method, args, &reply)
 }
+
+// auditedDM returns the DataManager recording into the audit log the changes done via the API method,
+// on behalf of the API user from opts
+func (apierSv1 *APIerSv1) auditedDM(method string, opts map[string]interface{}) *engine.DataManager {
+	return apierSv1.DataManager.WithAudit(utils.IfaceAsString(opts[utils.OptsAPIUser]), method)
+}
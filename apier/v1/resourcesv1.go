@@ -107,7 +107,7 @@ func (apierSv1 *APIerSv1) GetResourceProfileIDs(args *utils.PaginatorWithTenant,
 	return nil
 }
 
-//SetResourceProfile adds a new resource configuration
+// SetResourceProfile adds a new resource configuration
 func (apierSv1 *APIerSv1) SetResourceProfile(arg *engine.ResourceProfileWithAPIOpts, reply *string) (err error) {
 	if missing := utils.MissingStructFields(arg.ResourceProfile, []string{utils.ID}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -115,7 +115,7 @@ func (apierSv1 *APIerSv1) SetResourceProfile(arg *engine.ResourceProfileWithAPIO
 	if arg.Tenant == utils.EmptyString {
 		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err = apierSv1.DataManager.SetResourceProfile(arg.ResourceProfile, true); err != nil {
+	if err = apierSv1.auditedDM(utils.APIerSv1SetResourceProfile, arg.APIOpts).SetResourceProfile(arg.ResourceProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheResourceProfiles and CacheResources and store it in database
@@ -135,7 +135,7 @@ func (apierSv1 *APIerSv1) SetResourceProfile(arg *engine.ResourceProfileWithAPIO
 	return nil
 }
 
-//RemoveResourceProfile remove a specific resource configuration
+// RemoveResourceProfile remove a specific resource configuration
 func (apierSv1 *APIerSv1) RemoveResourceProfile(arg *utils.TenantIDWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -144,7 +144,7 @@ func (apierSv1 *APIerSv1) RemoveResourceProfile(arg *utils.TenantIDWithAPIOpts,
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveResourceProfile(tnt, arg.ID, true); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1RemoveResourceProfile, arg.APIOpts).RemoveResourceProfile(tnt, arg.ID, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//handle caching for ResourceProfile
@@ -69,7 +69,7 @@ type RouteWithAPIOpts struct {
 	APIOpts map[string]interface{}
 }
 
-//SetRouteProfile add a new Route configuration
+// SetRouteProfile add a new Route configuration
 func (apierSv1 *APIerSv1) SetRouteProfile(args *RouteWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(args.RouteProfile, []string{utils.ID}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -77,7 +77,7 @@ func (apierSv1 *APIerSv1) SetRouteProfile(args *RouteWithAPIOpts, reply *string)
 	if args.Tenant == utils.EmptyString {
 		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.SetRouteProfile(args.RouteProfile, true); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1SetRouteProfile, args.APIOpts).SetRouteProfile(args.RouteProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheRouteProfiles and store it in database
@@ -93,7 +93,7 @@ func (apierSv1 *APIerSv1) SetRouteProfile(args *RouteWithAPIOpts, reply *string)
 	return nil
 }
 
-//RemoveRouteProfile remove a specific Route configuration
+// RemoveRouteProfile remove a specific Route configuration
 func (apierSv1 *APIerSv1) RemoveRouteProfile(args *utils.TenantIDWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 { //Params missing
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -102,7 +102,7 @@ func (apierSv1 *APIerSv1) RemoveRouteProfile(args *utils.TenantIDWithAPIOpts, re
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveRouteProfile(tnt, args.ID, true); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1RemoveRouteProfile, args.APIOpts).RemoveRouteProfile(tnt, args.ID, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheRouteProfiles and store it in database
@@ -151,3 +151,8 @@ func (rS *RouteSv1) Ping(ign *utils.CGREvent, reply *string) error {
 func (rS *RouteSv1) GetRoutesList(args *utils.CGREvent, reply *[]string) error {
 	return rS.rS.V1GetRoutesList(args, reply)
 }
+
+// ProcessFeedback feeds the circuit breaker of a route with the outcome of a call
+func (rS *RouteSv1) ProcessFeedback(args *utils.CGREvent, reply *string) error {
+	return rS.rS.V1ProcessFeedback(args, reply)
+}
@@ -73,7 +73,7 @@ func (apierSv1 *APIerSv1) SetStatQueueProfile(arg *engine.StatQueueProfileWithAP
 	if arg.Tenant == utils.EmptyString {
 		arg.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err = apierSv1.DataManager.SetStatQueueProfile(arg.StatQueueProfile, true); err != nil {
+	if err = apierSv1.auditedDM(utils.APIerSv1SetStatQueueProfile, arg.APIOpts).SetStatQueueProfile(arg.StatQueueProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheStatQueueProfiles and CacheStatQueues and store it in database
@@ -100,7 +100,7 @@ func (apierSv1 *APIerSv1) RemoveStatQueueProfile(args *utils.TenantIDWithAPIOpts
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveStatQueueProfile(tnt, args.ID, true); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1RemoveStatQueueProfile, args.APIOpts).RemoveStatQueueProfile(tnt, args.ID, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//handle caching for StatQueueProfile
@@ -168,6 +168,11 @@ func (stsv1 *StatSv1) ResetStatQueue(tntID *utils.TenantIDWithAPIOpts, reply *st
 	return stsv1.sS.V1ResetStatQueue(tntID.TenantID, reply)
 }
 
+// GetQueueChildren returns the IDs of the child queues created out of a StatQueueProfile
+func (stsv1 *StatSv1) GetQueueChildren(args *utils.TenantIDWithAPIOpts, reply *[]string) error {
+	return stsv1.sS.V1GetQueueChildren(args.TenantID, reply)
+}
+
 // Ping .
 func (stsv1 *StatSv1) Ping(ign *utils.CGREvent, reply *string) error {
 	*reply = utils.Pong
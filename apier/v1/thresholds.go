@@ -65,6 +65,11 @@ func (tSv1 *ThresholdSv1) ResetThreshold(tntID *utils.TenantIDWithAPIOpts, reply
 	return tSv1.tS.V1ResetThreshold(tntID.TenantID, reply)
 }
 
+// GetRaisedAlarms returns the alarms currently raised for a tenant
+func (tSv1 *ThresholdSv1) GetRaisedAlarms(tenant *utils.TenantWithAPIOpts, reply *[]*engine.ThresholdAlarm) error {
+	return tSv1.tS.V1GetRaisedAlarms(tenant.Tenant, reply)
+}
+
 // GetThresholdProfile returns a Threshold Profile
 func (apierSv1 *APIerSv1) GetThresholdProfile(arg *utils.TenantID, reply *engine.ThresholdProfile) (err error) {
 	if missing := utils.MissingStructFields(arg, []string{utils.ID}); len(missing) != 0 { //Params missing
@@ -131,7 +136,7 @@ func (apierSv1 *APIerSv1) SetThresholdProfile(args *engine.ThresholdProfileWithA
 	if args.Tenant == utils.EmptyString {
 		args.Tenant = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.SetThresholdProfile(args.ThresholdProfile, true); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1SetThresholdProfile, args.APIOpts).SetThresholdProfile(args.ThresholdProfile, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheThresholdProfiles and CacheThresholds and store it in database
@@ -158,7 +163,7 @@ func (apierSv1 *APIerSv1) RemoveThresholdProfile(args *utils.TenantIDWithAPIOpts
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveThresholdProfile(tnt, args.ID, true); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1RemoveThresholdProfile, args.APIOpts).RemoveThresholdProfile(tnt, args.ID, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//handle caching for ThresholdProfile
@@ -44,7 +44,7 @@ func (apierSv1 *APIerSv1) SetTiming(args *utils.TPTimingWithAPIOpts, reply *stri
 		return utils.NewErrMandatoryIeMissing(missing...)
 	}
 
-	if err := apierSv1.DataManager.SetTiming(args.TPTiming); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1SetTiming, args.APIOpts).SetTiming(args.TPTiming); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheTimings and store it in database
@@ -71,7 +71,7 @@ func (apierSv1 *APIerSv1) RemoveTiming(args *utils.TPTimingWithAPIOpts, reply *s
 	if tnt == utils.EmptyString {
 		tnt = apierSv1.Config.GeneralCfg().DefaultTenant
 	}
-	if err := apierSv1.DataManager.RemoveTiming(args.ID, utils.NonTransactional); err != nil {
+	if err := apierSv1.auditedDM(utils.APIerSv1RemoveTiming, args.APIOpts).RemoveTiming(args.ID, utils.NonTransactional); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//handle caching for Timings
@@ -57,6 +57,7 @@ type AttrAddAccountActionTriggers struct {
 	ActionTriggerOverwrite bool
 	ActivationDate         string
 	Executed               bool
+	APIOpts                map[string]interface{}
 }
 
 func (apierSv1 *APIerSv1) AddAccountActionTriggers(attr *AttrAddAccountActionTriggers, reply *string) (err error) {
@@ -102,7 +103,7 @@ func (apierSv1 *APIerSv1) AddAccountActionTriggers(attr *AttrAddAccountActionTri
 			}
 		}
 		account.InitCounters()
-		return apierSv1.DataManager.SetAccount(account)
+		return apierSv1.auditedDM(utils.APIerSv1AddAccountActionTriggers, attr.APIOpts).SetAccount(account)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID)
 	if err != nil {
 		return
@@ -116,6 +117,7 @@ type AttrRemoveAccountActionTriggers struct {
 	Account  string
 	GroupID  string
 	UniqueID string
+	APIOpts  map[string]interface{}
 }
 
 func (apierSv1 *APIerSv1) RemoveAccountActionTriggers(attr *AttrRemoveAccountActionTriggers, reply *string) error {
@@ -145,7 +147,7 @@ func (apierSv1 *APIerSv1) RemoveAccountActionTriggers(attr *AttrRemoveAccountAct
 		}
 		account.ActionTriggers = newActionTriggers
 		account.InitCounters()
-		return apierSv1.DataManager.SetAccount(account)
+		return apierSv1.auditedDM(utils.APIerSv1RemoveAccountActionTriggers, attr.APIOpts).SetAccount(account)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, accID)
 	if err != nil {
 		*reply = err.Error()
@@ -161,6 +163,7 @@ type AttrResetAccountActionTriggers struct {
 	GroupID  string
 	UniqueID string
 	Executed bool
+	APIOpts  map[string]interface{}
 }
 
 func (apierSv1 *APIerSv1) ResetAccountActionTriggers(attr *AttrResetAccountActionTriggers, reply *string) error {
@@ -190,7 +193,7 @@ func (apierSv1 *APIerSv1) ResetAccountActionTriggers(attr *AttrResetAccountActio
 		if attr.Executed == false {
 			account.ExecuteActionTriggers(nil, apierSv1.FilterS)
 		}
-		return apierSv1.DataManager.SetAccount(account)
+		return apierSv1.auditedDM(utils.APIerSv1ResetAccountActionTriggers, attr.APIOpts).SetAccount(account)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID)
 	if err != nil {
 		*reply = err.Error()
@@ -209,6 +212,7 @@ type AttrSetActionTrigger struct {
 	GroupID       string
 	UniqueID      string
 	ActionTrigger map[string]interface{}
+	APIOpts       map[string]interface{}
 }
 
 // UpdateActionTrigger updates the ActionTrigger if is matching
@@ -386,7 +390,7 @@ func (apierSv1 *APIerSv1) SetAccountActionTriggers(attr *AttrSetAccountActionTri
 			}
 		}
 		account.ExecuteActionTriggers(nil, apierSv1.FilterS)
-		return apierSv1.DataManager.SetAccount(account)
+		return apierSv1.auditedDM(utils.APIerSv1SetAccountActionTriggers, attr.APIOpts).SetAccount(account)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID)
 	if err != nil {
 		*reply = err.Error()
@@ -399,6 +403,7 @@ func (apierSv1 *APIerSv1) SetAccountActionTriggers(attr *AttrSetAccountActionTri
 type AttrRemoveActionTrigger struct {
 	GroupID  string
 	UniqueID string
+	APIOpts  map[string]interface{}
 }
 
 func (apierSv1 *APIerSv1) RemoveActionTrigger(attr *AttrRemoveActionTrigger, reply *string) (err error) {
@@ -406,7 +411,7 @@ func (apierSv1 *APIerSv1) RemoveActionTrigger(attr *AttrRemoveActionTrigger, rep
 		return utils.NewErrMandatoryIeMissing(missing...)
 	}
 	if attr.UniqueID == "" {
-		err = apierSv1.DataManager.RemoveActionTriggers(attr.GroupID, utils.NonTransactional)
+		err = apierSv1.auditedDM(utils.APIerSv1RemoveActionTrigger, attr.APIOpts).RemoveActionTriggers(attr.GroupID, utils.NonTransactional)
 		if err != nil {
 			return
 		}
@@ -424,7 +429,7 @@ func (apierSv1 *APIerSv1) RemoveActionTrigger(attr *AttrRemoveActionTrigger, rep
 		}
 	}
 	// set the cleared list back
-	if err = apierSv1.DataManager.SetActionTriggers(attr.GroupID, remainingAtrs); err != nil {
+	if err = apierSv1.auditedDM(utils.APIerSv1RemoveActionTrigger, attr.APIOpts).SetActionTriggers(attr.GroupID, remainingAtrs); err != nil {
 		return
 	}
 	// CacheReload
@@ -474,7 +479,7 @@ func (apierSv1 *APIerSv1) SetActionTrigger(attr *AttrSetActionTrigger, reply *st
 		return
 	}
 
-	if err = apierSv1.DataManager.SetActionTriggers(attr.GroupID, atrs); err != nil {
+	if err = apierSv1.auditedDM(utils.APIerSv1SetActionTrigger, attr.APIOpts).SetActionTriggers(attr.GroupID, atrs); err != nil {
 		return
 	}
 	// CacheReload
@@ -542,6 +547,7 @@ type AttrAddActionTrigger struct {
 	BalanceSharedGroup    string
 	Weight                float64
 	ActionsId             string
+	APIOpts               map[string]interface{}
 }
 
 // Deprecated in rc8, replaced by AddAccountActionTriggers
@@ -594,7 +600,7 @@ func (apierSv1 *APIerSv1) AddTriggeredAction(attr AttrAddActionTrigger, reply *s
 		}
 		acnt.ActionTriggers = append(acnt.ActionTriggers, at)
 
-		return apierSv1.DataManager.SetAccount(acnt)
+		return apierSv1.auditedDM(utils.APIerSv1AddTriggeredAction, attr.APIOpts).SetAccount(acnt)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+acntID)
 	if err != nil {
 		return err
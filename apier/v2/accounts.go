@@ -124,6 +124,7 @@ type AttrSetAccount struct {
 	ActionTriggerOverwrite bool
 	ExtraOptions           map[string]bool
 	ReloadScheduler        bool
+	APIOpts                map[string]interface{}
 }
 
 func (apiv2 *APIerSv2) SetAccount(attr *AttrSetAccount, reply *string) error {
@@ -207,12 +208,12 @@ func (apiv2 *APIerSv2) SetAccount(attr *AttrSetAccount, reply *string) error {
 			}
 			apIDs := make([]string, 0, len(dirtyActionPlans))
 			for actionPlanID, ap := range dirtyActionPlans {
-				if err := apiv2.DataManager.SetActionPlan(actionPlanID, ap, true, utils.NonTransactional); err != nil {
+				if err := apiv2.auditedDM(utils.APIerSv2SetAccount, attr.APIOpts).SetActionPlan(actionPlanID, ap, true, utils.NonTransactional); err != nil {
 					return err
 				}
 				apIDs = append(apIDs, actionPlanID)
 			}
-			if err := apiv2.DataManager.SetAccountActionPlans(accID, acntAPids, true); err != nil {
+			if err := apiv2.auditedDM(utils.APIerSv2SetAccount, attr.APIOpts).SetAccountActionPlans(accID, acntAPids, true); err != nil {
 				return err
 			}
 			return apiv2.ConnMgr.Call(apiv2.Config.ApierCfg().CachesConns, nil,
@@ -255,7 +256,7 @@ func (apiv2 *APIerSv2) SetAccount(attr *AttrSetAccount, reply *string) error {
 			ub.Disabled = dis
 		}
 		// All prepared, save account
-		return apiv2.DataManager.SetAccount(ub)
+		return apiv2.auditedDM(utils.APIerSv2SetAccount, attr.APIOpts).SetAccount(ub)
 	}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID)
 	if err != nil {
 		return utils.NewErrServerError(err)
@@ -44,9 +44,16 @@ func (apiv2 *APIerSv2) Call(serviceMethod string,
 	return utils.APIerRPCCall(apiv2, serviceMethod, args, reply)
 }
 
+// auditedDM returns the DataManager recording into the audit log the changes done via the API method,
+// on behalf of the API user from opts
+func (apiv2 *APIerSv2) auditedDM(method string, opts map[string]interface{}) *engine.DataManager {
+	return apiv2.DataManager.WithAudit(utils.IfaceAsString(opts[utils.OptsAPIUser]), method)
+}
+
 type AttrLoadRatingProfile struct {
 	TPid            string
 	RatingProfileID string
+	APIOpts         map[string]interface{}
 }
 
 // Process dependencies and load a specific rating profile from storDb into dataDb.
@@ -61,6 +68,7 @@ func (apiv2 *APIerSv2) LoadRatingProfile(attrs *AttrLoadRatingProfile, reply *st
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	dbReader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv2LoadRatingProfile)
 	if err := dbReader.LoadRatingProfilesFiltered(&utils.TPRatingProfile{TPid: attrs.TPid}); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -77,6 +85,7 @@ func (apiv2 *APIerSv2) LoadRatingProfile(attrs *AttrLoadRatingProfile, reply *st
 type AttrLoadAccountActions struct {
 	TPid             string
 	AccountActionsId string
+	APIOpts          map[string]interface{}
 }
 
 // Process dependencies and load a specific AccountActions profile from storDb into dataDb.
@@ -91,6 +100,7 @@ func (apiv2 *APIerSv2) LoadAccountActions(attrs *AttrLoadAccountActions, reply *
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	dbReader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv2LoadAccountActions)
 	tpAa := &utils.TPAccountActions{TPid: attrs.TPid}
 	tpAa.SetAccountActionsId(attrs.AccountActionsId)
 	if err := guardian.Guardian.Guard(func() error {
@@ -125,6 +135,7 @@ func (apiv2 *APIerSv2) LoadTariffPlanFromFolder(attrs *utils.AttrLoadTpFromFolde
 	if err != nil {
 		return utils.NewErrServerError(err)
 	}
+	loader.WithAudit(utils.IfaceAsString(attrs.APIOpts[utils.OptsAPIUser]), utils.APIerSv2LoadTariffPlanFromFolder)
 	if err := loader.LoadAll(); err != nil {
 		return utils.NewErrServerError(err)
 	}
@@ -352,7 +363,7 @@ func (apiv2 *APIerSv2) SetActions(attrs *utils.AttrSetActions, reply *string) er
 		}
 		storeActions[idx] = a
 	}
-	if err := apiv2.DataManager.SetActions(attrs.ActionsId, storeActions); err != nil {
+	if err := apiv2.auditedDM(utils.APIerSv2SetActions, attrs.APIOpts).SetActions(attrs.ActionsId, storeActions); err != nil {
 		return utils.NewErrServerError(err)
 	}
 	//CacheReload
@@ -30,7 +30,7 @@ type AttributeWithAPIOpts struct {
 	APIOpts map[string]interface{}
 }
 
-//SetAttributeProfile add/update a new Attribute Profile
+// SetAttributeProfile add/update a new Attribute Profile
 func (APIerSv2 *APIerSv2) SetAttributeProfile(arg *AttributeWithAPIOpts, reply *string) error {
 	if missing := utils.MissingStructFields(arg.APIAttributeProfile, []string{utils.ID}); len(missing) != 0 {
 		return utils.NewErrMandatoryIeMissing(missing...)
@@ -42,7 +42,7 @@ func (APIerSv2 *APIerSv2) SetAttributeProfile(arg *AttributeWithAPIOpts, reply *
 	if err != nil {
 		return utils.APIErrorHandler(err)
 	}
-	if err := APIerSv2.DataManager.SetAttributeProfile(alsPrf, true); err != nil {
+	if err := APIerSv2.auditedDM(utils.APIerSv2SetAttributeProfile, arg.APIOpts).SetAttributeProfile(alsPrf, true); err != nil {
 		return utils.APIErrorHandler(err)
 	}
 	//generate a loadID for CacheAttributeProfiles and store it in database
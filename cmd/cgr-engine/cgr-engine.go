@@ -144,7 +144,7 @@ func startRPC(server *cores.Server, internalRaterChan,
 	internalAttrSChan, internalChargerSChan, internalThdSChan, internalSuplSChan,
 	internalSMGChan, internalAnalyzerSChan, internalDispatcherSChan,
 	internalLoaderSChan, internalRALsv1Chan, internalCacheSChan,
-	internalEEsChan chan rpcclient.ClientConnector,
+	internalEEsChan, internalFraudSChan chan rpcclient.ClientConnector,
 	shdChan *utils.SyncedChan) {
 	if !cfg.DispatcherSCfg().Enabled {
 		select { // Any of the rpc methods will unlock listening to rpc requests
@@ -176,6 +176,8 @@ func startRPC(server *cores.Server, internalRaterChan,
 			internalCacheSChan <- chS
 		case eeS := <-internalEEsChan:
 			internalEEsChan <- eeS
+		case frdS := <-internalFraudSChan:
+			internalFraudSChan <- frdS
 		case <-shdChan.Done():
 			return
 		}
@@ -449,6 +451,7 @@ func main() {
 	internalSessionSChan := make(chan rpcclient.ClientConnector, 1)
 	internalChargerSChan := make(chan rpcclient.ClientConnector, 1)
 	internalThresholdSChan := make(chan rpcclient.ClientConnector, 1)
+	internalFraudSChan := make(chan rpcclient.ClientConnector, 1)
 	internalStatSChan := make(chan rpcclient.ClientConnector, 1)
 	internalResourceSChan := make(chan rpcclient.ClientConnector, 1)
 	internalRouteSChan := make(chan rpcclient.ClientConnector, 1)
@@ -478,6 +481,7 @@ func main() {
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats):          internalStatSChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes):         internalRouteSChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds):     internalThresholdSChan,
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds):         internalFraudSChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaServiceManager): internalServeManagerChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaConfig):         internalConfigChan,
 		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCore):           internalCoreSv1Chan,
@@ -503,6 +507,7 @@ func main() {
 		utils.DNSAgent:        new(sync.WaitGroup),
 		utils.EEs:             new(sync.WaitGroup),
 		utils.ERs:             new(sync.WaitGroup),
+		utils.FraudS:          new(sync.WaitGroup),
 		utils.FreeSWITCHAgent: new(sync.WaitGroup),
 		utils.GlobalVarS:      new(sync.WaitGroup),
 		utils.HTTPAgent:       new(sync.WaitGroup),
@@ -520,6 +525,7 @@ func main() {
 		utils.StorDB:          new(sync.WaitGroup),
 		utils.ThresholdS:      new(sync.WaitGroup),
 		utils.AccountS:        new(sync.WaitGroup),
+		utils.WebSocketAgent:  new(sync.WaitGroup),
 	}
 	gvService := services.NewGlobalVarS(cfg, srvDep)
 	shdWg.Add(1)
@@ -592,6 +598,7 @@ func main() {
 	chrS := services.NewChargerService(cfg, dmService, cacheS, filterSChan, server,
 		internalChargerSChan, connManager, anz, srvDep)
 	tS := services.NewThresholdService(cfg, dmService, cacheS, filterSChan, server, internalThresholdSChan, anz, srvDep)
+	frdS := services.NewFraudService(cfg, dmService, filterSChan, server, internalFraudSChan, connManager, anz, srvDep)
 	stS := services.NewStatService(cfg, dmService, cacheS, filterSChan, server,
 		internalStatSChan, connManager, anz, srvDep)
 	reS := services.NewResourceService(cfg, dmService, cacheS, filterSChan, server,
@@ -619,16 +626,17 @@ func main() {
 	ldrs := services.NewLoaderService(cfg, dmService, filterSChan, server,
 		internalLoaderSChan, connManager, anz, srvDep)
 
-	srvManager.AddServices(gvService, attrS, chrS, tS, stS, reS, routeS, schS, rals,
+	srvManager.AddServices(gvService, attrS, chrS, tS, frdS, stS, reS, routeS, schS, rals,
 		apiSv1, apiSv2, cdrS, smg, coreS,
 		services.NewEventReaderService(cfg, filterSChan, shdChan, connManager, srvDep),
 		services.NewDNSAgent(cfg, filterSChan, shdChan, connManager, srvDep),
 		services.NewFreeswitchAgent(cfg, shdChan, connManager, srvDep),
-		services.NewKamailioAgent(cfg, shdChan, connManager, srvDep),
+		services.NewKamailioAgent(cfg, filterSChan, shdChan, connManager, srvDep),
 		services.NewAsteriskAgent(cfg, shdChan, connManager, srvDep),              // partial reload
 		services.NewRadiusAgent(cfg, filterSChan, shdChan, connManager, srvDep),   // partial reload
 		services.NewDiameterAgent(cfg, filterSChan, shdChan, connManager, srvDep), // partial reload
 		services.NewHTTPAgent(cfg, filterSChan, server, connManager, srvDep),      // no reload
+		services.NewWebSocketAgent(cfg, filterSChan, server, connManager, srvDep), // partial reload
 		ldrs, anz, dspS, dspH, dmService, storDBService,
 		services.NewEventExporterService(cfg, filterSChan,
 			connManager, server, internalEEsChan, anz, srvDep),
@@ -660,6 +668,7 @@ func main() {
 	engine.IntRPC.AddInternalRPCClient(utils.StatSv1, internalStatSChan)
 	engine.IntRPC.AddInternalRPCClient(utils.RouteSv1, internalRouteSChan)
 	engine.IntRPC.AddInternalRPCClient(utils.ThresholdSv1, internalThresholdSChan)
+	engine.IntRPC.AddInternalRPCClient(utils.FraudSv1, internalFraudSChan)
 	engine.IntRPC.AddInternalRPCClient(utils.ServiceManagerV1, internalServeManagerChan)
 	engine.IntRPC.AddInternalRPCClient(utils.ConfigSv1, internalConfigChan)
 	engine.IntRPC.AddInternalRPCClient(utils.CoreSv1, internalCoreSv1Chan)
@@ -679,7 +688,7 @@ func main() {
 		internalAttributeSChan, internalChargerSChan, internalThresholdSChan,
 		internalRouteSChan, internalSessionSChan, internalAnalyzerSChan,
 		internalDispatcherSChan, internalLoaderSChan, internalRALsChan,
-		internalCacheSChan, internalEEsChan, shdChan)
+		internalCacheSChan, internalEEsChan, internalFraudSChan, shdChan)
 
 	<-shdChan.Done()
 	shtdDone := make(chan struct{})
@@ -46,7 +46,13 @@ var (
 		"Configuration directory path.")
 
 	exec = cgrMigratorFlags.String(utils.ExecCgr, utils.EmptyString, "fire up automatic migration "+
-		"<*set_versions|*cost_details|*accounts|*actions|*action_triggers|*action_plans|*shared_groups|*filters|*stordb|*datadb>")
+		"<*set_versions|*cost_details|*accounts|*actions|*action_triggers|*action_plans|*shared_groups|*filters|*stordb|*datadb|*export_snapshot|*restore_snapshot>")
+	snapshotPath = cgrMigratorFlags.String(utils.SnapshotPathCgr, utils.EmptyString,
+		"the DataDB snapshot archive exported from the DataDB or restored into the output DataDB")
+	snapshotTenants = cgrMigratorFlags.String(utils.SnapshotTenantsCgr, utils.EmptyString,
+		"export or restore only the items of these tenants, separated by comma")
+	snapshotFlush = cgrMigratorFlags.Bool(utils.SnapshotFlushCgr, false,
+		"flush the output DataDB before restoring the snapshot")
 	version = cgrMigratorFlags.Bool(utils.VersionCgr, false, "prints the application version")
 
 	inDataDBType = cgrMigratorFlags.String(utils.DataDBTypeCgr, dfltCfg.DataDbCfg().Type,
@@ -386,10 +392,51 @@ func main() {
 	defer m.Close()
 	config.SetCgrConfig(mgrCfg)
 	if exec != nil && *exec != utils.EmptyString { // Run migrator
-		if err, migrstats := m.Migrate(strings.Split(*exec, utils.FieldsSep)); err != nil {
-			log.Fatal(err)
-		} else if *verbose {
-			log.Printf("Data migrated: %+v", migrstats)
+		var tenants []string
+		if *snapshotTenants != utils.EmptyString {
+			tenants = strings.Split(*snapshotTenants, utils.FieldsSep)
+		}
+		// the snapshot is exported before and restored after the migration tasks
+		var exportSnp, restoreSnp bool
+		tasks := make([]string, 0, len(strings.Split(*exec, utils.FieldsSep)))
+		for _, task := range strings.Split(*exec, utils.FieldsSep) {
+			switch task {
+			case utils.MetaExportSnapshot:
+				exportSnp = true
+			case utils.MetaRestoreSnapshot:
+				restoreSnp = true
+			default:
+				tasks = append(tasks, task)
+			}
+		}
+		if (exportSnp || restoreSnp) && *snapshotPath == utils.EmptyString {
+			log.Fatalf("the %s flag is mandatory for the snapshot tasks", utils.SnapshotPathCgr)
+		}
+		if exportSnp {
+			info, err := m.ExportDataDBSnapshot(*snapshotPath, tenants)
+			if err != nil {
+				log.Fatal(err)
+			}
+			log.Printf("DataDB snapshot exported to <%s>: %+v", *snapshotPath, info.Items)
+		}
+		if len(tasks) != 0 {
+			if err, migrstats := m.Migrate(tasks); err != nil {
+				log.Fatal(err)
+			} else if *verbose {
+				log.Printf("Data migrated: %+v", migrstats)
+			}
+		}
+		if restoreSnp {
+			info, err := m.RestoreDataDBSnapshot(*snapshotPath, tenants, *snapshotFlush)
+			if err != nil {
+				log.Fatal(err)
+			}
+			if info != nil {
+				log.Printf("DataDB snapshot restored from <%s>: %+v", *snapshotPath, info.Items)
+				if !info.IndexesHealth.Healthy() {
+					log.Printf("WARNING: unhealthy indexes after restore: %s", utils.ToJSON(info.IndexesHealth))
+				}
+			}
 		}
 	}
 
@@ -230,5 +230,20 @@ func TestFlags(t *testing.T) {
 	} else if !*verbose {
 		t.Errorf("Expected true received:%v ", *verbose)
 	}
+	if err := cgrMigratorFlags.Parse([]string{"-snapshot_path", "/tmp/datadb.snapshot.gz"}); err != nil {
+		t.Fatal(err)
+	} else if *snapshotPath != "/tmp/datadb.snapshot.gz" {
+		t.Errorf("Expected /tmp/datadb.snapshot.gz received:%v ", *snapshotPath)
+	}
+	if err := cgrMigratorFlags.Parse([]string{"-snapshot_tenants", "cgrates.org,itsyscom.com"}); err != nil {
+		t.Fatal(err)
+	} else if *snapshotTenants != "cgrates.org,itsyscom.com" {
+		t.Errorf("Expected cgrates.org,itsyscom.com received:%v ", *snapshotTenants)
+	}
+	if err := cgrMigratorFlags.Parse([]string{"-snapshot_flush", "true"}); err != nil {
+		t.Fatal(err)
+	} else if !*snapshotFlush {
+		t.Errorf("Expected true received:%v ", *snapshotFlush)
+	}
 
 }
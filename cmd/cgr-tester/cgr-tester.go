@@ -74,6 +74,10 @@ var (
 	usage        = cgrTesterFlags.String("usage", "1m", "The duration to use in call simulation.")
 	fPath        = cgrTesterFlags.String("file_path", "", "read requests from file with path")
 	reqSep       = cgrTesterFlags.String("req_separator", "\n\n", "separator for requests in file")
+	replaySrc    = cgrTesterFlags.String("replay", "", "replay the recorded requests from source <*analyzer|*file|*cdrs>")
+	replayPath   = cgrTesterFlags.String("replay_path", "", "path to the AnalyzerS index folder or to the exported JSON lines file")
+	replaySpeed  = cgrTesterFlags.Float64("replay_speed", 1, "speed multiplier applied to the recorded timing, 0 to replay without delay")
+	replayIgnore = cgrTesterFlags.String("replay_ignore", "", "comma separated reply fields ignored when comparing replies")
 
 	err error
 )
@@ -236,6 +240,17 @@ func main() {
 		}
 		return
 	}
+	if *replaySrc != "" {
+		rt, err := NewReplayTester(tstCfg, *replaySrc, *replayPath, *raterAddress, *tenant,
+			*replaySpeed, *parallel, parseReplayIgnore(*replayIgnore))
+		if err != nil {
+			log.Fatal(err)
+		}
+		if err := rt.Test(); err != nil {
+			log.Fatal(err)
+		}
+		return
+	}
 
 	var timeparsed time.Duration
 	var err error
@@ -0,0 +1,234 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package main
+
+import (
+	"fmt"
+	"log"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"os"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/analyzers"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// replayRequest is one recorded request together with the way to check its reply
+type replayRequest struct {
+	id        string
+	startTime time.Time
+	call      func(clnt *rpc.Client) error // replays the request returning the mismatch, if any
+}
+
+// NewReplayTester loads the requests to replay out of source:
+// *analyzer reads the AnalyzerS index from path, *file the JSON lines written by AnalyzerSv1.Export
+// and *cdrs the CDRs of the tenant out of the configured StorDB
+func NewReplayTester(cfg *config.CGRConfig, source, path, cgrAddr, tnt string,
+	speed float64, parallel int, ignore utils.StringSet) (rt *ReplayTester, err error) {
+	rt = &ReplayTester{
+		speed:    speed,
+		parallel: parallel,
+	}
+	switch source {
+	case utils.MetaAnalyzer, utils.MetaFile:
+		var recs []*analyzers.TrafficRecord
+		if recs, err = readTrafficRecords(source, path); err != nil {
+			return nil, err
+		}
+		rt.reqs = trafficReplayRequests(recs, ignore)
+	case utils.MetaCDRs:
+		if rt.reqs, err = storDBReplayRequests(cfg, tnt); err != nil {
+			return nil, err
+		}
+	default:
+		return nil, fmt.Errorf("unsupported replay source <%s>", source)
+	}
+	if rt.clnt, err = jsonrpc.Dial(utils.TCP, cgrAddr); err != nil {
+		return nil, fmt.Errorf("Could not connect to engine: %s", err.Error())
+	}
+	return
+}
+
+// ReplayTester sends recorded requests to an engine and compares the replies with the recorded ones
+type ReplayTester struct {
+	speed    float64 // 2 replays twice as fast as recorded, 0 sends without delay
+	parallel int
+	reqs     []*replayRequest
+	clnt     *rpc.Client
+}
+
+func readTrafficRecords(source, path string) (recs []*analyzers.TrafficRecord, err error) {
+	if source == utils.MetaAnalyzer {
+		return analyzers.ReadTrafficIndex(path)
+	}
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return
+	}
+	defer f.Close()
+	return analyzers.ReadTrafficRecords(f)
+}
+
+func trafficReplayRequests(recs []*analyzers.TrafficRecord, ignore utils.StringSet) (reqs []*replayRequest) {
+	reqs = make([]*replayRequest, len(recs))
+	for i, rec := range recs {
+		rec := rec
+		reqs[i] = &replayRequest{
+			id:        fmt.Sprintf("%s:%d", rec.RequestMethod, rec.RequestID),
+			startTime: rec.RequestStartTime,
+			call: func(clnt *rpc.Client) error {
+				var reply interface{}
+				err := clnt.Call(rec.RequestMethod, rec.RequestParams, &reply)
+				return rec.CompareReply(reply, err, ignore)
+			},
+		}
+	}
+	return
+}
+
+// storDBReplayRequests reads the *default run CDRs of the tenant out of the StorDB
+func storDBReplayRequests(cfg *config.CGRConfig, tnt string) (reqs []*replayRequest, err error) {
+	var storDB engine.StorDB
+	if storDB, err = engine.NewStorDBConn(cfg.StorDbCfg().Type,
+		cfg.StorDbCfg().Host, cfg.StorDbCfg().Port,
+		cfg.StorDbCfg().Name, cfg.StorDbCfg().User,
+		cfg.StorDbCfg().Password, cfg.GeneralCfg().DBDataEncoding,
+		cfg.StorDbCfg().StringIndexedFields, cfg.StorDbCfg().PrefixIndexedFields,
+		cfg.StorDbCfg().Opts, cfg.StorDbCfg().Items); err != nil {
+		return nil, fmt.Errorf("Could not connect to StorDB: %s", err.Error())
+	}
+	defer storDB.Close()
+	var cdrs []*engine.CDR
+	if cdrs, _, err = storDB.GetCDRs(&utils.CDRsFilter{
+		Tenants: []string{tnt},
+		RunIDs:  []string{utils.MetaDefault},
+	}, false); err != nil {
+		return
+	}
+	return cdrsReplayRequests(cdrs), nil
+}
+
+// cdrsReplayRequests re-rates the CDRs via CDRsV2.ProcessEvent and compares the new cost with the stored one.
+// The CDRs are rated as *rated so the replay does not debit the accounts again
+func cdrsReplayRequests(cdrs []*engine.CDR) (reqs []*replayRequest) {
+	flags := []string{utils.MetaChargers, utils.MetaRALs,
+		utils.ConcatenatedKey(utils.MetaStore, utils.FalseStr),
+		utils.ConcatenatedKey(utils.MetaExport, utils.FalseStr),
+		utils.ConcatenatedKey(utils.MetaThresholds, utils.FalseStr),
+		utils.ConcatenatedKey(utils.MetaStats, utils.FalseStr)}
+	reqs = make([]*replayRequest, len(cdrs))
+	for i, cdr := range cdrs {
+		cdr := cdr
+		startTime := cdr.AnswerTime
+		if startTime.IsZero() {
+			startTime = cdr.SetupTime
+		}
+		args := &engine.ArgV1ProcessEvent{
+			Flags:    flags,
+			CGREvent: *cdr.AsCGREvent(),
+		}
+		for _, fld := range []string{utils.RunID, utils.OrderID, utils.Cost,
+			utils.CostDetails, utils.CostSource, utils.ExtraInfo} { // computed again by the engine
+			delete(args.Event, fld)
+		}
+		args.Event[utils.RequestType] = utils.MetaRated
+		reqs[i] = &replayRequest{
+			id:        utils.ConcatenatedKey(cdr.CGRID, cdr.RunID),
+			startTime: startTime,
+			call: func(clnt *rpc.Client) (err error) {
+				var reply []*utils.EventWithFlags
+				if err = clnt.Call(utils.CDRsV2ProcessEvent, args, &reply); err != nil {
+					return
+				}
+				for _, ev := range reply {
+					if utils.IfaceAsString(ev.Event[utils.RunID]) != cdr.RunID {
+						continue
+					}
+					var cost float64
+					if cost, err = utils.IfaceAsFloat64(ev.Event[utils.Cost]); err != nil {
+						return
+					}
+					if cost != cdr.Cost {
+						return fmt.Errorf("expected cost <%v>, received <%v>", cdr.Cost, cost)
+					}
+					return
+				}
+				return fmt.Errorf("no event replied for run <%s>", cdr.RunID)
+			},
+		}
+	}
+	return
+}
+
+// Test replays the requests keeping their relative timing, divided by speed, logs the mismatches
+// and returns an error if any request mismatched
+func (rt *ReplayTester) Test() (err error) {
+	defer rt.clnt.Close()
+	sort.SliceStable(rt.reqs, func(i, j int) bool {
+		return rt.reqs[i].startTime.Before(rt.reqs[j].startTime)
+	})
+	var mismatched int
+	var mu sync.Mutex
+	var wg sync.WaitGroup
+	parallel := rt.parallel
+	if parallel < 1 {
+		parallel = 1
+	}
+	reqLimiter := make(chan struct{}, parallel)
+	start := time.Now()
+	for _, req := range rt.reqs {
+		if rt.speed > 0 {
+			offset := time.Duration(float64(req.startTime.Sub(rt.reqs[0].startTime)) / rt.speed)
+			time.Sleep(time.Until(start.Add(offset)))
+		}
+		reqLimiter <- struct{}{}
+		wg.Add(1)
+		go func(req *replayRequest) {
+			if err := req.call(rt.clnt); err != nil {
+				log.Printf("MISMATCH <%s>: %s", req.id, err.Error())
+				mu.Lock()
+				mismatched++
+				mu.Unlock()
+			}
+			<-reqLimiter
+			wg.Done()
+		}(req)
+	}
+	wg.Wait()
+	log.Printf("Replayed: %d, matched: %d, mismatched: %d, duration: %v",
+		len(rt.reqs), len(rt.reqs)-mismatched, mismatched, time.Since(start))
+	if mismatched != 0 {
+		return fmt.Errorf("%d of %d replayed requests mismatched", mismatched, len(rt.reqs))
+	}
+	return
+}
+
+// parseReplayIgnore returns the fields excluded from the replies comparison
+func parseReplayIgnore(flds string) utils.StringSet {
+	if flds == utils.EmptyString {
+		return nil
+	}
+	return utils.NewStringSet(strings.Split(flds, utils.FieldsSep))
+}
@@ -0,0 +1,169 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package main
+
+import (
+	"errors"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+type testReplayCDRs struct {
+	cost float64
+	args []*engine.ArgV1ProcessEvent
+}
+
+func (cdrS *testReplayCDRs) ProcessEvent(args *engine.ArgV1ProcessEvent, reply *[]*utils.EventWithFlags) error {
+	cdrS.args = append(cdrS.args, args)
+	ev := utils.CGREvent{Event: make(map[string]interface{})}
+	for k, v := range args.Event {
+		ev.Event[k] = v
+	}
+	ev.Event[utils.RunID] = utils.MetaDefault
+	ev.Event[utils.Cost] = cdrS.cost
+	*reply = []*utils.EventWithFlags{{Event: ev.Event}}
+	return nil
+}
+
+func newTestReplayClient(t *testing.T, rcv interface{}) *rpc.Client {
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("CDRsV2", rcv); err != nil {
+		t.Fatal(err)
+	}
+	srvConn, clntConn := net.Pipe()
+	go srv.ServeCodec(jsonrpc.NewServerCodec(srvConn))
+	return jsonrpc.NewClient(clntConn)
+}
+
+func TestCDRsReplayRequests(t *testing.T) {
+	cdrS := &testReplayCDRs{cost: 0.5}
+	clnt := newTestReplayClient(t, cdrS)
+	defer clnt.Close()
+	aTime := time.Date(2021, 6, 1, 10, 0, 0, 0, time.UTC)
+	cdrs := []*engine.CDR{
+		{
+			CGRID:       "cgrid1",
+			RunID:       utils.MetaDefault,
+			Tenant:      "cgrates.org",
+			RequestType: utils.MetaPostpaid,
+			Account:     "1001",
+			AnswerTime:  aTime,
+			Usage:       time.Minute,
+			Cost:        0.5,
+		},
+		{
+			CGRID:       "cgrid2",
+			RunID:       utils.MetaDefault,
+			Tenant:      "cgrates.org",
+			RequestType: utils.MetaPrepaid,
+			Account:     "1002",
+			SetupTime:   aTime.Add(time.Minute),
+			Usage:       time.Minute,
+			Cost:        0.7,
+		},
+	}
+	reqs := cdrsReplayRequests(cdrs)
+	if len(reqs) != 2 {
+		t.Fatalf("Expected 2 requests received: %d", len(reqs))
+	}
+	if reqs[0].id != utils.ConcatenatedKey("cgrid1", utils.MetaDefault) ||
+		!reqs[0].startTime.Equal(aTime) {
+		t.Errorf("Unexpected request: %s %v", reqs[0].id, reqs[0].startTime)
+	}
+	if !reqs[1].startTime.Equal(aTime.Add(time.Minute)) {
+		t.Errorf("Expected the SetupTime as start time, received: %v", reqs[1].startTime)
+	}
+	if err := reqs[0].call(clnt); err != nil {
+		t.Error(err)
+	}
+	if err := reqs[1].call(clnt); err == nil ||
+		err.Error() != "expected cost <0.7>, received <0.5>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for _, args := range cdrS.args {
+		if rqType := args.Event[utils.RequestType]; rqType != utils.MetaRated {
+			t.Errorf("Expected the CDR to be rated as %s, received: %v", utils.MetaRated, rqType)
+		}
+		if _, has := args.Event[utils.Cost]; has {
+			t.Errorf("Expected the Cost to be removed from: %s", utils.ToJSON(args.Event))
+		}
+	}
+}
+
+func TestReplayTesterTiming(t *testing.T) {
+	start := time.Now()
+	var mu sync.Mutex
+	sent := make(map[string]time.Duration)
+	newReq := func(id string, offset time.Duration, err error) *replayRequest {
+		return &replayRequest{
+			id:        id,
+			startTime: start.Add(offset),
+			call: func(*rpc.Client) error {
+				mu.Lock()
+				sent[id] = time.Since(start)
+				mu.Unlock()
+				return err
+			},
+		}
+	}
+	rt := &ReplayTester{
+		speed:    2,
+		parallel: 2,
+		reqs: []*replayRequest{ // unordered on purpose
+			newReq("3", 400*time.Millisecond, nil),
+			newReq("1", 0, nil),
+			newReq("2", 200*time.Millisecond, errors.New("mismatch")),
+		},
+		clnt: newTestReplayClient(t, new(testReplayCDRs)),
+	}
+	if err := rt.Test(); err == nil || err.Error() != "1 of 3 replayed requests mismatched" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	for id, exp := range map[string]time.Duration{
+		"1": 0,
+		"2": 100 * time.Millisecond,
+		"3": 200 * time.Millisecond,
+	} {
+		if sent[id] < exp || sent[id] > exp+50*time.Millisecond {
+			t.Errorf("Expected request %s sent after %v, received: %v", id, exp, sent[id])
+		}
+	}
+
+	sent = make(map[string]time.Duration)
+	start = time.Now()
+	rt.speed = 0
+	rt.reqs = []*replayRequest{
+		newReq("1", 0, nil),
+		newReq("2", time.Hour, nil),
+	}
+	rt.clnt = newTestReplayClient(t, new(testReplayCDRs))
+	if err := rt.Test(); err != nil {
+		t.Error(err)
+	}
+	if sent["2"] > 50*time.Millisecond {
+		t.Errorf("Expected request 2 sent without delay, received: %v", sent["2"])
+	}
+}
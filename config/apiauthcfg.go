@@ -0,0 +1,236 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// APIAuthCfg is the configuration of the role based API authorization
+type APIAuthCfg struct {
+	Enabled bool
+	Roles   map[string]*APIRoleCfg // indexed on role ID
+	Users   map[string]*APIUserCfg // indexed on user name
+}
+
+// APIRoleCfg lists the API methods and the tenants allowed for a role
+type APIRoleCfg struct {
+	Methods []string // <*any|Service.*|Service.Method>
+	Tenants []string // <*any|tenant>
+}
+
+// APIUserCfg holds the API key and the roles of an API user
+type APIUserCfg struct {
+	Key   string // sent by the clients within the *authKey API option
+	Roles []string
+}
+
+func (rl *APIRoleCfg) loadFromJSONCfg(jsnCfg *APIRoleJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Methods != nil {
+		rl.Methods = utils.CloneStringSlice(*jsnCfg.Methods)
+	}
+	if jsnCfg.Tenants != nil {
+		rl.Tenants = utils.CloneStringSlice(*jsnCfg.Tenants)
+	}
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (rl *APIRoleCfg) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.MethodsCfg: utils.CloneStringSlice(rl.Methods),
+		utils.TenantsCfg: utils.CloneStringSlice(rl.Tenants),
+	}
+}
+
+// Clone returns a deep copy of APIRoleCfg
+func (rl APIRoleCfg) Clone() *APIRoleCfg {
+	return &APIRoleCfg{
+		Methods: utils.CloneStringSlice(rl.Methods),
+		Tenants: utils.CloneStringSlice(rl.Tenants),
+	}
+}
+
+// allowsMethod checks if the method is allowed by the role
+func (rl *APIRoleCfg) allowsMethod(method string) bool {
+	for _, m := range rl.Methods {
+		if m == utils.MetaAny || m == method ||
+			(strings.HasSuffix(m, ".*") && strings.HasPrefix(method, m[:len(m)-1])) {
+			return true
+		}
+	}
+	return false
+}
+
+// allowsTenant checks if the tenant is allowed by the role
+// *any as tenant is allowed only by the roles not restricted to some tenants
+func (rl *APIRoleCfg) allowsTenant(tnt string) bool {
+	for _, t := range rl.Tenants {
+		if t == utils.MetaAny ||
+			(t == tnt && tnt != utils.MetaAny) {
+			return true
+		}
+	}
+	return false
+}
+
+func (usr *APIUserCfg) loadFromJSONCfg(jsnCfg *APIUserJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Key != nil {
+		usr.Key = *jsnCfg.Key
+	}
+	if jsnCfg.Roles != nil {
+		usr.Roles = utils.CloneStringSlice(*jsnCfg.Roles)
+	}
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+// the key is omitted so it is not exposed via the config APIs
+func (usr *APIUserCfg) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.RolesCfg: utils.CloneStringSlice(usr.Roles),
+	}
+}
+
+// Clone returns a deep copy of APIUserCfg
+func (usr APIUserCfg) Clone() *APIUserCfg {
+	return &APIUserCfg{
+		Key:   usr.Key,
+		Roles: utils.CloneStringSlice(usr.Roles),
+	}
+}
+
+func (aa *APIAuthCfg) loadFromJSONCfg(jsnCfg *APIAuthJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		aa.Enabled = *jsnCfg.Enabled
+	}
+	for id, jsnRl := range jsnCfg.Roles {
+		if _, has := aa.Roles[id]; !has {
+			aa.Roles[id] = new(APIRoleCfg)
+		}
+		aa.Roles[id].loadFromJSONCfg(jsnRl)
+	}
+	for name, jsnUsr := range jsnCfg.Users {
+		if _, has := aa.Users[name]; !has {
+			aa.Users[name] = new(APIUserCfg)
+		}
+		aa.Users[name].loadFromJSONCfg(jsnUsr)
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (aa *APIAuthCfg) AsMapInterface() map[string]interface{} {
+	roles := make(map[string]interface{}, len(aa.Roles))
+	for id, rl := range aa.Roles {
+		roles[id] = rl.AsMapInterface()
+	}
+	users := make(map[string]interface{}, len(aa.Users))
+	for name, usr := range aa.Users {
+		users[name] = usr.AsMapInterface()
+	}
+	return map[string]interface{}{
+		utils.EnabledCfg: aa.Enabled,
+		utils.RolesCfg:   roles,
+		utils.UsersCfg:   users,
+	}
+}
+
+// Clone returns a deep copy of APIAuthCfg
+func (aa APIAuthCfg) Clone() (cln *APIAuthCfg) {
+	cln = &APIAuthCfg{
+		Enabled: aa.Enabled,
+		Roles:   make(map[string]*APIRoleCfg, len(aa.Roles)),
+		Users:   make(map[string]*APIUserCfg, len(aa.Users)),
+	}
+	for id, rl := range aa.Roles {
+		cln.Roles[id] = rl.Clone()
+	}
+	for name, usr := range aa.Users {
+		cln.Users[name] = usr.Clone()
+	}
+	return
+}
+
+// UserByKey returns the name of the user owning the API key
+func (aa *APIAuthCfg) UserByKey(key string) (string, bool) {
+	if key == utils.EmptyString {
+		return utils.EmptyString, false
+	}
+	for name, usr := range aa.Users {
+		if usr.Key == key {
+			return name, true
+		}
+	}
+	return utils.EmptyString, false
+}
+
+// Authorize checks if one of the user roles allows both the method and the tenant
+// use *any as tenant for the requests not limited to one tenant
+func (aa *APIAuthCfg) Authorize(user, method, tnt string) bool {
+	usr, has := aa.Users[user]
+	if !has {
+		return false
+	}
+	for _, rlID := range usr.Roles {
+		if rl, has := aa.Roles[rlID]; has &&
+			rl.allowsMethod(method) && rl.allowsTenant(tnt) {
+			return true
+		}
+	}
+	return false
+}
+
+// checkSanity validates the roles and the users
+func (aa *APIAuthCfg) checkSanity() error {
+	for id, rl := range aa.Roles {
+		if len(rl.Methods) == 0 {
+			return fmt.Errorf("<%s> no %s defined for role <%s>", APIAuthJson, utils.MethodsCfg, id)
+		}
+		if len(rl.Tenants) == 0 {
+			return fmt.Errorf("<%s> no %s defined for role <%s>", APIAuthJson, utils.TenantsCfg, id)
+		}
+	}
+	keys := make(utils.StringSet)
+	for name, usr := range aa.Users {
+		for _, rlID := range usr.Roles {
+			if _, has := aa.Roles[rlID]; !has {
+				return fmt.Errorf("<%s> unknown role <%s> for user <%s>", APIAuthJson, rlID, name)
+			}
+		}
+		if usr.Key == utils.EmptyString {
+			continue
+		}
+		if keys.Has(usr.Key) {
+			return fmt.Errorf("<%s> duplicated %s for user <%s>", APIAuthJson, utils.KeyCfg, name)
+		}
+		keys.Add(usr.Key)
+	}
+	return nil
+}
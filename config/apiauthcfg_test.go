@@ -0,0 +1,256 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAPIAuthCfgloadFromJsonCfg(t *testing.T) {
+	cfgJSONStr := `{
+		"api_auth": {
+			"enabled": true,
+			"roles": {
+				"cdrs_reader": {
+					"methods": ["CDRsV1.GetCDRs", "CDRsV1.GetCDRsCount"],
+					"tenants": ["cgrates.org"],
+				},
+				"admin": {
+					"methods": ["*any"],
+					"tenants": ["*any"],
+				},
+			},
+			"users": {
+				"reader": {
+					"key": "r34d3r",
+					"roles": ["cdrs_reader"],
+				},
+			},
+		},
+}`
+	expected := &APIAuthCfg{
+		Enabled: true,
+		Roles: map[string]*APIRoleCfg{
+			"cdrs_reader": {
+				Methods: []string{utils.CDRsV1GetCDRs, utils.CDRsV1GetCDRsCount},
+				Tenants: []string{"cgrates.org"},
+			},
+			"admin": {
+				Methods: []string{utils.MetaAny},
+				Tenants: []string{utils.MetaAny},
+			},
+		},
+		Users: map[string]*APIUserCfg{
+			"reader": {
+				Key:   "r34d3r",
+				Roles: []string{"cdrs_reader"},
+			},
+		},
+	}
+	aa := &APIAuthCfg{Roles: make(map[string]*APIRoleCfg), Users: make(map[string]*APIUserCfg)}
+	if err := aa.loadFromJSONCfg(nil); err != nil {
+		t.Error(err)
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if jsnAA, err := jsnCfg.APIAuthJsonCfg(); err != nil {
+		t.Error(err)
+	} else if err = aa.loadFromJSONCfg(jsnAA); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, aa) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(expected), utils.ToJSON(aa))
+	}
+
+	eMap := map[string]interface{}{
+		utils.EnabledCfg: true,
+		utils.RolesCfg: map[string]interface{}{
+			"cdrs_reader": map[string]interface{}{
+				utils.MethodsCfg: []string{utils.CDRsV1GetCDRs, utils.CDRsV1GetCDRsCount},
+				utils.TenantsCfg: []string{"cgrates.org"},
+			},
+			"admin": map[string]interface{}{
+				utils.MethodsCfg: []string{utils.MetaAny},
+				utils.TenantsCfg: []string{utils.MetaAny},
+			},
+		},
+		utils.UsersCfg: map[string]interface{}{
+			"reader": map[string]interface{}{
+				utils.RolesCfg: []string{"cdrs_reader"},
+			},
+		},
+	}
+	if rcv := aa.AsMapInterface(); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+
+	cln := aa.Clone()
+	if !reflect.DeepEqual(aa, cln) {
+		t.Errorf("Expected: %s , received: %s", utils.ToJSON(aa), utils.ToJSON(cln))
+	}
+	cln.Roles["admin"].Tenants[0] = "cgrates.net"
+	if aa.Roles["admin"].Tenants[0] != utils.MetaAny {
+		t.Error("Expected clone to not modify the cloned")
+	}
+}
+
+func TestLoadAPIAuthCfgReplacesSection(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	prevAA := cfg.APIAuthCfg()
+	jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(`{
+		"api_auth": {
+			"enabled": true,
+			"roles": {"admin": {"methods": ["*any"], "tenants": ["*any"]}},
+			"users": {"admin": {"key": "4dm1n", "roles": ["admin"]}},
+		},
+	}`))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = cfg.loadAPIAuthCfg(jsnCfg); err != nil {
+		t.Fatal(err)
+	}
+	if aa := cfg.APIAuthCfg(); aa == prevAA || !aa.Enabled || len(aa.Users) != 1 {
+		t.Errorf("Expected the section to be replaced, received: %s", utils.ToJSON(aa))
+	}
+	if prevAA.Enabled || len(prevAA.Roles) != 0 || len(prevAA.Users) != 0 {
+		t.Errorf("Expected the previous section to not be modified, received: %s", utils.ToJSON(prevAA))
+	}
+}
+
+func TestAPIAuthCfgAuthorize(t *testing.T) {
+	aa := &APIAuthCfg{
+		Enabled: true,
+		Roles: map[string]*APIRoleCfg{
+			"cdrs_reader": {
+				Methods: []string{utils.CDRsV1GetCDRs},
+				Tenants: []string{"cgrates.org"},
+			},
+			"stats": {
+				Methods: []string{"StatSv1.*"},
+				Tenants: []string{"cgrates.net"},
+			},
+			"admin": {
+				Methods: []string{utils.MetaAny},
+				Tenants: []string{utils.MetaAny},
+			},
+		},
+		Users: map[string]*APIUserCfg{
+			"reader": {
+				Key:   "r34d3r",
+				Roles: []string{"cdrs_reader", "stats"},
+			},
+			"admin": {
+				Key:   "4dm1n",
+				Roles: []string{"admin"},
+			},
+		},
+	}
+	if usr, has := aa.UserByKey("r34d3r"); !has || usr != "reader" {
+		t.Errorf("Expected reader, received: %q", usr)
+	}
+	if _, has := aa.UserByKey(utils.EmptyString); has {
+		t.Error("Expected no user for empty key")
+	}
+	if _, has := aa.UserByKey("unknown"); has {
+		t.Error("Expected no user for unknown key")
+	}
+	for _, tc := range []struct {
+		user, method, tnt string
+		allowed           bool
+	}{
+		{"reader", utils.CDRsV1GetCDRs, "cgrates.org", true},
+		{"reader", utils.CDRsV1GetCDRs, "cgrates.net", false},
+		{"reader", utils.CDRsV1GetCDRs, utils.MetaAny, false},
+		{"reader", utils.CDRsV1ProcessCDR, "cgrates.org", false},
+		{"reader", utils.StatSv1GetQueueIDs, "cgrates.net", true},
+		{"reader", utils.StatSv1GetQueueIDs, "cgrates.org", false},
+		{"reader", "StatSv1", "cgrates.net", false},
+		{"admin", utils.APIerSv1SetAccount, "cgrates.org", true},
+		{"admin", utils.APIerSv1SetAccount, utils.MetaAny, true},
+		{"unknown", utils.CDRsV1GetCDRs, "cgrates.org", false},
+	} {
+		if rcv := aa.Authorize(tc.user, tc.method, tc.tnt); rcv != tc.allowed {
+			t.Errorf("Expected %v for %+v, received: %v", tc.allowed, tc, rcv)
+		}
+	}
+}
+
+func TestAPIAuthCfgV1Setters(t *testing.T) {
+	cfg := NewDefaultCGRConfig()
+	var reply string
+	if err := cfg.V1SetAPIAuthUser(&APIAuthUserWithAPIOpts{
+		ID:    "reader",
+		Key:   "r34d3r",
+		Roles: []string{"cdrs_reader"},
+	}, &reply); err == nil || err.Error() != "<api_auth> unknown role <cdrs_reader> for user <reader>" {
+		t.Error(err)
+	}
+	if err := cfg.V1SetAPIAuthRole(&APIAuthRoleWithAPIOpts{ID: "cdrs_reader"}, &reply); err == nil ||
+		err.Error() != utils.NewErrMandatoryIeMissing("Methods", "Tenants").Error() {
+		t.Error(err)
+	}
+	if err := cfg.V1SetAPIAuthRole(&APIAuthRoleWithAPIOpts{
+		ID:      "cdrs_reader",
+		Methods: []string{utils.CDRsV1GetCDRs},
+		Tenants: []string{"cgrates.org"},
+	}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("Unexpected reply: %q", reply)
+	}
+	oldAA := cfg.APIAuthCfg()
+	if err := cfg.V1SetAPIAuthUser(&APIAuthUserWithAPIOpts{
+		ID:    "reader",
+		Key:   "r34d3r",
+		Roles: []string{"cdrs_reader"},
+	}, &reply); err != nil {
+		t.Error(err)
+	}
+	if _, has := oldAA.Users["reader"]; has {
+		t.Error("Expected the section to be replaced instead of modified")
+	}
+	if !cfg.APIAuthCfg().Authorize("reader", utils.CDRsV1GetCDRs, "cgrates.org") {
+		t.Error("Expected the user to be authorized")
+	}
+	var rcv map[string]interface{}
+	if err := cfg.V1GetConfig(&SectionWithAPIOpts{Section: APIAuthJson}, &rcv); err != nil {
+		t.Error(err)
+	} else if usrs := rcv[APIAuthJson].(map[string]interface{})[utils.UsersCfg].(map[string]interface{}); len(usrs) != 1 {
+		t.Errorf("Expected the new user in config, received: %s", utils.ToJSON(rcv))
+	}
+	if err := cfg.V1RemoveAPIAuthRole(&APIAuthIDWithAPIOpts{ID: "cdrs_reader"}, &reply); err == nil ||
+		err.Error() != "<api_auth> unknown role <cdrs_reader> for user <reader>" {
+		t.Error(err)
+	}
+	if err := cfg.V1RemoveAPIAuthUser(&APIAuthIDWithAPIOpts{ID: "reader"}, &reply); err != nil {
+		t.Error(err)
+	}
+	if err := cfg.V1RemoveAPIAuthUser(&APIAuthIDWithAPIOpts{ID: "reader"}, &reply); err != utils.ErrNotFound {
+		t.Error(err)
+	}
+	if err := cfg.V1RemoveAPIAuthRole(&APIAuthIDWithAPIOpts{ID: "cdrs_reader"}, &reply); err != nil {
+		t.Error(err)
+	}
+	if len(cfg.APIAuthCfg().Roles) != 0 || len(cfg.APIAuthCfg().Users) != 0 {
+		t.Errorf("Unexpected config: %s", utils.ToJSON(cfg.APIAuthCfg()))
+	}
+}
@@ -29,6 +29,8 @@ type ApierCfg struct {
 	SchedulerConns  []string // connections towards Scheduler
 	AttributeSConns []string // connections towards AttributeS
 	EEsConns        []string // connections towards EEs
+	AuditLog        bool     // record the changes of the DataDB objects into StorDB
+	AuditEEsIDs     []string // exporters receiving the audit records
 }
 
 func (aCfg *ApierCfg) loadFromJSONCfg(jsnCfg *ApierJsonCfg) (err error) {
@@ -78,13 +80,21 @@ func (aCfg *ApierCfg) loadFromJSONCfg(jsnCfg *ApierJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Audit_log != nil {
+		aCfg.AuditLog = *jsnCfg.Audit_log
+	}
+	if jsnCfg.Audit_ees_ids != nil {
+		aCfg.AuditEEsIDs = utils.CloneStringSlice(*jsnCfg.Audit_ees_ids)
+	}
 	return nil
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
 func (aCfg *ApierCfg) AsMapInterface() (initialMap map[string]interface{}) {
 	initialMap = map[string]interface{}{
-		utils.EnabledCfg: aCfg.Enabled,
+		utils.EnabledCfg:     aCfg.Enabled,
+		utils.AuditLogCfg:    aCfg.AuditLog,
+		utils.AuditEEsIDsCfg: utils.CloneStringSlice(aCfg.AuditEEsIDs),
 	}
 	if aCfg.CachesConns != nil {
 		cachesConns := make([]string, len(aCfg.CachesConns))
@@ -132,7 +142,11 @@ func (aCfg *ApierCfg) AsMapInterface() (initialMap map[string]interface{}) {
 // Clone returns a deep copy of ApierCfg
 func (aCfg ApierCfg) Clone() (cln *ApierCfg) {
 	cln = &ApierCfg{
-		Enabled: aCfg.Enabled,
+		Enabled:  aCfg.Enabled,
+		AuditLog: aCfg.AuditLog,
+	}
+	if aCfg.AuditEEsIDs != nil {
+		cln.AuditEEsIDs = utils.CloneStringSlice(aCfg.AuditEEsIDs)
 	}
 	if aCfg.CachesConns != nil {
 		cln.CachesConns = make([]string, len(aCfg.CachesConns))
@@ -31,6 +31,8 @@ func TestApierCfgloadFromJsonCfg(t *testing.T) {
 		Scheduler_conns:  &[]string{utils.MetaInternal, "*conn1"},
 		Attributes_conns: &[]string{utils.MetaInternal, "*conn1"},
 		Ees_conns:        &[]string{utils.MetaInternal, "*conn1"},
+		Audit_log:        utils.BoolPointer(true),
+		Audit_ees_ids:    &[]string{"AUDIT_EXPORTER"},
 	}
 	expected := &ApierCfg{
 		Enabled:         false,
@@ -38,6 +40,8 @@ func TestApierCfgloadFromJsonCfg(t *testing.T) {
 		SchedulerConns:  []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaScheduler), "*conn1"},
 		AttributeSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		EEsConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs), "*conn1"},
+		AuditLog:        true,
+		AuditEEsIDs:     []string{"AUDIT_EXPORTER"},
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.apier.loadFromJSONCfg(jsonCfg); err != nil {
@@ -60,6 +64,8 @@ func TestApierCfgAsMapInterface1(t *testing.T) {
 		utils.SchedulerConnsCfg:  sls,
 		utils.AttributeSConnsCfg: sls,
 		utils.EEsConnsCfg:        sls,
+		utils.AuditLogCfg:        false,
+		utils.AuditEEsIDsCfg:     sls,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -76,6 +82,8 @@ func TestApierCfgAsMapInterface2(t *testing.T) {
        "ees_conns": ["*internal:*ees", "*conn1"],
        "caches_conns": ["*internal:*caches", "*conn1"],
        "scheduler_conns": ["*internal:*scheduler", "*conn1"],
+       "audit_log": true,
+       "audit_ees_ids": ["AUDIT_EXPORTER"],
     },
 }`
 	expectedMap := map[string]interface{}{
@@ -84,6 +92,8 @@ func TestApierCfgAsMapInterface2(t *testing.T) {
 		utils.SchedulerConnsCfg:  []string{utils.MetaInternal, "*conn1"},
 		utils.AttributeSConnsCfg: []string{utils.MetaInternal, "*conn1"},
 		utils.EEsConnsCfg:        []string{utils.MetaInternal, "*conn1"},
+		utils.AuditLogCfg:        true,
+		utils.AuditEEsIDsCfg:     []string{"AUDIT_EXPORTER"},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(myJSONStr); err != nil {
 		t.Error(err)
@@ -99,6 +109,8 @@ func TestApierCfgClone(t *testing.T) {
 		SchedulerConns:  []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaScheduler), "*conn1"},
 		AttributeSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		EEsConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs), "*conn1"},
+		AuditLog:        true,
+		AuditEEsIDs:     []string{"AUDIT_EXPORTER"},
 	}
 	rcv := sa.Clone()
 	if !reflect.DeepEqual(sa, rcv) {
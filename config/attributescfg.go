@@ -18,7 +18,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 
 package config
 
-import "github.com/cgrates/cgrates/utils"
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
 
 type AttributesOpts struct {
 	ProfileIDs           []string
@@ -40,6 +44,9 @@ type AttributeSCfg struct {
 	SuffixIndexedFields *[]string
 	NestedFields        bool
 	AnyContext          bool
+	LookupsTimeout      time.Duration
+	LookupsCacheTTL     time.Duration
+	SQLConns            map[string]string // indexed on connection ID
 	Opts                *AttributesOpts
 }
 
@@ -131,6 +138,22 @@ func (alS *AttributeSCfg) loadFromJSONCfg(jsnCfg *AttributeSJsonCfg) (err error)
 	if jsnCfg.Any_context != nil {
 		alS.AnyContext = *jsnCfg.Any_context
 	}
+	if jsnCfg.Lookups_timeout != nil {
+		if alS.LookupsTimeout, err = utils.ParseDurationWithNanosecs(*jsnCfg.Lookups_timeout); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Lookups_cache_ttl != nil {
+		if alS.LookupsCacheTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Lookups_cache_ttl); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Sql_conns != nil {
+		alS.SQLConns = make(map[string]string, len(*jsnCfg.Sql_conns))
+		for connID, dsn := range *jsnCfg.Sql_conns {
+			alS.SQLConns[connID] = dsn
+		}
+	}
 	if jsnCfg.Opts != nil {
 		alS.Opts.loadFromJSONCfg(jsnCfg.Opts)
 	}
@@ -138,6 +161,7 @@ func (alS *AttributeSCfg) loadFromJSONCfg(jsnCfg *AttributeSJsonCfg) (err error)
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
+// the sql_conns are omitted so the credentials within the DSNs are not exposed via the config APIs
 func (alS *AttributeSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	opts := map[string]interface{}{
 		utils.MetaProfileIDs:              alS.Opts.ProfileIDs,
@@ -149,11 +173,13 @@ func (alS *AttributeSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		opts[utils.OptsContext] = *alS.Opts.Context
 	}
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:        alS.Enabled,
-		utils.IndexedSelectsCfg: alS.IndexedSelects,
-		utils.NestedFieldsCfg:   alS.NestedFields,
-		utils.AnyContextCfg:     alS.AnyContext,
-		utils.OptsCfg:           opts,
+		utils.EnabledCfg:         alS.Enabled,
+		utils.IndexedSelectsCfg:  alS.IndexedSelects,
+		utils.NestedFieldsCfg:    alS.NestedFields,
+		utils.AnyContextCfg:      alS.AnyContext,
+		utils.LookupsTimeoutCfg:  alS.LookupsTimeout.String(),
+		utils.LookupsCacheTTLCfg: alS.LookupsCacheTTL.String(),
+		utils.OptsCfg:            opts,
 	}
 	if alS.StringIndexedFields != nil {
 		stringIndexedFields := make([]string, len(*alS.StringIndexedFields))
@@ -226,11 +252,19 @@ func (attrOpts *AttributesOpts) Clone() *AttributesOpts {
 // Clone returns a deep copy of AttributeSCfg
 func (alS AttributeSCfg) Clone() (cln *AttributeSCfg) {
 	cln = &AttributeSCfg{
-		Enabled:        alS.Enabled,
-		IndexedSelects: alS.IndexedSelects,
-		NestedFields:   alS.NestedFields,
-		AnyContext:     alS.AnyContext,
-		Opts:           alS.Opts.Clone(),
+		Enabled:         alS.Enabled,
+		IndexedSelects:  alS.IndexedSelects,
+		NestedFields:    alS.NestedFields,
+		AnyContext:      alS.AnyContext,
+		LookupsTimeout:  alS.LookupsTimeout,
+		LookupsCacheTTL: alS.LookupsCacheTTL,
+		Opts:            alS.Opts.Clone(),
+	}
+	if alS.SQLConns != nil {
+		cln.SQLConns = make(map[string]string, len(alS.SQLConns))
+		for connID, dsn := range alS.SQLConns {
+			cln.SQLConns[connID] = dsn
+		}
 	}
 	if alS.ResourceSConns != nil {
 		cln.ResourceSConns = make([]string, len(alS.ResourceSConns))
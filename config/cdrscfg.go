@@ -36,6 +36,8 @@ type CdrsCfg struct {
 	OnlineCDRExports []string // list of CDRE templates to use for real-time CDR exports
 	SchedulerConns   []string
 	EEsConns         []string
+	FraudSConns      []string
+	RouteSConns      []string
 }
 
 // loadFromJSONCfg loads Cdrs config from JsonCfg
@@ -131,6 +133,26 @@ func (cdrscfg *CdrsCfg) loadFromJSONCfg(jsnCdrsCfg *CdrsJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCdrsCfg.Frauds_conns != nil {
+		cdrscfg.FraudSConns = make([]string, len(*jsnCdrsCfg.Frauds_conns))
+		for idx, connID := range *jsnCdrsCfg.Frauds_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			cdrscfg.FraudSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				cdrscfg.FraudSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds)
+			}
+		}
+	}
+	if jsnCdrsCfg.Routes_conns != nil {
+		cdrscfg.RouteSConns = make([]string, len(*jsnCdrsCfg.Routes_conns))
+		for idx, connID := range *jsnCdrsCfg.Routes_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			cdrscfg.RouteSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				cdrscfg.RouteSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes)
+			}
+		}
+	}
 	return nil
 }
 
@@ -224,6 +246,26 @@ func (cdrscfg *CdrsCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.EEsConnsCfg] = eesConns
 	}
+	if cdrscfg.FraudSConns != nil {
+		fraudSConns := make([]string, len(cdrscfg.FraudSConns))
+		for i, item := range cdrscfg.FraudSConns {
+			fraudSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds) {
+				fraudSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.FraudSConnsCfg] = fraudSConns
+	}
+	if cdrscfg.RouteSConns != nil {
+		routeSConns := make([]string, len(cdrscfg.RouteSConns))
+		for i, item := range cdrscfg.RouteSConns {
+			routeSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes) {
+				routeSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.RouteSConnsCfg] = routeSConns
+	}
 	return
 }
 
@@ -283,6 +325,18 @@ func (cdrscfg CdrsCfg) Clone() (cln *CdrsCfg) {
 			cln.EEsConns[i] = con
 		}
 	}
+	if cdrscfg.FraudSConns != nil {
+		cln.FraudSConns = make([]string, len(cdrscfg.FraudSConns))
+		for i, con := range cdrscfg.FraudSConns {
+			cln.FraudSConns[i] = con
+		}
+	}
+	if cdrscfg.RouteSConns != nil {
+		cln.RouteSConns = make([]string, len(cdrscfg.RouteSConns))
+		for i, con := range cdrscfg.RouteSConns {
+			cln.RouteSConns[i] = con
+		}
+	}
 
 	return
 }
@@ -37,6 +37,8 @@ func TestCdrsCfgloadFromJsonCfg(t *testing.T) {
 		Online_cdr_exports:   &[]string{"randomVal"},
 		Scheduler_conns:      &[]string{utils.MetaInternal, "*conn1"},
 		Ees_conns:            &[]string{utils.MetaInternal, "*conn1"},
+		Frauds_conns:         &[]string{utils.MetaInternal, "*conn1"},
+		Routes_conns:         &[]string{utils.MetaInternal, "*conn1"},
 	}
 	expected := &CdrsCfg{
 		Enabled:          true,
@@ -50,6 +52,8 @@ func TestCdrsCfgloadFromJsonCfg(t *testing.T) {
 		OnlineCDRExports: []string{"randomVal"},
 		SchedulerConns:   []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaScheduler), "*conn1"},
 		EEsConns:         []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs), "*conn1"},
+		FraudSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds), "*conn1"},
+		RouteSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes), "*conn1"},
 		ExtraFields:      RSRParsers{},
 	}
 	jsnCfg := NewDefaultCGRConfig()
@@ -86,6 +90,8 @@ func TestCdrsCfgAsMapInterface(t *testing.T) {
 		"online_cdr_exports":["http_localhost", "amqp_localhost", "http_test_file"],
 		"scheduler_conns": ["*internal:*scheduler","*conn1"],		
         "ees_conns": ["*internal:*ees","*conn1"],
+		"frauds_conns": ["*internal:*frauds","*conn1"],
+		"routes_conns": ["*internal:*routes","*conn1"],
 	},
 }`
 	eMap := map[string]interface{}{
@@ -101,6 +107,8 @@ func TestCdrsCfgAsMapInterface(t *testing.T) {
 		utils.OnlineCDRExportsCfg: []string{"http_localhost", "amqp_localhost", "http_test_file"},
 		utils.SchedulerConnsCfg:   []string{utils.MetaInternal, "*conn1"},
 		utils.EEsConnsCfg:         []string{utils.MetaInternal, "*conn1"},
+		utils.FraudSConnsCfg:      []string{utils.MetaInternal, "*conn1"},
+		utils.RouteSConnsCfg:      []string{utils.MetaInternal, "*conn1"},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -131,6 +139,8 @@ func TestCdrsCfgAsMapInterface2(t *testing.T) {
 		utils.OnlineCDRExportsCfg: []string{},
 		utils.SchedulerConnsCfg:   []string{},
 		utils.EEsConnsCfg:         []string{"conn1"},
+		utils.FraudSConnsCfg:      []string{},
+		utils.RouteSConnsCfg:      []string{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
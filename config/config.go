@@ -150,12 +150,14 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.diameterAgentCfg = new(DiameterAgentCfg)
 	cfg.radiusAgentCfg = new(RadiusAgentCfg)
 	cfg.dnsAgentCfg = new(DNSAgentCfg)
+	cfg.wsAgentCfg = new(WebSocketAgentCfg)
 	cfg.attributeSCfg = &AttributeSCfg{Opts: &AttributesOpts{}}
 	cfg.chargerSCfg = new(ChargerSCfg)
 	cfg.resourceSCfg = &ResourceSConfig{Opts: &ResourcesOpts{}}
 	cfg.statsCfg = &StatSCfg{Opts: &StatsOpts{}}
 	cfg.thresholdSCfg = &ThresholdSCfg{Opts: &ThresholdsOpts{}}
-	cfg.routeSCfg = &RouteSCfg{Opts: &RoutesOpts{}}
+	cfg.fraudSCfg = new(FraudSCfg)
+	cfg.routeSCfg = &RouteSCfg{CircuitBreakers: make(map[string]*RouteBreakerCfg), Opts: &RoutesOpts{}}
 	cfg.sureTaxCfg = new(SureTaxCfg)
 	cfg.dispatcherSCfg = new(DispatcherSCfg)
 	cfg.registrarCCfg = new(RegistrarCCfgs)
@@ -176,6 +178,8 @@ func newCGRConfig(config []byte) (cfg *CGRConfig, err error) {
 	cfg.sipAgentCfg = new(SIPAgentCfg)
 	cfg.configSCfg = new(ConfigSCfg)
 	cfg.apiBanCfg = new(APIBanCfg)
+	cfg.apiAuthCfg = &APIAuthCfg{Roles: make(map[string]*APIRoleCfg), Users: make(map[string]*APIUserCfg)}
+	cfg.quotasCfg = &QuotasCfg{Tenants: make(map[string]*TenantQuotaCfg)}
 	cfg.coreSCfg = new(CoreSCfg)
 	cfg.dfltEvExp = &EventExporterCfg{Opts: &EventExporterOpts{}}
 	cfg.dfltEvRdr = &EventReaderCfg{Opts: &EventReaderOpts{}}
@@ -274,44 +278,48 @@ type CGRConfig struct {
 
 	templates FcTemplates
 
-	generalCfg       *GeneralCfg       // General config
-	dataDbCfg        *DataDbCfg        // Database config
-	storDbCfg        *StorDbCfg        // StroreDb config
-	tlsCfg           *TLSCfg           // TLS config
-	cacheCfg         *CacheCfg         // Cache config
-	listenCfg        *ListenCfg        // Listen config
-	httpCfg          *HTTPCfg          // HTTP config
-	filterSCfg       *FilterSCfg       // FilterS config
-	ralsCfg          *RalsCfg          // Rals config
-	schedulerCfg     *SchedulerCfg     // Scheduler config
-	cdrsCfg          *CdrsCfg          // Cdrs config
-	sessionSCfg      *SessionSCfg      // SessionS config
-	fsAgentCfg       *FsAgentCfg       // FreeSWITCHAgent config
-	kamAgentCfg      *KamAgentCfg      // KamailioAgent config
-	asteriskAgentCfg *AsteriskAgentCfg // AsteriskAgent config
-	diameterAgentCfg *DiameterAgentCfg // DiameterAgent config
-	radiusAgentCfg   *RadiusAgentCfg   // RadiusAgent config
-	dnsAgentCfg      *DNSAgentCfg      // DNSAgent config
-	attributeSCfg    *AttributeSCfg    // AttributeS config
-	chargerSCfg      *ChargerSCfg      // ChargerS config
-	resourceSCfg     *ResourceSConfig  // ResourceS config
-	statsCfg         *StatSCfg         // StatS config
-	thresholdSCfg    *ThresholdSCfg    // ThresholdS config
-	routeSCfg        *RouteSCfg        // RouteS config
-	sureTaxCfg       *SureTaxCfg       // SureTax config
-	dispatcherSCfg   *DispatcherSCfg   // DispatcherS config
-	registrarCCfg    *RegistrarCCfgs   // RegistrarC config
-	loaderCgrCfg     *LoaderCgrCfg     // LoaderCgr config
-	migratorCgrCfg   *MigratorCgrCfg   // MigratorCgr config
-	mailerCfg        *MailerCfg        // Mailer config
-	analyzerSCfg     *AnalyzerSCfg     // AnalyzerS config
-	apier            *ApierCfg         // APIer config
-	ersCfg           *ERsCfg           // EventReader config
-	eesCfg           *EEsCfg           // EventExporter config
-	sipAgentCfg      *SIPAgentCfg      // SIPAgent config
-	configSCfg       *ConfigSCfg       // ConfigS config
-	apiBanCfg        *APIBanCfg        // APIBan config
-	coreSCfg         *CoreSCfg         // CoreS config
+	generalCfg       *GeneralCfg        // General config
+	dataDbCfg        *DataDbCfg         // Database config
+	storDbCfg        *StorDbCfg         // StroreDb config
+	tlsCfg           *TLSCfg            // TLS config
+	cacheCfg         *CacheCfg          // Cache config
+	listenCfg        *ListenCfg         // Listen config
+	httpCfg          *HTTPCfg           // HTTP config
+	filterSCfg       *FilterSCfg        // FilterS config
+	ralsCfg          *RalsCfg           // Rals config
+	schedulerCfg     *SchedulerCfg      // Scheduler config
+	cdrsCfg          *CdrsCfg           // Cdrs config
+	sessionSCfg      *SessionSCfg       // SessionS config
+	fsAgentCfg       *FsAgentCfg        // FreeSWITCHAgent config
+	kamAgentCfg      *KamAgentCfg       // KamailioAgent config
+	asteriskAgentCfg *AsteriskAgentCfg  // AsteriskAgent config
+	diameterAgentCfg *DiameterAgentCfg  // DiameterAgent config
+	radiusAgentCfg   *RadiusAgentCfg    // RadiusAgent config
+	dnsAgentCfg      *DNSAgentCfg       // DNSAgent config
+	wsAgentCfg       *WebSocketAgentCfg // WebSocketAgent config
+	attributeSCfg    *AttributeSCfg     // AttributeS config
+	chargerSCfg      *ChargerSCfg       // ChargerS config
+	resourceSCfg     *ResourceSConfig   // ResourceS config
+	statsCfg         *StatSCfg          // StatS config
+	thresholdSCfg    *ThresholdSCfg     // ThresholdS config
+	fraudSCfg        *FraudSCfg         // FraudS config
+	routeSCfg        *RouteSCfg         // RouteS config
+	sureTaxCfg       *SureTaxCfg        // SureTax config
+	dispatcherSCfg   *DispatcherSCfg    // DispatcherS config
+	registrarCCfg    *RegistrarCCfgs    // RegistrarC config
+	loaderCgrCfg     *LoaderCgrCfg      // LoaderCgr config
+	migratorCgrCfg   *MigratorCgrCfg    // MigratorCgr config
+	mailerCfg        *MailerCfg         // Mailer config
+	analyzerSCfg     *AnalyzerSCfg      // AnalyzerS config
+	apier            *ApierCfg          // APIer config
+	ersCfg           *ERsCfg            // EventReader config
+	eesCfg           *EEsCfg            // EventExporter config
+	sipAgentCfg      *SIPAgentCfg       // SIPAgent config
+	configSCfg       *ConfigSCfg        // ConfigS config
+	apiBanCfg        *APIBanCfg         // APIBan config
+	apiAuthCfg       *APIAuthCfg        // APIAuth config
+	quotasCfg        *QuotasCfg         // Quotas config
+	coreSCfg         *CoreSCfg          // CoreS config
 
 	cacheDP    map[string]utils.MapStorage
 	cacheDPMux sync.RWMutex
@@ -377,14 +385,14 @@ func (cfg *CGRConfig) loadFromJSONCfg(jsnCfg *CgrJsonCfg) (err error) {
 		cfg.loadCdrsCfg, cfg.loadSessionSCfg,
 		cfg.loadFreeswitchAgentCfg, cfg.loadKamAgentCfg,
 		cfg.loadAsteriskAgentCfg, cfg.loadDiameterAgentCfg, cfg.loadRadiusAgentCfg,
-		cfg.loadDNSAgentCfg, cfg.loadHTTPAgentCfg, cfg.loadAttributeSCfg,
+		cfg.loadDNSAgentCfg, cfg.loadWebSocketAgentCfg, cfg.loadHTTPAgentCfg, cfg.loadAttributeSCfg,
 		cfg.loadChargerSCfg, cfg.loadResourceSCfg, cfg.loadStatSCfg,
-		cfg.loadThresholdSCfg, cfg.loadRouteSCfg, cfg.loadLoaderSCfg,
+		cfg.loadThresholdSCfg, cfg.loadFraudSCfg, cfg.loadRouteSCfg, cfg.loadLoaderSCfg,
 		cfg.loadMailerCfg, cfg.loadSureTaxCfg, cfg.loadDispatcherSCfg,
 		cfg.loadLoaderCgrCfg, cfg.loadMigratorCgrCfg, cfg.loadTLSCgrCfg,
 		cfg.loadAnalyzerCgrCfg, cfg.loadApierCfg, cfg.loadErsCfg, cfg.loadEesCfg,
 		cfg.loadSIPAgentCfg, cfg.loadRegistrarCCfg,
-		cfg.loadConfigSCfg, cfg.loadAPIBanCgrCfg, cfg.loadCoreSCfg} {
+		cfg.loadConfigSCfg, cfg.loadAPIBanCgrCfg, cfg.loadAPIAuthCfg, cfg.loadQuotasCfg, cfg.loadCoreSCfg} {
 		if err = loadFunc(jsnCfg); err != nil {
 			return
 		}
@@ -564,7 +572,7 @@ func (cfg *CGRConfig) loadKamAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	if jsnKamAgentCfg, err = jsnCfg.KamAgentJsonCfg(); err != nil {
 		return
 	}
-	return cfg.kamAgentCfg.loadFromJSONCfg(jsnKamAgentCfg)
+	return cfg.kamAgentCfg.loadFromJSONCfg(jsnKamAgentCfg, cfg.generalCfg.RSRSep)
 }
 
 // loadAsteriskAgentCfg loads the AsteriskAgent section of the configuration
@@ -657,6 +665,15 @@ func (cfg *CGRConfig) loadThresholdSCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.thresholdSCfg.loadFromJSONCfg(jsnThresholdSCfg)
 }
 
+// loadFraudSCfg loads the FraudS section of the configuration
+func (cfg *CGRConfig) loadFraudSCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnFraudSCfg *FraudSJsonCfg
+	if jsnFraudSCfg, err = jsnCfg.FraudSJsonCfg(); err != nil {
+		return
+	}
+	return cfg.fraudSCfg.loadFromJSONCfg(jsnFraudSCfg, cfg.generalCfg.RSRSep)
+}
+
 // loadRouteSCfg loads the RouteS section of the configuration
 func (cfg *CGRConfig) loadRouteSCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnRouteSCfg *RouteSJsonCfg
@@ -764,6 +781,30 @@ func (cfg *CGRConfig) loadAPIBanCgrCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.apiBanCfg.loadFromJSONCfg(jsnAPIBanCfg)
 }
 
+// loadAPIAuthCfg loads the APIAuth section of the configuration
+func (cfg *CGRConfig) loadAPIAuthCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnAPIAuthCfg *APIAuthJsonCfg
+	if jsnAPIAuthCfg, err = jsnCfg.APIAuthJsonCfg(); err != nil {
+		return
+	}
+	// loaded into a copy so the requests being authorized keep reading the current section without locks
+	aa := cfg.apiAuthCfg.Clone()
+	if err = aa.loadFromJSONCfg(jsnAPIAuthCfg); err != nil {
+		return
+	}
+	cfg.apiAuthCfg = aa
+	return
+}
+
+// loadQuotasCfg loads the Quotas section of the configuration
+func (cfg *CGRConfig) loadQuotasCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnQuotasCfg *QuotasJsonCfg
+	if jsnQuotasCfg, err = jsnCfg.QuotasJsonCfg(); err != nil {
+		return
+	}
+	return cfg.quotasCfg.loadFromJSONCfg(jsnQuotasCfg)
+}
+
 // loadApierCfg loads the Apier section of the configuration
 func (cfg *CGRConfig) loadApierCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnApierCfg *ApierJsonCfg
@@ -800,6 +841,15 @@ func (cfg *CGRConfig) loadEesCfg(jsnCfg *CgrJsonCfg) (err error) {
 	return cfg.eesCfg.loadFromJSONCfg(jsnEEsCfg, cfg.templates, cfg.generalCfg.RSRSep, cfg.dfltEvExp)
 }
 
+// loadWebSocketAgentCfg loads the websocket_agent section of the configuration
+func (cfg *CGRConfig) loadWebSocketAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
+	var jsnWSAgentCfg *WebSocketAgentJsonCfg
+	if jsnWSAgentCfg, err = jsnCfg.WebSocketAgentJsonCfg(); err != nil {
+		return
+	}
+	return cfg.wsAgentCfg.loadFromJSONCfg(jsnWSAgentCfg, cfg.generalCfg.RSRSep)
+}
+
 // loadSIPAgentCfg loads the sip_agent section of the configuration
 func (cfg *CGRConfig) loadSIPAgentCfg(jsnCfg *CgrJsonCfg) (err error) {
 	var jsnSIPAgentCfg *SIPAgentJsonCfg
@@ -894,6 +944,13 @@ func (cfg *CGRConfig) ThresholdSCfg() *ThresholdSCfg {
 	return cfg.thresholdSCfg
 }
 
+// FraudSCfg returns the config for FraudS
+func (cfg *CGRConfig) FraudSCfg() *FraudSCfg {
+	cfg.lks[FraudSJson].Lock()
+	defer cfg.lks[FraudSJson].Unlock()
+	return cfg.fraudSCfg
+}
+
 // RouteSCfg returns the config for RouteS
 func (cfg *CGRConfig) RouteSCfg() *RouteSCfg {
 	cfg.lks[RouteSJson].Lock()
@@ -1088,6 +1145,13 @@ func (cfg *CGRConfig) EEsNoLksCfg() *EEsCfg {
 	return cfg.eesCfg
 }
 
+// WebSocketAgentCfg returns the config for WebSocket Agent
+func (cfg *CGRConfig) WebSocketAgentCfg() *WebSocketAgentCfg {
+	cfg.lks[WebSocketAgentJson].Lock()
+	defer cfg.lks[WebSocketAgentJson].Unlock()
+	return cfg.wsAgentCfg
+}
+
 // SIPAgentCfg reads the Apier configuration
 func (cfg *CGRConfig) SIPAgentCfg() *SIPAgentCfg {
 	cfg.lks[SIPAgentJson].Lock()
@@ -1123,6 +1187,20 @@ func (cfg *CGRConfig) APIBanCfg() *APIBanCfg {
 	return cfg.apiBanCfg
 }
 
+// APIAuthCfg reads the APIAuth configuration
+func (cfg *CGRConfig) APIAuthCfg() *APIAuthCfg {
+	cfg.lks[APIAuthJson].Lock()
+	defer cfg.lks[APIAuthJson].Unlock()
+	return cfg.apiAuthCfg
+}
+
+// QuotasCfg reads the Quotas configuration
+func (cfg *CGRConfig) QuotasCfg() *QuotasCfg {
+	cfg.lks[QuotasJson].Lock()
+	defer cfg.lks[QuotasJson].Unlock()
+	return cfg.quotasCfg
+}
+
 // CoreSCfg reads the CoreS configuration
 func (cfg *CGRConfig) CoreSCfg() *CoreSCfg {
 	cfg.lks[CoreSCfgJson].Lock()
@@ -1225,6 +1303,7 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		RESOURCES_JSON:     cfg.loadResourceSCfg,
 		STATS_JSON:         cfg.loadStatSCfg,
 		THRESHOLDS_JSON:    cfg.loadThresholdSCfg,
+		FraudSJson:         cfg.loadFraudSCfg,
 		RouteSJson:         cfg.loadRouteSCfg,
 		LoaderJson:         cfg.loadLoaderSCfg,
 		MAILER_JSN:         cfg.loadMailerCfg,
@@ -1237,9 +1316,12 @@ func (cfg *CGRConfig) getLoadFunctions() map[string]func(*CgrJsonCfg) error {
 		ApierS:             cfg.loadApierCfg,
 		RPCConnsJsonName:   cfg.loadRPCConns,
 		SIPAgentJson:       cfg.loadSIPAgentCfg,
+		WebSocketAgentJson: cfg.loadWebSocketAgentCfg,
 		TemplatesJson:      cfg.loadTemplateSCfg,
 		ConfigSJson:        cfg.loadConfigSCfg,
 		APIBanCfgJson:      cfg.loadAPIBanCgrCfg,
+		APIAuthJson:        cfg.loadAPIAuthCfg,
+		QuotasJson:         cfg.loadQuotasCfg,
 		CoreSCfgJson:       cfg.loadCoreSCfg,
 	}
 }
@@ -1439,6 +1521,8 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 		case TemplatesJson:
 		case TlsCfgJson: // nothing to reload
 		case APIBanCfgJson: // nothing to reload
+		case APIAuthJson: // nothing to reload
+		case QuotasJson: // nothing to reload
 		case CoreSCfgJson: // nothing to reload
 		case HTTP_JSN:
 			cfg.rldChans[HTTP_JSN] <- struct{}{}
@@ -1476,6 +1560,8 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 			cfg.rldChans[STATS_JSON] <- struct{}{}
 		case THRESHOLDS_JSON:
 			cfg.rldChans[THRESHOLDS_JSON] <- struct{}{}
+		case FraudSJson:
+			cfg.rldChans[FraudSJson] <- struct{}{}
 		case RouteSJson:
 			cfg.rldChans[RouteSJson] <- struct{}{}
 		case LoaderJson:
@@ -1490,6 +1576,8 @@ func (cfg *CGRConfig) reloadSections(sections ...string) {
 			cfg.rldChans[EEsJson] <- struct{}{}
 		case SIPAgentJson:
 			cfg.rldChans[SIPAgentJson] <- struct{}{}
+		case WebSocketAgentJson:
+			cfg.rldChans[WebSocketAgentJson] <- struct{}{}
 		case RegistrarCJson:
 			cfg.rldChans[RegistrarCJson] <- struct{}{}
 		}
@@ -1515,7 +1603,7 @@ func (cfg *CGRConfig) AsMapInterface(separator string) (mp map[string]interface{
 		CDRS_JSN:           cfg.cdrsCfg.AsMapInterface(),
 		SessionSJson:       cfg.sessionSCfg.AsMapInterface(),
 		FreeSWITCHAgentJSN: cfg.fsAgentCfg.AsMapInterface(separator),
-		KamailioAgentJSN:   cfg.kamAgentCfg.AsMapInterface(),
+		KamailioAgentJSN:   cfg.kamAgentCfg.AsMapInterface(cfg.generalCfg.RSRSep),
 		AsteriskAgentJSN:   cfg.asteriskAgentCfg.AsMapInterface(),
 		DA_JSN:             cfg.diameterAgentCfg.AsMapInterface(separator),
 		RA_JSN:             cfg.radiusAgentCfg.AsMapInterface(separator),
@@ -1525,6 +1613,7 @@ func (cfg *CGRConfig) AsMapInterface(separator string) (mp map[string]interface{
 		RESOURCES_JSON:     cfg.resourceSCfg.AsMapInterface(),
 		STATS_JSON:         cfg.statsCfg.AsMapInterface(),
 		THRESHOLDS_JSON:    cfg.thresholdSCfg.AsMapInterface(),
+		FraudSJson:         cfg.fraudSCfg.AsMapInterface(separator),
 		RouteSJson:         cfg.routeSCfg.AsMapInterface(),
 		SURETAX_JSON:       cfg.sureTaxCfg.AsMapInterface(separator),
 		DispatcherSJson:    cfg.dispatcherSCfg.AsMapInterface(),
@@ -1536,8 +1625,11 @@ func (cfg *CGRConfig) AsMapInterface(separator string) (mp map[string]interface{
 		ApierS:             cfg.apier.AsMapInterface(),
 		ERsJson:            cfg.ersCfg.AsMapInterface(separator),
 		APIBanCfgJson:      cfg.apiBanCfg.AsMapInterface(),
+		APIAuthJson:        cfg.apiAuthCfg.AsMapInterface(),
+		QuotasJson:         cfg.quotasCfg.AsMapInterface(),
 		EEsJson:            cfg.eesCfg.AsMapInterface(separator),
 		SIPAgentJson:       cfg.sipAgentCfg.AsMapInterface(separator),
+		WebSocketAgentJson: cfg.wsAgentCfg.AsMapInterface(separator),
 		TemplatesJson:      cfg.templates.AsMapInterface(separator),
 		ConfigSJson:        cfg.configSCfg.AsMapInterface(),
 		CoreSCfgJson:       cfg.coreSCfg.AsMapInterface(),
@@ -1644,7 +1736,7 @@ func (cfg *CGRConfig) V1GetConfig(args *SectionWithAPIOpts, reply *map[string]in
 	case FreeSWITCHAgentJSN:
 		mp = cfg.FsAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case KamailioAgentJSN:
-		mp = cfg.KamAgentCfg().AsMapInterface()
+		mp = cfg.KamAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case AsteriskAgentJSN:
 		mp = cfg.AsteriskAgentCfg().AsMapInterface()
 	case DA_JSN:
@@ -1663,6 +1755,8 @@ func (cfg *CGRConfig) V1GetConfig(args *SectionWithAPIOpts, reply *map[string]in
 		mp = cfg.StatSCfg().AsMapInterface()
 	case THRESHOLDS_JSON:
 		mp = cfg.ThresholdSCfg().AsMapInterface()
+	case FraudSJson:
+		mp = cfg.FraudSCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case RouteSJson:
 		mp = cfg.RouteSCfg().AsMapInterface()
 	case SURETAX_JSON:
@@ -1687,12 +1781,18 @@ func (cfg *CGRConfig) V1GetConfig(args *SectionWithAPIOpts, reply *map[string]in
 		mp = cfg.RPCConns().AsMapInterface()
 	case SIPAgentJson:
 		mp = cfg.SIPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
+	case WebSocketAgentJson:
+		mp = cfg.WebSocketAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case TemplatesJson:
 		mp = cfg.TemplatesCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case ConfigSJson:
 		mp = cfg.ConfigSCfg().AsMapInterface()
 	case APIBanCfgJson:
 		mp = cfg.APIBanCfg().AsMapInterface()
+	case APIAuthJson:
+		mp = cfg.APIAuthCfg().AsMapInterface()
+	case QuotasJson:
+		mp = cfg.QuotasCfg().AsMapInterface()
 	case HttpAgentJson:
 		mp = cfg.HTTPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case MAILER_JSN:
@@ -1756,7 +1856,7 @@ func (cfg *CGRConfig) V1SetConfig(args *SetConfigArgs, reply *string) (err error
 	return
 }
 
-//V1GetConfigAsJSON will retrieve from CGRConfig a section as a string
+// V1GetConfigAsJSON will retrieve from CGRConfig a section as a string
 func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithAPIOpts, reply *string) (err error) {
 	args.Section = utils.FirstNonEmpty(args.Section, utils.MetaAll)
 	cfg.cacheDPMux.RLock()
@@ -1808,7 +1908,7 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithAPIOpts, reply *string)
 	case FreeSWITCHAgentJSN:
 		mp = cfg.FsAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case KamailioAgentJSN:
-		mp = cfg.KamAgentCfg().AsMapInterface()
+		mp = cfg.KamAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case AsteriskAgentJSN:
 		mp = cfg.AsteriskAgentCfg().AsMapInterface()
 	case DA_JSN:
@@ -1827,6 +1927,8 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithAPIOpts, reply *string)
 		mp = cfg.StatSCfg().AsMapInterface()
 	case THRESHOLDS_JSON:
 		mp = cfg.ThresholdSCfg().AsMapInterface()
+	case FraudSJson:
+		mp = cfg.FraudSCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case RouteSJson:
 		mp = cfg.RouteSCfg().AsMapInterface()
 	case SURETAX_JSON:
@@ -1849,10 +1951,16 @@ func (cfg *CGRConfig) V1GetConfigAsJSON(args *SectionWithAPIOpts, reply *string)
 		mp = cfg.ERsCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case SIPAgentJson:
 		mp = cfg.SIPAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
+	case WebSocketAgentJson:
+		mp = cfg.WebSocketAgentCfg().AsMapInterface(cfg.GeneralCfg().RSRSep)
 	case ConfigSJson:
 		mp = cfg.ConfigSCfg().AsMapInterface()
 	case APIBanCfgJson:
 		mp = cfg.APIBanCfg().AsMapInterface()
+	case APIAuthJson:
+		mp = cfg.APIAuthCfg().AsMapInterface()
+	case QuotasJson:
+		mp = cfg.QuotasCfg().AsMapInterface()
 	case RPCConnsJsonName:
 		mp = cfg.RPCConns().AsMapInterface()
 	case TemplatesJson:
@@ -1911,6 +2019,120 @@ func (cfg *CGRConfig) V1SetConfigFromJSON(args *SetConfigFromJSONArgs, reply *st
 	return
 }
 
+// APIAuthRoleWithAPIOpts the API params for V1SetAPIAuthRole
+type APIAuthRoleWithAPIOpts struct {
+	APIOpts map[string]interface{}
+	Tenant  string
+	ID      string
+	Methods []string
+	Tenants []string
+}
+
+// APIAuthUserWithAPIOpts the API params for V1SetAPIAuthUser
+type APIAuthUserWithAPIOpts struct {
+	APIOpts map[string]interface{}
+	Tenant  string
+	ID      string // the user name
+	Key     string
+	Roles   []string
+}
+
+// APIAuthIDWithAPIOpts the API params for V1RemoveAPIAuthRole and V1RemoveAPIAuthUser
+type APIAuthIDWithAPIOpts struct {
+	APIOpts map[string]interface{}
+	Tenant  string
+	ID      string
+}
+
+// updateAPIAuthCfg applies the change on a copy of the api_auth section which replaces the current one if it passes the sanity checks
+// the section is replaced instead of being modified so the requests being authorized can keep reading it without locks
+func (cfg *CGRConfig) updateAPIAuthCfg(update func(aa *APIAuthCfg) error) (err error) {
+	cfg.lks[APIAuthJson].Lock()
+	defer cfg.lks[APIAuthJson].Unlock()
+	aa := cfg.apiAuthCfg.Clone()
+	if err = update(aa); err != nil {
+		return
+	}
+	if err = aa.checkSanity(); err != nil {
+		return
+	}
+	cfg.apiAuthCfg = aa
+	cfg.reloadDPCache(APIAuthJson)
+	return
+}
+
+// V1SetAPIAuthRole adds or replaces a role of the api_auth section
+func (cfg *CGRConfig) V1SetAPIAuthRole(args *APIAuthRoleWithAPIOpts, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID, "Methods", "Tenants"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err = cfg.updateAPIAuthCfg(func(aa *APIAuthCfg) error {
+		aa.Roles[args.ID] = &APIRoleCfg{
+			Methods: utils.CloneStringSlice(args.Methods),
+			Tenants: utils.CloneStringSlice(args.Tenants),
+		}
+		return nil
+	}); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1RemoveAPIAuthRole removes a role of the api_auth section not used by any user
+func (cfg *CGRConfig) V1RemoveAPIAuthRole(args *APIAuthIDWithAPIOpts, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err = cfg.updateAPIAuthCfg(func(aa *APIAuthCfg) error {
+		if _, has := aa.Roles[args.ID]; !has {
+			return utils.ErrNotFound
+		}
+		delete(aa.Roles, args.ID)
+		return nil
+	}); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1SetAPIAuthUser adds or replaces an user of the api_auth section
+func (cfg *CGRConfig) V1SetAPIAuthUser(args *APIAuthUserWithAPIOpts, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID, "Roles"}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err = cfg.updateAPIAuthCfg(func(aa *APIAuthCfg) error {
+		aa.Users[args.ID] = &APIUserCfg{
+			Key:   args.Key,
+			Roles: utils.CloneStringSlice(args.Roles),
+		}
+		return nil
+	}); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1RemoveAPIAuthUser removes an user of the api_auth section
+func (cfg *CGRConfig) V1RemoveAPIAuthUser(args *APIAuthIDWithAPIOpts, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	if err = cfg.updateAPIAuthCfg(func(aa *APIAuthCfg) error {
+		if _, has := aa.Users[args.ID]; !has {
+			return utils.ErrNotFound
+		}
+		delete(aa.Users, args.ID)
+		return nil
+	}); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
 // Clone returns a deep copy of CGRConfig
 func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 	cln = &CGRConfig{
@@ -1946,6 +2168,7 @@ func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 		resourceSCfg:     cfg.resourceSCfg.Clone(),
 		statsCfg:         cfg.statsCfg.Clone(),
 		thresholdSCfg:    cfg.thresholdSCfg.Clone(),
+		fraudSCfg:        cfg.fraudSCfg.Clone(),
 		routeSCfg:        cfg.routeSCfg.Clone(),
 		sureTaxCfg:       cfg.sureTaxCfg.Clone(),
 		dispatcherSCfg:   cfg.dispatcherSCfg.Clone(),
@@ -1958,8 +2181,11 @@ func (cfg *CGRConfig) Clone() (cln *CGRConfig) {
 		ersCfg:           cfg.ersCfg.Clone(),
 		eesCfg:           cfg.eesCfg.Clone(),
 		sipAgentCfg:      cfg.sipAgentCfg.Clone(),
+		wsAgentCfg:       cfg.wsAgentCfg.Clone(),
 		configSCfg:       cfg.configSCfg.Clone(),
 		apiBanCfg:        cfg.apiBanCfg.Clone(),
+		apiAuthCfg:       cfg.apiAuthCfg.Clone(),
+		quotasCfg:        cfg.quotasCfg.Clone(),
 		coreSCfg:         cfg.coreSCfg.Clone(),
 
 		cacheDP: make(map[string]utils.MapStorage),
@@ -79,7 +79,8 @@ const CGRATES_CFG_JSON = `
 				//"tls":false,
 				//"client_key":"",
 				//"client_certificate":"",
-				//"ca_certificate":""
+				//"ca_certificate":"",
+				//"auth_key":""			// sent as *authKey API option, for the engines with api_auth enabled
 			//}
 		//],
 	//},
@@ -98,6 +99,9 @@ const CGRATES_CFG_JSON = `
 	"replication_conns":[],					// the conns the items are replicated
 	"replication_filtered": false, 			// if this is enabled the replication will be made only to the conns that received a get
 	"replication_cache": "", 				// the caching action that is executed on the replication_conns when the items are replicated 
+	"standby_dbs": [],						// DataDBs promoted in this order when the active one fails: [{"db_type": "*redis", "db_host": "127.0.0.1", "db_port": 6380, "db_name": "10", "db_user": "cgrates", "db_password": "", "opts": {}}]
+	"failover_interval": "1s",				// interval to check the DataDBs in case of standby_dbs
+	"failover_failures": 3,					// consecutive failed checks before a DataDB is considered unavailable
 	"items":{
 		"*accounts": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 					
 		"*reverse_destinations": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
@@ -168,6 +172,7 @@ const CGRATES_CFG_JSON = `
 	"items":{
 		"*session_costs": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
 		"*cdrs": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 		
+		"*audit_log": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*tp_timings": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 					
 		"*tp_destinations": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false},
 		"*tp_rates": {"limit": -1, "ttl": "", "static_ttl": false, "remote":false, "replicate":false}, 
@@ -310,6 +315,7 @@ const CGRATES_CFG_JSON = `
 	"stats_conns": [],						// connections to StatS for <*stats> filters, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"resources_conns": [],					// connections to ResourceS for <*resources> filters, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"apiers_conns": [],						// connections to RALs for <*accounts> filters, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
+	"geoip_db": "",							// path to the MaxMind format GeoIP database used by <*ipgeo> filters
 },
 
 
@@ -348,6 +354,8 @@ const CGRATES_CFG_JSON = `
 	"online_cdr_exports":[],				// list of CDRE profiles to use for real-time CDR exports
 	"scheduler_conns": [],					// connections to SchedulerS in case of *dynaprepaid request
 	"ees_conns": [],						// connections to EventExporter
+	"frauds_conns": [],						// connections to FraudS for tracking the spending of the accounts: <""|*internal|$rpc_conns_id>
+	"routes_conns": [],						// connections to RouteS for the circuit breakers feedback: <""|*internal|$rpc_conns_id>
 },
 
 
@@ -592,6 +600,7 @@ const CGRATES_CFG_JSON = `
 	"stats_conns": [],						// connections to StatS for reporting session events <""|*internal|$rpc_conns_id>
 	"routes_conns": [],						// connections to RouteS for querying routes for event <""|*internal|$rpc_conns_id>
 	"attributes_conns": [],					// connections to AttributeS for altering event fields <""|*internal|$rpc_conns_id>
+	"frauds_conns": [],						// connections to FraudS for blocking fraudulent calls <""|*internal|$rpc_conns_id>
 	"replication_conns": [],				// replicate sessions towards these session services
 	"debit_interval": "0s",					// interval to perform debits on.
 	"store_session_costs": false,			// enable storing of the session costs within CDRs
@@ -612,6 +621,8 @@ const CGRATES_CFG_JSON = `
 	"terminate_attempts": 5,				// attempts to get the session before terminating it
 	"alterable_fields": [],					// the session fields that can be updated
 	//"min_dur_low_balance": "5s",			// threshold which will trigger low balance warnings for prepaid calls (needs to be lower than debit_interval)
+	"balance_split": false,					// split the remaining balance between the concurrent prepaid sessions of an account (needs Account within session_indexes)
+	"max_account_sessions": 0,				// limit the concurrent sessions of an account, 0 to disable (needs Account within session_indexes)
 	"stir": {
 		"allowed_attest": ["*any"],			// the default attest for stir/shaken authentication <*any|A|B|C>
 		"payload_maxduration": "-1", 		// the duration that stir header is valid after it was created
@@ -627,6 +638,7 @@ const CGRATES_CFG_JSON = `
 	"enabled": false,						// starts the Asterisk agent: <true|false>
 	"sessions_conns": ["*birpc_internal"],
 	"create_cdr": false,					// create CDR out of events and sends it to CDRS component
+	"low_balance_ann_file": "",				// media played when low balance is reached for prepaid calls, eg: sound:tt-monkeys
 	"asterisk_conns":[						// instantiate connections to multiple Asterisk servers
 		{"address": "127.0.0.1:8088", "user": "cgrates", "password": "CGRateS.org", "connect_attempts": 3, "reconnects": 5, "max_reconnect_interval": ""}
 	],
@@ -654,9 +666,11 @@ const CGRATES_CFG_JSON = `
 	"sessions_conns": ["*birpc_internal"],
 	"create_cdr": false,					// create CDR out of events and sends them to CDRS component
 	"timezone": "",							// timezone of the Kamailio server
+	"mode": "*cgrates",						// format of the evapi events <*cgrates|*json>: *cgrates for the cgrates module events, *json for the dialog/acc JSON events
 	"evapi_conns":[							// instantiate connections to multiple Kamailio servers
 		{"address": "127.0.0.1:8448", "reconnects": 5, "max_reconnect_interval": ""}
 	],
+	"request_processors": [],				// request processors for the *json mode events
 },
 
 
@@ -705,13 +719,29 @@ const CGRATES_CFG_JSON = `
 	"enabled": false,											// enables the DNS agent: <true|false>
 	"listen": "127.0.0.1:2053",									// address where to listen for DNS requests <x.y.z.y:1234>
 	"listen_net": "udp",										// network to listen on <udp|tcp|tcp-tls>
+	"listeners": [],											// additional listeners served together with the main one: [{"address": "127.0.0.1:2053", "network": "tcp"}]
 	"sessions_conns": ["*internal"],
 	"timezone": "",												// timezone of the events if not specified  <UTC|Local|$IANA_TZ_DB>
+	"upstream_servers": [],										// resolvers receiving the queries not handled by request_processors, empty to disable forwarding <x.y.z.y:53>
+	"upstream_net": "udp",										// network used towards the upstream servers <udp|tcp|tcp-tls>
+	"upstream_timeout": "2s",									// timeout for the queries sent upstream
+	"upstream_cache_limit": -1,									// maximum number of upstream replies cached based on their TTL: <-1:unlimited; 0:disabled>
 	"request_processors": [										// request processors to be applied to DNS messages
 	],
 },
 
 
+"websocket_agent": {
+	"enabled": false,											// enables the WebSocket agent: <true|false>
+	"url": "/websocket_agent",									// path on the HTTP server where the WebSocket connections are accepted
+	"allowed_origins": [],										// origins allowed to open browser connections, empty for the same origin only <*any|$scheme://$host[:$port]>
+	"sessions_conns": ["*birpc_internal"],						// connections to SessionS, needs bidirectional connections for pushing notifications <*birpc_internal|$rpc_conns_id>
+	"timezone": "",												// timezone of the events if not specified  <UTC|Local|$IANA_TZ_DB>
+	"request_processors": [										// request processors to be applied to WebSocket messages
+	],
+},
+
+
 "attributes": {								// AttributeS config
 	"enabled": false,						// starts attribute service: <true|false>
 	"stats_conns": [],						// connections to StatS, empty to disable: <""|*internal|$rpc_conns_id>
@@ -723,6 +753,9 @@ const CGRATES_CFG_JSON = `
 	"suffix_indexed_fields": [],			// query indexes based on these fields for faster processing
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
 	"any_context": true,					// if we match the *any context
+	"lookups_timeout": "2s",				// timeout of the *http_get, *http_post and *sql attribute lookups
+	"lookups_cache_ttl": "1m",				// cache the results of the attribute lookups for this interval, 0 to disable caching
+	"sql_conns": {},						// databases queried by the *sql attributes: {"$conn_id": "<mysql|postgres>://$user:$password@$host:$port/$dbname"}
 	"opts": {
 		"*profileIDs": [],
 		"*profileRuns": 0,					// number of runs a profile will process the event
@@ -764,6 +797,7 @@ const CGRATES_CFG_JSON = `
 	"enabled": false,						// starts Stat service: <true|false>.
 	"store_interval": "",					// dump cache regularly to dataDB, 0 - dump at start/shutdown: <""|$dur>
 	"store_uncompressed_limit": 0,			// used to compress data
+	"child_queues_ttl": "1h",				// remove the child queues not receiving events within this interval, together with the ones of the changed or removed profiles, 0 to keep them: <$dur>
 	"thresholds_conns": [],					// connections to ThresholdS for StatUpdates, empty to disable thresholds functionality: <""|*internal|$rpc_conns_id>
 	"indexed_selects": true,				// enable profile matching exclusively on indexes
 	//"string_indexed_fields": [],			// query indexes based on these fields for faster processing
@@ -792,6 +826,28 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"frauds": {								// FraudS
+	"enabled": false,						// starts FraudS service: <true|false>.
+	"ees_conns": [],						// connections to EEs for fraud alerts: <""|*internal|$rpc_conns_id>
+	"ees_exporter_ids": [],					// exporters receiving the fraud alerts
+	"max_call_duration": "3h",				// the active calls not released after this duration are no longer tracked, 0 to track them until released
+	"rules": [								// rules evaluated for each account, the events are tracked per rule, account and group
+		// {
+		// 	"id": "",						// rule identifier
+		// 	"type": "*velocity",			// rule type: <*velocity|*new_destination|*concurrent_calls>
+		// 	"filters": [],					// filters the events the rule applies to
+		// 	"group": "",					// template grouping the events of the account (ie: ~*req.Destination:s/^(\\d{4}).*/${1}/), empty for one group
+		// 	"window": "1h",					// sliding window of the tracked events
+		// 	"max_cost": 0,					// *velocity: maximum cost within the window, 0 to disable
+		// 	"max_calls": 0,					// *velocity: maximum number of calls within the window, 0 to disable
+		// 	"max_concurrent": 0,			// *concurrent_calls: maximum number of active calls
+		// 	"block_period": "0s",			// block the account for this period once triggered, 0 to not block
+		// 	"action_ids": [],				// actions executed on the account once triggered (ie: *disable_account)
+		// },
+	],
+},
+
+
 "routes": {									// RouteS config
 	"enabled": false,						// starts RouteS service: <true|false>.
 	"indexed_selects": true,				// enable profile matching exclusively on indexes
@@ -804,6 +860,15 @@ const CGRATES_CFG_JSON = `
 	"stats_conns": [],						// connections to StatS for *stats sorting, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"rals_conns": [],						// connections to Rater for calculating cost, empty to disable stats functionality: <""|*internal|$rpc_conns_id>
 	"default_ratio":1,						// default ratio used in case of *load strategy
+	"circuit_breakers": {					// circuit breakers skipping the routes with failures, indexed on route ID: {"<*default|$route_id>": {...}}
+		// "*default": {						// applied on the routes without own circuit breaker
+		// 	"failures": 5,					// consecutive failures opening the circuit, 0 to disable
+		// 	"failure_ratio": 0,				// ratio of failures out of the last window outcomes opening the circuit, 0 to disable
+		// 	"window": 10,					// number of outcomes considered for the failure_ratio
+		// 	"open_period": "1m",			// the route is skipped for this period once the circuit is open
+		// 	"half_open_probes": 1,			// number of successful calls closing the breaker once the open_period passed
+		// },
+	},
 	"opts": {
 		"*context": "*routes",
 		// "*profileCount": 1,
@@ -821,6 +886,7 @@ const CGRATES_CFG_JSON = `
 		"enabled": false,									// starts as service: <true|false>.
 		"tenant": "",										// tenant used in filterS.Pass
 		"dry_run": false,									// do not send the CDRs to CDRS, just parse them
+		"transactional": false,								// parse and validate all the files of a run before storing them at once, on error nothing is stored; with run_delay -1 the run starts once the .cgr.run file is created
 		"run_delay": "0",									// sleep interval in seconds between consecutive runs, -1 to use automation via inotify or 0 to disable running all together
 		"lockfile_path": ".cgr.lck",						// Filename containing concurrency lock in case of delayed processing
 		"caches_conns": ["*internal"],
@@ -891,6 +957,7 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Stored", "path": "Stored", "type": "*variable", "value": "~*req.10"},
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.11"},
 					{"tag": "ThresholdIDs", "path": "ThresholdIDs", "type": "*variable", "value": "~*req.12"},
+					{"tag": "GroupBy", "path": "GroupBy", "type": "*variable", "value": "~*req.13"},
 				],
 			},
 			{
@@ -908,6 +975,9 @@ const CGRATES_CFG_JSON = `
 					{"tag": "Weight", "path": "Weight", "type": "*variable", "value": "~*req.8"},
 					{"tag": "ActionIDs", "path": "ActionIDs", "type": "*variable", "value": "~*req.9"},
 					{"tag": "Async", "path": "Async", "type": "*variable", "value": "~*req.10"},
+					{"tag": "RaiseFilterIDs", "path": "RaiseFilterIDs", "type": "*variable", "value": "~*req.11"},
+					{"tag": "ClearFilterIDs", "path": "ClearFilterIDs", "type": "*variable", "value": "~*req.12"},
+					{"tag": "ClearActionIDs", "path": "ClearActionIDs", "type": "*variable", "value": "~*req.13"},
 				],
 			},
 			{
@@ -1076,6 +1146,9 @@ const CGRATES_CFG_JSON = `
 	"nested_fields": false,					// determines which field is checked when matching indexed filters(true: all; false: only the one on the first level)
 	"attributes_conns": [],					// connections to AttributeS for API authorization, empty to disable auth functionality: <""|*internal|$rpc_conns_id>
 	"any_subsystem": true,					// if we match the *any subsystem
+	"health_check_interval": "0s",			// interval to ping the DispatcherHosts, ejecting the unhealthy ones: <""|0s to disable>
+	"healthy_threshold": 2,					// consecutive successful checks to put back an ejected host
+	"unhealthy_threshold": 3,				// consecutive failed checks to eject a host
 },
 
 
@@ -1110,6 +1183,8 @@ const CGRATES_CFG_JSON = `
 	"scheduler_conns": [],					// connections to SchedulerS for reloads
 	"attributes_conns": [],					// connections to AttributeS for CDRExporter
 	"ees_conns": [],						// connections to EEs
+	"audit_log": false,						// record the changes of the DataDB objects done via APIs and LoaderS into StorDB
+	"audit_ees_ids": [],					// export the audit records via ees_conns to these exporters, disabled if empty
 },
 
 
@@ -1228,4 +1303,25 @@ const CGRATES_CFG_JSON = `
 },
 
 
+"api_auth": {
+	"enabled": false,						// enforce the role based authorization on all the API listeners
+	"roles": {},							// roles indexed on ID, eg: {"cdrs_reader": {"methods": ["CDRsV1.GetCDRs", "CDRsV1.GetCDRsCount"], "tenants": ["cgrates.org"]}}
+	// "methods": [],						// allowed API methods <*any|Service.*|Service.Method>
+	// "tenants": [],						// allowed tenants <*any|tenant>
+	"users": {},							// users indexed on name, eg: {"reader": {"key": "r34d3r", "roles": ["cdrs_reader"]}}
+	// "key": "",							// API key sent by the clients within the *authKey API option, the HTTP basic auth user is authorized on name
+	// "roles": [],							// roles of the user
+},
+
+
+"quotas": {
+	"enabled": false,						// enforce the quotas of the tenants
+	"tenants": {},							// quotas indexed on tenant, *default applies to the tenants not listed, eg: {"cgrates.org": {"api_requests": 10, "objects": {"*attribute_profiles": 100}}}
+	// "api_requests": 0,					// maximum concurrent API requests, 0 for unlimited
+	// "sessions": 0,						// maximum active sessions within SessionS, 0 for unlimited
+	// "cdrs_rate": 0,						// maximum CDRs processed per second, 0 for unlimited
+	// "objects": {},						// maximum DataDB items per type <*accounts|*filters|*threshold_profiles|*statqueue_profiles|*resource_profiles|*route_profiles|*attribute_profiles|*charger_profiles|*dispatcher_profiles|*dispatcher_hosts>
+},
+
+
 }`
@@ -44,6 +44,7 @@ const (
 	RESOURCES_JSON     = "resources"
 	STATS_JSON         = "stats"
 	THRESHOLDS_JSON    = "thresholds"
+	FraudSJson         = "frauds"
 	RouteSJson         = "routes"
 	LoaderJson         = "loaders"
 	MAILER_JSN         = "mailer"
@@ -57,6 +58,7 @@ const (
 	AnalyzerCfgJson    = "analyzers"
 	ApierS             = "apiers"
 	DNSAgentJson       = "dns_agent"
+	WebSocketAgentJson = "websocket_agent"
 	ERsJson            = "ers"
 	EEsJson            = "ees"
 	RPCConnsJsonName   = "rpc_conns"
@@ -64,15 +66,17 @@ const (
 	TemplatesJson      = "templates"
 	ConfigSJson        = "configs"
 	APIBanCfgJson      = "apiban"
+	APIAuthJson        = "api_auth"
+	QuotasJson         = "quotas"
 	CoreSCfgJson       = "cores"
 )
 
 var (
 	sortedCfgSections = []string{GENERAL_JSN, RPCConnsJsonName, DATADB_JSN, STORDB_JSN, LISTEN_JSN, TlsCfgJson, HTTP_JSN, SCHEDULER_JSN,
 		CACHE_JSN, FilterSjsn, RALS_JSN, CDRS_JSN, ERsJson, SessionSJson, AsteriskAgentJSN, FreeSWITCHAgentJSN,
-		KamailioAgentJSN, DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON,
-		THRESHOLDS_JSON, RouteSJson, LoaderJson, MAILER_JSN, SURETAX_JSON, CgrLoaderCfgJson, CgrMigratorCfgJson, DispatcherSJson,
-		AnalyzerCfgJson, ApierS, EEsJson, SIPAgentJson, RegistrarCJson, TemplatesJson, ConfigSJson, APIBanCfgJson, CoreSCfgJson}
+		KamailioAgentJSN, DA_JSN, RA_JSN, HttpAgentJson, DNSAgentJson, WebSocketAgentJson, ATTRIBUTE_JSN, ChargerSCfgJson, RESOURCES_JSON, STATS_JSON,
+		THRESHOLDS_JSON, FraudSJson, RouteSJson, LoaderJson, MAILER_JSN, SURETAX_JSON, CgrLoaderCfgJson, CgrMigratorCfgJson, DispatcherSJson,
+		AnalyzerCfgJson, ApierS, EEsJson, SIPAgentJson, RegistrarCJson, TemplatesJson, ConfigSJson, APIBanCfgJson, APIAuthJson, QuotasJson, CoreSCfgJson}
 )
 
 // Loads the json config out of io.Reader, eg other sources than file, maybe over http
@@ -379,6 +383,19 @@ func (jsnCfg CgrJsonCfg) ThresholdSJsonCfg() (*ThresholdSJsonCfg, error) {
 	return cfg, nil
 }
 
+// FraudSJsonCfg returns the frauds section of the config
+func (jsnCfg CgrJsonCfg) FraudSJsonCfg() (*FraudSJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[FraudSJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(FraudSJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func (jsnCfg CgrJsonCfg) RouteSJsonCfg() (*RouteSJsonCfg, error) {
 	rawCfg, hasKey := jsnCfg[RouteSJson]
 	if !hasKey {
@@ -511,6 +528,19 @@ func (jsnCfg CgrJsonCfg) ApierCfgJson() (*ApierJsonCfg, error) {
 	return cfg, nil
 }
 
+// WebSocketAgentJsonCfg returns the websocket_agent section of the config
+func (jsnCfg CgrJsonCfg) WebSocketAgentJsonCfg() (*WebSocketAgentJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[WebSocketAgentJson]
+	if !hasKey {
+		return nil, nil
+	}
+	wsAgnt := new(WebSocketAgentJsonCfg)
+	if err := json.Unmarshal(*rawCfg, wsAgnt); err != nil {
+		return nil, err
+	}
+	return wsAgnt, nil
+}
+
 func (jsnCfg CgrJsonCfg) SIPAgentJsonCfg() (*SIPAgentJsonCfg, error) {
 	rawCfg, hasKey := jsnCfg[SIPAgentJson]
 	if !hasKey {
@@ -559,6 +589,32 @@ func (jsnCfg CgrJsonCfg) ApiBanCfgJson() (*APIBanJsonCfg, error) {
 	return cfg, nil
 }
 
+// APIAuthJsonCfg returns the api_auth section of the config
+func (jsnCfg CgrJsonCfg) APIAuthJsonCfg() (*APIAuthJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[APIAuthJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(APIAuthJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
+// QuotasJsonCfg returns the quotas section of the config
+func (jsnCfg CgrJsonCfg) QuotasJsonCfg() (*QuotasJsonCfg, error) {
+	rawCfg, hasKey := jsnCfg[QuotasJson]
+	if !hasKey {
+		return nil, nil
+	}
+	cfg := new(QuotasJsonCfg)
+	if err := json.Unmarshal(*rawCfg, cfg); err != nil {
+		return nil, err
+	}
+	return cfg, nil
+}
+
 func (jsnCfg CgrJsonCfg) CoreSCfgJson() (*CoreSJsonCfg, error) {
 	rawCfg, hasKey := jsnCfg[CoreSCfgJson]
 	if !hasKey {
@@ -274,6 +274,9 @@ func TestDfDataDbJsonCfg(t *testing.T) {
 		Replication_filtered: utils.BoolPointer(false),
 		Remote_conn_id:       utils.StringPointer(""),
 		Replication_cache:    utils.StringPointer(""),
+		Standby_dbs:          &[]*DbStandbyJsonCfg{},
+		Failover_interval:    utils.StringPointer("1s"),
+		Failover_failures:    utils.IntPointer(3),
 		Opts: map[string]interface{}{
 			utils.RedisSentinelNameCfg:       "",
 			utils.MongoQueryTimeoutCfg:       "10s",
@@ -649,6 +652,13 @@ func TestDfStorDBJsonCfg(t *testing.T) {
 				Ttl:        utils.StringPointer(utils.EmptyString),
 				Static_ttl: utils.BoolPointer(false),
 			},
+			utils.CacheAuditLogTBL: {
+				Replicate:  utils.BoolPointer(false),
+				Remote:     utils.BoolPointer(false),
+				Limit:      utils.IntPointer(-1),
+				Ttl:        utils.StringPointer(utils.EmptyString),
+				Static_ttl: utils.BoolPointer(false),
+			},
 			utils.CacheSessionCostsTBL: {
 				Replicate:  utils.BoolPointer(false),
 				Remote:     utils.BoolPointer(false),
@@ -790,6 +800,8 @@ func TestDfCdrsJsonCfg(t *testing.T) {
 		Online_cdr_exports:   &[]string{},
 		Scheduler_conns:      &[]string{},
 		Ees_conns:            &[]string{},
+		Frauds_conns:         &[]string{},
+		Routes_conns:         &[]string{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -815,6 +827,7 @@ func TestSmgJsonCfg(t *testing.T) {
 		Stats_conns:           &[]string{},
 		Routes_conns:          &[]string{},
 		Attributes_conns:      &[]string{},
+		Frauds_conns:          &[]string{},
 		Replication_conns:     &[]string{},
 		Debit_interval:        utils.StringPointer("0s"),
 		Store_session_costs:   utils.BoolPointer(false),
@@ -824,6 +837,8 @@ func TestSmgJsonCfg(t *testing.T) {
 		Channel_sync_interval: utils.StringPointer("0"),
 		Terminate_attempts:    utils.IntPointer(5),
 		Alterable_fields:      &[]string{},
+		Balance_split:         utils.BoolPointer(false),
+		Max_account_sessions:  utils.IntPointer(0),
 		Default_usage: &map[string]string{
 			utils.MetaAny:   "3h",
 			utils.MetaVoice: "3h",
@@ -893,7 +908,9 @@ func TestKamAgentJsonCfg(t *testing.T) {
 				Max_reconnect_interval: utils.StringPointer(utils.EmptyString),
 			},
 		},
-		Timezone: utils.StringPointer(utils.EmptyString),
+		Timezone:           utils.StringPointer(utils.EmptyString),
+		Mode:               utils.StringPointer(utils.MetaCGRateS),
+		Request_processors: &[]*ReqProcessorJsnCfg{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -909,9 +926,10 @@ func TestKamAgentJsonCfg(t *testing.T) {
 
 func TestAsteriskAgentJsonCfg(t *testing.T) {
 	eCfg := &AsteriskAgentJsonCfg{
-		Enabled:        utils.BoolPointer(false),
-		Sessions_conns: &[]string{rpcclient.BiRPCInternal},
-		Create_cdr:     utils.BoolPointer(false),
+		Enabled:              utils.BoolPointer(false),
+		Sessions_conns:       &[]string{rpcclient.BiRPCInternal},
+		Create_cdr:           utils.BoolPointer(false),
+		Low_balance_ann_file: utils.StringPointer(utils.EmptyString),
 		Asterisk_conns: &[]*AstConnJsonCfg{
 			{
 				Address:                utils.StringPointer("127.0.0.1:8088"),
@@ -1005,10 +1023,35 @@ func TestHttpAgentJsonCfg(t *testing.T) {
 
 func TestDNSAgentJsonCfg(t *testing.T) {
 	eCfg := &DNSAgentJsonCfg{
+		Enabled:              utils.BoolPointer(false),
+		Listen_net:           utils.StringPointer("udp"),
+		Listen:               utils.StringPointer("127.0.0.1:2053"),
+		Listeners:            &[]*DNSListenerJsnCfg{},
+		Sessions_conns:       &[]string{utils.ConcatenatedKey(utils.MetaInternal)},
+		Timezone:             utils.StringPointer(""),
+		Upstream_servers:     &[]string{},
+		Upstream_net:         utils.StringPointer("udp"),
+		Upstream_timeout:     utils.StringPointer("2s"),
+		Upstream_cache_limit: utils.IntPointer(-1),
+		Request_processors:   &[]*ReqProcessorJsnCfg{},
+	}
+	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
+	if err != nil {
+		t.Error(err)
+	}
+	if cfg, err := dfCgrJSONCfg.DNSAgentJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Errorf("expecting: %+v, received: %+v", utils.ToJSON(eCfg), utils.ToJSON(cfg))
+	}
+}
+
+func TestWebSocketAgentJsonCfg(t *testing.T) {
+	eCfg := &WebSocketAgentJsonCfg{
 		Enabled:            utils.BoolPointer(false),
-		Listen_net:         utils.StringPointer("udp"),
-		Listen:             utils.StringPointer("127.0.0.1:2053"),
-		Sessions_conns:     &[]string{utils.ConcatenatedKey(utils.MetaInternal)},
+		Url:                utils.StringPointer("/websocket_agent"),
+		Allowed_origins:    &[]string{},
+		Sessions_conns:     &[]string{rpcclient.BiRPCInternal},
 		Timezone:           utils.StringPointer(""),
 		Request_processors: &[]*ReqProcessorJsnCfg{},
 	}
@@ -1016,7 +1059,7 @@ func TestDNSAgentJsonCfg(t *testing.T) {
 	if err != nil {
 		t.Error(err)
 	}
-	if cfg, err := dfCgrJSONCfg.DNSAgentJsonCfg(); err != nil {
+	if cfg, err := dfCgrJSONCfg.WebSocketAgentJsonCfg(); err != nil {
 		t.Error(err)
 	} else if !reflect.DeepEqual(eCfg, cfg) {
 		t.Errorf("expecting: %+v, received: %+v", utils.ToJSON(eCfg), utils.ToJSON(cfg))
@@ -1035,6 +1078,9 @@ func TestDfAttributeServJsonCfg(t *testing.T) {
 		Suffix_indexed_fields: &[]string{},
 		Nested_fields:         utils.BoolPointer(false),
 		Any_context:           utils.BoolPointer(true),
+		Lookups_timeout:       utils.StringPointer("2s"),
+		Lookups_cache_ttl:     utils.StringPointer("1m"),
+		Sql_conns:             &map[string]string{},
 		Opts: &AttributesOptsJson{
 			ProcessRuns:          utils.IntPointer(1),
 			ProfileRuns:          utils.IntPointer(0),
@@ -1079,6 +1125,7 @@ func TestDfFilterSJsonCfg(t *testing.T) {
 		Stats_conns:     &[]string{},
 		Resources_conns: &[]string{},
 		Apiers_conns:    &[]string{},
+		Geoip_db:        utils.StringPointer(utils.EmptyString),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1123,6 +1170,7 @@ func TestDfStatServiceJsonCfg(t *testing.T) {
 		Indexed_selects:          utils.BoolPointer(true),
 		Store_interval:           utils.StringPointer(""),
 		Store_uncompressed_limit: utils.IntPointer(0),
+		Child_queues_ttl:         utils.StringPointer("1h"),
 		Thresholds_conns:         &[]string{},
 		String_indexed_fields:    nil,
 		Prefix_indexed_fields:    &[]string{},
@@ -1169,6 +1217,58 @@ func TestDfThresholdSJsonCfg(t *testing.T) {
 	}
 }
 
+func TestDfFraudSJsonCfg(t *testing.T) {
+	eCfg := &FraudSJsonCfg{
+		Enabled:           utils.BoolPointer(false),
+		Ees_conns:         &[]string{},
+		Ees_exporter_ids:  &[]string{},
+		Max_call_duration: utils.StringPointer("3h"),
+		Rules:             &[]*FraudRuleJsonCfg{},
+	}
+	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
+	if err != nil {
+		t.Error(err)
+	}
+	if cfg, err := dfCgrJSONCfg.FraudSJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Errorf("expecting: %+v, received: %+v", utils.ToJSON(eCfg), utils.ToJSON(cfg))
+	}
+}
+
+func TestDfAPIAuthJsonCfg(t *testing.T) {
+	eCfg := &APIAuthJsonCfg{
+		Enabled: utils.BoolPointer(false),
+		Roles:   map[string]*APIRoleJsonCfg{},
+		Users:   map[string]*APIUserJsonCfg{},
+	}
+	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
+	if err != nil {
+		t.Error(err)
+	}
+	if cfg, err := dfCgrJSONCfg.APIAuthJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Errorf("expecting: %+v, received: %+v", utils.ToJSON(eCfg), utils.ToJSON(cfg))
+	}
+}
+
+func TestDfQuotasJsonCfg(t *testing.T) {
+	eCfg := &QuotasJsonCfg{
+		Enabled: utils.BoolPointer(false),
+		Tenants: map[string]*TenantQuotaJsonCfg{},
+	}
+	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
+	if err != nil {
+		t.Error(err)
+	}
+	if cfg, err := dfCgrJSONCfg.QuotasJsonCfg(); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eCfg, cfg) {
+		t.Errorf("expecting: %+v, received: %+v", utils.ToJSON(eCfg), utils.ToJSON(cfg))
+	}
+}
+
 func TestDfRouteSJsonCfg(t *testing.T) {
 	eCfg := &RouteSJsonCfg{
 		Enabled:               utils.BoolPointer(false),
@@ -1182,6 +1282,7 @@ func TestDfRouteSJsonCfg(t *testing.T) {
 		Rals_conns:            &[]string{},
 		Default_ratio:         utils.IntPointer(1),
 		Nested_fields:         utils.BoolPointer(false),
+		Circuit_breakers:      &map[string]*RouteBreakerJsonCfg{},
 		Opts: &RoutesOptsJson{
 			Context:      utils.StringPointer(utils.MetaRoutes),
 			IgnoreErrors: utils.BoolPointer(false),
@@ -1206,6 +1307,7 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 			Enabled:         utils.BoolPointer(false),
 			Tenant:          utils.StringPointer(""),
 			Dry_run:         utils.BoolPointer(false),
+			Transactional:   utils.BoolPointer(false),
 			Run_delay:       utils.StringPointer("0"),
 			Lockfile_path:   utils.StringPointer(".cgr.lck"),
 			Caches_conns:    &[]string{utils.MetaInternal},
@@ -1408,6 +1510,10 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("ThresholdIDs"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.12")},
+						{Tag: utils.StringPointer("GroupBy"),
+							Path:  utils.StringPointer("GroupBy"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.13")},
 					},
 				},
 				{
@@ -1460,6 +1566,18 @@ func TestDfLoaderJsonCfg(t *testing.T) {
 							Path:  utils.StringPointer("Async"),
 							Type:  utils.StringPointer(utils.MetaVariable),
 							Value: utils.StringPointer("~*req.10")},
+						{Tag: utils.StringPointer("RaiseFilterIDs"),
+							Path:  utils.StringPointer("RaiseFilterIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.11")},
+						{Tag: utils.StringPointer("ClearFilterIDs"),
+							Path:  utils.StringPointer("ClearFilterIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.12")},
+						{Tag: utils.StringPointer("ClearActionIDs"),
+							Path:  utils.StringPointer("ClearActionIDs"),
+							Type:  utils.StringPointer(utils.MetaVariable),
+							Value: utils.StringPointer("~*req.13")},
 					},
 				},
 				{
@@ -1819,6 +1937,9 @@ func TestDfDispatcherSJsonCfg(t *testing.T) {
 		Attributes_conns:      &[]string{},
 		Nested_fields:         utils.BoolPointer(false),
 		Any_subsystem:         utils.BoolPointer(true),
+		Health_check_interval: utils.StringPointer("0s"),
+		Healthy_threshold:     utils.IntPointer(2),
+		Unhealthy_threshold:   utils.IntPointer(3),
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
@@ -1942,6 +2063,8 @@ func TestDfApierCfg(t *testing.T) {
 		Scheduler_conns:  &[]string{},
 		Attributes_conns: &[]string{},
 		Ees_conns:        &[]string{},
+		Audit_log:        utils.BoolPointer(false),
+		Audit_ees_ids:    &[]string{},
 	}
 	dfCgrJSONCfg, err := NewCgrJsonCfgFromBytes([]byte(CGRATES_CFG_JSON))
 	if err != nil {
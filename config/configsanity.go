@@ -20,6 +20,7 @@ package config
 
 import (
 	"fmt"
+	"net/url"
 	"os"
 	"path"
 	"strings"
@@ -115,6 +116,22 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CDRs, connID)
 			}
 		}
+		for _, connID := range cfg.cdrsCfg.FraudSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.fraudSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.FraudS, utils.CDRs)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CDRs, connID)
+			}
+		}
+		for _, connID := range cfg.cdrsCfg.RouteSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.routeSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.RouteS, utils.CDRs)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.CDRs, connID)
+			}
+		}
 	}
 	// Loaders sanity checks
 	for _, ldrSCfg := range cfg.loaderCfg {
@@ -166,6 +183,13 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		if cfg.sessionSCfg.TerminateAttempts < 1 {
 			return fmt.Errorf("<%s> 'terminate_attempts' should be at least 1", utils.SessionS)
 		}
+		if cfg.sessionSCfg.MaxAccountSessions < 0 {
+			return fmt.Errorf("<%s> 'max_account_sessions' should not be negative", utils.SessionS)
+		}
+		if (cfg.sessionSCfg.BalanceSplit || cfg.sessionSCfg.MaxAccountSessions != 0) &&
+			!cfg.sessionSCfg.SessionIndexes.Has(utils.AccountField) {
+			return fmt.Errorf("<%s> %s needs to be indexed for 'balance_split' and 'max_account_sessions'", utils.SessionS, utils.AccountField)
+		}
 		for _, connID := range cfg.sessionSCfg.ChargerSConns {
 			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.chargerSCfg.Enabled {
 				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.ChargerS, utils.SessionS)
@@ -222,6 +246,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SessionS, connID)
 			}
 		}
+		for _, connID := range cfg.sessionSCfg.FraudSConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.fraudSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.FraudS, utils.SessionS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.SessionS, connID)
+			}
+		}
 		for _, connID := range cfg.sessionSCfg.CDRsConns {
 			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.cdrsCfg.Enabled {
 				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.CDRs, utils.SessionS)
@@ -277,6 +309,47 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.KamailioAgent, connID)
 			}
 		}
+		if cfg.kamAgentCfg.Mode != utils.MetaCGRateS &&
+			cfg.kamAgentCfg.Mode != utils.MetaJSON {
+			return fmt.Errorf("<%s> unsupported mode: <%s>", utils.KamailioAgent, cfg.kamAgentCfg.Mode)
+		}
+		for _, req := range cfg.kamAgentCfg.RequestProcessors {
+			for _, field := range req.RequestFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.KamailioAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+				if err := utils.IsPathValidForExporters(field.Path); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.KamailioAgent, err, field.Path, utils.Path)
+				}
+				for _, val := range field.Value {
+					if err := utils.IsPathValidForExporters(val.path); err != nil {
+						return fmt.Errorf("<%s> %s for %s at %s of %s", utils.KamailioAgent, err, val.path, utils.Values, utils.RequestFieldsCfg)
+					}
+				}
+				if err := utils.CheckInLineFilter(field.Filters); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.KamailioAgent, err, field.Filters, utils.RequestFieldsCfg)
+				}
+			}
+			for _, field := range req.ReplyFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.KamailioAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+				if err := utils.IsPathValidForExporters(field.Path); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.KamailioAgent, err, field.Path, utils.Path)
+				}
+				for _, val := range field.Value {
+					if err := utils.IsPathValidForExporters(val.path); err != nil {
+						return fmt.Errorf("<%s> %s for %s at %s of %s", utils.KamailioAgent, err, val.path, utils.Values, utils.ReplyFieldsCfg)
+					}
+				}
+				if err := utils.CheckInLineFilter(field.Filters); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.KamailioAgent, err, field.Filters, utils.ReplyFieldsCfg)
+				}
+			}
+			if err := utils.CheckInLineFilter(req.Filters); err != nil {
+				return fmt.Errorf("<%s> %s for %s at %s", utils.KamailioAgent, err, req.Filters, utils.RequestProcessorsCfg)
+			}
+		}
 	}
 	// AsteriskAgent checks
 	if cfg.asteriskAgentCfg.Enabled {
@@ -435,6 +508,15 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.DNSAgent, connID)
 			}
 		}
+		for _, lstn := range cfg.dnsAgentCfg.Listeners {
+			if lstn.Address == utils.EmptyString {
+				return fmt.Errorf("<%s> empty %s for %s", utils.DNSAgent, utils.AddressCfg, utils.ListenersCfg)
+			}
+		}
+		if len(cfg.dnsAgentCfg.UpstreamServers) != 0 &&
+			!utils.SliceHasMember([]string{utils.UDP, utils.TCP, utils.TCP + "-" + utils.TLSNoCaps}, cfg.dnsAgentCfg.UpstreamNet) {
+			return fmt.Errorf("<%s> unsupported %s: <%s>", utils.DNSAgent, utils.UpstreamNetCfg, cfg.dnsAgentCfg.UpstreamNet)
+		}
 		for _, req := range cfg.dnsAgentCfg.RequestProcessors {
 			for _, field := range req.RequestFields {
 				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
@@ -473,6 +555,71 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// WebSocketAgent checks
+	if cfg.wsAgentCfg.Enabled {
+		if len(cfg.wsAgentCfg.SessionSConns) == 0 {
+			return fmt.Errorf("<%s> no %s connections defined",
+				utils.WebSocketAgent, utils.SessionS)
+		}
+		for _, connID := range cfg.wsAgentCfg.SessionSConns {
+			isInternal := strings.HasPrefix(connID, utils.MetaInternal) || strings.HasPrefix(connID, rpcclient.BiRPCInternal)
+			if isInternal && !cfg.sessionSCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.SessionS, utils.WebSocketAgent)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !isInternal {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.WebSocketAgent, connID)
+			}
+		}
+		if cfg.wsAgentCfg.URL == utils.EmptyString {
+			return fmt.Errorf("<%s> empty %s", utils.WebSocketAgent, utils.URLCfg)
+		}
+		for _, origin := range cfg.wsAgentCfg.AllowedOrigins {
+			if origin == utils.MetaAny {
+				continue
+			}
+			if u, err := url.Parse(origin); err != nil ||
+				u.Scheme == utils.EmptyString || u.Host == utils.EmptyString {
+				return fmt.Errorf("<%s> invalid origin <%s> in %s", utils.WebSocketAgent, origin, utils.AllowedOriginsCfg)
+			}
+		}
+		for _, req := range cfg.wsAgentCfg.RequestProcessors {
+			for _, field := range req.RequestFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.WebSocketAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+				if err := utils.IsPathValidForExporters(field.Path); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.WebSocketAgent, err, field.Path, utils.Path)
+				}
+				for _, val := range field.Value {
+					if err := utils.IsPathValidForExporters(val.path); err != nil {
+						return fmt.Errorf("<%s> %s for %s at %s of %s", utils.WebSocketAgent, err, val.path, utils.Values, utils.RequestFieldsCfg)
+					}
+				}
+				if err := utils.CheckInLineFilter(field.Filters); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.WebSocketAgent, err, field.Filters, utils.RequestFieldsCfg)
+				}
+			}
+			for _, field := range req.ReplyFields {
+				if field.Type != utils.MetaNone && field.Path == utils.EmptyString {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.WebSocketAgent, utils.NewErrMandatoryIeMissing(utils.Path), req.ID, field.Tag)
+				}
+				if err := utils.IsPathValidForExporters(field.Path); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.WebSocketAgent, err, field.Path, utils.Path)
+				}
+				for _, val := range field.Value {
+					if err := utils.IsPathValidForExporters(val.path); err != nil {
+						return fmt.Errorf("<%s> %s for %s at %s of %s", utils.WebSocketAgent, err, val.path, utils.Values, utils.ReplyFieldsCfg)
+					}
+				}
+				if err := utils.CheckInLineFilter(field.Filters); err != nil {
+					return fmt.Errorf("<%s> %s for %s at %s", utils.WebSocketAgent, err, field.Filters, utils.ReplyFieldsCfg)
+				}
+			}
+			if err := utils.CheckInLineFilter(req.Filters); err != nil {
+				return fmt.Errorf("<%s> %s for %s at %s", utils.WebSocketAgent, err, req.Filters, utils.RequestProcessorsCfg)
+			}
+		}
+	}
 	// HTTPAgent checks
 	for _, httpAgentCfg := range cfg.httpAgentCfg {
 		// httpAgent checks
@@ -587,6 +734,15 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		if cfg.attributeSCfg.Opts.ProcessRuns < 1 {
 			return fmt.Errorf("<%s> process_runs needs to be bigger than 0", utils.AttributeS)
 		}
+		for connID, dsn := range cfg.attributeSCfg.SQLConns {
+			u, err := url.Parse(dsn)
+			if err != nil {
+				return fmt.Errorf("<%s> invalid DSN for %s <%s>: %s", utils.AttributeS, utils.SQLConnsCfg, connID, err)
+			}
+			if u.Scheme != utils.MySQL && u.Scheme != utils.Postgres {
+				return fmt.Errorf("<%s> unsupported db type <%s> for %s <%s>", utils.AttributeS, u.Scheme, utils.SQLConnsCfg, connID)
+			}
+		}
 	}
 	if cfg.chargerSCfg.Enabled {
 		for _, connID := range cfg.chargerSCfg.AttributeSConns {
@@ -620,6 +776,56 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	// FraudS checks
+	if cfg.fraudSCfg.Enabled {
+		for _, connID := range cfg.fraudSCfg.EEsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.eesCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.EEs, utils.FraudS)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.FraudS, connID)
+			}
+		}
+		for _, expID := range cfg.fraudSCfg.EEsExporterIDs {
+			has := false
+			for _, ee := range cfg.eesCfg.Exporters {
+				if ee.ID == expID {
+					has = true
+					break
+				}
+			}
+			if !has {
+				return fmt.Errorf("<%s> cannot find exporter with ID: <%s>", utils.FraudS, expID)
+			}
+		}
+		for _, rule := range cfg.fraudSCfg.Rules {
+			if rule.ID == utils.EmptyString {
+				return fmt.Errorf("<%s> rule without ID", utils.FraudS)
+			}
+			switch rule.Type {
+			case utils.MetaVelocity:
+				if rule.MaxCost <= 0 && rule.MaxCalls <= 0 {
+					return fmt.Errorf("<%s> rule <%s>: 'max_cost' or 'max_calls' should be greater than 0", utils.FraudS, rule.ID)
+				}
+				if rule.Window <= 0 {
+					return fmt.Errorf("<%s> rule <%s>: 'window' should be greater than 0", utils.FraudS, rule.ID)
+				}
+			case utils.MetaNewDestination:
+				if rule.Window <= 0 {
+					return fmt.Errorf("<%s> rule <%s>: 'window' should be greater than 0", utils.FraudS, rule.ID)
+				}
+			case utils.MetaConcurrentCalls:
+				if rule.MaxConcurrent <= 0 {
+					return fmt.Errorf("<%s> rule <%s>: 'max_concurrent' should be greater than 0", utils.FraudS, rule.ID)
+				}
+			default:
+				return fmt.Errorf("<%s> rule <%s>: unsupported type <%s>", utils.FraudS, rule.ID, rule.Type)
+			}
+			if rule.BlockPeriod < 0 {
+				return fmt.Errorf("<%s> rule <%s>: 'block_period' should not be negative", utils.FraudS, rule.ID)
+			}
+		}
+	}
 	// RouteS checks
 	if cfg.routeSCfg.Enabled {
 		for _, connID := range cfg.routeSCfg.AttributeSConns {
@@ -654,6 +860,23 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.RouteS, connID)
 			}
 		}
+		for rID, rb := range cfg.routeSCfg.CircuitBreakers {
+			if rb.Failures < 0 {
+				return fmt.Errorf("<%s> circuit breaker <%s>: 'failures' should not be negative", utils.RouteS, rID)
+			}
+			if rb.FailureRatio < 0 || rb.FailureRatio > 1 {
+				return fmt.Errorf("<%s> circuit breaker <%s>: 'failure_ratio' should be between 0 and 1", utils.RouteS, rID)
+			}
+			if rb.FailureRatio > 0 && rb.Window <= 0 {
+				return fmt.Errorf("<%s> circuit breaker <%s>: 'window' should be greater than 0 when 'failure_ratio' is used", utils.RouteS, rID)
+			}
+			if rb.OpenPeriod <= 0 {
+				return fmt.Errorf("<%s> circuit breaker <%s>: 'open_period' should be greater than 0", utils.RouteS, rID)
+			}
+			if rb.HalfOpenProbes <= 0 {
+				return fmt.Errorf("<%s> circuit breaker <%s>: 'half_open_probes' should be greater than 0", utils.RouteS, rID)
+			}
+		}
 	}
 	// Scheduler check connection with CDR Server
 	if cfg.schedulerCfg.Enabled {
@@ -945,6 +1168,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			}
 		}
 	}
+	if len(cfg.dataDbCfg.StandbyDBs) != 0 && cfg.dataDbCfg.FailoverInterval <= 0 {
+		return fmt.Errorf("<%s> the failover_interval needs to be greater than 0 when standby_dbs are defined, received : %s",
+			utils.DataDB, cfg.dataDbCfg.FailoverInterval)
+	}
+	if len(cfg.dataDbCfg.StandbyDBs) != 0 && cfg.dataDbCfg.FailoverFailures <= 0 {
+		return fmt.Errorf("<%s> the failover_failures needs to be greater than 0 when standby_dbs are defined, received : %d",
+			utils.DataDB, cfg.dataDbCfg.FailoverFailures)
+	}
 	// APIer sanity checks
 	for _, connID := range cfg.apier.AttributeSConns {
 		if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.attributeSCfg.Enabled {
@@ -962,6 +1193,19 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.APIerSv1, connID)
 		}
 	}
+	if cfg.apier.AuditLog && len(cfg.apier.AuditEEsIDs) != 0 {
+		if len(cfg.apier.EEsConns) == 0 {
+			return fmt.Errorf("<%s> the audit_ees_ids need ees_conns to be defined", utils.APIerSv1)
+		}
+		for _, connID := range cfg.apier.EEsConns {
+			if strings.HasPrefix(connID, utils.MetaInternal) && !cfg.eesCfg.Enabled {
+				return fmt.Errorf("<%s> not enabled but requested by <%s> component", utils.EEs, utils.APIerSv1)
+			}
+			if _, has := cfg.rpcConns[connID]; !has && !strings.HasPrefix(connID, utils.MetaInternal) {
+				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.APIerSv1, connID)
+			}
+		}
+	}
 	// Dispatcher sanity check
 	if cfg.dispatcherSCfg.Enabled {
 		for _, connID := range cfg.dispatcherSCfg.AttributeSConns {
@@ -972,6 +1216,14 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 				return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.DispatcherS, connID)
 			}
 		}
+		if cfg.dispatcherSCfg.HealthCheckInterval > 0 {
+			if cfg.dispatcherSCfg.HealthyThreshold < 1 {
+				return fmt.Errorf("<%s> %s needs to be at least 1", utils.DispatcherS, utils.HealthyThresholdCfg)
+			}
+			if cfg.dispatcherSCfg.UnhealthyThreshold < 1 {
+				return fmt.Errorf("<%s> %s needs to be at least 1", utils.DispatcherS, utils.UnhealthyThresholdCfg)
+			}
+		}
 	}
 	// Cache check
 	for _, connID := range cfg.cacheCfg.ReplicationConns {
@@ -1019,6 +1271,11 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 			return fmt.Errorf("<%s> connection with id: <%s> not defined", utils.FilterS, connID)
 		}
 	}
+	if cfg.filterSCfg.GeoIPDB != utils.EmptyString {
+		if _, err := os.Stat(cfg.filterSCfg.GeoIPDB); err != nil && os.IsNotExist(err) {
+			return fmt.Errorf("<%s> nonexistent GeoIP database: %s", utils.FilterS, cfg.filterSCfg.GeoIPDB)
+		}
+	}
 
 	if len(cfg.registrarCCfg.Dispatchers.RegistrarSConns) != 0 {
 		if len(cfg.registrarCCfg.Dispatchers.Hosts) == 0 {
@@ -1100,5 +1357,25 @@ func (cfg *CGRConfig) checkConfigSanity() error {
 		}
 	}
 
+	if err := cfg.apiAuthCfg.checkSanity(); err != nil {
+		return err
+	}
+
+	if cfg.quotasCfg.Enabled {
+		for tnt, tq := range cfg.quotasCfg.Tenants {
+			if tq.APIRequests < 0 || tq.Sessions < 0 || tq.CDRsRate < 0 {
+				return fmt.Errorf("<%s> negative quota for tenant <%s>", QuotasJson, tnt)
+			}
+			for itmType, limit := range tq.Objects {
+				if !utils.TenantQuotaObjects.Has(itmType) {
+					return fmt.Errorf("<%s> unsupported objects type <%s> for tenant <%s>", QuotasJson, itmType, tnt)
+				}
+				if limit < 0 {
+					return fmt.Errorf("<%s> negative %s quota for tenant <%s>", QuotasJson, itmType, tnt)
+				}
+			}
+		}
+	}
+
 	return nil
 }
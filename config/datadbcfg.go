@@ -29,19 +29,22 @@ import (
 
 // DataDbCfg Database config
 type DataDbCfg struct {
-	Type        string
-	Host        string   // The host to connect to. Values that start with / are for UNIX domain sockets.
-	Port        string   // The port to bind to.
-	Name        string   // The name of the database to connect to.
-	User        string   // The user to sign in as.
-	Password    string   // The user's password.
-	RmtConns    []string // Remote DataDB  connIDs
-	RmtConnID   string
-	RplConns    []string // Replication connIDs
-	RplFiltered bool
-	RplCache    string
-	Items       map[string]*ItemOpt
-	Opts        map[string]interface{}
+	Type             string
+	Host             string   // The host to connect to. Values that start with / are for UNIX domain sockets.
+	Port             string   // The port to bind to.
+	Name             string   // The name of the database to connect to.
+	User             string   // The user to sign in as.
+	Password         string   // The user's password.
+	RmtConns         []string // Remote DataDB  connIDs
+	RmtConnID        string
+	RplConns         []string // Replication connIDs
+	RplFiltered      bool
+	RplCache         string
+	Items            map[string]*ItemOpt
+	Opts             map[string]interface{}
+	StandbyDBs       []*DataDbStandbyCfg // promoted in this order when the active DataDB fails
+	FailoverInterval time.Duration
+	FailoverFailures int // consecutive failed checks before a DataDB is considered unavailable
 }
 
 // loadFromJSONCfg loads Database config from JsonCfg
@@ -117,23 +120,53 @@ func (dbcfg *DataDbCfg) loadFromJSONCfg(jsnDbCfg *DbJsonCfg) (err error) {
 	if jsnDbCfg.Replication_cache != nil {
 		dbcfg.RplCache = *jsnDbCfg.Replication_cache
 	}
+	if jsnDbCfg.Standby_dbs != nil {
+		dbcfg.StandbyDBs = make([]*DataDbStandbyCfg, len(*jsnDbCfg.Standby_dbs))
+		for i, jsnStandby := range *jsnDbCfg.Standby_dbs {
+			standby := &DataDbStandbyCfg{
+				Type: dbcfg.Type,
+				Opts: make(map[string]interface{}),
+			}
+			for k, v := range dbcfg.Opts { // inherit the options of the primary DataDB
+				standby.Opts[k] = v
+			}
+			standby.loadFromJSONCfg(jsnStandby)
+			if standby.Port == utils.EmptyString {
+				standby.Port = dbDefaultsCfg.dbPort(standby.Type, utils.MetaDynamic)
+			}
+			if standby.Name == utils.EmptyString {
+				standby.Name = dbDefaultsCfg.dbName(standby.Type, utils.MetaDynamic)
+			}
+			dbcfg.StandbyDBs[i] = standby
+		}
+	}
+	if jsnDbCfg.Failover_interval != nil {
+		if dbcfg.FailoverInterval, err = utils.ParseDurationWithNanosecs(*jsnDbCfg.Failover_interval); err != nil {
+			return
+		}
+	}
+	if jsnDbCfg.Failover_failures != nil {
+		dbcfg.FailoverFailures = *jsnDbCfg.Failover_failures
+	}
 	return
 }
 
 // Clone returns the cloned object
 func (dbcfg *DataDbCfg) Clone() (cln *DataDbCfg) {
 	cln = &DataDbCfg{
-		Type:        dbcfg.Type,
-		Host:        dbcfg.Host,
-		Port:        dbcfg.Port,
-		Name:        dbcfg.Name,
-		User:        dbcfg.User,
-		Password:    dbcfg.Password,
-		RplFiltered: dbcfg.RplFiltered,
-		RplCache:    dbcfg.RplCache,
-		RmtConnID:   dbcfg.RmtConnID,
-		Items:       make(map[string]*ItemOpt),
-		Opts:        make(map[string]interface{}),
+		Type:             dbcfg.Type,
+		Host:             dbcfg.Host,
+		Port:             dbcfg.Port,
+		Name:             dbcfg.Name,
+		User:             dbcfg.User,
+		Password:         dbcfg.Password,
+		RplFiltered:      dbcfg.RplFiltered,
+		RplCache:         dbcfg.RplCache,
+		RmtConnID:        dbcfg.RmtConnID,
+		Items:            make(map[string]*ItemOpt),
+		Opts:             make(map[string]interface{}),
+		FailoverInterval: dbcfg.FailoverInterval,
+		FailoverFailures: dbcfg.FailoverFailures,
 	}
 	for k, itm := range dbcfg.Items {
 		cln.Items[k] = itm.Clone()
@@ -154,6 +187,12 @@ func (dbcfg *DataDbCfg) Clone() (cln *DataDbCfg) {
 			cln.RplConns[i] = conn
 		}
 	}
+	if dbcfg.StandbyDBs != nil {
+		cln.StandbyDBs = make([]*DataDbStandbyCfg, len(dbcfg.StandbyDBs))
+		for i, standby := range dbcfg.StandbyDBs {
+			cln.StandbyDBs[i] = standby.Clone()
+		}
+	}
 	return
 }
 
@@ -170,12 +209,22 @@ func (dbcfg *DataDbCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.ReplicationConnsCfg:    dbcfg.RplConns,
 		utils.ReplicationFilteredCfg: dbcfg.RplFiltered,
 		utils.ReplicationCache:       dbcfg.RplCache,
+		utils.FailoverIntervalCfg:    "0",
+		utils.FailoverFailuresCfg:    dbcfg.FailoverFailures,
 	}
 	opts := make(map[string]interface{})
 	for k, v := range dbcfg.Opts {
 		opts[k] = v
 	}
 	initialMP[utils.OptsCfg] = opts
+	standbyDBs := make([]map[string]interface{}, len(dbcfg.StandbyDBs))
+	for i, standby := range dbcfg.StandbyDBs {
+		standbyDBs[i] = standby.AsMapInterface()
+	}
+	initialMP[utils.StandbyDBsCfg] = standbyDBs
+	if dbcfg.FailoverInterval != 0 {
+		initialMP[utils.FailoverIntervalCfg] = dbcfg.FailoverInterval.String()
+	}
 	if dbcfg.Items != nil {
 		items := make(map[string]interface{})
 		for key, item := range dbcfg.Items {
@@ -189,6 +238,85 @@ func (dbcfg *DataDbCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	return
 }
 
+// DataDbStandbyCfg is one standby DataDB promoted on failover
+type DataDbStandbyCfg struct {
+	Type     string
+	Host     string
+	Port     string
+	Name     string
+	User     string
+	Password string
+	Opts     map[string]interface{}
+}
+
+func (sbCfg *DataDbStandbyCfg) loadFromJSONCfg(jsnCfg *DbStandbyJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Db_type != nil {
+		sbCfg.Type = strings.TrimPrefix(*jsnCfg.Db_type, "*")
+	}
+	if jsnCfg.Db_host != nil {
+		sbCfg.Host = *jsnCfg.Db_host
+	}
+	if jsnCfg.Db_port != nil {
+		port := strconv.Itoa(*jsnCfg.Db_port)
+		if port == "-1" {
+			port = utils.MetaDynamic
+		}
+		sbCfg.Port = dbDefaultsCfg.dbPort(sbCfg.Type, port)
+	}
+	if jsnCfg.Db_name != nil {
+		sbCfg.Name = *jsnCfg.Db_name
+	}
+	if jsnCfg.Db_user != nil {
+		sbCfg.User = *jsnCfg.Db_user
+	}
+	if jsnCfg.Db_password != nil {
+		sbCfg.Password = *jsnCfg.Db_password
+	}
+	for k, v := range jsnCfg.Opts {
+		sbCfg.Opts[k] = v
+	}
+}
+
+// Clone returns a deep copy of DataDbStandbyCfg
+func (sbCfg *DataDbStandbyCfg) Clone() (cln *DataDbStandbyCfg) {
+	cln = &DataDbStandbyCfg{
+		Type:     sbCfg.Type,
+		Host:     sbCfg.Host,
+		Port:     sbCfg.Port,
+		Name:     sbCfg.Name,
+		User:     sbCfg.User,
+		Password: sbCfg.Password,
+		Opts:     make(map[string]interface{}),
+	}
+	for k, v := range sbCfg.Opts {
+		cln.Opts[k] = v
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (sbCfg *DataDbStandbyCfg) AsMapInterface() (initialMP map[string]interface{}) {
+	initialMP = map[string]interface{}{
+		utils.DataDbTypeCfg: utils.Meta + sbCfg.Type,
+		utils.DataDbHostCfg: sbCfg.Host,
+		utils.DataDbNameCfg: sbCfg.Name,
+		utils.DataDbUserCfg: sbCfg.User,
+		utils.DataDbPassCfg: sbCfg.Password,
+	}
+	opts := make(map[string]interface{})
+	for k, v := range sbCfg.Opts {
+		opts[k] = v
+	}
+	initialMP[utils.OptsCfg] = opts
+	if sbCfg.Port != "" {
+		initialMP[utils.DataDbPortCfg], _ = strconv.Atoi(sbCfg.Port)
+	}
+	return
+}
+
 // ItemOpt the options for the stored items
 type ItemOpt struct {
 	Limit     int
@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -569,3 +570,87 @@ func TestCloneDataDB(t *testing.T) {
 		}
 	}
 }
+
+func TestDataDbCfgStandbyDBs(t *testing.T) {
+	cfgJSONStr := `{
+	"data_db": {
+		"db_type": "*redis",
+		"db_host": "127.0.0.1",
+		"opts": {
+			"redisSentinel": "sentinel",
+		},
+		"standby_dbs": [
+			{"db_host": "127.0.0.2"},
+			{"db_host": "127.0.0.3", "db_port": 6380, "db_name": "11", "opts": {"redisSentinel": ""}},
+		],
+		"failover_interval": "500ms",
+		"failover_failures": 2,
+	},
+}`
+	eStandbyDBs := []*DataDbStandbyCfg{
+		{
+			Type: utils.Redis,
+			Host: "127.0.0.2",
+			Port: "6379",
+			Name: "10",
+			Opts: map[string]interface{}{
+				utils.RedisSentinelNameCfg:       "sentinel",
+				utils.MongoQueryTimeoutCfg:       "10s",
+				utils.RedisClusterCfg:            false,
+				utils.RedisClusterOnDownDelayCfg: "0",
+				utils.RedisClusterSyncCfg:        "5s",
+				utils.RedisTLS:                   false,
+				utils.RedisClientCertificate:     "",
+				utils.RedisClientKey:             "",
+				utils.RedisCACertificate:         "",
+			},
+		},
+		{
+			Type: utils.Redis,
+			Host: "127.0.0.3",
+			Port: "6380",
+			Name: "11",
+			Opts: map[string]interface{}{
+				utils.RedisSentinelNameCfg:       "",
+				utils.MongoQueryTimeoutCfg:       "10s",
+				utils.RedisClusterCfg:            false,
+				utils.RedisClusterOnDownDelayCfg: "0",
+				utils.RedisClusterSyncCfg:        "5s",
+				utils.RedisTLS:                   false,
+				utils.RedisClientCertificate:     "",
+				utils.RedisClientKey:             "",
+				utils.RedisCACertificate:         "",
+			},
+		},
+	}
+	cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(eStandbyDBs, cgrCfg.DataDbCfg().StandbyDBs) {
+		t.Errorf("Expected %s \n, received %s", utils.ToJSON(eStandbyDBs), utils.ToJSON(cgrCfg.DataDbCfg().StandbyDBs))
+	}
+	if cgrCfg.DataDbCfg().FailoverInterval != 500*time.Millisecond {
+		t.Errorf("Expected %v, received %v", 500*time.Millisecond, cgrCfg.DataDbCfg().FailoverInterval)
+	}
+	if cgrCfg.DataDbCfg().FailoverFailures != 2 {
+		t.Errorf("Expected %v, received %v", 2, cgrCfg.DataDbCfg().FailoverFailures)
+	}
+	if rcv := cgrCfg.DataDbCfg().Clone(); !reflect.DeepEqual(cgrCfg.DataDbCfg().StandbyDBs, rcv.StandbyDBs) {
+		t.Errorf("Expected %s \n, received %s", utils.ToJSON(cgrCfg.DataDbCfg().StandbyDBs), utils.ToJSON(rcv.StandbyDBs))
+	}
+	rcv := cgrCfg.DataDbCfg().AsMapInterface()
+	if rcv[utils.FailoverIntervalCfg] != "500ms" {
+		t.Errorf("Expected %q, received %v", "500ms", rcv[utils.FailoverIntervalCfg])
+	}
+	if rcv[utils.FailoverFailuresCfg] != 2 {
+		t.Errorf("Expected %v, received %v", 2, rcv[utils.FailoverFailuresCfg])
+	}
+	if standbyDBs, can := rcv[utils.StandbyDBsCfg].([]map[string]interface{}); !can || len(standbyDBs) != 2 {
+		t.Errorf("Unexpected standby_dbs: %s", utils.ToJSON(rcv[utils.StandbyDBsCfg]))
+	} else if standbyDBs[1][utils.DataDbPortCfg] != 6380 ||
+		standbyDBs[1][utils.DataDbTypeCfg] != utils.Meta+utils.Redis ||
+		standbyDBs[1][utils.DataDbHostCfg] != "127.0.0.3" {
+		t.Errorf("Unexpected standby_db: %s", utils.ToJSON(standbyDBs[1]))
+	}
+}
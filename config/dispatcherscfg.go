@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -32,6 +34,9 @@ type DispatcherSCfg struct {
 	AttributeSConns     []string
 	NestedFields        bool
 	AnySubsystem        bool
+	HealthCheckInterval time.Duration // 0 to disable the health checks
+	HealthyThreshold    int
+	UnhealthyThreshold  int
 }
 
 func (dps *DispatcherSCfg) loadFromJSONCfg(jsnCfg *DispatcherSJsonCfg) (err error) {
@@ -81,6 +86,17 @@ func (dps *DispatcherSCfg) loadFromJSONCfg(jsnCfg *DispatcherSJsonCfg) (err erro
 	if jsnCfg.Any_subsystem != nil {
 		dps.AnySubsystem = *jsnCfg.Any_subsystem
 	}
+	if jsnCfg.Health_check_interval != nil {
+		if dps.HealthCheckInterval, err = utils.ParseDurationWithNanosecs(*jsnCfg.Health_check_interval); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Healthy_threshold != nil {
+		dps.HealthyThreshold = *jsnCfg.Healthy_threshold
+	}
+	if jsnCfg.Unhealthy_threshold != nil {
+		dps.UnhealthyThreshold = *jsnCfg.Unhealthy_threshold
+	}
 	return nil
 }
 
@@ -91,6 +107,12 @@ func (dps *DispatcherSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.IndexedSelectsCfg: dps.IndexedSelects,
 		utils.NestedFieldsCfg:   dps.NestedFields,
 		utils.AnySubsystemCfg:   dps.AnySubsystem,
+		utils.HealthCheckIntervalCfg: "0",
+		utils.HealthyThresholdCfg:    dps.HealthyThreshold,
+		utils.UnhealthyThresholdCfg:  dps.UnhealthyThreshold,
+	}
+	if dps.HealthCheckInterval != 0 {
+		initialMP[utils.HealthCheckIntervalCfg] = dps.HealthCheckInterval.String()
 	}
 	if dps.StringIndexedFields != nil {
 		stringIndexedFields := make([]string, len(*dps.StringIndexedFields))
@@ -133,6 +155,9 @@ func (dps DispatcherSCfg) Clone() (cln *DispatcherSCfg) {
 		IndexedSelects: dps.IndexedSelects,
 		NestedFields:   dps.NestedFields,
 		AnySubsystem:   dps.AnySubsystem,
+		HealthCheckInterval: dps.HealthCheckInterval,
+		HealthyThreshold:    dps.HealthyThreshold,
+		UnhealthyThreshold:  dps.UnhealthyThreshold,
 	}
 
 	if dps.AttributeSConns != nil {
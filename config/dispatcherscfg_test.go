@@ -21,6 +21,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -35,6 +36,9 @@ func TestDispatcherSCfgloadFromJsonCfg(t *testing.T) {
 		Attributes_conns:      &[]string{utils.MetaInternal, "*conn1"},
 		Nested_fields:         utils.BoolPointer(true),
 		Any_subsystem:         utils.BoolPointer(true),
+		Health_check_interval: utils.StringPointer("30s"),
+		Healthy_threshold:     utils.IntPointer(1),
+		Unhealthy_threshold:   utils.IntPointer(5),
 	}
 	expected := &DispatcherSCfg{
 		Enabled:             true,
@@ -45,6 +49,9 @@ func TestDispatcherSCfgloadFromJsonCfg(t *testing.T) {
 		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		NestedFields:        true,
 		AnySubsystem:        true,
+		HealthCheckInterval: 30 * time.Second,
+		HealthyThreshold:    1,
+		UnhealthyThreshold:  5,
 	}
 	jsnCfg := NewDefaultCGRConfig()
 	if err = jsnCfg.dispatcherSCfg.loadFromJSONCfg(jsonCfg); err != nil {
@@ -74,6 +81,9 @@ func TestDispatcherSCfgAsMapInterface(t *testing.T) {
 		utils.NestedFieldsCfg:        false,
 		utils.AttributeSConnsCfg:     []string{},
 		utils.AnySubsystemCfg:        true,
+		utils.HealthCheckIntervalCfg: "0",
+		utils.HealthyThresholdCfg:    2,
+		utils.UnhealthyThresholdCfg:  3,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -92,6 +102,7 @@ func TestDispatcherSCfgAsMapInterface1(t *testing.T) {
             "suffix_indexed_fields": ["*req.prefix"],
 			"nested_fields": false,
 			"attributes_conns": ["*internal:*attributes", "*conn1"],
+			"health_check_interval": "10s",
 		},
 		
 }`
@@ -104,6 +115,9 @@ func TestDispatcherSCfgAsMapInterface1(t *testing.T) {
 		utils.NestedFieldsCfg:        false,
 		utils.AttributeSConnsCfg:     []string{"*internal", "*conn1"},
 		utils.AnySubsystemCfg:        true,
+		utils.HealthCheckIntervalCfg: "10s",
+		utils.HealthyThresholdCfg:    2,
+		utils.UnhealthyThresholdCfg:  3,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -124,6 +138,9 @@ func TestDispatcherSCfgAsMapInterface2(t *testing.T) {
 		utils.NestedFieldsCfg:        false,
 		utils.AttributeSConnsCfg:     []string{},
 		utils.AnySubsystemCfg:        true,
+		utils.HealthCheckIntervalCfg: "0",
+		utils.HealthyThresholdCfg:    2,
+		utils.UnhealthyThresholdCfg:  3,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -141,6 +158,9 @@ func TestDispatcherSCfgClone(t *testing.T) {
 		AttributeSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
 		NestedFields:        true,
 		AnySubsystem:        true,
+		HealthCheckInterval: time.Minute,
+		HealthyThreshold:    2,
+		UnhealthyThreshold:  3,
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
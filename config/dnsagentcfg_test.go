@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -29,8 +30,15 @@ func TestDNSAgentCfgloadFromJsonCfg(t *testing.T) {
 		Enabled:        utils.BoolPointer(true),
 		Listen:         utils.StringPointer("127.0.0.1:2053"),
 		Listen_net:     utils.StringPointer("udp"),
-		Sessions_conns: &[]string{utils.MetaInternal, "*conn1"},
-		Timezone:       utils.StringPointer("UTC"),
+		Listeners: &[]*DNSListenerJsnCfg{
+			{Address: utils.StringPointer("127.0.0.1:2053"), Network: utils.StringPointer(utils.TCP)},
+		},
+		Sessions_conns:       &[]string{utils.MetaInternal, "*conn1"},
+		Timezone:             utils.StringPointer("UTC"),
+		Upstream_servers:     &[]string{"127.0.0.1:53"},
+		Upstream_net:         utils.StringPointer(utils.TCP),
+		Upstream_timeout:     utils.StringPointer("1s"),
+		Upstream_cache_limit: utils.IntPointer(100),
 		Request_processors: &[]*ReqProcessorJsnCfg{
 			{
 				ID:             utils.StringPointer("OutboundAUTHDryRun"),
@@ -46,11 +54,18 @@ func TestDNSAgentCfgloadFromJsonCfg(t *testing.T) {
 		},
 	}
 	expected := &DNSAgentCfg{
-		Enabled:       true,
-		Listen:        "127.0.0.1:2053",
-		ListenNet:     "udp",
-		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
-		Timezone:      "UTC",
+		Enabled:   true,
+		Listen:    "127.0.0.1:2053",
+		ListenNet: "udp",
+		Listeners: []*DNSListener{
+			{Address: "127.0.0.1:2053", Network: utils.TCP},
+		},
+		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		Timezone:           "UTC",
+		UpstreamServers:    []string{"127.0.0.1:53"},
+		UpstreamNet:        utils.TCP,
+		UpstreamTimeout:    time.Second,
+		UpstreamCacheLimit: 100,
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -196,12 +211,17 @@ func TestDNSAgentCfgAsMapInterface(t *testing.T) {
 	},
 }`
 	eMap := map[string]interface{}{
-		utils.EnabledCfg:           false,
-		utils.ListenCfg:            "127.0.0.1:2053",
-		utils.ListenNetCfg:         "udp",
-		utils.SessionSConnsCfg:     []string{"*internal"},
-		utils.TimezoneCfg:          "",
-		utils.RequestProcessorsCfg: []map[string]interface{}{},
+		utils.EnabledCfg:            false,
+		utils.ListenCfg:             "127.0.0.1:2053",
+		utils.ListenNetCfg:          "udp",
+		utils.ListenersCfg:          []map[string]interface{}{},
+		utils.SessionSConnsCfg:      []string{"*internal"},
+		utils.TimezoneCfg:           "",
+		utils.UpstreamServersCfg:    []string{},
+		utils.UpstreamNetCfg:        "udp",
+		utils.UpstreamTimeoutCfg:    "2s",
+		utils.UpstreamCacheLimitCfg: -1,
+		utils.RequestProcessorsCfg:  []map[string]interface{}{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -216,8 +236,13 @@ func TestDNSAgentCfgAsMapInterface1(t *testing.T) {
 			"enabled": false,
 			"listen": "127.0.0.1:2053",
 			"listen_net": "udp",
+			"listeners": [
+				{"address": "127.0.0.1:2054", "network": "tcp"},
+			],
 			"sessions_conns": ["*internal:*sessions", "*conn1"],
 			"timezone": "UTC",
+			"upstream_servers": ["127.0.0.1:53"],
+			"upstream_timeout": "0",
 			"request_processors": [
 			{
 				"id": "OutboundAUTHDryRun",
@@ -243,11 +268,18 @@ func TestDNSAgentCfgAsMapInterface1(t *testing.T) {
 		},
 	}`
 	eMap := map[string]interface{}{
-		utils.EnabledCfg:       false,
-		utils.ListenCfg:        "127.0.0.1:2053",
-		utils.ListenNetCfg:     "udp",
-		utils.SessionSConnsCfg: []string{utils.MetaInternal, "*conn1"},
-		utils.TimezoneCfg:      "UTC",
+		utils.EnabledCfg:   false,
+		utils.ListenCfg:    "127.0.0.1:2053",
+		utils.ListenNetCfg: "udp",
+		utils.ListenersCfg: []map[string]interface{}{
+			{utils.AddressCfg: "127.0.0.1:2054", utils.NetworkCfg: utils.TCP},
+		},
+		utils.SessionSConnsCfg:      []string{utils.MetaInternal, "*conn1"},
+		utils.TimezoneCfg:           "UTC",
+		utils.UpstreamServersCfg:    []string{"127.0.0.1:53"},
+		utils.UpstreamNetCfg:        "udp",
+		utils.UpstreamTimeoutCfg:    "0",
+		utils.UpstreamCacheLimitCfg: -1,
 		utils.RequestProcessorsCfg: []map[string]interface{}{
 			{
 				utils.IDCfg:            "OutboundAUTHDryRun",
@@ -299,11 +331,18 @@ func TestRequestProcessorClone(t *testing.T) {
 
 func TestDNSAgentCfgClone(t *testing.T) {
 	ban := &DNSAgentCfg{
-		Enabled:       true,
-		Listen:        "127.0.0.1:2053",
-		ListenNet:     "udp",
-		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
-		Timezone:      "UTC",
+		Enabled:   true,
+		Listen:    "127.0.0.1:2053",
+		ListenNet: "udp",
+		Listeners: []*DNSListener{
+			{Address: "127.0.0.1:2054", Network: utils.TCP},
+		},
+		SessionSConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		Timezone:           "UTC",
+		UpstreamServers:    []string{"127.0.0.1:53"},
+		UpstreamNet:        utils.UDP,
+		UpstreamTimeout:    time.Second,
+		UpstreamCacheLimit: -1,
 		RequestProcessors: []*RequestProcessor{
 			{
 				ID:            "OutboundAUTHDryRun",
@@ -327,4 +366,7 @@ func TestDNSAgentCfgClone(t *testing.T) {
 	if rcv.RequestProcessors[0].ID = ""; ban.RequestProcessors[0].ID != "OutboundAUTHDryRun" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.Listeners[0].Network = utils.UDP; ban.Listeners[0].Network != utils.TCP {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
@@ -19,17 +19,58 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 )
 
+// DNSListener is an additional address the DNSAgent is listening on
+type DNSListener struct {
+	Address string
+	Network string // udp, tcp or tcp-tls
+}
+
+func (dl *DNSListener) loadFromJSONCfg(jsnCfg *DNSListenerJsnCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Address != nil {
+		dl.Address = *jsnCfg.Address
+	}
+	if jsnCfg.Network != nil {
+		dl.Network = *jsnCfg.Network
+	}
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (dl *DNSListener) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.AddressCfg: dl.Address,
+		utils.NetworkCfg: dl.Network,
+	}
+}
+
+// Clone returns a deep copy of DNSListener
+func (dl DNSListener) Clone() *DNSListener {
+	return &DNSListener{
+		Address: dl.Address,
+		Network: dl.Network,
+	}
+}
+
 // DNSAgentCfg the config section that describes the DNS Agent
 type DNSAgentCfg struct {
-	Enabled           bool
-	Listen            string
-	ListenNet         string // udp or tcp
-	SessionSConns     []string
-	Timezone          string
-	RequestProcessors []*RequestProcessor
+	Enabled            bool
+	Listen             string
+	ListenNet          string // udp or tcp
+	Listeners          []*DNSListener
+	SessionSConns      []string
+	Timezone           string
+	UpstreamServers    []string
+	UpstreamNet        string
+	UpstreamTimeout    time.Duration
+	UpstreamCacheLimit int
+	RequestProcessors  []*RequestProcessor
 }
 
 func (da *DNSAgentCfg) loadFromJSONCfg(jsnCfg *DNSAgentJsonCfg, sep string) (err error) {
@@ -45,9 +86,30 @@ func (da *DNSAgentCfg) loadFromJSONCfg(jsnCfg *DNSAgentJsonCfg, sep string) (err
 	if jsnCfg.Listen != nil {
 		da.Listen = *jsnCfg.Listen
 	}
+	if jsnCfg.Listeners != nil {
+		da.Listeners = make([]*DNSListener, len(*jsnCfg.Listeners))
+		for i, lstnJsn := range *jsnCfg.Listeners {
+			da.Listeners[i] = new(DNSListener)
+			da.Listeners[i].loadFromJSONCfg(lstnJsn)
+		}
+	}
 	if jsnCfg.Timezone != nil {
 		da.Timezone = *jsnCfg.Timezone
 	}
+	if jsnCfg.Upstream_servers != nil {
+		da.UpstreamServers = utils.CloneStringSlice(*jsnCfg.Upstream_servers)
+	}
+	if jsnCfg.Upstream_net != nil {
+		da.UpstreamNet = *jsnCfg.Upstream_net
+	}
+	if jsnCfg.Upstream_timeout != nil {
+		if da.UpstreamTimeout, err = utils.ParseDurationWithNanosecs(*jsnCfg.Upstream_timeout); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Upstream_cache_limit != nil {
+		da.UpstreamCacheLimit = *jsnCfg.Upstream_cache_limit
+	}
 	if jsnCfg.Sessions_conns != nil {
 		da.SessionSConns = make([]string, len(*jsnCfg.Sessions_conns))
 		for idx, connID := range *jsnCfg.Sessions_conns {
@@ -83,11 +145,23 @@ func (da *DNSAgentCfg) loadFromJSONCfg(jsnCfg *DNSAgentJsonCfg, sep string) (err
 // AsMapInterface returns the config as a map[string]interface{}
 func (da *DNSAgentCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:   da.Enabled,
-		utils.ListenCfg:    da.Listen,
-		utils.ListenNetCfg: da.ListenNet,
-		utils.TimezoneCfg:  da.Timezone,
+		utils.EnabledCfg:            da.Enabled,
+		utils.ListenCfg:             da.Listen,
+		utils.ListenNetCfg:          da.ListenNet,
+		utils.TimezoneCfg:           da.Timezone,
+		utils.UpstreamServersCfg:    utils.CloneStringSlice(da.UpstreamServers),
+		utils.UpstreamNetCfg:        da.UpstreamNet,
+		utils.UpstreamTimeoutCfg:    "0",
+		utils.UpstreamCacheLimitCfg: da.UpstreamCacheLimit,
+	}
+	if da.UpstreamTimeout != 0 {
+		initialMP[utils.UpstreamTimeoutCfg] = da.UpstreamTimeout.String()
+	}
+	listeners := make([]map[string]interface{}, len(da.Listeners))
+	for i, lstn := range da.Listeners {
+		listeners[i] = lstn.AsMapInterface()
 	}
+	initialMP[utils.ListenersCfg] = listeners
 
 	requestProcessors := make([]map[string]interface{}, len(da.RequestProcessors))
 	for i, item := range da.RequestProcessors {
@@ -111,10 +185,22 @@ func (da *DNSAgentCfg) AsMapInterface(separator string) (initialMP map[string]in
 // Clone returns a deep copy of DNSAgentCfg
 func (da DNSAgentCfg) Clone() (cln *DNSAgentCfg) {
 	cln = &DNSAgentCfg{
-		Enabled:   da.Enabled,
-		Listen:    da.Listen,
-		ListenNet: da.ListenNet,
-		Timezone:  da.Timezone,
+		Enabled:            da.Enabled,
+		Listen:             da.Listen,
+		ListenNet:          da.ListenNet,
+		Timezone:           da.Timezone,
+		UpstreamNet:        da.UpstreamNet,
+		UpstreamTimeout:    da.UpstreamTimeout,
+		UpstreamCacheLimit: da.UpstreamCacheLimit,
+	}
+	if da.Listeners != nil {
+		cln.Listeners = make([]*DNSListener, len(da.Listeners))
+		for i, lstn := range da.Listeners {
+			cln.Listeners[i] = lstn.Clone()
+		}
+	}
+	if da.UpstreamServers != nil {
+		cln.UpstreamServers = utils.CloneStringSlice(da.UpstreamServers)
 	}
 	if da.SessionSConns != nil {
 		cln.SessionSConns = make([]string, len(da.SessionSConns))
@@ -27,6 +27,7 @@ type FilterSCfg struct {
 	StatSConns     []string
 	ResourceSConns []string
 	ApierSConns    []string
+	GeoIPDB        string // path to the GeoIP database used by *ipgeo filters
 }
 
 func (fSCfg *FilterSCfg) loadFromJSONCfg(jsnCfg *FilterSJsonCfg) (err error) {
@@ -63,12 +64,17 @@ func (fSCfg *FilterSCfg) loadFromJSONCfg(jsnCfg *FilterSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Geoip_db != nil {
+		fSCfg.GeoIPDB = *jsnCfg.Geoip_db
+	}
 	return
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
 func (fSCfg *FilterSCfg) AsMapInterface() (initialMP map[string]interface{}) {
-	initialMP = make(map[string]interface{})
+	initialMP = map[string]interface{}{
+		utils.GeoIPDBCfg: fSCfg.GeoIPDB,
+	}
 	if fSCfg.StatSConns != nil {
 		statSConns := make([]string, len(fSCfg.StatSConns))
 		for i, item := range fSCfg.StatSConns {
@@ -104,7 +110,9 @@ func (fSCfg *FilterSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 
 // Clone returns a deep copy of FilterSCfg
 func (fSCfg FilterSCfg) Clone() (cln *FilterSCfg) {
-	cln = new(FilterSCfg)
+	cln = &FilterSCfg{
+		GeoIPDB: fSCfg.GeoIPDB,
+	}
 	if fSCfg.StatSConns != nil {
 		cln.StatSConns = make([]string, len(fSCfg.StatSConns))
 		for i, con := range fSCfg.StatSConns {
@@ -55,6 +55,7 @@ func TestFilterSCfgAsMapInterface(t *testing.T) {
 		utils.StatSConnsCfg:     []string{utils.MetaInternal, "*conn1"},
 		utils.ResourceSConnsCfg: []string{utils.MetaInternal, "*conn1"},
 		utils.ApierSConnsCfg:    []string{utils.MetaInternal, "*conn1"},
+		utils.GeoIPDBCfg:        utils.EmptyString,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -71,6 +72,7 @@ func TestFilterSCfgAsMapInterface2(t *testing.T) {
 		utils.StatSConnsCfg:     []string{},
 		utils.ResourceSConnsCfg: []string{},
 		utils.ApierSConnsCfg:    []string{},
+		utils.GeoIPDBCfg:        utils.EmptyString,
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
@@ -84,6 +86,7 @@ func TestFilterSCfgClone(t *testing.T) {
 		StatSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
 		ResourceSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResources), "*conn1"},
 		ApierSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaApier), "*conn1"},
+		GeoIPDB:        "/usr/share/GeoIP/GeoLite2-Country.mmdb",
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
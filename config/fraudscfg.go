@@ -0,0 +1,212 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// FraudSCfg is the configuration of the fraud detection service
+type FraudSCfg struct {
+	Enabled         bool
+	EEsConns        []string
+	EEsExporterIDs  []string      // exporters receiving the fraud alerts
+	MaxCallDuration time.Duration // the active calls not released after this duration are no longer tracked
+	Rules           []*FraudRuleCfg
+}
+
+// FraudRuleCfg is one rule evaluated by FraudS for each account
+type FraudRuleCfg struct {
+	ID            string
+	Type          string // <*velocity|*new_destination|*concurrent_calls>
+	FilterIDs     []string
+	Group         RSRParsers    // groups the tracked events per account (ie: destination prefix), empty for one group
+	Window        time.Duration // sliding window of the tracked events
+	MaxCost       float64       // *velocity: maximum cost within the window
+	MaxCalls      int           // *velocity: maximum number of calls within the window
+	MaxConcurrent int           // *concurrent_calls: maximum number of active calls
+	BlockPeriod   time.Duration // block the account for this period once the rule is triggered
+	ActionIDs     []string      // actions executed on the account once the rule is triggered
+}
+
+func (fr *FraudRuleCfg) loadFromJSONCfg(jsnCfg *FraudRuleJsonCfg, sep string) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.ID != nil {
+		fr.ID = *jsnCfg.ID
+	}
+	if jsnCfg.Type != nil {
+		fr.Type = *jsnCfg.Type
+	}
+	if jsnCfg.Filters != nil {
+		fr.FilterIDs = utils.CloneStringSlice(*jsnCfg.Filters)
+	}
+	if jsnCfg.Group != nil {
+		if fr.Group, err = NewRSRParsers(*jsnCfg.Group, sep); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Window != nil {
+		if fr.Window, err = utils.ParseDurationWithNanosecs(*jsnCfg.Window); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Max_cost != nil {
+		fr.MaxCost = *jsnCfg.Max_cost
+	}
+	if jsnCfg.Max_calls != nil {
+		fr.MaxCalls = *jsnCfg.Max_calls
+	}
+	if jsnCfg.Max_concurrent != nil {
+		fr.MaxConcurrent = *jsnCfg.Max_concurrent
+	}
+	if jsnCfg.Block_period != nil {
+		if fr.BlockPeriod, err = utils.ParseDurationWithNanosecs(*jsnCfg.Block_period); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Action_ids != nil {
+		fr.ActionIDs = utils.CloneStringSlice(*jsnCfg.Action_ids)
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (fr *FraudRuleCfg) AsMapInterface(separator string) map[string]interface{} {
+	return map[string]interface{}{
+		utils.IDCfg:            fr.ID,
+		utils.TypeCfg:          fr.Type,
+		utils.FiltersCfg:       utils.CloneStringSlice(fr.FilterIDs),
+		utils.GroupCfg:         fr.Group.GetRule(separator),
+		utils.WindowCfg:        fr.Window.String(),
+		utils.MaxCostCfg:       fr.MaxCost,
+		utils.MaxCallsCfg:      fr.MaxCalls,
+		utils.MaxConcurrentCfg: fr.MaxConcurrent,
+		utils.BlockPeriodCfg:   fr.BlockPeriod.String(),
+		utils.ActionIDsCfg:     utils.CloneStringSlice(fr.ActionIDs),
+	}
+}
+
+// Clone returns a deep copy of FraudRuleCfg
+func (fr FraudRuleCfg) Clone() *FraudRuleCfg {
+	if fr.FilterIDs != nil {
+		fr.FilterIDs = utils.CloneStringSlice(fr.FilterIDs)
+	}
+	fr.Group = fr.Group.Clone()
+	if fr.ActionIDs != nil {
+		fr.ActionIDs = utils.CloneStringSlice(fr.ActionIDs)
+	}
+	return &fr
+}
+
+func (fS *FraudSCfg) loadFromJSONCfg(jsnCfg *FraudSJsonCfg, sep string) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		fS.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Ees_conns != nil {
+		fS.EEsConns = make([]string, len(*jsnCfg.Ees_conns))
+		for idx, connID := range *jsnCfg.Ees_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			fS.EEsConns[idx] = connID
+			if connID == utils.MetaInternal {
+				fS.EEsConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)
+			}
+		}
+	}
+	if jsnCfg.Ees_exporter_ids != nil {
+		fS.EEsExporterIDs = utils.CloneStringSlice(*jsnCfg.Ees_exporter_ids)
+	}
+	if jsnCfg.Max_call_duration != nil {
+		if fS.MaxCallDuration, err = utils.ParseDurationWithNanosecs(*jsnCfg.Max_call_duration); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Rules != nil {
+		for _, ruleJsn := range *jsnCfg.Rules {
+			rule := new(FraudRuleCfg)
+			var haveID bool
+			for _, ruleSet := range fS.Rules {
+				if ruleJsn.ID != nil && ruleSet.ID == *ruleJsn.ID {
+					rule = ruleSet // Will load data into the one set
+					haveID = true
+					break
+				}
+			}
+			if err = rule.loadFromJSONCfg(ruleJsn, sep); err != nil {
+				return
+			}
+			if !haveID {
+				fS.Rules = append(fS.Rules, rule)
+			}
+		}
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (fS *FraudSCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
+	initialMP = map[string]interface{}{
+		utils.EnabledCfg:         fS.Enabled,
+		utils.EEsExporterIDsCfg:  utils.CloneStringSlice(fS.EEsExporterIDs),
+		utils.MaxCallDurationCfg: fS.MaxCallDuration.String(),
+	}
+	if fS.EEsConns != nil {
+		eesConns := make([]string, len(fS.EEsConns))
+		for i, item := range fS.EEsConns {
+			eesConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs) {
+				eesConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.EEsConnsCfg] = eesConns
+	}
+	rules := make([]map[string]interface{}, len(fS.Rules))
+	for i, rule := range fS.Rules {
+		rules[i] = rule.AsMapInterface(separator)
+	}
+	initialMP[utils.RulesCfg] = rules
+	return
+}
+
+// Clone returns a deep copy of FraudSCfg
+func (fS FraudSCfg) Clone() (cln *FraudSCfg) {
+	cln = &FraudSCfg{
+		Enabled:         fS.Enabled,
+		MaxCallDuration: fS.MaxCallDuration,
+	}
+	if fS.EEsConns != nil {
+		cln.EEsConns = utils.CloneStringSlice(fS.EEsConns)
+	}
+	if fS.EEsExporterIDs != nil {
+		cln.EEsExporterIDs = utils.CloneStringSlice(fS.EEsExporterIDs)
+	}
+	if fS.Rules != nil {
+		cln.Rules = make([]*FraudRuleCfg, len(fS.Rules))
+		for i, rule := range fS.Rules {
+			cln.Rules[i] = rule.Clone()
+		}
+	}
+	return
+}
@@ -0,0 +1,191 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestFraudSCfgloadFromJsonCfg(t *testing.T) {
+	cfgJSON := &FraudSJsonCfg{
+		Enabled:           utils.BoolPointer(true),
+		Ees_conns:         &[]string{utils.MetaInternal, "*conn1"},
+		Ees_exporter_ids:  &[]string{"fraud_alerts"},
+		Max_call_duration: utils.StringPointer("2h"),
+		Rules: &[]*FraudRuleJsonCfg{{
+			ID:           utils.StringPointer("VELOCITY"),
+			Type:         utils.StringPointer(utils.MetaVelocity),
+			Filters:      &[]string{"*prefix:~*req.Destination:882"},
+			Group:        utils.StringPointer("~*req.Destination:s/^(\\d{3}).*/${1}/"),
+			Window:       utils.StringPointer("1h"),
+			Max_cost:     utils.Float64Pointer(100),
+			Block_period: utils.StringPointer("24h"),
+			Action_ids:   &[]string{"DISABLE_ACCOUNT"},
+		}},
+	}
+	grp, err := NewRSRParsers("~*req.Destination:s/^(\\d{3}).*/${1}/", utils.InfieldSep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expected := &FraudSCfg{
+		Enabled:         true,
+		EEsConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs), "*conn1"},
+		EEsExporterIDs:  []string{"fraud_alerts"},
+		MaxCallDuration: 2 * time.Hour,
+		Rules: []*FraudRuleCfg{{
+			ID:          "VELOCITY",
+			Type:        utils.MetaVelocity,
+			FilterIDs:   []string{"*prefix:~*req.Destination:882"},
+			Group:       grp,
+			Window:      time.Hour,
+			MaxCost:     100,
+			BlockPeriod: 24 * time.Hour,
+			ActionIDs:   []string{"DISABLE_ACCOUNT"},
+		}},
+	}
+	jsonCfg := NewDefaultCGRConfig()
+	if err = jsonCfg.fraudSCfg.loadFromJSONCfg(cfgJSON, utils.InfieldSep); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, jsonCfg.fraudSCfg) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(jsonCfg.fraudSCfg))
+	}
+
+	// the rules with the same ID are merged
+	cfgJSON = &FraudSJsonCfg{
+		Rules: &[]*FraudRuleJsonCfg{{
+			ID:       utils.StringPointer("VELOCITY"),
+			Max_cost: utils.Float64Pointer(50),
+		}},
+	}
+	expected.Rules[0].MaxCost = 50
+	if err = jsonCfg.fraudSCfg.loadFromJSONCfg(cfgJSON, utils.InfieldSep); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, jsonCfg.fraudSCfg) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(jsonCfg.fraudSCfg))
+	}
+}
+
+func TestFraudSCfgloadFromJsonCfgErrors(t *testing.T) {
+	expected := "time: unknown unit \"ss\" in duration \"1ss\""
+	jsonCfg := NewDefaultCGRConfig()
+	if err := jsonCfg.fraudSCfg.loadFromJSONCfg(&FraudSJsonCfg{
+		Max_call_duration: utils.StringPointer("1ss"),
+	}, utils.InfieldSep); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+	if err := jsonCfg.fraudSCfg.loadFromJSONCfg(&FraudSJsonCfg{
+		Rules: &[]*FraudRuleJsonCfg{{Window: utils.StringPointer("1ss")}},
+	}, utils.InfieldSep); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+	if err := jsonCfg.fraudSCfg.loadFromJSONCfg(&FraudSJsonCfg{
+		Rules: &[]*FraudRuleJsonCfg{{Block_period: utils.StringPointer("1ss")}},
+	}, utils.InfieldSep); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+	if err := jsonCfg.fraudSCfg.loadFromJSONCfg(&FraudSJsonCfg{
+		Rules: &[]*FraudRuleJsonCfg{{Group: utils.StringPointer("a{*(")}},
+	}, utils.InfieldSep); err == nil {
+		t.Errorf("Expected error, received %+v", err)
+	}
+}
+
+func TestFraudSCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+		"ees": {
+			"enabled": true,
+			"exporters": [{"id": "fraud_alerts", "type": "*virt"}],
+		},
+		"frauds": {
+			"enabled": true,
+			"ees_conns": ["*internal"],
+			"ees_exporter_ids": ["fraud_alerts"],
+			"max_call_duration": "1h",
+			"rules": [{
+				"id": "CONCURRENT",
+				"type": "*concurrent_calls",
+				"filters": ["*string:~*req.Category:call"],
+				"max_concurrent": 5,
+				"block_period": "1h",
+			}],
+		},
+}`
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:         true,
+		utils.EEsConnsCfg:        []string{utils.MetaInternal},
+		utils.EEsExporterIDsCfg:  []string{"fraud_alerts"},
+		utils.MaxCallDurationCfg: "1h0m0s",
+		utils.RulesCfg: []map[string]interface{}{{
+			utils.IDCfg:            "CONCURRENT",
+			utils.TypeCfg:          utils.MetaConcurrentCalls,
+			utils.FiltersCfg:       []string{"*string:~*req.Category:call"},
+			utils.GroupCfg:         utils.EmptyString,
+			utils.WindowCfg:        "0s",
+			utils.MaxCostCfg:       0.,
+			utils.MaxCallsCfg:      0,
+			utils.MaxConcurrentCfg: 5,
+			utils.BlockPeriodCfg:   "1h0m0s",
+			utils.ActionIDsCfg:     []string{},
+		}},
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.fraudSCfg.AsMapInterface(utils.InfieldSep); !reflect.DeepEqual(rcv, eMap) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestFraudSCfgClone(t *testing.T) {
+	grp, err := NewRSRParsers("~*req.Destination", utils.InfieldSep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	ban := &FraudSCfg{
+		Enabled:         true,
+		EEsConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)},
+		EEsExporterIDs:  []string{"fraud_alerts"},
+		MaxCallDuration: 3 * time.Hour,
+		Rules: []*FraudRuleCfg{{
+			ID:          "NEW_DST",
+			Type:        utils.MetaNewDestination,
+			FilterIDs:   []string{"*prefix:~*req.Destination:882"},
+			Group:       grp,
+			Window:      24 * time.Hour,
+			BlockPeriod: time.Hour,
+			ActionIDs:   []string{"DISABLE_ACCOUNT"},
+		}},
+	}
+	rcv := ban.Clone()
+	if !reflect.DeepEqual(ban, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(ban), utils.ToJSON(rcv))
+	}
+	if rcv.EEsConns[0] = ""; ban.EEsConns[0] != utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs) {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.Rules[0].FilterIDs[0] = ""; ban.Rules[0].FilterIDs[0] != "*prefix:~*req.Destination:882" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.Rules[0].ActionIDs[0] = ""; ban.Rules[0].ActionIDs[0] != "DISABLE_ACCOUNT" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
@@ -20,6 +20,7 @@ package config
 
 import (
 	"crypto/tls"
+	"encoding/base64"
 	"net"
 	"net/http"
 
@@ -204,3 +205,13 @@ func (httpcfg HTTPCfg) Clone() (cln *HTTPCfg) {
 	}
 	return
 }
+
+// VerifyCredential checks the basic auth credentials against the auth_users
+func (httpcfg *HTTPCfg) VerifyCredential(user, pass string) bool {
+	hash, has := httpcfg.HTTPAuthUsers[user]
+	if !has {
+		return false
+	}
+	storedPass, err := base64.StdEncoding.DecodeString(hash)
+	return err == nil && string(storedPass) == pass
+}
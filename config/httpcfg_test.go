@@ -203,3 +203,25 @@ func TestHTTPCfgClone(t *testing.T) {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
 }
+
+func TestHTTPCfgVerifyCredential(t *testing.T) {
+	httpCfg := &HTTPCfg{
+		HTTPAuthUsers: map[string]string{
+			"admin":   "c2VjcmV0", // secret
+			"invalid": "not base64",
+		},
+	}
+	for _, tc := range []struct {
+		user, pass string
+		valid      bool
+	}{
+		{"admin", "secret", true},
+		{"admin", "wrong", false},
+		{"unknown", "secret", false},
+		{"invalid", "not base64", false},
+	} {
+		if rcv := httpCfg.VerifyCredential(tc.user, tc.pass); rcv != tc.valid {
+			t.Errorf("Expected %v for %+v, received: %v", tc.valid, tc, rcv)
+		}
+	}
+}
@@ -85,14 +85,16 @@ func (kamCfg KamConnCfg) Clone() *KamConnCfg {
 
 // KamAgentCfg is the Kamailio config section
 type KamAgentCfg struct {
-	Enabled       bool
-	SessionSConns []string
-	CreateCdr     bool
-	EvapiConns    []*KamConnCfg
-	Timezone      string
+	Enabled           bool
+	SessionSConns     []string
+	CreateCdr         bool
+	EvapiConns        []*KamConnCfg
+	Timezone          string
+	Mode              string // format of the events received over evapi <*cgrates|*json>
+	RequestProcessors []*RequestProcessor
 }
 
-func (ka *KamAgentCfg) loadFromJSONCfg(jsnCfg *KamAgentJsonCfg) error {
+func (ka *KamAgentCfg) loadFromJSONCfg(jsnCfg *KamAgentJsonCfg, sep string) (err error) {
 	if jsnCfg == nil {
 		return nil
 	}
@@ -123,16 +125,44 @@ func (ka *KamAgentCfg) loadFromJSONCfg(jsnCfg *KamAgentJsonCfg) error {
 	if jsnCfg.Timezone != nil {
 		ka.Timezone = *jsnCfg.Timezone
 	}
-	return nil
+	if jsnCfg.Mode != nil {
+		ka.Mode = *jsnCfg.Mode
+	}
+	if jsnCfg.Request_processors != nil {
+		for _, reqProcJsn := range *jsnCfg.Request_processors {
+			rp := new(RequestProcessor)
+			var haveID bool
+			for _, rpSet := range ka.RequestProcessors {
+				if reqProcJsn.ID != nil && rpSet.ID == *reqProcJsn.ID {
+					rp = rpSet // Will load data into the one set
+					haveID = true
+					break
+				}
+			}
+			if err = rp.loadFromJSONCfg(reqProcJsn, sep); err != nil {
+				return
+			}
+			if !haveID {
+				ka.RequestProcessors = append(ka.RequestProcessors, rp)
+			}
+		}
+	}
+	return
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
-func (ka *KamAgentCfg) AsMapInterface() (initialMP map[string]interface{}) {
+func (ka *KamAgentCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
 		utils.EnabledCfg:   ka.Enabled,
 		utils.CreateCdrCfg: ka.CreateCdr,
 		utils.TimezoneCfg:  ka.Timezone,
+		utils.ModeCfg:      ka.Mode,
+	}
+	requestProcessors := make([]map[string]interface{}, len(ka.RequestProcessors))
+	for i, item := range ka.RequestProcessors {
+		requestProcessors[i] = item.AsMapInterface(separator)
 	}
+	initialMP[utils.RequestProcessorsCfg] = requestProcessors
 	if ka.EvapiConns != nil {
 		evapiConns := make([]map[string]interface{}, len(ka.EvapiConns))
 		for i, item := range ka.EvapiConns {
@@ -161,6 +191,7 @@ func (ka KamAgentCfg) Clone() (cln *KamAgentCfg) {
 		Enabled:   ka.Enabled,
 		CreateCdr: ka.CreateCdr,
 		Timezone:  ka.Timezone,
+		Mode:      ka.Mode,
 	}
 	if ka.SessionSConns != nil {
 		cln.SessionSConns = make([]string, len(ka.SessionSConns))
@@ -174,5 +205,11 @@ func (ka KamAgentCfg) Clone() (cln *KamAgentCfg) {
 			cln.EvapiConns[i] = req.Clone()
 		}
 	}
+	if ka.RequestProcessors != nil {
+		cln.RequestProcessors = make([]*RequestProcessor, len(ka.RequestProcessors))
+		for i, req := range ka.RequestProcessors {
+			cln.RequestProcessors[i] = req.Clone()
+		}
+	}
 	return
 }
@@ -38,6 +38,16 @@ func TestKamAgentCfgloadFromJsonCfg(t *testing.T) {
 			},
 		},
 		Timezone: utils.StringPointer("Local"),
+		Mode:     utils.StringPointer(utils.MetaJSON),
+		Request_processors: &[]*ReqProcessorJsnCfg{
+			{
+				ID:             utils.StringPointer("DialogStart"),
+				Filters:        &[]string{"*string:~*req.event:dialog_start"},
+				Flags:          &[]string{utils.MetaInitiate},
+				Request_fields: &[]*FcTemplateJsonCfg{},
+				Reply_fields:   &[]*FcTemplateJsonCfg{},
+			},
+		},
 	}
 	expected := &KamAgentCfg{
 		Enabled:       true,
@@ -45,9 +55,19 @@ func TestKamAgentCfgloadFromJsonCfg(t *testing.T) {
 		CreateCdr:     true,
 		EvapiConns:    []*KamConnCfg{{Address: "127.0.0.1:8448", Reconnects: 10, Alias: "randomAlias"}},
 		Timezone:      "Local",
+		Mode:          utils.MetaJSON,
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:            "DialogStart",
+				Filters:       []string{"*string:~*req.event:dialog_start"},
+				Flags:         utils.FlagsWithParams{utils.MetaInitiate: {}},
+				RequestFields: []*FCTemplate{},
+				ReplyFields:   []*FCTemplate{},
+			},
+		},
 	}
 	jsnCfg := NewDefaultCGRConfig()
-	if err = jsnCfg.kamAgentCfg.loadFromJSONCfg(cfgJSON); err != nil {
+	if err = jsnCfg.kamAgentCfg.loadFromJSONCfg(cfgJSON, utils.InfieldSep); err != nil {
 		t.Error(err)
 	} else if !reflect.DeepEqual(expected, jsnCfg.kamAgentCfg) {
 		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(jsnCfg.kamAgentCfg))
@@ -100,10 +120,12 @@ func TestKamAgentCfgAsMapInterface(t *testing.T) {
 		utils.EvapiConnsCfg: []map[string]interface{}{
 			{utils.AddressCfg: "127.0.0.1:8448", utils.ReconnectsCfg: 5, utils.MaxReconnectIntervalCfg: "0s", utils.AliasCfg: ""},
 		},
+		utils.ModeCfg:              utils.MetaCGRateS,
+		utils.RequestProcessorsCfg: []map[string]interface{}{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
-	} else if rcv := cgrCfg.kamAgentCfg.AsMapInterface(); !reflect.DeepEqual(rcv, eMap) {
+	} else if rcv := cgrCfg.kamAgentCfg.AsMapInterface(utils.InfieldSep); !reflect.DeepEqual(rcv, eMap) {
 		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
 	}
 }
@@ -120,10 +142,12 @@ func TestKamAgentCfgAsMapInterface1(t *testing.T) {
 		utils.EvapiConnsCfg: []map[string]interface{}{
 			{utils.AddressCfg: "127.0.0.1:8448", utils.ReconnectsCfg: 5, utils.MaxReconnectIntervalCfg: "0s", utils.AliasCfg: ""},
 		},
+		utils.ModeCfg:              utils.MetaCGRateS,
+		utils.RequestProcessorsCfg: []map[string]interface{}{},
 	}
 	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
 		t.Error(err)
-	} else if rcv := cgrCfg.kamAgentCfg.AsMapInterface(); !reflect.DeepEqual(rcv, eMap) {
+	} else if rcv := cgrCfg.kamAgentCfg.AsMapInterface(utils.InfieldSep); !reflect.DeepEqual(rcv, eMap) {
 		t.Errorf("Expected %+v \n, received %+v", eMap, rcv)
 	}
 }
@@ -135,6 +159,16 @@ func TestKamAgentCfgClone(t *testing.T) {
 		CreateCdr:     true,
 		EvapiConns:    []*KamConnCfg{{Address: "127.0.0.1:8448", Reconnects: 10, Alias: "randomAlias"}},
 		Timezone:      "Local",
+		Mode:          utils.MetaJSON,
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:            "DialogStart",
+				Filters:       []string{"*string:~*req.event:dialog_start"},
+				Flags:         utils.FlagsWithParams{utils.MetaInitiate: {}},
+				RequestFields: []*FCTemplate{},
+				ReplyFields:   []*FCTemplate{},
+			},
+		},
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -146,4 +180,7 @@ func TestKamAgentCfgClone(t *testing.T) {
 	if rcv.EvapiConns[0].Alias = ""; ban.EvapiConns[0].Alias != "randomAlias" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.RequestProcessors[0].ID = ""; ban.RequestProcessors[0].ID != "DialogStart" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 }
@@ -116,6 +116,20 @@ type DbJsonCfg struct {
 	Replication_cache     *string
 	Items                 *map[string]*ItemOptJson
 	Opts                  map[string]interface{}
+	Standby_dbs           *[]*DbStandbyJsonCfg
+	Failover_interval     *string
+	Failover_failures     *int
+}
+
+// DbStandbyJsonCfg is one standby DataDB used on failover
+type DbStandbyJsonCfg struct {
+	Db_type     *string
+	Db_host     *string
+	Db_port     *int
+	Db_name     *string
+	Db_user     *string
+	Db_password *string
+	Opts        map[string]interface{}
 }
 
 type ItemOptJson struct {
@@ -134,6 +148,7 @@ type FilterSJsonCfg struct {
 	Stats_conns     *[]string
 	Resources_conns *[]string
 	Apiers_conns    *[]string
+	Geoip_db        *string
 }
 
 // Rater config section
@@ -172,6 +187,8 @@ type CdrsJsonCfg struct {
 	Online_cdr_exports   *[]string
 	Scheduler_conns      *[]string
 	Ees_conns            *[]string
+	Frauds_conns         *[]string
+	Routes_conns         *[]string
 }
 
 // EventReaderSJsonCfg contains the configuration of EventReaderService
@@ -353,6 +370,7 @@ type SessionSJsonCfg struct {
 	Cdrs_conns             *[]string
 	Replication_conns      *[]string
 	Attributes_conns       *[]string
+	Frauds_conns           *[]string
 	Debit_interval         *string
 	Store_session_costs    *bool
 	Session_ttl            *string
@@ -366,6 +384,8 @@ type SessionSJsonCfg struct {
 	Terminate_attempts     *int
 	Alterable_fields       *[]string
 	Min_dur_low_balance    *string
+	Balance_split          *bool
+	Max_account_sessions   *int
 	Scheduler_conns        *[]string
 	Stir                   *STIRJsonCfg
 	Default_usage          *map[string]string
@@ -410,6 +430,7 @@ type RemoteHostJson struct {
 	Key_path               *string
 	Cert_path              *string
 	Ca_path                *string
+	Auth_key               *string
 	Conn_attempts          *int
 	Reconnects             *int
 	Max_reconnect_interval *string
@@ -428,10 +449,11 @@ type AstConnJsonCfg struct {
 }
 
 type AsteriskAgentJsonCfg struct {
-	Enabled        *bool
-	Sessions_conns *[]string
-	Create_cdr     *bool
-	Asterisk_conns *[]*AstConnJsonCfg
+	Enabled              *bool
+	Sessions_conns       *[]string
+	Create_cdr           *bool
+	Low_balance_ann_file *string
+	Asterisk_conns       *[]*AstConnJsonCfg
 }
 
 type CacheParamJsonCfg struct {
@@ -449,11 +471,13 @@ type CacheJsonCfg struct {
 
 // SM-Kamailio config section
 type KamAgentJsonCfg struct {
-	Enabled        *bool
-	Sessions_conns *[]string
-	Create_cdr     *bool
-	Evapi_conns    *[]*KamConnJsonCfg
-	Timezone       *string
+	Enabled            *bool
+	Sessions_conns     *[]string
+	Create_cdr         *bool
+	Evapi_conns        *[]*KamConnJsonCfg
+	Timezone           *string
+	Mode               *string
+	Request_processors *[]*ReqProcessorJsnCfg
 }
 
 // Represents one connection instance towards Kamailio
@@ -514,12 +538,23 @@ type HttpAgentJsonCfg struct {
 
 // DNSAgentJsonCfg
 type DNSAgentJsonCfg struct {
-	Enabled            *bool
-	Listen             *string
-	Listen_net         *string
-	Sessions_conns     *[]string
-	Timezone           *string
-	Request_processors *[]*ReqProcessorJsnCfg
+	Enabled              *bool
+	Listen               *string
+	Listen_net           *string
+	Listeners            *[]*DNSListenerJsnCfg
+	Sessions_conns       *[]string
+	Timezone             *string
+	Upstream_servers     *[]string
+	Upstream_net         *string
+	Upstream_timeout     *string
+	Upstream_cache_limit *int
+	Request_processors   *[]*ReqProcessorJsnCfg
+}
+
+// DNSListenerJsnCfg is an additional listener of the DNSAgent
+type DNSListenerJsnCfg struct {
+	Address *string
+	Network *string
 }
 
 type ReqProcessorJsnCfg struct {
@@ -552,6 +587,9 @@ type AttributeSJsonCfg struct {
 	Suffix_indexed_fields *[]string
 	Nested_fields         *bool // applies when indexed fields is not defined
 	Any_context           *bool
+	Lookups_timeout       *string
+	Lookups_cache_ttl     *string
+	Sql_conns             *map[string]string
 	Opts                  *AttributesOptsJson
 }
 
@@ -596,6 +634,7 @@ type StatServJsonCfg struct {
 	Indexed_selects          *bool
 	Store_interval           *string
 	Store_uncompressed_limit *int
+	Child_queues_ttl         *string
 	Thresholds_conns         *[]string
 	String_indexed_fields    *[]string
 	Prefix_indexed_fields    *[]string
@@ -621,6 +660,29 @@ type ThresholdSJsonCfg struct {
 	Opts                  *ThresholdsOptsJson
 }
 
+// FraudSJsonCfg is the frauds section of the config
+type FraudSJsonCfg struct {
+	Enabled           *bool
+	Ees_conns         *[]string
+	Ees_exporter_ids  *[]string
+	Max_call_duration *string
+	Rules             *[]*FraudRuleJsonCfg
+}
+
+// FraudRuleJsonCfg is one rule of FraudS
+type FraudRuleJsonCfg struct {
+	ID             *string
+	Type           *string
+	Filters        *[]string
+	Group          *string
+	Window         *string
+	Max_cost       *float64
+	Max_calls      *int
+	Max_concurrent *int
+	Block_period   *string
+	Action_ids     *[]string
+}
+
 type RoutesOptsJson struct {
 	Context      *string     `json:"*context"`
 	IgnoreErrors *bool       `json:"*ignoreErrors"`
@@ -643,9 +705,19 @@ type RouteSJsonCfg struct {
 	Stats_conns           *[]string
 	Rals_conns            *[]string
 	Default_ratio         *int
+	Circuit_breakers      *map[string]*RouteBreakerJsonCfg
 	Opts                  *RoutesOptsJson
 }
 
+// RouteBreakerJsonCfg is the circuit breaker configuration of a route
+type RouteBreakerJsonCfg struct {
+	Failures         *int
+	Failure_ratio    *float64
+	Window           *int
+	Open_period      *string
+	Half_open_probes *int
+}
+
 type LoaderJsonDataType struct {
 	Type      *string
 	File_name *string
@@ -658,6 +730,7 @@ type LoaderJsonCfg struct {
 	Enabled         *bool
 	Tenant          *string
 	Dry_run         *bool
+	Transactional   *bool
 	Run_delay       *string
 	Lockfile_path   *string
 	Caches_conns    *[]string
@@ -714,6 +787,9 @@ type DispatcherSJsonCfg struct {
 	Nested_fields         *bool // applies when indexed fields is not defined
 	Attributes_conns      *[]string
 	Any_subsystem         *bool
+	Health_check_interval *string
+	Healthy_threshold     *int
+	Unhealthy_threshold   *int
 }
 
 type RegistrarCJsonCfg struct {
@@ -793,6 +869,8 @@ type ApierJsonCfg struct {
 	Scheduler_conns  *[]string
 	Attributes_conns *[]string
 	Ees_conns        *[]string
+	Audit_log        *bool
+	Audit_ees_ids    *[]string
 }
 
 type STIRJsonCfg struct {
@@ -803,6 +881,16 @@ type STIRJsonCfg struct {
 	Privatekey_path     *string
 }
 
+// WebSocketAgentJsonCfg
+type WebSocketAgentJsonCfg struct {
+	Enabled            *bool
+	Url                *string
+	Allowed_origins    *[]string
+	Sessions_conns     *[]string
+	Timezone           *string
+	Request_processors *[]*ReqProcessorJsnCfg
+}
+
 // SIPAgentJsonCfg
 type SIPAgentJsonCfg struct {
 	Enabled              *bool
@@ -825,6 +913,39 @@ type APIBanJsonCfg struct {
 	Keys    *[]string
 }
 
+// APIAuthJsonCfg is the api_auth section of the config
+type APIAuthJsonCfg struct {
+	Enabled *bool
+	Roles   map[string]*APIRoleJsonCfg
+	Users   map[string]*APIUserJsonCfg
+}
+
+// APIRoleJsonCfg is one role of the api_auth section
+type APIRoleJsonCfg struct {
+	Methods *[]string
+	Tenants *[]string
+}
+
+// APIUserJsonCfg is one user of the api_auth section
+type APIUserJsonCfg struct {
+	Key   *string
+	Roles *[]string
+}
+
+// QuotasJsonCfg is the quotas section of the config
+type QuotasJsonCfg struct {
+	Enabled *bool
+	Tenants map[string]*TenantQuotaJsonCfg
+}
+
+// TenantQuotaJsonCfg are the quotas of one tenant within the quotas section
+type TenantQuotaJsonCfg struct {
+	Api_requests *int
+	Sessions     *int
+	Cdrs_rate    *int
+	Objects      map[string]int
+}
+
 type CoreSJsonCfg struct {
 	Caps                *int
 	Caps_strategy       *string
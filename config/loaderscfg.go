@@ -73,6 +73,7 @@ type LoaderSCfg struct {
 	Enabled        bool
 	Tenant         string
 	DryRun         bool
+	Transactional  bool // parse and validate all the files before storing them at once
 	RunDelay       time.Duration
 	LockFilePath   string
 	CacheSConns    []string
@@ -132,6 +133,9 @@ func (l *LoaderSCfg) loadFromJSONCfg(jsnCfg *LoaderJsonCfg, msgTemplates map[str
 	if jsnCfg.Dry_run != nil {
 		l.DryRun = *jsnCfg.Dry_run
 	}
+	if jsnCfg.Transactional != nil {
+		l.Transactional = *jsnCfg.Transactional
+	}
 	if jsnCfg.Run_delay != nil {
 		if l.RunDelay, err = utils.ParseDurationWithNanosecs(*jsnCfg.Run_delay); err != nil {
 			return
@@ -207,6 +211,7 @@ func (l LoaderSCfg) Clone() (cln *LoaderSCfg) {
 		Enabled:        l.Enabled,
 		Tenant:         l.Tenant,
 		DryRun:         l.DryRun,
+		Transactional:  l.Transactional,
 		RunDelay:       l.RunDelay,
 		LockFilePath:   l.LockFilePath,
 		CacheSConns:    make([]string, len(l.CacheSConns)),
@@ -243,15 +248,16 @@ func (lData *LoaderDataType) AsMapInterface(separator string) (initialMP map[str
 // AsMapInterface returns the config as a map[string]interface{}
 func (l *LoaderSCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.IDCfg:           l.ID,
-		utils.TenantCfg:       l.Tenant,
-		utils.EnabledCfg:      l.Enabled,
-		utils.DryRunCfg:       l.DryRun,
-		utils.LockFilePathCfg: l.LockFilePath,
-		utils.FieldSepCfg:     l.FieldSeparator,
-		utils.TpInDirCfg:      l.TpInDir,
-		utils.TpOutDirCfg:     l.TpOutDir,
-		utils.RunDelayCfg:     "0",
+		utils.IDCfg:            l.ID,
+		utils.TenantCfg:        l.Tenant,
+		utils.EnabledCfg:       l.Enabled,
+		utils.DryRunCfg:        l.DryRun,
+		utils.TransactionalCfg: l.Transactional,
+		utils.LockFilePathCfg:  l.LockFilePath,
+		utils.FieldSepCfg:      l.FieldSeparator,
+		utils.TpInDirCfg:       l.TpInDir,
+		utils.TpOutDirCfg:      l.TpOutDir,
+		utils.RunDelayCfg:      "0",
 	}
 	if l.Data != nil {
 		data := make([]map[string]interface{}, len(l.Data))
@@ -33,6 +33,7 @@ func TestLoaderSCfgloadFromJsonCfgCase1(t *testing.T) {
 		"id": "*default",
 		"enabled": true,
 		"tenant": "cgrates.org",
+		"transactional": true,
 		"lockfile_path": ".cgr.lck",
 		"caches_conns": ["*internal","*conn1"],
 		"field_separator": ",",
@@ -62,6 +63,7 @@ func TestLoaderSCfgloadFromJsonCfgCase1(t *testing.T) {
 			Enabled:        true,
 			ID:             utils.MetaDefault,
 			Tenant:         ten,
+			Transactional:  true,
 			LockFilePath:   ".cgr.lck",
 			CacheSConns:    []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCaches), "*conn1"},
 			FieldSeparator: ",",
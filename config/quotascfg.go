@@ -0,0 +1,135 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// QuotasCfg is the configuration of the per tenant quotas
+type QuotasCfg struct {
+	Enabled bool
+	Tenants map[string]*TenantQuotaCfg // indexed on tenant, *default applying to the tenants not listed
+}
+
+// TenantQuotaCfg holds the quotas of a tenant, 0 meaning unlimited
+type TenantQuotaCfg struct {
+	APIRequests int            // concurrent API requests
+	Sessions    int            // active sessions within SessionS
+	CDRsRate    int            // CDRs processed by CDRs per second
+	Objects     map[string]int // DataDB items indexed on item type, ie: *attribute_profiles
+}
+
+func (tq *TenantQuotaCfg) loadFromJSONCfg(jsnCfg *TenantQuotaJsonCfg) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Api_requests != nil {
+		tq.APIRequests = *jsnCfg.Api_requests
+	}
+	if jsnCfg.Sessions != nil {
+		tq.Sessions = *jsnCfg.Sessions
+	}
+	if jsnCfg.Cdrs_rate != nil {
+		tq.CDRsRate = *jsnCfg.Cdrs_rate
+	}
+	if jsnCfg.Objects != nil {
+		tq.Objects = make(map[string]int, len(jsnCfg.Objects))
+		for itmType, limit := range jsnCfg.Objects {
+			tq.Objects[itmType] = limit
+		}
+	}
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (tq *TenantQuotaCfg) AsMapInterface() map[string]interface{} {
+	objects := make(map[string]interface{}, len(tq.Objects))
+	for itmType, limit := range tq.Objects {
+		objects[itmType] = limit
+	}
+	return map[string]interface{}{
+		utils.APIRequestsCfg: tq.APIRequests,
+		utils.SessionsCfg:    tq.Sessions,
+		utils.CDRsRateCfg:    tq.CDRsRate,
+		utils.ObjectsCfg:     objects,
+	}
+}
+
+// Clone returns a deep copy of TenantQuotaCfg
+func (tq TenantQuotaCfg) Clone() *TenantQuotaCfg {
+	if tq.Objects != nil {
+		objects := make(map[string]int, len(tq.Objects))
+		for itmType, limit := range tq.Objects {
+			objects[itmType] = limit
+		}
+		tq.Objects = objects
+	}
+	return &tq
+}
+
+func (q *QuotasCfg) loadFromJSONCfg(jsnCfg *QuotasJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Enabled != nil {
+		q.Enabled = *jsnCfg.Enabled
+	}
+	for tnt, jsnTQ := range jsnCfg.Tenants {
+		if _, has := q.Tenants[tnt]; !has {
+			q.Tenants[tnt] = new(TenantQuotaCfg)
+		}
+		q.Tenants[tnt].loadFromJSONCfg(jsnTQ)
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (q *QuotasCfg) AsMapInterface() map[string]interface{} {
+	tenants := make(map[string]interface{}, len(q.Tenants))
+	for tnt, tq := range q.Tenants {
+		tenants[tnt] = tq.AsMapInterface()
+	}
+	return map[string]interface{}{
+		utils.EnabledCfg: q.Enabled,
+		utils.TenantsCfg: tenants,
+	}
+}
+
+// Clone returns a deep copy of QuotasCfg
+func (q QuotasCfg) Clone() (cln *QuotasCfg) {
+	cln = &QuotasCfg{
+		Enabled: q.Enabled,
+		Tenants: make(map[string]*TenantQuotaCfg, len(q.Tenants)),
+	}
+	for tnt, tq := range q.Tenants {
+		cln.Tenants[tnt] = tq.Clone()
+	}
+	return
+}
+
+// TenantQuota returns the quotas of the tenant, nil if the quotas are disabled or not configured for it
+func (q *QuotasCfg) TenantQuota(tnt string) *TenantQuotaCfg {
+	if !q.Enabled {
+		return nil
+	}
+	if tq, has := q.Tenants[tnt]; has {
+		return tq
+	}
+	return q.Tenants[utils.MetaDefault]
+}
@@ -0,0 +1,130 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestQuotasCfgloadFromJsonCfg(t *testing.T) {
+	cfgJSONStr := `{
+		"quotas": {
+			"enabled": true,
+			"tenants": {
+				"*default": {"api_requests": 5},
+				"cgrates.org": {
+					"api_requests": 10,
+					"sessions": 100,
+					"cdrs_rate": 50,
+					"objects": {"*attribute_profiles": 20, "*accounts": 1000},
+				},
+			},
+		},
+	}`
+	expected := &QuotasCfg{
+		Enabled: true,
+		Tenants: map[string]*TenantQuotaCfg{
+			utils.MetaDefault: {APIRequests: 5},
+			"cgrates.org": {
+				APIRequests: 10,
+				Sessions:    100,
+				CDRsRate:    50,
+				Objects: map[string]int{
+					utils.CacheAttributeProfiles: 20,
+					utils.MetaAccounts:           1000,
+				},
+			},
+		},
+	}
+	if jsnCfg, err := NewCgrJsonCfgFromBytes([]byte(cfgJSONStr)); err != nil {
+		t.Error(err)
+	} else if cfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cfg.QuotasCfg(); !reflect.DeepEqual(expected, rcv) {
+		t.Errorf("Expected %+v, received %+v", utils.ToJSON(expected), utils.ToJSON(rcv))
+	} else if jsnQuotas, err := jsnCfg.QuotasJsonCfg(); err != nil {
+		t.Error(err)
+	} else if jsnQuotas.Tenants["cgrates.org"].Cdrs_rate == nil ||
+		*jsnQuotas.Tenants["cgrates.org"].Cdrs_rate != 50 {
+		t.Errorf("unexpected cdrs_rate: %s", utils.ToJSON(jsnQuotas))
+	}
+}
+
+func TestQuotasCfgAsMapInterface(t *testing.T) {
+	q := &QuotasCfg{
+		Enabled: true,
+		Tenants: map[string]*TenantQuotaCfg{
+			"cgrates.org": {
+				APIRequests: 10,
+				Objects:     map[string]int{utils.CacheFilters: 20},
+			},
+		},
+	}
+	expected := map[string]interface{}{
+		utils.EnabledCfg: true,
+		utils.TenantsCfg: map[string]interface{}{
+			"cgrates.org": map[string]interface{}{
+				utils.APIRequestsCfg: 10,
+				utils.SessionsCfg:    0,
+				utils.CDRsRateCfg:    0,
+				utils.ObjectsCfg:     map[string]interface{}{utils.CacheFilters: 20},
+			},
+		},
+	}
+	if rcv := q.AsMapInterface(); !reflect.DeepEqual(expected, rcv) {
+		t.Errorf("Expected %+v, received %+v", utils.ToJSON(expected), utils.ToJSON(rcv))
+	}
+}
+
+func TestQuotasCfgCloneAndTenantQuota(t *testing.T) {
+	q := &QuotasCfg{
+		Tenants: map[string]*TenantQuotaCfg{
+			utils.MetaDefault: {APIRequests: 5},
+			"cgrates.org": {
+				Sessions: 10,
+				Objects:  map[string]int{utils.CacheFilters: 20},
+			},
+		},
+	}
+	cln := q.Clone()
+	if !reflect.DeepEqual(q, cln) {
+		t.Errorf("Expected %+v, received %+v", utils.ToJSON(q), utils.ToJSON(cln))
+	}
+	cln.Tenants["cgrates.org"].Objects[utils.CacheFilters] = 30
+	if q.Tenants["cgrates.org"].Objects[utils.CacheFilters] != 20 {
+		t.Error("clone shares the objects quotas")
+	}
+	if tq := q.TenantQuota("cgrates.org"); tq != nil {
+		t.Errorf("expected no quota while disabled, received %+v", tq)
+	}
+	q.Enabled = true
+	if tq := q.TenantQuota("cgrates.org"); tq == nil || tq.Sessions != 10 {
+		t.Errorf("unexpected quota: %+v", tq)
+	}
+	if tq := q.TenantQuota("itsyscom.com"); tq == nil || tq.APIRequests != 5 {
+		t.Errorf("unexpected quota: %+v", tq)
+	}
+	delete(q.Tenants, utils.MetaDefault)
+	if tq := q.TenantQuota("itsyscom.com"); tq != nil {
+		t.Errorf("expected no quota, received %+v", tq)
+	}
+}
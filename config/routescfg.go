@@ -19,6 +19,8 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package config
 
 import (
+	"time"
+
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -44,9 +46,59 @@ type RouteSCfg struct {
 	RALsConns           []string
 	DefaultRatio        int
 	NestedFields        bool
+	CircuitBreakers     map[string]*RouteBreakerCfg // indexed on route ID, *default applying to the routes not defined
 	Opts                *RoutesOpts
 }
 
+// RouteBreakerCfg is the circuit breaker configuration of a route
+type RouteBreakerCfg struct {
+	Failures       int           // consecutive failures opening the circuit
+	FailureRatio   float64       // ratio of failures out of the last Window outcomes opening the circuit
+	Window         int           // number of outcomes considered for the FailureRatio
+	OpenPeriod     time.Duration // the route is skipped for this period once the circuit is open
+	HalfOpenProbes int           // number of successful calls closing the breaker after the OpenPeriod
+}
+
+func (rbCfg *RouteBreakerCfg) loadFromJSONCfg(jsnCfg *RouteBreakerJsonCfg) (err error) {
+	if jsnCfg == nil {
+		return
+	}
+	if jsnCfg.Failures != nil {
+		rbCfg.Failures = *jsnCfg.Failures
+	}
+	if jsnCfg.Failure_ratio != nil {
+		rbCfg.FailureRatio = *jsnCfg.Failure_ratio
+	}
+	if jsnCfg.Window != nil {
+		rbCfg.Window = *jsnCfg.Window
+	}
+	if jsnCfg.Open_period != nil {
+		if rbCfg.OpenPeriod, err = utils.ParseDurationWithNanosecs(*jsnCfg.Open_period); err != nil {
+			return
+		}
+	}
+	if jsnCfg.Half_open_probes != nil {
+		rbCfg.HalfOpenProbes = *jsnCfg.Half_open_probes
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (rbCfg *RouteBreakerCfg) AsMapInterface() map[string]interface{} {
+	return map[string]interface{}{
+		utils.FailuresCfg:       rbCfg.Failures,
+		utils.FailureRatioCfg:   rbCfg.FailureRatio,
+		utils.WindowCfg:         rbCfg.Window,
+		utils.OpenPeriodCfg:     rbCfg.OpenPeriod.String(),
+		utils.HalfOpenProbesCfg: rbCfg.HalfOpenProbes,
+	}
+}
+
+// Clone returns a deep copy of RouteBreakerCfg
+func (rbCfg RouteBreakerCfg) Clone() *RouteBreakerCfg {
+	return &rbCfg
+}
+
 func (rtsOpts *RoutesOpts) loadFromJSONCfg(jsnCfg *RoutesOptsJson) {
 	if jsnCfg == nil {
 		return
@@ -148,6 +200,18 @@ func (rts *RouteSCfg) loadFromJSONCfg(jsnCfg *RouteSJsonCfg) (err error) {
 	if jsnCfg.Nested_fields != nil {
 		rts.NestedFields = *jsnCfg.Nested_fields
 	}
+	if jsnCfg.Circuit_breakers != nil {
+		for rID, rbJsn := range *jsnCfg.Circuit_breakers {
+			rb, has := rts.CircuitBreakers[rID]
+			if !has {
+				rb = new(RouteBreakerCfg)
+			}
+			if err = rb.loadFromJSONCfg(rbJsn); err != nil {
+				return
+			}
+			rts.CircuitBreakers[rID] = rb
+		}
+	}
 	if jsnCfg.Opts != nil {
 		rts.Opts.loadFromJSONCfg(jsnCfg.Opts)
 	}
@@ -172,12 +236,17 @@ func (rts *RouteSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	if rts.Opts.ProfileCount != nil {
 		opts[utils.MetaProfileCountCfg] = rts.Opts.ProfileCount
 	}
+	circuitBreakers := make(map[string]interface{}, len(rts.CircuitBreakers))
+	for rID, rb := range rts.CircuitBreakers {
+		circuitBreakers[rID] = rb.AsMapInterface()
+	}
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:        rts.Enabled,
-		utils.IndexedSelectsCfg: rts.IndexedSelects,
-		utils.DefaultRatioCfg:   rts.DefaultRatio,
-		utils.NestedFieldsCfg:   rts.NestedFields,
-		utils.OptsCfg:           opts,
+		utils.EnabledCfg:         rts.Enabled,
+		utils.IndexedSelectsCfg:  rts.IndexedSelects,
+		utils.DefaultRatioCfg:    rts.DefaultRatio,
+		utils.NestedFieldsCfg:    rts.NestedFields,
+		utils.CircuitBreakersCfg: circuitBreakers,
+		utils.OptsCfg:            opts,
 	}
 	if rts.StringIndexedFields != nil {
 		stringIndexedFields := make([]string, len(*rts.StringIndexedFields))
@@ -270,6 +339,12 @@ func (rts RouteSCfg) Clone() (cln *RouteSCfg) {
 		NestedFields:   rts.NestedFields,
 		Opts:           rts.Opts.Clone(),
 	}
+	if rts.CircuitBreakers != nil {
+		cln.CircuitBreakers = make(map[string]*RouteBreakerCfg, len(rts.CircuitBreakers))
+		for rID, rb := range rts.CircuitBreakers {
+			cln.CircuitBreakers[rID] = rb.Clone()
+		}
+	}
 	if rts.AttributeSConns != nil {
 		cln.AttributeSConns = make([]string, len(rts.AttributeSConns))
 		for i, con := range rts.AttributeSConns {
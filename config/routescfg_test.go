@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -37,6 +38,15 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 		Rals_conns:            &[]string{utils.MetaInternal, "conn1"},
 		Default_ratio:         utils.IntPointer(10),
 		Nested_fields:         utils.BoolPointer(true),
+		Circuit_breakers: &map[string]*RouteBreakerJsonCfg{
+			utils.MetaDefault: {
+				Failures:         utils.IntPointer(5),
+				Failure_ratio:    utils.Float64Pointer(0.5),
+				Window:           utils.IntPointer(10),
+				Open_period:      utils.StringPointer("1m"),
+				Half_open_probes: utils.IntPointer(2),
+			},
+		},
 	}
 	expected := &RouteSCfg{
 		Enabled:             true,
@@ -50,6 +60,15 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 		RALsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder), "conn1"},
 		DefaultRatio:        10,
 		NestedFields:        true,
+		CircuitBreakers: map[string]*RouteBreakerCfg{
+			utils.MetaDefault: {
+				Failures:       5,
+				FailureRatio:   0.5,
+				Window:         10,
+				OpenPeriod:     time.Minute,
+				HalfOpenProbes: 2,
+			},
+		},
 		Opts: &RoutesOpts{
 			Context:      utils.MetaRoutes,
 			IgnoreErrors: false,
@@ -62,6 +81,15 @@ func TestRouteSCfgloadFromJsonCfg(t *testing.T) {
 	} else if !reflect.DeepEqual(expected, jsonCfg.routeSCfg) {
 		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(jsonCfg.routeSCfg))
 	}
+	cfgJSON = &RouteSJsonCfg{
+		Circuit_breakers: &map[string]*RouteBreakerJsonCfg{
+			"ROUTE1": {Open_period: utils.StringPointer("a")},
+		},
+	}
+	expErr := `time: invalid duration "a"`
+	if err = jsonCfg.routeSCfg.loadFromJSONCfg(cfgJSON); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %+v, received %+v", expErr, err)
+	}
 }
 
 func TestRouteSCfgAsMapInterface(t *testing.T) {
@@ -79,6 +107,7 @@ func TestRouteSCfgAsMapInterface(t *testing.T) {
 		utils.StatSConnsCfg:          []string{},
 		utils.RALsConnsCfg:           []string{},
 		utils.DefaultRatioCfg:        1,
+		utils.CircuitBreakersCfg:     map[string]interface{}{},
 		utils.OptsCfg: map[string]interface{}{
 			utils.OptsContext:         utils.MetaRoutes,
 			utils.MetaIgnoreErrorsCfg: false,
@@ -106,6 +135,9 @@ func TestRouteSCfgAsMapInterface1(t *testing.T) {
 			"stats_conns": ["*internal:*stats", "conn1"],
 			"rals_conns": ["*internal:*responder", "conn1"],
 			"default_ratio":2,
+			"circuit_breakers": {
+				"ROUTE1": {"failures": 3, "open_period": "30s", "half_open_probes": 1},
+			},
 		},
 	}`
 	eMap := map[string]interface{}{
@@ -120,6 +152,15 @@ func TestRouteSCfgAsMapInterface1(t *testing.T) {
 		utils.StatSConnsCfg:          []string{utils.MetaInternal, "conn1"},
 		utils.RALsConnsCfg:           []string{utils.MetaInternal, "conn1"},
 		utils.DefaultRatioCfg:        2,
+		utils.CircuitBreakersCfg: map[string]interface{}{
+			"ROUTE1": map[string]interface{}{
+				utils.FailuresCfg:       3,
+				utils.FailureRatioCfg:   0.,
+				utils.WindowCfg:         0,
+				utils.OpenPeriodCfg:     "30s",
+				utils.HalfOpenProbesCfg: 1,
+			},
+		},
 		utils.OptsCfg: map[string]interface{}{
 			utils.OptsContext:         utils.MetaRoutes,
 			utils.MetaIgnoreErrorsCfg: false,
@@ -146,7 +187,10 @@ func TestRouteSCfgClone(t *testing.T) {
 		RALsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaResponder), "conn1"},
 		DefaultRatio:        10,
 		NestedFields:        true,
-		Opts:                &RoutesOpts{},
+		CircuitBreakers: map[string]*RouteBreakerCfg{
+			utils.MetaDefault: {Failures: 5, OpenPeriod: time.Minute, HalfOpenProbes: 1},
+		},
+		Opts: &RoutesOpts{},
 	}
 	rcv := ban.Clone()
 	if !reflect.DeepEqual(ban, rcv) {
@@ -155,6 +199,9 @@ func TestRouteSCfgClone(t *testing.T) {
 	if rcv.AttributeSConns[1] = ""; ban.AttributeSConns[1] != "conn1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
+	if rcv.CircuitBreakers[utils.MetaDefault].Failures = 0; ban.CircuitBreakers[utils.MetaDefault].Failures != 5 {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
 	if rcv.ResourceSConns[1] = ""; ban.ResourceSConns[1] != "conn1" {
 		t.Errorf("Expected clone to not modify the cloned")
 	}
@@ -131,6 +131,7 @@ type RemoteHost struct {
 	ClientKey            string
 	ClientCertificate    string
 	CaCertificate        string
+	AuthKey              string // sent as *authKey API option on each request, for the engines with api_auth enabled
 }
 
 func (rh *RemoteHost) loadFromJSONCfg(jsnCfg *RemoteHostJson) (err error) {
@@ -161,6 +162,9 @@ func (rh *RemoteHost) loadFromJSONCfg(jsnCfg *RemoteHostJson) (err error) {
 	if jsnCfg.Ca_path != nil {
 		rh.CaCertificate = *jsnCfg.Ca_path
 	}
+	if jsnCfg.Auth_key != nil {
+		rh.AuthKey = *jsnCfg.Auth_key
+	}
 	if jsnCfg.Conn_attempts != nil {
 		rh.ConnectAttempts = *jsnCfg.Conn_attempts
 	}
@@ -186,6 +190,7 @@ func (rh *RemoteHost) loadFromJSONCfg(jsnCfg *RemoteHostJson) (err error) {
 }
 
 // AsMapInterface returns the config as a map[string]interface{}
+// the auth_key is omitted so it is not exposed via the config APIs
 func (rh *RemoteHost) AsMapInterface() (mp map[string]interface{}) {
 	mp = map[string]interface{}{
 		utils.AddressCfg:   rh.Address,
@@ -239,6 +244,7 @@ func (rh RemoteHost) Clone() (cln *RemoteHost) {
 		ClientKey:            rh.ClientKey,
 		ClientCertificate:    rh.ClientCertificate,
 		CaCertificate:        rh.CaCertificate,
+		AuthKey:              rh.AuthKey,
 	}
 }
 
@@ -264,6 +270,7 @@ func UpdateRPCCons(rpcConns RPCConns, newHosts map[string]*RemoteHost) (connIDs
 			rh.ClientKey = newHost.ClientKey
 			rh.ClientCertificate = newHost.ClientCertificate
 			rh.CaCertificate = newHost.CaCertificate
+			rh.AuthKey = newHost.AuthKey
 		}
 	}
 	return
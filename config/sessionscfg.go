@@ -105,6 +105,7 @@ type SessionSCfg struct {
 	StatSConns          []string
 	RouteSConns         []string
 	AttrSConns          []string
+	FraudSConns         []string
 	CDRsConns           []string
 	ReplicationConns    []string
 	DebitInterval       time.Duration
@@ -120,6 +121,8 @@ type SessionSCfg struct {
 	TerminateAttempts   int
 	AlterableFields     utils.StringSet
 	MinDurLowBalance    time.Duration
+	BalanceSplit        bool
+	MaxAccountSessions  int
 	SchedulerConns      []string
 	STIRCfg             *STIRcfg
 	DefaultUsage        map[string]time.Duration
@@ -208,6 +211,16 @@ func (scfg *SessionSCfg) loadFromJSONCfg(jsnCfg *SessionSJsonCfg) (err error) {
 			}
 		}
 	}
+	if jsnCfg.Frauds_conns != nil {
+		scfg.FraudSConns = make([]string, len(*jsnCfg.Frauds_conns))
+		for idx, connID := range *jsnCfg.Frauds_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			scfg.FraudSConns[idx] = connID
+			if connID == utils.MetaInternal {
+				scfg.FraudSConns[idx] = utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds)
+			}
+		}
+	}
 	if jsnCfg.Cdrs_conns != nil {
 		scfg.CDRsConns = make([]string, len(*jsnCfg.Cdrs_conns))
 		for idx, connID := range *jsnCfg.Cdrs_conns {
@@ -290,6 +303,12 @@ func (scfg *SessionSCfg) loadFromJSONCfg(jsnCfg *SessionSJsonCfg) (err error) {
 			return err
 		}
 	}
+	if jsnCfg.Balance_split != nil {
+		scfg.BalanceSplit = *jsnCfg.Balance_split
+	}
+	if jsnCfg.Max_account_sessions != nil {
+		scfg.MaxAccountSessions = *jsnCfg.Max_account_sessions
+	}
 	if jsnCfg.Default_usage != nil {
 		for k, v := range *jsnCfg.Default_usage {
 			if scfg.DefaultUsage[k], err = utils.ParseDurationWithNanosecs(v); err != nil {
@@ -339,6 +358,8 @@ func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.AlterableFieldsCfg:     scfg.AlterableFields.AsSlice(),
 		utils.STIRCfg:                scfg.STIRCfg.AsMapInterface(),
 		utils.MinDurLowBalanceCfg:    "0",
+		utils.BalanceSplitCfg:        scfg.BalanceSplit,
+		utils.MaxAccountSessionsCfg:  scfg.MaxAccountSessions,
 		utils.ChannelSyncIntervalCfg: "0",
 		utils.DebitIntervalCfg:       "0",
 		utils.SessionTTLCfg:          "0",
@@ -438,6 +459,16 @@ func (scfg *SessionSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		}
 		initialMP[utils.AttributeSConnsCfg] = attrSConns
 	}
+	if scfg.FraudSConns != nil {
+		fraudSConns := make([]string, len(scfg.FraudSConns))
+		for i, item := range scfg.FraudSConns {
+			fraudSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds) {
+				fraudSConns[i] = utils.MetaInternal
+			}
+		}
+		initialMP[utils.FraudSConnsCfg] = fraudSConns
+	}
 	if scfg.CDRsConns != nil {
 		CDRsConns := make([]string, len(scfg.CDRsConns))
 		for i, item := range scfg.CDRsConns {
@@ -473,6 +504,8 @@ func (scfg SessionSCfg) Clone() (cln *SessionSCfg) {
 		ChannelSyncInterval: scfg.ChannelSyncInterval,
 		TerminateAttempts:   scfg.TerminateAttempts,
 		MinDurLowBalance:    scfg.MinDurLowBalance,
+		BalanceSplit:        scfg.BalanceSplit,
+		MaxAccountSessions:  scfg.MaxAccountSessions,
 
 		SessionIndexes:  scfg.SessionIndexes.Clone(),
 		AlterableFields: scfg.AlterableFields.Clone(),
@@ -537,6 +570,12 @@ func (scfg SessionSCfg) Clone() (cln *SessionSCfg) {
 			cln.AttrSConns[i] = con
 		}
 	}
+	if scfg.FraudSConns != nil {
+		cln.FraudSConns = make([]string, len(scfg.FraudSConns))
+		for i, con := range scfg.FraudSConns {
+			cln.FraudSConns[i] = con
+		}
+	}
 	if scfg.CDRsConns != nil {
 		cln.CDRsConns = make([]string, len(scfg.CDRsConns))
 		for i, con := range scfg.CDRsConns {
@@ -774,10 +813,11 @@ func (aConnCfg AsteriskConnCfg) Clone() *AsteriskConnCfg {
 
 // AsteriskAgentCfg the config section that describes the Asterisk Agent
 type AsteriskAgentCfg struct {
-	Enabled       bool
-	SessionSConns []string
-	CreateCDR     bool
-	AsteriskConns []*AsteriskConnCfg
+	Enabled           bool
+	SessionSConns     []string
+	CreateCDR         bool
+	LowBalanceAnnFile string
+	AsteriskConns     []*AsteriskConnCfg
 }
 
 func (aCfg *AsteriskAgentCfg) loadFromJSONCfg(jsnCfg *AsteriskAgentJsonCfg) (err error) {
@@ -801,6 +841,9 @@ func (aCfg *AsteriskAgentCfg) loadFromJSONCfg(jsnCfg *AsteriskAgentJsonCfg) (err
 	if jsnCfg.Create_cdr != nil {
 		aCfg.CreateCDR = *jsnCfg.Create_cdr
 	}
+	if jsnCfg.Low_balance_ann_file != nil {
+		aCfg.LowBalanceAnnFile = *jsnCfg.Low_balance_ann_file
+	}
 
 	if jsnCfg.Asterisk_conns != nil {
 		aCfg.AsteriskConns = make([]*AsteriskConnCfg, len(*jsnCfg.Asterisk_conns))
@@ -815,8 +858,9 @@ func (aCfg *AsteriskAgentCfg) loadFromJSONCfg(jsnCfg *AsteriskAgentJsonCfg) (err
 // AsMapInterface returns the config as a map[string]interface{}
 func (aCfg *AsteriskAgentCfg) AsMapInterface() (initialMP map[string]interface{}) {
 	initialMP = map[string]interface{}{
-		utils.EnabledCfg:   aCfg.Enabled,
-		utils.CreateCDRCfg: aCfg.CreateCDR,
+		utils.EnabledCfg:           aCfg.Enabled,
+		utils.CreateCDRCfg:         aCfg.CreateCDR,
+		utils.LowBalanceAnnFileCfg: aCfg.LowBalanceAnnFile,
 	}
 	if aCfg.AsteriskConns != nil {
 		conns := make([]map[string]interface{}, len(aCfg.AsteriskConns))
@@ -843,8 +887,9 @@ func (aCfg *AsteriskAgentCfg) AsMapInterface() (initialMP map[string]interface{}
 // Clone returns a deep copy of AsteriskAgentCfg
 func (aCfg AsteriskAgentCfg) Clone() (cln *AsteriskAgentCfg) {
 	cln = &AsteriskAgentCfg{
-		Enabled:   aCfg.Enabled,
-		CreateCDR: aCfg.CreateCDR,
+		Enabled:           aCfg.Enabled,
+		CreateCDR:         aCfg.CreateCDR,
+		LowBalanceAnnFile: aCfg.LowBalanceAnnFile,
 	}
 	if aCfg.SessionSConns != nil {
 		cln.SessionSConns = make([]string, len(aCfg.SessionSConns))
@@ -73,6 +73,7 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 		Stats_conns:           &[]string{utils.MetaInternal, "*conn1"},
 		Routes_conns:          &[]string{utils.MetaInternal, "*conn1"},
 		Attributes_conns:      &[]string{utils.MetaInternal, "*conn1"},
+		Frauds_conns:          &[]string{utils.MetaInternal, "*conn1"},
 		Cdrs_conns:            &[]string{utils.MetaInternal, "*conn1"},
 		Replication_conns:     &[]string{"*conn1"},
 		Debit_interval:        utils.StringPointer("2"),
@@ -84,6 +85,8 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 		Terminate_attempts:    utils.IntPointer(6),
 		Alterable_fields:      &[]string{},
 		Min_dur_low_balance:   utils.StringPointer("1"),
+		Balance_split:         utils.BoolPointer(true),
+		Max_account_sessions:  utils.IntPointer(3),
 		Scheduler_conns:       &[]string{utils.MetaInternal, "*conn1"},
 		Stir: &STIRJsonCfg{
 			Allowed_attest:      &[]string{utils.MetaAny},
@@ -103,6 +106,7 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 		StatSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaStats), "*conn1"},
 		RouteSConns:         []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes), "*conn1"},
 		AttrSConns:          []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes), "*conn1"},
+		FraudSConns:         []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds), "*conn1"},
 		CDRsConns:           []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaCDRs), "*conn1"},
 		ReplicationConns:    []string{"*conn1"},
 		DebitInterval:       2,
@@ -114,6 +118,8 @@ func TestSessionSCfgloadFromJsonCfgCase1(t *testing.T) {
 		TerminateAttempts:   6,
 		AlterableFields:     utils.StringSet{},
 		MinDurLowBalance:    1,
+		BalanceSplit:        true,
+		MaxAccountSessions:  3,
 		SchedulerConns:      []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaScheduler), "*conn1"},
 		STIRCfg: &STIRcfg{
 			AllowedAttest:      utils.StringSet{utils.MetaAny: {}},
@@ -241,6 +247,7 @@ func TestSessionSCfgloadFromJsonCfgCase10(t *testing.T) {
 		StatSConns:          []string{},
 		RouteSConns:         []string{},
 		AttrSConns:          []string{},
+		FraudSConns:         []string{},
 		CDRsConns:           []string{},
 		ReplicationConns:    []string{},
 		DebitInterval:       0,
@@ -352,6 +359,7 @@ func TestSessionSCfgAsMapInterfaceCase1(t *testing.T) {
 		utils.StatSConnsCfg:          []string{},
 		utils.RouteSConnsCfg:         []string{},
 		utils.AttributeSConnsCfg:     []string{},
+		utils.FraudSConnsCfg:         []string{},
 		utils.ReplicationConnsCfg:    []string{},
 		utils.DebitIntervalCfg:       "0",
 		utils.StoreSCostsCfg:         false,
@@ -365,6 +373,8 @@ func TestSessionSCfgAsMapInterfaceCase1(t *testing.T) {
 		utils.ChannelSyncIntervalCfg: "1s",
 		utils.TerminateAttemptsCfg:   5,
 		utils.MinDurLowBalanceCfg:    "0",
+		utils.BalanceSplitCfg:        false,
+		utils.MaxAccountSessionsCfg:  0,
 		utils.AlterableFieldsCfg:     []string{},
 		utils.STIRCfg: map[string]interface{}{
 			utils.AllowedAtestCfg:       []string{"*any"},
@@ -401,11 +411,14 @@ func TestSessionSCfgAsMapInterfaceCase2(t *testing.T) {
 			"stats_conns": ["*internal:*stats", "*conn1"],
 			"routes_conns": ["*internal:*routes", "*conn1"],
 			"attributes_conns": ["*internal:*attributes", "*conn1"],
+			"frauds_conns": ["*internal:*frauds", "*conn1"],
 			"replication_conns": ["*localhost"],
 			"debit_interval": "8s",
 			"store_session_costs": true,
 			"session_ttl": "1s",
             "min_dur_low_balance": "1s",
+			"balance_split": true,
+			"max_account_sessions": 2,
 			"client_protocol": 2.0,
 			"terminate_attempts": 10,
 			"stir": {
@@ -430,10 +443,13 @@ func TestSessionSCfgAsMapInterfaceCase2(t *testing.T) {
 		utils.StatSConnsCfg:          []string{utils.MetaInternal, "*conn1"},
 		utils.RouteSConnsCfg:         []string{utils.MetaInternal, "*conn1"},
 		utils.AttributeSConnsCfg:     []string{utils.MetaInternal, "*conn1"},
+		utils.FraudSConnsCfg:         []string{utils.MetaInternal, "*conn1"},
 		utils.ReplicationConnsCfg:    []string{utils.MetaLocalHost},
 		utils.DebitIntervalCfg:       "8s",
 		utils.StoreSCostsCfg:         true,
 		utils.MinDurLowBalanceCfg:    "1s",
+		utils.BalanceSplitCfg:        true,
+		utils.MaxAccountSessionsCfg:  2,
 		utils.SessionTTLCfg:          "1s",
 		utils.SessionIndexesCfg:      []string{},
 		utils.ClientProtocolCfg:      2.0,
@@ -704,9 +720,10 @@ func TestRemoteHostloadFromJsonCfg(t *testing.T) {
 
 func TestAsteriskAgentCfgloadFromJsonCfg(t *testing.T) {
 	cfgJSON := &AsteriskAgentJsonCfg{
-		Enabled:        utils.BoolPointer(true),
-		Sessions_conns: &[]string{utils.MetaInternal},
-		Create_cdr:     utils.BoolPointer(true),
+		Enabled:              utils.BoolPointer(true),
+		Sessions_conns:       &[]string{utils.MetaInternal},
+		Create_cdr:           utils.BoolPointer(true),
+		Low_balance_ann_file: utils.StringPointer("sound:tt-monkeys"),
 		Asterisk_conns: &[]*AstConnJsonCfg{
 			{
 				Alias:            utils.StringPointer("127.0.0.1:8448"),
@@ -719,9 +736,10 @@ func TestAsteriskAgentCfgloadFromJsonCfg(t *testing.T) {
 		},
 	}
 	expected := &AsteriskAgentCfg{
-		Enabled:       true,
-		SessionSConns: []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
-		CreateCDR:     true,
+		Enabled:           true,
+		SessionSConns:     []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS)},
+		CreateCDR:         true,
+		LowBalanceAnnFile: "sound:tt-monkeys",
 		AsteriskConns: []*AsteriskConnCfg{{
 			Alias:           "127.0.0.1:8448",
 			Address:         "127.0.0.1:8088",
@@ -746,9 +764,10 @@ func TestAsteriskAgentCfgAsMapInterface(t *testing.T) {
 	},
 }`
 	eMap := map[string]interface{}{
-		utils.EnabledCfg:       false,
-		utils.SessionSConnsCfg: []string{utils.MetaInternal},
-		utils.CreateCdrCfg:     false,
+		utils.EnabledCfg:           false,
+		utils.SessionSConnsCfg:     []string{utils.MetaInternal},
+		utils.CreateCdrCfg:         false,
+		utils.LowBalanceAnnFileCfg: "",
 		utils.AsteriskConnsCfg: []map[string]interface{}{
 			{utils.AliasCfg: "", utils.AddressCfg: "127.0.0.1:8088", utils.UserCf: "cgrates", utils.Password: "CGRateS.org", utils.ConnectAttemptsCfg: 3, utils.ReconnectsCfg: 5, utils.MaxReconnectIntervalCfg: "0s"},
 		},
@@ -766,15 +785,17 @@ func TestAsteriskAgentCfgAsMapInterface1(t *testing.T) {
 		"enabled": true,
 		"sessions_conns": ["*birpc_internal", "*conn1","*conn2"],
 		"create_cdr": true,
+		"low_balance_ann_file": "sound:tt-monkeys",
 		"asterisk_conns":[
 			{"address": "127.0.0.1:8089","connect_attempts": 5,"reconnects": 8}
 		],
 	},
 }`
 	eMap := map[string]interface{}{
-		utils.EnabledCfg:       true,
-		utils.SessionSConnsCfg: []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
-		utils.CreateCdrCfg:     true,
+		utils.EnabledCfg:           true,
+		utils.SessionSConnsCfg:     []string{rpcclient.BiRPCInternal, "*conn1", "*conn2"},
+		utils.CreateCdrCfg:         true,
+		utils.LowBalanceAnnFileCfg: "sound:tt-monkeys",
 		utils.AsteriskConnsCfg: []map[string]interface{}{
 			{utils.AliasCfg: "", utils.AddressCfg: "127.0.0.1:8089", utils.UserCf: "cgrates", utils.Password: "CGRateS.org", utils.ConnectAttemptsCfg: 5, utils.ReconnectsCfg: 8, utils.MaxReconnectIntervalCfg: "0s"},
 		},
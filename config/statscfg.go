@@ -35,6 +35,7 @@ type StatSCfg struct {
 	IndexedSelects         bool
 	StoreInterval          time.Duration // Dump regularly from cache into dataDB
 	StoreUncompressedLimit int
+	ChildQueuesTTL         time.Duration // remove the child queues not receiving events within this interval
 	ThresholdSConns        []string
 	StringIndexedFields    *[]string
 	PrefixIndexedFields    *[]string
@@ -73,6 +74,11 @@ func (st *StatSCfg) loadFromJSONCfg(jsnCfg *StatServJsonCfg) (err error) {
 	if jsnCfg.Store_uncompressed_limit != nil {
 		st.StoreUncompressedLimit = *jsnCfg.Store_uncompressed_limit
 	}
+	if jsnCfg.Child_queues_ttl != nil {
+		if st.ChildQueuesTTL, err = utils.ParseDurationWithNanosecs(*jsnCfg.Child_queues_ttl); err != nil {
+			return err
+		}
+	}
 	if jsnCfg.Thresholds_conns != nil {
 		st.ThresholdSConns = make([]string, len(*jsnCfg.Thresholds_conns))
 		for idx, conn := range *jsnCfg.Thresholds_conns {
@@ -123,6 +129,7 @@ func (st *StatSCfg) AsMapInterface() (initialMP map[string]interface{}) {
 		utils.EnabledCfg:                st.Enabled,
 		utils.IndexedSelectsCfg:         st.IndexedSelects,
 		utils.StoreUncompressedLimitCfg: st.StoreUncompressedLimit,
+		utils.ChildQueuesTTLCfg:         st.ChildQueuesTTL.String(),
 		utils.NestedFieldsCfg:           st.NestedFields,
 		utils.StoreIntervalCfg:          utils.EmptyString,
 		utils.OptsCfg:                   opts,
@@ -181,6 +188,7 @@ func (st StatSCfg) Clone() (cln *StatSCfg) {
 		IndexedSelects:         st.IndexedSelects,
 		StoreInterval:          st.StoreInterval,
 		StoreUncompressedLimit: st.StoreUncompressedLimit,
+		ChildQueuesTTL:         st.ChildQueuesTTL,
 		NestedFields:           st.NestedFields,
 		Opts:                   st.Opts.Clone(),
 	}
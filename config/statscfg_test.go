@@ -20,6 +20,7 @@ package config
 import (
 	"reflect"
 	"testing"
+	"time"
 
 	"github.com/cgrates/cgrates/utils"
 )
@@ -30,6 +31,7 @@ func TestStatSCfgloadFromJsonCfgCase1(t *testing.T) {
 		Indexed_selects:          utils.BoolPointer(true),
 		Store_interval:           utils.StringPointer("2"),
 		Store_uncompressed_limit: utils.IntPointer(10),
+		Child_queues_ttl:         utils.StringPointer("10m"),
 		Thresholds_conns:         &[]string{utils.MetaInternal, "*conn1"},
 		String_indexed_fields:    &[]string{"*req.string"},
 		Prefix_indexed_fields:    &[]string{"*req.index1", "*req.index2"},
@@ -41,6 +43,7 @@ func TestStatSCfgloadFromJsonCfgCase1(t *testing.T) {
 		IndexedSelects:         true,
 		StoreInterval:          2,
 		StoreUncompressedLimit: 10,
+		ChildQueuesTTL:         10 * time.Minute,
 		ThresholdSConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
 		StringIndexedFields:    &[]string{"*req.string"},
 		PrefixIndexedFields:    &[]string{"*req.index1", "*req.index2"},
@@ -77,6 +80,7 @@ func TestStatSCfgAsMapInterface(t *testing.T) {
 		utils.EnabledCfg:                false,
 		utils.StoreIntervalCfg:          utils.EmptyString,
 		utils.StoreUncompressedLimitCfg: 0,
+		utils.ChildQueuesTTLCfg:         "1h0m0s",
 		utils.ThresholdSConnsCfg:        []string{},
 		utils.IndexedSelectsCfg:         true,
 		utils.PrefixIndexedFieldsCfg:    []string{},
@@ -100,6 +104,7 @@ func TestStatSCfgAsMapInterface1(t *testing.T) {
 			"enabled": true,				
 			"store_interval": "72h",			
 			"store_uncompressed_limit": 1,	
+			"child_queues_ttl": "1h",
 			"thresholds_conns": ["*internal:*thresholds", "*conn1"],			
 			"indexed_selects":false,			
             "string_indexed_fields": ["*req.string"],
@@ -112,6 +117,7 @@ func TestStatSCfgAsMapInterface1(t *testing.T) {
 		utils.EnabledCfg:                true,
 		utils.StoreIntervalCfg:          "72h0m0s",
 		utils.StoreUncompressedLimitCfg: 1,
+		utils.ChildQueuesTTLCfg:         "1h0m0s",
 		utils.ThresholdSConnsCfg:        []string{utils.MetaInternal, "*conn1"},
 		utils.IndexedSelectsCfg:         false,
 		utils.StringIndexedFieldsCfg:    []string{"*req.string"},
@@ -135,6 +141,7 @@ func TestStatSCfgClone(t *testing.T) {
 		IndexedSelects:         true,
 		StoreInterval:          2,
 		StoreUncompressedLimit: 10,
+		ChildQueuesTTL:         10 * time.Minute,
 		ThresholdSConns:        []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds), "*conn1"},
 		StringIndexedFields:    &[]string{"*req.index1"},
 		PrefixIndexedFields:    &[]string{"*req.index1", "*req.index2"},
@@ -0,0 +1,135 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// WebSocketAgentCfg the config section that describes the WebSocket Agent
+type WebSocketAgentCfg struct {
+	Enabled           bool
+	URL               string   // path on the HTTP server where the WebSocket connections are accepted
+	AllowedOrigins    []string // origins allowed to open browser connections, the same origin only if empty
+	SessionSConns     []string
+	Timezone          string
+	RequestProcessors []*RequestProcessor
+}
+
+func (wa *WebSocketAgentCfg) loadFromJSONCfg(jsnCfg *WebSocketAgentJsonCfg, sep string) (err error) {
+	if jsnCfg == nil {
+		return nil
+	}
+	if jsnCfg.Enabled != nil {
+		wa.Enabled = *jsnCfg.Enabled
+	}
+	if jsnCfg.Url != nil {
+		wa.URL = *jsnCfg.Url
+	}
+	if jsnCfg.Allowed_origins != nil {
+		wa.AllowedOrigins = utils.CloneStringSlice(*jsnCfg.Allowed_origins)
+	}
+	if jsnCfg.Timezone != nil {
+		wa.Timezone = *jsnCfg.Timezone
+	}
+	if jsnCfg.Sessions_conns != nil {
+		wa.SessionSConns = make([]string, len(*jsnCfg.Sessions_conns))
+		for idx, connID := range *jsnCfg.Sessions_conns {
+			// if we have the connection internal we change the name so we can have internal rpc for each subsystem
+			wa.SessionSConns[idx] = connID
+			if connID == utils.MetaInternal ||
+				connID == rpcclient.BiRPCInternal {
+				wa.SessionSConns[idx] = utils.ConcatenatedKey(connID, utils.MetaSessionS)
+			}
+		}
+	}
+	if jsnCfg.Request_processors != nil {
+		for _, reqProcJsn := range *jsnCfg.Request_processors {
+			rp := new(RequestProcessor)
+			var haveID bool
+			for _, rpSet := range wa.RequestProcessors {
+				if reqProcJsn.ID != nil && rpSet.ID == *reqProcJsn.ID {
+					rp = rpSet // Will load data into the one set
+					haveID = true
+					break
+				}
+			}
+			if err = rp.loadFromJSONCfg(reqProcJsn, sep); err != nil {
+				return
+			}
+			if !haveID {
+				wa.RequestProcessors = append(wa.RequestProcessors, rp)
+			}
+		}
+	}
+	return
+}
+
+// AsMapInterface returns the config as a map[string]interface{}
+func (wa *WebSocketAgentCfg) AsMapInterface(separator string) (initialMP map[string]interface{}) {
+	initialMP = map[string]interface{}{
+		utils.EnabledCfg:        wa.Enabled,
+		utils.URLCfg:            wa.URL,
+		utils.AllowedOriginsCfg: utils.CloneStringSlice(wa.AllowedOrigins),
+		utils.TimezoneCfg:       wa.Timezone,
+	}
+
+	requestProcessors := make([]map[string]interface{}, len(wa.RequestProcessors))
+	for i, item := range wa.RequestProcessors {
+		requestProcessors[i] = item.AsMapInterface(separator)
+	}
+	initialMP[utils.RequestProcessorsCfg] = requestProcessors
+
+	if wa.SessionSConns != nil {
+		sessionSConns := make([]string, len(wa.SessionSConns))
+		for i, item := range wa.SessionSConns {
+			sessionSConns[i] = item
+			if item == utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS) {
+				sessionSConns[i] = utils.MetaInternal
+			} else if item == utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS) {
+				sessionSConns[i] = rpcclient.BiRPCInternal
+			}
+		}
+		initialMP[utils.SessionSConnsCfg] = sessionSConns
+	}
+	return
+}
+
+// Clone returns a deep copy of WebSocketAgentCfg
+func (wa WebSocketAgentCfg) Clone() (cln *WebSocketAgentCfg) {
+	cln = &WebSocketAgentCfg{
+		Enabled:  wa.Enabled,
+		URL:      wa.URL,
+		Timezone: wa.Timezone,
+	}
+	if wa.AllowedOrigins != nil {
+		cln.AllowedOrigins = utils.CloneStringSlice(wa.AllowedOrigins)
+	}
+	if wa.SessionSConns != nil {
+		cln.SessionSConns = utils.CloneStringSlice(wa.SessionSConns)
+	}
+	if wa.RequestProcessors != nil {
+		cln.RequestProcessors = make([]*RequestProcessor, len(wa.RequestProcessors))
+		for i, req := range wa.RequestProcessors {
+			cln.RequestProcessors[i] = req.Clone()
+		}
+	}
+	return
+}
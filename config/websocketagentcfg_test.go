@@ -0,0 +1,143 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package config
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestWebSocketAgentCfgloadFromJsonCfg(t *testing.T) {
+	cfgJSON := &WebSocketAgentJsonCfg{
+		Enabled:         utils.BoolPointer(true),
+		Url:             utils.StringPointer("/ws"),
+		Allowed_origins: &[]string{"https://cgrates.org"},
+		Sessions_conns:  &[]string{rpcclient.BiRPCInternal, utils.MetaInternal, "*conn1"},
+		Timezone:        utils.StringPointer("UTC"),
+		Request_processors: &[]*ReqProcessorJsnCfg{
+			{
+				ID:             utils.StringPointer("OutboundAUTHDryRun"),
+				Filters:        &[]string{"*string:~*req.request_type:OutboundAUTH"},
+				Flags:          &[]string{utils.MetaDryRun},
+				Request_fields: &[]*FcTemplateJsonCfg{},
+				Reply_fields:   &[]*FcTemplateJsonCfg{},
+			},
+		},
+	}
+	expected := &WebSocketAgentCfg{
+		Enabled:        true,
+		URL:            "/ws",
+		AllowedOrigins: []string{"https://cgrates.org"},
+		SessionSConns: []string{utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS),
+			utils.ConcatenatedKey(utils.MetaInternal, utils.MetaSessionS), "*conn1"},
+		Timezone: "UTC",
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:            "OutboundAUTHDryRun",
+				Filters:       []string{"*string:~*req.request_type:OutboundAUTH"},
+				Flags:         utils.FlagsWithParams{utils.MetaDryRun: {}},
+				RequestFields: []*FCTemplate{},
+				ReplyFields:   []*FCTemplate{},
+			},
+		},
+	}
+	jsonCfg := NewDefaultCGRConfig()
+	if err := jsonCfg.wsAgentCfg.loadFromJSONCfg(nil, jsonCfg.generalCfg.RSRSep); err != nil {
+		t.Error(err)
+	} else if err := jsonCfg.wsAgentCfg.loadFromJSONCfg(cfgJSON, jsonCfg.generalCfg.RSRSep); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expected, jsonCfg.wsAgentCfg) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(expected), utils.ToJSON(jsonCfg.wsAgentCfg))
+	}
+}
+
+func TestWebSocketAgentCfgAsMapInterface(t *testing.T) {
+	cfgJSONStr := `{
+	"websocket_agent": {
+		"enabled": true,
+		"url": "/ws",
+		"allowed_origins": ["*any"],
+		"sessions_conns": ["*birpc_internal", "*internal", "*conn1"],
+		"timezone": "UTC",
+		"request_processors": [
+			{
+				"id": "OutboundAUTHDryRun",
+				"filters": ["*string:~*req.request_type:OutboundAUTH"],
+				"flags": ["*dryrun"],
+				"request_fields": [],
+				"reply_fields": [],
+			},
+		],
+	},
+}`
+	eMap := map[string]interface{}{
+		utils.EnabledCfg:        true,
+		utils.URLCfg:            "/ws",
+		utils.AllowedOriginsCfg: []string{utils.MetaAny},
+		utils.SessionSConnsCfg:  []string{rpcclient.BiRPCInternal, utils.MetaInternal, "*conn1"},
+		utils.TimezoneCfg:       "UTC",
+		utils.RequestProcessorsCfg: []map[string]interface{}{
+			{
+				utils.IDCfg:            "OutboundAUTHDryRun",
+				utils.FiltersCfg:       []string{"*string:~*req.request_type:OutboundAUTH"},
+				utils.FlagsCfg:         []string{utils.MetaDryRun},
+				utils.TimezoneCfg:      utils.EmptyString,
+				utils.RequestFieldsCfg: []map[string]interface{}{},
+				utils.ReplyFieldsCfg:   []map[string]interface{}{},
+			},
+		},
+	}
+	if cgrCfg, err := NewCGRConfigFromJSONStringWithDefaults(cfgJSONStr); err != nil {
+		t.Error(err)
+	} else if rcv := cgrCfg.wsAgentCfg.AsMapInterface(cgrCfg.generalCfg.RSRSep); !reflect.DeepEqual(eMap, rcv) {
+		t.Errorf("Expected %+v \n, received %+v", utils.ToJSON(eMap), utils.ToJSON(rcv))
+	}
+}
+
+func TestWebSocketAgentCfgClone(t *testing.T) {
+	ban := &WebSocketAgentCfg{
+		Enabled:        true,
+		URL:            "/ws",
+		AllowedOrigins: []string{"https://cgrates.org"},
+		SessionSConns:  []string{utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS)},
+		Timezone:       "UTC",
+		RequestProcessors: []*RequestProcessor{
+			{
+				ID:            "OutboundAUTHDryRun",
+				Filters:       []string{"*string:~*req.request_type:OutboundAUTH"},
+				Flags:         utils.FlagsWithParams{utils.MetaDryRun: {}},
+				RequestFields: []*FCTemplate{},
+				ReplyFields:   []*FCTemplate{},
+			},
+		},
+	}
+	rcv := ban.Clone()
+	if !reflect.DeepEqual(ban, rcv) {
+		t.Errorf("Expected: %+v\nReceived: %+v", utils.ToJSON(ban), utils.ToJSON(rcv))
+	}
+	if rcv.SessionSConns[0] = ""; ban.SessionSConns[0] != utils.ConcatenatedKey(rpcclient.BiRPCInternal, utils.MetaSessionS) {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+	if rcv.RequestProcessors[0].ID = ""; ban.RequestProcessors[0].ID != "OutboundAUTHDryRun" {
+		t.Errorf("Expected clone to not modify the cloned")
+	}
+}
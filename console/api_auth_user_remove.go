@@ -0,0 +1,65 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package console
+
+import (
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdRemoveAPIAuthUser{
+		name:      "api_auth_user_remove",
+		rpcMethod: utils.ConfigSv1RemoveAPIAuthUser,
+		rpcParams: &config.APIAuthIDWithAPIOpts{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// CmdRemoveAPIAuthUser removes an user of the API authorization
+type CmdRemoveAPIAuthUser struct {
+	name      string
+	rpcMethod string
+	rpcParams *config.APIAuthIDWithAPIOpts
+	*CommandExecuter
+}
+
+func (self *CmdRemoveAPIAuthUser) Name() string {
+	return self.name
+}
+
+func (self *CmdRemoveAPIAuthUser) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdRemoveAPIAuthUser) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &config.APIAuthIDWithAPIOpts{}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdRemoveAPIAuthUser) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdRemoveAPIAuthUser) RpcResult() interface{} {
+	var reply string
+	return &reply
+}
@@ -0,0 +1,65 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package console
+
+import (
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdSetAPIAuthUser{
+		name:      "api_auth_user_set",
+		rpcMethod: utils.ConfigSv1SetAPIAuthUser,
+		rpcParams: &config.APIAuthUserWithAPIOpts{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// CmdSetAPIAuthUser adds or replaces an user of the API authorization
+type CmdSetAPIAuthUser struct {
+	name      string
+	rpcMethod string
+	rpcParams *config.APIAuthUserWithAPIOpts
+	*CommandExecuter
+}
+
+func (self *CmdSetAPIAuthUser) Name() string {
+	return self.name
+}
+
+func (self *CmdSetAPIAuthUser) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdSetAPIAuthUser) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &config.APIAuthUserWithAPIOpts{}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdSetAPIAuthUser) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdSetAPIAuthUser) RpcResult() interface{} {
+	var reply string
+	return &reply
+}
@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package console
+
+import (
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdExportDataDBSnapshot{
+		name:      "datadb_snapshot_export",
+		rpcMethod: utils.APIerSv1ExportDataDBSnapshot,
+		rpcParams: &v1.AttrDataDBSnapshot{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// CmdExportDataDBSnapshot exports a snapshot of the DataDB into an archive
+type CmdExportDataDBSnapshot struct {
+	name      string
+	rpcMethod string
+	rpcParams *v1.AttrDataDBSnapshot
+	*CommandExecuter
+}
+
+func (self *CmdExportDataDBSnapshot) Name() string {
+	return self.name
+}
+
+func (self *CmdExportDataDBSnapshot) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdExportDataDBSnapshot) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &v1.AttrDataDBSnapshot{}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdExportDataDBSnapshot) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdExportDataDBSnapshot) RpcResult() interface{} {
+	var info engine.DataDBSnapshotInfo
+	return &info
+}
@@ -0,0 +1,66 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package console
+
+import (
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdRestoreDataDBSnapshot{
+		name:      "datadb_snapshot_restore",
+		rpcMethod: utils.APIerSv1RestoreDataDBSnapshot,
+		rpcParams: &v1.AttrDataDBSnapshot{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+// CmdRestoreDataDBSnapshot restores a DataDB snapshot archive
+type CmdRestoreDataDBSnapshot struct {
+	name      string
+	rpcMethod string
+	rpcParams *v1.AttrDataDBSnapshot
+	*CommandExecuter
+}
+
+func (self *CmdRestoreDataDBSnapshot) Name() string {
+	return self.name
+}
+
+func (self *CmdRestoreDataDBSnapshot) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdRestoreDataDBSnapshot) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &v1.AttrDataDBSnapshot{}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdRestoreDataDBSnapshot) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdRestoreDataDBSnapshot) RpcResult() interface{} {
+	var info engine.DataDBSnapshotInfo
+	return &info
+}
@@ -0,0 +1,67 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package console
+
+import (
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func init() {
+	c := &CmdGetThresholdAlarms{
+		name:      "thresholds_alarms",
+		rpcMethod: utils.ThresholdSv1GetRaisedAlarms,
+		rpcParams: &utils.TenantWithAPIOpts{},
+	}
+	commands[c.Name()] = c
+	c.CommandExecuter = &CommandExecuter{c}
+}
+
+type CmdGetThresholdAlarms struct {
+	name      string
+	rpcMethod string
+	rpcParams *utils.TenantWithAPIOpts
+	*CommandExecuter
+}
+
+func (self *CmdGetThresholdAlarms) Name() string {
+	return self.name
+}
+
+func (self *CmdGetThresholdAlarms) RpcMethod() string {
+	return self.rpcMethod
+}
+
+func (self *CmdGetThresholdAlarms) RpcParams(reset bool) interface{} {
+	if reset || self.rpcParams == nil {
+		self.rpcParams = &utils.TenantWithAPIOpts{
+			APIOpts: make(map[string]interface{}),
+		}
+	}
+	return self.rpcParams
+}
+
+func (self *CmdGetThresholdAlarms) PostprocessRpcParams() error {
+	return nil
+}
+
+func (self *CmdGetThresholdAlarms) RpcResult() interface{} {
+	var atr []*engine.ThresholdAlarm
+	return &atr
+}
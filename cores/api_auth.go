@@ -0,0 +1,225 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"fmt"
+	"net/rpc"
+	"reflect"
+
+	"github.com/cenkalti/rpc2"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// APIAuthSv1 exports the connection level authentication, the key being checked by the server codecs
+type APIAuthSv1 struct{}
+
+// Authenticate authenticates the following requests of the connection with the user owning the *authKey API option
+// used by the clients not able to send the key with each request, like the ones of the methods without APIOpts
+func (APIAuthSv1) Authenticate(_ *utils.TenantWithAPIOpts, reply *string) error {
+	*reply = utils.OK
+	return nil
+}
+
+// biRPCAuthenticate is the BiRPC handler of APIAuthSv1.Authenticate
+func biRPCAuthenticate(_ *rpc2.Client, args *utils.TenantWithAPIOpts, reply *string) error {
+	return APIAuthSv1{}.Authenticate(args, reply)
+}
+
+// newAPIAuthCodec returns a codec authorizing the requests based on the api_auth section of the config
+// the user authorized by basicAuth, if any, is passed to the methods via the APIOpts of the arguments
+func newAPIAuthCodec(sc rpc.ServerCodec, user string) rpc.ServerCodec {
+	return &apiAuthServerCodec{
+		ServerCodec: sc,
+		user:        user,
+	}
+}
+
+type apiAuthServerCodec struct {
+	rpc.ServerCodec
+	user    string
+	keyUser string // the user authenticated via APIAuthSv1.Authenticate
+	method  string // the method of the request being read
+}
+
+func (c *apiAuthServerCodec) ReadRequestHeader(r *rpc.Request) (err error) {
+	if err = c.ServerCodec.ReadRequestHeader(r); err == nil {
+		c.method = r.ServiceMethod
+	}
+	return
+}
+
+// ReadRequestBody decodes the arguments and authorizes the request
+// on error the rpc server replies with it and continues reading the following requests
+func (c *apiAuthServerCodec) ReadRequestBody(x interface{}) (err error) {
+	if err = c.ServerCodec.ReadRequestBody(x); err != nil ||
+		x == nil { // body discarded by the rpc server
+		return
+	}
+	user := utils.FirstNonEmpty(c.user, c.keyUser)
+	cfg := config.CgrConfig()
+	if aa := cfg.APIAuthCfg(); aa.Enabled {
+		if c.method != utils.APIAuthSv1Authenticate {
+			user, err = authorizeAPIRequest(aa, user, c.method, x, cfg.GeneralCfg().DefaultTenant)
+		} else if c.user == utils.EmptyString {
+			c.keyUser, err = authenticateAPIRequest(aa, c.method, x)
+			user = c.keyUser
+		} else { // the basic auth user has priority
+			popAuthKey(x)
+		}
+		if err != nil {
+			return
+		}
+	}
+	if user != utils.EmptyString {
+		setAPIUser(x, user)
+	}
+	return
+}
+
+// newAPIAuthBiRPCCodec returns a BiRPC codec authorizing the requests based on the api_auth section of the config
+func newAPIAuthBiRPCCodec(sc rpc2.Codec) rpc2.Codec {
+	return &apiAuthBiRPCCodec{Codec: sc}
+}
+
+type apiAuthBiRPCCodec struct {
+	rpc2.Codec
+	keyUser string       // the user authenticated via APIAuthSv1.Authenticate
+	req     rpc2.Request // the request being read
+}
+
+// ReadHeader must read a message and populate either the request
+// or the response by inspecting the incoming message.
+func (c *apiAuthBiRPCCodec) ReadHeader(req *rpc2.Request, resp *rpc2.Response) (err error) {
+	if err = c.Codec.ReadHeader(req, resp); err == nil {
+		c.req = *req
+	}
+	return
+}
+
+// ReadRequestBody decodes the arguments and authorizes the request
+// rpc2 closes the connection on read errors so the error reply is sent from here
+func (c *apiAuthBiRPCCodec) ReadRequestBody(x interface{}) (err error) {
+	if err = c.Codec.ReadRequestBody(x); err != nil || x == nil {
+		return
+	}
+	cfg := config.CgrConfig()
+	aa := cfg.APIAuthCfg()
+	if !aa.Enabled {
+		return
+	}
+	var user string
+	if c.req.Method == utils.APIAuthSv1Authenticate {
+		c.keyUser, err = authenticateAPIRequest(aa, c.req.Method, x)
+		user = c.keyUser
+	} else {
+		user, err = authorizeAPIRequest(aa, c.keyUser, c.req.Method, x, cfg.GeneralCfg().DefaultTenant)
+	}
+	if err != nil {
+		if c.req.Seq != 0 { // not a notification
+			resp := &rpc2.Response{Seq: c.req.Seq, Error: err.Error()}
+			c.Codec.WriteResponse(resp, resp)
+		}
+		return
+	}
+	setAPIUser(x, user)
+	return
+}
+
+// authenticateAPIRequest returns the user owning the *authKey API option, removing it from the arguments
+func authenticateAPIRequest(aa *config.APIAuthCfg, method string, args interface{}) (user string, err error) {
+	var has bool
+	if user, has = aa.UserByKey(popAuthKey(args)); !has {
+		utils.Logger.Warning(fmt.Sprintf("<APIAuth> unauthenticated call of method <%s>", method))
+		return utils.EmptyString, utils.ErrUnauthorizedApi
+	}
+	return
+}
+
+// authorizeAPIRequest authenticates the user, if not already done by basicAuth or per connection, based on the *authKey API option
+// and checks if the user roles allow the method for all the tenants of the request
+func authorizeAPIRequest(aa *config.APIAuthCfg, user, method string, args interface{}, dfltTnt string) (string, error) {
+	if user == utils.EmptyString {
+		var err error
+		if user, err = authenticateAPIRequest(aa, method, args); err != nil {
+			return user, err
+		}
+	} else {
+		popAuthKey(args)
+	}
+	for _, tnt := range requestTenants(args, dfltTnt) {
+		if !aa.Authorize(user, method, tnt) {
+			utils.Logger.Warning(fmt.Sprintf("<APIAuth> unauthorized call of method <%s> on tenant <%s> by user <%s>",
+				method, tnt, user))
+			return user, utils.ErrUnauthorizedApi
+		}
+	}
+	return user, nil
+}
+
+// popAuthKey returns the *authKey API option, removing it from the arguments
+func popAuthKey(args interface{}) (key string) {
+	opts, has := argsAPIOpts(args)
+	if !has || opts.IsNil() {
+		return
+	}
+	optKey := reflect.ValueOf(utils.OptsAuthKey)
+	val := opts.MapIndex(optKey)
+	if !val.IsValid() {
+		return
+	}
+	opts.SetMapIndex(optKey, reflect.Value{})
+	return utils.IfaceAsString(val.Interface())
+}
+
+// requestTenants returns the tenants targeted by the arguments of a request
+// an empty Tenant means the default one while an empty list of Tenants, like the arguments without tenant, targets *any tenant
+func requestTenants(args interface{}, dfltTnt string) (tnts []string) {
+	v := reflect.ValueOf(args)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return []string{utils.MetaAny}
+	}
+	if v = v.Elem(); v.Kind() != reflect.Struct {
+		return []string{utils.MetaAny}
+	}
+	var hasTnt bool
+	if fld, has := v.Type().FieldByName(utils.Tenant); has && fld.Type.Kind() == reflect.String {
+		hasTnt = true
+		tnt := dfltTnt
+		if fldVal, err := v.FieldByIndexErr(fld.Index); err == nil && fldVal.String() != utils.EmptyString {
+			tnt = fldVal.String()
+		}
+		tnts = append(tnts, tnt)
+	}
+	if fld, has := v.Type().FieldByName("Tenants"); has && fld.Type == reflect.TypeOf([]string{}) { // ie: CDRs filters
+		fldVal, err := v.FieldByIndexErr(fld.Index)
+		if err != nil || fldVal.Len() == 0 {
+			return append(tnts, utils.MetaAny)
+		}
+		for i := 0; i < fldVal.Len(); i++ {
+			tnts = append(tnts, fldVal.Index(i).String())
+		}
+		return
+	}
+	if !hasTnt {
+		return []string{utils.MetaAny}
+	}
+	return
+}
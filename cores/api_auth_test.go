@@ -0,0 +1,291 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package cores
+
+import (
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"reflect"
+	"testing"
+
+	"github.com/cenkalti/rpc2"
+	jsonrpc2 "github.com/cenkalti/rpc2/jsonrpc"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+type apiAuthTestSv1 struct{}
+
+func (apiAuthTestSv1) Echo(args *utils.CGREvent, reply *string) error {
+	*reply = utils.IfaceAsString(args.APIOpts[utils.OptsAPIUser]) + utils.InInFieldSep + utils.IfaceAsString(args.APIOpts[utils.OptsAuthKey])
+	return nil
+}
+
+func newAPIAuthTestCfg(enabled bool) *config.CGRConfig {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.APIAuthCfg().Enabled = enabled
+	cfg.APIAuthCfg().Roles["echo"] = &config.APIRoleCfg{
+		Methods: []string{"APIAuthTestSv1.*"},
+		Tenants: []string{"cgrates.org"},
+	}
+	cfg.APIAuthCfg().Users["1001"] = &config.APIUserCfg{
+		Key:   "k1001",
+		Roles: []string{"echo"},
+	}
+	return cfg
+}
+
+func TestAPIAuthServerCodec(t *testing.T) {
+	defer config.SetCgrConfig(config.CgrConfig())
+	config.SetCgrConfig(newAPIAuthTestCfg(true))
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("APIAuthTestSv1", new(apiAuthTestSv1)); err != nil {
+		t.Fatal(err)
+	}
+	srvConn, clntConn := net.Pipe()
+	go srv.ServeCodec(newAPIAuthCodec(jsonrpc.NewServerCodec(srvConn), utils.EmptyString))
+	clnt := jsonrpc.NewClient(clntConn)
+	defer clnt.Close()
+
+	var reply string
+	args := &utils.CGREvent{
+		Tenant:  "cgrates.org",
+		APIOpts: map[string]interface{}{utils.OptsAuthKey: "k1001"},
+	}
+	if err := clnt.Call("APIAuthTestSv1.Echo", args, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "1001:" {
+		t.Errorf("Expected the API user without the key, received: %q", reply)
+	}
+	args.Tenant = "cgrates.net"
+	if err := clnt.Call("APIAuthTestSv1.Echo", args, &reply); err == nil || err.Error() != utils.ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+	args.Tenant = "cgrates.org"
+	args.APIOpts = map[string]interface{}{utils.OptsAuthKey: "unknown"}
+	if err := clnt.Call("APIAuthTestSv1.Echo", args, &reply); err == nil || err.Error() != utils.ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+	// the connection is still usable after the unauthorized requests
+	args.APIOpts = map[string]interface{}{utils.OptsAuthKey: "k1001"}
+	if err := clnt.Call("APIAuthTestSv1.Echo", args, &reply); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestAPIAuthServerCodecBasicAuthUser(t *testing.T) {
+	defer config.SetCgrConfig(config.CgrConfig())
+	config.SetCgrConfig(newAPIAuthTestCfg(true))
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("APIAuthTestSv1", new(apiAuthTestSv1)); err != nil {
+		t.Fatal(err)
+	}
+	srvConn, clntConn := net.Pipe()
+	go srv.ServeCodec(newAPIAuthCodec(jsonrpc.NewServerCodec(srvConn), "1001"))
+	clnt := jsonrpc.NewClient(clntConn)
+	defer clnt.Close()
+
+	var reply string
+	if err := clnt.Call("APIAuthTestSv1.Echo", &utils.CGREvent{Tenant: "cgrates.org"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "1001:" {
+		t.Errorf("Expected the basic auth user, received: %q", reply)
+	}
+	if err := clnt.Call("APIAuthTestSv1.Echo", &utils.CGREvent{Tenant: "cgrates.net"}, &reply); err == nil || err.Error() != utils.ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+}
+
+func TestAPIAuthServerCodecDisabled(t *testing.T) {
+	defer config.SetCgrConfig(config.CgrConfig())
+	config.SetCgrConfig(newAPIAuthTestCfg(false))
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("APIAuthTestSv1", new(apiAuthTestSv1)); err != nil {
+		t.Fatal(err)
+	}
+	srvConn, clntConn := net.Pipe()
+	go srv.ServeCodec(newAPIAuthCodec(jsonrpc.NewServerCodec(srvConn), utils.EmptyString))
+	clnt := jsonrpc.NewClient(clntConn)
+	defer clnt.Close()
+
+	var reply string
+	if err := clnt.Call("APIAuthTestSv1.Echo", &utils.CGREvent{
+		Tenant:  "cgrates.net",
+		APIOpts: map[string]interface{}{utils.OptsAuthKey: "unknown"},
+	}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != ":unknown" {
+		t.Errorf("Expected the request unchanged, received: %q", reply)
+	}
+}
+
+func TestAPIAuthBiRPCCodec(t *testing.T) {
+	defer config.SetCgrConfig(config.CgrConfig())
+	config.SetCgrConfig(newAPIAuthTestCfg(true))
+
+	srv := rpc2.NewServer()
+	srv.Handle("APIAuthTestSv1.Echo", func(_ *rpc2.Client, args *utils.CGREvent, reply *string) error {
+		return apiAuthTestSv1{}.Echo(args, reply)
+	})
+	srvConn, clntConn := net.Pipe()
+	go srv.ServeCodec(newAPIAuthBiRPCCodec(jsonrpc2.NewJSONCodec(srvConn)))
+	clnt := rpc2.NewClientWithCodec(jsonrpc2.NewJSONCodec(clntConn))
+	go clnt.Run()
+	defer clnt.Close()
+
+	var reply string
+	args := &utils.CGREvent{
+		Tenant:  "cgrates.org",
+		APIOpts: map[string]interface{}{utils.OptsAuthKey: "k1001"},
+	}
+	if err := clnt.Call("APIAuthTestSv1.Echo", args, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "1001:" {
+		t.Errorf("Expected the API user without the key, received: %q", reply)
+	}
+	args.Tenant = "cgrates.net"
+	if err := clnt.Call("APIAuthTestSv1.Echo", args, &reply); err == nil || err.Error() != utils.ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+}
+
+func TestAPIAuthServerCodecAuthenticate(t *testing.T) {
+	defer config.SetCgrConfig(config.CgrConfig())
+	config.SetCgrConfig(newAPIAuthTestCfg(true))
+
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("APIAuthTestSv1", new(apiAuthTestSv1)); err != nil {
+		t.Fatal(err)
+	}
+	if err := srv.RegisterName(utils.APIAuthSv1, APIAuthSv1{}); err != nil {
+		t.Fatal(err)
+	}
+	srvConn, clntConn := net.Pipe()
+	go srv.ServeCodec(newAPIAuthCodec(jsonrpc.NewServerCodec(srvConn), utils.EmptyString))
+	clnt := jsonrpc.NewClient(clntConn)
+	defer clnt.Close()
+
+	var reply string
+	if err := clnt.Call("APIAuthTestSv1.Echo", &utils.CGREvent{Tenant: "cgrates.org"}, &reply); err == nil || err.Error() != utils.ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+	if err := clnt.Call(utils.APIAuthSv1Authenticate, &utils.TenantWithAPIOpts{
+		APIOpts: map[string]interface{}{utils.OptsAuthKey: "unknown"},
+	}, &reply); err == nil || err.Error() != utils.ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+	if err := clnt.Call(utils.APIAuthSv1Authenticate, &utils.TenantWithAPIOpts{
+		APIOpts: map[string]interface{}{utils.OptsAuthKey: "k1001"},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %q, received: %q", utils.OK, reply)
+	}
+	// the following requests of the connection are done by the authenticated user
+	if err := clnt.Call("APIAuthTestSv1.Echo", &utils.CGREvent{Tenant: "cgrates.org"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "1001:" {
+		t.Errorf("Expected the authenticated user, received: %q", reply)
+	}
+	if err := clnt.Call("APIAuthTestSv1.Echo", &utils.CGREvent{Tenant: "cgrates.net"}, &reply); err == nil || err.Error() != utils.ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+	// a failed authentication drops the previous one
+	if err := clnt.Call(utils.APIAuthSv1Authenticate, &utils.TenantWithAPIOpts{}, &reply); err == nil || err.Error() != utils.ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+	if err := clnt.Call("APIAuthTestSv1.Echo", &utils.CGREvent{Tenant: "cgrates.org"}, &reply); err == nil || err.Error() != utils.ErrUnauthorizedApi.Error() {
+		t.Errorf("Expected %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+}
+
+func TestAPIAuthBiRPCCodecAuthenticate(t *testing.T) {
+	defer config.SetCgrConfig(config.CgrConfig())
+	config.SetCgrConfig(newAPIAuthTestCfg(true))
+
+	srv := rpc2.NewServer()
+	srv.Handle("APIAuthTestSv1.Echo", func(_ *rpc2.Client, args *utils.CGREvent, reply *string) error {
+		return apiAuthTestSv1{}.Echo(args, reply)
+	})
+	srv.Handle(utils.APIAuthSv1Authenticate, biRPCAuthenticate)
+	srvConn, clntConn := net.Pipe()
+	go srv.ServeCodec(newAPIAuthBiRPCCodec(jsonrpc2.NewJSONCodec(srvConn)))
+	clnt := rpc2.NewClientWithCodec(jsonrpc2.NewJSONCodec(clntConn))
+	go clnt.Run()
+	defer clnt.Close()
+
+	var reply string
+	if err := clnt.Call(utils.APIAuthSv1Authenticate, &utils.TenantWithAPIOpts{
+		APIOpts: map[string]interface{}{utils.OptsAuthKey: "k1001"},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := clnt.Call("APIAuthTestSv1.Echo", &utils.CGREvent{Tenant: "cgrates.org"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "1001:" {
+		t.Errorf("Expected the authenticated user, received: %q", reply)
+	}
+}
+
+func TestRequestTenants(t *testing.T) {
+	for _, tc := range []struct {
+		args interface{}
+		exp  []string
+	}{
+		{&utils.CGREvent{Tenant: "cgrates.net"}, []string{"cgrates.net"}},
+		{&utils.CGREvent{}, []string{"cgrates.org"}},
+		{&utils.TenantIDWithAPIOpts{}, []string{"cgrates.org"}}, // nil embedded TenantID
+		{&utils.RPCCDRsFilterWithAPIOpts{RPCCDRsFilter: &utils.RPCCDRsFilter{Tenants: []string{"cgrates.org", "cgrates.net"}}},
+			[]string{"cgrates.org", "cgrates.org", "cgrates.net"}},
+		{&utils.RPCCDRsFilterWithAPIOpts{RPCCDRsFilter: new(utils.RPCCDRsFilter)}, []string{"cgrates.org", utils.MetaAny}},
+		{&utils.RPCCDRsFilterWithAPIOpts{}, []string{"cgrates.org", utils.MetaAny}}, // nil embedded filter
+		{&utils.RPCCDRsFilter{Tenants: []string{"cgrates.net"}}, []string{"cgrates.net"}},
+		{utils.StringPointer("test"), []string{utils.MetaAny}},
+		{nil, []string{utils.MetaAny}},
+	} {
+		if rcv := requestTenants(tc.args, "cgrates.org"); !reflect.DeepEqual(tc.exp, rcv) {
+			t.Errorf("Expected %+v for %+v, received: %+v", tc.exp, tc.args, rcv)
+		}
+	}
+}
+
+func TestPopAuthKey(t *testing.T) {
+	args := &utils.CGREvent{
+		APIOpts: map[string]interface{}{
+			utils.OptsAuthKey: "k1001",
+			utils.OptsAPIKey:  "attr1001",
+		},
+	}
+	if key := popAuthKey(args); key != "k1001" {
+		t.Errorf("Expected k1001, received: %q", key)
+	}
+	if exp := map[string]interface{}{utils.OptsAPIKey: "attr1001"}; !reflect.DeepEqual(exp, args.APIOpts) {
+		t.Errorf("Expected %+v, received: %+v", exp, args.APIOpts)
+	}
+	if key := popAuthKey(args); key != utils.EmptyString {
+		t.Errorf("Expected no key, received: %q", key)
+	}
+	if key := popAuthKey(new(utils.CGREvent)); key != utils.EmptyString {
+		t.Errorf("Expected no key, received: %q", key)
+	}
+}
@@ -19,9 +19,11 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package cores
 
 import (
+	"context"
 	"encoding/base64"
 	"fmt"
 	"net/http"
+	"reflect"
 	"strings"
 
 	"github.com/cgrates/cgrates/utils"
@@ -80,11 +82,52 @@ func basicAuth(userList map[string]string) basicAuthMiddleware {
 				return
 			}
 
-			h.ServeHTTP(w, r)
+			h.ServeHTTP(w, r.WithContext(context.WithValue(r.Context(), apiUserCtxKey{}, userPass[0])))
 		}
 	}
 }
 
+// apiUserCtxKey is the request context key of the user authorized by basicAuth
+type apiUserCtxKey struct{}
+
+// apiUserFromContext returns the user authorized by basicAuth, empty if none
+func apiUserFromContext(ctx context.Context) (user string) {
+	user, _ = ctx.Value(apiUserCtxKey{}).(string)
+	return
+}
+
+// argsAPIOpts returns the APIOpts field of the arguments, if they have one
+func argsAPIOpts(args interface{}) (opts reflect.Value, has bool) {
+	v := reflect.ValueOf(args)
+	if v.Kind() != reflect.Ptr || v.IsNil() {
+		return
+	}
+	if v = v.Elem(); v.Kind() != reflect.Struct {
+		return
+	}
+	fld, has := v.Type().FieldByName(utils.APIOpts)
+	if !has || fld.Type != reflect.TypeOf(map[string]interface{}{}) {
+		return opts, false
+	}
+	var err error
+	if opts, err = v.FieldByIndexErr(fld.Index); err != nil || !opts.CanSet() { // nil embedded struct or unexported field
+		return opts, false
+	}
+	return opts, true
+}
+
+// setAPIUser populates the APIOpts of the arguments with the API user, overwriting the one sent by the client
+func setAPIUser(args interface{}, user string) {
+	opts, has := argsAPIOpts(args)
+	if !has {
+		return
+	}
+	if opts.IsNil() {
+		opts.Set(reflect.MakeMap(opts.Type()))
+	}
+	opts.SetMapIndex(reflect.ValueOf(utils.OptsAPIUser), reflect.ValueOf(user))
+}
+
 // verifyCredential validates the incoming username and password against the authorized user list
 func verifyCredential(username string, password string, userList map[string]string) bool {
 	hash, ok := userList[username]
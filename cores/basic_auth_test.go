@@ -21,7 +21,11 @@ import (
 	"encoding/base64"
 	"net/http"
 	"net/http/httptest"
+	"reflect"
 	"testing"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
 )
 
 func TestUse(t *testing.T) {
@@ -155,3 +159,51 @@ func TestVerifyCredential(t *testing.T) {
 		}
 	}
 }
+
+func TestBasicAuthAPIUser(t *testing.T) {
+	var user string
+	toTest := basicAuth(map[string]string{"1001": "MTIzNA=="})(func(_ http.ResponseWriter, r *http.Request) {
+		user = apiUserFromContext(r.Context())
+	})
+	req, err := http.NewRequest("GET", "/api/users", nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set("Authorization", "Basic "+base64.StdEncoding.EncodeToString([]byte("1001:1234")))
+	toTest.ServeHTTP(httptest.NewRecorder(), req)
+	if user != "1001" {
+		t.Errorf("Expected API user <1001>, received: <%s>", user)
+	}
+	if rcv := apiUserFromContext(req.Context()); rcv != utils.EmptyString {
+		t.Errorf("Expected no API user, received: <%s>", rcv)
+	}
+}
+
+func TestSetAPIUser(t *testing.T) {
+	args := &utils.TenantIDWithAPIOpts{
+		TenantID: &utils.TenantID{Tenant: "cgrates.org", ID: "ATTR_1"},
+	}
+	setAPIUser(args, "1001")
+	if exp := map[string]interface{}{utils.OptsAPIUser: "1001"}; !reflect.DeepEqual(exp, args.APIOpts) {
+		t.Errorf("Expected %+v, received: %+v", exp, args.APIOpts)
+	}
+	args.APIOpts = map[string]interface{}{utils.OptsAPIUser: "1002", utils.OptsAPIKey: "key"}
+	setAPIUser(args, "1001")
+	if exp := map[string]interface{}{utils.OptsAPIUser: "1001", utils.OptsAPIKey: "key"}; !reflect.DeepEqual(exp, args.APIOpts) {
+		t.Errorf("Expected %+v, received: %+v", exp, args.APIOpts)
+	}
+
+	rpfArgs := &utils.TPRatingProfileWithAPIOpts{TPRatingProfile: &utils.TPRatingProfile{TPid: "TP1"}}
+	setAPIUser(rpfArgs, "1001")
+	if exp := map[string]interface{}{utils.OptsAPIUser: "1001"}; !reflect.DeepEqual(exp, rpfArgs.APIOpts) {
+		t.Errorf("Expected %+v, received: %+v", exp, rpfArgs.APIOpts)
+	}
+
+	cgrEv := &engine.CGREventWithEeIDs{} // nil embedded CGREvent
+	setAPIUser(cgrEv, "1001")
+	if cgrEv.CGREvent != nil {
+		t.Errorf("Expected nil CGREvent, received: %+v", cgrEv.CGREvent)
+	}
+	setAPIUser(utils.StringPointer("test"), "1001")
+	setAPIUser(nil, "1001")
+}
@@ -22,10 +22,12 @@ import (
 	"net"
 	"net/rpc"
 	"net/rpc/jsonrpc"
+	"sync"
 
 	"github.com/cenkalti/rpc2"
 	jsonrpc2 "github.com/cenkalti/rpc2/jsonrpc"
 	"github.com/cgrates/cgrates/analyzers"
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
@@ -197,3 +199,137 @@ func (c *capsBiRPCCodec) WriteResponse(r *rpc2.Response, x interface{}) error {
 
 // Close is called when client/server finished with the connection.
 func (c *capsBiRPCCodec) Close() error { return c.sc.Close() }
+
+// newTenantCapsCodec returns a codec limiting the concurrent requests of each tenant based on the quotas section of the config
+func newTenantCapsCodec(sc rpc.ServerCodec, caps *engine.Caps) rpc.ServerCodec {
+	return &tenantCapsServerCodec{
+		ServerCodec: sc,
+		caps:        caps,
+		tnts:        make(map[uint64]string),
+	}
+}
+
+type tenantCapsServerCodec struct {
+	rpc.ServerCodec
+	caps *engine.Caps
+	seq  uint64 // the sequence of the request being read
+
+	tntsMux sync.Mutex
+	tnts    map[uint64]string // tenants allocated indexed on request sequence
+}
+
+func (c *tenantCapsServerCodec) ReadRequestHeader(r *rpc.Request) (err error) {
+	if err = c.ServerCodec.ReadRequestHeader(r); err == nil {
+		c.seq = r.Seq
+	}
+	return
+}
+
+// ReadRequestBody decodes the arguments and allocates the request to its tenant
+// on error the rpc server replies with it and continues reading the following requests
+func (c *tenantCapsServerCodec) ReadRequestBody(x interface{}) (err error) {
+	if err = c.ServerCodec.ReadRequestBody(x); err != nil || x == nil {
+		return
+	}
+	var tnt string
+	if tnt, err = allocateTenantCaps(c.caps, x); err != nil || tnt == utils.EmptyString {
+		return
+	}
+	c.tntsMux.Lock()
+	c.tnts[c.seq] = tnt
+	c.tntsMux.Unlock()
+	return
+}
+
+func (c *tenantCapsServerCodec) WriteResponse(r *rpc.Response, x interface{}) error {
+	c.tntsMux.Lock()
+	tnt, has := c.tnts[r.Seq]
+	delete(c.tnts, r.Seq)
+	c.tntsMux.Unlock()
+	if has { // free it before replying so the client can follow up within the quota
+		c.caps.DeallocateTenant(tnt)
+	}
+	return c.ServerCodec.WriteResponse(r, x)
+}
+
+// newTenantCapsBiRPCCodec returns a BiRPC codec limiting the concurrent requests of each tenant based on the quotas section of the config
+func newTenantCapsBiRPCCodec(sc rpc2.Codec, caps *engine.Caps) rpc2.Codec {
+	return &tenantCapsBiRPCCodec{
+		Codec: sc,
+		caps:  caps,
+		tnts:  make(map[uint64]string),
+	}
+}
+
+type tenantCapsBiRPCCodec struct {
+	rpc2.Codec
+	caps *engine.Caps
+	seq  uint64 // the sequence of the request being read
+
+	tntsMux sync.Mutex
+	tnts    map[uint64]string // tenants allocated indexed on request sequence
+}
+
+// ReadHeader must read a message and populate either the request
+// or the response by inspecting the incoming message.
+func (c *tenantCapsBiRPCCodec) ReadHeader(req *rpc2.Request, resp *rpc2.Response) (err error) {
+	if err = c.Codec.ReadHeader(req, resp); err == nil {
+		c.seq = req.Seq
+	}
+	return
+}
+
+// ReadRequestBody decodes the arguments and allocates the request to its tenant
+// rpc2 closes the connection on read errors so the error reply is sent from here
+func (c *tenantCapsBiRPCCodec) ReadRequestBody(x interface{}) (err error) {
+	if err = c.Codec.ReadRequestBody(x); err != nil || x == nil {
+		return
+	}
+	var tnt string
+	if tnt, err = allocateTenantCaps(c.caps, x); err != nil {
+		if c.seq != 0 { // not a notification
+			resp := &rpc2.Response{Seq: c.seq, Error: err.Error()}
+			c.Codec.WriteResponse(resp, resp)
+		}
+		return
+	}
+	if tnt == utils.EmptyString {
+		return
+	}
+	if c.seq == 0 { // notifications are not replied
+		c.caps.DeallocateTenant(tnt)
+		return
+	}
+	c.tntsMux.Lock()
+	c.tnts[c.seq] = tnt
+	c.tntsMux.Unlock()
+	return
+}
+
+// WriteResponse must be safe for concurrent use by multiple goroutines.
+func (c *tenantCapsBiRPCCodec) WriteResponse(r *rpc2.Response, x interface{}) error {
+	c.tntsMux.Lock()
+	tnt, has := c.tnts[r.Seq]
+	delete(c.tnts, r.Seq)
+	c.tntsMux.Unlock()
+	if has { // free it before replying so the client can follow up within the quota
+		c.caps.DeallocateTenant(tnt)
+	}
+	return c.Codec.WriteResponse(r, x)
+}
+
+// allocateTenantCaps allocates the request to the tenant targeted by its arguments
+// returning the tenant allocated, empty if the quotas are disabled or the request targets *any tenant
+func allocateTenantCaps(caps *engine.Caps, args interface{}) (tnt string, err error) {
+	cfg := config.CgrConfig()
+	if caps == nil || !cfg.QuotasCfg().Enabled {
+		return
+	}
+	if tnt = requestTenants(args, cfg.GeneralCfg().DefaultTenant)[0]; tnt == utils.MetaAny {
+		return utils.EmptyString, nil
+	}
+	if err = caps.AllocateTenant(tnt); err != nil {
+		return utils.EmptyString, err
+	}
+	return
+}
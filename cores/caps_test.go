@@ -28,6 +28,7 @@ import (
 	"github.com/cenkalti/rpc2"
 	jsonrpc2 "github.com/cenkalti/rpc2/jsonrpc"
 	"github.com/cgrates/cgrates/analyzers"
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/engine"
 	"github.com/cgrates/cgrates/utils"
 	"github.com/cgrates/rpcclient"
@@ -244,3 +245,68 @@ func TestNewCapsJSONBiRPCCodec(t *testing.T) {
 		t.Errorf("Expected: %v ,received:%v", exp, r)
 	}
 }
+
+type tenantCapsTestSv1 struct {
+	started chan struct{}
+	release chan struct{}
+}
+
+func (s *tenantCapsTestSv1) Wait(args *utils.CGREvent, reply *string) error {
+	s.started <- struct{}{}
+	<-s.release
+	*reply = utils.OK
+	return nil
+}
+
+func (s *tenantCapsTestSv1) Echo(args *utils.CGREvent, reply *string) error {
+	*reply = args.Tenant
+	return nil
+}
+
+func TestTenantCapsServerCodec(t *testing.T) {
+	defer config.SetCgrConfig(config.CgrConfig())
+	cfg := config.NewDefaultCGRConfig()
+	cfg.QuotasCfg().Enabled = true
+	cfg.QuotasCfg().Tenants["cgrates.org"] = &config.TenantQuotaCfg{APIRequests: 1}
+	config.SetCgrConfig(cfg)
+
+	srvc := &tenantCapsTestSv1{
+		started: make(chan struct{}),
+		release: make(chan struct{}),
+	}
+	srv := rpc.NewServer()
+	if err := srv.RegisterName("TenantCapsTestSv1", srvc); err != nil {
+		t.Fatal(err)
+	}
+	caps := engine.NewCaps(0, utils.MetaBusy)
+	srvConn, clntConn := net.Pipe()
+	go srv.ServeCodec(newTenantCapsCodec(jsonrpc.NewServerCodec(srvConn), caps))
+	clnt := jsonrpc.NewClient(clntConn)
+	defer clnt.Close()
+
+	waitCall := clnt.Go("TenantCapsTestSv1.Wait", &utils.CGREvent{Tenant: "cgrates.org"}, new(string), nil)
+	<-srvc.started
+	if n := caps.TenantAllocated("cgrates.org"); n != 1 {
+		t.Errorf("Expected 1 request allocated, received: %d", n)
+	}
+	var reply string
+	expErr := utils.NewErrTenantQuotaExceeded(utils.APIRequestsCfg, "cgrates.org").Error()
+	if err := clnt.Call("TenantCapsTestSv1.Echo", &utils.CGREvent{Tenant: "cgrates.org"}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %v, received: %v", expErr, err)
+	}
+	if err := clnt.Call("TenantCapsTestSv1.Echo", &utils.CGREvent{Tenant: "itsyscom.com"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != "itsyscom.com" {
+		t.Errorf("Expected itsyscom.com, received: %q", reply)
+	}
+	close(srvc.release)
+	if call := <-waitCall.Done; call.Error != nil {
+		t.Error(call.Error)
+	}
+	if err := clnt.Call("TenantCapsTestSv1.Echo", &utils.CGREvent{Tenant: "cgrates.org"}, &reply); err != nil {
+		t.Error(err)
+	}
+	if n := caps.TenantAllocated("cgrates.org"); n != 0 {
+		t.Errorf("Expected no request allocated, received: %d", n)
+	}
+}
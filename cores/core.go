@@ -41,6 +41,7 @@ func NewCoreService(cfg *config.CGRConfig, caps *engine.Caps, fileCPU io.Closer,
 		st = engine.NewCapsStats(cfg.CoreSCfg().CapsStatsInterval, caps, stopChan)
 	}
 	return &CoreService{
+		caps:       caps,
 		shdWg:      shdWg,
 		stopMemPrf: stopMemPrf,
 		shdChan:    shdChan,
@@ -53,6 +54,7 @@ func NewCoreService(cfg *config.CGRConfig, caps *engine.Caps, fileCPU io.Closer,
 
 type CoreService struct {
 	cfg        *config.CGRConfig
+	caps       *engine.Caps
 	CapsStats  *engine.CapsStats
 	shdWg      *sync.WaitGroup
 	stopMemPrf chan struct{}
@@ -141,10 +143,30 @@ func (cS *CoreService) Status(arg *utils.TenantWithAPIOpts, reply *map[string]in
 	}
 	response[utils.RunningSince] = utils.GetStartTime()
 	response[utils.GoVersion] = runtime.Version()
+	if cS.caps != nil && cS.caps.IsLimited() {
+		response[utils.CapsAllocated] = cS.caps.Allocated()
+	}
+	if dbSts := engine.GetDataDBBackendsStatus(); dbSts != nil {
+		response[utils.DataDBBackends] = dbSts
+	}
 	*reply = response
 	return
 }
 
+// GetTenantQuotas returns the usage of the quotas of the tenant
+func (cS *CoreService) GetTenantQuotas(arg *utils.TenantWithAPIOpts, reply *engine.TenantQuotaStatus) (err error) {
+	tnt := arg.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cS.cfg.GeneralCfg().DefaultTenant
+	}
+	var sts *engine.TenantQuotaStatus
+	if sts, err = engine.GetTenantQuotaStatus(tnt, cS.caps); err != nil {
+		return
+	}
+	*reply = *sts
+	return
+}
+
 // StartCPUProfiling is used to start CPUProfiling in the given path
 func (cS *CoreService) StartCPUProfiling(argPath string) (err error) {
 	cS.fileMx.Lock()
@@ -40,6 +40,7 @@ func TestNewCoreService(t *testing.T) {
 	shdChan := utils.NewSyncedChan()
 	stopMemPrf := make(chan struct{})
 	expected := &CoreService{
+		caps:       caps,
 		fileMEM:    "/tmp",
 		shdWg:      shdWg,
 		shdChan:    shdChan,
@@ -104,6 +105,9 @@ func TestCoreServiceStatus(t *testing.T) {
 	if !reflect.DeepEqual(expected[utils.NodeID], reply[utils.NodeID]) {
 		t.Errorf("Expected %+v, received %+v", utils.ToJSON(expected[utils.NodeID]), utils.ToJSON(reply[utils.NodeID]))
 	}
+	if reply[utils.CapsAllocated] != 0 {
+		t.Errorf("Expected %+v, received %+v", 0, utils.ToJSON(reply[utils.CapsAllocated]))
+	}
 	utils.GitLastLog = `Date: wrong format
 `
 	if err := cores.Status(args, &reply); err != nil {
@@ -112,3 +116,25 @@ func TestCoreServiceStatus(t *testing.T) {
 
 	utils.GitLastLog = ""
 }
+
+func TestCoreServiceGetTenantQuotas(t *testing.T) {
+	defer config.SetCgrConfig(config.CgrConfig())
+	cfg := config.NewDefaultCGRConfig()
+	cfg.QuotasCfg().Enabled = true
+	cfg.QuotasCfg().Tenants["cgrates.org"] = &config.TenantQuotaCfg{APIRequests: 2, CDRsRate: 10}
+	config.SetCgrConfig(cfg)
+	caps := engine.NewCaps(0, utils.MetaBusy)
+	if err := caps.AllocateTenant("cgrates.org"); err != nil {
+		t.Fatal(err)
+	}
+	cS := NewCoreService(cfg, caps, nil, "/tmp", nil, nil, nil, nil)
+	var reply engine.TenantQuotaStatus
+	if err := cS.GetTenantQuotas(&utils.TenantWithAPIOpts{}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if reply.Tenant != "cgrates.org" || !reply.Enabled ||
+		*reply.APIRequests != (engine.QuotaUsage{Limit: 2, Used: 1}) ||
+		reply.CDRsRate.Limit != 10 || reply.Sessions.Limit != 0 {
+		t.Errorf("Unexpected status: %s", utils.ToJSON(reply))
+	}
+}
@@ -43,6 +43,7 @@ import (
 )
 
 func NewServer(caps *engine.Caps) (s *Server) {
+	rpc.RegisterName(utils.APIAuthSv1, APIAuthSv1{}) // not enabling the rpc listeners
 	return &Server{
 		httpMux:         http.NewServeMux(),
 		httpsMux:        http.NewServeMux(),
@@ -115,6 +116,7 @@ func (s *Server) BiRPCRegisterName(method string, handlerFunc interface{}) {
 	if isNil {
 		s.Lock()
 		s.birpcSrv = rpc2.NewServer()
+		s.birpcSrv.Handle(utils.APIAuthSv1Authenticate, biRPCAuthenticate)
 		s.Unlock()
 	}
 	s.birpcSrv.Handle(method, handlerFunc)
@@ -159,7 +161,7 @@ func (s *Server) accept(l net.Listener, codecName string, newCodec func(conn con
 			}
 			continue
 		}
-		go rpc.ServeCodec(newCodec(conn, s.caps, s.anz))
+		go rpc.ServeCodec(newTenantCapsCodec(newAPIAuthCodec(newCodec(conn, s.caps, s.anz), utils.EmptyString), s.caps))
 	}
 }
 
@@ -176,7 +178,9 @@ func (s *Server) handleRequest(w http.ResponseWriter, r *http.Request) {
 	w.Header().Set("Content-Type", "application/json")
 	rmtIP, _ := utils.GetRemoteIP(r)
 	rmtAddr, _ := net.ResolveIPAddr(utils.EmptyString, rmtIP)
-	res := newRPCRequest(r.Body, rmtAddr, s.caps, s.anz).Call()
+	rpcReq := newRPCRequest(r.Body, rmtAddr, s.caps, s.anz)
+	rpcReq.apiUser = apiUserFromContext(r.Context())
+	res := rpcReq.Call()
 	io.Copy(w, res)
 }
 
@@ -297,7 +301,7 @@ func (s *Server) acceptBiRPC(srv *rpc2.Server, l net.Listener, codecName string,
 			utils.Logger.Crit(fmt.Sprintf("Stopped Bi%s server beacause %s", codecName, err))
 			return // stop if we get Accept error
 		}
-		go srv.ServeCodec(newCodec(conn, s.caps, s.anz))
+		go srv.ServeCodec(newTenantCapsBiRPCCodec(newAPIAuthBiRPCCodec(newCodec(conn, s.caps, s.anz)), s.caps))
 	}
 }
 
@@ -317,6 +321,7 @@ type rpcRequest struct {
 	remoteAddr net.Addr
 	caps       *engine.Caps
 	anzWarpper *analyzers.AnalyzerService
+	apiUser    string // the user authorized by basicAuth
 }
 
 // newRPCRequest returns a new rpcRequest.
@@ -351,7 +356,7 @@ func (r *rpcRequest) Close() error {
 
 // Call invokes the RPC request, waits for it to complete, and returns the results.
 func (r *rpcRequest) Call() io.Reader {
-	rpc.ServeCodec(newCapsJSONCodec(r, r.caps, r.anzWarpper))
+	rpc.ServeCodec(newTenantCapsCodec(newAPIAuthCodec(newCapsJSONCodec(r, r.caps, r.anzWarpper), r.apiUser), r.caps))
 	return r.rw
 }
 
@@ -429,7 +434,8 @@ func (s *Server) ServeJSONTLS(addr, serverCrt, serverKey, caCert string,
 }
 
 func (s *Server) handleWebSocket(ws *websocket.Conn) {
-	rpc.ServeCodec(newCapsJSONCodec(ws, s.caps, s.anz))
+	rpc.ServeCodec(newTenantCapsCodec(newAPIAuthCodec(newCapsJSONCodec(ws, s.caps, s.anz),
+		apiUserFromContext(ws.Request().Context())), s.caps))
 }
 
 func (s *Server) ServeHTTPTLS(addr, serverCrt, serverKey, caCert string, serverPolicy int,
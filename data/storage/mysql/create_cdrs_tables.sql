@@ -50,3 +50,26 @@ CREATE TABLE session_costs (
   KEY run_origin_idx (run_id, origin_id),
   KEY deleted_at_idx (deleted_at)
 );
+
+--
+-- Table structure for table `audit_log`
+--
+
+DROP TABLE IF EXISTS audit_log;
+CREATE TABLE audit_log (
+  id int(11) NOT NULL AUTO_INCREMENT,
+  record_id varchar(64) NOT NULL,
+  created_at TIMESTAMP(6) NOT NULL,
+  audit_user varchar(128) NOT NULL,
+  source varchar(128) NOT NULL,
+  action varchar(16) NOT NULL,
+  item_type varchar(64) NOT NULL,
+  tenant varchar(64) NOT NULL,
+  item_id varchar(256) NOT NULL,
+  item_before MEDIUMTEXT,
+  item_after MEDIUMTEXT,
+  PRIMARY KEY (`id`),
+  UNIQUE KEY record_id (record_id),
+  KEY created_at_idx (created_at),
+  KEY item_idx (item_type, tenant, item_id)
+);
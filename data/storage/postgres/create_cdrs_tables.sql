@@ -57,3 +57,27 @@ DROP INDEX IF EXISTS run_origin_sessionscost_idx;
 CREATE INDEX run_origin_sessionscost_idx ON session_costs (run_id, origin_id);
 DROP INDEX IF EXISTS deleted_at_sessionscost_idx;
 CREATE INDEX deleted_at_sessionscost_idx ON session_costs (deleted_at);
+
+--
+-- Table structure for table `audit_log`
+--
+
+DROP TABLE IF EXISTS audit_log;
+CREATE TABLE audit_log (
+  id SERIAL PRIMARY KEY,
+  record_id VARCHAR(64) NOT NULL,
+  created_at TIMESTAMP WITH TIME ZONE NOT NULL,
+  audit_user VARCHAR(128) NOT NULL,
+  source VARCHAR(128) NOT NULL,
+  action VARCHAR(16) NOT NULL,
+  item_type VARCHAR(64) NOT NULL,
+  tenant VARCHAR(64) NOT NULL,
+  item_id VARCHAR(256) NOT NULL,
+  item_before TEXT,
+  item_after TEXT,
+  UNIQUE (record_id)
+);
+DROP INDEX IF EXISTS created_at_auditlog_idx;
+CREATE INDEX created_at_auditlog_idx ON audit_log (created_at);
+DROP INDEX IF EXISTS item_auditlog_idx;
+CREATE INDEX item_auditlog_idx ON audit_log (item_type, tenant, item_id);
@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"reflect"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -45,18 +46,101 @@ func NewDispatcherService(dm *engine.DataManager,
 // DispatcherService  is the service handling dispatching towards internal components
 // designed to handle automatic partitioning and failover
 type DispatcherService struct {
-	dm      *engine.DataManager
-	cfg     *config.CGRConfig
-	fltrS   *engine.FilterS
-	connMgr *engine.ConnManager
+	dm          *engine.DataManager
+	cfg         *config.CGRConfig
+	fltrS       *engine.FilterS
+	connMgr     *engine.ConnManager
+	loopStopped chan struct{}
+	stopHealth  chan struct{}
+}
+
+// StartLoop starts the gorutine with the health checks of the DispatcherHosts
+func (dS *DispatcherService) StartLoop() {
+	dS.stopHealth = make(chan struct{})
+	dS.loopStopped = make(chan struct{})
+	go dS.runHealthChecks()
+}
+
+// stopLoop stops the health checks loop and waits until it is done
+func (dS *DispatcherService) stopLoop() {
+	if dS.stopHealth == nil {
+		return
+	}
+	close(dS.stopHealth)
+	<-dS.loopStopped
+	dS.stopHealth = nil
+}
+
+// Reload stops the health checks loop and starts it again with the new config
+func (dS *DispatcherService) Reload() {
+	dS.stopLoop()
+	dS.StartLoop()
 }
 
 // Shutdown is called to shutdown the service
 func (dS *DispatcherService) Shutdown() {
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown initialized", utils.DispatcherS))
+	dS.stopLoop()
 	utils.Logger.Info(fmt.Sprintf("<%s> service shutdown complete", utils.DispatcherS))
 }
 
+// runHealthChecks will periodically check the health of the DispatcherHosts
+func (dS *DispatcherService) runHealthChecks() {
+	defer close(dS.loopStopped)
+	chkIntvl := dS.cfg.DispatcherSCfg().HealthCheckInterval
+	if chkIntvl <= 0 {
+		dspHostsHealth.reset() // without checks no host stays ejected
+		return
+	}
+	for {
+		dS.checkHostsHealth()
+		select {
+		case <-dS.stopHealth:
+			return
+		case <-time.After(chkIntvl):
+		}
+	}
+}
+
+// checkHostsHealth queries the status of all the DispatcherHosts in parallel
+// and updates their health and load
+func (dS *DispatcherService) checkHostsHealth() {
+	keys, err := dS.dm.DataDB().GetKeysForPrefix(utils.DispatcherHostPrefix)
+	if err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> failed retrieving the hosts for health checks, error: %s",
+			utils.DispatcherS, err.Error()))
+		return
+	}
+	healthyThr := dS.cfg.DispatcherSCfg().HealthyThreshold
+	unhealthyThr := dS.cfg.DispatcherSCfg().UnhealthyThreshold
+	tntIDs := make(utils.StringSet)
+	var wg sync.WaitGroup
+	for _, key := range keys {
+		tntID := utils.NewTenantID(key[len(utils.DispatcherHostPrefix):])
+		var dH *engine.DispatcherHost
+		if dH, err = dS.dm.GetDispatcherHost(tntID.Tenant, tntID.ID,
+			true, true, utils.NonTransactional); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed retrieving host %q for health checks, error: %s",
+				utils.DispatcherS, tntID.TenantID(), err.Error()))
+			continue
+		}
+		tntIDs.Add(dH.TenantID())
+		wg.Add(1)
+		go func(dH *engine.DispatcherHost) {
+			var status map[string]interface{}
+			var load int64
+			err := dH.Call(utils.CoreSv1Status, &utils.TenantWithAPIOpts{Tenant: dH.Tenant}, &status)
+			if err == nil {
+				load, err = hostLoad(status)
+			}
+			dspHostsHealth.update(dH.Tenant, dH.ID, load, err, healthyThr, unhealthyThr)
+			wg.Done()
+		}(dH)
+	}
+	wg.Wait()
+	dspHostsHealth.prune(tntIDs)
+}
+
 func (dS *DispatcherService) authorizeEvent(ev *utils.CGREvent,
 	reply *engine.AttrSProcessEventReply) (err error) {
 	ev.APIOpts[utils.OptsContext] = utils.MetaAuth
@@ -407,3 +491,57 @@ func (dS *DispatcherService) DispatcherSv1RemotePing(args *utils.CGREvent, reply
 	}
 	return dS.Dispatch(args, utils.MetaCore, utils.CoreSv1Ping, args, reply)
 }
+
+// DispatcherSv1GetHostsHealth returns the health of the DispatcherHosts for the tenant
+func (dS *DispatcherService) DispatcherSv1GetHostsHealth(args *utils.TenantWithAPIOpts,
+	reply *[]*HostHealth) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	prfx := utils.DispatcherHostPrefix + tnt + utils.ConcatenatedKeySep
+	var keys []string
+	if keys, err = dS.dm.DataDB().GetKeysForPrefix(prfx); err != nil {
+		return
+	}
+	if len(keys) == 0 {
+		return utils.ErrNotFound
+	}
+	ids := make([]string, len(keys))
+	for i, key := range keys {
+		ids[i] = key[len(prfx):]
+	}
+	*reply = dspHostsHealth.health(tnt, ids)
+	return
+}
+
+// DispatcherSv1DrainHost takes the DispatcherHost out of dispatching until resumed
+func (dS *DispatcherService) DispatcherSv1DrainHost(args *utils.TenantIDWithAPIOpts,
+	reply *string) (err error) {
+	return dS.setHostDrained(args, true, reply)
+}
+
+// DispatcherSv1ResumeHost puts back in dispatching a drained DispatcherHost
+func (dS *DispatcherService) DispatcherSv1ResumeHost(args *utils.TenantIDWithAPIOpts,
+	reply *string) (err error) {
+	return dS.setHostDrained(args, false, reply)
+}
+
+func (dS *DispatcherService) setHostDrained(args *utils.TenantIDWithAPIOpts,
+	drained bool, reply *string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 {
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if _, err = dS.dm.GetDispatcherHost(tnt, args.ID, true, true, utils.NonTransactional); err != nil {
+		return
+	}
+	dspHostsHealth.setDrained(tnt, args.ID, drained)
+	utils.Logger.Info(fmt.Sprintf("<%s> host %q drained: %t",
+		utils.DispatcherS, utils.ConcatenatedKey(tnt, args.ID), drained))
+	*reply = utils.OK
+	return
+}
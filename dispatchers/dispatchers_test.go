@@ -1753,3 +1753,25 @@ func TestDispatchersdispatcherProfileForEventAnySStrueBothFound(t *testing.T) {
 		t.Errorf("\nexpected: <%+v>, \nreceived: <%+v>", dsp1, rcv)
 	}
 }
+
+func TestDispatcherServiceHealthLoopStops(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DispatcherSCfg().HealthCheckInterval = time.Hour
+	dataDB := engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(dataDB, nil, nil)
+	dss := NewDispatcherService(dm, cfg, nil, nil)
+	done := make(chan struct{})
+	go func() {
+		dss.Shutdown() // without the loop started
+		dss.StartLoop()
+		dss.Reload()
+		dss.Shutdown()
+		dss.Shutdown()
+		close(done)
+	}()
+	select {
+	case <-done:
+	case <-time.After(time.Second):
+		t.Fatal("health checks loop did not stop")
+	}
+}
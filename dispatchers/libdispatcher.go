@@ -21,8 +21,11 @@ package dispatchers
 import (
 	"encoding/gob"
 	"fmt"
+	"hash/crc32"
 	"math/rand"
 	"sort"
+	"strconv"
+	"strings"
 	"sync"
 
 	"github.com/cgrates/cgrates/config"
@@ -56,6 +59,14 @@ func newDispatcher(pfl *engine.DispatcherProfile) (d Dispatcher, err error) {
 		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(randomSort))
 	case utils.MetaRoundRobin:
 		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(roundRobinSort))
+	case utils.MetaLeastLoad:
+		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), new(leastLoadSort))
+	case utils.MetaConsistentHash:
+		var hs *hashRingSort
+		if hs, err = newHashRingSort(hosts, pfl.StrategyParams); err != nil {
+			return
+		}
+		return newSingleDispatcher(hosts, pfl.StrategyParams, pfl.TenantID(), hs)
 	case rpcclient.PoolBroadcast,
 		rpcclient.PoolBroadcastSync,
 		rpcclient.PoolBroadcastAsync:
@@ -73,6 +84,9 @@ func getDispatcherHosts(fltrs *engine.FilterS, ev utils.DataProvider, tnt string
 	hostIDs = make(engine.DispatcherHostIDs, 0, len(hosts))
 	for _, host := range hosts {
 		var pass bool
+		if !dspHostsHealth.available(utils.ConcatenatedKey(tnt, host.ID)) {
+			continue // ejected by health checks or drained
+		}
 		if pass, err = fltrs.Pass(tnt, host.FilterIDs, ev); err != nil {
 			return
 		}
@@ -124,6 +138,99 @@ func (rs *roundRobinSort) Sort(fltrs *engine.FilterS, ev utils.DataProvider, tnt
 	return getDispatcherHosts(fltrs, ev, tnt, dh)
 }
 
+type leastLoadSort struct{}
+
+// Sort orders the hosts on the load reported by them on health checks
+// keeping the weight order for the hosts with the same load
+func (leastLoadSort) Sort(fltrs *engine.FilterS, ev utils.DataProvider, tnt string, hosts engine.DispatcherHostProfiles) (hostIDs engine.DispatcherHostIDs, err error) {
+	hc := &hostCosts{
+		hosts: make(engine.DispatcherHostProfiles, len(hosts)),
+		load:  dspHostsHealth.loads(tnt, hosts),
+	}
+	copy(hc.hosts, hosts)
+	sort.Stable(hc)
+	return getDispatcherHosts(fltrs, ev, tnt, hc.hosts)
+}
+
+// hashRingVirtualNodes is the number of points each host has on the hash ring
+const hashRingVirtualNodes = 100
+
+func newHashRingSort(hosts engine.DispatcherHostProfiles, params map[string]interface{}) (hs *hashRingSort, err error) {
+	keyRules := strategyParam(params, utils.MetaHashKey)
+	if keyRules == utils.EmptyString {
+		return nil, utils.NewErrMandatoryIeMissing(utils.MetaHashKey)
+	}
+	hs = &hashRingSort{
+		ring:  make([]uint32, 0, len(hosts)*hashRingVirtualNodes),
+		nodes: make(map[uint32]string),
+	}
+	if hs.key, err = config.NewRSRParsers(keyRules, config.CgrConfig().GeneralCfg().RSRSep); err != nil {
+		return
+	}
+	for _, host := range hosts {
+		for i := 0; i < hashRingVirtualNodes; i++ {
+			point := crc32.ChecksumIEEE([]byte(host.ID + utils.HashtagSep + strconv.Itoa(i)))
+			if hostID, has := hs.nodes[point]; has {
+				if hostID < host.ID { // on collision keep the same host independent of the order
+					continue
+				}
+			} else {
+				hs.ring = append(hs.ring, point)
+			}
+			hs.nodes[point] = host.ID
+		}
+	}
+	sort.Slice(hs.ring, func(i, j int) bool { return hs.ring[i] < hs.ring[j] })
+	return
+}
+
+// hashRingSort orders the hosts based on the position of the event key on a hash ring
+// built only out of the host IDs so the same key always reaches the same host
+// and only the keys of the added/removed hosts are remapped
+type hashRingSort struct {
+	key   config.RSRParsers
+	ring  []uint32          // sorted points of the hosts on the ring
+	nodes map[uint32]string // the host ID for each point
+}
+
+func (hs *hashRingSort) Sort(fltrs *engine.FilterS, ev utils.DataProvider, tnt string, hosts engine.DispatcherHostProfiles) (hostIDs engine.DispatcherHostIDs, err error) {
+	var key string
+	if key, err = hs.key.ParseDataProvider(ev); err != nil {
+		return
+	}
+	hostsIdx := make(map[string]*engine.DispatcherHostProfile, len(hosts))
+	for _, host := range hosts {
+		hostsIdx[host.ID] = host
+	}
+	dh := make(engine.DispatcherHostProfiles, 0, len(hosts))
+	// walk the ring clockwise starting from the key so the failover is always the same
+	point := crc32.ChecksumIEEE([]byte(key))
+	start := sort.Search(len(hs.ring), func(i int) bool { return hs.ring[i] >= point })
+	for i := 0; i < len(hs.ring) && len(dh) != len(hostsIdx); i++ {
+		hostID := hs.nodes[hs.ring[(start+i)%len(hs.ring)]]
+		if host, has := hostsIdx[hostID]; has {
+			dh = append(dh, host)
+			delete(hostsIdx, hostID)
+		}
+	}
+	return getDispatcherHosts(fltrs, ev, tnt, dh)
+}
+
+// strategyParam returns the value of the strategy parameter out of
+// the API format (key: value) or the .csv one ("key:value")
+func strategyParam(params map[string]interface{}, key string) string {
+	if val, has := params[key]; has {
+		return utils.IfaceAsString(val)
+	}
+	prfx := key + utils.InInFieldSep
+	for _, val := range params {
+		if strVal := utils.IfaceAsString(val); strings.HasPrefix(strVal, prfx) {
+			return strVal[len(prfx):]
+		}
+	}
+	return utils.EmptyString
+}
+
 func newSingleDispatcher(hosts engine.DispatcherHostProfiles, params map[string]interface{}, tntID string, sorter hostSorter) (_ Dispatcher, err error) {
 	if dflt, has := params[utils.MetaDefaultRatio]; has {
 		var ratio int64
@@ -167,7 +274,8 @@ func (sd *singleResultDispatcher) Dispatch(dm *engine.DataManager, flts *engine.
 		routeID = utils.ConcatenatedKey(routeID, subsystem)
 		// use previously discovered route
 		if x, ok := engine.Cache.Get(utils.CacheDispatcherRoutes,
-			routeID); ok && x != nil {
+			routeID); ok && x != nil &&
+			dspHostsHealth.available(x.(*engine.DispatcherHost).TenantID()) {
 			dH = x.(*engine.DispatcherHost)
 			if err = dH.Call(serviceMethod, args, reply); !rpcclient.IsNetworkError(err) {
 				return
@@ -269,7 +377,8 @@ func (ld *loadDispatcher) Dispatch(dm *engine.DataManager, flts *engine.FilterS,
 		routeID = utils.ConcatenatedKey(routeID, subsystem)
 		// use previously discovered route
 		if x, ok := engine.Cache.Get(utils.CacheDispatcherRoutes,
-			routeID); ok && x != nil {
+			routeID); ok && x != nil &&
+			dspHostsHealth.available(x.(*engine.DispatcherHost).TenantID()) {
 			dH = x.(*engine.DispatcherHost)
 			lM.incrementLoad(dH.ID, ld.tntID)
 			err = dH.Call(serviceMethod, args, reply)
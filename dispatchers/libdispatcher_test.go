@@ -21,6 +21,7 @@ package dispatchers
 import (
 	"net/rpc"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/cgrates/cgrates/config"
@@ -1136,3 +1137,179 @@ func TestLibDispatcherRoundRobinSort(t *testing.T) {
 		t.Errorf("Expected: %q, received: %q", expHostIDs2, hostIDs)
 	}
 }
+
+func TestLibDispatcherNewDispatcherMetaLeastLoad(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Hosts:    engine.DispatcherHostProfiles{},
+		Strategy: utils.MetaLeastLoad,
+	}
+	result, err := newDispatcher(pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if sorter := result.(*singleResultDispatcher).sorter; !reflect.DeepEqual(sorter, new(leastLoadSort)) {
+		t.Errorf("\nExpected <%+v>, \nReceived <%+v>", new(leastLoadSort), sorter)
+	}
+}
+
+func TestLibDispatcherNewDispatcherMetaConsistentHash(t *testing.T) {
+	pfl := &engine.DispatcherProfile{
+		Hosts:    engine.DispatcherHostProfiles{{ID: "testID1"}, {ID: "testID2"}},
+		Strategy: utils.MetaConsistentHash,
+	}
+	if _, err := newDispatcher(pfl); err == nil || err.Error() != utils.NewErrMandatoryIeMissing(utils.MetaHashKey).Error() {
+		t.Errorf("Expected error: %v, received: %v", utils.NewErrMandatoryIeMissing(utils.MetaHashKey), err)
+	}
+	pfl.StrategyParams = map[string]interface{}{utils.MetaHashKey: "~*req.Account{*"}
+	if _, err := newDispatcher(pfl); err == nil {
+		t.Error("Expected error for invalid hash key")
+	}
+	pfl.StrategyParams = map[string]interface{}{"0": "*hash_key:~*req.Account"} // as loaded from .csv
+	result, err := newDispatcher(pfl)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hs, canCast := result.(*singleResultDispatcher).sorter.(*hashRingSort)
+	if !canCast {
+		t.Fatalf("Unexpected sorter: %T", result.(*singleResultDispatcher).sorter)
+	}
+	if len(hs.ring) != 2*hashRingVirtualNodes {
+		t.Errorf("Expected %d points on ring, received: %d", 2*hashRingVirtualNodes, len(hs.ring))
+	}
+	if rule := hs.key.GetRule(utils.InfieldSep); rule != "~*req.Account" {
+		t.Errorf("Expected hash key: %q, received: %q", "~*req.Account", rule)
+	}
+}
+
+func TestLibDispatcherLeastLoadSort(t *testing.T) {
+	defer func(hh *hostsHealth) { dspHostsHealth = hh }(dspHostsHealth)
+	dspHostsHealth = newHostsHealth()
+	cfg := config.NewDefaultCGRConfig()
+	flts := engine.NewFilterS(cfg, nil, nil)
+	sorter := new(leastLoadSort)
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "testID1"},
+		{ID: "testID2"},
+		{ID: "testID3"},
+	}
+	dspHostsHealth.update("cgrates.org", "testID1", 10, nil, 1, 1)
+	dspHostsHealth.update("cgrates.org", "testID2", 2, nil, 1, 1)
+	// testID3 was not checked yet so it has no load
+	expHostIDs := engine.DispatcherHostIDs{"testID3", "testID2", "testID1"}
+	if hostIDs, err := sorter.Sort(flts, nil, "cgrates.org", hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expHostIDs, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", expHostIDs, hostIDs)
+	}
+	dspHostsHealth.update("cgrates.org", "testID3", 2, nil, 1, 1)
+	expHostIDs = engine.DispatcherHostIDs{"testID2", "testID3", "testID1"}
+	if hostIDs, err := sorter.Sort(flts, nil, "cgrates.org", hosts); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(expHostIDs, hostIDs) {
+		t.Errorf("Expected: %q, received: %q", expHostIDs, hostIDs)
+	}
+	if hosts[0].ID != "testID1" {
+		t.Errorf("Expected the profile hosts to not be reordered, received: %s", utils.ToJSON(hosts))
+	}
+}
+
+func TestLibDispatcherHashRingSort(t *testing.T) {
+	defer func(hh *hostsHealth) { dspHostsHealth = hh }(dspHostsHealth)
+	dspHostsHealth = newHostsHealth()
+	cfg := config.NewDefaultCGRConfig()
+	flts := engine.NewFilterS(cfg, nil, nil)
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "testID1"},
+		{ID: "testID2"},
+		{ID: "testID3"},
+	}
+	params := map[string]interface{}{utils.MetaHashKey: "~*req.Account"}
+	sorter, err := newHashRingSort(hosts, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = sorter.Sort(flts, utils.MapStorage{utils.MetaReq: utils.MapStorage{}}, "cgrates.org", hosts); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v, received: %v", utils.ErrNotFound, err)
+	}
+	const nrAccounts = 1000
+	firstHost := func(s *hashRingSort, hosts engine.DispatcherHostProfiles, acnt string) string {
+		hostIDs, err := s.Sort(flts, utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.AccountField: acnt}}, "cgrates.org", hosts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return hostIDs[0]
+	}
+	acntHosts := make(map[string]string)
+	hostLoad := make(map[string]int)
+	for i := 0; i < nrAccounts; i++ {
+		acnt := "100" + strconv.Itoa(i)
+		ev := utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.AccountField: acnt}}
+		hostIDs, err := sorter.Sort(flts, ev, "cgrates.org", hosts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if len(hostIDs) != len(hosts) {
+			t.Fatalf("Expected all the hosts, received: %q", hostIDs)
+		}
+		acntHosts[acnt] = hostIDs[0]
+		hostLoad[hostIDs[0]]++
+	}
+	for _, host := range hosts {
+		if hostLoad[host.ID] < nrAccounts/10 {
+			t.Errorf("Unbalanced ring: %+v", hostLoad)
+		}
+	}
+
+	// the profile order does not matter, ie: after dispatcher restart with hosts sorted differently
+	reordered := engine.DispatcherHostProfiles{hosts[2], hosts[0], hosts[1]}
+	sorter2, err := newHashRingSort(reordered, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	for acnt, hostID := range acntHosts {
+		if rcv := firstHost(sorter2, reordered, acnt); rcv != hostID {
+			t.Fatalf("Expected account %s on %s, received: %s", acnt, hostID, rcv)
+		}
+	}
+
+	// adding a host moves only the accounts going to the new one
+	added := append(hosts.Clone(), &engine.DispatcherHostProfile{ID: "testID4"})
+	sorter3, err := newHashRingSort(added, params)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var moved int
+	for acnt, hostID := range acntHosts {
+		if rcv := firstHost(sorter3, added, acnt); rcv != hostID {
+			if rcv != "testID4" {
+				t.Fatalf("Expected account %s to move on testID4, received: %s", acnt, rcv)
+			}
+			moved++
+		}
+	}
+	if moved == 0 || moved > nrAccounts/2 {
+		t.Errorf("Unexpected number of remapped accounts: %d", moved)
+	}
+
+	// failover goes to the next host on the ring
+	for acnt, hostID := range acntHosts {
+		dspHostsHealth.setDrained("cgrates.org", hostID, true)
+		if rcv := firstHost(sorter, hosts, acnt); rcv == hostID {
+			t.Errorf("Expected drained host %s to be skipped", hostID)
+		}
+		dspHostsHealth.setDrained("cgrates.org", hostID, false)
+		break
+	}
+}
+
+func TestLibDispatcherStrategyParam(t *testing.T) {
+	if rcv := strategyParam(map[string]interface{}{utils.MetaHashKey: "~*req.Account"}, utils.MetaHashKey); rcv != "~*req.Account" {
+		t.Errorf("Expected: %q, received: %q", "~*req.Account", rcv)
+	}
+	if rcv := strategyParam(map[string]interface{}{"0": "*default_ratio:2", "1": "*hash_key:~*req.Account"}, utils.MetaHashKey); rcv != "~*req.Account" {
+		t.Errorf("Expected: %q, received: %q", "~*req.Account", rcv)
+	}
+	if rcv := strategyParam(map[string]interface{}{"0": "*default_ratio:2"}, utils.MetaHashKey); rcv != utils.EmptyString {
+		t.Errorf("Expected empty param, received: %q", rcv)
+	}
+}
@@ -0,0 +1,194 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatchers
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// dspHostsHealth keeps the health of the DispatcherHosts, shared by all the dispatch strategies
+var dspHostsHealth = newHostsHealth()
+
+// HostHealth is the health status of one DispatcherHost
+type HostHealth struct {
+	Tenant    string
+	ID        string
+	Healthy   bool
+	Drained   bool  // manually taken out of dispatching
+	Failures  int   // consecutive failed checks
+	Successes int   // consecutive successful checks
+	Load      int64 // load reported by the host on the last successful check
+	LastCheck time.Time
+	LastError string `json:",omitempty"`
+}
+
+// TenantID returns the tenant concatenated with the ID
+func (hH *HostHealth) TenantID() string {
+	return utils.ConcatenatedKey(hH.Tenant, hH.ID)
+}
+
+// Available returns true if the host can receive dispatched requests
+func (hH *HostHealth) Available() bool {
+	return hH.Healthy && !hH.Drained
+}
+
+// Clone returns a copy of the HostHealth
+func (hH *HostHealth) Clone() *HostHealth {
+	cln := *hH
+	return &cln
+}
+
+func newHostsHealth() *hostsHealth {
+	return &hostsHealth{hosts: make(map[string]*HostHealth)}
+}
+
+// hostsHealth is the registry with the health of the DispatcherHosts
+type hostsHealth struct {
+	sync.RWMutex
+	hosts map[string]*HostHealth // indexed on tenantID
+}
+
+// getOrSet returns the health of the host, creating a healthy one if missing
+// needs to be called under lock
+func (hh *hostsHealth) getOrSet(tnt, id string) (hH *HostHealth) {
+	tntID := utils.ConcatenatedKey(tnt, id)
+	var has bool
+	if hH, has = hh.hosts[tntID]; !has {
+		hH = &HostHealth{Tenant: tnt, ID: id, Healthy: true}
+		hh.hosts[tntID] = hH
+	}
+	return
+}
+
+// available returns false only for the hosts known to be unhealthy or drained
+func (hh *hostsHealth) available(tntID string) bool {
+	hh.RLock()
+	defer hh.RUnlock()
+	hH, has := hh.hosts[tntID]
+	return !has || hH.Available()
+}
+
+// update records the result of one health check, changing the health
+// of the host once the thresholds are reached
+func (hh *hostsHealth) update(tnt, id string, load int64, err error, healthyThr, unhealthyThr int) {
+	hh.Lock()
+	defer hh.Unlock()
+	hH := hh.getOrSet(tnt, id)
+	hH.LastCheck = time.Now()
+	if err != nil {
+		hH.Successes = 0
+		hH.Failures++
+		hH.LastError = err.Error()
+		if hH.Healthy && hH.Failures >= unhealthyThr {
+			hH.Healthy = false
+			utils.Logger.Warning(fmt.Sprintf("<%s> ejecting host %q after %d failed health checks, last error: %s",
+				utils.DispatcherS, hH.TenantID(), hH.Failures, hH.LastError))
+		}
+		return
+	}
+	hH.Failures = 0
+	hH.Successes++
+	hH.Load = load
+	hH.LastError = utils.EmptyString
+	if !hH.Healthy && hH.Successes >= healthyThr {
+		hH.Healthy = true
+		utils.Logger.Info(fmt.Sprintf("<%s> host %q recovered after %d successful health checks",
+			utils.DispatcherS, hH.TenantID(), hH.Successes))
+	}
+}
+
+// loads returns the last reported load of the hosts, 0 for the ones not yet checked
+func (hh *hostsHealth) loads(tnt string, hosts engine.DispatcherHostProfiles) (lds []int64) {
+	lds = make([]int64, len(hosts))
+	hh.RLock()
+	for i, host := range hosts {
+		if hH, has := hh.hosts[utils.ConcatenatedKey(tnt, host.ID)]; has {
+			lds[i] = hH.Load
+		}
+	}
+	hh.RUnlock()
+	return
+}
+
+// hostLoad returns the load out of the CoreSv1.Status reply: the
+// allocated caps if limited, the number of active goroutines otherwise
+func hostLoad(status map[string]interface{}) (load int64, err error) {
+	ld, has := status[utils.CapsAllocated]
+	if !has {
+		if ld, has = status[utils.ActiveGoroutines]; !has {
+			return
+		}
+	}
+	return utils.IfaceAsTInt64(ld)
+}
+
+// setDrained marks the host as drained or not
+func (hh *hostsHealth) setDrained(tnt, id string, drained bool) {
+	hh.Lock()
+	hh.getOrSet(tnt, id).Drained = drained
+	hh.Unlock()
+}
+
+// reset marks all the hosts as healthy, keeping only the drained ones
+// used when the health checks are disabled
+func (hh *hostsHealth) reset() {
+	hh.Lock()
+	for tntID, hH := range hh.hosts {
+		if !hH.Drained {
+			delete(hh.hosts, tntID)
+			continue
+		}
+		hh.hosts[tntID] = &HostHealth{Tenant: hH.Tenant, ID: hH.ID, Healthy: true, Drained: true}
+	}
+	hh.Unlock()
+}
+
+// prune removes the hosts which are not in the given tenantIDs
+func (hh *hostsHealth) prune(tntIDs utils.StringSet) {
+	hh.Lock()
+	for tntID := range hh.hosts {
+		if !tntIDs.Has(tntID) {
+			delete(hh.hosts, tntID)
+		}
+	}
+	hh.Unlock()
+}
+
+// health returns a copy of the health for the given host IDs of the tenant sorted by ID
+// the hosts not yet checked are considered healthy
+func (hh *hostsHealth) health(tnt string, ids []string) (hHs []*HostHealth) {
+	hHs = make([]*HostHealth, len(ids))
+	hh.RLock()
+	for i, id := range ids {
+		if hH, has := hh.hosts[utils.ConcatenatedKey(tnt, id)]; has {
+			hHs[i] = hH.Clone()
+			continue
+		}
+		hHs[i] = &HostHealth{Tenant: tnt, ID: id, Healthy: true}
+	}
+	hh.RUnlock()
+	sort.Slice(hHs, func(i, j int) bool { return hHs[i].ID < hHs[j].ID })
+	return
+}
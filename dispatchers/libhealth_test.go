@@ -0,0 +1,280 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package dispatchers
+
+import (
+	"errors"
+	"net"
+	"net/rpc"
+	"net/rpc/jsonrpc"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestHostsHealthUpdate(t *testing.T) {
+	hh := newHostsHealth()
+	if !hh.available("cgrates.org:DSP_1") {
+		t.Error("Expected unknown host to be available")
+	}
+	errPing := errors.New("connection refused")
+	for i := 0; i < 2; i++ {
+		hh.update("cgrates.org", "DSP_1", 0, errPing, 2, 3)
+	}
+	if !hh.available("cgrates.org:DSP_1") {
+		t.Error("Expected host to be available before reaching the threshold")
+	}
+	hh.update("cgrates.org", "DSP_1", 0, errPing, 2, 3)
+	if hh.available("cgrates.org:DSP_1") {
+		t.Error("Expected host to be ejected")
+	}
+	hh.update("cgrates.org", "DSP_1", 0, nil, 2, 3)
+	if hh.available("cgrates.org:DSP_1") {
+		t.Error("Expected host to stay ejected before reaching the threshold")
+	}
+	hh.update("cgrates.org", "DSP_1", 0, errPing, 2, 3)
+	hh.update("cgrates.org", "DSP_1", 0, nil, 2, 3)
+	if hh.available("cgrates.org:DSP_1") {
+		t.Error("Expected the successful checks to be consecutive")
+	}
+	hh.update("cgrates.org", "DSP_1", 0, nil, 2, 3)
+	if !hh.available("cgrates.org:DSP_1") {
+		t.Error("Expected host to recover")
+	}
+	hH := hh.hosts["cgrates.org:DSP_1"]
+	if hH.Failures != 0 || hH.Successes != 2 ||
+		hH.LastError != utils.EmptyString || hH.LastCheck.IsZero() {
+		t.Errorf("Unexpected health: %s", utils.ToJSON(hH))
+	}
+}
+
+func TestHostsHealthDrain(t *testing.T) {
+	hh := newHostsHealth()
+	hh.setDrained("cgrates.org", "DSP_1", true)
+	hh.update("cgrates.org", "DSP_2", 0, errors.New("timeout"), 1, 1)
+	if hh.available("cgrates.org:DSP_1") {
+		t.Error("Expected drained host to not be available")
+	}
+	hh.update("cgrates.org", "DSP_1", 0, nil, 1, 1)
+	if hh.available("cgrates.org:DSP_1") {
+		t.Error("Expected healthy drained host to not be available")
+	}
+	exp := []*HostHealth{
+		{Tenant: "cgrates.org", ID: "DSP_1", Healthy: true, Drained: true, Successes: 1},
+		{Tenant: "cgrates.org", ID: "DSP_2", Healthy: false, Failures: 1, LastError: "timeout"},
+		{Tenant: "cgrates.org", ID: "DSP_3", Healthy: true},
+	}
+	rply := hh.health("cgrates.org", []string{"DSP_3", "DSP_2", "DSP_1"})
+	for _, hH := range rply {
+		hH.LastCheck = time.Time{}
+	}
+	if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+	}
+
+	hh.reset()
+	if len(hh.hosts) != 1 || !hh.available("cgrates.org:DSP_2") {
+		t.Errorf("Expected only the drained host to remain, received: %s", utils.ToJSON(hh.hosts))
+	}
+	hh.setDrained("cgrates.org", "DSP_1", false)
+	if !hh.available("cgrates.org:DSP_1") {
+		t.Error("Expected resumed host to be available")
+	}
+	hh.prune(utils.NewStringSet(nil))
+	if len(hh.hosts) != 0 {
+		t.Errorf("Expected no hosts, received: %s", utils.ToJSON(hh.hosts))
+	}
+}
+
+func TestGetDispatcherHostsEjected(t *testing.T) {
+	defer func(hh *hostsHealth) { dspHostsHealth = hh }(dspHostsHealth)
+	dspHostsHealth = newHostsHealth()
+	cfg := config.NewDefaultCGRConfig()
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	fltrs := engine.NewFilterS(cfg, nil, dm)
+	hosts := engine.DispatcherHostProfiles{
+		{ID: "DSP_1"},
+		{ID: "DSP_2"},
+		{ID: "DSP_3"},
+	}
+	dspHostsHealth.setDrained("cgrates.org", "DSP_1", true)
+	dspHostsHealth.update("cgrates.org", "DSP_3", 0, errors.New("timeout"), 1, 1)
+	exp := engine.DispatcherHostIDs{"DSP_2"}
+	if rply, err := getDispatcherHosts(fltrs, utils.MapStorage{}, "cgrates.org", hosts); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected: %q ,received: %q", exp, rply)
+	}
+	dspHostsHealth.setDrained("cgrates.org", "DSP_2", true)
+	sd := &singleResultDispatcher{sorter: new(noSort), hosts: hosts}
+	if err := sd.Dispatch(dm, fltrs, utils.MapStorage{}, "cgrates.org", utils.EmptyString,
+		utils.MetaCore, utils.CoreSv1Ping, &utils.CGREvent{}, new(string)); err != utils.ErrHostNotFound {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrHostNotFound, err)
+	}
+}
+
+type testHealthCoreSv1 struct {
+	sync.RWMutex
+	err error
+}
+
+func (c *testHealthCoreSv1) Status(_ *utils.TenantWithAPIOpts, reply *map[string]interface{}) error {
+	c.RLock()
+	defer c.RUnlock()
+	if c.err != nil {
+		return c.err
+	}
+	*reply = map[string]interface{}{
+		utils.NodeID:        "DSP_1",
+		utils.CapsAllocated: 7,
+	}
+	return nil
+}
+
+func TestDispatcherServiceHealthChecks(t *testing.T) {
+	defer func(hh *hostsHealth) { dspHostsHealth = hh }(dspHostsHealth)
+	dspHostsHealth = newHostsHealth()
+	coreS := new(testHealthCoreSv1)
+	srv := rpc.NewServer()
+	if err := srv.RegisterName(utils.CoreSv1, coreS); err != nil {
+		t.Fatal(err)
+	}
+	l, err := net.Listen(utils.TCP, "127.0.0.1:0")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer l.Close()
+	go func() {
+		for {
+			conn, err := l.Accept()
+			if err != nil {
+				return
+			}
+			go srv.ServeCodec(jsonrpc.NewServerCodec(conn))
+		}
+	}()
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.DispatcherSCfg().HealthyThreshold = 1
+	cfg.DispatcherSCfg().UnhealthyThreshold = 2
+	dm := engine.NewDataManager(engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	dH := &engine.DispatcherHost{
+		Tenant: "cgrates.org",
+		RemoteHost: &config.RemoteHost{
+			ID:        "DSP_1",
+			Address:   l.Addr().String(),
+			Transport: rpcclient.JSONrpc,
+		},
+	}
+	if err := dm.SetDispatcherHost(dH); err != nil {
+		t.Fatal(err)
+	}
+	dS := NewDispatcherService(dm, cfg, nil, nil)
+
+	dS.checkHostsHealth()
+	if !dspHostsHealth.available(dH.TenantID()) {
+		t.Errorf("Expected host to be available, received: %s", utils.ToJSON(dspHostsHealth.hosts))
+	} else if ld := dspHostsHealth.hosts[dH.TenantID()].Load; ld != 7 {
+		t.Errorf("Expected load: 7, received: %d", ld)
+	}
+	coreS.Lock()
+	coreS.err = errors.New("SERVER_ERROR")
+	coreS.Unlock()
+	dS.checkHostsHealth()
+	dS.checkHostsHealth()
+	if dspHostsHealth.available(dH.TenantID()) {
+		t.Errorf("Expected host to be ejected, received: %s", utils.ToJSON(dspHostsHealth.hosts))
+	}
+	var rply []*HostHealth
+	if err := dS.DispatcherSv1GetHostsHealth(&utils.TenantWithAPIOpts{}, &rply); err != nil {
+		t.Fatal(err)
+	} else if len(rply) != 1 || rply[0].Healthy || rply[0].LastError != "SERVER_ERROR" {
+		t.Errorf("Unexpected health: %s", utils.ToJSON(rply))
+	}
+	coreS.Lock()
+	coreS.err = nil
+	coreS.Unlock()
+	dS.checkHostsHealth()
+	if !dspHostsHealth.available(dH.TenantID()) {
+		t.Errorf("Expected host to recover, received: %s", utils.ToJSON(dspHostsHealth.hosts))
+	}
+
+	var reply string
+	if err := dS.DispatcherSv1DrainHost(&utils.TenantIDWithAPIOpts{
+		TenantID: &utils.TenantID{ID: "DSP_1"},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected: %q ,received: %q", utils.OK, reply)
+	}
+	if dspHostsHealth.available(dH.TenantID()) {
+		t.Error("Expected host to be drained")
+	}
+	if err := dS.DispatcherSv1ResumeHost(&utils.TenantIDWithAPIOpts{
+		TenantID: &utils.TenantID{ID: "DSP_1"},
+	}, &reply); err != nil {
+		t.Fatal(err)
+	}
+	if !dspHostsHealth.available(dH.TenantID()) {
+		t.Error("Expected host to be resumed")
+	}
+	if err := dS.DispatcherSv1DrainHost(&utils.TenantIDWithAPIOpts{
+		TenantID: &utils.TenantID{ID: "DSP_2"},
+	}, &reply); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrNotFound, err)
+	}
+
+	if err := dm.RemoveDispatcherHost("cgrates.org", "DSP_1"); err != nil {
+		t.Fatal(err)
+	}
+	dS.checkHostsHealth()
+	if len(dspHostsHealth.hosts) != 0 {
+		t.Errorf("Expected the removed host to be pruned, received: %s", utils.ToJSON(dspHostsHealth.hosts))
+	}
+}
+
+func TestHostLoad(t *testing.T) {
+	if ld, err := hostLoad(map[string]interface{}{
+		utils.CapsAllocated:    float64(3), // as decoded from JSON
+		utils.ActiveGoroutines: 120,
+	}); err != nil {
+		t.Error(err)
+	} else if ld != 3 {
+		t.Errorf("Expected: 3, received: %d", ld)
+	}
+	if ld, err := hostLoad(map[string]interface{}{utils.ActiveGoroutines: 120}); err != nil {
+		t.Error(err)
+	} else if ld != 120 {
+		t.Errorf("Expected: 120, received: %d", ld)
+	}
+	if ld, err := hostLoad(map[string]interface{}{}); err != nil {
+		t.Error(err)
+	} else if ld != 0 {
+		t.Errorf("Expected: 0, received: %d", ld)
+	}
+	if _, err := hostLoad(map[string]interface{}{utils.ActiveGoroutines: "many"}); err == nil {
+		t.Error("Expected error for invalid load")
+	}
+}
@@ -99,3 +99,20 @@ func (dS *DispatcherService) ThresholdSv1GetThreshold(args *utils.TenantIDWithAP
 		APIOpts: args.APIOpts,
 	}, utils.MetaThresholds, utils.ThresholdSv1GetThreshold, args, th)
 }
+
+func (dS *DispatcherService) ThresholdSv1GetRaisedAlarms(args *utils.TenantWithAPIOpts, alarms *[]*engine.ThresholdAlarm) (err error) {
+	tnt := dS.cfg.GeneralCfg().DefaultTenant
+	if args.Tenant != utils.EmptyString {
+		tnt = args.Tenant
+	}
+	if len(dS.cfg.DispatcherSCfg().AttributeSConns) != 0 {
+		if err = dS.authorize(utils.ThresholdSv1GetRaisedAlarms,
+			tnt, utils.IfaceAsString(args.APIOpts[utils.OptsAPIKey]), utils.TimePointer(time.Now())); err != nil {
+			return
+		}
+	}
+	return dS.Dispatch(&utils.CGREvent{
+		Tenant:  tnt,
+		APIOpts: args.APIOpts,
+	}, utils.MetaThresholds, utils.ThresholdSv1GetRaisedAlarms, args, alarms)
+}
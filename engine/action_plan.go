@@ -257,7 +257,7 @@ func (at *ActionTiming) Execute(fltrS *FilterS) (err error) {
 				}
 			}
 			if !transactionFailed && !removeAccountActionFound {
-				dm.SetAccount(acc)
+				dm.setAccount(acc)
 			}
 			return nil
 		}, config.CgrConfig().GeneralCfg().LockingTimeout, utils.AccountPrefix+accID)
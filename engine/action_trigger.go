@@ -107,7 +107,7 @@ func (at *ActionTrigger) Execute(ub *Account, fltrS *FilterS) (err error) {
 		at.Executed = false
 	}
 	if !transactionFailed && ub != nil && !removeAccountActionFound {
-		dm.SetAccount(ub)
+		dm.setAccount(ub)
 	}
 	return
 }
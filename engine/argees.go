@@ -78,3 +78,19 @@ func (cgr *CGREventWithEeIDs) UnmarshalJSON(data []byte) (err error) {
 	}
 	return
 }
+
+// RPCClone implements rpcclient.RPCCloner interface, keeping the EeIDs
+// which would be lost by the one promoted from CGREvent
+func (cgr *CGREventWithEeIDs) RPCClone() (interface{}, error) {
+	if cgr.CGREvent == nil {
+		return cgr, nil
+	}
+	ev, err := cgr.CGREvent.RPCClone()
+	if err != nil {
+		return nil, err
+	}
+	return &CGREventWithEeIDs{
+		EeIDs:    cgr.EeIDs,
+		CGREvent: ev.(*utils.CGREvent),
+	}, nil
+}
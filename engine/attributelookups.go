@@ -0,0 +1,286 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"context"
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/ltcache"
+	"gorm.io/driver/mysql"
+	"gorm.io/driver/postgres"
+	"gorm.io/gorm"
+)
+
+// newAttrLookups returns the attrLookups out of AttributeS config
+func newAttrLookups(cfg *config.AttributeSCfg) (al *attrLookups) {
+	al = &attrLookups{
+		httpClient: new(http.Client),
+		sqlDBs:     make(map[string]*sql.DB),
+		cacheTTL:   cfg.LookupsCacheTTL,
+	}
+	if cfg.LookupsCacheTTL > 0 {
+		al.cache = ltcache.NewCache(-1, cfg.LookupsCacheTTL, false, nil)
+	}
+	return
+}
+
+// reloaded returns new attrLookups out of AttributeS config, closing the opened databases
+// the cache is cleared and reused if its TTL did not change since its cleanup routine cannot be stopped
+func (al *attrLookups) reloaded(cfg *config.AttributeSCfg) (nAl *attrLookups) {
+	if al == nil { // AttributeService not created via constructor
+		return newAttrLookups(cfg)
+	}
+	al.close()
+	if al.cache == nil || al.cacheTTL != cfg.LookupsCacheTTL {
+		if al.cache != nil {
+			al.cache.Clear()
+		}
+		return newAttrLookups(cfg)
+	}
+	al.cache.Clear()
+	return &attrLookups{
+		cache:      al.cache,
+		cacheTTL:   al.cacheTTL,
+		httpClient: al.httpClient,
+		sqlDBs:     make(map[string]*sql.DB),
+	}
+}
+
+// attrLookups queries external sources for the *http_get, *http_post and *sql attributes
+type attrLookups struct {
+	cache      *ltcache.Cache // nil if caching is disabled
+	cacheTTL   time.Duration
+	httpClient *http.Client
+	sqlDBs     map[string]*sql.DB // opened on first query, indexed on connection ID
+	sqlMux     sync.Mutex         // protects sqlDBs
+}
+
+// isLookupAttribute returns true if the attribute type needs an external lookup
+func isLookupAttribute(attrType string) bool {
+	return attrType == utils.MetaHTTPGet ||
+		attrType == utils.MetaHTTPPost ||
+		attrType == utils.MetaSQL
+}
+
+// parse returns the attribute value queried out of the external source
+func (al *attrLookups) parse(cfg *config.AttributeSCfg, dp utils.DataProvider,
+	attrType string, value config.RSRParsers) (out interface{}, err error) {
+	var args []string
+	switch attrType {
+	case utils.MetaHTTPGet: // the URL is composed out of all values
+		if len(value) < 1 {
+			return nil, fmt.Errorf("invalid number of arguments <%s> to %s",
+				utils.ToJSON(value), utils.MetaHTTPGet)
+		}
+		args = make([]string, 1)
+		if args[0], err = composeLookupValue(value, dp); err != nil {
+			return
+		}
+	case utils.MetaHTTPPost: // first value is the URL, the body is composed out of the rest
+		if len(value) < 2 {
+			return nil, fmt.Errorf("invalid number of arguments <%s> to %s",
+				utils.ToJSON(value), utils.MetaHTTPPost)
+		}
+		args = make([]string, 2)
+		if args[0], err = value[0].ParseDataProvider(dp); err != nil {
+			return
+		}
+		if args[1], err = composeLookupValue(value[1:], dp); err != nil {
+			return
+		}
+	case utils.MetaSQL: // connection ID, the query and its parameters
+		if len(value) < 2 {
+			return nil, fmt.Errorf("invalid number of arguments <%s> to %s",
+				utils.ToJSON(value), utils.MetaSQL)
+		}
+		args = make([]string, len(value))
+		for i, val := range value {
+			if args[i], err = val.ParseDataProvider(dp); err != nil {
+				return
+			}
+		}
+	default:
+		return nil, fmt.Errorf("unsupported type: <%s>", attrType)
+	}
+	cacheKey := attrType + utils.InInFieldSep + strings.Join(args, utils.InfieldSep)
+	if al.cache != nil {
+		if x, has := al.cache.Get(cacheKey); has {
+			if x == nil {
+				return nil, utils.ErrNotFound
+			}
+			return x, nil
+		}
+	}
+	ctx := context.Background()
+	if cfg.LookupsTimeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, cfg.LookupsTimeout)
+		defer cancel()
+	}
+	var rply string
+	switch attrType {
+	case utils.MetaHTTPGet:
+		rply, err = al.httpQuery(ctx, http.MethodGet, args[0], utils.EmptyString)
+	case utils.MetaHTTPPost:
+		rply, err = al.httpQuery(ctx, http.MethodPost, args[0], args[1])
+	case utils.MetaSQL:
+		rply, err = al.sqlQuery(ctx, cfg, args[0], args[1], args[2:])
+	}
+	if err != nil {
+		if err == utils.ErrNotFound && al.cache != nil { // cache the missing results also
+			al.cache.Set(cacheKey, nil, nil)
+		}
+		return
+	}
+	if al.cache != nil {
+		al.cache.Set(cacheKey, rply, nil)
+	}
+	return rply, nil
+}
+
+// composeLookupValue concatenates the values, escaping the ones coming from the event
+func composeLookupValue(value config.RSRParsers, dp utils.DataProvider) (out string, err error) {
+	for _, rsr := range value {
+		var outPrsr string
+		if outPrsr, err = rsr.ParseDataProvider(dp); err != nil {
+			return
+		}
+		if strings.HasPrefix(rsr.Rules, utils.DynamicDataPrefix) {
+			outPrsr = url.QueryEscape(outPrsr)
+		}
+		out += outPrsr
+	}
+	return
+}
+
+// httpQuery returns the body of the reply, utils.ErrNotFound on 404 status code
+func (al *attrLookups) httpQuery(ctx context.Context, method, addr, body string) (rply string, err error) {
+	var req *http.Request
+	if req, err = http.NewRequestWithContext(ctx, method, addr, strings.NewReader(body)); err != nil {
+		return
+	}
+	if method == http.MethodPost {
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+	}
+	var resp *http.Response
+	if resp, err = al.httpClient.Do(req); err != nil {
+		return
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode == http.StatusNotFound {
+		return utils.EmptyString, utils.ErrNotFound
+	}
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		return utils.EmptyString, fmt.Errorf("unexpected status code <%d> from <%s>", resp.StatusCode, addr)
+	}
+	var rplyBody []byte
+	if rplyBody, err = io.ReadAll(resp.Body); err != nil {
+		return
+	}
+	return strings.TrimSpace(string(rplyBody)), nil
+}
+
+// sqlQuery returns the first column of the first row, utils.ErrNotFound if the query returns no rows
+func (al *attrLookups) sqlQuery(ctx context.Context, cfg *config.AttributeSCfg,
+	connID, query string, params []string) (rply string, err error) {
+	var db *sql.DB
+	if db, err = al.sqlDB(cfg, connID); err != nil {
+		return
+	}
+	qryArgs := make([]interface{}, len(params))
+	for i, param := range params {
+		qryArgs[i] = param
+	}
+	var val sql.NullString
+	if err = db.QueryRowContext(ctx, query, qryArgs...).Scan(&val); err != nil {
+		if err == sql.ErrNoRows {
+			err = utils.ErrNotFound
+		}
+		return
+	}
+	return val.String, nil
+}
+
+// sqlDB returns the database for the connection ID, opening it if needed
+func (al *attrLookups) sqlDB(cfg *config.AttributeSCfg, connID string) (db *sql.DB, err error) {
+	al.sqlMux.Lock()
+	defer al.sqlMux.Unlock()
+	if al.sqlDBs == nil {
+		return nil, fmt.Errorf("SQL connection <%s> closed on reload", connID)
+	}
+	if db, has := al.sqlDBs[connID]; has {
+		return db, nil
+	}
+	dsn, has := cfg.SQLConns[connID]
+	if !has {
+		return nil, fmt.Errorf("unknown SQL connection <%s>", connID)
+	}
+	var u *url.URL
+	if u, err = url.Parse(dsn); err != nil {
+		return
+	}
+	password, _ := u.User.Password()
+	dbName := strings.TrimPrefix(u.Path, utils.Slash)
+	var dialect gorm.Dialector
+	switch u.Scheme {
+	case utils.MySQL:
+		dialect = mysql.Open(fmt.Sprintf("%s:%s@tcp(%s:%s)/%s?charset=utf8&loc=Local&parseTime=true",
+			u.User.Username(), password, u.Hostname(), u.Port(), dbName))
+	case utils.Postgres:
+		dialect = postgres.Open(fmt.Sprintf("host=%s port=%s dbname=%s user=%s password=%s sslmode=%s",
+			u.Hostname(), u.Port(), dbName, u.User.Username(), password, utils.SQLDefaultSSLMode))
+	default:
+		return nil, fmt.Errorf("db type <%s> not supported", u.Scheme)
+	}
+	var gormDB *gorm.DB
+	if gormDB, err = gorm.Open(dialect, &gorm.Config{}); err != nil {
+		return
+	}
+	if db, err = gormDB.DB(); err != nil {
+		return
+	}
+	al.sqlDBs[connID] = db
+	return
+}
+
+// close closes the opened databases, the following queries failing
+func (al *attrLookups) close() {
+	if al == nil { // AttributeService not created via constructor
+		return
+	}
+	al.sqlMux.Lock()
+	for connID, db := range al.sqlDBs {
+		if err := db.Close(); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed closing SQL connection <%s>: %s",
+				utils.AttributeS, connID, err))
+		}
+	}
+	al.sqlDBs = nil
+	al.sqlMux.Unlock()
+}
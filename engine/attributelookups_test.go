@@ -0,0 +1,216 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"database/sql"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAttributesProcessEventLookups(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		switch r.URL.Path {
+		case "/tier":
+			fmt.Fprintf(w, "gold_%s\n", r.URL.Query().Get("account"))
+		case "/hlr":
+			body, _ := io.ReadAll(r.Body)
+			if r.Method != http.MethodPost ||
+				r.Header.Get("Content-Type") != "application/x-www-form-urlencoded" {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			fmt.Fprintf(w, "ported_%s", body)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+
+	cfg := config.NewDefaultCGRConfig()
+	dataDB := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+	alS := NewAttributeService(dm, NewFilterS(cfg, nil, dm), cfg)
+	defer alS.Shutdown()
+
+	tierVal, err := config.NewRSRParsers(srv.URL+"/tier?account=;~*req.Account", utils.InfieldSep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	hlrVal, err := config.NewRSRParsers(srv.URL+"/hlr;number=;~*req.Destination", utils.InfieldSep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = dm.SetAttributeProfile(&AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ATTR_LOOKUPS",
+		FilterIDs: []string{"*string:~*req.Account:1001 2"},
+		Contexts:  []string{utils.MetaAny},
+		Attributes: []*Attribute{
+			{
+				Path:  utils.MetaReq + utils.NestingSep + "Tier",
+				Type:  utils.MetaHTTPGet,
+				Value: tierVal,
+			},
+			{
+				Path:  utils.MetaReq + utils.NestingSep + "Operator",
+				Type:  utils.MetaHTTPPost,
+				Value: hlrVal,
+			},
+		},
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "LookupEv",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001 2",
+			utils.Destination:  "+4986517174963",
+		},
+		APIOpts: map[string]interface{}{},
+	}
+	exp := AttrSProcessEventReply{
+		MatchedProfiles: []string{"cgrates.org:ATTR_LOOKUPS"},
+		AlteredFields:   []string{utils.MetaReq + utils.NestingSep + "Operator", utils.MetaReq + utils.NestingSep + "Tier"},
+		CGREvent: &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "LookupEv",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001 2",
+				utils.Destination:  "+4986517174963",
+				"Tier":             "gold_1001 2",
+				"Operator":         "ported_number=%2B4986517174963",
+			},
+			APIOpts: map[string]interface{}{},
+		},
+	}
+	for i := 0; i < 2; i++ { // second time the results are cached
+		var rply AttrSProcessEventReply
+		if err = alS.V1ProcessEvent(ev.Clone(), &rply); err != nil {
+			t.Fatal(err)
+		}
+		sort.Strings(rply.AlteredFields)
+		if !reflect.DeepEqual(exp, rply) {
+			t.Errorf("expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply))
+		}
+	}
+	if hits != 2 {
+		t.Errorf("expected 2 HTTP queries, received: %d", hits)
+	}
+
+	// the reload drops the cached results and closes the SQL connections
+	db, err := sql.Open(utils.MySQL, "cgrates:CGRateS.org@tcp(127.0.0.1:3306)/crm")
+	if err != nil {
+		t.Fatal(err)
+	}
+	alS.lookups.sqlDBs["crm"] = db
+	lkpsCache := alS.lookups.cache
+	alS.Reload()
+	if err = db.Ping(); err == nil || err.Error() != "sql: database is closed" {
+		t.Errorf("expected the SQL connection to be closed, received: %v", err)
+	}
+	if alS.lookups.cache != lkpsCache || len(alS.lookups.sqlDBs) != 0 {
+		t.Errorf("expected the cache to be reused and no SQL connection, received: %+v", alS.lookups)
+	}
+	var rply AttrSProcessEventReply
+	if err = alS.V1ProcessEvent(ev.Clone(), &rply); err != nil {
+		t.Fatal(err)
+	}
+	if hits != 4 {
+		t.Errorf("expected 4 HTTP queries, received: %d", hits)
+	}
+	cfg.AttributeSCfg().LookupsCacheTTL = 0
+	alS.Reload()
+	if alS.lookups.cache != nil {
+		t.Error("expected the lookups cache to be disabled")
+	}
+}
+
+func TestAttrLookupsParse(t *testing.T) {
+	var hits int
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		hits++
+		switch r.URL.Path {
+		case "/slow":
+			time.Sleep(50 * time.Millisecond)
+		case "/error":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+		}
+	}))
+	defer srv.Close()
+	cfg := config.NewDefaultCGRConfig()
+	cfg.AttributeSCfg().LookupsTimeout = 10 * time.Millisecond
+	al := newAttrLookups(cfg.AttributeSCfg())
+	dp := utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.AccountField: "1001"}}
+
+	// the missing results are cached also
+	for i := 0; i < 2; i++ {
+		if _, err := al.parse(cfg.AttributeSCfg(), dp, utils.MetaHTTPGet,
+			config.NewRSRParsersMustCompile(srv.URL+"/missing", utils.InfieldSep)); err != utils.ErrNotFound {
+			t.Errorf("expected: <%+v>, received: <%+v>", utils.ErrNotFound, err)
+		}
+	}
+	if hits != 1 {
+		t.Errorf("expected 1 HTTP query, received: %d", hits)
+	}
+	expErr := fmt.Sprintf("unexpected status code <500> from <%s/error>", srv.URL)
+	if _, err := al.parse(cfg.AttributeSCfg(), dp, utils.MetaHTTPGet,
+		config.NewRSRParsersMustCompile(srv.URL+"/error", utils.InfieldSep)); err == nil || err.Error() != expErr {
+		t.Errorf("expected: <%+v>, received: <%+v>", expErr, err)
+	}
+	if _, err := al.parse(cfg.AttributeSCfg(), dp, utils.MetaHTTPGet,
+		config.NewRSRParsersMustCompile(srv.URL+"/slow", utils.InfieldSep)); err == nil {
+		t.Error("expected timeout error")
+	}
+	expErr = "invalid number of arguments <null> to *http_get"
+	if _, err := al.parse(cfg.AttributeSCfg(), dp, utils.MetaHTTPGet, nil); err == nil || err.Error() != expErr {
+		t.Errorf("expected: <%+v>, received: <%+v>", expErr, err)
+	}
+	expErr = `invalid number of arguments <[{"Rules":"crm"}]> to *sql`
+	if _, err := al.parse(cfg.AttributeSCfg(), dp, utils.MetaSQL,
+		config.NewRSRParsersMustCompile("crm", utils.InfieldSep)); err == nil || err.Error() != expErr {
+		t.Errorf("expected: <%+v>, received: <%+v>", expErr, err)
+	}
+	expErr = "unknown SQL connection <crm>"
+	if _, err := al.parse(cfg.AttributeSCfg(), dp, utils.MetaSQL,
+		config.NewRSRParsersMustCompile("crm;`SELECT tier FROM customers WHERE account=?`;~*req.Account", utils.InfieldSep)); err == nil || err.Error() != expErr {
+		t.Errorf("expected: <%+v>, received: <%+v>", expErr, err)
+	}
+	cfg.AttributeSCfg().SQLConns = map[string]string{"crm": "mongo://127.0.0.1:27017/crm"}
+	expErr = "db type <mongo> not supported"
+	if _, err := al.parse(cfg.AttributeSCfg(), dp, utils.MetaSQL,
+		config.NewRSRParsersMustCompile("crm;`SELECT tier FROM customers`", utils.InfieldSep)); err == nil || err.Error() != expErr {
+		t.Errorf("expected: <%+v>, received: <%+v>", expErr, err)
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -37,6 +38,7 @@ func NewAttributeService(dm *DataManager, filterS *FilterS,
 		dm:      dm,
 		filterS: filterS,
 		cgrcfg:  cgrcfg,
+		lookups: newAttrLookups(cgrcfg.AttributeSCfg()),
 	}
 }
 
@@ -45,60 +47,51 @@ type AttributeService struct {
 	dm      *DataManager
 	filterS *FilterS
 	cgrcfg  *config.CGRConfig
+	lookups *attrLookups // external sources queried by the attributes
+	lkpsMux sync.RWMutex // protects lookups
 }
 
 // Shutdown is called to shutdown the service
 func (alS *AttributeService) Shutdown() {
 	utils.Logger.Info(fmt.Sprintf("<%s> shutdown initialized", utils.AttributeS))
+	alS.lkpsMux.RLock()
+	alS.lookups.close()
+	alS.lkpsMux.RUnlock()
 	utils.Logger.Info(fmt.Sprintf("<%s> shutdown complete", utils.AttributeS))
 }
 
+// Reload recreates the lookups after the AttributeS config changed
+// so the lookups cache and the SQL connections follow the new config
+func (alS *AttributeService) Reload() {
+	alS.lkpsMux.Lock()
+	alS.lookups = alS.lookups.reloaded(alS.cgrcfg.AttributeSCfg())
+	alS.lkpsMux.Unlock()
+}
+
 // attributeProfileForEvent returns the matching attribute
 func (alS *AttributeService) attributeProfileForEvent(tnt string, ctx *string, attrsIDs []string, actTime *time.Time, evNm utils.MapStorage,
 	lastID string, processedPrfNo map[string]int, profileRuns int, ignoreFilters bool) (matchAttrPrfl *AttributeProfile, err error) {
-	var attrIDs []string
+	return alS.explainedAttributeProfileForEvent(tnt, ctx, attrsIDs, actTime, evNm,
+		lastID, processedPrfNo, profileRuns, ignoreFilters, nil)
+}
+
+// explainedAttributeProfileForEvent is attributeProfileForEvent appending to prfsExpl, if not nil,
+// how each AttributeProfile was considered for the event
+func (alS *AttributeService) explainedAttributeProfileForEvent(tnt string, ctx *string, attrsIDs []string, actTime *time.Time, evNm utils.MapStorage,
+	lastID string, processedPrfNo map[string]int, profileRuns int, ignoreFilters bool,
+	prfsExpl *[]*ProfileExplain) (matchAttrPrfl *AttributeProfile, err error) {
 	contextVal := utils.MetaDefault
 	if ctx != nil && *ctx != "" {
 		contextVal = *ctx
 	}
-	attrIdxKey := utils.ConcatenatedKey(tnt, contextVal)
-	if len(attrsIDs) != 0 {
-		attrIDs = attrsIDs
-	} else {
+	if len(attrsIDs) == 0 {
 		ignoreFilters = false
-		aPrflIDs, err := MatchingItemIDsForEvent(evNm,
-			alS.cgrcfg.AttributeSCfg().StringIndexedFields,
-			alS.cgrcfg.AttributeSCfg().PrefixIndexedFields,
-			alS.cgrcfg.AttributeSCfg().SuffixIndexedFields,
-			alS.dm, utils.CacheAttributeFilterIndexes, attrIdxKey,
-			alS.cgrcfg.AttributeSCfg().IndexedSelects,
-			alS.cgrcfg.AttributeSCfg().NestedFields,
-		)
-		if err != nil &&
-			err != utils.ErrNotFound {
-			return nil, err
-		}
-		if err == utils.ErrNotFound ||
-			alS.cgrcfg.AttributeSCfg().AnyContext {
-			aPrflAnyIDs, err := MatchingItemIDsForEvent(evNm,
-				alS.cgrcfg.AttributeSCfg().StringIndexedFields,
-				alS.cgrcfg.AttributeSCfg().PrefixIndexedFields,
-				alS.cgrcfg.AttributeSCfg().SuffixIndexedFields,
-				alS.dm, utils.CacheAttributeFilterIndexes,
-				utils.ConcatenatedKey(tnt, utils.MetaAny),
-				alS.cgrcfg.AttributeSCfg().IndexedSelects,
-				alS.cgrcfg.AttributeSCfg().NestedFields)
-			if aPrflIDs.Size() == 0 {
-				if err != nil { // return the error if no attribute matched the needed context
-					return nil, err
-				}
-				aPrflIDs = aPrflAnyIDs
-			} else if err == nil && aPrflAnyIDs.Size() != 0 {
-				aPrflIDs = utils.JoinStringSet(aPrflIDs, aPrflAnyIDs)
-			}
-		}
-		attrIDs = aPrflIDs.AsSlice()
 	}
+	attrIDs, err := alS.attributeProfileIDs(tnt, contextVal, attrsIDs, evNm)
+	if err != nil {
+		return
+	}
+	var matchedExpl *ProfileExplain
 	for _, apID := range attrIDs {
 		aPrfl, err := alS.dm.GetAttributeProfile(tnt, apID, true, true, utils.NonTransactional)
 		if err != nil {
@@ -107,44 +100,118 @@ func (alS *AttributeService) attributeProfileForEvent(tnt string, ctx *string, a
 			}
 			return nil, err
 		}
+		tntID := aPrfl.TenantIDInline()
+		prfExpl := newProfileExplain(prfsExpl, tntID)
 		if !(len(aPrfl.Contexts) == 1 && aPrfl.Contexts[0] == utils.MetaAny) &&
 			!utils.IsSliceMember(aPrfl.Contexts, contextVal) {
+			prfExpl.setReason(utils.ExplainContextNotMatching)
 			continue
 		}
 		if aPrfl.ActivationInterval != nil && actTime != nil &&
 			!aPrfl.ActivationInterval.IsActiveAtTime(*actTime) { // not active
+			prfExpl.setReason(utils.ExplainNotActive)
 			continue
 		}
-		tntID := aPrfl.TenantIDInline()
 		(evNm[utils.MetaVars].(utils.MapStorage))[utils.MetaAttrPrfTenantID] = tntID
 		if !ignoreFilters {
-			if pass, err := alS.filterS.Pass(tnt, aPrfl.FilterIDs,
-				evNm); err != nil {
+			if pass, err := alS.filterS.passExplained(tnt, aPrfl.FilterIDs,
+				evNm, prfExpl); err != nil {
 				return nil, err
 			} else if !pass {
+				prfExpl.setReason(utils.ExplainFiltersNotPassing)
 				continue
 			}
 		}
-		if (matchAttrPrfl == nil || matchAttrPrfl.Weight < aPrfl.Weight) &&
-			tntID != lastID &&
-			(profileRuns <= 0 || processedPrfNo[tntID] < profileRuns) {
+		if tntID == lastID ||
+			(profileRuns > 0 && processedPrfNo[tntID] >= profileRuns) {
+			prfExpl.setReason(utils.ExplainAlreadyProcessed)
+			continue
+		}
+		if matchAttrPrfl == nil || matchAttrPrfl.Weight < aPrfl.Weight {
+			matchedExpl.setReason(utils.ExplainLowerWeight)
 			matchAttrPrfl = aPrfl
+			matchedExpl = prfExpl
+			continue
 		}
+		prfExpl.setReason(utils.ExplainLowerWeight)
 	}
 	// All good, convert from Map to Slice so we can sort
 	if matchAttrPrfl == nil {
 		return nil, utils.ErrNotFound
 	}
+	if matchedExpl != nil {
+		matchedExpl.Selected = true
+	}
 	(evNm[utils.MetaVars].(utils.MapStorage))[utils.MetaAttrPrfTenantID] = matchAttrPrfl.TenantIDInline()
 	return
 }
 
+// attributeProfileIDs returns the IDs of the AttributeProfiles to be checked for the event
+func (alS *AttributeService) attributeProfileIDs(tnt, contextVal string, attrsIDs []string,
+	evNm utils.MapStorage) (attrIDs []string, err error) {
+	if len(attrsIDs) != 0 {
+		return attrsIDs, nil
+	}
+	aPrflIDs, err := MatchingItemIDsForEvent(evNm,
+		alS.cgrcfg.AttributeSCfg().StringIndexedFields,
+		alS.cgrcfg.AttributeSCfg().PrefixIndexedFields,
+		alS.cgrcfg.AttributeSCfg().SuffixIndexedFields,
+		alS.dm, utils.CacheAttributeFilterIndexes, utils.ConcatenatedKey(tnt, contextVal),
+		alS.cgrcfg.AttributeSCfg().IndexedSelects,
+		alS.cgrcfg.AttributeSCfg().NestedFields,
+	)
+	if err != nil &&
+		err != utils.ErrNotFound {
+		return nil, err
+	}
+	if err == utils.ErrNotFound ||
+		alS.cgrcfg.AttributeSCfg().AnyContext {
+		aPrflAnyIDs, err := MatchingItemIDsForEvent(evNm,
+			alS.cgrcfg.AttributeSCfg().StringIndexedFields,
+			alS.cgrcfg.AttributeSCfg().PrefixIndexedFields,
+			alS.cgrcfg.AttributeSCfg().SuffixIndexedFields,
+			alS.dm, utils.CacheAttributeFilterIndexes,
+			utils.ConcatenatedKey(tnt, utils.MetaAny),
+			alS.cgrcfg.AttributeSCfg().IndexedSelects,
+			alS.cgrcfg.AttributeSCfg().NestedFields)
+		if aPrflIDs.Size() == 0 {
+			if err != nil { // return the error if no attribute matched the needed context
+				return nil, err
+			}
+			aPrflIDs = aPrflAnyIDs
+		} else if err == nil && aPrflAnyIDs.Size() != 0 {
+			aPrflIDs = utils.JoinStringSet(aPrflIDs, aPrflAnyIDs)
+		}
+	}
+	return aPrflIDs.AsSlice(), nil
+}
+
+// AttrFieldExplain details one Attribute of the selected profile
+type AttrFieldExplain struct {
+	Path    string
+	Type    string
+	Filters []*FilterExplain `json:",omitempty"`
+	Applied bool             // false if the filters of the attribute are not passing
+	Before  interface{}      // value of the Path before the attribute was applied, nil if missing
+	After   interface{}      // value of the Path after the attribute was applied, nil if removed
+}
+
+// AttrRunExplain details one process run of AttributeS
+type AttrRunExplain struct {
+	ProcessRun     int
+	Profiles       []*ProfileExplain
+	MatchedProfile string
+	Attributes     []*AttrFieldExplain
+}
+
 // AttrSProcessEventReply reply used for proccess event
 type AttrSProcessEventReply struct {
 	MatchedProfiles []string
 	AlteredFields   []string
 	*utils.CGREvent
-	blocker bool // internally used to stop further processRuns
+	Explain []*AttrRunExplain `json:",omitempty"` // populated when *explain option is enabled
+	blocker bool              // internally used to stop further processRuns
+	explain *AttrRunExplain   // internally used to collect the explain of one processRun
 }
 
 // Digest returns serialized version of alteredFields in AttrSProcessEventReply
@@ -181,29 +248,71 @@ func (alS *AttributeService) processEvent(tnt string, args *utils.CGREvent, evNm
 		utils.OptsAttributesProfileIgnoreFilters); err != nil {
 		return
 	}
+	var explain bool
+	if explain, err = utils.GetBoolOpts(args, false, utils.OptsExplain); err != nil {
+		return
+	}
+	var runExpl *AttrRunExplain
+	var prfsExpl *[]*ProfileExplain
+	if explain {
+		runExpl = new(AttrRunExplain)
+		prfsExpl = &runExpl.Profiles
+	}
 	var attrPrf *AttributeProfile
-	if attrPrf, err = alS.attributeProfileForEvent(tnt, context, attrIDs, args.Time, evNm, lastID, processedPrfNo, profileRuns, ignFilters); err != nil {
+	if attrPrf, err = alS.explainedAttributeProfileForEvent(tnt, context, attrIDs, args.Time, evNm,
+		lastID, processedPrfNo, profileRuns, ignFilters, prfsExpl); err != nil {
+		if err == utils.ErrNotFound && explain { // return the explain of the profiles not matching
+			rply = &AttrSProcessEventReply{explain: runExpl}
+		}
 		return
 	}
 	rply = &AttrSProcessEventReply{
 		MatchedProfiles: []string{attrPrf.TenantIDInline()},
 		CGREvent:        args,
 		blocker:         attrPrf.Blocker,
+		explain:         runExpl,
 	}
 	rply.Tenant = tnt
+	if explain {
+		runExpl.MatchedProfile = attrPrf.TenantIDInline()
+	}
 	for _, attribute := range attrPrf.Attributes {
+		var fldExpl *AttrFieldExplain
+		if explain {
+			fldExpl = &AttrFieldExplain{
+				Path: attribute.Path,
+				Type: utils.FirstNonEmpty(attribute.Type, utils.MetaVariable),
+			}
+			runExpl.Attributes = append(runExpl.Attributes, fldExpl)
+		}
 		//in case that we have filter for attribute send them to FilterS to be processed
 		if len(attribute.FilterIDs) != 0 {
 			var pass bool
-			if pass, err = alS.filterS.Pass(tnt, attribute.FilterIDs,
-				evNm); err != nil {
+			if explain {
+				fldExpl.Filters, pass, err = alS.filterS.PassWithExplain(tnt, attribute.FilterIDs, evNm)
+			} else {
+				pass, err = alS.filterS.Pass(tnt, attribute.FilterIDs, evNm)
+			}
+			if err != nil {
 				return
 			} else if !pass {
 				continue
 			}
 		}
+		if explain {
+			fldExpl.Applied = true
+			fldExpl.Before = attrFieldValue(evNm, attribute.Path)
+		}
 		var out interface{}
-		if out, err = ParseAttribute(dynDP, utils.FirstNonEmpty(attribute.Type, utils.MetaVariable), utils.DynamicDataPrefix+attribute.Path, attribute.Value, alS.cgrcfg.GeneralCfg().RoundingDecimals, alS.cgrcfg.GeneralCfg().DefaultTimezone, time.RFC3339, alS.cgrcfg.GeneralCfg().RSRSep); err != nil {
+		if isLookupAttribute(attribute.Type) {
+			alS.lkpsMux.RLock()
+			lkps := alS.lookups
+			alS.lkpsMux.RUnlock()
+			out, err = lkps.parse(alS.cgrcfg.AttributeSCfg(), dynDP, attribute.Type, attribute.Value)
+		} else {
+			out, err = ParseAttribute(dynDP, utils.FirstNonEmpty(attribute.Type, utils.MetaVariable), utils.DynamicDataPrefix+attribute.Path, attribute.Value, alS.cgrcfg.GeneralCfg().RoundingDecimals, alS.cgrcfg.GeneralCfg().DefaultTimezone, time.RFC3339, alS.cgrcfg.GeneralCfg().RSRSep)
+		}
+		if err != nil {
 			rply = nil
 			return
 		}
@@ -219,6 +328,9 @@ func (alS *AttributeService) processEvent(tnt string, args *utils.CGREvent, evNm
 				rply.CGREvent.Tenant = substitute
 			}
 			evNm[utils.MetaTenant] = substitute
+			if explain {
+				fldExpl.After = rply.CGREvent.Tenant
+			}
 			continue
 		}
 		if substitute == utils.MetaRemove {
@@ -237,10 +349,19 @@ func (alS *AttributeService) processEvent(tnt string, args *utils.CGREvent, evNm
 			rply = nil
 			return
 		}
+		if explain {
+			fldExpl.After = substitute
+		}
 	}
 	return
 }
 
+// attrFieldValue returns the value of the path within the event, nil if missing
+func attrFieldValue(evNm utils.MapStorage, path string) (val interface{}) {
+	val, _ = evNm.FieldAsInterface(utils.SplitPath(path, utils.NestingSep[0], -1))
+	return
+}
+
 // V1GetAttributeForEvent returns the AttributeProfile that matches the event
 func (alS *AttributeService) V1GetAttributeForEvent(args *utils.CGREvent,
 	attrPrfl *AttributeProfile) (err error) {
@@ -316,6 +437,11 @@ func (alS *AttributeService) V1ProcessEvent(args *utils.CGREvent,
 		},
 		utils.MetaTenant: tnt,
 	}
+	var explain bool
+	if explain, err = utils.GetBoolOpts(args, false, utils.OptsExplain); err != nil {
+		return
+	}
+	var runsExpl []*AttrRunExplain
 	var lastID string
 	matchedIDs := make([]string, 0, processRuns)
 	alteredFields := make(utils.StringSet)
@@ -325,11 +451,18 @@ func (alS *AttributeService) V1ProcessEvent(args *utils.CGREvent,
 		(eNV[utils.MetaVars].(utils.MapStorage))[utils.MetaProcessRuns] = i + 1
 		var evRply *AttrSProcessEventReply
 		evRply, err = alS.processEvent(tnt, args, eNV, dynDP, lastID, processedPrfNo, profileRuns)
+		if evRply != nil && evRply.explain != nil {
+			evRply.explain.ProcessRun = i + 1
+			runsExpl = append(runsExpl, evRply.explain)
+		}
 		if err != nil {
 			if err != utils.ErrNotFound {
 				err = utils.NewErrServerError(err)
 			} else if i != 0 { // ignore "not found" in a loop different than 0
 				err = nil
+			} else if explain { // the reply is not sent back with the error so log the reasons of not matching
+				utils.Logger.Info(fmt.Sprintf("<%s> no profile matching event <%s>, explain: %s",
+					utils.AttributeS, args.ID, utils.ToJSON(runsExpl)))
 			}
 			break
 		}
@@ -363,6 +496,7 @@ func (alS *AttributeService) V1ProcessEvent(args *utils.CGREvent,
 		MatchedProfiles: matchedIDs,
 		AlteredFields:   alteredFields.AsSlice(),
 		CGREvent:        args,
+		Explain:         runsExpl,
 	}
 	return
 }
@@ -0,0 +1,197 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// AuditRecord is one change of a DataDB item done via the APIs or the LoaderS
+type AuditRecord struct {
+	ID       string
+	Time     time.Time
+	User     string // the API user requesting the change
+	Source   string // the API method or the loader doing the change
+	Action   string // *store or *remove
+	ItemType string // the cache partition of the item, e.g. *attribute_profiles
+	Tenant   string // empty for the items not belonging to a tenant
+	ItemID   string
+	Before   json.RawMessage `json:",omitempty"` // the item before the change, empty if created
+	After    json.RawMessage `json:",omitempty"` // the item after the change, empty if removed
+}
+
+// AsCGREvent converts the record into an event to be exported via EEs
+func (ar *AuditRecord) AsCGREvent() *utils.CGREvent {
+	tnt := ar.Tenant
+	if tnt == utils.EmptyString {
+		tnt = config.CgrConfig().GeneralCfg().DefaultTenant
+	}
+	return &utils.CGREvent{
+		Tenant: tnt,
+		ID:     ar.ID,
+		Time:   utils.TimePointer(ar.Time),
+		Event: map[string]interface{}{
+			utils.ID:         ar.ID,
+			utils.Time:       ar.Time,
+			utils.User:       ar.User,
+			utils.Source:     ar.Source,
+			utils.Action:     ar.Action,
+			utils.ItemType:   ar.ItemType,
+			utils.Tenant:     ar.Tenant,
+			utils.ItemID:     ar.ItemID,
+			utils.ItemBefore: string(ar.Before),
+			utils.ItemAfter:  string(ar.After),
+		},
+		APIOpts: make(map[string]interface{}),
+	}
+}
+
+// auditInfo identifies the originator of the changes done via an audited DataManager
+type auditInfo struct {
+	user   string
+	source string
+}
+
+// WithAudit returns a copy of the DataManager recording into the audit log the changes done
+// by user via source, the DataManager itself if the audit log is disabled
+func (dm *DataManager) WithAudit(user, source string) *DataManager {
+	if dm == nil || !config.CgrConfig().ApierCfg().AuditLog {
+		return dm
+	}
+	adm := *dm
+	adm.audit = &auditInfo{user: user, source: source}
+	return &adm
+}
+
+// AuditChange is the change of a DataDB item, recorded once done
+type AuditChange struct {
+	dm     *DataManager
+	prfx   string
+	key    string
+	before json.RawMessage
+}
+
+// NewAuditChange starts the change of the item with the given prefix and key, nil if not audited
+func (dm *DataManager) NewAuditChange(prfx, key string) (ac *AuditChange) {
+	if dm == nil || dm.audit == nil {
+		return
+	}
+	ac = &AuditChange{dm: dm, prfx: prfx, key: key}
+	ac.before = ac.getItem()
+	return
+}
+
+// getItem returns the JSON of the item as stored in DataDB, nil if not found
+func (ac *AuditChange) getItem() (itmJSON json.RawMessage) {
+	itm, err := dataDBItemHandlers[ac.prfx].get(ac.dm.dataDB, ac.key)
+	if err == nil {
+		itmJSON, err = json.Marshal(itm)
+	}
+	if err != nil && err != utils.ErrNotFound {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s> querying item with key: <%s> for audit",
+			utils.DataManager, err.Error(), ac.prfx+ac.key))
+	}
+	return
+}
+
+// Record writes into the audit log the change of the item, considered removed if not found anymore
+func (ac *AuditChange) Record() {
+	if ac == nil {
+		return
+	}
+	after := ac.getItem()
+	if ac.before == nil && after == nil { // removing a missing item changes nothing
+		return
+	}
+	rec := &AuditRecord{
+		ID:       utils.GenUUID(),
+		Time:     time.Now(),
+		User:     ac.dm.audit.user,
+		Source:   ac.dm.audit.source,
+		Action:   utils.MetaStore,
+		ItemType: utils.CachePrefixToInstance[ac.prfx],
+		ItemID:   ac.key,
+		Before:   ac.before,
+		After:    after,
+	}
+	if ac.prfx == utils.AccountPrefix { // the accounts are not cached
+		rec.ItemType = utils.MetaAccounts
+	}
+	if rec.After == nil {
+		rec.Action = utils.MetaRemove
+	}
+	if tnt, hasTnt := dataDBItemTenant(ac.prfx, ac.key); hasTnt {
+		rec.Tenant = tnt
+		rec.ItemID = strings.TrimPrefix(ac.key, tnt+utils.ConcatenatedKeySep)
+	}
+	logAuditRecord(rec)
+}
+
+// logAuditRecord stores the record into StorDB and exports it via EEs if configured
+func logAuditRecord(rec *AuditRecord) {
+	if cdrStorage == nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> no StorDB to store the audit record: %s",
+			utils.DataManager, utils.ToJSON(rec)))
+	} else if err := cdrStorage.SetAuditRecord(rec); err != nil {
+		utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s> storing the audit record: %s",
+			utils.DataManager, err.Error(), utils.ToJSON(rec)))
+	}
+	aCfg := config.CgrConfig().ApierCfg()
+	if len(aCfg.AuditEEsIDs) == 0 {
+		return
+	}
+	go func() {
+		var rply map[string]map[string]interface{}
+		if err := connMgr.Call(aCfg.EEsConns, nil, utils.EeSv1ProcessEvent,
+			&CGREventWithEeIDs{
+				EeIDs:    aCfg.AuditEEsIDs,
+				CGREvent: rec.AsCGREvent(),
+			}, &rply); err != nil {
+			utils.Logger.Warning(fmt.Sprintf("<%s> error: <%s> exporting the audit record with ID: <%s>",
+				utils.DataManager, err.Error(), rec.ID))
+		}
+	}()
+}
+
+// paginateAuditRecords sorts the records on time and applies the paginator
+func paginateAuditRecords(recs []*AuditRecord, pgnt utils.Paginator) []*AuditRecord {
+	sort.Slice(recs, func(i, j int) bool {
+		if recs[i].Time.Equal(recs[j].Time) {
+			return recs[i].ID < recs[j].ID
+		}
+		return recs[i].Time.Before(recs[j].Time)
+	})
+	if pgnt.Offset != nil {
+		if *pgnt.Offset >= len(recs) {
+			return nil
+		}
+		recs = recs[*pgnt.Offset:]
+	}
+	if pgnt.Limit != nil && *pgnt.Limit < len(recs) {
+		recs = recs[:*pgnt.Limit]
+	}
+	return recs
+}
@@ -0,0 +1,169 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"encoding/json"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestAuditLogDataManager(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	tmpCfg := config.CgrConfig()
+	tmpStor := cdrStorage
+	defer func() {
+		config.SetCgrConfig(tmpCfg)
+		SetCdrStorage(tmpStor)
+	}()
+	config.SetCgrConfig(cfg)
+	stor := NewInternalDB(nil, nil, false, cfg.StorDbCfg().Items)
+	SetCdrStorage(stor)
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+
+	if adm := dm.WithAudit("1001", utils.APIerSv1SetAttributeProfile); adm != dm {
+		t.Errorf("Expected the same DataManager with the audit log disabled")
+	}
+	cfg.ApierCfg().AuditLog = true
+	attrPrf := &AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ATTR_AUDIT",
+		Contexts:  []string{utils.MetaAny},
+		FilterIDs: []string{"*string:~*req.Account:1001"},
+		Attributes: []*Attribute{{
+			Path:  utils.MetaReq + utils.NestingSep + utils.Subject,
+			Type:  utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("1002", utils.InfieldSep),
+		}},
+		Weight: 10,
+	}
+	if err := dm.SetAttributeProfile(attrPrf, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := stor.GetAuditRecords(new(utils.AuditRecordsFilter)); err != utils.ErrNotFound {
+		t.Errorf("Expected %v for the changes done without audit, received: %v", utils.ErrNotFound, err)
+	}
+
+	adm := dm.WithAudit("1001", utils.APIerSv1SetAttributeProfile)
+	updPrf := *attrPrf // the internal DataDB keeps the stored pointer
+	updPrf.Weight = 20
+	if err := adm.SetAttributeProfile(&updPrf, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := adm.SetAccount(&Account{ID: "cgrates.org:1001"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := adm.RemoveAttributeProfile("cgrates.org", "ATTR_AUDIT", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := adm.RemoveAttributeProfile("cgrates.org", "ATTR_AUDIT", false); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+
+	recs, err := stor.GetAuditRecords(new(utils.AuditRecordsFilter))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(recs) != 3 {
+		t.Fatalf("Expected 3 records, received: %s", utils.ToJSON(recs))
+	}
+	for i, exp := range []*AuditRecord{
+		{User: "1001", Source: utils.APIerSv1SetAttributeProfile, Action: utils.MetaStore,
+			ItemType: utils.CacheAttributeProfiles, Tenant: "cgrates.org", ItemID: "ATTR_AUDIT"},
+		{User: "1001", Source: utils.APIerSv1SetAttributeProfile, Action: utils.MetaStore,
+			ItemType: utils.MetaAccounts, Tenant: "cgrates.org", ItemID: "1001"},
+		{User: "1001", Source: utils.APIerSv1SetAttributeProfile, Action: utils.MetaRemove,
+			ItemType: utils.CacheAttributeProfiles, Tenant: "cgrates.org", ItemID: "ATTR_AUDIT"},
+	} {
+		rcv := *recs[i]
+		rcv.ID, rcv.Time, rcv.Before, rcv.After = "", exp.Time, nil, nil
+		if !reflect.DeepEqual(*exp, rcv) {
+			t.Errorf("Expected %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+		}
+	}
+	var before, after AttributeProfile
+	if err = json.Unmarshal(recs[0].Before, &before); err != nil {
+		t.Fatal(err)
+	} else if before.Weight != 10 {
+		t.Errorf("Expected the weight before the change to be 10, received: %v", before.Weight)
+	}
+	if err = json.Unmarshal(recs[0].After, &after); err != nil {
+		t.Fatal(err)
+	} else if after.Weight != 20 {
+		t.Errorf("Expected the weight after the change to be 20, received: %v", after.Weight)
+	}
+	if recs[1].Before != nil {
+		t.Errorf("Expected no item before creating the account, received: %s", recs[1].Before)
+	}
+	if recs[2].After != nil {
+		t.Errorf("Expected no item after the removal, received: %s", recs[2].After)
+	}
+
+	if recs, err = stor.GetAuditRecords(&utils.AuditRecordsFilter{
+		ItemTypes: []string{utils.CacheAttributeProfiles},
+		Actions:   []string{utils.MetaRemove},
+	}); err != nil {
+		t.Fatal(err)
+	} else if len(recs) != 1 || recs[0].Action != utils.MetaRemove {
+		t.Errorf("Expected the removal record, received: %s", utils.ToJSON(recs))
+	}
+	if recs, err = stor.GetAuditRecords(&utils.AuditRecordsFilter{
+		Paginator: utils.Paginator{Limit: utils.IntPointer(1), Offset: utils.IntPointer(1)},
+	}); err != nil {
+		t.Fatal(err)
+	} else if len(recs) != 1 || recs[0].ItemType != utils.MetaAccounts {
+		t.Errorf("Expected the account record, received: %s", utils.ToJSON(recs))
+	}
+	if _, err = stor.GetAuditRecords(&utils.AuditRecordsFilter{
+		Users: []string{"1002"},
+	}); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	tEnd := recs[0].Time
+	if recs, err = stor.GetAuditRecords(&utils.AuditRecordsFilter{
+		Time: utils.TimeInterval{End: &tEnd},
+	}); err != nil {
+		t.Fatal(err)
+	} else if len(recs) != 1 || recs[0].ItemType != utils.CacheAttributeProfiles {
+		t.Errorf("Expected the first record, received: %s", utils.ToJSON(recs))
+	}
+}
+
+func TestAuditRecordAsCGREvent(t *testing.T) {
+	rec := &AuditRecord{
+		ID:       "rec1",
+		User:     "1001",
+		Source:   utils.APIerSv1SetTiming,
+		Action:   utils.MetaStore,
+		ItemType: utils.CacheTimings,
+		ItemID:   "WORKDAYS",
+		After:    []byte(`{"ID":"WORKDAYS"}`),
+	}
+	cgrEv := rec.AsCGREvent()
+	if cgrEv.Tenant != config.CgrConfig().GeneralCfg().DefaultTenant {
+		t.Errorf("Expected the default tenant, received: %s", cgrEv.Tenant)
+	}
+	if cgrEv.Event[utils.ItemAfter] != `{"ID":"WORKDAYS"}` ||
+		cgrEv.Event[utils.ItemBefore] != utils.EmptyString {
+		t.Errorf("Unexpected event: %s", utils.ToJSON(cgrEv))
+	}
+}
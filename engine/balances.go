@@ -762,7 +762,7 @@ func (bc Balances) SaveDirtyBalances(acc *Account, initBal map[string]float64) {
 		}
 		savedAccounts.Add(b.account.ID)
 		if b.account != acc {
-			dm.SetAccount(b.account)
+			dm.setAccount(b.account)
 		}
 		b.account.Publish(initBal)
 	}
@@ -735,7 +735,7 @@ func (cd *CallDescriptor) debit(account *Account, dryRun bool, goNegative bool,
 	cc.UpdateRatedUsage()
 	cc.Timespans.Compress()
 	if !dryRun {
-		dm.SetAccount(account)
+		dm.setAccount(account)
 	}
 	if cd.PerformRounding {
 		cc.Round()
@@ -862,7 +862,7 @@ func (cd *CallDescriptor) refundIncrements(fltrS *FilterS) (acnt *Account, err e
 				account = acc
 				accountsCache[increment.BalanceInfo.AccountID] = account
 				// will save the account only once at the end of the function
-				defer dm.SetAccount(account)
+				defer dm.setAccount(account)
 			}
 		}
 		if account == nil {
@@ -922,7 +922,7 @@ func (cd *CallDescriptor) refundRounding(old *Account, fltrS *FilterS) (accounts
 	accountsCache = make(map[string]*Account)
 	if old != nil {
 		accountsCache[old.ID] = old
-		defer dm.SetAccount(old)
+		defer dm.setAccount(old)
 	}
 	for _, increment := range cd.Increments {
 		account, found := accountsCache[increment.BalanceInfo.AccountID]
@@ -931,7 +931,7 @@ func (cd *CallDescriptor) refundRounding(old *Account, fltrS *FilterS) (accounts
 				account = acc
 				accountsCache[increment.BalanceInfo.AccountID] = account
 				// will save the account only once at the end of the function
-				defer dm.SetAccount(account)
+				defer dm.setAccount(account)
 			}
 		}
 		if account == nil {
@@ -23,6 +23,7 @@ import (
 	"sync"
 	"time"
 
+	"github.com/cgrates/cgrates/config"
 	"github.com/cgrates/cgrates/utils"
 )
 
@@ -30,6 +31,8 @@ import (
 type Caps struct {
 	strategy string
 	aReqs    chan struct{}
+	tntMux   sync.Mutex
+	tntReqs  map[string]int // requests actively serviced indexed on tenant
 }
 
 // NewCaps creates a new caps
@@ -37,6 +40,7 @@ func NewCaps(reqs int, strategy string) *Caps {
 	return &Caps{
 		strategy: strategy,
 		aReqs:    make(chan struct{}, reqs),
+		tntReqs:  make(map[string]int),
 	}
 }
 
@@ -69,6 +73,40 @@ func (cR *Caps) Deallocate() {
 	<-cR.aReqs
 }
 
+// AllocateTenant reserves one of the concurrent API requests allowed for the tenant by the quotas section of the config
+func (cR *Caps) AllocateTenant(tnt string) (err error) {
+	var limit int
+	if tq := config.CgrConfig().QuotasCfg().TenantQuota(tnt); tq != nil {
+		limit = tq.APIRequests
+	}
+	cR.tntMux.Lock()
+	defer cR.tntMux.Unlock()
+	if limit != 0 && cR.tntReqs[tnt] >= limit {
+		return utils.NewErrTenantQuotaExceeded(utils.APIRequestsCfg, tnt)
+	}
+	cR.tntReqs[tnt]++
+	return
+}
+
+// DeallocateTenant frees one of the API requests of the tenant
+func (cR *Caps) DeallocateTenant(tnt string) {
+	cR.tntMux.Lock()
+	if cR.tntReqs[tnt] <= 1 {
+		delete(cR.tntReqs, tnt)
+	} else {
+		cR.tntReqs[tnt]--
+	}
+	cR.tntMux.Unlock()
+}
+
+// TenantAllocated returns the number of requests of the tenant actively serviced
+func (cR *Caps) TenantAllocated(tnt string) (n int) {
+	cR.tntMux.Lock()
+	n = cR.tntReqs[tnt]
+	cR.tntMux.Unlock()
+	return
+}
+
 // NewCapsStats returns the stats for the caps
 func NewCapsStats(sampleinterval time.Duration, caps *Caps, stopChan chan struct{}) (cs *CapsStats) {
 	st, _ := NewStatAverage(1, utils.MetaDynReq, nil)
@@ -423,6 +423,22 @@ func (cdrS *CDRServer) statSProcessEvent(cgrEv *utils.CGREvent) (err error) {
 	return
 }
 
+// fraudSProcessEvent will send the event to FraudS
+func (cdrS *CDRServer) fraudSProcessEvent(cgrEv *utils.CGREvent) (err error) {
+	var reply []string
+	return cdrS.connMgr.Call(cdrS.cgrCfg.CdrsCfg().FraudSConns, nil,
+		utils.FraudSv1ProcessEvent,
+		cgrEv.Clone(), &reply)
+}
+
+// routeSProcessFeedback feeds the circuit breaker of the route used by the call with its outcome
+func (cdrS *CDRServer) routeSProcessFeedback(cgrEv *utils.CGREvent) (err error) {
+	var reply string
+	return cdrS.connMgr.Call(cdrS.cgrCfg.CdrsCfg().RouteSConns, nil,
+		utils.RouteSv1ProcessFeedback,
+		cgrEv.Clone(), &reply)
+}
+
 // eeSProcessEvent will process the event with the EEs component
 func (cdrS *CDRServer) eeSProcessEvent(cgrEv *CGREventWithEeIDs) (err error) {
 	var reply map[string]map[string]interface{}
@@ -438,7 +454,7 @@ func (cdrS *CDRServer) eeSProcessEvent(cgrEv *CGREventWithEeIDs) (err error) {
 // processEvent processes a CGREvent based on arguments
 // in case of partially executed, both error and evs will be returned
 func (cdrS *CDRServer) processEvents(evs []*utils.CGREvent,
-	chrgS, attrS, refund, ralS, store, reRate, export, thdS, stS bool) (outEvs []*utils.EventWithFlags, err error) {
+	chrgS, attrS, refund, ralS, store, reRate, export, thdS, stS, fraudS, routeS bool) (outEvs []*utils.EventWithFlags, err error) {
 	if attrS {
 		for _, ev := range evs {
 			if err = cdrS.attrSProcessEvent(ev); err != nil {
@@ -607,6 +623,39 @@ func (cdrS *CDRServer) processEvents(evs []*utils.CGREvent,
 			}
 		}
 	}
+	if fraudS {
+		for _, cgrEv := range cgrEvs {
+			// only the default run is counted so the charged runs do not add up the spending
+			if runID := utils.IfaceAsString(cgrEv.Event[utils.RunID]); runID != utils.EmptyString &&
+				runID != utils.MetaDefault {
+				continue
+			}
+			if err = cdrS.fraudSProcessEvent(cgrEv); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> processing event %+v with %s",
+						utils.CDRs, err.Error(), utils.ToJSON(cgrEv), utils.FraudS))
+				partiallyExecuted = true
+			}
+		}
+	}
+	if routeS {
+		for _, cgrEv := range cgrEvs {
+			// one feedback per call, out of the default run
+			if runID := utils.IfaceAsString(cgrEv.Event[utils.RunID]); runID != utils.EmptyString &&
+				runID != utils.MetaDefault {
+				continue
+			}
+			if utils.IfaceAsString(cgrEv.Event[utils.RouteID]) == utils.EmptyString { // not routed via RouteS
+				continue
+			}
+			if err = cdrS.routeSProcessFeedback(cgrEv); err != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s> error: <%s> processing event %+v with %s",
+						utils.CDRs, err.Error(), utils.ToJSON(cgrEv), utils.RouteS))
+				partiallyExecuted = true
+			}
+		}
+	}
 	if partiallyExecuted {
 		err = utils.ErrPartiallyExecuted
 	}
@@ -677,6 +726,9 @@ func (cdrS *CDRServer) V1ProcessCDR(cdr *CDRWithAPIOpts, reply *string) (err err
 	if cdr.Tenant == utils.EmptyString {
 		cdr.Tenant = cdrS.cgrCfg.GeneralCfg().DefaultTenant
 	}
+	if err = AllowTenantCDR(cdr.Tenant); err != nil {
+		return
+	}
 	if cdr.Category == utils.EmptyString {
 		cdr.Category = cdrS.cgrCfg.GeneralCfg().DefaultCategory
 	}
@@ -698,7 +750,9 @@ func (cdrS *CDRServer) V1ProcessCDR(cdr *CDRWithAPIOpts, reply *string) (err err
 		false, // no rerate
 		len(cdrS.cgrCfg.CdrsCfg().OnlineCDRExports) != 0 || len(cdrS.cgrCfg.CdrsCfg().EEsConns) != 0,
 		len(cdrS.cgrCfg.CdrsCfg().ThresholdSConns) != 0,
-		len(cdrS.cgrCfg.CdrsCfg().StatSConns) != 0); err != nil {
+		len(cdrS.cgrCfg.CdrsCfg().StatSConns) != 0,
+		len(cdrS.cgrCfg.CdrsCfg().FraudSConns) != 0,
+		len(cdrS.cgrCfg.CdrsCfg().RouteSConns) != 0); err != nil {
 		return
 	}
 	*reply = utils.OK
@@ -767,6 +821,9 @@ func (cdrS *CDRServer) V1ProcessEvent(arg *ArgV1ProcessEvent, reply *string) (er
 			nil, true, utils.NonTransactional)
 	}
 	// end of RPC caching
+	if err = AllowTenantCDR(arg.CGREvent.Tenant); err != nil {
+		return
+	}
 
 	// processing options
 	flgs := utils.FlagsWithParamsFromSlice(arg.Flags)
@@ -843,10 +900,30 @@ func (cdrS *CDRServer) V1ProcessEvent(arg *ArgV1ProcessEvent, reply *string) (er
 	if flgs.Has(utils.MetaRefund) {
 		refund = flgs.GetBool(utils.MetaRefund)
 	}
+	// the rerated CDRs were already counted by FraudS
+	fraudS := len(cdrS.cgrCfg.CdrsCfg().FraudSConns) != 0 && !reRate
+	if v, has := arg.APIOpts[utils.OptsFraudS]; has {
+		if fraudS, err = utils.IfaceAsBool(v); err != nil {
+			return
+		}
+	}
+	if flgs.Has(utils.MetaFrauds) {
+		fraudS = flgs.GetBool(utils.MetaFrauds)
+	}
+	// the outcome of the rerated calls was already fed to RouteS
+	routeS := len(cdrS.cgrCfg.CdrsCfg().RouteSConns) != 0 && !reRate
+	if v, has := arg.APIOpts[utils.OptsRouteS]; has {
+		if routeS, err = utils.IfaceAsBool(v); err != nil {
+			return
+		}
+	}
+	if flgs.Has(utils.MetaRoutes) {
+		routeS = flgs.GetBool(utils.MetaRoutes)
+	}
 	// end of processing options
 
 	if _, err = cdrS.processEvents([]*utils.CGREvent{&arg.CGREvent}, chrgS, attrS, refund,
-		ralS, store, reRate, export, thdS, stS); err != nil {
+		ralS, store, reRate, export, thdS, stS, fraudS, routeS); err != nil {
 		return
 	}
 	*reply = utils.OK
@@ -877,6 +954,13 @@ func (cdrS *CDRServer) V2ProcessEvent(arg *ArgV1ProcessEvent, evs *[]*utils.Even
 			nil, true, utils.NonTransactional)
 	}
 	// end of RPC caching
+	tnt := arg.CGREvent.Tenant
+	if tnt == utils.EmptyString {
+		tnt = cdrS.cgrCfg.GeneralCfg().DefaultTenant
+	}
+	if err = AllowTenantCDR(tnt); err != nil {
+		return
+	}
 
 	// processing options
 	flgs := utils.FlagsWithParamsFromSlice(arg.Flags)
@@ -919,11 +1003,21 @@ func (cdrS *CDRServer) V2ProcessEvent(arg *ArgV1ProcessEvent, evs *[]*utils.Even
 	if flgs.Has(utils.MetaRefund) {
 		refund = flgs.GetBool(utils.MetaRefund)
 	}
+	// the rerated CDRs were already counted by FraudS
+	fraudS := len(cdrS.cgrCfg.CdrsCfg().FraudSConns) != 0 && !reRate
+	if flgs.Has(utils.MetaFrauds) {
+		fraudS = flgs.GetBool(utils.MetaFrauds)
+	}
+	// the outcome of the rerated calls was already fed to RouteS
+	routeS := len(cdrS.cgrCfg.CdrsCfg().RouteSConns) != 0 && !reRate
+	if flgs.Has(utils.MetaRoutes) {
+		routeS = flgs.GetBool(utils.MetaRoutes)
+	}
 	// end of processing options
 
 	var procEvs []*utils.EventWithFlags
 	if procEvs, err = cdrS.processEvents([]*utils.CGREvent{&arg.CGREvent}, chrgS, attrS, refund,
-		ralS, store, reRate, export, thdS, stS); err != nil {
+		ralS, store, reRate, export, thdS, stS, fraudS, routeS); err != nil {
 		return
 	}
 	*evs = procEvs
@@ -1078,6 +1172,14 @@ func (cdrS *CDRServer) V1RateCDRs(arg *ArgRateCDRs, reply *string) (err error) {
 	if flgs.Has(utils.MetaAttributes) {
 		attrS = flgs.GetBool(utils.MetaAttributes)
 	}
+	var fraudS bool // the rerated CDRs were already counted by FraudS
+	if flgs.Has(utils.MetaFrauds) {
+		fraudS = flgs.GetBool(utils.MetaFrauds)
+	}
+	var routeS bool // the outcome of the rerated calls was already fed to RouteS
+	if flgs.Has(utils.MetaRoutes) {
+		routeS = flgs.GetBool(utils.MetaRoutes)
+	}
 
 	if chrgS && len(cdrS.cgrCfg.CdrsCfg().ChargerSConns) == 0 {
 		return utils.NewErrNotConnected(utils.ChargerS)
@@ -1089,7 +1191,7 @@ func (cdrS *CDRServer) V1RateCDRs(arg *ArgRateCDRs, reply *string) (err error) {
 		cgrEvs[i].APIOpts = arg.APIOpts
 	}
 	if _, err = cdrS.processEvents(cgrEvs, chrgS, attrS, true,
-		true, store, true, export, thdS, statS); err != nil {
+		true, store, true, export, thdS, statS, fraudS, routeS); err != nil {
 		return utils.NewErrServerError(err)
 	}
 
@@ -20,6 +20,7 @@ package engine
 
 import (
 	"fmt"
+	"reflect"
 	"testing"
 	"time"
 
@@ -215,3 +216,116 @@ func TestCDRSV1V1ProcessExternalCDRNoTenant(t *testing.T) {
 		t.Error(err)
 	}
 }
+
+func TestCDRSV1ProcessEventFrauds(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().FraudSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds)}
+	var processed int
+	clMock := clMock(func(serviceMethod string, _ interface{}, _ interface{}) error {
+		if serviceMethod != utils.FraudSv1ProcessEvent {
+			return fmt.Errorf("unexpected method: %s", serviceMethod)
+		}
+		processed++
+		return nil
+	})
+	chanClnt := make(chan rpcclient.ClientConnector, 1)
+	chanClnt <- clMock
+	connMngr := NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds): chanClnt,
+	})
+	cdrs := &CDRServer{
+		cgrCfg:  cfg,
+		connMgr: connMngr,
+		dm:      NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), connMngr),
+	}
+	for _, tc := range []struct {
+		flags   []string
+		apiOpts map[string]interface{}
+		exp     int
+	}{
+		{flags: []string{utils.MetaStore + ":false"}, exp: 1},
+		{flags: []string{utils.MetaStore + ":false", utils.MetaFrauds + ":false"}, exp: 0},
+		{flags: []string{utils.MetaStore + ":false"}, apiOpts: map[string]interface{}{utils.OptsFraudS: false}, exp: 0},
+	} {
+		processed = 0
+		args := &ArgV1ProcessEvent{
+			Flags: tc.flags,
+			CGREvent: utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     "TestCDRSV1ProcessEventFrauds",
+				Event: map[string]interface{}{
+					utils.RunID:        utils.MetaDefault,
+					utils.OriginID:     utils.GenUUID(),
+					utils.AccountField: "1001",
+					utils.Usage:        time.Minute,
+				},
+				APIOpts: tc.apiOpts,
+			},
+		}
+		var reply string
+		if err := cdrs.V1ProcessEvent(args, &reply); err != nil {
+			t.Error(err)
+		} else if processed != tc.exp {
+			t.Errorf("Expected %d events processed by FraudS for flags %v, received: %d", tc.exp, tc.flags, processed)
+		}
+	}
+}
+
+func TestCDRSV1ProcessEventRoutes(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.CdrsCfg().RouteSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes)}
+	var feedbacks []string
+	clMock := clMock(func(serviceMethod string, args interface{}, _ interface{}) error {
+		if serviceMethod != utils.RouteSv1ProcessFeedback {
+			return fmt.Errorf("unexpected method: %s", serviceMethod)
+		}
+		feedbacks = append(feedbacks, utils.IfaceAsString(args.(*utils.CGREvent).Event[utils.RouteID]))
+		return nil
+	})
+	chanClnt := make(chan rpcclient.ClientConnector, 1)
+	chanClnt <- clMock
+	connMngr := NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRoutes): chanClnt,
+	})
+	cdrs := &CDRServer{
+		cgrCfg:  cfg,
+		connMgr: connMngr,
+		dm:      NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), connMngr),
+	}
+	for _, tc := range []struct {
+		flags   []string
+		apiOpts map[string]interface{}
+		routeID string
+		exp     []string
+	}{
+		{flags: []string{utils.MetaStore + ":false"}, routeID: "route1", exp: []string{"route1"}},
+		{flags: []string{utils.MetaStore + ":false"}},
+		{flags: []string{utils.MetaStore + ":false", utils.MetaRoutes + ":false"}, routeID: "route1"},
+		{flags: []string{utils.MetaStore + ":false"}, apiOpts: map[string]interface{}{utils.OptsRouteS: false}, routeID: "route1"},
+	} {
+		feedbacks = nil
+		args := &ArgV1ProcessEvent{
+			Flags: tc.flags,
+			CGREvent: utils.CGREvent{
+				Tenant: "cgrates.org",
+				ID:     "TestCDRSV1ProcessEventRoutes",
+				Event: map[string]interface{}{
+					utils.RunID:        utils.MetaDefault,
+					utils.OriginID:     utils.GenUUID(),
+					utils.AccountField: "1001",
+					utils.Usage:        time.Minute,
+				},
+				APIOpts: tc.apiOpts,
+			},
+		}
+		if tc.routeID != utils.EmptyString {
+			args.Event[utils.RouteID] = tc.routeID
+		}
+		var reply string
+		if err := cdrs.V1ProcessEvent(args, &reply); err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(tc.exp, feedbacks) {
+			t.Errorf("Expected the feedback for routes %v with flags %v, received: %v", tc.exp, tc.flags, feedbacks)
+		}
+	}
+}
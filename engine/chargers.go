@@ -47,6 +47,13 @@ func (cS *ChargerService) Shutdown() {
 
 // matchingChargingProfilesForEvent returns ordered list of matching chargers which are active by the time of the function call
 func (cS *ChargerService) matchingChargerProfilesForEvent(tnt string, cgrEv *utils.CGREvent) (cPs ChargerProfiles, err error) {
+	return cS.explainedChargerProfilesForEvent(tnt, cgrEv, nil)
+}
+
+// explainedChargerProfilesForEvent is matchingChargerProfilesForEvent appending to prfsExpl, if not nil,
+// how each ChargerProfile was considered for the event
+func (cS *ChargerService) explainedChargerProfilesForEvent(tnt string, cgrEv *utils.CGREvent,
+	prfsExpl *[]*ProfileExplain) (cPs ChargerProfiles, err error) {
 	evNm := utils.MapStorage{
 		utils.MetaReq:  cgrEv.Event,
 		utils.MetaOpts: cgrEv.APIOpts,
@@ -63,7 +70,7 @@ func (cS *ChargerService) matchingChargerProfilesForEvent(tnt string, cgrEv *uti
 		return nil, err
 	}
 	matchingCPs := make(map[string]*ChargerProfile)
-	for cpID := range cpIDs {
+	for _, cpID := range cpIDs.AsOrderedSlice() { // ordered so the explain is consistent
 		cP, err := cS.dm.GetChargerProfile(tnt, cpID, true, true, utils.NonTransactional)
 		if err != nil {
 			if err == utils.ErrNotFound {
@@ -71,16 +78,22 @@ func (cS *ChargerService) matchingChargerProfilesForEvent(tnt string, cgrEv *uti
 			}
 			return nil, err
 		}
+		prfExpl := newProfileExplain(prfsExpl, cP.TenantID())
 		if cP.ActivationInterval != nil && cgrEv.Time != nil &&
 			!cP.ActivationInterval.IsActiveAtTime(*cgrEv.Time) { // not active
+			prfExpl.setReason(utils.ExplainNotActive)
 			continue
 		}
-		if pass, err := cS.filterS.Pass(tnt, cP.FilterIDs,
-			evNm); err != nil {
+		if pass, err := cS.filterS.passExplained(tnt, cP.FilterIDs,
+			evNm, prfExpl); err != nil {
 			return nil, err
 		} else if !pass {
+			prfExpl.setReason(utils.ExplainFiltersNotPassing)
 			continue
 		}
+		if prfExpl != nil {
+			prfExpl.Selected = true // all the matching chargers are forking the event
+		}
 		matchingCPs[cpID] = cP
 	}
 	if len(matchingCPs) == 0 {
@@ -96,17 +109,37 @@ func (cS *ChargerService) matchingChargerProfilesForEvent(tnt string, cgrEv *uti
 	return
 }
 
+// ChargerExplain details the decisions taken by ChargerS when the *explain option is set
+type ChargerExplain struct {
+	Profiles   []*ProfileExplain // all the ChargerProfiles considered for the event
+	Attributes []*AttrRunExplain `json:",omitempty"` // AttributeS decisions for the forked event
+}
+
 // ChrgSProcessEventReply is the reply to processEvent
 type ChrgSProcessEventReply struct {
 	ChargerSProfile    string
 	AttributeSProfiles []string
 	AlteredFields      []string
 	CGREvent           *utils.CGREvent
+	Explain            *ChargerExplain `json:",omitempty"`
 }
 
 func (cS *ChargerService) processEvent(tnt string, cgrEv *utils.CGREvent) (rply []*ChrgSProcessEventReply, err error) {
+	var explain bool
+	if explain, err = utils.GetBoolOpts(cgrEv, false, utils.OptsExplain); err != nil {
+		return
+	}
+	var prfsExpl []*ProfileExplain
+	var prfsExplPtr *[]*ProfileExplain
+	if explain {
+		prfsExplPtr = &prfsExpl
+	}
 	var cPs ChargerProfiles
-	if cPs, err = cS.matchingChargerProfilesForEvent(tnt, cgrEv); err != nil {
+	if cPs, err = cS.explainedChargerProfilesForEvent(tnt, cgrEv, prfsExplPtr); err != nil {
+		if err == utils.ErrNotFound && explain { // no reply is sent back with the error so log the reasons of not matching
+			utils.Logger.Info(fmt.Sprintf("<%s> no profile matching event <%s>, explain: %s",
+				utils.ChargerS, cgrEv.ID, utils.ToJSON(prfsExpl)))
+		}
 		return nil, err
 	}
 	rply = make([]*ChrgSProcessEventReply, len(cPs))
@@ -120,6 +153,9 @@ func (cS *ChargerService) processEvent(tnt string, cgrEv *utils.CGREvent) (rply
 			CGREvent:        clonedEv,
 			AlteredFields:   []string{utils.MetaReqRunID},
 		}
+		if explain {
+			rply[i].Explain = &ChargerExplain{Profiles: prfsExpl}
+		}
 		if len(cP.AttributeIDs) == 1 && cP.AttributeIDs[0] == utils.MetaNone {
 			continue // AttributeS disabled
 		}
@@ -137,6 +173,9 @@ func (cS *ChargerService) processEvent(tnt string, cgrEv *utils.CGREvent) (rply
 			err = nil
 		}
 		rply[i].AttributeSProfiles = evReply.MatchedProfiles
+		if explain {
+			rply[i].Explain.Attributes = evReply.Explain
+		}
 		if len(evReply.AlteredFields) != 0 {
 			rply[i].AlteredFields = append(rply[i].AlteredFields, evReply.AlteredFields...)
 			rply[i].CGREvent = evReply.CGREvent
@@ -95,6 +95,7 @@ type DataManager struct {
 	cacheCfg *config.CacheCfg
 	connMgr  *ConnManager
 	ms       Marshaler
+	audit    *auditInfo // not nil for the DataManagers recording the changes into the audit log
 }
 
 // DataDB exports access to dataDB
@@ -385,9 +386,11 @@ func (dm *DataManager) SetDestination(dest *Destination, transactionID string) (
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.DestinationPrefix, dest.Id)
 	if err = dm.dataDB.SetDestinationDrv(dest, transactionID); err != nil {
 		return
 	}
+	ac.Record()
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDestinations]; itm.Replicate {
 		err = replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -413,9 +416,11 @@ func (dm *DataManager) RemoveDestination(destID string, transactionID string) (e
 		return
 	}
 
+	ac := dm.NewAuditChange(utils.DestinationPrefix, destID)
 	if err = dm.dataDB.RemoveDestinationDrv(destID, transactionID); err != nil {
 		return
 	}
+	ac.Record()
 	if err = Cache.Remove(utils.CacheDestinations, destID,
 		cacheCommit(transactionID), transactionID); err != nil {
 		return
@@ -588,9 +593,24 @@ func (dm *DataManager) SetAccount(acc *Account) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	if err = dm.checkObjectsQuota(utils.AccountPrefix, acc.ID); err != nil {
+		return
+	}
+	return dm.setAccount(acc)
+}
+
+// setAccount stores the account without checking the objects quota
+// used for the runtime updates (e.g. debits) of the existing accounts
+func (dm *DataManager) setAccount(acc *Account) (err error) {
+	if dm == nil {
+		return utils.ErrNoDatabaseConn
+	}
+	ac := dm.NewAuditChange(utils.AccountPrefix, acc.ID)
 	if err = dm.dataDB.SetAccountDrv(acc); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectStored(utils.AccountPrefix, acc.ID)
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAccounts]; itm.Replicate {
 		err = replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -608,9 +628,12 @@ func (dm *DataManager) RemoveAccount(id string) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.AccountPrefix, id)
 	if err = dm.dataDB.RemoveAccountDrv(id); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectRemoved(utils.AccountPrefix, id)
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaAccounts]; itm.Replicate {
 		replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -694,9 +717,15 @@ func (dm *DataManager) SetFilter(fltr *Filter, withIndex bool) (err error) {
 		utils.NonTransactional); err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	if err = dm.checkObjectsQuota(utils.FilterPrefix, fltr.TenantID()); err != nil {
+		return
+	}
+	ac := dm.NewAuditChange(utils.FilterPrefix, fltr.TenantID())
 	if err = dm.DataDB().SetFilterDrv(fltr); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectStored(utils.FilterPrefix, fltr.TenantID())
 	if withIndex {
 		if err = UpdateFilterIndex(dm, oldFlt, fltr); err != nil {
 			return
@@ -739,9 +768,12 @@ func (dm *DataManager) RemoveFilter(tenant, id string, withIndex bool) (err erro
 				tntCtx, utils.ToJSON(rcvIndx))
 		}
 	}
+	ac := dm.NewAuditChange(utils.FilterPrefix, utils.ConcatenatedKey(tenant, id))
 	if err = dm.DataDB().RemoveFilterDrv(tenant, id); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectRemoved(utils.FilterPrefix, utils.ConcatenatedKey(tenant, id))
 	if oldFlt == nil {
 		return utils.ErrNotFound
 	}
@@ -910,9 +942,15 @@ func (dm *DataManager) SetThresholdProfile(th *ThresholdProfile, withIndex bool)
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	if err = dm.checkObjectsQuota(utils.ThresholdProfilePrefix, th.TenantID()); err != nil {
+		return
+	}
+	ac := dm.NewAuditChange(utils.ThresholdProfilePrefix, th.TenantID())
 	if err = dm.DataDB().SetThresholdProfileDrv(th); err != nil {
 		return err
 	}
+	ac.Record()
+	tenantObjectStored(utils.ThresholdProfilePrefix, th.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldTh != nil {
@@ -964,9 +1002,12 @@ func (dm *DataManager) RemoveThresholdProfile(tenant, id string, withIndex bool)
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	ac := dm.NewAuditChange(utils.ThresholdProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if err = dm.DataDB().RemThresholdProfileDrv(tenant, id); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectRemoved(utils.ThresholdProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if oldTh == nil {
 		return utils.ErrNotFound
 	}
@@ -1158,14 +1199,26 @@ func (dm *DataManager) SetStatQueueProfile(sqp *StatQueueProfile, withIndex bool
 			return fmt.Errorf("%+s for item with ID: %+v",
 				err, sqp.TenantID())
 		}
+		for _, grpBy := range sqp.GroupBy {
+			if _, err = config.NewRSRParser(grpBy); err != nil {
+				return fmt.Errorf("invalid GroupBy <%s> for item with ID: %+v",
+					grpBy, sqp.TenantID())
+			}
+		}
 	}
 	oldSts, err := dm.GetStatQueueProfile(sqp.Tenant, sqp.ID, true, false, utils.NonTransactional)
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	if err = dm.checkObjectsQuota(utils.StatQueueProfilePrefix, sqp.TenantID()); err != nil {
+		return
+	}
+	ac := dm.NewAuditChange(utils.StatQueueProfilePrefix, sqp.TenantID())
 	if err = dm.DataDB().SetStatQueueProfileDrv(sqp); err != nil {
 		return err
 	}
+	ac.Record()
+	tenantObjectStored(utils.StatQueueProfilePrefix, sqp.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldSts != nil {
@@ -1249,9 +1302,12 @@ func (dm *DataManager) RemoveStatQueueProfile(tenant, id string, withIndex bool)
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	ac := dm.NewAuditChange(utils.StatQueueProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if err = dm.DataDB().RemStatQueueProfileDrv(tenant, id); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectRemoved(utils.StatQueueProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if oldSts == nil {
 		return utils.ErrNotFound
 	}
@@ -1328,9 +1384,11 @@ func (dm *DataManager) SetTiming(t *utils.TPTiming) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.TimingsPrefix, t.ID)
 	if err = dm.DataDB().SetTimingDrv(t); err != nil {
 		return
 	}
+	ac.Record()
 	if err = dm.CacheDataFromDB(utils.TimingsPrefix, []string{t.ID}, true); err != nil {
 		return
 	}
@@ -1351,9 +1409,11 @@ func (dm *DataManager) RemoveTiming(id, transactionID string) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.TimingsPrefix, id)
 	if err = dm.DataDB().RemoveTimingDrv(id); err != nil {
 		return
 	}
+	ac.Record()
 	if errCh := Cache.Remove(utils.CacheTimings, id,
 		cacheCommit(transactionID), transactionID); errCh != nil {
 		return errCh
@@ -1522,9 +1582,15 @@ func (dm *DataManager) SetResourceProfile(rp *ResourceProfile, withIndex bool) (
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	if err = dm.checkObjectsQuota(utils.ResourceProfilesPrefix, rp.TenantID()); err != nil {
+		return
+	}
+	ac := dm.NewAuditChange(utils.ResourceProfilesPrefix, rp.TenantID())
 	if err = dm.DataDB().SetResourceProfileDrv(rp); err != nil {
 		return err
 	}
+	ac.Record()
+	tenantObjectStored(utils.ResourceProfilesPrefix, rp.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldRes != nil {
@@ -1551,6 +1617,8 @@ func (dm *DataManager) SetResourceProfile(rp *ResourceProfile, withIndex bool) (
 	if oldRes == nil || // create the resource if it didn't exist before
 		oldRes.UsageTTL != rp.UsageTTL ||
 		oldRes.Limit != rp.Limit ||
+		oldRes.Rate != rp.Rate ||
+		oldRes.RateInterval != rp.RateInterval ||
 		(oldRes.Stored != rp.Stored && oldRes.Stored) { // reset the resource if the profile changed this fields
 		err = dm.SetResource(&Resource{
 			Tenant: rp.Tenant,
@@ -1576,9 +1644,12 @@ func (dm *DataManager) RemoveResourceProfile(tenant, id string, withIndex bool)
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	ac := dm.NewAuditChange(utils.ResourceProfilesPrefix, utils.ConcatenatedKey(tenant, id))
 	if err = dm.DataDB().RemoveResourceProfileDrv(tenant, id); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectRemoved(utils.ResourceProfilesPrefix, utils.ConcatenatedKey(tenant, id))
 	if oldRes == nil {
 		return utils.ErrNotFound
 	}
@@ -1654,9 +1725,11 @@ func (dm *DataManager) RemoveActionTriggers(id, transactionID string) (err error
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.ActionTriggerPrefix, id)
 	if err = dm.DataDB().RemoveActionTriggersDrv(id); err != nil {
 		return
 	}
+	ac.Record()
 	if err = Cache.Remove(utils.CacheActionTriggers, id,
 		cacheCommit(transactionID), transactionID); err != nil {
 		return
@@ -1687,9 +1760,11 @@ func (dm *DataManager) SetActionTriggers(key string, attr ActionTriggers) (err e
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.ActionTriggerPrefix, key)
 	if err = dm.DataDB().SetActionTriggersDrv(key, attr); err != nil {
 		return
 	}
+	ac.Record()
 	if err = dm.CacheDataFromDB(utils.ActionTriggerPrefix, []string{key}, true); err != nil {
 		return
 	}
@@ -1758,9 +1833,11 @@ func (dm *DataManager) SetSharedGroup(sg *SharedGroup) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.SharedGroupPrefix, sg.Id)
 	if err = dm.DataDB().SetSharedGroupDrv(sg); err != nil {
 		return
 	}
+	ac.Record()
 	if err = dm.CacheDataFromDB(utils.SharedGroupPrefix,
 		[]string{sg.Id}, true); err != nil {
 		return
@@ -1783,9 +1860,11 @@ func (dm *DataManager) RemoveSharedGroup(id, transactionID string) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.SharedGroupPrefix, id)
 	if err = dm.DataDB().RemoveSharedGroupDrv(id); err != nil {
 		return
 	}
+	ac.Record()
 	if errCh := Cache.Remove(utils.CacheSharedGroups, id,
 		cacheCommit(transactionID), transactionID); errCh != nil {
 		return errCh
@@ -1864,9 +1943,11 @@ func (dm *DataManager) SetActions(key string, as Actions) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.ActionPrefix, key)
 	if err = dm.DataDB().SetActionsDrv(key, as); err != nil {
 		return
 	}
+	ac.Record()
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaActions]; itm.Replicate {
 		err = replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -1886,9 +1967,11 @@ func (dm *DataManager) RemoveActions(key string) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.ActionPrefix, key)
 	if err = dm.DataDB().RemoveActionsDrv(key); err != nil {
 		return
 	}
+	ac.Record()
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaActions]; itm.Replicate {
 		replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -1981,9 +2064,11 @@ func (dm *DataManager) SetActionPlan(key string, ats *ActionPlan,
 		}
 	}
 
+	ac := dm.NewAuditChange(utils.ActionPlanPrefix, key)
 	if err = dm.dataDB.SetActionPlanDrv(key, ats); err != nil {
 		return
 	}
+	ac.Record()
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaActionPlans]; itm.Replicate {
 		err = replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -2027,9 +2112,11 @@ func (dm *DataManager) RemoveActionPlan(key string, transactionID string) (err e
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.ActionPlanPrefix, key)
 	if err = dm.dataDB.RemoveActionPlanDrv(key); err != nil {
 		return
 	}
+	ac.Record()
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaActionPlans]; itm.Replicate {
 		replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -2228,9 +2315,11 @@ func (dm *DataManager) SetRatingPlan(rp *RatingPlan) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.RatingPlanPrefix, rp.Id)
 	if err = dm.DataDB().SetRatingPlanDrv(rp); err != nil {
 		return
 	}
+	ac.Record()
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRatingPlans]; itm.Replicate {
 		err = replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -2249,9 +2338,11 @@ func (dm *DataManager) RemoveRatingPlan(key string, transactionID string) (err e
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.RatingPlanPrefix, key)
 	if err = dm.DataDB().RemoveRatingPlanDrv(key); err != nil {
 		return
 	}
+	ac.Record()
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRatingPlans]; itm.Replicate {
 		replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -2320,9 +2411,11 @@ func (dm *DataManager) SetRatingProfile(rpf *RatingProfile) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.RatingProfilePrefix, rpf.Id)
 	if err = dm.DataDB().SetRatingProfileDrv(rpf); err != nil {
 		return
 	}
+	ac.Record()
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRatingProfiles]; itm.Replicate {
 		err = replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -2341,9 +2434,11 @@ func (dm *DataManager) RemoveRatingProfile(key string) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	ac := dm.NewAuditChange(utils.RatingProfilePrefix, key)
 	if err = dm.DataDB().RemoveRatingProfileDrv(key); err != nil {
 		return
 	}
+	ac.Record()
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaRatingProfiles]; itm.Replicate {
 		replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -2434,9 +2529,15 @@ func (dm *DataManager) SetRouteProfile(rpp *RouteProfile, withIndex bool) (err e
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	if err = dm.checkObjectsQuota(utils.RouteProfilePrefix, rpp.TenantID()); err != nil {
+		return
+	}
+	ac := dm.NewAuditChange(utils.RouteProfilePrefix, rpp.TenantID())
 	if err = dm.DataDB().SetRouteProfileDrv(rpp); err != nil {
 		return err
 	}
+	ac.Record()
+	tenantObjectStored(utils.RouteProfilePrefix, rpp.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldRpp != nil {
@@ -2468,9 +2569,12 @@ func (dm *DataManager) RemoveRouteProfile(tenant, id string, withIndex bool) (er
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	ac := dm.NewAuditChange(utils.RouteProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if err = dm.DataDB().RemoveRouteProfileDrv(tenant, id); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectRemoved(utils.RouteProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if oldRpp == nil {
 		return utils.ErrNotFound
 	}
@@ -2568,9 +2672,15 @@ func (dm *DataManager) SetAttributeProfile(ap *AttributeProfile, withIndex bool)
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	if err = dm.checkObjectsQuota(utils.AttributeProfilePrefix, ap.TenantID()); err != nil {
+		return
+	}
+	ac := dm.NewAuditChange(utils.AttributeProfilePrefix, ap.TenantID())
 	if err = dm.DataDB().SetAttributeProfileDrv(ap); err != nil {
 		return err
 	}
+	ac.Record()
+	tenantObjectStored(utils.AttributeProfilePrefix, ap.TenantID())
 	if withIndex {
 		var oldContexes *[]string
 		var oldFiltersIDs *[]string
@@ -2604,9 +2714,12 @@ func (dm *DataManager) RemoveAttributeProfile(tenant, id string, withIndex bool)
 	if err != nil {
 		return err
 	}
+	ac := dm.NewAuditChange(utils.AttributeProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if err = dm.DataDB().RemoveAttributeProfileDrv(tenant, id); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectRemoved(utils.AttributeProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if oldAttr == nil {
 		return utils.ErrNotFound
 	}
@@ -2699,9 +2812,15 @@ func (dm *DataManager) SetChargerProfile(cpp *ChargerProfile, withIndex bool) (e
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	if err = dm.checkObjectsQuota(utils.ChargerProfilePrefix, cpp.TenantID()); err != nil {
+		return
+	}
+	ac := dm.NewAuditChange(utils.ChargerProfilePrefix, cpp.TenantID())
 	if err = dm.DataDB().SetChargerProfileDrv(cpp); err != nil {
 		return err
 	}
+	ac.Record()
+	tenantObjectStored(utils.ChargerProfilePrefix, cpp.TenantID())
 	if withIndex {
 		var oldFiltersIDs *[]string
 		if oldCpp != nil {
@@ -2733,9 +2852,12 @@ func (dm *DataManager) RemoveChargerProfile(tenant, id string, withIndex bool) (
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	ac := dm.NewAuditChange(utils.ChargerProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if err = dm.DataDB().RemoveChargerProfileDrv(tenant, id); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectRemoved(utils.ChargerProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if oldCpp == nil {
 		return utils.ErrNotFound
 	}
@@ -2826,9 +2948,15 @@ func (dm *DataManager) SetDispatcherProfile(dpp *DispatcherProfile, withIndex bo
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	if err = dm.checkObjectsQuota(utils.DispatcherProfilePrefix, dpp.TenantID()); err != nil {
+		return
+	}
+	ac := dm.NewAuditChange(utils.DispatcherProfilePrefix, dpp.TenantID())
 	if err = dm.DataDB().SetDispatcherProfileDrv(dpp); err != nil {
 		return err
 	}
+	ac.Record()
+	tenantObjectStored(utils.DispatcherProfilePrefix, dpp.TenantID())
 	if withIndex {
 		var oldContexes *[]string
 		var oldFiltersIDs *[]string
@@ -2862,9 +2990,12 @@ func (dm *DataManager) RemoveDispatcherProfile(tenant, id string, withIndex bool
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	ac := dm.NewAuditChange(utils.DispatcherProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if err = dm.DataDB().RemoveDispatcherProfileDrv(tenant, id); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectRemoved(utils.DispatcherProfilePrefix, utils.ConcatenatedKey(tenant, id))
 	if oldDpp == nil {
 		return utils.ErrNotFound
 	}
@@ -2946,9 +3077,15 @@ func (dm *DataManager) SetDispatcherHost(dpp *DispatcherHost) (err error) {
 	if dm == nil {
 		return utils.ErrNoDatabaseConn
 	}
+	if err = dm.checkObjectsQuota(utils.DispatcherHostPrefix, dpp.TenantID()); err != nil {
+		return
+	}
+	ac := dm.NewAuditChange(utils.DispatcherHostPrefix, dpp.TenantID())
 	if err = dm.DataDB().SetDispatcherHostDrv(dpp); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectStored(utils.DispatcherHostPrefix, dpp.TenantID())
 	if itm := config.CgrConfig().DataDbCfg().Items[utils.MetaDispatcherHosts]; itm.Replicate {
 		err = replicate(dm.connMgr, config.CgrConfig().DataDbCfg().RplConns,
 			config.CgrConfig().DataDbCfg().RplFiltered,
@@ -2970,9 +3107,12 @@ func (dm *DataManager) RemoveDispatcherHost(tenant, id string) (err error) {
 	if err != nil && err != utils.ErrNotFound {
 		return err
 	}
+	ac := dm.NewAuditChange(utils.DispatcherHostPrefix, utils.ConcatenatedKey(tenant, id))
 	if err = dm.DataDB().RemoveDispatcherHostDrv(tenant, id); err != nil {
 		return
 	}
+	ac.Record()
+	tenantObjectRemoved(utils.DispatcherHostPrefix, utils.ConcatenatedKey(tenant, id))
 	if oldDpp == nil {
 		return utils.ErrNotFound
 	}
@@ -3062,8 +3202,7 @@ func (dm *DataManager) SetLoadIDs(loadIDs map[string]int64) (err error) {
 
 // Reconnect reconnects to the DB when the config was changed
 func (dm *DataManager) Reconnect(marshaller string, newcfg *config.DataDbCfg, itmsCfg map[string]*config.ItemOpt) (err error) {
-	d, err := NewDataDBConn(newcfg.Type, newcfg.Host, newcfg.Port, newcfg.Name,
-		newcfg.User, newcfg.Password, marshaller, newcfg.Opts, itmsCfg)
+	d, err := NewDataDBFromCfg(newcfg, marshaller, itmsCfg)
 	if err != nil {
 		return
 	}
@@ -229,19 +229,19 @@ var supportedFiltersType utils.StringSet = utils.NewStringSet([]string{
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessThan, utils.MetaLessOrEqual,
 	utils.MetaGreaterThan, utils.MetaGreaterOrEqual, utils.MetaEqual,
 	utils.MetaIPNet, utils.MetaAPIBan, utils.MetaActivationInterval,
-	utils.MetaRegex})
+	utils.MetaRegex, utils.MetaGeo, utils.MetaIPGeo})
 var needsFieldName utils.StringSet = utils.NewStringSet([]string{
 	utils.MetaString, utils.MetaPrefix, utils.MetaSuffix,
 	utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations, utils.MetaLessThan,
 	utils.MetaEmpty, utils.MetaExists, utils.MetaLessOrEqual, utils.MetaGreaterThan,
 	utils.MetaGreaterOrEqual, utils.MetaEqual, utils.MetaIPNet, utils.MetaAPIBan,
 	utils.MetaActivationInterval,
-	utils.MetaRegex})
+	utils.MetaRegex, utils.MetaGeo, utils.MetaIPGeo})
 var needsValues utils.StringSet = utils.NewStringSet([]string{utils.MetaString, utils.MetaPrefix,
 	utils.MetaSuffix, utils.MetaTimings, utils.MetaRSR, utils.MetaDestinations,
 	utils.MetaLessThan, utils.MetaLessOrEqual, utils.MetaGreaterThan, utils.MetaGreaterOrEqual,
 	utils.MetaEqual, utils.MetaIPNet, utils.MetaAPIBan, utils.MetaActivationInterval,
-	utils.MetaRegex})
+	utils.MetaRegex, utils.MetaGeo, utils.MetaIPGeo})
 
 // NewFilterRule returns a new filter
 func NewFilterRule(rfType, fieldName string, vals []string) (*FilterRule, error) {
@@ -282,6 +282,8 @@ type FilterRule struct {
 	rsrElement  *config.RSRParser // Cache here the
 	rsrFilters  utils.RSRFilters  // Cache here the RSRFilter Values
 	regexValues []*regexp.Regexp
+	geoAreas    []*geoArea        // Cache here the *geo Values
+	rsrGeoElem  config.RSRParsers // latitude and longitude of *geo when found in separate fields
 	negative    *bool
 }
 
@@ -300,6 +302,18 @@ func (fltr *FilterRule) CompileValues() (err error) {
 			return
 		}
 	case utils.MetaExists, utils.MetaNotExists, utils.MetaEmpty, utils.MetaNotEmpty: // only the element is builded
+	case utils.MetaGeo, utils.MetaNotGeo:
+		fltr.geoAreas = make([]*geoArea, len(fltr.Values))
+		for i, val := range fltr.Values {
+			if fltr.geoAreas[i], err = newGeoArea(val); err != nil {
+				return
+			}
+		}
+		if elems := strings.Split(fltr.Element, utils.ANDSep); len(elems) == 2 {
+			if fltr.rsrGeoElem, err = config.NewRSRParsersFromSlice(elems); err != nil {
+				return
+			}
+		}
 	case utils.MetaActivationInterval, utils.MetaNotActivationInterval:
 		fltr.rsrValues = make(config.RSRParsers, len(fltr.Values))
 		for i, strVal := range fltr.Values {
@@ -355,6 +369,10 @@ func (fltr *FilterRule) Pass(dDP utils.DataProvider) (result bool, err error) {
 		result, err = fltr.passActivationInterval(dDP)
 	case utils.MetaRegex, utils.MetaNotRegex:
 		result, err = fltr.passRegex(dDP)
+	case utils.MetaGeo, utils.MetaNotGeo:
+		result, err = fltr.passGeo(dDP)
+	case utils.MetaIPGeo, utils.MetaNotIPGeo:
+		result, err = fltr.passIPGeo(dDP)
 	default:
 		err = utils.ErrPrefixNotErrNotImplemented(fltr.Type)
 	}
@@ -646,6 +664,75 @@ func (fltr *FilterRule) passAPIBan(dDP utils.DataProvider) (bool, error) {
 	return dm.GetAPIBan(strVal, config.CgrConfig().APIBanCfg().Keys, fltr.Values[0] != utils.MetaAll, true, true)
 }
 
+// passGeo checks if the location is within one of the areas
+// the location is either one field in "lat,long" format or two fields joined by &
+func (fltr *FilterRule) passGeo(dDP utils.DataProvider) (bool, error) {
+	var lat, long string
+	var err error
+	if fltr.rsrGeoElem != nil {
+		if lat, err = fltr.rsrGeoElem[0].ParseDataProvider(dDP); err == nil {
+			long, err = fltr.rsrGeoElem[1].ParseDataProvider(dDP)
+		}
+	} else {
+		var strVal string
+		if strVal, err = fltr.rsrElement.ParseDataProvider(dDP); err == nil {
+			coords := strings.Split(strVal, utils.FieldsSep)
+			if len(coords) != 2 {
+				return false, nil
+			}
+			lat, long = coords[0], coords[1]
+		}
+	}
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	gp, err := newGeoPoint(lat, long)
+	if err != nil {
+		return false, nil
+	}
+	for _, area := range fltr.geoAreas {
+		if area.contains(gp) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
+// passIPGeo checks the country of the IP, located via the GeoIP database, against the ISO codes
+func (fltr *FilterRule) passIPGeo(dDP utils.DataProvider) (bool, error) {
+	strVal, err := fltr.rsrElement.ParseDataProvider(dDP)
+	if err != nil {
+		if err == utils.ErrNotFound {
+			return false, nil
+		}
+		return false, err
+	}
+	ip := net.ParseIP(strVal)
+	if ip == nil {
+		return false, nil
+	}
+	iso, err := geoIPDB.countryISO(config.CgrConfig().FilterSCfg().GeoIPDB, ip)
+	if err != nil {
+		return false, err
+	}
+	if iso == utils.EmptyString {
+		return false, nil
+	}
+	for _, val := range fltr.rsrValues {
+		sval, err := val.ParseDataProvider(dDP)
+		if err != nil {
+			continue
+		}
+		if strings.EqualFold(iso, sval) {
+			return true, nil
+		}
+	}
+	return false, nil
+}
+
 func parseTime(rsr *config.RSRParser, dDp utils.DataProvider) (_ time.Time, err error) {
 	var str string
 	if str, err = rsr.ParseDataProvider(dDp); err != nil {
@@ -0,0 +1,577 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// maxFraudDestinations limits the destinations tracked per *new_destination rule within one account,
+// the least recently seen one being dropped when a new destination is seen
+const maxFraudDestinations = 1000
+
+// NewFraudService is the constructor for FraudS
+func NewFraudService(cgrcfg *config.CGRConfig, filterS *FilterS, connMgr *ConnManager) *FraudService {
+	return &FraudService{
+		cgrcfg:   cgrcfg,
+		filterS:  filterS,
+		connMgr:  connMgr,
+		accounts: make(map[string]*fraudAccount),
+	}
+}
+
+// FraudService tracks the spending of the accounts in memory, evaluating the fraud rules on each event
+type FraudService struct {
+	cgrcfg   *config.CGRConfig
+	filterS  *FilterS
+	connMgr  *ConnManager
+	mux      sync.Mutex               // protects accounts
+	accounts map[string]*fraudAccount // indexed on tenant:account
+}
+
+// fraudAccount holds the fraud tracking data of one account
+type fraudAccount struct {
+	blockedUntil time.Time
+	blockedBy    string                   // ID of the rule which blocked the account
+	trackers     map[string]*fraudTracker // indexed on ruleID:group
+}
+
+// fraudEvent is one event tracked by the *velocity rules
+type fraudEvent struct {
+	time time.Time
+	cost float64
+}
+
+// fraudTracker holds the data of one rule and group within an account
+type fraudTracker struct {
+	rule     *config.FraudRuleCfg
+	events   []*fraudEvent        // *velocity: events within the window
+	lastSeen time.Time            // *new_destination: last time the group was seen
+	calls    map[string]time.Time // *concurrent_calls: start time of the active calls indexed on OriginID
+}
+
+// prune removes the data outside the window of the rule and the calls lasting more than maxCallDur
+func (ft *fraudTracker) prune(rule *config.FraudRuleCfg, maxCallDur time.Duration, now time.Time) {
+	if maxCallDur > 0 { // the calls never released (ie: lost CDRs) do not count forever
+		for originID, start := range ft.calls {
+			if now.Sub(start) > maxCallDur {
+				delete(ft.calls, originID)
+			}
+		}
+	}
+	if rule.Window > 0 {
+		var i int
+		for i < len(ft.events) && now.Sub(ft.events[i].time) > rule.Window {
+			i++
+		}
+		ft.events = ft.events[i:]
+		if !ft.lastSeen.IsZero() && now.Sub(ft.lastSeen) > rule.Window {
+			ft.lastSeen = time.Time{}
+		}
+	}
+}
+
+// isEmpty returns true if nothing is tracked anymore
+func (ft *fraudTracker) isEmpty() bool {
+	return len(ft.events) == 0 && ft.lastSeen.IsZero() && len(ft.calls) == 0
+}
+
+// fraudMatch is a rule matching an event, together with the group of the event within the rule
+type fraudMatch struct {
+	rule  *config.FraudRuleCfg
+	group string
+}
+
+func (fm *fraudMatch) trackerID() string {
+	return utils.ConcatenatedKey(fm.rule.ID, fm.group)
+}
+
+// fraudTrigger is a rule triggered by an event
+type fraudTrigger struct {
+	*fraudMatch
+	cost  float64 // cost within the window for *velocity
+	calls int     // calls within the window for *velocity or active calls for *concurrent_calls
+}
+
+// FraudBlock describes a blocked account
+type FraudBlock struct {
+	Tenant       string
+	Account      string
+	RuleID       string
+	BlockedUntil time.Time
+}
+
+// matchingRules returns the rules of the given types matching the event
+func (fS *FraudService) matchingRules(ev *utils.CGREvent, ruleTypes utils.StringSet) (matches []*fraudMatch, err error) {
+	evNm := utils.MapStorage{
+		utils.MetaReq:  ev.Event,
+		utils.MetaOpts: ev.APIOpts,
+	}
+	for _, rule := range fS.cgrcfg.FraudSCfg().Rules {
+		if !ruleTypes.Has(rule.Type) {
+			continue
+		}
+		var pass bool
+		if pass, err = fS.filterS.Pass(ev.Tenant, rule.FilterIDs, evNm); err != nil {
+			return
+		} else if !pass {
+			continue
+		}
+		var group string
+		if group, err = rule.Group.ParseDataProvider(evNm); err != nil {
+			if err != utils.ErrNotFound {
+				return
+			}
+			err = nil // the event cannot be grouped within this rule
+			continue
+		}
+		matches = append(matches, &fraudMatch{rule: rule, group: group})
+	}
+	return
+}
+
+// eventAccount returns the tenant:account of the event, populating the tenant if missing
+func (fS *FraudService) eventAccount(ev *utils.CGREvent) (tntAcnt string, err error) {
+	if ev == nil {
+		return utils.EmptyString, utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	if ev.Event == nil {
+		return utils.EmptyString, utils.NewErrMandatoryIeMissing(utils.Event)
+	}
+	acnt := utils.IfaceAsString(ev.Event[utils.AccountField])
+	if acnt == utils.EmptyString {
+		return utils.EmptyString, utils.NewErrMandatoryIeMissing(utils.AccountField)
+	}
+	if ev.Tenant == utils.EmptyString {
+		ev.Tenant = fS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	return utils.ConcatenatedKey(ev.Tenant, acnt), nil
+}
+
+// account returns the tracking data of the account, creating it if missing
+// needs to be called under lock
+func (fS *FraudService) account(tntAcnt string) (fa *fraudAccount) {
+	var has bool
+	if fa, has = fS.accounts[tntAcnt]; !has {
+		fa = &fraudAccount{trackers: make(map[string]*fraudTracker)}
+		fS.accounts[tntAcnt] = fa
+	}
+	return
+}
+
+// tracker returns the tracker of the rule match within the account, creating it if missing
+// the expired data is removed out of the tracker
+// needs to be called under lock
+func (fa *fraudAccount) tracker(fm *fraudMatch, maxCallDur time.Duration, now time.Time) (ft *fraudTracker) {
+	trkID := fm.trackerID()
+	var has bool
+	if ft, has = fa.trackers[trkID]; !has {
+		if fm.rule.Type == utils.MetaNewDestination {
+			fa.limitDestinations(fm.rule)
+		}
+		ft = &fraudTracker{rule: fm.rule, calls: make(map[string]time.Time)}
+		fa.trackers[trkID] = ft
+	}
+	ft.prune(fm.rule, maxCallDur, now)
+	return
+}
+
+// limitDestinations drops the least recently seen destination of the rule
+// if the account reached maxFraudDestinations for it
+// needs to be called under lock
+func (fa *fraudAccount) limitDestinations(rule *config.FraudRuleCfg) {
+	var nrDsts int
+	var oldestID string
+	var oldest time.Time
+	for trkID, ft := range fa.trackers {
+		if ft.rule.ID != rule.ID {
+			continue
+		}
+		nrDsts++
+		if oldestID == utils.EmptyString || ft.lastSeen.Before(oldest) {
+			oldestID, oldest = trkID, ft.lastSeen
+		}
+	}
+	if nrDsts >= maxFraudDestinations {
+		delete(fa.trackers, oldestID)
+	}
+}
+
+// isBlocked returns true if the account is blocked at the given time
+func (fa *fraudAccount) isBlocked(now time.Time) bool {
+	return now.Before(fa.blockedUntil)
+}
+
+// block will block the account for the period of the rule
+func (fa *fraudAccount) block(rule *config.FraudRuleCfg, now time.Time) {
+	if rule.BlockPeriod <= 0 {
+		return
+	}
+	if until := now.Add(rule.BlockPeriod); until.After(fa.blockedUntil) {
+		fa.blockedUntil = until
+		fa.blockedBy = rule.ID
+	}
+}
+
+// cleanup removes the expired data of the account and the account itself if nothing is tracked anymore
+// needs to be called under lock
+func (fS *FraudService) cleanup(tntAcnt string, now time.Time) {
+	fa, has := fS.accounts[tntAcnt]
+	if !has {
+		return
+	}
+	for trkID, ft := range fa.trackers {
+		ft.prune(ft.rule, fS.cgrcfg.FraudSCfg().MaxCallDuration, now) // the trackers not matched by the event expire as well
+		if ft.isEmpty() {
+			delete(fa.trackers, trkID)
+		}
+	}
+	if len(fa.trackers) == 0 && !fa.isBlocked(now) {
+		delete(fS.accounts, tntAcnt)
+	}
+}
+
+// seenDestination marks the group as seen, returning true if it was not seen within the window
+func (ft *fraudTracker) seenDestination(now time.Time) (isNew bool) {
+	isNew = ft.lastSeen.IsZero()
+	ft.lastSeen = now
+	return
+}
+
+// authorizeEvent checks if the account is blocked or it reached the limit of concurrent calls
+func (fS *FraudService) authorizeEvent(ev *utils.CGREvent, now time.Time) (err error) {
+	var tntAcnt string
+	if tntAcnt, err = fS.eventAccount(ev); err != nil {
+		return
+	}
+	var matches []*fraudMatch
+	if matches, err = fS.matchingRules(ev, utils.NewStringSet([]string{utils.MetaConcurrentCalls})); err != nil {
+		return
+	}
+	fS.mux.Lock()
+	defer fS.mux.Unlock()
+	fa, has := fS.accounts[tntAcnt]
+	if !has {
+		return
+	}
+	if fa.isBlocked(now) {
+		return utils.ErrFraudBlocked
+	}
+	for _, fm := range matches {
+		ft, has := fa.trackers[fm.trackerID()]
+		if !has {
+			continue
+		}
+		ft.prune(fm.rule, fS.cgrcfg.FraudSCfg().MaxCallDuration, now)
+		if len(ft.calls) >= fm.rule.MaxConcurrent {
+			return utils.ErrFraudBlocked
+		}
+	}
+	return
+}
+
+// allocateCall tracks a new call of the account, evaluating the *concurrent_calls and *new_destination rules
+func (fS *FraudService) allocateCall(ev *utils.CGREvent, now time.Time) (triggers []*fraudTrigger, err error) {
+	var tntAcnt string
+	if tntAcnt, err = fS.eventAccount(ev); err != nil {
+		return
+	}
+	originID := utils.IfaceAsString(ev.Event[utils.OriginID])
+	if originID == utils.EmptyString {
+		return nil, utils.NewErrMandatoryIeMissing(utils.OriginID)
+	}
+	var matches []*fraudMatch
+	if matches, err = fS.matchingRules(ev,
+		utils.NewStringSet([]string{utils.MetaConcurrentCalls, utils.MetaNewDestination})); err != nil {
+		return
+	}
+	fS.mux.Lock()
+	defer fS.mux.Unlock()
+	fa := fS.account(tntAcnt)
+	if fa.isBlocked(now) {
+		return nil, utils.ErrFraudBlocked
+	}
+	for _, fm := range matches {
+		ft := fa.tracker(fm, fS.cgrcfg.FraudSCfg().MaxCallDuration, now)
+		switch fm.rule.Type {
+		case utils.MetaConcurrentCalls:
+			ft.calls[originID] = now
+			if len(ft.calls) > fm.rule.MaxConcurrent {
+				triggers = append(triggers, &fraudTrigger{fraudMatch: fm, calls: len(ft.calls)})
+			}
+		case utils.MetaNewDestination:
+			if ft.seenDestination(now) {
+				triggers = append(triggers, &fraudTrigger{fraudMatch: fm})
+			}
+		}
+	}
+	for _, trg := range triggers {
+		fa.block(trg.rule, now)
+	}
+	if fa.isBlocked(now) { // the call is refused so do not count it
+		fS.releaseCall(tntAcnt, originID)
+		err = utils.ErrFraudBlocked
+	}
+	fS.cleanup(tntAcnt, now)
+	return
+}
+
+// releaseCall removes the call out of the active ones
+// needs to be called under lock
+func (fS *FraudService) releaseCall(tntAcnt, originID string) {
+	fa, has := fS.accounts[tntAcnt]
+	if !has {
+		return
+	}
+	for _, ft := range fa.trackers {
+		delete(ft.calls, originID)
+	}
+}
+
+// processEvent records the cost of a finished call, evaluating the *velocity and *new_destination rules
+func (fS *FraudService) processEvent(ev *utils.CGREvent, now time.Time) (triggers []*fraudTrigger, err error) {
+	var tntAcnt string
+	if tntAcnt, err = fS.eventAccount(ev); err != nil {
+		return
+	}
+	var cost float64
+	if cost, err = ev.FieldAsFloat64(utils.Cost); err != nil {
+		if err != utils.ErrNotFound {
+			return
+		}
+		err = nil
+	}
+	if cost < 0 { // not rated
+		cost = 0
+	}
+	var matches []*fraudMatch
+	if matches, err = fS.matchingRules(ev,
+		utils.NewStringSet([]string{utils.MetaVelocity, utils.MetaNewDestination})); err != nil {
+		return
+	}
+	fS.mux.Lock()
+	defer fS.mux.Unlock()
+	if originID := utils.IfaceAsString(ev.Event[utils.OriginID]); originID != utils.EmptyString {
+		fS.releaseCall(tntAcnt, originID)
+	}
+	fa := fS.account(tntAcnt)
+	for _, fm := range matches {
+		ft := fa.tracker(fm, fS.cgrcfg.FraudSCfg().MaxCallDuration, now)
+		switch fm.rule.Type {
+		case utils.MetaVelocity:
+			ft.events = append(ft.events, &fraudEvent{time: now, cost: cost})
+			var winCost float64
+			for _, fEv := range ft.events {
+				winCost += fEv.cost
+			}
+			if (fm.rule.MaxCost > 0 && winCost > fm.rule.MaxCost) ||
+				(fm.rule.MaxCalls > 0 && len(ft.events) > fm.rule.MaxCalls) {
+				triggers = append(triggers, &fraudTrigger{fraudMatch: fm,
+					cost: winCost, calls: len(ft.events)})
+				ft.events = nil // start counting again so we do not trigger on each event
+			}
+		case utils.MetaNewDestination:
+			if ft.seenDestination(now) {
+				triggers = append(triggers, &fraudTrigger{fraudMatch: fm})
+			}
+		}
+	}
+	for _, trg := range triggers {
+		fa.block(trg.rule, now)
+	}
+	fS.cleanup(tntAcnt, now)
+	return
+}
+
+// processTriggers executes the actions of the triggered rules on the account and sends the alerts to EEs
+func (fS *FraudService) processTriggers(ev *utils.CGREvent, triggers []*fraudTrigger) (err error) {
+	acnt := utils.IfaceAsString(ev.Event[utils.AccountField])
+	tntAcnt := utils.ConcatenatedKey(ev.Tenant, acnt)
+	for _, trg := range triggers {
+		utils.Logger.Warning(fmt.Sprintf("<%s> rule <%s> triggered for account <%s> on event: %s",
+			utils.FraudS, trg.rule.ID, tntAcnt, utils.ToJSON(ev)))
+		for _, actionSetID := range trg.rule.ActionIDs {
+			at := &ActionTiming{
+				Uuid:       utils.GenUUID(),
+				ActionsID:  actionSetID,
+				ExtraData:  ev,
+				accountIDs: utils.NewStringMap(tntAcnt),
+			}
+			if errExec := at.Execute(fS.filterS); errExec != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> failed executing actions: %s, error: %s",
+					utils.FraudS, actionSetID, errExec.Error()))
+				err = utils.ErrPartiallyExecuted
+			}
+		}
+		if len(fS.cgrcfg.FraudSCfg().EEsConns) == 0 {
+			continue
+		}
+		alert := &CGREventWithEeIDs{
+			EeIDs: fS.cgrcfg.FraudSCfg().EEsExporterIDs,
+			CGREvent: &utils.CGREvent{
+				Tenant: ev.Tenant,
+				ID:     utils.GenUUID(),
+				Event: map[string]interface{}{
+					utils.AccountField:  acnt,
+					utils.FraudRuleID:   trg.rule.ID,
+					utils.FraudRuleType: trg.rule.Type,
+					utils.FraudGroup:    trg.group,
+					utils.Cost:          trg.cost,
+					utils.FraudCalls:    trg.calls,
+					utils.EventSource:   ev.ID,
+				},
+				APIOpts: map[string]interface{}{
+					utils.MetaEventType: utils.FraudAlert,
+				},
+			},
+		}
+		var reply map[string]map[string]interface{}
+		if errEEs := fS.connMgr.Call(fS.cgrcfg.FraudSCfg().EEsConns, nil,
+			utils.EeSv1ProcessEvent, alert, &reply); errEEs != nil &&
+			errEEs.Error() != utils.ErrNotFound.Error() {
+			utils.Logger.Warning(fmt.Sprintf("<%s> error: %s sending alert %s to EEs",
+				utils.FraudS, errEEs.Error(), utils.ToJSON(alert)))
+			err = utils.ErrPartiallyExecuted
+		}
+	}
+	return
+}
+
+// V1AuthorizeEvent returns ErrFraudBlocked if the account of the event is blocked
+// or it reached the limit of concurrent calls
+func (fS *FraudService) V1AuthorizeEvent(args *utils.CGREvent, reply *string) (err error) {
+	if err = fS.authorizeEvent(args, time.Now()); err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1AllocateCall starts tracking a call of the account
+func (fS *FraudService) V1AllocateCall(args *utils.CGREvent, reply *string) (err error) {
+	var triggers []*fraudTrigger
+	triggers, err = fS.allocateCall(args, time.Now())
+	if len(triggers) != 0 {
+		if errTrg := fS.processTriggers(args, triggers); errTrg != nil && err == nil {
+			err = errTrg
+		}
+	}
+	if err != nil {
+		return
+	}
+	*reply = utils.OK
+	return
+}
+
+// V1ReleaseCall stops tracking a call of the account
+func (fS *FraudService) V1ReleaseCall(args *utils.CGREvent, reply *string) (err error) {
+	var tntAcnt string
+	if tntAcnt, err = fS.eventAccount(args); err != nil {
+		return
+	}
+	originID := utils.IfaceAsString(args.Event[utils.OriginID])
+	if originID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.OriginID)
+	}
+	fS.mux.Lock()
+	fS.releaseCall(tntAcnt, originID)
+	fS.cleanup(tntAcnt, time.Now())
+	fS.mux.Unlock()
+	*reply = utils.OK
+	return
+}
+
+// V1ProcessEvent records the cost of a finished call, returning the IDs of the triggered rules
+func (fS *FraudService) V1ProcessEvent(args *utils.CGREvent, reply *[]string) (err error) {
+	var triggers []*fraudTrigger
+	if triggers, err = fS.processEvent(args, time.Now()); err != nil {
+		return
+	}
+	ruleIDs := make([]string, len(triggers))
+	for i, trg := range triggers {
+		ruleIDs[i] = trg.rule.ID
+	}
+	if len(triggers) != 0 {
+		err = fS.processTriggers(args, triggers)
+	}
+	*reply = ruleIDs
+	return
+}
+
+// V1GetBlockedAccounts returns the accounts of the tenant which are currently blocked
+func (fS *FraudService) V1GetBlockedAccounts(tenant string, reply *[]*FraudBlock) (err error) {
+	if tenant == utils.EmptyString {
+		tenant = fS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	now := time.Now()
+	blocks := make([]*FraudBlock, 0)
+	fS.mux.Lock()
+	for tntAcnt, fa := range fS.accounts {
+		if !fa.isBlocked(now) {
+			continue
+		}
+		tntID := utils.NewTenantID(tntAcnt)
+		if tntID.Tenant != tenant {
+			continue
+		}
+		blocks = append(blocks, &FraudBlock{
+			Tenant:       tntID.Tenant,
+			Account:      tntID.ID,
+			RuleID:       fa.blockedBy,
+			BlockedUntil: fa.blockedUntil,
+		})
+	}
+	fS.mux.Unlock()
+	if len(blocks) == 0 {
+		return utils.ErrNotFound
+	}
+	sort.Slice(blocks, func(i, j int) bool { return blocks[i].Account < blocks[j].Account })
+	*reply = blocks
+	return
+}
+
+// V1UnblockAccount removes the block of an account
+func (fS *FraudService) V1UnblockAccount(args *utils.TenantID, reply *string) (err error) {
+	if args == nil || args.ID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.ID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = fS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	tntAcnt := utils.ConcatenatedKey(tnt, args.ID)
+	now := time.Now()
+	fS.mux.Lock()
+	defer fS.mux.Unlock()
+	fa, has := fS.accounts[tntAcnt]
+	if !has || !fa.isBlocked(now) {
+		return utils.ErrNotFound
+	}
+	fa.blockedUntil = time.Time{}
+	fa.blockedBy = utils.EmptyString
+	fS.cleanup(tntAcnt, now)
+	*reply = utils.OK
+	return
+}
@@ -0,0 +1,316 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func newTestFraudService(cfg *config.CGRConfig, connMgr *ConnManager) *FraudService {
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	return NewFraudService(cfg, &FilterS{dm: dm, cfg: cfg}, connMgr)
+}
+
+func TestFraudSVelocity(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	grp, err := config.NewRSRParsers("~*req.Destination:s/^(\\d{3}).*/${1}/", utils.InfieldSep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.FraudSCfg().Rules = []*config.FraudRuleCfg{{
+		ID:          "VELOCITY",
+		Type:        utils.MetaVelocity,
+		FilterIDs:   []string{"*prefix:~*req.Destination:882"},
+		Group:       grp,
+		Window:      time.Hour,
+		MaxCost:     10,
+		BlockPeriod: time.Hour,
+	}}
+	fS := newTestFraudService(cfg, nil)
+	ev := &utils.CGREvent{
+		ID: "ev1",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Destination:  "88234567",
+			utils.Cost:         6,
+		},
+	}
+	now := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	if trgs, err := fS.processEvent(ev, now); err != nil {
+		t.Fatal(err)
+	} else if len(trgs) != 0 {
+		t.Errorf("unexpected triggers: %+v", trgs)
+	}
+	// other destination is not counted
+	if trgs, err := fS.processEvent(&utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Destination:  "4986517174963",
+			utils.Cost:         100,
+		},
+	}, now); err != nil {
+		t.Fatal(err)
+	} else if len(trgs) != 0 {
+		t.Errorf("unexpected triggers: %+v", trgs)
+	}
+	// out of the window
+	if trgs, err := fS.processEvent(ev, now.Add(2*time.Hour)); err != nil {
+		t.Fatal(err)
+	} else if len(trgs) != 0 {
+		t.Errorf("unexpected triggers: %+v", trgs)
+	}
+	trgs, err := fS.processEvent(ev, now.Add(2*time.Hour+time.Minute))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(trgs) != 1 || trgs[0].rule.ID != "VELOCITY" ||
+		trgs[0].group != "882" || trgs[0].cost != 12 || trgs[0].calls != 2 {
+		t.Errorf("unexpected triggers: %s", utils.ToJSON(trgs))
+	}
+	if err := fS.authorizeEvent(ev, now.Add(2*time.Hour+2*time.Minute)); err != utils.ErrFraudBlocked {
+		t.Errorf("expected %v, received %v", utils.ErrFraudBlocked, err)
+	}
+	if err := fS.authorizeEvent(ev, now.Add(4*time.Hour)); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFraudSConcurrentCalls(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.FraudSCfg().Rules = []*config.FraudRuleCfg{{
+		ID:            "CONCURRENT",
+		Type:          utils.MetaConcurrentCalls,
+		MaxConcurrent: 1,
+	}}
+	fS := newTestFraudService(cfg, nil)
+	ev := func(originID string) *utils.CGREvent {
+		return &utils.CGREvent{
+			Tenant: "cgrates.org",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+				utils.OriginID:     originID,
+			},
+		}
+	}
+	now := time.Now()
+	if err := fS.authorizeEvent(ev("call1"), now); err != nil {
+		t.Error(err)
+	}
+	if trgs, err := fS.allocateCall(ev("call1"), now); err != nil {
+		t.Error(err)
+	} else if len(trgs) != 0 {
+		t.Errorf("unexpected triggers: %+v", trgs)
+	}
+	if err := fS.authorizeEvent(ev("call2"), now); err != utils.ErrFraudBlocked {
+		t.Errorf("expected %v, received %v", utils.ErrFraudBlocked, err)
+	}
+	// without block period the rule only alerts
+	if trgs, err := fS.allocateCall(ev("call2"), now); err != nil {
+		t.Error(err)
+	} else if len(trgs) != 1 || trgs[0].calls != 2 {
+		t.Errorf("unexpected triggers: %s", utils.ToJSON(trgs))
+	}
+	fS.mux.Lock()
+	fS.releaseCall("cgrates.org:1001", "call1")
+	fS.releaseCall("cgrates.org:1001", "call2")
+	fS.cleanup("cgrates.org:1001", now)
+	if len(fS.accounts) != 0 {
+		t.Errorf("expected the account data to be removed, received: %+v", fS.accounts)
+	}
+	fS.mux.Unlock()
+
+	// the calls never released expire after the max call duration
+	cfg.FraudSCfg().MaxCallDuration = time.Hour
+	if _, err := fS.allocateCall(ev("call3"), now); err != nil {
+		t.Error(err)
+	}
+	if err := fS.authorizeEvent(ev("call4"), now.Add(time.Hour)); err != utils.ErrFraudBlocked {
+		t.Errorf("expected %v, received %v", utils.ErrFraudBlocked, err)
+	}
+	if err := fS.authorizeEvent(ev("call4"), now.Add(2*time.Hour)); err != nil {
+		t.Error(err)
+	}
+	fS.mux.Lock()
+	fS.cleanup("cgrates.org:1001", now.Add(2*time.Hour))
+	if len(fS.accounts) != 0 {
+		t.Errorf("expected the account data to be removed, received: %+v", fS.accounts)
+	}
+	fS.mux.Unlock()
+
+	expErr := "MANDATORY_IE_MISSING: [OriginID]"
+	if _, err := fS.allocateCall(&utils.CGREvent{
+		Event: map[string]interface{}{utils.AccountField: "1001"},
+	}, now); err == nil || err.Error() != expErr {
+		t.Errorf("expected %q, received %v", expErr, err)
+	}
+}
+
+func TestFraudSNewDestination(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	grp, err := config.NewRSRParsers("~*req.Destination", utils.InfieldSep)
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg.FraudSCfg().Rules = []*config.FraudRuleCfg{{
+		ID:          "NEW_DST",
+		Type:        utils.MetaNewDestination,
+		FilterIDs:   []string{"*prefix:~*req.Destination:882"},
+		Group:       grp,
+		Window:      24 * time.Hour,
+		BlockPeriod: time.Hour,
+	}}
+	fS := newTestFraudService(cfg, nil)
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.OriginID:     "call1",
+			utils.Destination:  "88234567",
+		},
+	}
+	now := time.Now()
+	if trgs, err := fS.allocateCall(ev, now); err != utils.ErrFraudBlocked {
+		t.Errorf("expected %v, received %v", utils.ErrFraudBlocked, err)
+	} else if len(trgs) != 1 || trgs[0].rule.ID != "NEW_DST" {
+		t.Errorf("unexpected triggers: %s", utils.ToJSON(trgs))
+	}
+	var blocks []*FraudBlock
+	if err := fS.V1GetBlockedAccounts(utils.EmptyString, &blocks); err != nil {
+		t.Error(err)
+	} else if len(blocks) != 1 || blocks[0].Account != "1001" || blocks[0].RuleID != "NEW_DST" {
+		t.Errorf("unexpected blocks: %s", utils.ToJSON(blocks))
+	}
+	var reply string
+	if err := fS.V1UnblockAccount(&utils.TenantID{ID: "1001"}, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("unexpected reply: %s", reply)
+	}
+	if err := fS.V1GetBlockedAccounts(utils.EmptyString, &blocks); err != utils.ErrNotFound {
+		t.Errorf("expected %v, received %v", utils.ErrNotFound, err)
+	}
+	// the destination is known within the window
+	if trgs, err := fS.processEvent(ev, now.Add(time.Hour)); err != nil {
+		t.Error(err)
+	} else if len(trgs) != 0 {
+		t.Errorf("unexpected triggers: %+v", trgs)
+	}
+	if trgs, err := fS.processEvent(ev, now.Add(50*time.Hour)); err != nil {
+		t.Error(err)
+	} else if len(trgs) != 1 {
+		t.Errorf("unexpected triggers: %+v", trgs)
+	}
+	// the destinations not seen within the window expire on the events with other destinations
+	ev.Event[utils.Destination] = "88200000"
+	if _, err := fS.processEvent(ev, now.Add(100*time.Hour)); err != nil {
+		t.Error(err)
+	}
+	if fa := fS.accounts["cgrates.org:1001"]; len(fa.trackers) != 1 ||
+		fa.trackers["NEW_DST:88200000"] == nil {
+		t.Errorf("unexpected trackers: %s", utils.ToJSON(fa.trackers))
+	}
+	for i := 0; i < maxFraudDestinations; i++ {
+		ev.Event[utils.Destination] = fmt.Sprintf("882%05d", i+1)
+		if _, err := fS.processEvent(ev, now.Add(100*time.Hour+time.Duration(i+1)*time.Second)); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if fa := fS.accounts["cgrates.org:1001"]; len(fa.trackers) != maxFraudDestinations ||
+		fa.trackers["NEW_DST:88200000"] != nil {
+		t.Errorf("expected the least recently seen destination dropped, trackers: %d", len(fa.trackers))
+	}
+}
+
+func TestFraudSProcessEventAlert(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.FraudSCfg().EEsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs)}
+	cfg.FraudSCfg().EEsExporterIDs = []string{"fraud_alerts"}
+	cfg.FraudSCfg().Rules = []*config.FraudRuleCfg{{
+		ID:       "VELOCITY",
+		Type:     utils.MetaVelocity,
+		Window:   time.Hour,
+		MaxCalls: 1,
+	}}
+	var alert *CGREventWithEeIDs
+	ccM := &ccMock{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.EeSv1ProcessEvent: func(args, reply interface{}) error {
+				alert = args.(*CGREventWithEeIDs)
+				return nil
+			},
+		},
+	}
+	rpcInternal := make(chan rpcclient.ClientConnector, 1)
+	rpcInternal <- ccM
+	fS := newTestFraudService(cfg, NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaEEs): rpcInternal,
+	}))
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "cdr1",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Cost:         1.5,
+		},
+	}
+	var ruleIDs []string
+	if err := fS.V1ProcessEvent(ev, &ruleIDs); err != nil {
+		t.Error(err)
+	} else if len(ruleIDs) != 0 {
+		t.Errorf("unexpected rules: %+v", ruleIDs)
+	}
+	if err := fS.V1ProcessEvent(ev, &ruleIDs); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]string{"VELOCITY"}, ruleIDs) {
+		t.Errorf("unexpected rules: %+v", ruleIDs)
+	}
+	if alert == nil {
+		t.Fatal("expected the alert to be sent")
+	}
+	if !reflect.DeepEqual([]string{"fraud_alerts"}, alert.EeIDs) {
+		t.Errorf("unexpected exporters: %+v", alert.EeIDs)
+	}
+	expEv := map[string]interface{}{
+		utils.AccountField:  "1001",
+		utils.FraudRuleID:   "VELOCITY",
+		utils.FraudRuleType: utils.MetaVelocity,
+		utils.FraudGroup:    utils.EmptyString,
+		utils.Cost:          3.,
+		utils.FraudCalls:    2,
+		utils.EventSource:   "cdr1",
+	}
+	if !reflect.DeepEqual(expEv, alert.Event) {
+		t.Errorf("expected %s, received %s", utils.ToJSON(expEv), utils.ToJSON(alert.Event))
+	}
+
+	expErr := "MANDATORY_IE_MISSING: [Account]"
+	if err := fS.V1ProcessEvent(&utils.CGREvent{Event: map[string]interface{}{}},
+		&ruleIDs); err == nil || err.Error() != expErr {
+		t.Errorf("expected %q, received %v", expErr, err)
+	}
+}
@@ -43,6 +43,18 @@ func SetDataStorage(dm2 *DataManager) {
 	dm = dm2
 }
 
+// GetDataDBBackendsStatus returns the status of the DataDBs when failing over to
+// standby ones, nil otherwise
+func GetDataDBBackendsStatus() []*DataDBBackendStatus {
+	if dm == nil {
+		return nil
+	}
+	if fdb, isFailover := dm.DataDB().(*FailoverDataDB); isFailover {
+		return fdb.Status()
+	}
+	return nil
+}
+
 // SetConnManager is the exported method to set the connectionManager used when operate on an account.
 func SetConnManager(conMgr *ConnManager) {
 	connMgr = conMgr
@@ -0,0 +1,355 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"reflect"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+const (
+	// DataDBSnapshotFormat identifies the DataDB snapshot archives
+	DataDBSnapshotFormat = "cgrates_datadb_snapshot"
+	// DataDBSnapshotVersion is the version of the archive format written by this engine
+	DataDBSnapshotVersion = 1
+
+	dataDBSnapshotAttempts = 3 // exports retried when the DataDB was changed meanwhile
+)
+
+// DataDBSnapshotHeader is the first record of a DataDB snapshot archive
+type DataDBSnapshotHeader struct {
+	Format      string
+	Version     int
+	CreatedAt   time.Time
+	StorageType string           // the type of the exported DataDB
+	Tenants     []string         `json:",omitempty"` // the exported tenants, all if empty
+	Versions    Versions         // the versions of the exported DataDB
+	LoadIDs     map[string]int64 // the load IDs at the start of the export
+}
+
+// dataDBSnapshotItem is one record of a DataDB snapshot archive, following the header
+type dataDBSnapshotItem struct {
+	Prefix string
+	Key    string
+	Item   json.RawMessage
+}
+
+// DataDBSnapshotInfo summarizes an exported or a restored DataDB snapshot
+type DataDBSnapshotInfo struct {
+	Header        *DataDBSnapshotHeader
+	Items         map[string]int       // number of items indexed on prefix
+	IndexesHealth *DataDBIndexesHealth `json:",omitempty"` // checked after restore
+}
+
+// dataDBItemTenant returns the tenant of the item, false for the items not belonging to a tenant
+func dataDBItemTenant(prfx, key string) (tnt string, hasTnt bool) {
+	switch prfx {
+	case utils.DestinationPrefix, utils.RatingPlanPrefix, utils.ActionPrefix,
+		utils.ActionPlanPrefix, utils.ActionTriggerPrefix, utils.SharedGroupPrefix,
+		utils.TimingsPrefix:
+		return
+	case utils.RatingProfilePrefix: // *out:tenant:category:subject
+		flds := strings.SplitN(key, utils.ConcatenatedKeySep, 3)
+		if len(flds) < 2 {
+			return
+		}
+		return flds[1], true
+	}
+	return strings.SplitN(key, utils.ConcatenatedKeySep, 2)[0], true
+}
+
+// newTenantFilter returns a function matching the items of the given tenants, all if empty
+// the items not belonging to a tenant are matched only withShared
+func newTenantFilter(tenants []string, withShared bool) func(prfx, key string) bool {
+	tnts := utils.NewStringSet(tenants)
+	return func(prfx, key string) bool {
+		if tnts.Size() == 0 {
+			return true
+		}
+		tnt, hasTnt := dataDBItemTenant(prfx, key)
+		if !hasTnt {
+			return withShared
+		}
+		return tnts.Has(tnt)
+	}
+}
+
+// dataDBItemIndexes are the prefixes of the indexes built out of the items indexed on prefix
+var dataDBItemIndexes = map[string]string{
+	utils.ResourceProfilesPrefix:  utils.ResourceFilterIndexes,
+	utils.StatQueueProfilePrefix:  utils.StatFilterIndexes,
+	utils.ThresholdProfilePrefix:  utils.ThresholdFilterIndexes,
+	utils.FilterPrefix:            utils.FilterIndexPrfx,
+	utils.RouteProfilePrefix:      utils.RouteFilterIndexes,
+	utils.AttributeProfilePrefix:  utils.AttributeFilterIndexes,
+	utils.ChargerProfilePrefix:    utils.ChargerFilterIndexes,
+	utils.DispatcherProfilePrefix: utils.DispatcherFilterIndexes,
+}
+
+// removeDataDBItems removes the items with prefix matched by fltr, together with their indexes
+// the removed prefixes are added to cleared so they are not removed twice
+func removeDataDBItems(db DataDB, prfx string, fltr func(prfx, key string) bool, cleared utils.StringSet) (err error) {
+	prfxs := []string{prfx}
+	if idxPrfx, has := dataDBItemIndexes[prfx]; has {
+		prfxs = append(prfxs, idxPrfx)
+	}
+	for _, prfx := range prfxs {
+		if cleared.Has(prfx) {
+			continue
+		}
+		cleared.Add(prfx)
+		hdlr := dataDBItemHandlers[prfx]
+		if hdlr.rem == nil { // not belonging to a tenant
+			continue
+		}
+		var keys []string
+		if keys, err = dataDBItemKeys(db, prfx); err != nil {
+			return fmt.Errorf("error <%s> querying keys for prefix: <%s>", err.Error(), prfx)
+		}
+		for _, key := range keys {
+			if !fltr(prfx, key) {
+				continue
+			}
+			if err = hdlr.rem(db, key); err != nil && err != utils.ErrNotFound {
+				return fmt.Errorf("error <%s> removing item with key: <%s>", err.Error(), prfx+key)
+			}
+		}
+		err = nil
+	}
+	return
+}
+
+// ExportDataDBSnapshot writes the items of the DataDB as a gzip compressed archive with one JSON record per line:
+// the DataDBSnapshotHeader followed by the items, the ones not belonging to a tenant being always exported
+func ExportDataDBSnapshot(db DataDB, w io.Writer, tenants []string) (info *DataDBSnapshotInfo, err error) {
+	hdr := &DataDBSnapshotHeader{
+		Format:      DataDBSnapshotFormat,
+		Version:     DataDBSnapshotVersion,
+		CreatedAt:   time.Now(),
+		StorageType: db.GetStorageType(),
+		Tenants:     tenants,
+	}
+	if hdr.Versions, err = db.GetVersions(utils.EmptyString); err != nil && err != utils.ErrNotFound {
+		return
+	}
+	if hdr.LoadIDs, err = db.GetItemLoadIDsDrv(utils.EmptyString); err != nil && err != utils.ErrNotFound {
+		return
+	}
+	err = nil
+	info = &DataDBSnapshotInfo{
+		Header: hdr,
+		Items:  make(map[string]int),
+	}
+	gzW := gzip.NewWriter(w)
+	enc := json.NewEncoder(gzW)
+	if err = enc.Encode(hdr); err != nil {
+		return
+	}
+	fltr := newTenantFilter(tenants, true)
+	for _, prfx := range dataDBSyncPrefixes {
+		hdlr := dataDBItemHandlers[prfx]
+		var keys []string
+		if keys, err = dataDBItemKeys(db, prfx); err != nil {
+			return nil, fmt.Errorf("error <%s> querying keys for prefix: <%s>", err.Error(), prfx)
+		}
+		for _, key := range keys {
+			if !fltr(prfx, key) {
+				continue
+			}
+			var itm interface{}
+			if itm, err = hdlr.get(db, key); err == utils.ErrNotFound { // removed in the meantime
+				err = nil
+				continue
+			} else if err != nil {
+				return nil, fmt.Errorf("error <%s> exporting item with key: <%s>", err.Error(), prfx+key)
+			}
+			var itmJSON []byte
+			if itmJSON, err = json.Marshal(itm); err != nil {
+				return
+			}
+			if err = enc.Encode(&dataDBSnapshotItem{Prefix: prfx, Key: key, Item: itmJSON}); err != nil {
+				return
+			}
+			info.Items[prfx]++
+		}
+	}
+	err = gzW.Close()
+	return
+}
+
+// ExportDataDBSnapshotToFile exports the snapshot into the file at path, retrying
+// the export if the items were loaded into the DataDB meanwhile
+// the changes are detected via the load IDs so the runtime updates of the accounts,
+// resources, stats and thresholds done during the export are not detected
+func ExportDataDBSnapshotToFile(db DataDB, path string, tenants []string) (info *DataDBSnapshotInfo, err error) {
+	tmpPath := path + utils.TmpSuffix
+	for i := 0; i < dataDBSnapshotAttempts; i++ {
+		var f *os.File
+		if f, err = os.Create(tmpPath); err != nil {
+			return
+		}
+		info, err = ExportDataDBSnapshot(db, f, tenants)
+		if errClose := f.Close(); err == nil {
+			err = errClose
+		}
+		if err != nil {
+			os.Remove(tmpPath)
+			return nil, err
+		}
+		var loadIDs map[string]int64
+		if loadIDs, err = db.GetItemLoadIDsDrv(utils.EmptyString); err != nil && err != utils.ErrNotFound {
+			os.Remove(tmpPath)
+			return nil, err
+		}
+		err = nil
+		if len(loadIDs) == 0 && len(info.Header.LoadIDs) == 0 ||
+			reflect.DeepEqual(loadIDs, info.Header.LoadIDs) {
+			return info, os.Rename(tmpPath, path)
+		}
+		utils.Logger.Warning(fmt.Sprintf("<%s> DataDB changed while exporting the snapshot to <%s>, retrying",
+			utils.DataDB, path))
+	}
+	os.Remove(tmpPath)
+	return nil, errors.New("DataDB changed during the snapshot export")
+}
+
+// RestoreDataDBSnapshot writes the items of the archive into the DataDB, optionally flushing it first
+// with tenants, only the items of the given tenants are restored, the ones not belonging to a tenant being skipped
+// and the existing items of the tenants, together with their indexes, being removed for each restored prefix
+func RestoreDataDBSnapshot(db DataDB, r io.Reader, tenants []string, flush bool) (info *DataDBSnapshotInfo, err error) {
+	defer resetTenantObjects() // the restored items are written bypassing the DataManager
+	return restoreDataDBSnapshot(db, r, tenants, flush, false)
+}
+
+// restoreDataDBSnapshot decodes the archive, writing the items into the DataDB if not dryRun
+func restoreDataDBSnapshot(db DataDB, r io.Reader, tenants []string, flush, dryRun bool) (info *DataDBSnapshotInfo, err error) {
+	if flush && len(tenants) != 0 {
+		return nil, errors.New("cannot flush the DataDB when restoring only some tenants")
+	}
+	var gzR *gzip.Reader
+	if gzR, err = gzip.NewReader(r); err != nil {
+		return
+	}
+	defer gzR.Close()
+	dec := json.NewDecoder(gzR)
+	hdr := new(DataDBSnapshotHeader)
+	if err = dec.Decode(hdr); err != nil {
+		return
+	}
+	if hdr.Format != DataDBSnapshotFormat {
+		return nil, fmt.Errorf("unsupported snapshot format: <%s>", hdr.Format)
+	}
+	if hdr.Version > DataDBSnapshotVersion {
+		return nil, fmt.Errorf("unsupported snapshot version: %d", hdr.Version)
+	}
+	if flush && !dryRun {
+		if err = db.Flush(utils.EmptyString); err != nil {
+			return
+		}
+	}
+	info = &DataDBSnapshotInfo{
+		Header: hdr,
+		Items:  make(map[string]int),
+	}
+	fltr := newTenantFilter(tenants, false) // the shared items would overwrite the ones used by the other tenants
+	cleared := make(utils.StringSet)        // the prefixes with the existing items of the tenants removed
+	for {
+		var snpItm dataDBSnapshotItem
+		if err = dec.Decode(&snpItm); err == io.EOF {
+			break
+		} else if err != nil {
+			return nil, err
+		}
+		hdlr, has := dataDBItemHandlers[snpItm.Prefix]
+		if !has {
+			return nil, fmt.Errorf("unsupported prefix: <%s>", snpItm.Prefix)
+		}
+		if !fltr(snpItm.Prefix, snpItm.Key) {
+			continue
+		}
+		itm := hdlr.newItem()
+		if err = json.Unmarshal(snpItm.Item, itm); err != nil {
+			return nil, fmt.Errorf("error <%s> decoding item with key: <%s>", err.Error(), snpItm.Prefix+snpItm.Key)
+		}
+		if dryRun {
+			info.Items[snpItm.Prefix]++
+			continue
+		}
+		if len(tenants) != 0 && !cleared.Has(snpItm.Prefix) {
+			if err = removeDataDBItems(db, snpItm.Prefix, fltr, cleared); err != nil {
+				return
+			}
+		}
+		if err = hdlr.set(db, snpItm.Key, reflect.ValueOf(itm).Elem().Interface()); err != nil {
+			return nil, fmt.Errorf("error <%s> restoring item with key: <%s>", err.Error(), snpItm.Prefix+snpItm.Key)
+		}
+		info.Items[snpItm.Prefix]++
+	}
+	err = nil
+	if dryRun {
+		return
+	}
+	if len(tenants) == 0 && len(hdr.Versions) != 0 {
+		if err = db.SetVersions(hdr.Versions, flush); err != nil {
+			return
+		}
+	}
+	// the restored items get new load IDs so the changes are detected
+	loadIDs := make(map[string]int64)
+	now := time.Now().UnixNano()
+	for prfx := range info.Items {
+		if cacheID, has := utils.CachePrefixToInstance[prfx]; has {
+			loadIDs[cacheID] = now
+		}
+	}
+	if len(loadIDs) != 0 {
+		err = db.SetLoadIDsDrv(loadIDs)
+	}
+	return
+}
+
+// RestoreDataDBSnapshotFromFile restores the snapshot out of the file at path, verifying the
+// whole archive before writing into the DataDB and checking the health of the indexes afterwards
+func RestoreDataDBSnapshotFromFile(dm *DataManager, path string, tenants []string, flush bool) (info *DataDBSnapshotInfo, err error) {
+	var f *os.File
+	if f, err = os.Open(path); err != nil {
+		return
+	}
+	defer f.Close()
+	if _, err = restoreDataDBSnapshot(nil, f, tenants, flush, true); err != nil {
+		return nil, fmt.Errorf("invalid snapshot archive: %s", err.Error())
+	}
+	if _, err = f.Seek(0, io.SeekStart); err != nil {
+		return
+	}
+	if info, err = RestoreDataDBSnapshot(dm.DataDB(), f, tenants, flush); err != nil {
+		return
+	}
+	info.IndexesHealth, err = GetDataDBIndexesHealth(dm)
+	return
+}
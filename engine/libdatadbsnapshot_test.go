@@ -0,0 +1,314 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bytes"
+	"compress/gzip"
+	"os"
+	"path"
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func testDataDBSnapshotSource(t *testing.T) (db DataDB) {
+	cfg := config.NewDefaultCGRConfig()
+	db = NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(db, cfg.CacheCfg(), nil)
+	dst := &Destination{Id: "DST_1001", Prefixes: []string{"1001"}}
+	if err := dm.SetDestination(dst, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetReverseDestination(dst.Id, dst.Prefixes, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	for _, tnt := range []string{"cgrates.org", "itsyscom.com"} {
+		if err := dm.SetAccount(&Account{ID: utils.ConcatenatedKey(tnt, "1001")}); err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.SetFilter(&Filter{
+			Tenant: tnt,
+			ID:     "FLTR_1",
+			Rules: []*FilterRule{{
+				Type:    utils.MetaString,
+				Element: utils.DynamicDataPrefix + utils.MetaReq + utils.NestingSep + utils.AccountField,
+				Values:  []string{"1001"},
+			}},
+		}, true); err != nil {
+			t.Fatal(err)
+		}
+		if err := dm.SetAttributeProfile(&AttributeProfile{
+			Tenant:    tnt,
+			ID:        "ATTR_1",
+			Contexts:  []string{utils.MetaAny},
+			FilterIDs: []string{"FLTR_1"},
+			Attributes: []*Attribute{{
+				Path:  utils.MetaReq + utils.NestingSep + utils.Subject,
+				Type:  utils.MetaConstant,
+				Value: config.NewRSRParsersMustCompile("1002", utils.InfieldSep),
+			}},
+		}, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	asr, err := NewASR(1, utils.EmptyString, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := asr.AddEvent("EV_1", utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.AnswerTime: "2021-01-01T10:00:00Z"}}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SetStatQueueDrv(nil, &StatQueue{
+		Tenant:    "cgrates.org",
+		ID:        "SQ_1",
+		SQItems:   []SQItem{{EventID: "EV_1"}},
+		SQMetrics: map[string]StatMetric{utils.MetaASR: asr},
+	}); err != nil {
+		t.Fatal(err)
+	}
+	if err := db.SetVersions(CurrentDataDBVersions(), true); err != nil {
+		t.Fatal(err)
+	}
+	return
+}
+
+func TestDataDBSnapshotExportRestore(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	from := testDataDBSnapshotSource(t)
+	var buf bytes.Buffer
+	info, err := ExportDataDBSnapshot(from, &buf, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	expItems := map[string]int{
+		utils.DestinationPrefix:      1,
+		utils.AccountPrefix:          2,
+		utils.FilterPrefix:           2,
+		utils.AttributeProfilePrefix: 2,
+		utils.StatQueuePrefix:        1,
+		utils.AttributeFilterIndexes: 2,
+		utils.FilterIndexPrfx:        2,
+	}
+	if !reflect.DeepEqual(expItems, info.Items) {
+		t.Errorf("Expected: %v ,received: %v", expItems, info.Items)
+	}
+	if info.Header.Format != DataDBSnapshotFormat || info.Header.Version != DataDBSnapshotVersion ||
+		info.Header.StorageType != utils.Internal {
+		t.Errorf("Unexpected header: %s", utils.ToJSON(info.Header))
+	}
+
+	to := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	if err := to.SetAccountDrv(&Account{ID: "cgrates.org:stale"}); err != nil {
+		t.Fatal(err)
+	}
+	if info, err = RestoreDataDBSnapshot(to, bytes.NewReader(buf.Bytes()), nil, true); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(expItems, info.Items) {
+		t.Errorf("Expected: %v ,received: %v", expItems, info.Items)
+	}
+	if _, err := to.GetAccountDrv("cgrates.org:stale"); err != utils.ErrNotFound {
+		t.Errorf("Expected the stale account to be flushed, received: %v", err)
+	}
+	if exp, err := from.GetAttributeProfileDrv("itsyscom.com", "ATTR_1"); err != nil {
+		t.Error(err)
+	} else if rcv, err := to.GetAttributeProfileDrv("itsyscom.com", "ATTR_1"); err != nil {
+		t.Error(err)
+	} else if utils.ToJSON(exp) != utils.ToJSON(rcv) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv, err := to.GetReverseDestinationDrv("1001", utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]string{"DST_1001"}, rcv) {
+		t.Errorf("Expected: %q ,received: %q", []string{"DST_1001"}, rcv)
+	}
+	if sq, err := to.GetStatQueueDrv("cgrates.org", "SQ_1"); err != nil {
+		t.Error(err)
+	} else if val := sq.SQMetrics[utils.MetaASR].GetValue(config.CgrConfig().GeneralCfg().RoundingDecimals); val != 100. {
+		t.Errorf("Expected ASR: 100, received: %v", val)
+	}
+	if rcv, err := to.GetVersions(utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(CurrentDataDBVersions(), rcv) {
+		t.Errorf("Expected: %v ,received: %v", CurrentDataDBVersions(), rcv)
+	}
+	if loadIDs, err := to.GetItemLoadIDsDrv(utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if _, has := loadIDs[utils.CacheAttributeProfiles]; !has {
+		t.Errorf("Expected load ID for %s, received: %v", utils.CacheAttributeProfiles, loadIDs)
+	}
+	if expIH, err := GetDataDBIndexesHealth(NewDataManager(from, cfg.CacheCfg(), nil)); err != nil {
+		t.Error(err)
+	} else if ih, err := GetDataDBIndexesHealth(NewDataManager(to, cfg.CacheCfg(), nil)); err != nil {
+		t.Error(err)
+	} else if utils.ToJSON(expIH) != utils.ToJSON(ih) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(expIH), utils.ToJSON(ih))
+	}
+}
+
+func TestDataDBSnapshotTenants(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	from := testDataDBSnapshotSource(t)
+	var buf bytes.Buffer
+	if _, err := ExportDataDBSnapshot(from, &buf, []string{"itsyscom.com"}); err != nil {
+		t.Fatal(err)
+	}
+	to := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	if _, err := RestoreDataDBSnapshot(to, bytes.NewReader(buf.Bytes()), []string{"itsyscom.com"}, true); err == nil ||
+		err.Error() != "cannot flush the DataDB when restoring only some tenants" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	if _, err := RestoreDataDBSnapshot(to, bytes.NewReader(buf.Bytes()), nil, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := to.GetAccountDrv("itsyscom.com:1001"); err != nil {
+		t.Error(err)
+	}
+	if _, err := to.GetAccountDrv("cgrates.org:1001"); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := to.GetStatQueueDrv("cgrates.org", "SQ_1"); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := to.GetDestinationDrv("DST_1001", utils.NonTransactional); err != nil {
+		t.Errorf("Expected the destinations to be exported for all tenants, received: %v", err)
+	}
+	if _, err := to.GetVersions(utils.EmptyString); err != nil {
+		t.Error(err)
+	}
+
+	// filter on restore
+	buf.Reset()
+	if _, err := ExportDataDBSnapshot(from, &buf, nil); err != nil {
+		t.Fatal(err)
+	}
+	to = NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	if info, err := RestoreDataDBSnapshot(to, bytes.NewReader(buf.Bytes()), []string{"cgrates.org"}, false); err != nil {
+		t.Fatal(err)
+	} else if info.Items[utils.AccountPrefix] != 1 {
+		t.Errorf("Expected 1 account restored, received: %v", info.Items)
+	}
+	if _, err := to.GetAttributeProfileDrv("itsyscom.com", "ATTR_1"); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := to.GetAttributeProfileDrv("cgrates.org", "ATTR_1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := to.GetDestinationDrv("DST_1001", utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected the shared items to be skipped, received: %v", err)
+	}
+
+	// the existing items of the restored tenants are removed together with their indexes
+	dm := NewDataManager(to, cfg.CacheCfg(), nil)
+	for _, tnt := range []string{"cgrates.org", "itsyscom.com"} {
+		if err := dm.SetAttributeProfile(&AttributeProfile{
+			Tenant:    tnt,
+			ID:        "ATTR_2",
+			Contexts:  []string{utils.MetaAny},
+			FilterIDs: []string{"*string:~*req.Account:1002"},
+			Attributes: []*Attribute{{
+				Path:  utils.MetaReq + utils.NestingSep + utils.Subject,
+				Type:  utils.MetaConstant,
+				Value: config.NewRSRParsersMustCompile("1001", utils.InfieldSep),
+			}},
+		}, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err := RestoreDataDBSnapshot(to, bytes.NewReader(buf.Bytes()), []string{"cgrates.org"}, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := to.GetAttributeProfileDrv("cgrates.org", "ATTR_2"); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := to.GetAttributeProfileDrv("cgrates.org", "ATTR_1"); err != nil {
+		t.Error(err)
+	}
+	if _, err := to.GetIndexesDrv(utils.CacheAttributeFilterIndexes, "cgrates.org:"+utils.MetaAny,
+		"*string:*req.Account:1002"); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := to.GetAttributeProfileDrv("itsyscom.com", "ATTR_2"); err != nil {
+		t.Errorf("Expected the other tenants to be kept, received: %v", err)
+	}
+	if _, err := to.GetIndexesDrv(utils.CacheAttributeFilterIndexes, "itsyscom.com:"+utils.MetaAny,
+		"*string:*req.Account:1002"); err != nil {
+		t.Errorf("Expected the indexes of the other tenants to be kept, received: %v", err)
+	}
+}
+
+func TestDataDBSnapshotFile(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	from := testDataDBSnapshotSource(t)
+	snpPath := path.Join(t.TempDir(), "datadb.snapshot.gz")
+	if _, err := ExportDataDBSnapshotToFile(from, snpPath, nil); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := os.Stat(snpPath + utils.TmpSuffix); !os.IsNotExist(err) {
+		t.Errorf("Expected the temporary file to be removed, received: %v", err)
+	}
+	dm := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	if info, err := RestoreDataDBSnapshotFromFile(dm, snpPath, nil, true); err != nil {
+		t.Fatal(err)
+	} else if info.IndexesHealth == nil ||
+		len(info.IndexesHealth.AccountActionPlans.MissingAccountActionPlans) != 0 ||
+		len(info.IndexesHealth.ReverseDestinations.MissingReverseDestinations) != 0 {
+		t.Errorf("Unexpected indexes health: %s", utils.ToJSON(info.IndexesHealth))
+	}
+
+	// a truncated archive is not restored
+	content, err := os.ReadFile(snpPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := os.WriteFile(snpPath, content[:len(content)/2], 0644); err != nil {
+		t.Fatal(err)
+	}
+	dm = NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	if err := dm.DataDB().SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := RestoreDataDBSnapshotFromFile(dm, snpPath, nil, true); err == nil {
+		t.Error("Expected error for truncated archive")
+	}
+	if _, err := dm.DataDB().GetAccountDrv("cgrates.org:1002"); err != nil {
+		t.Errorf("Expected the DataDB to be untouched, received: %v", err)
+	}
+}
+
+func TestDataDBSnapshotInvalidFormat(t *testing.T) {
+	var buf bytes.Buffer
+	gzW := gzip.NewWriter(&buf)
+	gzW.Write([]byte(`{"Format":"unknown","Version":1}`))
+	gzW.Close()
+	if _, err := RestoreDataDBSnapshot(nil, bytes.NewReader(buf.Bytes()), nil, false); err == nil ||
+		err.Error() != "unsupported snapshot format: <unknown>" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+	buf.Reset()
+	gzW = gzip.NewWriter(&buf)
+	gzW.Write([]byte(`{"Format":"cgrates_datadb_snapshot","Version":2}`))
+	gzW.Close()
+	if _, err := RestoreDataDBSnapshot(nil, bytes.NewReader(buf.Bytes()), nil, false); err == nil ||
+		err.Error() != "unsupported snapshot version: 2" {
+		t.Errorf("Unexpected error: %v", err)
+	}
+}
@@ -0,0 +1,423 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"reflect"
+	"strings"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// dataDBSyncPrefixes are the prefixes of the items copied between DataDBs, in copy order
+// the reverse destinations are rebuilt out of the destinations
+var dataDBSyncPrefixes = []string{
+	utils.DestinationPrefix,
+	utils.RatingPlanPrefix,
+	utils.RatingProfilePrefix,
+	utils.ActionPrefix,
+	utils.ActionPlanPrefix,
+	utils.AccountActionPlansPrefix,
+	utils.ActionTriggerPrefix,
+	utils.SharedGroupPrefix,
+	utils.AccountPrefix,
+	utils.TimingsPrefix,
+	utils.ResourceProfilesPrefix,
+	utils.ResourcesPrefix,
+	utils.StatQueueProfilePrefix,
+	utils.StatQueuePrefix,
+	utils.ThresholdProfilePrefix,
+	utils.ThresholdPrefix,
+	utils.FilterPrefix,
+	utils.RouteProfilePrefix,
+	utils.AttributeProfilePrefix,
+	utils.ChargerProfilePrefix,
+	utils.DispatcherProfilePrefix,
+	utils.DispatcherHostPrefix,
+	utils.AttributeFilterIndexes,
+	utils.ResourceFilterIndexes,
+	utils.StatFilterIndexes,
+	utils.ThresholdFilterIndexes,
+	utils.RouteFilterIndexes,
+	utils.ChargerFilterIndexes,
+	utils.DispatcherFilterIndexes,
+	utils.FilterIndexPrfx,
+}
+
+// dataDBItemMarshaler is used to store the StatQueue metrics in a DataDB independent format
+var dataDBItemMarshaler = new(JSONMarshaler)
+
+// dataDBItemHandler gets, sets and removes one type of DataDB items
+type dataDBItemHandler struct {
+	get  func(db DataDB, key string) (interface{}, error)
+	set  func(db DataDB, key string, itm interface{}) error
+	rem  func(db DataDB, key string) error // only for the items belonging to a tenant
+	zero interface{}                       // used to decode the items returned by get
+}
+
+// newItem returns a pointer to a new item of the handled type
+func (h *dataDBItemHandler) newItem() interface{} {
+	return reflect.New(reflect.TypeOf(h.zero)).Interface()
+}
+
+// dataDBIndexesHandler handles all the indexes of one tntCtx
+func dataDBIndexesHandler(idxItmType string) *dataDBItemHandler {
+	return &dataDBItemHandler{
+		get: func(db DataDB, tntCtx string) (interface{}, error) {
+			return db.GetIndexesDrv(idxItmType, tntCtx, utils.EmptyString)
+		},
+		set: func(db DataDB, tntCtx string, itm interface{}) error {
+			return db.SetIndexesDrv(idxItmType, tntCtx, itm.(map[string]utils.StringSet), true, utils.NonTransactional)
+		},
+		rem: func(db DataDB, tntCtx string) error {
+			return db.RemoveIndexesDrv(idxItmType, tntCtx, utils.EmptyString)
+		},
+		zero: map[string]utils.StringSet(nil),
+	}
+}
+
+// dataDBItemHandlers are indexed on prefix
+var dataDBItemHandlers = map[string]*dataDBItemHandler{
+	utils.DestinationPrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			return db.GetDestinationDrv(key, utils.NonTransactional)
+		},
+		set: func(db DataDB, _ string, itm interface{}) (err error) {
+			dst := itm.(*Destination)
+			if err = db.SetDestinationDrv(dst, utils.NonTransactional); err != nil {
+				return
+			}
+			return db.SetReverseDestinationDrv(dst.Id, dst.Prefixes, utils.NonTransactional)
+		},
+		zero: (*Destination)(nil),
+	},
+	utils.RatingPlanPrefix: {
+		get:  func(db DataDB, key string) (interface{}, error) { return db.GetRatingPlanDrv(key) },
+		set:  func(db DataDB, _ string, itm interface{}) error { return db.SetRatingPlanDrv(itm.(*RatingPlan)) },
+		zero: (*RatingPlan)(nil),
+	},
+	utils.RatingProfilePrefix: {
+		get:  func(db DataDB, key string) (interface{}, error) { return db.GetRatingProfileDrv(key) },
+		set:  func(db DataDB, _ string, itm interface{}) error { return db.SetRatingProfileDrv(itm.(*RatingProfile)) },
+		rem:  func(db DataDB, key string) error { return db.RemoveRatingProfileDrv(key) },
+		zero: (*RatingProfile)(nil),
+	},
+	utils.ActionPrefix: {
+		get:  func(db DataDB, key string) (interface{}, error) { return db.GetActionsDrv(key) },
+		set:  func(db DataDB, key string, itm interface{}) error { return db.SetActionsDrv(key, itm.(Actions)) },
+		zero: Actions(nil),
+	},
+	utils.ActionPlanPrefix: {
+		get:  func(db DataDB, key string) (interface{}, error) { return db.GetActionPlanDrv(key) },
+		set:  func(db DataDB, key string, itm interface{}) error { return db.SetActionPlanDrv(key, itm.(*ActionPlan)) },
+		zero: (*ActionPlan)(nil),
+	},
+	utils.AccountActionPlansPrefix: {
+		get: func(db DataDB, key string) (interface{}, error) { return db.GetAccountActionPlansDrv(key) },
+		set: func(db DataDB, key string, itm interface{}) error {
+			return db.SetAccountActionPlansDrv(key, itm.([]string))
+		},
+		rem:  func(db DataDB, key string) error { return db.RemAccountActionPlansDrv(key) },
+		zero: []string(nil),
+	},
+	utils.ActionTriggerPrefix: {
+		get: func(db DataDB, key string) (interface{}, error) { return db.GetActionTriggersDrv(key) },
+		set: func(db DataDB, key string, itm interface{}) error {
+			return db.SetActionTriggersDrv(key, itm.(ActionTriggers))
+		},
+		zero: ActionTriggers(nil),
+	},
+	utils.SharedGroupPrefix: {
+		get:  func(db DataDB, key string) (interface{}, error) { return db.GetSharedGroupDrv(key) },
+		set:  func(db DataDB, _ string, itm interface{}) error { return db.SetSharedGroupDrv(itm.(*SharedGroup)) },
+		zero: (*SharedGroup)(nil),
+	},
+	utils.AccountPrefix: {
+		get:  func(db DataDB, key string) (interface{}, error) { return db.GetAccountDrv(key) },
+		set:  func(db DataDB, _ string, itm interface{}) error { return db.SetAccountDrv(itm.(*Account)) },
+		rem:  func(db DataDB, key string) error { return db.RemoveAccountDrv(key) },
+		zero: (*Account)(nil),
+	},
+	utils.TimingsPrefix: {
+		get:  func(db DataDB, key string) (interface{}, error) { return db.GetTimingDrv(key) },
+		set:  func(db DataDB, _ string, itm interface{}) error { return db.SetTimingDrv(itm.(*utils.TPTiming)) },
+		zero: (*utils.TPTiming)(nil),
+	},
+	utils.ResourceProfilesPrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetResourceProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error {
+			return db.SetResourceProfileDrv(itm.(*ResourceProfile))
+		},
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemoveResourceProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*ResourceProfile)(nil),
+	},
+	utils.ResourcesPrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetResourceDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error { return db.SetResourceDrv(itm.(*Resource)) },
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemoveResourceDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*Resource)(nil),
+	},
+	utils.StatQueueProfilePrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetStatQueueProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error {
+			return db.SetStatQueueProfileDrv(itm.(*StatQueueProfile))
+		},
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemStatQueueProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*StatQueueProfile)(nil),
+	},
+	utils.StatQueuePrefix: { // handled as StoredStatQueue since the metrics are interfaces
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			sq, err := db.GetStatQueueDrv(tntID.Tenant, tntID.ID)
+			if err != nil {
+				return nil, err
+			}
+			return NewStoredStatQueue(sq, dataDBItemMarshaler)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error {
+			sq, err := itm.(*StoredStatQueue).AsStatQueue(dataDBItemMarshaler)
+			if err != nil {
+				return err
+			}
+			return db.SetStatQueueDrv(nil, sq)
+		},
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemStatQueueDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*StoredStatQueue)(nil),
+	},
+	utils.ThresholdProfilePrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetThresholdProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error {
+			return db.SetThresholdProfileDrv(itm.(*ThresholdProfile))
+		},
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemThresholdProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*ThresholdProfile)(nil),
+	},
+	utils.ThresholdPrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetThresholdDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error { return db.SetThresholdDrv(itm.(*Threshold)) },
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemoveThresholdDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*Threshold)(nil),
+	},
+	utils.FilterPrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetFilterDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error { return db.SetFilterDrv(itm.(*Filter)) },
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemoveFilterDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*Filter)(nil),
+	},
+	utils.RouteProfilePrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetRouteProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error {
+			return db.SetRouteProfileDrv(itm.(*RouteProfile))
+		},
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemoveRouteProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*RouteProfile)(nil),
+	},
+	utils.AttributeProfilePrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetAttributeProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error {
+			return db.SetAttributeProfileDrv(itm.(*AttributeProfile))
+		},
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemoveAttributeProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*AttributeProfile)(nil),
+	},
+	utils.ChargerProfilePrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetChargerProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error {
+			return db.SetChargerProfileDrv(itm.(*ChargerProfile))
+		},
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemoveChargerProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*ChargerProfile)(nil),
+	},
+	utils.DispatcherProfilePrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetDispatcherProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error {
+			return db.SetDispatcherProfileDrv(itm.(*DispatcherProfile))
+		},
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemoveDispatcherProfileDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*DispatcherProfile)(nil),
+	},
+	utils.DispatcherHostPrefix: {
+		get: func(db DataDB, key string) (interface{}, error) {
+			tntID := utils.NewTenantID(key)
+			return db.GetDispatcherHostDrv(tntID.Tenant, tntID.ID)
+		},
+		set: func(db DataDB, _ string, itm interface{}) error {
+			return db.SetDispatcherHostDrv(itm.(*DispatcherHost))
+		},
+		rem: func(db DataDB, key string) error {
+			tntID := utils.NewTenantID(key)
+			return db.RemoveDispatcherHostDrv(tntID.Tenant, tntID.ID)
+		},
+		zero: (*DispatcherHost)(nil),
+	},
+	utils.AttributeFilterIndexes:  dataDBIndexesHandler(utils.CacheAttributeFilterIndexes),
+	utils.ResourceFilterIndexes:   dataDBIndexesHandler(utils.CacheResourceFilterIndexes),
+	utils.StatFilterIndexes:       dataDBIndexesHandler(utils.CacheStatFilterIndexes),
+	utils.ThresholdFilterIndexes:  dataDBIndexesHandler(utils.CacheThresholdFilterIndexes),
+	utils.RouteFilterIndexes:      dataDBIndexesHandler(utils.CacheRouteFilterIndexes),
+	utils.ChargerFilterIndexes:    dataDBIndexesHandler(utils.CacheChargerFilterIndexes),
+	utils.DispatcherFilterIndexes: dataDBIndexesHandler(utils.CacheDispatcherFilterIndexes),
+	utils.FilterIndexPrfx:         dataDBIndexesHandler(utils.CacheReverseFilterIndexes),
+}
+
+// dataDBItemKeys returns the keys used by the dataDBItemHandlers out of the ones returned
+// by GetKeysForPrefix, the indexes being handled once per tntCtx
+func dataDBItemKeys(db DataDB, prfx string) (keys []string, err error) {
+	var dbKeys []string
+	if dbKeys, err = db.GetKeysForPrefix(prfx); err != nil {
+		return
+	}
+	keys = make([]string, 0, len(dbKeys))
+	var tntCtxs utils.StringSet
+	if filterIndexesPrefixMap.Has(prfx) {
+		tntCtxs = make(utils.StringSet)
+	}
+	for _, dbKey := range dbKeys {
+		key := strings.TrimPrefix(dbKey, prfx)
+		if tntCtxs != nil {
+			if prfx == utils.FilterIndexPrfx {
+				idx := strings.LastIndexByte(key, utils.InInFieldSep[0])
+				if idx < 0 {
+					return nil, fmt.Errorf("WRONG_IDX_KEY_FORMAT<%s>", key)
+				}
+				key = key[:idx]
+			} else if key, _, err = splitFilterIndex(key); err != nil {
+				return
+			}
+			if tntCtxs.Has(key) {
+				continue
+			}
+			tntCtxs.Add(key)
+		}
+		keys = append(keys, key)
+	}
+	return
+}
+
+// syncDataDB flushes the to DataDB and copies all the data of the from DataDB into it
+func syncDataDB(from, to DataDB) (err error) {
+	if err = to.Flush(utils.EmptyString); err != nil {
+		return
+	}
+	return copyDataDB(from, to)
+}
+
+// copyDataDB copies all the items, load IDs and versions of the from DataDB into the to DataDB
+// the task queue and the load history are not copied
+func copyDataDB(from, to DataDB) (err error) {
+	for _, prfx := range dataDBSyncPrefixes {
+		hdlr := dataDBItemHandlers[prfx]
+		var keys []string
+		if keys, err = dataDBItemKeys(from, prfx); err != nil {
+			return fmt.Errorf("error <%s> querying keys for prefix: <%s>", err.Error(), prfx)
+		}
+		for _, key := range keys {
+			var itm interface{}
+			if itm, err = hdlr.get(from, key); err == utils.ErrNotFound { // removed in the meantime
+				err = nil
+				continue
+			} else if err == nil {
+				err = hdlr.set(to, key, itm)
+			}
+			if err != nil {
+				return fmt.Errorf("error <%s> copying item with key: <%s>", err.Error(), prfx+key)
+			}
+		}
+	}
+	var loadIDs map[string]int64
+	if loadIDs, err = from.GetItemLoadIDsDrv(utils.EmptyString); err != nil && err != utils.ErrNotFound {
+		return
+	} else if err == nil && len(loadIDs) != 0 {
+		if err = to.SetLoadIDsDrv(loadIDs); err != nil {
+			return
+		}
+	}
+	var vrs Versions
+	if vrs, err = from.GetVersions(utils.EmptyString); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	return to.SetVersions(vrs, true)
+}
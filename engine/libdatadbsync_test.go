@@ -0,0 +1,137 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestSyncDataDB(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	from := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dmFrom := NewDataManager(from, cfg.CacheCfg(), nil)
+	dst := &Destination{Id: "DST_1001", Prefixes: []string{"1001", "1002"}}
+	if err := dmFrom.SetDestination(dst, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmFrom.SetReverseDestination(dst.Id, dst.Prefixes, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	fltr := &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_1",
+		Rules: []*FilterRule{{
+			Type:    utils.MetaString,
+			Element: utils.DynamicDataPrefix + utils.MetaReq + utils.NestingSep + utils.AccountField,
+			Values:  []string{"1001"},
+		}},
+	}
+	if err := fltr.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dmFrom.SetFilter(fltr, true); err != nil {
+		t.Fatal(err)
+	}
+	attrPrf := &AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ATTR_1",
+		Contexts:  []string{utils.MetaAny},
+		FilterIDs: []string{"FLTR_1"},
+		Attributes: []*Attribute{{
+			Path:  utils.MetaReq + utils.NestingSep + utils.Subject,
+			Type:  utils.MetaConstant,
+			Value: config.NewRSRParsersMustCompile("1002", utils.InfieldSep),
+		}},
+	}
+	if err := dmFrom.SetAttributeProfile(attrPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	acnt := &Account{ID: "cgrates.org:1001"}
+	if err := dmFrom.SetAccount(acnt); err != nil {
+		t.Fatal(err)
+	}
+	loadIDs := map[string]int64{utils.CacheAttributeProfiles: 10}
+	if err := from.SetLoadIDsDrv(loadIDs); err != nil {
+		t.Fatal(err)
+	}
+	if err := from.SetVersions(CurrentDataDBVersions(), true); err != nil {
+		t.Fatal(err)
+	}
+
+	to := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	if err := to.SetAccountDrv(&Account{ID: "cgrates.org:stale"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := syncDataDB(from, to); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := to.GetAccountDrv("cgrates.org:stale"); err != utils.ErrNotFound {
+		t.Errorf("Expected the stale account to be flushed, received: %v", err)
+	}
+	if rcv, err := to.GetAccountDrv(acnt.ID); err != nil {
+		t.Error(err)
+	} else if exp, err := from.GetAccountDrv(acnt.ID); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if rcv, err := to.GetDestinationDrv(dst.Id, utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(dst, rcv) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(dst), utils.ToJSON(rcv))
+	}
+	if rcv, err := to.GetReverseDestinationDrv("1002", utils.NonTransactional); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual([]string{dst.Id}, rcv) {
+		t.Errorf("Expected: %q ,received: %q", []string{dst.Id}, rcv)
+	}
+	if rcv, err := to.GetAttributeProfileDrv(attrPrf.Tenant, attrPrf.ID); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(attrPrf, rcv) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(attrPrf), utils.ToJSON(rcv))
+	}
+	for idxType, tntCtx := range map[string]string{
+		utils.CacheAttributeFilterIndexes: "cgrates.org:*any",
+		utils.CacheReverseFilterIndexes:   "cgrates.org:FLTR_1",
+	} {
+		exp, err := from.GetIndexesDrv(idxType, tntCtx, utils.EmptyString)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if rcv, err := to.GetIndexesDrv(idxType, tntCtx, utils.EmptyString); err != nil {
+			t.Error(err)
+		} else if !reflect.DeepEqual(exp, rcv) {
+			t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+		}
+	}
+	if rcv, err := to.GetItemLoadIDsDrv(utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(loadIDs, rcv) {
+		t.Errorf("Expected: %v ,received: %v", loadIDs, rcv)
+	}
+	if rcv, err := to.GetVersions(utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(CurrentDataDBVersions(), rcv) {
+		t.Errorf("Expected: %v ,received: %v", CurrentDataDBVersions(), rcv)
+	}
+}
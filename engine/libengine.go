@@ -20,6 +20,7 @@ package engine
 
 import (
 	"fmt"
+	"reflect"
 	"strings"
 	"time"
 
@@ -82,6 +83,11 @@ func NewRPCConnection(cfg *config.RemoteHost, keyPath, certPath, caPath string,
 			utils.FirstIntNonEmpty(cfg.Reconnects, reconnects), utils.FirstDurationNonEmpty(cfg.ConnectTimeout, connectTimeout), utils.FirstDurationNonEmpty(cfg.ReplyTimeout, replyTimeout),
 			utils.FirstNonEmpty(cfg.Transport, rpcclient.GOBrpc), nil, lazyConnect, biRPCClient)
 	}
+	if cfg.AuthKey != utils.EmptyString && client != nil &&
+		cfg.Address != rpcclient.InternalRPC &&
+		cfg.Address != rpcclient.BiRPCInternal { // the internal requests are not authorized
+		client = &authKeyConnector{ClientConnector: client, key: cfg.AuthKey}
+	}
 	if connID != utils.EmptyString &&
 		err == nil {
 		connCache.Set(id, client, nil)
@@ -89,6 +95,73 @@ func NewRPCConnection(cfg *config.RemoteHost, keyPath, certPath, caPath string,
 	return
 }
 
+// authKeyConnector sends the key as *authKey API option on each request
+type authKeyConnector struct {
+	rpcclient.ClientConnector
+	key string
+}
+
+// Call implements rpcclient.ClientConnector
+// the arguments without APIOpts rely on the connection being authenticated via APIAuthSv1.Authenticate,
+// done when such a request is not authorized, ie: on the first request or after reconnecting
+func (c *authKeyConnector) Call(serviceMethod string, args interface{}, reply interface{}) (err error) {
+	if keyArgs, withKey := argsWithAuthKey(args, c.key); withKey {
+		return c.ClientConnector.Call(serviceMethod, keyArgs, reply)
+	}
+	if err = c.ClientConnector.Call(serviceMethod, args, reply); err == nil ||
+		err.Error() != utils.ErrUnauthorizedApi.Error() {
+		return
+	}
+	var rply string
+	if err = c.ClientConnector.Call(utils.APIAuthSv1Authenticate, &utils.TenantWithAPIOpts{
+		APIOpts: map[string]interface{}{utils.OptsAuthKey: c.key},
+	}, &rply); err != nil {
+		return
+	}
+	return c.ClientConnector.Call(serviceMethod, args, reply)
+}
+
+// argsWithAuthKey returns a copy of the arguments having the key as *authKey API option
+// the arguments are copied so the ones of the caller, possibly sent over other connections, are not modified
+// the arguments without APIOpts are returned as they are, withKey being false
+func argsWithAuthKey(args interface{}, key string) (_ interface{}, withKey bool) {
+	v := reflect.ValueOf(args)
+	if v.Kind() != reflect.Ptr || v.IsNil() || v.Elem().Kind() != reflect.Struct {
+		return args, false
+	}
+	fld, has := v.Elem().Type().FieldByName(utils.APIOpts)
+	if !has || fld.Type != reflect.TypeOf(map[string]interface{}{}) {
+		return args, false
+	}
+	cp := reflect.New(v.Elem().Type())
+	cp.Elem().Set(v.Elem())
+	strct := cp.Elem()
+	for _, idx := range fld.Index[:len(fld.Index)-1] { // copy the embedded structs up to the APIOpts
+		f := strct.Field(idx)
+		if f.Kind() == reflect.Ptr {
+			if f.IsNil() || !f.CanSet() {
+				return args, false
+			}
+			emb := reflect.New(f.Elem().Type())
+			emb.Elem().Set(f.Elem())
+			f.Set(emb)
+			f = emb
+		}
+		strct = reflect.Indirect(f)
+	}
+	opts := strct.Field(fld.Index[len(fld.Index)-1])
+	if !opts.CanSet() {
+		return args, false
+	}
+	newOpts := make(map[string]interface{}, opts.Len()+1)
+	for k, v := range opts.Interface().(map[string]interface{}) {
+		newOpts[k] = v
+	}
+	newOpts[utils.OptsAuthKey] = key
+	opts.Set(reflect.ValueOf(newOpts))
+	return cp.Interface(), true
+}
+
 // IntRPC is the global variable that is used to comunicate with all the subsystems internally
 var IntRPC RPCClientSet
 
@@ -308,3 +308,89 @@ func TestLibengineCallNilArgument(t *testing.T) {
 		t.Errorf("\nexpected: <%+v>, \nreceived: <%+v>", experr, err)
 	}
 }
+
+func TestLibengineArgsWithAuthKey(t *testing.T) {
+	args := &utils.CGREvent{
+		Tenant:  "cgrates.org",
+		ID:      "EV1",
+		APIOpts: map[string]interface{}{utils.OptsAPIKey: "attr12345"},
+	}
+	keyArgs, withKey := argsWithAuthKey(args, "k3y")
+	if !withKey {
+		t.Error("Expected the key to be added")
+	}
+	rcv, canCast := keyArgs.(*utils.CGREvent)
+	if !canCast {
+		t.Fatalf("Expected *utils.CGREvent, received: %T", rcv)
+	}
+	exp := &utils.CGREvent{
+		Tenant:  "cgrates.org",
+		ID:      "EV1",
+		APIOpts: map[string]interface{}{utils.OptsAPIKey: "attr12345", utils.OptsAuthKey: "k3y"},
+	}
+	if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if _, has := args.APIOpts[utils.OptsAuthKey]; has {
+		t.Error("Expected the arguments of the caller to not be modified")
+	}
+
+	// APIOpts within an embedded struct
+	evArgs := &CGREventWithEeIDs{CGREvent: &utils.CGREvent{Tenant: "cgrates.org"}}
+	if rcv, _ := argsWithAuthKey(evArgs, "k3y"); rcv.(*CGREventWithEeIDs).APIOpts[utils.OptsAuthKey] != "k3y" {
+		t.Errorf("Expected the key within the APIOpts, received: %s", utils.ToJSON(rcv))
+	} else if evArgs.APIOpts != nil {
+		t.Errorf("Expected the arguments of the caller to not be modified, received: %s", utils.ToJSON(evArgs))
+	}
+
+	// arguments without APIOpts are sent as they are
+	strArg := utils.StringPointer("test")
+	if rcv, withKey := argsWithAuthKey(strArg, "k3y"); rcv != strArg || withKey {
+		t.Errorf("Expected %v, received: %v", strArg, rcv)
+	}
+	if rcv, withKey := argsWithAuthKey(&CGREventWithEeIDs{}, "k3y"); rcv.(*CGREventWithEeIDs).CGREvent != nil || withKey {
+		t.Errorf("Expected nil CGREvent, received: %s", utils.ToJSON(rcv))
+	}
+}
+
+func TestLibengineAuthKeyConnectorAuthenticate(t *testing.T) {
+	var authenticated bool
+	var calls []string
+	conn := &authKeyConnector{
+		ClientConnector: clMock(func(method string, args interface{}, _ interface{}) error {
+			calls = append(calls, method)
+			if method == utils.APIAuthSv1Authenticate {
+				if args.(*utils.TenantWithAPIOpts).APIOpts[utils.OptsAuthKey] != "k3y" {
+					return utils.ErrUnauthorizedApi
+				}
+				authenticated = true
+				return nil
+			}
+			if !authenticated {
+				return utils.ErrUnauthorizedApi
+			}
+			return nil
+		}),
+		key: "k3y",
+	}
+	if err := conn.Call(utils.APIerSv1ComputeActionPlanIndexes, utils.StringPointer("test"), new(string)); err != nil {
+		t.Fatal(err)
+	}
+	exp := []string{utils.APIerSv1ComputeActionPlanIndexes, utils.APIAuthSv1Authenticate, utils.APIerSv1ComputeActionPlanIndexes}
+	if !reflect.DeepEqual(exp, calls) {
+		t.Errorf("Expected %v, received: %v", exp, calls)
+	}
+	calls = nil
+	if err := conn.Call(utils.APIerSv1ComputeActionPlanIndexes, utils.StringPointer("test"), new(string)); err != nil {
+		t.Fatal(err)
+	}
+	if exp := []string{utils.APIerSv1ComputeActionPlanIndexes}; !reflect.DeepEqual(exp, calls) {
+		t.Errorf("Expected the connection to stay authenticated, received: %v", calls)
+	}
+
+	conn.key = "wrong"
+	authenticated = false
+	if err := conn.Call(utils.APIerSv1ComputeActionPlanIndexes, utils.StringPointer("test"), new(string)); err != utils.ErrUnauthorizedApi {
+		t.Errorf("Expected %v, received: %v", utils.ErrUnauthorizedApi, err)
+	}
+}
@@ -0,0 +1,170 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/utils"
+)
+
+// FilterRuleExplain details the evaluation of one FilterRule
+type FilterRuleExplain struct {
+	Type         string
+	Element      string
+	Values       []string
+	ElementValue string // value of the Element within the event, N/A if missing
+	// ResolvedValues are the Values parsed out of the event, populated only for dynamic Values
+	ResolvedValues []string `json:",omitempty"`
+	IndexKeys      []string `json:",omitempty"` // filter index keys built for the rule, empty if not indexed
+	Pass           bool
+	Error          string `json:",omitempty"`
+}
+
+// FilterExplain details the evaluation of one filter
+type FilterExplain struct {
+	FilterID string
+	Active   bool // false if out of the ActivationInterval, the filter being ignored
+	Pass     bool
+	Rules    []*FilterRuleExplain
+}
+
+// ProfileExplain details how a profile was considered for the event
+type ProfileExplain struct {
+	ProfileID string
+	Filters   []*FilterExplain `json:",omitempty"`
+	Selected  bool
+	Reason    string `json:",omitempty"` // why the profile was not selected
+}
+
+// newProfileExplain appends the explain of the profile to prfsExpl, returning nil if not explaining
+func newProfileExplain(prfsExpl *[]*ProfileExplain, prfID string) (prfExpl *ProfileExplain) {
+	if prfsExpl == nil {
+		return
+	}
+	prfExpl = &ProfileExplain{ProfileID: prfID}
+	*prfsExpl = append(*prfsExpl, prfExpl)
+	return
+}
+
+// setReason records why the profile was not selected, nil safe so it can be used when not explaining
+func (prfExpl *ProfileExplain) setReason(reason string) {
+	if prfExpl != nil {
+		prfExpl.Reason = reason
+	}
+}
+
+// passExplained is Pass recording the details of each filter within prfExpl, if not nil
+func (fS *FilterS) passExplained(tenant string, filterIDs []string,
+	ev utils.DataProvider, prfExpl *ProfileExplain) (pass bool, err error) {
+	if prfExpl == nil {
+		return fS.Pass(tenant, filterIDs, ev)
+	}
+	prfExpl.Filters, pass, err = fS.PassWithExplain(tenant, filterIDs, ev)
+	return
+}
+
+// PassWithExplain is Pass returning also the details of each filter
+// all the rules are evaluated so the ones failing can be traced
+func (fS *FilterS) PassWithExplain(tenant string, filterIDs []string,
+	ev utils.DataProvider) (fltrsExpl []*FilterExplain, pass bool, err error) {
+	pass = true
+	if len(filterIDs) == 0 {
+		return
+	}
+	dDP := newDynamicDP(fS.cfg.FilterSCfg().ResourceSConns, fS.cfg.FilterSCfg().StatSConns,
+		fS.cfg.FilterSCfg().ApierSConns, tenant, ev)
+	fltrsExpl = make([]*FilterExplain, len(filterIDs))
+	for i, fltrID := range filterIDs {
+		var f *Filter
+		if f, err = fS.dm.GetFilter(tenant, fltrID,
+			true, true, utils.NonTransactional); err != nil {
+			if err == utils.ErrNotFound {
+				err = utils.ErrPrefixNotFound(fltrID)
+			}
+			return nil, false, err
+		}
+		fltrExpl := &FilterExplain{
+			FilterID: fltrID,
+			Active: f.ActivationInterval == nil ||
+				f.ActivationInterval.IsActiveAtTime(time.Now()),
+			Pass:  true,
+			Rules: make([]*FilterRuleExplain, len(f.Rules)),
+		}
+		for j, fltr := range f.Rules {
+			fltrExpl.Rules[j] = fltr.explain(dDP)
+			if !fltrExpl.Rules[j].Pass {
+				fltrExpl.Pass = false
+			}
+		}
+		if fltrExpl.Active && !fltrExpl.Pass {
+			pass = false
+		}
+		fltrsExpl[i] = fltrExpl
+	}
+	return
+}
+
+// explain evaluates the FilterRule, recording the value of the Element
+func (fltr *FilterRule) explain(dDP utils.DataProvider) (rlExpl *FilterRuleExplain) {
+	rlExpl = &FilterRuleExplain{
+		Type:         fltr.Type,
+		Element:      fltr.Element,
+		Values:       fltr.Values,
+		ElementValue: utils.NotAvailable,
+	}
+	if fltr.rsrElement != nil {
+		if elmVal, err := fltr.rsrElement.ParseDataProvider(dDP); err == nil {
+			rlExpl.ElementValue = elmVal
+		}
+	}
+	for i, val := range fltr.Values {
+		if !strings.HasPrefix(val, utils.DynamicDataPrefix) ||
+			i >= len(fltr.rsrValues) || fltr.rsrValues[i] == nil {
+			continue
+		}
+		if rlExpl.ResolvedValues == nil {
+			rlExpl.ResolvedValues = make([]string, len(fltr.Values))
+			copy(rlExpl.ResolvedValues, fltr.Values)
+		}
+		rlExpl.ResolvedValues[i] = utils.NotAvailable
+		if resVal, err := fltr.rsrValues[i].ParseDataProvider(dDP); err == nil {
+			rlExpl.ResolvedValues[i] = resVal
+		}
+	}
+	rlExpl.IndexKeys = fltr.indexKeys()
+	var err error
+	if rlExpl.Pass, err = fltr.Pass(dDP); err != nil {
+		rlExpl.Error = err.Error()
+	}
+	return
+}
+
+// ArgsExplainFilters are the arguments for APIerSv1.ExplainFilters
+type ArgsExplainFilters struct {
+	FilterIDs []string
+	*utils.CGREvent
+}
+
+// FiltersExplain is the reply of APIerSv1.ExplainFilters
+type FiltersExplain struct {
+	Pass    bool
+	Filters []*FilterExplain
+}
@@ -0,0 +1,433 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+func TestFilterSPassWithExplain(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dataDB := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+	fS := NewFilterS(cfg, nil, dm)
+	fltrInactive := &Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_INACTIVE",
+		Rules: []*FilterRule{{
+			Type:    utils.MetaString,
+			Element: "~*req.Account",
+			Values:  []string{"1002"},
+		}},
+		ActivationInterval: &utils.ActivationInterval{
+			ActivationTime: time.Date(2014, 7, 14, 14, 25, 0, 0, time.UTC),
+			ExpiryTime:     time.Date(2014, 7, 15, 14, 25, 0, 0, time.UTC),
+		},
+	}
+	if err := fltrInactive.Compile(); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetFilter(fltrInactive, true); err != nil {
+		t.Fatal(err)
+	}
+	ev := utils.MapStorage{utils.MetaReq: utils.MapStorage{utils.AccountField: "1001"}}
+
+	exp := []*FilterExplain{
+		{
+			FilterID: "*string:~*req.Account:1001",
+			Active:   true,
+			Pass:     true,
+			Rules: []*FilterRuleExplain{{
+				Type:         utils.MetaString,
+				Element:      "~*req.Account",
+				Values:       []string{"1001"},
+				ElementValue: "1001",
+				IndexKeys:    []string{"*string:*req.Account:1001"},
+				Pass:         true,
+			}},
+		},
+		{
+			FilterID: "FLTR_INACTIVE",
+			Pass:     false,
+			Rules: []*FilterRuleExplain{{
+				Type:         utils.MetaString,
+				Element:      "~*req.Account",
+				Values:       []string{"1002"},
+				ElementValue: "1001",
+				IndexKeys:    []string{"*string:*req.Account:1002"},
+			}},
+		},
+	}
+	if rcv, pass, err := fS.PassWithExplain("cgrates.org",
+		[]string{"*string:~*req.Account:1001", "FLTR_INACTIVE"}, ev); err != nil {
+		t.Fatal(err)
+	} else if !pass {
+		t.Error("expected to pass since the failing filter is not active")
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+
+	// all the rules are evaluated even after the first one failing
+	exp = []*FilterExplain{{
+		FilterID: "*prefix:~*req.Destination:+49",
+		Active:   true,
+		Rules: []*FilterRuleExplain{{
+			Type:         utils.MetaPrefix,
+			Element:      "~*req.Destination",
+			Values:       []string{"+49"},
+			ElementValue: utils.NotAvailable,
+			IndexKeys:    []string{"*prefix:*req.Destination:+49"},
+		}},
+	}, {
+		FilterID: "*string:~*req.Account:1001",
+		Active:   true,
+		Pass:     true,
+		Rules: []*FilterRuleExplain{{
+			Type:         utils.MetaString,
+			Element:      "~*req.Account",
+			Values:       []string{"1001"},
+			ElementValue: "1001",
+			IndexKeys:    []string{"*string:*req.Account:1001"},
+			Pass:         true,
+		}},
+	}}
+	if rcv, pass, err := fS.PassWithExplain("cgrates.org",
+		[]string{"*prefix:~*req.Destination:+49", "*string:~*req.Account:1001"}, ev); err != nil {
+		t.Fatal(err)
+	} else if pass {
+		t.Error("expected not to pass")
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+
+	if _, _, err := fS.PassWithExplain("cgrates.org",
+		[]string{"FLTR_MISSING"}, ev); err == nil ||
+		err.Error() != utils.ErrPrefixNotFound("FLTR_MISSING").Error() {
+		t.Errorf("expected %s, received: %v", utils.ErrPrefixNotFound("FLTR_MISSING"), err)
+	}
+}
+
+func TestAttributesV1ProcessEventExplain(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dataDB := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+	alS := NewAttributeService(dm, NewFilterS(cfg, nil, dm), cfg)
+	for _, attrPrf := range []*AttributeProfile{
+		{
+			Tenant:    "cgrates.org",
+			ID:        "ATTR_HIGH",
+			FilterIDs: []string{"*string:~*req.Account:1001"},
+			Contexts:  []string{utils.MetaAny},
+			Attributes: []*Attribute{
+				{
+					Path:  utils.MetaReq + utils.NestingSep + utils.Subject,
+					Value: config.NewRSRParsersMustCompile("1002", utils.InfieldSep),
+				},
+				{
+					FilterIDs: []string{"*string:~*req.Category:sms"},
+					Path:      utils.MetaReq + utils.NestingSep + utils.Category,
+					Value:     config.NewRSRParsersMustCompile("text", utils.InfieldSep),
+				},
+			},
+			Weight: 20,
+		},
+		{
+			Tenant:    "cgrates.org",
+			ID:        "ATTR_LOW",
+			FilterIDs: []string{"*string:~*req.Account:1001"},
+			Contexts:  []string{utils.MetaAny},
+			Attributes: []*Attribute{{
+				Path:  utils.MetaReq + utils.NestingSep + utils.Subject,
+				Value: config.NewRSRParsersMustCompile("1003", utils.InfieldSep),
+			}},
+			Weight: 10,
+		},
+		{
+			Tenant:    "cgrates.org",
+			ID:        "ATTR_NO_MATCH",
+			FilterIDs: []string{"*string:~*req.Account:1004"},
+			Contexts:  []string{utils.MetaAny},
+			Attributes: []*Attribute{{
+				Path:  utils.MetaReq + utils.NestingSep + utils.Subject,
+				Value: config.NewRSRParsersMustCompile("1005", utils.InfieldSep),
+			}},
+			Weight: 30,
+		},
+	} {
+		if err := dm.SetAttributeProfile(attrPrf, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ExplainEv",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Subject:      "1001",
+			utils.Category:     "call",
+		},
+		APIOpts: map[string]interface{}{
+			utils.OptsExplain: true,
+			utils.OptsAttributesProfileIDs: []string{
+				"ATTR_HIGH", "ATTR_LOW", "ATTR_NO_MATCH"},
+		},
+	}
+	fltrAcnt := func(acnt string, pass bool) []*FilterExplain {
+		return []*FilterExplain{{
+			FilterID: "*string:~*req.Account:" + acnt,
+			Active:   true,
+			Pass:     pass,
+			Rules: []*FilterRuleExplain{{
+				Type:         utils.MetaString,
+				Element:      "~*req.Account",
+				Values:       []string{acnt},
+				ElementValue: "1001",
+				IndexKeys:    []string{"*string:*req.Account:" + acnt},
+				Pass:         pass,
+			}},
+		}}
+	}
+	exp := []*AttrRunExplain{{
+		ProcessRun: 1,
+		Profiles: []*ProfileExplain{
+			{
+				ProfileID: "cgrates.org:ATTR_HIGH",
+				Filters:   fltrAcnt("1001", true),
+				Selected:  true,
+			},
+			{
+				ProfileID: "cgrates.org:ATTR_LOW",
+				Filters:   fltrAcnt("1001", true),
+				Reason:    utils.ExplainLowerWeight,
+			},
+			{
+				ProfileID: "cgrates.org:ATTR_NO_MATCH",
+				Filters:   fltrAcnt("1004", false),
+				Reason:    utils.ExplainFiltersNotPassing,
+			},
+		},
+		MatchedProfile: "cgrates.org:ATTR_HIGH",
+		Attributes: []*AttrFieldExplain{
+			{
+				Path:    utils.MetaReq + utils.NestingSep + utils.Subject,
+				Type:    utils.MetaVariable,
+				Applied: true,
+				Before:  "1001",
+				After:   "1002",
+			},
+			{
+				Path: utils.MetaReq + utils.NestingSep + utils.Category,
+				Type: utils.MetaVariable,
+				Filters: []*FilterExplain{{
+					FilterID: "*string:~*req.Category:sms",
+					Active:   true,
+					Rules: []*FilterRuleExplain{{
+						Type:         utils.MetaString,
+						Element:      "~*req.Category",
+						Values:       []string{"sms"},
+						ElementValue: "call",
+						IndexKeys:    []string{"*string:*req.Category:sms"},
+					}},
+				}},
+			},
+		},
+	}}
+	var rply AttrSProcessEventReply
+	if err := alS.V1ProcessEvent(ev.Clone(), &rply); err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(exp, rply.Explain) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply.Explain))
+	} else if !reflect.DeepEqual([]string{"cgrates.org:ATTR_HIGH"}, rply.MatchedProfiles) {
+		t.Errorf("unexpected matched profiles: %v", rply.MatchedProfiles)
+	}
+
+	// not matching any profile is still returning NOT_FOUND, the reasons reaching only the internal callers
+	ev.APIOpts[utils.OptsAttributesProfileIDs] = []string{"ATTR_NO_MATCH"}
+	exp = []*AttrRunExplain{{
+		ProcessRun: 1,
+		Profiles: []*ProfileExplain{{
+			ProfileID: "cgrates.org:ATTR_NO_MATCH",
+			Filters:   fltrAcnt("1004", false),
+			Reason:    utils.ExplainFiltersNotPassing,
+		}},
+	}}
+	rply = AttrSProcessEventReply{}
+	if err := alS.V1ProcessEvent(ev.Clone(), &rply); err != utils.ErrNotFound {
+		t.Errorf("expected %s, received: %v", utils.ErrNotFound, err)
+	} else if !reflect.DeepEqual(exp, rply.Explain) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rply.Explain))
+	}
+
+	// without the option the reply is not changed
+	delete(ev.APIOpts, utils.OptsExplain)
+	if err := alS.V1ProcessEvent(ev.Clone(), &rply); err != utils.ErrNotFound {
+		t.Errorf("expected %s, received: %v", utils.ErrNotFound, err)
+	}
+}
+
+func TestChargersProcessEventExplain(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.ChargerSCfg().IndexedSelects = false // list also the profiles not matching the indexes
+	cfg.ChargerSCfg().AttributeSConns = []string{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes)}
+	dataDB := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(dataDB, cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+	fltrS := NewFilterS(cfg, nil, dm)
+	alS := NewAttributeService(dm, fltrS, cfg)
+	ccM := &ccMock{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.AttributeSv1ProcessEvent: func(args, reply interface{}) error {
+				return alS.V1ProcessEvent(args.(*utils.CGREvent), reply.(*AttrSProcessEventReply))
+			},
+		},
+	}
+	clientConn := make(chan rpcclient.ClientConnector, 1)
+	clientConn <- ccM
+	cS := &ChargerService{
+		dm:      dm,
+		filterS: fltrS,
+		cfg:     cfg,
+		connMgr: NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+			utils.ConcatenatedKey(utils.MetaInternal, utils.MetaAttributes): clientConn,
+		}),
+	}
+	if err := dm.SetAttributeProfile(&AttributeProfile{
+		Tenant:   "cgrates.org",
+		ID:       "ATTR_RAW",
+		Contexts: []string{utils.MetaAny},
+		Attributes: []*Attribute{{
+			Path:  utils.MetaReq + utils.NestingSep + utils.RequestType,
+			Value: config.NewRSRParsersMustCompile(utils.MetaNone, utils.InfieldSep),
+		}},
+	}, true); err != nil {
+		t.Fatal(err)
+	}
+	for _, cP := range []*ChargerProfile{
+		{
+			Tenant:       "cgrates.org",
+			ID:           "CHRG_RAW",
+			FilterIDs:    []string{"*string:~*req.Account:1001"},
+			RunID:        utils.MetaRaw,
+			AttributeIDs: []string{"ATTR_RAW"},
+		},
+		{
+			Tenant:       "cgrates.org",
+			ID:           "CHRG_OTHER",
+			FilterIDs:    []string{"*string:~*req.Account:1002"},
+			RunID:        "other",
+			AttributeIDs: []string{utils.MetaNone},
+		},
+	} {
+		if err := dm.SetChargerProfile(cP, true); err != nil {
+			t.Fatal(err)
+		}
+	}
+	ev := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ExplainEv",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.RequestType:  utils.MetaPrepaid,
+		},
+		APIOpts: map[string]interface{}{
+			utils.OptsExplain: true,
+		},
+	}
+	fltrAcnt := func(acnt string, pass bool) []*FilterExplain {
+		return []*FilterExplain{{
+			FilterID: "*string:~*req.Account:" + acnt,
+			Active:   true,
+			Pass:     pass,
+			Rules: []*FilterRuleExplain{{
+				Type:         utils.MetaString,
+				Element:      "~*req.Account",
+				Values:       []string{acnt},
+				ElementValue: "1001",
+				IndexKeys:    []string{"*string:*req.Account:" + acnt},
+				Pass:         pass,
+			}},
+		}}
+	}
+	expPrfs := []*ProfileExplain{
+		{
+			ProfileID: "cgrates.org:CHRG_OTHER",
+			Filters:   fltrAcnt("1002", false),
+			Reason:    utils.ExplainFiltersNotPassing,
+		},
+		{
+			ProfileID: "cgrates.org:CHRG_RAW",
+			Filters:   fltrAcnt("1001", true),
+			Selected:  true,
+		},
+	}
+	expAttrs := []*AttrRunExplain{{
+		ProcessRun: 1,
+		Profiles: []*ProfileExplain{{
+			ProfileID: "cgrates.org:ATTR_RAW",
+			Selected:  true,
+		}},
+		MatchedProfile: "cgrates.org:ATTR_RAW",
+		Attributes: []*AttrFieldExplain{{
+			Path:    utils.MetaReq + utils.NestingSep + utils.RequestType,
+			Type:    utils.MetaVariable,
+			Applied: true,
+			Before:  utils.MetaPrepaid,
+			After:   utils.MetaNone,
+		}},
+	}}
+	var rply []*ChrgSProcessEventReply
+	if err := cS.V1ProcessEvent(ev, &rply); err != nil {
+		t.Fatal(err)
+	} else if len(rply) != 1 {
+		t.Fatalf("expected one reply, received: %s", utils.ToJSON(rply))
+	} else if rply[0].ChargerSProfile != "CHRG_RAW" {
+		t.Errorf("unexpected charger: %s", rply[0].ChargerSProfile)
+	} else if rply[0].Explain == nil {
+		t.Fatal("missing explain")
+	} else if !reflect.DeepEqual(expPrfs, rply[0].Explain.Profiles) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(expPrfs), utils.ToJSON(rply[0].Explain.Profiles))
+	} else if !reflect.DeepEqual(expAttrs, rply[0].Explain.Attributes) {
+		t.Errorf("expected: %s, received: %s", utils.ToJSON(expAttrs), utils.ToJSON(rply[0].Explain.Attributes))
+	}
+
+	// no charger matching is still replying with NOT_FOUND
+	ev.Event[utils.AccountField] = "1003"
+	rply = nil
+	if err := cS.V1ProcessEvent(ev, &rply); err != utils.ErrNotFound {
+		t.Errorf("expected %s, received: %v", utils.ErrNotFound, err)
+	} else if rply != nil {
+		t.Errorf("unexpected reply: %s", utils.ToJSON(rply))
+	}
+	var prfsExpl []*ProfileExplain
+	if _, err := cS.explainedChargerProfilesForEvent("cgrates.org", ev, &prfsExpl); err != utils.ErrNotFound {
+		t.Errorf("expected %s, received: %v", utils.ErrNotFound, err)
+	} else if len(prfsExpl) != 2 || prfsExpl[0].Selected || prfsExpl[1].Selected ||
+		prfsExpl[0].Reason != utils.ExplainFiltersNotPassing {
+		t.Errorf("unexpected explain: %s", utils.ToJSON(prfsExpl))
+	}
+}
@@ -0,0 +1,175 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/cgrates/cgrates/utils"
+	"github.com/oschwald/maxminddb-golang"
+)
+
+// earthRadius is the mean radius of the Earth in meters
+const earthRadius = 6371008.8
+
+// geoPoint is a location in decimal degrees
+type geoPoint struct {
+	lat  float64
+	long float64
+}
+
+// newGeoPoint parses the latitude and longitude of a location
+func newGeoPoint(lat, long string) (gp geoPoint, err error) {
+	if gp.lat, err = strconv.ParseFloat(strings.TrimSpace(lat), 64); err != nil {
+		return
+	}
+	if gp.long, err = strconv.ParseFloat(strings.TrimSpace(long), 64); err != nil {
+		return
+	}
+	if gp.lat < -90 || gp.lat > 90 ||
+		gp.long < -180 || gp.long > 180 {
+		err = fmt.Errorf("invalid location <%v,%v>", gp.lat, gp.long)
+	}
+	return
+}
+
+// distance returns the great-circle distance in meters between the two points
+func (gp geoPoint) distance(to geoPoint) float64 {
+	lat1 := gp.lat * math.Pi / 180
+	lat2 := to.lat * math.Pi / 180
+	dLat := lat2 - lat1
+	dLong := (to.long - gp.long) * math.Pi / 180
+	a := math.Sin(dLat/2)*math.Sin(dLat/2) +
+		math.Cos(lat1)*math.Cos(lat2)*math.Sin(dLong/2)*math.Sin(dLong/2)
+	return 2 * earthRadius * math.Asin(math.Sqrt(a))
+}
+
+// geoArea is the area defined by one *geo filter value
+// either a circle with the radius in meters or a polygon
+type geoArea struct {
+	center  geoPoint
+	radius  float64
+	polygon []geoPoint
+}
+
+// newGeoArea parses the *geo filter value with the coordinates separated by space:
+// "lat long radius" for circles or "lat1 long1 lat2 long2 lat3 long3 ..." for polygons
+func newGeoArea(val string) (ga *geoArea, err error) {
+	coords := strings.Fields(val)
+	switch {
+	case len(coords) == 3:
+		ga = new(geoArea)
+		if ga.center, err = newGeoPoint(coords[0], coords[1]); err != nil {
+			return nil, err
+		}
+		if ga.radius, err = strconv.ParseFloat(coords[2], 64); err != nil {
+			return nil, err
+		}
+		if ga.radius <= 0 {
+			return nil, fmt.Errorf("invalid radius in geo area: <%s>", val)
+		}
+	case len(coords) >= 6 && len(coords)%2 == 0:
+		ga = &geoArea{polygon: make([]geoPoint, len(coords)/2)}
+		for i := range ga.polygon {
+			if ga.polygon[i], err = newGeoPoint(coords[2*i], coords[2*i+1]); err != nil {
+				return nil, err
+			}
+		}
+	default:
+		return nil, fmt.Errorf("invalid geo area: <%s>", val)
+	}
+	return
+}
+
+// contains returns true if the point is located within the area
+func (ga *geoArea) contains(gp geoPoint) bool {
+	if ga.polygon == nil {
+		return ga.center.distance(gp) <= ga.radius
+	}
+	// ray casting with the longitude as x and the latitude as y
+	var in bool
+	for i, j := 0, len(ga.polygon)-1; i < len(ga.polygon); j, i = i, i+1 {
+		pi, pj := ga.polygon[i], ga.polygon[j]
+		if (pi.lat > gp.lat) != (pj.lat > gp.lat) &&
+			gp.long < (pj.long-pi.long)*(gp.lat-pi.lat)/(pj.lat-pi.lat)+pi.long {
+			in = !in
+		}
+	}
+	return in
+}
+
+// geoIPDB is the GeoIP database used by the *ipgeo filters
+var geoIPDB = new(geoIPReader)
+
+// geoIPReader opens the MaxMind format database on first lookup
+// reopening it when the path changes (ie: on config reload)
+type geoIPReader struct {
+	sync.RWMutex
+	path string
+	rdr  *maxminddb.Reader
+}
+
+// countryISO returns the ISO code of the country the IP is located in, empty if not found
+func (gr *geoIPReader) countryISO(path string, ip net.IP) (iso string, err error) {
+	if path == utils.EmptyString {
+		return utils.EmptyString, errors.New("GeoIP database not configured")
+	}
+	gr.RLock()
+	if gr.path != path {
+		gr.RUnlock()
+		if err = gr.open(path); err != nil {
+			return
+		}
+		gr.RLock()
+	}
+	defer gr.RUnlock()
+	var rec struct {
+		Country struct {
+			ISOCode string `maxminddb:"iso_code"`
+		} `maxminddb:"country"`
+	}
+	if err = gr.rdr.Lookup(ip, &rec); err != nil {
+		return
+	}
+	return rec.Country.ISOCode, nil
+}
+
+// open replaces the database with the one found at path
+func (gr *geoIPReader) open(path string) (err error) {
+	gr.Lock()
+	defer gr.Unlock()
+	if gr.path == path {
+		return
+	}
+	var rdr *maxminddb.Reader
+	if rdr, err = maxminddb.Open(path); err != nil {
+		return
+	}
+	if gr.rdr != nil {
+		gr.rdr.Close()
+	}
+	gr.path, gr.rdr = path, rdr
+	return
+}
@@ -0,0 +1,236 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"bytes"
+	"encoding/binary"
+	"net"
+	"os"
+	"path"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestGeoAreaContains(t *testing.T) {
+	if _, err := newGeoArea("44.43 26.10"); err == nil {
+		t.Error("expected error for invalid area")
+	}
+	if _, err := newGeoArea("44.43 26.10 -5"); err == nil {
+		t.Error("expected error for negative radius")
+	}
+	if _, err := newGeoArea("95 26.10 500"); err == nil {
+		t.Error("expected error for invalid latitude")
+	}
+	// 5km around Bucharest center
+	circle, err := newGeoArea("44.4268 26.1025 5000")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !circle.contains(geoPoint{lat: 44.4378, long: 26.0946}) { // ~1.4km
+		t.Error("expected to be within the radius")
+	}
+	if circle.contains(geoPoint{lat: 44.5711, long: 26.0850}) { // ~16km
+		t.Error("expected not to be within the radius")
+	}
+	// rectangle around Berlin
+	polygon, err := newGeoArea("52.34 13.09 52.34 13.76 52.68 13.76 52.68 13.09")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !polygon.contains(geoPoint{lat: 52.52, long: 13.405}) {
+		t.Error("expected to be within the polygon")
+	}
+	if polygon.contains(geoPoint{lat: 48.1351, long: 11.582}) {
+		t.Error("expected not to be within the polygon")
+	}
+}
+
+func TestFilterPassGeo(t *testing.T) {
+	dp := utils.MapStorage{
+		utils.MetaReq: utils.MapStorage{
+			"Location":  "44.4378,26.0946",
+			"Latitude":  "52.52",
+			"Longitude": "13.405",
+			"Invalid":   "44.4378",
+		},
+	}
+	for _, tc := range []struct {
+		fltr string
+		pass bool
+	}{
+		{"*geo:~*req.Location:44.4268 26.1025 5000", true},
+		{"*geo:~*req.Location:52.34 13.09 52.34 13.76 52.68 13.76 52.68 13.09", false},
+		{"*geo:~*req.Latitude&~*req.Longitude:44.4268 26.1025 5000|52.34 13.09 52.34 13.76 52.68 13.76 52.68 13.09", true},
+		{"*notgeo:~*req.Latitude&~*req.Longitude:44.4268 26.1025 5000", true},
+		{"*geo:~*req.Invalid:44.4268 26.1025 5000", false},
+		{"*geo:~*req.Missing:44.4268 26.1025 5000", false},
+	} {
+		fltr, err := NewFilterFromInline("cgrates.org", tc.fltr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pass, err := fltr.Rules[0].Pass(dp); err != nil {
+			t.Error(err)
+		} else if pass != tc.pass {
+			t.Errorf("expected %v for %s, received: %v", tc.pass, tc.fltr, pass)
+		}
+	}
+	if _, err := NewFilterFromInline("cgrates.org", "*geo:~*req.Location:44.4268"); err == nil {
+		t.Error("expected error for invalid geo area")
+	}
+}
+
+func TestFilterPassIPGeo(t *testing.T) {
+	dbPath := path.Join(t.TempDir(), "GeoIP-Country.mmdb")
+	if err := writeTestGeoIPDB(dbPath, map[string]string{
+		"81.2.69.0/24":  "GB",
+		"89.160.0.0/16": "SE",
+	}); err != nil {
+		t.Fatal(err)
+	}
+	defer config.SetCgrConfig(config.CgrConfig())
+	cfg := config.NewDefaultCGRConfig()
+	config.SetCgrConfig(cfg)
+
+	dp := utils.MapStorage{
+		utils.MetaReq: utils.MapStorage{
+			"SourceIP":  "81.2.69.160",
+			"OtherIP":   "89.160.20.112",
+			"UnknownIP": "10.0.0.1",
+			"Invalid":   "not_an_ip",
+		},
+	}
+	fltr, err := NewFilterFromInline("cgrates.org", "*ipgeo:~*req.SourceIP:gb|DE")
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fltr.Rules[0].Pass(dp); err == nil {
+		t.Error("expected error without the GeoIP database configured")
+	}
+	cfg.FilterSCfg().GeoIPDB = dbPath
+	for _, tc := range []struct {
+		fltr string
+		pass bool
+	}{
+		{"*ipgeo:~*req.SourceIP:gb|DE", true},
+		{"*ipgeo:~*req.OtherIP:GB|DE", false},
+		{"*notipgeo:~*req.OtherIP:GB|DE", true},
+		{"*ipgeo:~*req.OtherIP:~*req.Country", false},
+		{"*ipgeo:~*req.UnknownIP:GB", false},
+		{"*ipgeo:~*req.Invalid:GB", false},
+		{"*ipgeo:~*req.Missing:GB", false},
+	} {
+		fltr, err := NewFilterFromInline("cgrates.org", tc.fltr)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if pass, err := fltr.Rules[0].Pass(dp); err != nil {
+			t.Error(err)
+		} else if pass != tc.pass {
+			t.Errorf("expected %v for %s, received: %v", tc.pass, tc.fltr, pass)
+		}
+	}
+	dp[utils.MetaReq].(utils.MapStorage)["Country"] = "SE"
+	if fltr, err = NewFilterFromInline("cgrates.org", "*ipgeo:~*req.OtherIP:~*req.Country"); err != nil {
+		t.Fatal(err)
+	}
+	if pass, err := fltr.Rules[0].Pass(dp); err != nil {
+		t.Error(err)
+	} else if !pass {
+		t.Error("expected to pass with the country taken out of the event")
+	}
+}
+
+// writeTestGeoIPDB writes an IPv4 MaxMind format database with the country of each network
+func writeTestGeoIPDB(fPath string, networks map[string]string) (err error) {
+	const empty = -1
+	tree := [][2]int{{empty, empty}}
+	var data bytes.Buffer
+	dataRecord := func(off int) int { return -off - 2 } // data is stored as negative values in tree
+	for cidr, iso := range networks {
+		var ipNet *net.IPNet
+		if _, ipNet, err = net.ParseCIDR(cidr); err != nil {
+			return
+		}
+		off := data.Len()
+		data.Write(mmdbMap(mmdbString("country"), mmdbMap(mmdbString("iso_code"), mmdbString(iso))))
+		ones, _ := ipNet.Mask.Size()
+		ip := ipNet.IP.To4()
+		node := 0
+		for i := 0; i < ones; i++ {
+			bit := (ip[i/8] >> (7 - uint(i%8))) & 1
+			if i == ones-1 {
+				tree[node][bit] = dataRecord(off)
+				break
+			}
+			if tree[node][bit] == empty {
+				tree = append(tree, [2]int{empty, empty})
+				tree[node][bit] = len(tree) - 1
+			}
+			node = tree[node][bit]
+		}
+	}
+	nodeCount := len(tree)
+	var db bytes.Buffer
+	for _, node := range tree {
+		for _, rec := range node {
+			val := rec
+			if rec == empty {
+				val = nodeCount
+			} else if rec < 0 {
+				val = nodeCount + 16 + (-rec - 2)
+			}
+			db.Write([]byte{byte(val >> 16), byte(val >> 8), byte(val)})
+		}
+	}
+	db.Write(make([]byte, 16)) // data section separator
+	db.Write(data.Bytes())
+	db.WriteString("\xAB\xCD\xEFMaxMind.com")
+	db.Write(mmdbMap(
+		mmdbString("binary_format_major_version"), mmdbUint(5, 2),
+		mmdbString("binary_format_minor_version"), mmdbUint(5, 0),
+		mmdbString("database_type"), mmdbString("Test-Country"),
+		mmdbString("ip_version"), mmdbUint(5, 4),
+		mmdbString("node_count"), mmdbUint(6, uint64(nodeCount)),
+		mmdbString("record_size"), mmdbUint(5, 24),
+	))
+	return os.WriteFile(fPath, db.Bytes(), 0644)
+}
+
+func mmdbString(s string) []byte {
+	return append([]byte{2<<5 | byte(len(s))}, s...)
+}
+
+func mmdbUint(typ byte, v uint64) []byte {
+	buf := make([]byte, 8)
+	binary.BigEndian.PutUint64(buf, v)
+	buf = bytes.TrimLeft(buf, "\x00")
+	return append([]byte{typ<<5 | byte(len(buf))}, buf...)
+}
+
+func mmdbMap(kvs ...[]byte) []byte {
+	out := []byte{7<<5 | byte(len(kvs)/2)}
+	for _, kv := range kvs {
+		out = append(out, kv...)
+	}
+	return out
+}
@@ -81,27 +81,7 @@ func newFilterIndex(dm *DataManager, idxItmType, tnt, ctx, itemID string, filter
 		}
 
 		for _, flt := range fltr.Rules {
-			if !FilterIndexTypes.Has(flt.Type) ||
-				IsDynamicDPPath(flt.Element) {
-				continue
-			}
-			isDyn := strings.HasPrefix(flt.Element, utils.DynamicDataPrefix)
-			for _, fldVal := range flt.Values {
-				if IsDynamicDPPath(fldVal) {
-					continue
-				}
-				var idxKey string
-				if isDyn {
-					if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) { // do not index if both the element and the value is dynamic
-						continue
-					}
-					idxKey = utils.ConcatenatedKey(flt.Type, flt.Element[1:], fldVal)
-				} else if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) {
-					idxKey = utils.ConcatenatedKey(flt.Type, fldVal[1:], flt.Element)
-				} else {
-					// do not index not dynamic filters
-					continue
-				}
+			for _, idxKey := range flt.indexKeys() {
 				var rcvIndx map[string]utils.StringSet
 				// only read from cache in case if we do not find the index to not cache the negative response
 				if rcvIndx, err = dm.GetIndexes(idxItmType, tntCtx,
@@ -122,6 +102,29 @@ func newFilterIndex(dm *DataManager, idxItmType, tnt, ctx, itemID string, filter
 	return
 }
 
+// indexKeys returns the filter index keys built for the rule, nil for the rules not indexed
+func (fltr *FilterRule) indexKeys() (idxKeys []string) {
+	if !FilterIndexTypes.Has(fltr.Type) ||
+		IsDynamicDPPath(fltr.Element) {
+		return
+	}
+	isDyn := strings.HasPrefix(fltr.Element, utils.DynamicDataPrefix)
+	for _, fldVal := range fltr.Values {
+		if IsDynamicDPPath(fldVal) {
+			continue
+		}
+		if isDyn {
+			if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) { // do not index if both the element and the value is dynamic
+				continue
+			}
+			idxKeys = append(idxKeys, utils.ConcatenatedKey(fltr.Type, fltr.Element[1:], fldVal))
+		} else if strings.HasPrefix(fldVal, utils.DynamicDataPrefix) {
+			idxKeys = append(idxKeys, utils.ConcatenatedKey(fltr.Type, fldVal[1:], fltr.Element))
+		} // do not index not dynamic filters
+	}
+	return
+}
+
 // addItemToFilterIndex will add the itemID to the existing/created index and set it in the DataDB
 func addItemToFilterIndex(dm *DataManager, idxItmType, tnt, ctx, itemID string, filterIDs []string) (err error) {
 	tntCtx := tnt
@@ -794,3 +794,61 @@ func GetRevFltrIdxHealth(dm *DataManager, fltrCache, revFltrIdxCache *ltcache.Ca
 	}
 	return
 }
+
+// DataDBIndexesHealth is the health of all the indexes in the DataDB
+type DataDBIndexesHealth struct {
+	AccountActionPlans   *AccountActionPlanIHReply
+	ReverseDestinations  *ReverseDestinationsIHReply
+	FilterIndexes        map[string]*FilterIHReply        // indexed on the filter index type
+	ReverseFilterIndexes map[string]*ReverseFilterIHReply // only the unhealthy ones
+}
+
+// Healthy returns true if no problem was found with the indexes
+func (ih *DataDBIndexesHealth) Healthy() bool {
+	if len(ih.AccountActionPlans.MissingAccountActionPlans) != 0 ||
+		len(ih.AccountActionPlans.BrokenReferences) != 0 ||
+		len(ih.ReverseDestinations.MissingReverseDestinations) != 0 ||
+		len(ih.ReverseDestinations.BrokenReferences) != 0 ||
+		len(ih.ReverseFilterIndexes) != 0 {
+		return false
+	}
+	for _, fIH := range ih.FilterIndexes {
+		if len(fIH.MissingObjects) != 0 ||
+			len(fIH.MissingIndexes) != 0 ||
+			len(fIH.BrokenIndexes) != 0 ||
+			len(fIH.MissingFilters) != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// GetDataDBIndexesHealth checks the health of all the indexes using unlimited local caches
+func GetDataDBIndexesHealth(dm *DataManager) (ih *DataDBIndexesHealth, err error) {
+	ih = &DataDBIndexesHealth{FilterIndexes: make(map[string]*FilterIHReply)}
+	if ih.AccountActionPlans, err = GetAccountActionPlansIndexHealth(dm, -1, -1, 0, 0, false, false); err != nil {
+		return
+	}
+	if ih.ReverseDestinations, err = GetReverseDestinationsIndexHealth(dm, -1, -1, 0, 0, false, false); err != nil {
+		return
+	}
+	objCaches := make(map[string]*ltcache.Cache)
+	for indxType := range utils.CacheIndexesToPrefix {
+		objCaches[indxType] = ltcache.NewCache(-1, 0, false, nil)
+		if indxType == utils.CacheReverseFilterIndexes {
+			continue
+		}
+		if ih.FilterIndexes[indxType], err = GetFltrIdxHealth(dm,
+			ltcache.NewCache(-1, 0, false, nil),
+			ltcache.NewCache(-1, 0, false, nil),
+			ltcache.NewCache(-1, 0, false, nil),
+			indxType); err != nil {
+			return
+		}
+	}
+	ih.ReverseFilterIndexes, err = GetRevFltrIdxHealth(dm,
+		ltcache.NewCache(-1, 0, false, nil),
+		ltcache.NewCache(-1, 0, false, nil),
+		objCaches)
+	return
+}
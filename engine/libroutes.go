@@ -20,7 +20,9 @@ package engine
 
 import (
 	"fmt"
+	"math"
 	"sort"
+	"strconv"
 	"strings"
 
 	"github.com/cgrates/cgrates/utils"
@@ -39,6 +41,8 @@ type SortedRoutes struct {
 	ProfileID string         // Profile matched
 	Sorting   string         // Sorting algorithm
 	Routes    []*SortedRoute // list of route IDs and SortingData data
+
+	SkippedRoutes map[string]string `json:",omitempty"` // routes skipped by the circuit breakers with the reason
 }
 
 // RouteIDs returns a list of route IDs
@@ -185,6 +189,89 @@ func (sRoutes *SortedRoutes) Digest() string {
 	return strings.Join(sRoutes.RoutesWithParams(), utils.FieldsSep)
 }
 
+// scoreMetric is one of the metrics composing the score of a route
+type scoreMetric struct {
+	metric      string  // key within SortingData (ie: Cost, Weight, ResourceUsage, *asr)
+	coefficient float64 // contribution of the metric to the score
+	descending  bool    // higher values are better
+}
+
+// newScoreMetrics parses the SortingParameters of *score strategy
+// defined as []string{"metric:coefficient[:*asc|*desc]"}
+func newScoreMetrics(params []string) (sMetrics []*scoreMetric, err error) {
+	sMetrics = make([]*scoreMetric, len(params))
+	for i, param := range params {
+		splt := strings.Split(param, utils.ConcatenatedKeySep)
+		if len(splt) < 2 || len(splt) > 3 || splt[0] == utils.EmptyString {
+			return nil, fmt.Errorf("invalid score sorting parameter: <%s>", param)
+		}
+		sMetrics[i] = &scoreMetric{metric: splt[0]}
+		if sMetrics[i].coefficient, err = strconv.ParseFloat(splt[1], 64); err != nil {
+			return nil, fmt.Errorf("invalid score sorting parameter: <%s>", param)
+		}
+		if len(splt) == 3 {
+			switch splt[2] {
+			case utils.MetaAsc:
+			case utils.MetaDesc:
+				sMetrics[i].descending = true
+			default:
+				return nil, fmt.Errorf("invalid score sorting parameter: <%s>", param)
+			}
+		}
+	}
+	return
+}
+
+// SortScore is part of sort interface,
+// computes the Score of each route out of the normalised metrics
+// and sorts ascendent based on it with fallback on Weight
+func (sRoutes *SortedRoutes) SortScore(sMetrics []*scoreMetric) {
+	for _, sM := range sMetrics {
+		minVal, maxVal := math.Inf(1), math.Inf(-1)
+		for _, sRoute := range sRoutes.Routes {
+			if val, has := sRoute.scoreMetricValue(sM.metric); has {
+				minVal = math.Min(minVal, val)
+				maxVal = math.Max(maxVal, val)
+			}
+		}
+		for _, sRoute := range sRoutes.Routes {
+			penalty := 1.0 // routes without the metric get the worst value
+			if val, has := sRoute.scoreMetricValue(sM.metric); has {
+				penalty = 0
+				if maxVal != minVal {
+					penalty = (val - minVal) / (maxVal - minVal)
+				}
+				if sM.descending {
+					penalty = 1 - penalty
+				}
+			}
+			sRoute.sortingDataF64[utils.Score] += sM.coefficient * penalty
+		}
+	}
+	for _, sRoute := range sRoutes.Routes {
+		sRoute.SortingData[utils.Score] = sRoute.sortingDataF64[utils.Score]
+	}
+	sort.Slice(sRoutes.Routes, func(i, j int) bool {
+		if sRoutes.Routes[i].sortingDataF64[utils.Score] == sRoutes.Routes[j].sortingDataF64[utils.Score] {
+			if sRoutes.Routes[i].sortingDataF64[utils.Weight] == sRoutes.Routes[j].sortingDataF64[utils.Weight] {
+				return utils.BoolGenerator().RandomBool()
+			}
+			return sRoutes.Routes[i].sortingDataF64[utils.Weight] > sRoutes.Routes[j].sortingDataF64[utils.Weight]
+		}
+		return sRoutes.Routes[i].sortingDataF64[utils.Score] < sRoutes.Routes[j].sortingDataF64[utils.Score]
+	})
+}
+
+// scoreMetricValue returns the value of the metric used in score computation
+// the stat metrics which are not available are considered missing
+func (ss *SortedRoute) scoreMetricValue(metric string) (val float64, has bool) {
+	if val, has = ss.sortingDataF64[metric]; has &&
+		strings.HasPrefix(metric, utils.Meta) && val == utils.StatsNA {
+		has = false
+	}
+	return
+}
+
 func (ss *SortedRoute) AsNavigableMap() (nm *utils.DataNode) {
 	nm = &utils.DataNode{
 		Type: utils.NMMapType,
@@ -232,6 +319,7 @@ func NewRouteSortDispatcher(lcrS *RouteService) (rsd RouteSortDispatcher) {
 	rsd[utils.MetaReas] = NewResourceAscendetSorter(lcrS)
 	rsd[utils.MetaReds] = NewResourceDescendentSorter(lcrS)
 	rsd[utils.MetaLoad] = NewLoadDistributionSorter(lcrS)
+	rsd[utils.MetaScore] = NewScoreSorter(lcrS)
 	return
 }
 
@@ -1400,3 +1400,68 @@ func TestSortedRoutesAsNavigableMap(t *testing.T) {
 		t.Errorf("Expected %+v, received %+v", utils.ToJSON(expNavMap), utils.ToJSON(rcv))
 	}
 }
+
+func TestLibRoutesSortScore(t *testing.T) {
+	sSpls := &SortedRoutes{
+		Routes: []*SortedRoute{
+			{
+				RouteID: "route1",
+				sortingDataF64: map[string]float64{
+					utils.Weight:  10.0,
+					utils.Cost:    1.0,
+					utils.MetaASR: 50.0,
+					utils.MetaPDD: 3.0,
+				},
+				SortingData: map[string]interface{}{},
+			},
+			{
+				RouteID: "route2",
+				sortingDataF64: map[string]float64{
+					utils.Weight:  10.0,
+					utils.Cost:    2.0,
+					utils.MetaASR: 100.0,
+					utils.MetaPDD: 1.0,
+				},
+				SortingData: map[string]interface{}{},
+			},
+			{
+				RouteID: "route3",
+				sortingDataF64: map[string]float64{
+					utils.Weight:  20.0,
+					utils.Cost:    3.0,
+					utils.MetaASR: utils.StatsNA,
+				},
+				SortingData: map[string]interface{}{},
+			},
+		},
+	}
+	sMetrics, err := newScoreMetrics([]string{"Cost:0.6", "*asr:0.3:*desc", "*pdd:0.1:*asc"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	sSpls.SortScore(sMetrics)
+	if rcv, eIds := sSpls.RouteIDs(), []string{"route2", "route1", "route3"}; !reflect.DeepEqual(eIds, rcv) {
+		t.Errorf("Expecting: %+v, \n received: %+v", eIds, rcv)
+	}
+	if score := sSpls.Routes[0].SortingData[utils.Score]; score != 0.3 {
+		t.Errorf("Expecting score 0.3, received: %v", score)
+	}
+}
+
+func TestLibRoutesNewScoreMetrics(t *testing.T) {
+	exp := []*scoreMetric{
+		{metric: utils.Cost, coefficient: 0.6},
+		{metric: utils.MetaASR, coefficient: 0.4, descending: true},
+	}
+	if rcv, err := newScoreMetrics([]string{"Cost:0.6", "*asr:0.4:*desc"}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expecting: %s, received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	for _, param := range []string{"Cost", "Cost:a", "Cost:0.6:*up", ":0.6"} {
+		expErr := "invalid score sorting parameter: <" + param + ">"
+		if _, err := newScoreMetrics([]string{param}); err == nil || err.Error() != expErr {
+			t.Errorf("Expecting: %s, received: %v", expErr, err)
+		}
+	}
+}
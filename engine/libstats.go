@@ -44,6 +44,7 @@ type StatQueueProfile struct {
 	Blocker            bool // blocker flag to stop processing on filters matched
 	Weight             float64
 	ThresholdIDs       []string // list of thresholds to be checked after changes
+	GroupBy            []string // templates building one child queue per distinct values (ie: ~*req.Route)
 
 	lkID string // holds the reference towards guardian lock key
 }
@@ -197,6 +198,43 @@ type StatQueue struct {
 	ttl       *time.Duration // timeToLeave, picked on each init
 }
 
+// statQueueChild is a StatQueue created on the fly out of a StatQueueProfile with GroupBy
+type statQueueChild struct {
+	sq       *StatQueue
+	lastUsed time.Time
+}
+
+// statQueueChildren holds the child queues of one StatQueueProfile
+type statQueueChildren struct {
+	sqPrfl *StatQueueProfile          // the profile the children were created out of
+	queues map[string]*statQueueChild // indexed on the child queue ID
+}
+
+// remIdle removes the children not used within the ttl
+func (sqc *statQueueChildren) remIdle(ttl time.Duration, now time.Time) {
+	if ttl <= 0 {
+		return
+	}
+	for chldID, chld := range sqc.queues {
+		if now.Sub(chld.lastUsed) >= ttl {
+			delete(sqc.queues, chldID)
+		}
+	}
+}
+
+// createdOutOf returns true if the children were created out of the given version of the profile
+func (sqc *statQueueChildren) createdOutOf(sqPrfl *StatQueueProfile) bool {
+	if sqc.sqPrfl == sqPrfl {
+		return true
+	}
+	// the profile may be read again out of DataDB without being changed
+	if utils.ToJSON(sqc.sqPrfl) != utils.ToJSON(sqPrfl) {
+		return false
+	}
+	sqc.sqPrfl = sqPrfl
+	return true
+}
+
 // statQueueLockKey returns the ID used to lock a StatQueue with guardian
 func statQueueLockKey(tnt, id string) string {
 	return utils.ConcatenatedKey(utils.CacheStatQueues, tnt, id)
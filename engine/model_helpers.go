@@ -1191,7 +1191,11 @@ func APItoResource(tpRL *utils.TPResourceProfile, timezone string) (rp *Resource
 			return nil, err
 		}
 	}
-	if tpRL.Limit != utils.EmptyString {
+	if strings.HasPrefix(tpRL.Limit, utils.MetaRate+utils.InInFieldSep) {
+		if err = rp.parseRateLimit(tpRL.Limit); err != nil {
+			return nil, err
+		}
+	} else if tpRL.Limit != utils.EmptyString {
 		if rp.Limit, err = strconv.ParseFloat(tpRL.Limit, 64); err != nil {
 			return nil, err
 		}
@@ -1199,6 +1203,40 @@ func APItoResource(tpRL *utils.TPResourceProfile, timezone string) (rp *Resource
 	return rp, nil
 }
 
+// parseRateLimit populates the rate-limit of the profile out of the
+// *rate:<units>:<interval>[:<burst>] limit, the burst defaulting to the units
+func (rp *ResourceProfile) parseRateLimit(lmt string) (err error) {
+	rateSplt := strings.Split(lmt, utils.InInFieldSep)
+	if len(rateSplt) != 3 && len(rateSplt) != 4 {
+		return fmt.Errorf("invalid rate limit: <%s>", lmt)
+	}
+	if rp.Rate, err = strconv.ParseFloat(rateSplt[1], 64); err != nil {
+		return
+	}
+	if rp.RateInterval, err = utils.ParseDurationWithNanosecs(rateSplt[2]); err != nil {
+		return
+	}
+	if rp.Rate <= 0 || rp.RateInterval <= 0 {
+		return fmt.Errorf("invalid rate limit: <%s>", lmt)
+	}
+	rp.Limit = rp.Rate
+	if len(rateSplt) == 4 {
+		rp.Limit, err = strconv.ParseFloat(rateSplt[3], 64)
+	}
+	return
+}
+
+// rateLimitAsString returns the rate-limit in the *rate:<units>:<interval>[:<burst>] format
+func (rp *ResourceProfile) rateLimitAsString() (lmt string) {
+	lmt = utils.MetaRate + utils.InInFieldSep +
+		strconv.FormatFloat(rp.Rate, 'f', -1, 64) + utils.InInFieldSep +
+		rp.RateInterval.String()
+	if rp.Limit != rp.Rate {
+		lmt += utils.InInFieldSep + strconv.FormatFloat(rp.Limit, 'f', -1, 64)
+	}
+	return
+}
+
 func ResourceProfileToAPI(rp *ResourceProfile) (tpRL *utils.TPResourceProfile) {
 	tpRL = &utils.TPResourceProfile{
 		Tenant:             rp.Tenant,
@@ -1215,6 +1253,9 @@ func ResourceProfileToAPI(rp *ResourceProfile) (tpRL *utils.TPResourceProfile) {
 	if rp.UsageTTL != time.Duration(0) {
 		tpRL.UsageTTL = rp.UsageTTL.String()
 	}
+	if rp.isRateLimit() {
+		tpRL.Limit = rp.rateLimitAsString()
+	}
 	for i, fli := range rp.FilterIDs {
 		tpRL.FilterIDs[i] = fli
 	}
@@ -1239,12 +1280,13 @@ type StatMdls []*StatMdl
 func (tps StatMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.QueueLength, utils.TTL, utils.MinItems, utils.MetricIDs, utils.MetricFilterIDs,
-		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs}
+		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs, utils.GroupBy}
 }
 
 func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 	filterMap := make(map[string]utils.StringSet)
 	thresholdMap := make(map[string]utils.StringSet)
+	groupByMap := make(map[string][]string) // ordered since the values of the templates build the child queue IDs
 	statMetricsMap := make(map[string]map[string]*utils.MetricWithFilters)
 	mst := make(map[string]*utils.TPStatProfile)
 	for _, model := range models {
@@ -1287,6 +1329,13 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 			}
 			thresholdMap[key.TenantID()].AddSlice(strings.Split(model.ThresholdIDs, utils.InfieldSep))
 		}
+		if model.GroupBy != utils.EmptyString {
+			for _, grpBy := range strings.Split(model.GroupBy, utils.InfieldSep) {
+				if !utils.IsSliceMember(groupByMap[key.TenantID()], grpBy) {
+					groupByMap[key.TenantID()] = append(groupByMap[key.TenantID()], grpBy)
+				}
+			}
+		}
 		if len(model.ActivationInterval) != 0 {
 			st.ActivationInterval = new(utils.TPActivationInterval)
 			aiSplt := strings.Split(model.ActivationInterval, utils.InfieldSep)
@@ -1330,6 +1379,7 @@ func (models StatMdls) AsTPStats() (result []*utils.TPStatProfile) {
 		result[i] = st
 		result[i].FilterIDs = filterMap[tntID].AsSlice()
 		result[i].ThresholdIDs = thresholdMap[tntID].AsSlice()
+		result[i].GroupBy = groupByMap[tntID]
 		for _, metric := range statMetricsMap[tntID] {
 			result[i].Metrics = append(result[i].Metrics, metric)
 		}
@@ -1373,6 +1423,7 @@ func APItoModelStats(st *utils.TPStatProfile) (mdls StatMdls) {
 					}
 					mdl.ThresholdIDs += val
 				}
+				mdl.GroupBy = strings.Join(st.GroupBy, utils.InfieldSep)
 			}
 			for i, val := range metric.FilterIDs {
 				if i != 0 {
@@ -1417,6 +1468,9 @@ func APItoStats(tpST *utils.TPStatProfile, timezone string) (st *StatQueueProfil
 	for i, fltr := range tpST.FilterIDs {
 		st.FilterIDs[i] = fltr
 	}
+	if len(tpST.GroupBy) != 0 {
+		st.GroupBy = utils.CloneStringSlice(tpST.GroupBy)
+	}
 	if tpST.ActivationInterval != nil {
 		if st.ActivationInterval, err = tpST.ActivationInterval.AsActivationInterval(timezone); err != nil {
 			return nil, err
@@ -1457,6 +1511,9 @@ func StatQueueProfileToAPI(st *StatQueueProfile) (tpST *utils.TPStatProfile) {
 	for i, fli := range st.FilterIDs {
 		tpST.FilterIDs[i] = fli
 	}
+	if len(st.GroupBy) != 0 {
+		tpST.GroupBy = utils.CloneStringSlice(st.GroupBy)
+	}
 	for i, fli := range st.ThresholdIDs {
 		tpST.ThresholdIDs[i] = fli
 	}
@@ -1478,13 +1535,17 @@ type ThresholdMdls []*ThresholdMdl
 func (tps ThresholdMdls) CSVHeader() (result []string) {
 	return []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.MaxHits, utils.MinHits, utils.MinSleep,
-		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async}
+		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async,
+		utils.RaiseFilterIDs, utils.ClearFilterIDs, utils.ClearActionIDs}
 }
 
 func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 	mst := make(map[string]*utils.TPThresholdProfile)
 	filterMap := make(map[string]utils.StringSet)
 	actionMap := make(map[string]utils.StringSet)
+	raiseFilterMap := make(map[string]utils.StringSet)
+	clearFilterMap := make(map[string]utils.StringSet)
+	clearActionMap := make(map[string]utils.StringSet)
 	for _, tp := range tps {
 		tenID := (&utils.TenantID{Tenant: tp.Tenant, ID: tp.ID}).TenantID()
 		th, found := mst[tenID]
@@ -1525,6 +1586,24 @@ func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 			}
 			filterMap[tenID].AddSlice(strings.Split(tp.FilterIDs, utils.InfieldSep))
 		}
+		if tp.RaiseFilterIDs != utils.EmptyString {
+			if _, has := raiseFilterMap[tenID]; !has {
+				raiseFilterMap[tenID] = make(utils.StringSet)
+			}
+			raiseFilterMap[tenID].AddSlice(strings.Split(tp.RaiseFilterIDs, utils.InfieldSep))
+		}
+		if tp.ClearFilterIDs != utils.EmptyString {
+			if _, has := clearFilterMap[tenID]; !has {
+				clearFilterMap[tenID] = make(utils.StringSet)
+			}
+			clearFilterMap[tenID].AddSlice(strings.Split(tp.ClearFilterIDs, utils.InfieldSep))
+		}
+		if tp.ClearActionIDs != utils.EmptyString {
+			if _, has := clearActionMap[tenID]; !has {
+				clearActionMap[tenID] = make(utils.StringSet)
+			}
+			clearActionMap[tenID].AddSlice(strings.Split(tp.ClearActionIDs, utils.InfieldSep))
+		}
 
 		mst[tenID] = th
 	}
@@ -1534,6 +1613,15 @@ func (tps ThresholdMdls) AsTPThreshold() (result []*utils.TPThresholdProfile) {
 		result[i] = th
 		result[i].FilterIDs = filterMap[tntID].AsSlice()
 		result[i].ActionIDs = actionMap[tntID].AsSlice()
+		if fltrIDs, has := raiseFilterMap[tntID]; has {
+			result[i].RaiseFilterIDs = fltrIDs.AsSlice()
+		}
+		if fltrIDs, has := clearFilterMap[tntID]; has {
+			result[i].ClearFilterIDs = fltrIDs.AsSlice()
+		}
+		if actIDs, has := clearActionMap[tntID]; has {
+			result[i].ClearActionIDs = actIDs.AsSlice()
+		}
 		i++
 	}
 	return
@@ -1613,6 +1701,26 @@ func APItoModelTPThreshold(th *utils.TPThresholdProfile) (mdls ThresholdMdls) {
 				mdls = append(mdls, mdl)
 			}
 		}
+		// the alarm columns are spread over the rows, adding new ones if needed
+		mdlAt := func(i int) *ThresholdMdl {
+			for len(mdls) <= i {
+				mdls = append(mdls, &ThresholdMdl{
+					Tpid:   th.TPid,
+					Tenant: th.Tenant,
+					ID:     th.ID,
+				})
+			}
+			return mdls[i]
+		}
+		for i, fltrID := range th.RaiseFilterIDs {
+			mdlAt(i).RaiseFilterIDs = fltrID
+		}
+		for i, fltrID := range th.ClearFilterIDs {
+			mdlAt(i).ClearFilterIDs = fltrID
+		}
+		for i, actID := range th.ClearActionIDs {
+			mdlAt(i).ClearActionIDs = actID
+		}
 	}
 	return
 }
@@ -1641,6 +1749,15 @@ func APItoThresholdProfile(tpTH *utils.TPThresholdProfile, timezone string) (th
 	for i, fli := range tpTH.FilterIDs {
 		th.FilterIDs[i] = fli
 	}
+	if len(tpTH.RaiseFilterIDs) != 0 {
+		th.RaiseFilterIDs = utils.CloneStringSlice(tpTH.RaiseFilterIDs)
+	}
+	if len(tpTH.ClearFilterIDs) != 0 {
+		th.ClearFilterIDs = utils.CloneStringSlice(tpTH.ClearFilterIDs)
+	}
+	if len(tpTH.ClearActionIDs) != 0 {
+		th.ClearActionIDs = utils.CloneStringSlice(tpTH.ClearActionIDs)
+	}
 	if tpTH.ActivationInterval != nil {
 		if th.ActivationInterval, err = tpTH.ActivationInterval.AsActivationInterval(timezone); err != nil {
 			return nil, err
@@ -1671,6 +1788,15 @@ func ThresholdProfileToAPI(th *ThresholdProfile) (tpTH *utils.TPThresholdProfile
 	for i, fli := range th.ActionIDs {
 		tpTH.ActionIDs[i] = fli
 	}
+	if len(th.RaiseFilterIDs) != 0 {
+		tpTH.RaiseFilterIDs = utils.CloneStringSlice(th.RaiseFilterIDs)
+	}
+	if len(th.ClearFilterIDs) != 0 {
+		tpTH.ClearFilterIDs = utils.CloneStringSlice(th.ClearFilterIDs)
+	}
+	if len(th.ClearActionIDs) != 0 {
+		tpTH.ClearActionIDs = utils.CloneStringSlice(th.ClearActionIDs)
+	}
 
 	if th.ActivationInterval != nil {
 		if !th.ActivationInterval.ActivationTime.IsZero() {
@@ -1905,6 +1905,44 @@ func TestResourceProfileToAPI(t *testing.T) {
 	}
 }
 
+func TestAPItoResourceRateLimit(t *testing.T) {
+	tpRL := &utils.TPResourceProfile{
+		Tenant: "cgrates.org",
+		ID:     "RL_CAPS",
+		Limit:  "*rate:30:1s",
+	}
+	eRL := &ResourceProfile{
+		Tenant:       "cgrates.org",
+		ID:           "RL_CAPS",
+		FilterIDs:    []string{},
+		ThresholdIDs: []string{},
+		Limit:        30,
+		Rate:         30,
+		RateInterval: time.Second,
+	}
+	if rl, err := APItoResource(tpRL, "UTC"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eRL, rl) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eRL), utils.ToJSON(rl))
+	}
+	tpRL.Limit = "*rate:5:1s:10"
+	eRL.Rate = 5
+	eRL.Limit = 10
+	if rl, err := APItoResource(tpRL, "UTC"); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(eRL, rl) {
+		t.Errorf("Expecting: %+v, received: %+v", utils.ToJSON(eRL), utils.ToJSON(rl))
+	} else if rcv := ResourceProfileToAPI(rl); rcv.Limit != tpRL.Limit {
+		t.Errorf("Expecting: %+v, received: %+v", tpRL.Limit, rcv.Limit)
+	}
+	for _, lmt := range []string{"*rate:5", "*rate:5:0s", "*rate:a:1s", "*rate:5:1s:a"} {
+		tpRL.Limit = lmt
+		if _, err := APItoResource(tpRL, "UTC"); err == nil {
+			t.Errorf("Expected error for limit: %s", lmt)
+		}
+	}
+}
+
 func TestAPItoModelResource(t *testing.T) {
 	tpRL := &utils.TPResourceProfile{
 		Tenant:             "cgrates.org",
@@ -2109,6 +2147,51 @@ func TestStatQueueProfileToAPI(t *testing.T) {
 	}
 }
 
+func TestStatQueueProfileToAPIGroupBy(t *testing.T) {
+	sqPrf := &StatQueueProfile{
+		Tenant:    "cgrates.org",
+		ID:        "SQ_ROUTE",
+		FilterIDs: []string{"*string:~*req.Account:1001"},
+		ActivationInterval: &utils.ActivationInterval{
+			ActivationTime: time.Date(2014, 7, 29, 15, 0, 0, 0, time.UTC),
+		},
+		QueueLength:  100,
+		TTL:          time.Second,
+		Metrics:      []*MetricWithFilters{{MetricID: utils.MetaASR}},
+		ThresholdIDs: []string{},
+		GroupBy:      []string{"~*req.Route", "~*req.Destination:s/^(\\d{2}).*/${1}/"},
+	}
+	expected := &utils.TPStatProfile{
+		Tenant:             "cgrates.org",
+		ID:                 "SQ_ROUTE",
+		FilterIDs:          []string{"*string:~*req.Account:1001"},
+		ActivationInterval: &utils.TPActivationInterval{ActivationTime: "2014-07-29T15:00:00Z"},
+		QueueLength:        100,
+		TTL:                "1s",
+		Metrics:            []*utils.MetricWithFilters{{MetricID: utils.MetaASR}},
+		ThresholdIDs:       []string{},
+		GroupBy:            []string{"~*req.Route", "~*req.Destination:s/^(\\d{2}).*/${1}/"},
+	}
+	tpST := StatQueueProfileToAPI(sqPrf)
+	if !reflect.DeepEqual(expected, tpST) {
+		t.Errorf("Expecting: %+v,\n received: %+v", utils.ToJSON(expected), utils.ToJSON(tpST))
+	}
+	if rcv, err := APItoStats(tpST, utils.EmptyString); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(sqPrf, rcv) {
+		t.Errorf("Expecting: %+v,\n received: %+v", utils.ToJSON(sqPrf), utils.ToJSON(rcv))
+	}
+
+	mdls := APItoModelStats(tpST)
+	if len(mdls) != 1 || mdls[0].GroupBy != "~*req.Route;~*req.Destination:s/^(\\d{2}).*/${1}/" {
+		t.Errorf("Unexpected models: %+v", utils.ToJSON(mdls))
+	}
+	expected.ThresholdIDs = nil
+	if rcv := mdls.AsTPStats(); len(rcv) != 1 || !reflect.DeepEqual(expected, rcv[0]) {
+		t.Errorf("Expecting: %+v,\n received: %+v", utils.ToJSON(expected), utils.ToJSON(rcv))
+	}
+}
+
 func TestAPItoModelStats(t *testing.T) {
 	tpS := &utils.TPStatProfile{
 		TPid:      "TPS1",
@@ -2534,6 +2617,60 @@ func TestThresholdProfileToAPI(t *testing.T) {
 	}
 }
 
+func TestThresholdProfileAlarmToAPI(t *testing.T) {
+	tpTH := &utils.TPThresholdProfile{
+		Tenant:         "cgrates.org",
+		ID:             "TH_ASR",
+		FilterIDs:      []string{"FLTR_STAT_ASR"},
+		MaxHits:        -1,
+		ActionIDs:      []string{"ACT_RAISE"},
+		RaiseFilterIDs: []string{"*lt:~*req.*asr:30"},
+		ClearFilterIDs: []string{"*gt:~*req.*asr:40"},
+		ClearActionIDs: []string{"ACT_CLEAR"},
+	}
+	expected := &ThresholdProfile{
+		Tenant:         "cgrates.org",
+		ID:             "TH_ASR",
+		FilterIDs:      []string{"FLTR_STAT_ASR"},
+		MaxHits:        -1,
+		ActionIDs:      []string{"ACT_RAISE"},
+		RaiseFilterIDs: []string{"*lt:~*req.*asr:30"},
+		ClearFilterIDs: []string{"*gt:~*req.*asr:40"},
+		ClearActionIDs: []string{"ACT_CLEAR"},
+	}
+	thPrf, err := APItoThresholdProfile(tpTH, "UTC")
+	if err != nil {
+		t.Fatal(err)
+	} else if !reflect.DeepEqual(expected, thPrf) {
+		t.Errorf("Expecting: %+v,\n received: %+v", utils.ToJSON(expected), utils.ToJSON(thPrf))
+	}
+	tpTH.ActivationInterval = new(utils.TPActivationInterval)
+	if rcv := ThresholdProfileToAPI(thPrf); !reflect.DeepEqual(tpTH, rcv) {
+		t.Errorf("Expecting: %+v,\n received: %+v", utils.ToJSON(tpTH), utils.ToJSON(rcv))
+	}
+
+	tpTH.ActivationInterval = nil
+	tpTH.ClearFilterIDs = append(tpTH.ClearFilterIDs, "*gt:~*req.*acd:60")
+	expMdls := ThresholdMdls{
+		{Tenant: "cgrates.org", ID: "TH_ASR", FilterIDs: "FLTR_STAT_ASR", MaxHits: -1, ActionIDs: "ACT_RAISE",
+			RaiseFilterIDs: "*lt:~*req.*asr:30", ClearFilterIDs: "*gt:~*req.*asr:40", ClearActionIDs: "ACT_CLEAR"},
+		{Tenant: "cgrates.org", ID: "TH_ASR", ClearFilterIDs: "*gt:~*req.*acd:60"},
+	}
+	mdls := APItoModelTPThreshold(tpTH)
+	if !reflect.DeepEqual(expMdls, mdls) {
+		t.Errorf("Expecting: %+v,\n received: %+v", utils.ToJSON(expMdls), utils.ToJSON(mdls))
+	}
+	rcvTPs := mdls.AsTPThreshold()
+	if len(rcvTPs) != 1 {
+		t.Fatalf("Expecting one profile, received: %+v", utils.ToJSON(rcvTPs))
+	}
+	sort.Strings(tpTH.ClearFilterIDs)
+	sort.Strings(rcvTPs[0].ClearFilterIDs)
+	if !reflect.DeepEqual(tpTH, rcvTPs[0]) {
+		t.Errorf("Expecting: %+v,\n received: %+v", utils.ToJSON(tpTH), utils.ToJSON(rcvTPs[0]))
+	}
+}
+
 func TestTPFilterAsTPFilter(t *testing.T) {
 	tps := []*FilterMdl{
 		{
@@ -4996,7 +5133,8 @@ func TestThresholdMdlsCSVHeader(t *testing.T) {
 	}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.MaxHits, utils.MinHits, utils.MinSleep,
-		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async}
+		utils.Blocker, utils.Weight, utils.ActionIDs, utils.Async,
+		utils.RaiseFilterIDs, utils.ClearFilterIDs, utils.ClearActionIDs}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
@@ -5581,7 +5719,7 @@ func TestStatMdlsCSVHeader(t *testing.T) {
 	}}
 	expStruct := []string{"#" + utils.Tenant, utils.ID, utils.FilterIDs, utils.ActivationIntervalString,
 		utils.QueueLength, utils.TTL, utils.MinItems, utils.MetricIDs, utils.MetricFilterIDs,
-		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs}
+		utils.Stored, utils.Blocker, utils.Weight, utils.ThresholdIDs, utils.GroupBy}
 	result := testStruct.CSVHeader()
 	if !reflect.DeepEqual(result, expStruct) {
 		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ToJSON(expStruct), utils.ToJSON(result))
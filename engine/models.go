@@ -19,6 +19,7 @@ along with this program.  If not, see <http://www.gnu.org/licenses/>
 package engine
 
 import (
+	"encoding/json"
 	"fmt"
 	"strings"
 	"time"
@@ -276,6 +277,7 @@ type StatMdl struct {
 	Blocker            bool    `index:"10" re:""`
 	Weight             float64 `index:"11" re:"\d+\.?\d*"`
 	ThresholdIDs       string  `index:"12" re:""`
+	GroupBy            string  `index:"13" re:""`
 	CreatedAt          time.Time
 }
 
@@ -297,6 +299,9 @@ type ThresholdMdl struct {
 	Weight             float64 `index:"8" re:"\d+\.?\d*"`
 	ActionIDs          string  `index:"9" re:""`
 	Async              bool    `index:"10" re:""`
+	RaiseFilterIDs     string  `index:"11" re:""`
+	ClearFilterIDs     string  `index:"12" re:""`
+	ClearActionIDs     string  `index:"13" re:""`
 	CreatedAt          time.Time
 }
 
@@ -398,6 +403,44 @@ func (t SessionCostsSQL) TableName() string {
 	return utils.SessionCostsTBL
 }
 
+type AuditLogSQL struct {
+	ID         int64
+	RecordID   string
+	CreatedAt  time.Time
+	AuditUser  string
+	Source     string
+	Action     string
+	ItemType   string
+	Tenant     string
+	ItemID     string
+	ItemBefore string
+	ItemAfter  string
+}
+
+func (t AuditLogSQL) TableName() string {
+	return utils.AuditLogTBL
+}
+
+func (t AuditLogSQL) AsAuditRecord() *AuditRecord {
+	rec := &AuditRecord{
+		ID:       t.RecordID,
+		Time:     t.CreatedAt,
+		User:     t.AuditUser,
+		Source:   t.Source,
+		Action:   t.Action,
+		ItemType: t.ItemType,
+		Tenant:   t.Tenant,
+		ItemID:   t.ItemID,
+	}
+	if t.ItemBefore != utils.EmptyString {
+		rec.Before = json.RawMessage(t.ItemBefore)
+	}
+	if t.ItemAfter != utils.EmptyString {
+		rec.After = json.RawMessage(t.ItemAfter)
+	}
+	return rec
+}
+
 type TBLVersion struct {
 	ID      uint
 	Item    string
@@ -0,0 +1,249 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// tntQuotas tracks the per tenant usage of the sessions and CDRs rate quotas
+var tntQuotas = newTenantQuotas()
+
+func newTenantQuotas() *tenantQuotas {
+	return &tenantQuotas{
+		sessions: make(map[string]int),
+		reserved: make(map[string]int),
+		cdrs:     make(map[string]*rateWindow),
+		objects:  make(map[string]utils.StringSet),
+	}
+}
+
+type tenantQuotas struct {
+	mux      sync.Mutex
+	sessions map[string]int         // active sessions indexed on tenant
+	reserved map[string]int         // sessions passed the quota check but not yet registered, indexed on tenant
+	cdrs     map[string]*rateWindow // CDRs processed within the current second indexed on tenant
+
+	objMux  sync.Mutex
+	objects map[string]utils.StringSet // keys of the stored items with objects quota, indexed on prefix+tenant, loaded on first check
+}
+
+// rateWindow counts the events within one second
+type rateWindow struct {
+	second int64
+	count  int
+}
+
+// TenantSessionStarted counts a new active session of the tenant
+func TenantSessionStarted(tnt string) {
+	tntQuotas.mux.Lock()
+	tntQuotas.sessions[tnt]++
+	tntQuotas.mux.Unlock()
+}
+
+// TenantSessionEnded discounts an active session of the tenant
+func TenantSessionEnded(tnt string) {
+	tntQuotas.mux.Lock()
+	if tntQuotas.sessions[tnt] <= 1 {
+		delete(tntQuotas.sessions, tnt)
+	} else {
+		tntQuotas.sessions[tnt]--
+	}
+	tntQuotas.mux.Unlock()
+}
+
+// ReserveTenantSession checks the sessions quota of the tenant and reserves one session within the same critical section
+// the reservation counts towards the quota until released with ReleaseTenantSession, once the session is registered or on failure
+// reserved is false if the tenant has no sessions quota
+func ReserveTenantSession(tnt string) (reserved bool, err error) {
+	tq := config.CgrConfig().QuotasCfg().TenantQuota(tnt)
+	if tq == nil || tq.Sessions == 0 {
+		return
+	}
+	tntQuotas.mux.Lock()
+	if tntQuotas.sessions[tnt]+tntQuotas.reserved[tnt] >= tq.Sessions {
+		err = utils.NewErrTenantQuotaExceeded(utils.SessionsCfg, tnt)
+	} else {
+		tntQuotas.reserved[tnt]++
+		reserved = true
+	}
+	tntQuotas.mux.Unlock()
+	return
+}
+
+// ReleaseTenantSession releases a session reserved with ReserveTenantSession
+func ReleaseTenantSession(tnt string) {
+	tntQuotas.mux.Lock()
+	if tntQuotas.reserved[tnt] <= 1 {
+		delete(tntQuotas.reserved, tnt)
+	} else {
+		tntQuotas.reserved[tnt]--
+	}
+	tntQuotas.mux.Unlock()
+}
+
+// AllowTenantCDR counts one CDR of the tenant, returning error if the tenant reached the CDRs rate quota
+func AllowTenantCDR(tnt string) (err error) {
+	tq := config.CgrConfig().QuotasCfg().TenantQuota(tnt)
+	if tq == nil || tq.CDRsRate == 0 {
+		return
+	}
+	return tntQuotas.allowCDR(tnt, tq.CDRsRate, time.Now().Unix())
+}
+
+// allowCDR counts one CDR of the tenant within the window of the second now
+func (tq *tenantQuotas) allowCDR(tnt string, limit int, now int64) (err error) {
+	tq.mux.Lock()
+	defer tq.mux.Unlock()
+	w, has := tq.cdrs[tnt]
+	if !has {
+		w = new(rateWindow)
+		tq.cdrs[tnt] = w
+	}
+	if w.second != now {
+		w.second, w.count = now, 0
+	}
+	if w.count >= limit {
+		return utils.NewErrTenantQuotaExceeded(utils.CDRsRateCfg, tnt)
+	}
+	w.count++
+	return
+}
+
+// tenantCDRsRate returns the number of CDRs of the tenant processed within the current second
+func tenantCDRsRate(tnt string) int {
+	tntQuotas.mux.Lock()
+	defer tntQuotas.mux.Unlock()
+	if w, has := tntQuotas.cdrs[tnt]; has && w.second == time.Now().Unix() {
+		return w.count
+	}
+	return 0
+}
+
+// checkObjectsQuota returns error if storing the new item would exceed the objects quota of its tenant
+// the items already stored are only updated so they are not limited
+// the keys of the stored items are queried once per tenant and kept updated by tenantObjectStored/tenantObjectRemoved
+func (dm *DataManager) checkObjectsQuota(prfx, tntID string) (err error) {
+	tnt := utils.NewTenantID(tntID).Tenant
+	tq := config.CgrConfig().QuotasCfg().TenantQuota(tnt)
+	if tq == nil {
+		return
+	}
+	limit := tq.Objects[utils.CachePrefixToInstance[prfx]]
+	if limit == 0 {
+		return
+	}
+	tntQuotas.objMux.Lock()
+	defer tntQuotas.objMux.Unlock()
+	keys, has := tntQuotas.objects[prfx+tnt]
+	if !has {
+		var keysLst []string
+		if keysLst, err = dm.DataDB().GetKeysForPrefix(prfx + tnt + utils.ConcatenatedKeySep); err != nil {
+			return
+		}
+		keys = utils.NewStringSet(keysLst)
+		tntQuotas.objects[prfx+tnt] = keys
+	}
+	if !keys.Has(prfx+tntID) &&
+		keys.Size() >= limit {
+		return utils.NewErrTenantQuotaExceeded(utils.CachePrefixToInstance[prfx], tnt)
+	}
+	return
+}
+
+// tenantObjectStored counts the stored item towards the objects quota of its tenant
+func tenantObjectStored(prfx, tntID string) {
+	tntQuotas.objMux.Lock()
+	if keys, has := tntQuotas.objects[prfx+utils.NewTenantID(tntID).Tenant]; has {
+		keys.Add(prfx + tntID)
+	}
+	tntQuotas.objMux.Unlock()
+}
+
+// tenantObjectRemoved discounts the removed item from the objects quota of its tenant
+func tenantObjectRemoved(prfx, tntID string) {
+	tntQuotas.objMux.Lock()
+	if keys, has := tntQuotas.objects[prfx+utils.NewTenantID(tntID).Tenant]; has {
+		keys.Remove(prfx + tntID)
+	}
+	tntQuotas.objMux.Unlock()
+}
+
+// resetTenantObjects drops the keys of the stored items so they are queried again
+// on the next check, used when the items are written bypassing the DataManager
+func resetTenantObjects() {
+	tntQuotas.objMux.Lock()
+	tntQuotas.objects = make(map[string]utils.StringSet)
+	tntQuotas.objMux.Unlock()
+}
+
+// QuotaUsage is the usage of one quota, a Limit of 0 meaning unlimited
+type QuotaUsage struct {
+	Limit int
+	Used  int
+}
+
+// TenantQuotaStatus is the usage of the quotas of one tenant
+type TenantQuotaStatus struct {
+	Tenant      string
+	Enabled     bool
+	APIRequests *QuotaUsage
+	Sessions    *QuotaUsage
+	CDRsRate    *QuotaUsage
+	Objects     map[string]*QuotaUsage // indexed on item type
+}
+
+// GetTenantQuotaStatus returns the usage of the quotas of the tenant
+func GetTenantQuotaStatus(tnt string, caps *Caps) (sts *TenantQuotaStatus, err error) {
+	qCfg := config.CgrConfig().QuotasCfg()
+	tq := qCfg.TenantQuota(tnt)
+	if tq == nil {
+		tq = new(config.TenantQuotaCfg)
+	}
+	sts = &TenantQuotaStatus{
+		Tenant:      tnt,
+		Enabled:     qCfg.Enabled,
+		APIRequests: &QuotaUsage{Limit: tq.APIRequests},
+		Sessions:    &QuotaUsage{Limit: tq.Sessions},
+		CDRsRate:    &QuotaUsage{Limit: tq.CDRsRate, Used: tenantCDRsRate(tnt)},
+		Objects:     make(map[string]*QuotaUsage, len(utils.TenantQuotaObjects)),
+	}
+	if caps != nil {
+		sts.APIRequests.Used = caps.TenantAllocated(tnt)
+	}
+	tntQuotas.mux.Lock()
+	sts.Sessions.Used = tntQuotas.sessions[tnt]
+	tntQuotas.mux.Unlock()
+	if dm == nil {
+		return
+	}
+	for itmType := range utils.TenantQuotaObjects {
+		var keys []string
+		if keys, err = dm.DataDB().GetKeysForPrefix(utils.CacheInstanceToPrefix[itmType] +
+			tnt + utils.ConcatenatedKeySep); err != nil {
+			return nil, err
+		}
+		sts.Objects[itmType] = &QuotaUsage{Limit: tq.Objects[itmType], Used: len(keys)}
+	}
+	return
+}
@@ -0,0 +1,160 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestTenantQuotasSessionsAndCDRs(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	tmpCfg := config.CgrConfig()
+	tmpQuotas := tntQuotas
+	defer func() {
+		config.SetCgrConfig(tmpCfg)
+		tntQuotas = tmpQuotas
+	}()
+	config.SetCgrConfig(cfg)
+	tntQuotas = newTenantQuotas()
+
+	TenantSessionStarted("cgrates.org")
+	if rsrvd, err := ReserveTenantSession("cgrates.org"); err != nil {
+		t.Error(err)
+	} else if rsrvd {
+		t.Error("Expected no reservation without quota")
+	}
+	cfg.QuotasCfg().Enabled = true
+	cfg.QuotasCfg().Tenants["cgrates.org"] = &config.TenantQuotaCfg{Sessions: 2, CDRsRate: 2}
+	if rsrvd, err := ReserveTenantSession("cgrates.org"); err != nil {
+		t.Error(err)
+	} else if !rsrvd {
+		t.Error("Expected the session to be reserved")
+	}
+	expErr := "TENANT_QUOTA_EXCEEDED: <sessions> for tenant <cgrates.org>"
+	// the reserved session counts towards the quota
+	if _, err := ReserveTenantSession("cgrates.org"); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received: %v", expErr, err)
+	}
+	if _, err := ReserveTenantSession("itsyscom.com"); err != nil {
+		t.Error(err)
+	}
+	ReleaseTenantSession("cgrates.org")
+	TenantSessionEnded("cgrates.org")
+	if rsrvd, err := ReserveTenantSession("cgrates.org"); err != nil {
+		t.Error(err)
+	} else if rsrvd {
+		ReleaseTenantSession("cgrates.org")
+	}
+	if len(tntQuotas.sessions) != 0 || len(tntQuotas.reserved) != 0 {
+		t.Errorf("Expected no sessions, received: %v and %v reserved", tntQuotas.sessions, tntQuotas.reserved)
+	}
+
+	if err := AllowTenantCDR("cgrates.org"); err != nil {
+		t.Error(err)
+	}
+	tntQuotas.cdrs = make(map[string]*rateWindow)
+	for i := 0; i < 2; i++ {
+		if err := tntQuotas.allowCDR("cgrates.org", 2, 10); err != nil {
+			t.Error(err)
+		}
+	}
+	expErr = "TENANT_QUOTA_EXCEEDED: <cdrs_rate> for tenant <cgrates.org>"
+	if err := tntQuotas.allowCDR("cgrates.org", 2, 10); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received: %v", expErr, err)
+	}
+	if err := tntQuotas.allowCDR("cgrates.org", 2, 11); err != nil { // new window
+		t.Error(err)
+	}
+}
+
+func TestTenantQuotasObjects(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	tmpCfg := config.CgrConfig()
+	tmpDm := dm
+	defer func() {
+		config.SetCgrConfig(tmpCfg)
+		SetDataStorage(tmpDm)
+	}()
+	config.SetCgrConfig(cfg)
+	dMgr := NewDataManager(NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	SetDataStorage(dMgr)
+	resetTenantObjects()
+	defer resetTenantObjects()
+	cfg.QuotasCfg().Enabled = true
+	cfg.QuotasCfg().Tenants[utils.MetaDefault] = &config.TenantQuotaCfg{
+		APIRequests: 1,
+		Objects: map[string]int{
+			utils.CacheChargerProfiles: 1,
+			utils.MetaAccounts:         1,
+		},
+	}
+
+	cpp := &ChargerProfile{Tenant: "cgrates.org", ID: "CPP_1", RunID: utils.MetaDefault}
+	if err := dMgr.SetChargerProfile(cpp, false); err != nil {
+		t.Fatal(err)
+	}
+	if err := dMgr.SetChargerProfile(cpp, false); err != nil { // update of an existing item
+		t.Error(err)
+	}
+	expErr := "TENANT_QUOTA_EXCEEDED: <*charger_profiles> for tenant <cgrates.org>"
+	if err := dMgr.SetChargerProfile(&ChargerProfile{Tenant: "cgrates.org", ID: "CPP_2"}, false); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received: %v", expErr, err)
+	}
+	if err := dMgr.SetChargerProfile(&ChargerProfile{Tenant: "itsyscom.com", ID: "CPP_2"}, false); err != nil {
+		t.Error(err)
+	}
+	if err := dMgr.RemoveChargerProfile("cgrates.org", "CPP_1", false); err != nil {
+		t.Fatal(err)
+	}
+	if err := dMgr.SetChargerProfile(&ChargerProfile{Tenant: "cgrates.org", ID: "CPP_2"}, false); err != nil {
+		t.Error(err)
+	}
+	if err := dMgr.SetAccount(&Account{ID: "cgrates.org:1001"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := dMgr.SetAccount(&Account{ID: "cgrates.org:1001", AllowNegative: true}); err != nil { // update of an existing item
+		t.Error(err)
+	}
+	expErr = "TENANT_QUOTA_EXCEEDED: <*accounts> for tenant <cgrates.org>"
+	if err := dMgr.SetAccount(&Account{ID: "cgrates.org:1002"}); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error %s, received: %v", expErr, err)
+	}
+
+	caps := NewCaps(0, utils.MetaBusy)
+	if err := caps.AllocateTenant("cgrates.org"); err != nil {
+		t.Fatal(err)
+	}
+	sts, err := GetTenantQuotaStatus("cgrates.org", caps)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !sts.Enabled || sts.Tenant != "cgrates.org" ||
+		*sts.APIRequests != (QuotaUsage{Limit: 1, Used: 1}) ||
+		*sts.Objects[utils.CacheChargerProfiles] != (QuotaUsage{Limit: 1, Used: 1}) ||
+		*sts.Objects[utils.MetaAccounts] != (QuotaUsage{Limit: 1, Used: 1}) ||
+		*sts.Objects[utils.CacheAttributeProfiles] != (QuotaUsage{}) {
+		t.Errorf("Unexpected status: %s", utils.ToJSON(sts))
+	}
+	if len(sts.Objects) != len(utils.TenantQuotaObjects) {
+		t.Errorf("Expected %d object types, received: %s", len(utils.TenantQuotaObjects), utils.ToJSON(sts.Objects))
+	}
+}
@@ -46,8 +46,10 @@ type ResourceProfile struct {
 	AllocationMessage  string                    // message returned by the winning resource on allocation
 	Blocker            bool                      // blocker flag to stop processing on filters matched
 	Stored             bool
-	Weight             float64  // Weight to sort the resources
-	ThresholdIDs       []string // Thresholds to check after changing Limit
+	Weight             float64       // Weight to sort the resources
+	ThresholdIDs       []string      // Thresholds to check after changing Limit
+	Rate               float64       // units refilled each RateInterval, Limit being the burst for rate-limit resources
+	RateInterval       time.Duration // makes the resource a rate limiter (token bucket) if not 0
 
 	lkID string // holds the reference towards guardian lock key
 }
@@ -93,6 +95,33 @@ func (rp *ResourceProfile) isLocked() bool {
 	return rp.lkID != utils.EmptyString
 }
 
+// isRateLimit returns true if the resource limits the rate of the usages instead of the concurrent ones
+func (rp *ResourceProfile) isRateLimit() bool {
+	return rp.RateInterval > 0
+}
+
+// ResourceBucket holds the token bucket of the rate-limit resources
+// one bucket is kept per resource instead of the individual usages
+type ResourceBucket struct {
+	Tokens  float64   // units available at the Updated time
+	Updated time.Time // last time the tokens were calculated
+}
+
+// tokensAt returns the units available at a specific time, refilling the bucket based on the profile rate
+func (rb *ResourceBucket) tokensAt(rp *ResourceProfile, atTime time.Time) (tkns float64) {
+	if rb == nil { // never used, the bucket is full
+		return rp.Limit
+	}
+	tkns = rb.Tokens
+	if elapsed := atTime.Sub(rb.Updated); elapsed > 0 {
+		tkns += rp.Rate * float64(elapsed) / float64(rp.RateInterval)
+	}
+	if tkns > rp.Limit {
+		tkns = rp.Limit
+	}
+	return
+}
+
 // ResourceUsage represents an usage counted
 type ResourceUsage struct {
 	Tenant     string
@@ -125,6 +154,7 @@ type Resource struct {
 	ID     string
 	Usages map[string]*ResourceUsage
 	TTLIdx []string         // holds ordered list of ResourceIDs based on their TTL, empty if feature is disableda
+	Bucket *ResourceBucket  // token bucket for the rate-limit resources, nil until first usage
 	lkID   string           // ID of the lock used when matching the resource
 	ttl    *time.Duration   // time to leave for this resource, picked up on each Resource initialization out of config
 	tUsage *float64         // sum of all usages
@@ -223,11 +253,26 @@ func (r *Resource) TotalUsage() (tU float64) {
 // Available returns the available number of units
 // Exported method to be used by filterS
 func (r *ResourceWithConfig) Available() float64 {
+	if r.Config.isRateLimit() {
+		return r.Bucket.tokensAt(r.Config, time.Now())
+	}
 	return r.Config.Limit - r.TotalUsage()
 }
 
+// consumeTokens takes the units out of the bucket of a rate-limit resource
+func (r *Resource) consumeTokens(units float64, atTime time.Time) {
+	r.Bucket = &ResourceBucket{
+		Tokens:  r.Bucket.tokensAt(r.rPrf, atTime) - units,
+		Updated: atTime,
+	}
+}
+
 // recordUsage records a new usage
 func (r *Resource) recordUsage(ru *ResourceUsage) (err error) {
+	if r.rPrf != nil && r.rPrf.isRateLimit() { // no usage is kept, the units are taken out of the bucket
+		r.consumeTokens(ru.Units, time.Now())
+		return
+	}
 	if _, hasID := r.Usages[ru.ID]; hasID {
 		return fmt.Errorf("duplicate resource usage with id: %s", ru.TenantID())
 	}
@@ -250,6 +295,9 @@ func (r *Resource) recordUsage(ru *ResourceUsage) (err error) {
 
 // clearUsage clears the usage for an ID
 func (r *Resource) clearUsage(ruID string) (err error) {
+	if r.rPrf != nil && r.rPrf.isRateLimit() { // the consumed tokens are not given back on release
+		return
+	}
 	ru, hasIt := r.Usages[ruID]
 	if !hasIt {
 		return fmt.Errorf("cannot find usage record with id: %s", ruID)
@@ -336,6 +384,7 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 		return "", utils.ErrResourceUnavailable
 	}
 	// Simulate resource usage
+	now := time.Now()
 	for _, r := range rs {
 		r.removeExpiredUnits()
 		if _, hasID := r.Usages[ru.ID]; hasID && !dryRun { // update
@@ -345,6 +394,13 @@ func (rs Resources) allocateResource(ru *ResourceUsage, dryRun bool) (alcMessage
 			err = fmt.Errorf("empty configuration for resourceID: %s", r.TenantID())
 			return
 		}
+		if r.rPrf.isRateLimit() {
+			if alcMessage == utils.EmptyString &&
+				(r.Bucket.tokensAt(r.rPrf, now) >= ru.Units || r.rPrf.Limit == -1) {
+				alcMessage = utils.FirstNonEmpty(r.rPrf.AllocationMessage, r.rPrf.ID)
+			}
+			continue
+		}
 		if alcMessage == utils.EmptyString &&
 			(r.rPrf.Limit >= r.TotalUsage()+ru.Units || r.rPrf.Limit == -1) {
 			alcMessage = utils.FirstNonEmpty(r.rPrf.AllocationMessage, r.rPrf.ID)
@@ -0,0 +1,187 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// states of the route circuit breaker
+const (
+	breakerClosed   = "closed"
+	breakerOpen     = "open"
+	breakerHalfOpen = "half-open"
+)
+
+// routeBreaker keeps the circuit breaker state of one route
+type routeBreaker struct {
+	state     string
+	consec    int    // consecutive failures
+	outcomes  []bool // last outcomes within the window, true for failure
+	openUntil time.Time
+	probes    int // successful probes while half-open, counted on feedback
+}
+
+// failureRatio returns the ratio of failures within the window
+func (rb *routeBreaker) failureRatio() float64 {
+	if len(rb.outcomes) == 0 {
+		return 0
+	}
+	var failed int
+	for _, f := range rb.outcomes {
+		if f {
+			failed++
+		}
+	}
+	return float64(failed) / float64(len(rb.outcomes))
+}
+
+// open will trip the breaker for the configured period
+func (rb *routeBreaker) open(cfg *config.RouteBreakerCfg, now time.Time) {
+	rb.state = breakerOpen
+	rb.openUntil = now.Add(cfg.OpenPeriod)
+	rb.consec = 0
+	rb.outcomes = nil
+	rb.probes = 0
+}
+
+// allow decides if the route can be used, returning the reason when it is skipped
+// while half-open the route is returned until the feedback of the probes decides the state
+// since the queries not followed by calls do not use up the probes
+func (rb *routeBreaker) allow(now time.Time) (reason string, pass bool) {
+	if rb.state == breakerOpen {
+		if now.Before(rb.openUntil) {
+			return fmt.Sprintf("circuit breaker open until %s", rb.openUntil.Format(time.RFC3339)), false
+		}
+		rb.state = breakerHalfOpen
+		rb.probes = 0
+	}
+	return utils.EmptyString, true
+}
+
+// feedback updates the breaker state with the outcome of a call
+func (rb *routeBreaker) feedback(cfg *config.RouteBreakerCfg, failed bool, now time.Time) {
+	switch rb.state {
+	case breakerOpen: // the route was not used, late results are ignored
+		return
+	case breakerHalfOpen:
+		if failed {
+			rb.open(cfg, now)
+			return
+		}
+		if rb.probes++; rb.probes >= cfg.HalfOpenProbes {
+			*rb = routeBreaker{state: breakerClosed}
+		}
+		return
+	}
+	if !failed {
+		rb.consec = 0
+	} else {
+		rb.consec++
+	}
+	if cfg.Window > 0 {
+		rb.outcomes = append(rb.outcomes, failed)
+		if len(rb.outcomes) > cfg.Window {
+			rb.outcomes = rb.outcomes[len(rb.outcomes)-cfg.Window:]
+		}
+	}
+	if (cfg.Failures > 0 && rb.consec >= cfg.Failures) ||
+		(cfg.FailureRatio > 0 && cfg.Window > 0 &&
+			len(rb.outcomes) >= cfg.Window && rb.failureRatio() >= cfg.FailureRatio) {
+		rb.open(cfg, now)
+	}
+}
+
+// breakerCfg returns the circuit breaker configuration of the route or nil if not configured
+func (rpS *RouteService) breakerCfg(routeID string) (cfg *config.RouteBreakerCfg) {
+	brkCfgs := rpS.cgrcfg.RouteSCfg().CircuitBreakers
+	if cfg = brkCfgs[routeID]; cfg == nil {
+		cfg = brkCfgs[utils.MetaDefault]
+	}
+	return
+}
+
+// routeAllowed checks the circuit breaker of the route
+// returning the reason in case the route needs to be skipped
+func (rpS *RouteService) routeAllowed(tnt, routeID string) (reason string, pass bool) {
+	if rpS.breakerCfg(routeID) == nil {
+		return utils.EmptyString, true
+	}
+	rpS.brkMux.Lock()
+	defer rpS.brkMux.Unlock()
+	rb, has := rpS.breakers[utils.ConcatenatedKey(tnt, routeID)]
+	if !has {
+		return utils.EmptyString, true
+	}
+	return rb.allow(time.Now())
+}
+
+// processFeedback updates the circuit breaker of the route with the outcome of the call
+func (rpS *RouteService) processFeedback(tnt, routeID string, failed bool) {
+	cfg := rpS.breakerCfg(routeID)
+	if cfg == nil {
+		return
+	}
+	tntID := utils.ConcatenatedKey(tnt, routeID)
+	rpS.brkMux.Lock()
+	defer rpS.brkMux.Unlock()
+	if rpS.breakers == nil {
+		rpS.breakers = make(map[string]*routeBreaker)
+	}
+	rb, has := rpS.breakers[tntID]
+	if !has {
+		rb = &routeBreaker{state: breakerClosed}
+		rpS.breakers[tntID] = rb
+	}
+	rb.feedback(cfg, failed, time.Now())
+}
+
+// V1ProcessFeedback feeds the circuit breaker of a route with the outcome of a call,
+// the call being considered failed if the AnswerTime is missing or empty
+func (rpS *RouteService) V1ProcessFeedback(args *utils.CGREvent, reply *string) (err error) {
+	if args == nil {
+		return utils.NewErrMandatoryIeMissing(utils.CGREventString)
+	}
+	if args.Event == nil {
+		return utils.NewErrMandatoryIeMissing(utils.Event)
+	}
+	routeID := utils.IfaceAsString(args.Event[utils.RouteID])
+	if routeID == utils.EmptyString {
+		return utils.NewErrMandatoryIeMissing(utils.RouteID)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = rpS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	failed := true
+	var at time.Time
+	if at, err = args.FieldAsTime(utils.AnswerTime,
+		rpS.cgrcfg.GeneralCfg().DefaultTimezone); err == nil {
+		failed = at.IsZero()
+	} else if err != utils.ErrNotFound {
+		return
+	}
+	rpS.processFeedback(tnt, routeID, failed)
+	*reply = utils.OK
+	return nil
+}
@@ -0,0 +1,166 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func TestRouteBreakerFailures(t *testing.T) {
+	cfg := &config.RouteBreakerCfg{
+		Failures:       2,
+		OpenPeriod:     time.Minute,
+		HalfOpenProbes: 1,
+	}
+	now := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	rb := &routeBreaker{state: breakerClosed}
+	rb.feedback(cfg, true, now)
+	rb.feedback(cfg, false, now)
+	rb.feedback(cfg, true, now)
+	if _, pass := rb.allow(now); !pass || rb.state != breakerClosed {
+		t.Errorf("expected closed breaker, received: %+v", rb)
+	}
+	rb.feedback(cfg, true, now)
+	if rb.state != breakerOpen {
+		t.Errorf("expected open breaker, received: %+v", rb)
+	}
+	expReason := "circuit breaker open until 2021-01-01T10:01:00Z"
+	if reason, pass := rb.allow(now.Add(time.Second)); pass || reason != expReason {
+		t.Errorf("expected %q, received %q", expReason, reason)
+	}
+	// the open period passed, the route is probed
+	if _, pass := rb.allow(now.Add(time.Minute)); !pass || rb.state != breakerHalfOpen {
+		t.Errorf("expected half-open breaker, received: %+v", rb)
+	}
+	// failed probe opens the breaker again
+	rb.feedback(cfg, true, now.Add(time.Minute))
+	if rb.state != breakerOpen || !rb.openUntil.Equal(now.Add(2*time.Minute)) {
+		t.Errorf("expected open breaker, received: %+v", rb)
+	}
+	rb.allow(now.Add(2 * time.Minute))
+	rb.feedback(cfg, false, now.Add(2*time.Minute))
+	if exp := (&routeBreaker{state: breakerClosed}); !reflect.DeepEqual(exp, rb) {
+		t.Errorf("expected %+v, received: %+v", exp, rb)
+	}
+}
+
+func TestRouteBreakerProbesOnFeedback(t *testing.T) {
+	cfg := &config.RouteBreakerCfg{
+		Failures:       1,
+		OpenPeriod:     time.Minute,
+		HalfOpenProbes: 2,
+	}
+	now := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	rb := &routeBreaker{state: breakerClosed}
+	rb.feedback(cfg, true, now)
+	// the queries without feedback do not use up the probes
+	for i := 0; i < 5; i++ {
+		if _, pass := rb.allow(now.Add(time.Minute)); !pass || rb.state != breakerHalfOpen {
+			t.Errorf("expected query %d to return the route, received: %+v", i, rb)
+		}
+	}
+	rb.feedback(cfg, false, now.Add(time.Minute))
+	if rb.state != breakerHalfOpen || rb.probes != 1 {
+		t.Errorf("expected half-open breaker with one probe, received: %+v", rb)
+	}
+	if _, pass := rb.allow(now.Add(time.Minute)); !pass {
+		t.Error("expected the route to be returned until all the probes got feedback")
+	}
+	rb.feedback(cfg, false, now.Add(time.Minute))
+	if exp := (&routeBreaker{state: breakerClosed}); !reflect.DeepEqual(exp, rb) {
+		t.Errorf("expected %+v, received: %+v", exp, rb)
+	}
+}
+
+func TestRouteBreakerFailureRatio(t *testing.T) {
+	cfg := &config.RouteBreakerCfg{
+		FailureRatio:   0.5,
+		Window:         4,
+		OpenPeriod:     time.Minute,
+		HalfOpenProbes: 1,
+	}
+	now := time.Now()
+	rb := &routeBreaker{state: breakerClosed}
+	for _, failed := range []bool{true, false, true} {
+		rb.feedback(cfg, failed, now)
+	}
+	if rb.state != breakerClosed { // window not yet full
+		t.Errorf("expected closed breaker, received: %+v", rb)
+	}
+	rb.feedback(cfg, false, now)
+	if rb.state != breakerOpen {
+		t.Errorf("expected open breaker, received: %+v", rb)
+	}
+}
+
+func TestRoutesSortedForEventCircuitBreaker(t *testing.T) {
+	Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	cfg.RouteSCfg().StringIndexedFields = nil
+	cfg.RouteSCfg().PrefixIndexedFields = nil
+	cfg.RouteSCfg().CircuitBreakers[utils.MetaDefault] = &config.RouteBreakerCfg{
+		Failures:       2,
+		OpenPeriod:     time.Hour,
+		HalfOpenProbes: 1,
+	}
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dmSPP := NewDataManager(data, config.CgrConfig().CacheCfg(), nil)
+	routeService := NewRouteService(dmSPP, &FilterS{
+		dm: dmSPP, cfg: cfg}, cfg, nil)
+	prepareRoutesData(t, dmSPP)
+
+	var reply string
+	for i := 0; i < 2; i++ {
+		if err := routeService.V1ProcessFeedback(&utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     "feedback",
+			Event: map[string]interface{}{
+				utils.RouteID:    "route2",
+				utils.AnswerTime: time.Time{},
+			},
+		}, &reply); err != nil {
+			t.Fatal(err)
+		} else if reply != utils.OK {
+			t.Errorf("unexpected reply: %s", reply)
+		}
+	}
+	sprf, err := routeService.sortedRoutesForEvent("cgrates.org", testRoutesArgs[1])
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rIDs := sprf.RouteIDs(); !reflect.DeepEqual([]string{"route1", "route3"}, rIDs) {
+		t.Errorf("unexpected routes: %+v", rIDs)
+	}
+	if reason := sprf[0].SkippedRoutes["route2"]; !strings.HasPrefix(reason, "circuit breaker open until") {
+		t.Errorf("unexpected skipped routes: %+v", sprf[0].SkippedRoutes)
+	}
+
+	expErr := "MANDATORY_IE_MISSING: [RouteID]"
+	if err := routeService.V1ProcessFeedback(&utils.CGREvent{
+		Event: map[string]interface{}{},
+	}, &reply); err == nil || err.Error() != expErr {
+		t.Errorf("expected %q, received %v", expErr, err)
+	}
+}
@@ -0,0 +1,58 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewScoreSorter constructs ScoreSorter
+func NewScoreSorter(rS *RouteService) *ScoreSorter {
+	return &ScoreSorter{rS: rS,
+		sorting: utils.MetaScore}
+}
+
+// ScoreSorter orders routes based on a weighted score of multiple metrics
+type ScoreSorter struct {
+	sorting string
+	rS      *RouteService
+}
+
+// SortRoutes .
+func (ss *ScoreSorter) SortRoutes(prflID string, routes map[string]*Route,
+	ev *utils.CGREvent, extraOpts *optsGetRoutes) (sortedRoutes *SortedRoutes, err error) {
+	var sMetrics []*scoreMetric
+	if sMetrics, err = newScoreMetrics(extraOpts.sortingParameters); err != nil {
+		return
+	}
+	popOpts := *extraOpts
+	popOpts.sortingParameters = nil // the parameters are not metric names, the missing metrics are handled by the score
+	sortedRoutes = &SortedRoutes{ProfileID: prflID,
+		Sorting: ss.sorting,
+		Routes:  make([]*SortedRoute, 0)}
+	for _, route := range routes {
+		if srtRoute, pass, err := ss.rS.populateSortingData(ev, route, &popOpts); err != nil {
+			return nil, err
+		} else if pass && srtRoute != nil {
+			sortedRoutes.Routes = append(sortedRoutes.Routes, srtRoute)
+		}
+	}
+	sortedRoutes.SortScore(sMetrics)
+	return
+}
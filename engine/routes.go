@@ -24,6 +24,7 @@ import (
 	"sort"
 	"strconv"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/cgrates/cgrates/config"
@@ -65,6 +66,10 @@ type RouteProfileWithAPIOpts struct {
 }
 
 func (rp *RouteProfile) compileCacheParameters() error {
+	if rp.Sorting == utils.MetaScore { // validate the score metrics
+		_, err := newScoreMetrics(rp.SortingParameters)
+		return err
+	}
 	if rp.Sorting == utils.MetaLoad {
 		// construct the map for ratio
 		ratioMap := make(map[string]int)
@@ -120,6 +125,8 @@ func NewRouteService(dm *DataManager,
 		filterS: filterS,
 		cgrcfg:  cgrcfg,
 		connMgr: connMgr,
+
+		breakers: make(map[string]*routeBreaker),
 	}
 	rS.sorter = NewRouteSortDispatcher(rS)
 	return
@@ -132,6 +139,9 @@ type RouteService struct {
 	cgrcfg  *config.CGRConfig
 	sorter  RouteSortDispatcher
 	connMgr *ConnManager
+
+	brkMux   sync.Mutex               // protects the breakers
+	breakers map[string]*routeBreaker // circuit breakers indexed on tenant:routeID
 }
 
 // Shutdown is called to shutdown the service
@@ -630,6 +640,7 @@ func (rpS *RouteService) sortedRoutesForProfile(tnt string, rPrfl *RouteProfile,
 		utils.MetaOpts: ev.APIOpts,
 	}
 	passedRoutes := make(map[string]*Route)
+	var skippedRoutes map[string]string
 	// apply filters for event
 	for _, route := range rPrfl.Routes {
 		pass, lazyCheckRules, err := rpS.filterS.LazyPass(tnt,
@@ -643,6 +654,13 @@ func (rpS *RouteService) sortedRoutesForProfile(tnt string, rPrfl *RouteProfile,
 		} else if !pass {
 			continue
 		}
+		if reason, allowed := rpS.routeAllowed(tnt, route.ID); !allowed {
+			if skippedRoutes == nil {
+				skippedRoutes = make(map[string]string)
+			}
+			skippedRoutes[route.ID] = reason
+			continue
+		}
 		route.lazyCheckRules = lazyCheckRules
 		if prev, has := passedRoutes[route.ID]; has && prev.Weight >= route.Weight {
 			continue
@@ -654,6 +672,7 @@ func (rpS *RouteService) sortedRoutesForProfile(tnt string, rPrfl *RouteProfile,
 		passedRoutes, ev, extraOpts); err != nil {
 		return nil, err
 	}
+	sortedRoutes.SkippedRoutes = skippedRoutes
 	if pag.Offset != nil {
 		if *pag.Offset <= len(sortedRoutes.Routes) {
 			sortedRoutes.Routes = sortedRoutes.Routes[*pag.Offset:]
@@ -21,6 +21,7 @@ package engine
 import (
 	"fmt"
 	"runtime"
+	"sort"
 	"sync"
 	"time"
 
@@ -38,6 +39,7 @@ func NewStatService(dm *DataManager, cgrcfg *config.CGRConfig,
 		filterS:          filterS,
 		cgrcfg:           cgrcfg,
 		storedStatQueues: make(utils.StringSet),
+		sqChildren:       make(map[string]*statQueueChildren),
 		loopStopped:      make(chan struct{}),
 		stopBackup:       make(chan struct{}),
 	}
@@ -51,8 +53,11 @@ type StatService struct {
 	cgrcfg           *config.CGRConfig
 	loopStopped      chan struct{}
 	stopBackup       chan struct{}
-	storedStatQueues utils.StringSet // keep a record of stats which need saving, map[statsTenantID]bool
-	ssqMux           sync.RWMutex    // protects storedStatQueues
+	storedStatQueues utils.StringSet               // keep a record of stats which need saving, map[statsTenantID]bool
+	ssqMux           sync.RWMutex                  // protects storedStatQueues
+	sqChildren       map[string]*statQueueChildren // child queues, indexed on the profile tenantID
+	sqcLastSweep     time.Time                     // last time the idle children were removed
+	sqcMux           sync.Mutex                    // protects sqChildren and sqcLastSweep
 }
 
 // Reload stops the backupLoop and restarts it
@@ -205,6 +210,20 @@ func (sS *StatService) matchingStatQueuesForEvent(tnt string, statsIDs []string,
 				continue
 			}
 		}
+		if len(sqPrfl.GroupBy) != 0 {
+			var sq *StatQueue
+			if sq, err = sS.childStatQueue(sqPrfl, evNm); err != nil {
+				sqPrfl.unlock()
+				if err == utils.ErrNotFound { // grouping fields not in event
+					err = nil
+					continue
+				}
+				sqs.unlock()
+				return nil, err
+			}
+			sqs = append(sqs, sq)
+			continue
+		}
 		lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
 			config.CgrConfig().GeneralCfg().LockingTimeout,
 			statQueueLockKey(sqPrfl.Tenant, sqPrfl.ID))
@@ -240,6 +259,111 @@ func (sS *StatService) matchingStatQueuesForEvent(tnt string, statsIDs []string,
 	return
 }
 
+// childStatQueue returns the locked child queue of the profile for the event, creating it if missing
+func (sS *StatService) childStatQueue(sqPrfl *StatQueueProfile, evNm utils.DataProvider) (sq *StatQueue, err error) {
+	chldIDs := make([]string, 1, len(sqPrfl.GroupBy)+1)
+	chldIDs[0] = sqPrfl.ID
+	for _, grpBy := range sqPrfl.GroupBy {
+		var rsr *config.RSRParser
+		if rsr, err = config.NewRSRParser(grpBy); err != nil {
+			return
+		}
+		var grpVal string
+		if grpVal, err = rsr.ParseDataProvider(evNm); err != nil {
+			return
+		}
+		chldIDs = append(chldIDs, grpVal)
+	}
+	chldID := utils.ConcatenatedKey(chldIDs...)
+	now := time.Now()
+	sS.sqcMux.Lock()
+	sS.sweepChildren(now)
+	chldrn, has := sS.sqChildren[sqPrfl.TenantID()]
+	if !has || !chldrn.createdOutOf(sqPrfl) { // the children of a changed profile start over
+		chldrn = &statQueueChildren{
+			sqPrfl: sqPrfl,
+			queues: make(map[string]*statQueueChild),
+		}
+		sS.sqChildren[sqPrfl.TenantID()] = chldrn
+	}
+	chld, has := chldrn.queues[chldID]
+	if !has {
+		var chldSq *StatQueue
+		if chldSq, err = NewStatQueue(sqPrfl.Tenant, chldID,
+			sqPrfl.Metrics, sqPrfl.MinItems); err != nil {
+			sS.sqcMux.Unlock()
+			return
+		}
+		chld = &statQueueChild{sq: chldSq}
+		chldrn.queues[chldID] = chld
+	}
+	chld.lastUsed = now
+	sS.sqcMux.Unlock()
+	sq = chld.sq
+	sq.lock(utils.EmptyString)
+	if sqPrfl.TTL > 0 {
+		sq.ttl = utils.DurationPointer(sqPrfl.TTL)
+	}
+	sq.sqPrfl = sqPrfl
+	return
+}
+
+// sweepChildren removes the idle children and the ones of the removed or changed profiles
+// the sweep is done at most once per child_queues_ttl
+// needs to be called under sqcMux lock
+func (sS *StatService) sweepChildren(now time.Time) {
+	ttl := sS.cgrcfg.StatSCfg().ChildQueuesTTL
+	if ttl <= 0 || now.Sub(sS.sqcLastSweep) < ttl {
+		return
+	}
+	sS.sqcLastSweep = now
+	for prflTntID, chldrn := range sS.sqChildren {
+		if !sS.profileChildrenValid(prflTntID, chldrn) {
+			continue
+		}
+		chldrn.remIdle(ttl, now)
+		if len(chldrn.queues) == 0 {
+			delete(sS.sqChildren, prflTntID)
+		}
+	}
+}
+
+// profileChildrenValid drops the children if their profile was removed or changed since they were created
+// needs to be called under sqcMux lock
+func (sS *StatService) profileChildrenValid(prflTntID string, chldrn *statQueueChildren) bool {
+	sqPrfl, err := sS.dm.GetStatQueueProfile(chldrn.sqPrfl.Tenant, chldrn.sqPrfl.ID,
+		true, true, utils.NonTransactional)
+	if err == utils.ErrNotFound ||
+		err == nil && !chldrn.createdOutOf(sqPrfl) {
+		delete(sS.sqChildren, prflTntID)
+		return false
+	}
+	return true
+}
+
+// getChildQueue returns the child queue with the given ID or nil if not found
+func (sS *StatService) getChildQueue(tnt, id string) *StatQueue {
+	sS.sqcMux.Lock()
+	defer sS.sqcMux.Unlock()
+	if len(sS.sqChildren) == 0 {
+		return nil
+	}
+	// the child ID is built out of the profile ID followed by the grouping values
+	for idx, char := range id {
+		if string(char) != utils.ConcatenatedKeySep {
+			continue
+		}
+		prflTntID := utils.ConcatenatedKey(tnt, id[:idx])
+		if chldrn, has := sS.sqChildren[prflTntID]; has &&
+			sS.profileChildrenValid(prflTntID, chldrn) {
+			if chld, has := chldrn.queues[id]; has {
+				return chld.sq
+			}
+		}
+	}
+	return nil
+}
+
 // Call implements rpcclient.ClientConnector interface for internal RPC
 // here for cases when passing StatsService as rpccclient.RpcClientConnection
 func (sS *StatService) Call(serviceMethod string, args interface{}, reply interface{}) error {
@@ -247,8 +371,10 @@ func (sS *StatService) Call(serviceMethod string, args interface{}, reply interf
 }
 
 func (sS *StatService) getStatQueue(tnt, id string) (sq *StatQueue, err error) {
-	if sq, err = sS.dm.GetStatQueue(tnt, id, true, true, utils.EmptyString); err != nil {
-		return
+	if sq = sS.getChildQueue(tnt, id); sq == nil {
+		if sq, err = sS.dm.GetStatQueue(tnt, id, true, true, utils.EmptyString); err != nil {
+			return
+		}
 	}
 	var removed int
 	if removed, err = sq.remExpired(); err != nil || removed == 0 {
@@ -301,6 +427,9 @@ func (sS *StatService) processThresholds(sQs StatQueues, opts map[string]interfa
 			},
 			APIOpts: opts,
 		}
+		if sq.ID != sq.sqPrfl.ID { // child queue, thresholds can match all the children of a profile
+			thEv.Event[utils.ParentStatID] = sq.sqPrfl.ID
+		}
 		for metricID, metric := range sq.SQMetrics {
 			thEv.Event[metricID] = metric.GetValue(sS.cgrcfg.GeneralCfg().RoundingDecimals)
 		}
@@ -532,10 +661,13 @@ func (sS *StatService) V1ResetStatQueue(tntID *utils.TenantID, rply *string) (er
 		config.CgrConfig().GeneralCfg().LockingTimeout,
 		statQueueLockKey(tnt, tntID.ID))
 	defer guardian.Guardian.UnguardIDs(lkID)
-	var sq *StatQueue
-	if sq, err = sS.dm.GetStatQueue(tnt, tntID.ID,
-		true, true, utils.NonTransactional); err != nil {
-		return
+	sq := sS.getChildQueue(tnt, tntID.ID)
+	isChild := sq != nil
+	if !isChild {
+		if sq, err = sS.dm.GetStatQueue(tnt, tntID.ID,
+			true, true, utils.NonTransactional); err != nil {
+			return
+		}
 	}
 	sq.SQItems = make([]SQItem, 0)
 	metrics := sq.SQMetrics
@@ -548,8 +680,36 @@ func (sS *StatService) V1ResetStatQueue(tntID *utils.TenantID, rply *string) (er
 		}
 		sq.SQMetrics[id] = metric
 	}
-	sq.dirty = utils.BoolPointer(true)
-	sS.storeStatQueue(sq)
+	if !isChild { // the child queues are not stored
+		sq.dirty = utils.BoolPointer(true)
+		sS.storeStatQueue(sq)
+	}
 	*rply = utils.OK
 	return
 }
+
+// V1GetQueueChildren returns the IDs of the child queues created out of a StatQueueProfile
+func (sS *StatService) V1GetQueueChildren(args *utils.TenantID, reply *[]string) (err error) {
+	if missing := utils.MissingStructFields(args, []string{utils.ID}); len(missing) != 0 { //Params missing
+		return utils.NewErrMandatoryIeMissing(missing...)
+	}
+	tnt := args.Tenant
+	if tnt == utils.EmptyString {
+		tnt = sS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	prflTntID := utils.ConcatenatedKey(tnt, args.ID)
+	sS.sqcMux.Lock()
+	defer sS.sqcMux.Unlock()
+	sS.sweepChildren(time.Now())
+	chldrn, has := sS.sqChildren[prflTntID]
+	if !has || !sS.profileChildrenValid(prflTntID, chldrn) {
+		return utils.ErrNotFound
+	}
+	chldIDs := make([]string, 0, len(chldrn.queues))
+	for chldID := range chldrn.queues {
+		chldIDs = append(chldIDs, chldID)
+	}
+	sort.Strings(chldIDs)
+	*reply = chldIDs
+	return
+}
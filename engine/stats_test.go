@@ -3149,3 +3149,219 @@ func TestStatQueueStoreStatQueueCacheSetErr(t *testing.T) {
 
 	utils.Logger.SetLogLevel(0)
 }
+
+func TestStatQueueProcessEventChildQueues(t *testing.T) {
+	tmpC := config.CgrConfig()
+	tmpCM := connMgr
+	defer func() {
+		config.SetCgrConfig(tmpC)
+		connMgr = tmpCM
+	}()
+
+	cfg := config.NewDefaultCGRConfig()
+	cfg.StatSCfg().ThresholdSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds)}
+	cfg.StatSCfg().ChildQueuesTTL = time.Hour
+	data := NewInternalDB(nil, nil, true, config.CgrConfig().DataDbCfg().Items)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	Cache.Clear(nil)
+
+	var thEvs []*utils.CGREvent
+	ccM := &ccMock{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.ThresholdSv1ProcessEvent: func(args, reply interface{}) error {
+				thEvs = append(thEvs, args.(*utils.CGREvent))
+				return nil
+			},
+		},
+	}
+	rpcInternal := make(chan rpcclient.ClientConnector, 1)
+	rpcInternal <- ccM
+	connMgr = NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaThresholds): rpcInternal,
+	})
+	sS := NewStatService(dm, cfg, NewFilterS(cfg, nil, dm), connMgr)
+
+	sqPrf := &StatQueueProfile{
+		Tenant:       "cgrates.org",
+		ID:           "SQ_ROUTE",
+		FilterIDs:    []string{"*string:~*req.Account:1001"},
+		QueueLength:  10,
+		ThresholdIDs: []string{"TH1"},
+		Metrics: []*MetricWithFilters{
+			{
+				MetricID: utils.MetaTCC,
+			},
+		},
+		GroupBy: []string{"~*req.Route", "~*req.Destination:s/^(\\d{2}).*/${1}/"},
+	}
+	if err := dm.SetStatQueueProfile(sqPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	evs := []*utils.CGREvent{
+		{
+			Tenant: "cgrates.org",
+			ID:     "ev1",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+				utils.Route:        "RT1",
+				utils.Destination:  "4986517174963",
+				utils.Cost:         2,
+			},
+		},
+		{
+			Tenant: "cgrates.org",
+			ID:     "ev2",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+				utils.Route:        "RT2",
+				utils.Destination:  "4986517174963",
+				utils.Cost:         3,
+			},
+		},
+		{
+			Tenant: "cgrates.org",
+			ID:     "ev3",
+			Event: map[string]interface{}{
+				utils.AccountField: "1001",
+				utils.Route:        "RT1",
+				utils.Destination:  "4912345",
+				utils.Cost:         4,
+			},
+		},
+	}
+	expIDs := []string{"SQ_ROUTE:RT1:49", "SQ_ROUTE:RT2:49", "SQ_ROUTE:RT1:49"}
+	for i, ev := range evs {
+		var reply []string
+		if err := sS.V1ProcessEvent(ev, &reply); err != nil {
+			t.Fatal(err)
+		} else if !reflect.DeepEqual(reply, []string{expIDs[i]}) {
+			t.Errorf("expected: <%+v>, received: <%+v>", []string{expIDs[i]}, reply)
+		}
+	}
+	// the events not containing the grouping fields are not matching the profile
+	var reply []string
+	if err := sS.V1ProcessEvent(&utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "ev4",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.Destination:  "4912345",
+		},
+	}, &reply); err != utils.ErrNotFound {
+		t.Errorf("expected: <%+v>, received: <%+v>", utils.ErrNotFound, err)
+	}
+
+	if len(thEvs) != 3 {
+		t.Fatalf("expected 3 threshold events, received: %s", utils.ToJSON(thEvs))
+	}
+	for i, thEv := range thEvs {
+		if thEv.Event[utils.StatID] != expIDs[i] ||
+			thEv.Event[utils.ParentStatID] != "SQ_ROUTE" {
+			t.Errorf("unexpected threshold event: %s", utils.ToJSON(thEv))
+		}
+	}
+
+	var chldIDs []string
+	if err := sS.V1GetQueueChildren(&utils.TenantID{ID: "SQ_ROUTE"}, &chldIDs); err != nil {
+		t.Error(err)
+	} else if exp := []string{"SQ_ROUTE:RT1:49", "SQ_ROUTE:RT2:49"}; !reflect.DeepEqual(chldIDs, exp) {
+		t.Errorf("expected: <%+v>, received: <%+v>", exp, chldIDs)
+	}
+	var metrics map[string]float64
+	if err := sS.V1GetQueueFloatMetrics(&utils.TenantID{
+		Tenant: "cgrates.org",
+		ID:     "SQ_ROUTE:RT1:49",
+	}, &metrics); err != nil {
+		t.Error(err)
+	} else if exp := map[string]float64{utils.MetaTCC: 6}; !reflect.DeepEqual(metrics, exp) {
+		t.Errorf("expected: <%+v>, received: <%+v>", exp, metrics)
+	}
+	var rplyReset string
+	if err := sS.V1ResetStatQueue(&utils.TenantID{
+		Tenant: "cgrates.org",
+		ID:     "SQ_ROUTE:RT1:49",
+	}, &rplyReset); err != nil {
+		t.Error(err)
+	} else if err := sS.V1GetQueueFloatMetrics(&utils.TenantID{
+		Tenant: "cgrates.org",
+		ID:     "SQ_ROUTE:RT1:49",
+	}, &metrics); err != nil {
+		t.Error(err)
+	} else if exp := map[string]float64{utils.MetaTCC: -1}; !reflect.DeepEqual(metrics, exp) {
+		t.Errorf("expected: <%+v>, received: <%+v>", exp, metrics)
+	}
+	if _, err := dm.GetStatQueue("cgrates.org", "SQ_ROUTE:RT1:49",
+		true, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("expected: <%+v>, received: <%+v>", utils.ErrNotFound, err)
+	}
+
+	// the idle children are removed after the ttl
+	sS.sqcLastSweep = sS.sqcLastSweep.Add(-time.Hour)
+	sS.sqChildren["cgrates.org:SQ_ROUTE"].queues["SQ_ROUTE:RT2:49"].lastUsed = time.Now().Add(-time.Hour)
+	if err := sS.V1GetQueueChildren(&utils.TenantID{ID: "SQ_ROUTE"}, &chldIDs); err != nil {
+		t.Error(err)
+	} else if exp := []string{"SQ_ROUTE:RT1:49"}; !reflect.DeepEqual(chldIDs, exp) {
+		t.Errorf("expected: <%+v>, received: <%+v>", exp, chldIDs)
+	}
+
+	// the children are dropped once the profile is changed or removed
+	sqPrf2 := *sqPrf
+	sqPrf2.MinItems = 2
+	if err := dm.SetStatQueueProfile(&sqPrf2, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := Cache.Remove(utils.CacheStatQueueProfiles, "cgrates.org:SQ_ROUTE", true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := sS.V1GetQueueChildren(&utils.TenantID{ID: "SQ_ROUTE"}, &chldIDs); err != utils.ErrNotFound {
+		t.Errorf("expected: <%+v>, received: <%+v>", utils.ErrNotFound, err)
+	}
+	if err := sS.V1ProcessEvent(evs[0], &reply); err != nil {
+		t.Fatal(err)
+	}
+	if err := sS.V1GetQueueFloatMetrics(&utils.TenantID{
+		Tenant: "cgrates.org",
+		ID:     "SQ_ROUTE:RT1:49",
+	}, &metrics); err != nil {
+		t.Error(err)
+	} else if exp := map[string]float64{utils.MetaTCC: -1}; !reflect.DeepEqual(metrics, exp) {
+		t.Errorf("expected: <%+v>, received: <%+v>", exp, metrics)
+	}
+	if err := dm.RemoveStatQueueProfile("cgrates.org", "SQ_ROUTE", true); err != nil {
+		t.Fatal(err)
+	}
+	if err := Cache.Remove(utils.CacheStatQueueProfiles, "cgrates.org:SQ_ROUTE", true, utils.NonTransactional); err != nil {
+		t.Fatal(err)
+	}
+	if err := sS.V1GetQueueFloatMetrics(&utils.TenantID{
+		Tenant: "cgrates.org",
+		ID:     "SQ_ROUTE:RT1:49",
+	}, &metrics); err != utils.ErrNotFound {
+		t.Errorf("expected: <%+v>, received: <%+v>", utils.ErrNotFound, err)
+	}
+	if len(sS.sqChildren) != 0 {
+		t.Errorf("expected the children to be removed, received: %+v", sS.sqChildren)
+	}
+	if err := sS.V1GetQueueChildren(&utils.TenantID{ID: "SQ_NOT_FOUND"}, &chldIDs); err != utils.ErrNotFound {
+		t.Errorf("expected: <%+v>, received: <%+v>", utils.ErrNotFound, err)
+	}
+	if err := sS.V1GetQueueChildren(&utils.TenantID{}, &chldIDs); err == nil ||
+		err.Error() != "MANDATORY_IE_MISSING: [ID]" {
+		t.Errorf("expected: <MANDATORY_IE_MISSING: [ID]>, received: <%+v>", err)
+	}
+}
+
+func TestStatQueueSetStatQueueProfileInvalidGroupBy(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	sqPrf := &StatQueueProfile{
+		Tenant:  "cgrates.org",
+		ID:      "SQ_ROUTE",
+		GroupBy: []string{"~*req.Route:s/(/"},
+	}
+	expErr := "invalid GroupBy <~*req.Route:s/(/> for item with ID: cgrates.org:SQ_ROUTE"
+	if err := dm.SetStatQueueProfile(sqPrf, true); err == nil || err.Error() != expErr {
+		t.Errorf("expected: <%+v>, received: <%+v>", expErr, err)
+	}
+}
@@ -0,0 +1,853 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// DataDBBackendStatus is the status of one of the DataDBs handled by the FailoverDataDB
+type DataDBBackendStatus struct {
+	Type      string
+	Address   string
+	Name      string
+	Active    bool   // receiving the reads and the writes
+	Available bool   // answering to the health checks
+	Synced    bool   // having the same data as the active one
+	LastError string `json:",omitempty"`
+}
+
+// maxResyncWrites limits the writes queued while resyncing a backend, the resync
+// being retried on the next check if more writes are done during the copy
+const maxResyncWrites = 10000
+
+// failoverEpochVersion is the key within the versions keeping the number of promotions seen by the DataDB
+// a promoted backend and the ones synced with it get a higher epoch than the ones left behind,
+// so the backend holding the newest data is known after a restart and is never flushed
+const failoverEpochVersion = "*failover_epoch"
+
+// getFailoverEpoch returns the failover epoch of the DataDB, 0 if never promoted
+func getFailoverEpoch(db DataDB) (epoch int64, err error) {
+	var vrs Versions
+	if vrs, err = db.GetVersions(failoverEpochVersion); err != nil {
+		if err == utils.ErrNotFound {
+			err = nil
+		}
+		return
+	}
+	return vrs[failoverEpochVersion], nil
+}
+
+// setFailoverEpoch records the failover epoch within the DataDB versions
+func setFailoverEpoch(db DataDB, epoch int64) error {
+	return db.SetVersions(Versions{failoverEpochVersion: epoch}, false)
+}
+
+// failoverBackend is one of the DataDBs handled by the FailoverDataDB
+type failoverBackend struct {
+	cfg       *config.DataDbStandbyCfg
+	db        DataDB // nil while not connected
+	available bool
+	synced    bool
+	lastErr   string
+	failures  int                  // consecutive failed checks
+	resyncing bool                 // the data is copied into it, the writes being queued meanwhile
+	pending   []func(DataDB) error // the writes done on the active backend while resyncing
+}
+
+func (fb *failoverBackend) address() string {
+	return net.JoinHostPort(fb.cfg.Host, fb.cfg.Port)
+}
+
+// NewFailoverDataDB connects to the primary DataDB and to the standby ones
+// the primary needs to be available so the data of the standby ones can be verified, the first one
+// with the highest failover epoch becoming the active one (a standby promoted before the restart)
+func NewFailoverDataDB(dbCfg *config.DataDbCfg, marshaler string,
+	itmsCfg map[string]*config.ItemOpt) (fdb *FailoverDataDB, err error) {
+	fdb = &FailoverDataDB{
+		backends: make([]*failoverBackend, 0, len(dbCfg.StandbyDBs)+1),
+		active:   -1,
+		interval: dbCfg.FailoverInterval,
+		failures: dbCfg.FailoverFailures,
+		checkNow: make(chan struct{}, 1),
+		ms:       marshaler,
+		itmsCfg:  itmsCfg,
+	}
+	fdb.backends = append(fdb.backends, &failoverBackend{cfg: &config.DataDbStandbyCfg{
+		Type:     dbCfg.Type,
+		Host:     dbCfg.Host,
+		Port:     dbCfg.Port,
+		Name:     dbCfg.Name,
+		User:     dbCfg.User,
+		Password: dbCfg.Password,
+		Opts:     dbCfg.Opts,
+	}})
+	for _, sbCfg := range dbCfg.StandbyDBs {
+		fdb.backends = append(fdb.backends, &failoverBackend{cfg: sbCfg})
+	}
+	for _, fb := range fdb.backends {
+		fb.db, err = fdb.connect(fb.cfg)
+		if err != nil {
+			fb.lastErr = err.Error()
+			utils.Logger.Warning(fmt.Sprintf("<%s> could not connect to DataDB at <%s>, error: %s",
+				utils.DataDB, fb.address(), fb.lastErr))
+		}
+	}
+	if err = fdb.selectActive(); err != nil {
+		fdb.Close()
+		return nil, err
+	}
+	return
+}
+
+// selectActive chooses on start the active backend out of the connected ones based on their failover epoch
+func (fdb *FailoverDataDB) selectActive() (err error) {
+	fdb.active = -1
+	epochs := make([]int64, len(fdb.backends))
+	for i, fb := range fdb.backends {
+		fb.available, fb.synced = false, false
+		if fb.db == nil {
+			if i == 0 { // without the primary the standby ones can not be verified
+				return errors.New(fb.lastErr)
+			}
+			continue
+		}
+		if epochs[i], err = getFailoverEpoch(fb.db); err != nil {
+			if i == 0 {
+				return
+			}
+			fb.lastErr = err.Error()
+			utils.Logger.Warning(fmt.Sprintf("<%s> could not read the failover epoch of DataDB at <%s>, error: %s",
+				utils.DataDB, fb.address(), fb.lastErr))
+			err = nil
+			continue
+		}
+		fb.available = true
+		if fdb.active == -1 || epochs[i] > epochs[fdb.active] {
+			fdb.active = i
+		}
+	}
+	fdb.epoch = epochs[fdb.active]
+	fdb.backends[fdb.active].synced = true
+	if fdb.active != 0 {
+		utils.Logger.Warning(fmt.Sprintf("<%s> DataDB at <%s> holds newer data than the primary, using it as active",
+			utils.DataDB, fdb.backends[fdb.active].address()))
+	}
+	return
+}
+
+// FailoverDataDB is a DataDB sending the reads and the writes to the active backend
+// and mirroring the writes to the synced standby ones, the first available synced backend
+// in priority order being promoted as active when the active one fails
+type FailoverDataDB struct {
+	sync.RWMutex              // protects the active index and the backends status
+	wrMux        sync.RWMutex // the writes are paused while replaying the queued ones on the resynced backends
+	backends     []*failoverBackend
+	active       int
+	epoch        int64 // the failover epoch of the active backend
+	interval     time.Duration
+	failures     int           // consecutive failed checks before a backend is considered unavailable
+	checkNow     chan struct{} // triggers a check out of the interval, e.g. on a failed write
+	ms           string
+	itmsCfg      map[string]*config.ItemOpt
+	stopLoop     chan struct{}
+	loopStopped  chan struct{}
+}
+
+func (fdb *FailoverDataDB) connect(cfg *config.DataDbStandbyCfg) (DataDB, error) {
+	return NewDataDBConn(cfg.Type, cfg.Host, cfg.Port, cfg.Name,
+		cfg.User, cfg.Password, fdb.ms, cfg.Opts, fdb.itmsCfg)
+}
+
+// StartLoop starts the goroutine checking periodically the backends
+func (fdb *FailoverDataDB) StartLoop() {
+	fdb.stopLoop = make(chan struct{})
+	fdb.loopStopped = make(chan struct{})
+	go fdb.runChecks()
+}
+
+func (fdb *FailoverDataDB) runChecks() {
+	defer close(fdb.loopStopped)
+	if fdb.interval <= 0 {
+		return
+	}
+	for {
+		select {
+		case <-fdb.stopLoop:
+			return
+		case <-time.After(fdb.interval):
+		case <-fdb.checkNow:
+		}
+		fdb.checkBackends()
+	}
+}
+
+// checkBackends reconnects and checks the health of the backends, resyncs the
+// available unsynced ones out of the active backend and promotes the first available synced one
+func (fdb *FailoverDataDB) checkBackends() {
+	fdb.RLock()
+	dbs := make([]DataDB, len(fdb.backends))
+	for i, fb := range fdb.backends {
+		dbs[i] = fb.db
+	}
+	fdb.RUnlock()
+	errs := make([]error, len(dbs))
+	newConns := make([]bool, len(dbs))
+	for i, db := range dbs {
+		if db == nil {
+			if dbs[i], errs[i] = fdb.connect(fdb.backends[i].cfg); errs[i] != nil {
+				continue
+			}
+			newConns[i] = true
+		}
+		if _, errs[i] = dbs[i].GetVersions(utils.EmptyString); errs[i] == utils.ErrNotFound {
+			errs[i] = nil
+		}
+	}
+	fdb.Lock()
+	for i, fb := range fdb.backends {
+		if newConns[i] {
+			fb.db = dbs[i]
+		}
+		if errs[i] != nil {
+			fb.lastErr = errs[i].Error()
+			if fb.failures++; fb.available && fb.failures < fdb.failures {
+				utils.Logger.Warning(fmt.Sprintf("<%s> DataDB at <%s> failed %d of %d checks, error: %s",
+					utils.DataDB, fb.address(), fb.failures, fdb.failures, errs[i]))
+				continue
+			}
+			if fb.available {
+				utils.Logger.Warning(fmt.Sprintf("<%s> DataDB at <%s> not available, error: %s",
+					utils.DataDB, fb.address(), errs[i]))
+			}
+			fb.available = false
+			if i != fdb.active { // the active one stays the reference until another is promoted
+				fb.synced = false // needs resync after recovery
+			}
+			continue
+		}
+		fb.failures = 0
+		if !fb.available && fb.lastErr != utils.EmptyString {
+			utils.Logger.Info(fmt.Sprintf("<%s> DataDB at <%s> available again",
+				utils.DataDB, fb.address()))
+		}
+		fb.available = true
+		fb.lastErr = utils.EmptyString
+	}
+	fdb.promote()
+	fdb.Unlock()
+	fdb.resync()
+	fdb.Lock()
+	fdb.promote()
+	fdb.Unlock()
+}
+
+// promote makes active the first available and synced backend in priority order
+// needs to be called under lock
+func (fdb *FailoverDataDB) promote() {
+	for i, fb := range fdb.backends {
+		if !fb.available || !fb.synced {
+			continue
+		}
+		if i != fdb.active {
+			prevActive := fdb.backends[fdb.active]
+			if !prevActive.available {
+				prevActive.synced = false
+			}
+			// the backends left behind keep the previous epoch so they are not considered up to date after a restart
+			epoch := fdb.epoch + 1
+			if err := setFailoverEpoch(fb.db, epoch); err != nil {
+				utils.Logger.Warning(fmt.Sprintf("<%s> could not promote DataDB at <%s>, error: %s",
+					utils.DataDB, fb.address(), err))
+				fb.synced = false
+				continue
+			}
+			for j, sb := range fdb.backends {
+				if j == i || !sb.available || !sb.synced {
+					continue
+				}
+				if err := setFailoverEpoch(sb.db, epoch); err != nil {
+					sb.synced = false
+				}
+			}
+			utils.Logger.Warning(fmt.Sprintf("<%s> promoting DataDB at <%s> as active instead of <%s>",
+				utils.DataDB, fb.address(), prevActive.address()))
+			fdb.active = i
+			fdb.epoch = epoch
+		}
+		return
+	}
+	if !fdb.backends[fdb.active].available {
+		utils.Logger.Err(fmt.Sprintf("<%s> no DataDB available to promote as active", utils.DataDB))
+	}
+}
+
+// resync copies the data of the active backend into the available unsynced ones
+// the writes done during the copy are queued and replayed afterwards, pausing the writes only for the replay
+func (fdb *FailoverDataDB) resync() {
+	fdb.Lock()
+	active := fdb.backends[fdb.active]
+	epoch := fdb.epoch
+	var toSync []*failoverBackend
+	if active.available {
+		for i, fb := range fdb.backends {
+			if i != fdb.active && fb.available && !fb.synced {
+				fb.resyncing = true
+				fb.pending = nil
+				toSync = append(toSync, fb)
+			}
+		}
+	}
+	fdb.Unlock()
+	for _, fb := range toSync {
+		utils.Logger.Info(fmt.Sprintf("<%s> resyncing DataDB at <%s> from <%s>",
+			utils.DataDB, fb.address(), active.address()))
+		fbEpoch, err := getFailoverEpoch(fb.db)
+		if err == nil && fbEpoch > epoch { // never flush a backend which may hold newer data
+			err = fmt.Errorf("failover epoch %d newer than the active one %d, needs manual resync", fbEpoch, epoch)
+		}
+		if err == nil {
+			err = syncDataDB(active.db, fb.db)
+		}
+		if err == nil {
+			err = fdb.replayWrites(fb)
+		}
+		fdb.Lock()
+		fb.resyncing = false
+		fb.pending = nil
+		if err != nil {
+			fb.lastErr = err.Error()
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed resyncing DataDB at <%s>, error: %s",
+				utils.DataDB, fb.address(), fb.lastErr))
+		} else {
+			fb.synced = true
+		}
+		fdb.Unlock()
+	}
+}
+
+// replayWrites applies on the resynced backend the writes done during the copy
+// the writes are idempotent so the ones already copied are applied again without harm
+func (fdb *FailoverDataDB) replayWrites(fb *failoverBackend) (err error) {
+	fdb.wrMux.Lock()
+	defer fdb.wrMux.Unlock()
+	fdb.Lock()
+	pending, resyncing := fb.pending, fb.resyncing
+	fdb.Unlock()
+	if !resyncing {
+		return fmt.Errorf("more than %d writes done during the copy", maxResyncWrites)
+	}
+	for _, f := range pending {
+		if err = f(fb.db); err != nil && err != utils.ErrNotFound {
+			return
+		}
+	}
+	return nil
+}
+
+// queueResyncWrite queues the write done on the active backend for the backends being resynced
+func (fdb *FailoverDataDB) queueResyncWrite(f func(DataDB) error) {
+	fdb.Lock()
+	for _, fb := range fdb.backends {
+		if !fb.resyncing {
+			continue
+		}
+		if len(fb.pending) == maxResyncWrites {
+			fb.resyncing = false // too many writes, the resync is retried on the next check
+			fb.pending = nil
+			continue
+		}
+		fb.pending = append(fb.pending, f)
+	}
+	fdb.Unlock()
+}
+
+// triggerCheck makes the loop check the backends without waiting for the interval
+func (fdb *FailoverDataDB) triggerCheck() {
+	select {
+	case fdb.checkNow <- struct{}{}:
+	default: // a check is already pending
+	}
+}
+
+// activeDB returns the DataDB receiving the reads
+func (fdb *FailoverDataDB) activeDB() (db DataDB) {
+	fdb.RLock()
+	db = fdb.backends[fdb.active].db
+	fdb.RUnlock()
+	return
+}
+
+// write executes f on the active backend and mirrors it to the synced standby ones
+// a standby failing the write will be resynced by the next check
+func (fdb *FailoverDataDB) write(f func(DataDB) error) (err error) {
+	fdb.wrMux.RLock()
+	defer fdb.wrMux.RUnlock()
+	fdb.RLock()
+	active := fdb.backends[fdb.active]
+	var mirrors []*failoverBackend
+	for i, fb := range fdb.backends {
+		if i != fdb.active && fb.available && fb.synced {
+			mirrors = append(mirrors, fb)
+		}
+	}
+	fdb.RUnlock()
+	if err = f(active.db); err != nil {
+		if err != utils.ErrNotFound {
+			fdb.triggerCheck()
+		}
+		return
+	}
+	fdb.queueResyncWrite(f)
+	for _, fb := range mirrors {
+		if errMirror := f(fb.db); errMirror != nil {
+			fdb.Lock()
+			fb.synced = false
+			fb.lastErr = errMirror.Error()
+			fdb.Unlock()
+			utils.Logger.Warning(fmt.Sprintf("<%s> failed mirroring write to DataDB at <%s>, error: %s",
+				utils.DataDB, fb.address(), errMirror))
+		}
+	}
+	return
+}
+
+// Status returns the status of the backends in priority order
+func (fdb *FailoverDataDB) Status() (sts []*DataDBBackendStatus) {
+	fdb.RLock()
+	sts = make([]*DataDBBackendStatus, len(fdb.backends))
+	for i, fb := range fdb.backends {
+		sts[i] = &DataDBBackendStatus{
+			Type:      utils.Meta + fb.cfg.Type,
+			Address:   fb.address(),
+			Name:      fb.cfg.Name,
+			Active:    i == fdb.active,
+			Available: fb.available,
+			Synced:    fb.synced,
+			LastError: fb.lastErr,
+		}
+	}
+	fdb.RUnlock()
+	return
+}
+
+// Close stops the checks and closes the connections to all the backends
+func (fdb *FailoverDataDB) Close() {
+	if fdb.stopLoop != nil {
+		close(fdb.stopLoop)
+		<-fdb.loopStopped
+		fdb.stopLoop = nil
+	}
+	fdb.Lock()
+	for _, fb := range fdb.backends {
+		if fb.db != nil {
+			fb.db.Close()
+		}
+	}
+	fdb.Unlock()
+}
+
+// PopTask pops the task from the active backend, dropping it from the synced standby ones also
+func (fdb *FailoverDataDB) PopTask() (t *Task, err error) {
+	err = fdb.write(func(db DataDB) (errPop error) {
+		if t == nil {
+			t, errPop = db.PopTask()
+			return
+		}
+		db.PopTask()
+		return
+	})
+	return
+}
+
+func (fdb *FailoverDataDB) Flush(ignore string) error {
+	return fdb.write(func(db DataDB) error { return db.Flush(ignore) })
+}
+
+func (fdb *FailoverDataDB) GetKeysForPrefix(prefix string) (keys []string, err error) {
+	return fdb.activeDB().GetKeysForPrefix(prefix)
+}
+
+func (fdb *FailoverDataDB) RemoveKeysForPrefix(prefix string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveKeysForPrefix(prefix) })
+}
+
+func (fdb *FailoverDataDB) GetVersions(itm string) (vrs Versions, err error) {
+	return fdb.activeDB().GetVersions(itm)
+}
+
+func (fdb *FailoverDataDB) SetVersions(vrs Versions, overwrite bool) (err error) {
+	if overwrite { // keep the failover epoch
+		fdb.RLock()
+		epoch := fdb.epoch
+		fdb.RUnlock()
+		if epoch != 0 {
+			epochVrs := make(Versions, len(vrs)+1)
+			for k, v := range vrs {
+				epochVrs[k] = v
+			}
+			epochVrs[failoverEpochVersion] = epoch
+			vrs = epochVrs
+		}
+	}
+	return fdb.write(func(db DataDB) error { return db.SetVersions(vrs, overwrite) })
+}
+
+func (fdb *FailoverDataDB) RemoveVersions(vrs Versions) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveVersions(vrs) })
+}
+
+func (fdb *FailoverDataDB) SelectDatabase(dbName string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SelectDatabase(dbName) })
+}
+
+func (fdb *FailoverDataDB) GetStorageType() string {
+	return fdb.activeDB().GetStorageType()
+}
+
+func (fdb *FailoverDataDB) IsDBEmpty() (resp bool, err error) {
+	return fdb.activeDB().IsDBEmpty()
+}
+
+func (fdb *FailoverDataDB) HasDataDrv(category, subject, tenant string) (exists bool, err error) {
+	return fdb.activeDB().HasDataDrv(category, subject, tenant)
+}
+
+func (fdb *FailoverDataDB) GetRatingPlanDrv(key string) (rp *RatingPlan, err error) {
+	return fdb.activeDB().GetRatingPlanDrv(key)
+}
+
+func (fdb *FailoverDataDB) SetRatingPlanDrv(rp *RatingPlan) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetRatingPlanDrv(rp) })
+}
+
+func (fdb *FailoverDataDB) RemoveRatingPlanDrv(key string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveRatingPlanDrv(key) })
+}
+
+func (fdb *FailoverDataDB) GetRatingProfileDrv(key string) (rpf *RatingProfile, err error) {
+	return fdb.activeDB().GetRatingProfileDrv(key)
+}
+
+func (fdb *FailoverDataDB) SetRatingProfileDrv(rpf *RatingProfile) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetRatingProfileDrv(rpf) })
+}
+
+func (fdb *FailoverDataDB) RemoveRatingProfileDrv(key string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveRatingProfileDrv(key) })
+}
+
+func (fdb *FailoverDataDB) GetDestinationDrv(key, transactionID string) (dest *Destination, err error) {
+	return fdb.activeDB().GetDestinationDrv(key, transactionID)
+}
+
+func (fdb *FailoverDataDB) SetDestinationDrv(dest *Destination, transactionID string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetDestinationDrv(dest, transactionID) })
+}
+
+func (fdb *FailoverDataDB) RemoveDestinationDrv(destID, transactionID string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveDestinationDrv(destID, transactionID) })
+}
+
+func (fdb *FailoverDataDB) RemoveReverseDestinationDrv(dstID, prfx, transactionID string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveReverseDestinationDrv(dstID, prfx, transactionID) })
+}
+
+func (fdb *FailoverDataDB) SetReverseDestinationDrv(destID string, prefixes []string, transactionID string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetReverseDestinationDrv(destID, prefixes, transactionID) })
+}
+
+func (fdb *FailoverDataDB) GetReverseDestinationDrv(key, transactionID string) (ids []string, err error) {
+	return fdb.activeDB().GetReverseDestinationDrv(key, transactionID)
+}
+
+func (fdb *FailoverDataDB) GetActionsDrv(key string) (as Actions, err error) {
+	return fdb.activeDB().GetActionsDrv(key)
+}
+
+func (fdb *FailoverDataDB) SetActionsDrv(key string, as Actions) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetActionsDrv(key, as) })
+}
+
+func (fdb *FailoverDataDB) RemoveActionsDrv(key string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveActionsDrv(key) })
+}
+
+func (fdb *FailoverDataDB) GetSharedGroupDrv(key string) (sg *SharedGroup, err error) {
+	return fdb.activeDB().GetSharedGroupDrv(key)
+}
+
+func (fdb *FailoverDataDB) SetSharedGroupDrv(sg *SharedGroup) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetSharedGroupDrv(sg) })
+}
+
+func (fdb *FailoverDataDB) RemoveSharedGroupDrv(id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveSharedGroupDrv(id) })
+}
+
+func (fdb *FailoverDataDB) GetActionTriggersDrv(key string) (atrs ActionTriggers, err error) {
+	return fdb.activeDB().GetActionTriggersDrv(key)
+}
+
+func (fdb *FailoverDataDB) SetActionTriggersDrv(key string, atrs ActionTriggers) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetActionTriggersDrv(key, atrs) })
+}
+
+func (fdb *FailoverDataDB) RemoveActionTriggersDrv(key string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveActionTriggersDrv(key) })
+}
+
+func (fdb *FailoverDataDB) GetActionPlanDrv(key string) (ats *ActionPlan, err error) {
+	return fdb.activeDB().GetActionPlanDrv(key)
+}
+
+func (fdb *FailoverDataDB) SetActionPlanDrv(key string, ats *ActionPlan) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetActionPlanDrv(key, ats) })
+}
+
+func (fdb *FailoverDataDB) RemoveActionPlanDrv(key string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveActionPlanDrv(key) })
+}
+
+func (fdb *FailoverDataDB) GetAllActionPlansDrv() (ats map[string]*ActionPlan, err error) {
+	return fdb.activeDB().GetAllActionPlansDrv()
+}
+
+func (fdb *FailoverDataDB) GetAccountActionPlansDrv(acntID string) (aPlIDs []string, err error) {
+	return fdb.activeDB().GetAccountActionPlansDrv(acntID)
+}
+
+func (fdb *FailoverDataDB) SetAccountActionPlansDrv(acntID string, aPlIDs []string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetAccountActionPlansDrv(acntID, aPlIDs) })
+}
+
+func (fdb *FailoverDataDB) RemAccountActionPlansDrv(acntID string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemAccountActionPlansDrv(acntID) })
+}
+
+func (fdb *FailoverDataDB) PushTask(t *Task) (err error) {
+	return fdb.write(func(db DataDB) error { return db.PushTask(t) })
+}
+
+func (fdb *FailoverDataDB) GetAccountDrv(key string) (ub *Account, err error) {
+	return fdb.activeDB().GetAccountDrv(key)
+}
+
+func (fdb *FailoverDataDB) SetAccountDrv(acc *Account) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetAccountDrv(acc) })
+}
+
+func (fdb *FailoverDataDB) RemoveAccountDrv(key string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveAccountDrv(key) })
+}
+
+func (fdb *FailoverDataDB) GetResourceProfileDrv(tenant, id string) (rsp *ResourceProfile, err error) {
+	return fdb.activeDB().GetResourceProfileDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetResourceProfileDrv(rsp *ResourceProfile) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetResourceProfileDrv(rsp) })
+}
+
+func (fdb *FailoverDataDB) RemoveResourceProfileDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveResourceProfileDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetResourceDrv(tenant, id string) (r *Resource, err error) {
+	return fdb.activeDB().GetResourceDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetResourceDrv(r *Resource) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetResourceDrv(r) })
+}
+
+func (fdb *FailoverDataDB) RemoveResourceDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveResourceDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetTimingDrv(id string) (t *utils.TPTiming, err error) {
+	return fdb.activeDB().GetTimingDrv(id)
+}
+
+func (fdb *FailoverDataDB) SetTimingDrv(t *utils.TPTiming) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetTimingDrv(t) })
+}
+
+func (fdb *FailoverDataDB) RemoveTimingDrv(id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveTimingDrv(id) })
+}
+
+func (fdb *FailoverDataDB) GetLoadHistory(limit int, skipCache bool, transactionID string) (loadInsts []*utils.LoadInstance, err error) {
+	return fdb.activeDB().GetLoadHistory(limit, skipCache, transactionID)
+}
+
+func (fdb *FailoverDataDB) AddLoadHistory(ldInst *utils.LoadInstance, loadHistSize int, transactionID string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.AddLoadHistory(ldInst, loadHistSize, transactionID) })
+}
+
+func (fdb *FailoverDataDB) GetIndexesDrv(idxItmType, tntCtx, idxKey string) (indexes map[string]utils.StringSet, err error) {
+	return fdb.activeDB().GetIndexesDrv(idxItmType, tntCtx, idxKey)
+}
+
+func (fdb *FailoverDataDB) SetIndexesDrv(idxItmType, tntCtx string, indexes map[string]utils.StringSet, commit bool, transactionID string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetIndexesDrv(idxItmType, tntCtx, indexes, commit, transactionID) })
+}
+
+func (fdb *FailoverDataDB) RemoveIndexesDrv(idxItmType, tntCtx, idxKey string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveIndexesDrv(idxItmType, tntCtx, idxKey) })
+}
+
+func (fdb *FailoverDataDB) GetStatQueueProfileDrv(tenant string, id string) (sq *StatQueueProfile, err error) {
+	return fdb.activeDB().GetStatQueueProfileDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetStatQueueProfileDrv(sq *StatQueueProfile) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetStatQueueProfileDrv(sq) })
+}
+
+func (fdb *FailoverDataDB) RemStatQueueProfileDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemStatQueueProfileDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetStatQueueDrv(tenant, id string) (sq *StatQueue, err error) {
+	return fdb.activeDB().GetStatQueueDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetStatQueueDrv(ssq *StoredStatQueue, sq *StatQueue) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetStatQueueDrv(ssq, sq) })
+}
+
+func (fdb *FailoverDataDB) RemStatQueueDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemStatQueueDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetThresholdProfileDrv(tenant, ID string) (tp *ThresholdProfile, err error) {
+	return fdb.activeDB().GetThresholdProfileDrv(tenant, ID)
+}
+
+func (fdb *FailoverDataDB) SetThresholdProfileDrv(tp *ThresholdProfile) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetThresholdProfileDrv(tp) })
+}
+
+func (fdb *FailoverDataDB) RemThresholdProfileDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemThresholdProfileDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetThresholdDrv(tenant, id string) (r *Threshold, err error) {
+	return fdb.activeDB().GetThresholdDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetThresholdDrv(r *Threshold) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetThresholdDrv(r) })
+}
+
+func (fdb *FailoverDataDB) RemoveThresholdDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveThresholdDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetFilterDrv(tenant, id string) (r *Filter, err error) {
+	return fdb.activeDB().GetFilterDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetFilterDrv(r *Filter) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetFilterDrv(r) })
+}
+
+func (fdb *FailoverDataDB) RemoveFilterDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveFilterDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetRouteProfileDrv(tenant, id string) (r *RouteProfile, err error) {
+	return fdb.activeDB().GetRouteProfileDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetRouteProfileDrv(r *RouteProfile) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetRouteProfileDrv(r) })
+}
+
+func (fdb *FailoverDataDB) RemoveRouteProfileDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveRouteProfileDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetAttributeProfileDrv(tenant, id string) (r *AttributeProfile, err error) {
+	return fdb.activeDB().GetAttributeProfileDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetAttributeProfileDrv(r *AttributeProfile) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetAttributeProfileDrv(r) })
+}
+
+func (fdb *FailoverDataDB) RemoveAttributeProfileDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveAttributeProfileDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetChargerProfileDrv(tenant, id string) (r *ChargerProfile, err error) {
+	return fdb.activeDB().GetChargerProfileDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetChargerProfileDrv(r *ChargerProfile) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetChargerProfileDrv(r) })
+}
+
+func (fdb *FailoverDataDB) RemoveChargerProfileDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveChargerProfileDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetDispatcherProfileDrv(tenant, id string) (r *DispatcherProfile, err error) {
+	return fdb.activeDB().GetDispatcherProfileDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetDispatcherProfileDrv(r *DispatcherProfile) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetDispatcherProfileDrv(r) })
+}
+
+func (fdb *FailoverDataDB) RemoveDispatcherProfileDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveDispatcherProfileDrv(tenant, id) })
+}
+
+func (fdb *FailoverDataDB) GetItemLoadIDsDrv(itemIDPrefix string) (loadIDs map[string]int64, err error) {
+	return fdb.activeDB().GetItemLoadIDsDrv(itemIDPrefix)
+}
+
+func (fdb *FailoverDataDB) SetLoadIDsDrv(loadIDs map[string]int64) error {
+	return fdb.write(func(db DataDB) error { return db.SetLoadIDsDrv(loadIDs) })
+}
+
+func (fdb *FailoverDataDB) RemoveLoadIDsDrv() (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveLoadIDsDrv() })
+}
+
+func (fdb *FailoverDataDB) GetDispatcherHostDrv(tenant, id string) (r *DispatcherHost, err error) {
+	return fdb.activeDB().GetDispatcherHostDrv(tenant, id)
+}
+
+func (fdb *FailoverDataDB) SetDispatcherHostDrv(r *DispatcherHost) (err error) {
+	return fdb.write(func(db DataDB) error { return db.SetDispatcherHostDrv(r) })
+}
+
+func (fdb *FailoverDataDB) RemoveDispatcherHostDrv(tenant, id string) (err error) {
+	return fdb.write(func(db DataDB) error { return db.RemoveDispatcherHostDrv(tenant, id) })
+}
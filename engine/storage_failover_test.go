@@ -0,0 +1,357 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package engine
+
+import (
+	"errors"
+	"reflect"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// testDownDataDB is a DataDB which can be taken down
+type testDownDataDB struct {
+	DataDB
+	sync.RWMutex
+	down bool
+}
+
+var errTestDataDBDown = errors.New("connection refused")
+
+func (db *testDownDataDB) setDown(down bool) {
+	db.Lock()
+	db.down = down
+	db.Unlock()
+}
+
+func (db *testDownDataDB) isDown() bool {
+	db.RLock()
+	defer db.RUnlock()
+	return db.down
+}
+
+func (db *testDownDataDB) GetVersions(itm string) (Versions, error) {
+	if db.isDown() {
+		return nil, errTestDataDBDown
+	}
+	return db.DataDB.GetVersions(itm)
+}
+
+func (db *testDownDataDB) SetAccountDrv(acc *Account) error {
+	if db.isDown() {
+		return errTestDataDBDown
+	}
+	return db.DataDB.SetAccountDrv(acc)
+}
+
+func TestFailoverDataDB(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	dbCfg := cfg.DataDbCfg().Clone()
+	dbCfg.Type = utils.Internal
+	dbCfg.Host = "127.0.0.1"
+	dbCfg.Port = "6379"
+	dbCfg.Name = "10"
+	dbCfg.FailoverFailures = 2
+	dbCfg.StandbyDBs = []*config.DataDbStandbyCfg{{
+		Type: utils.Internal,
+		Host: "127.0.0.2",
+		Port: "6379",
+	}}
+	fdb, err := NewFailoverDataDB(dbCfg, utils.MetaMSGPACK, dbCfg.Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fdb.Close()
+	primary := &testDownDataDB{DataDB: fdb.backends[0].db}
+	standby := &testDownDataDB{DataDB: fdb.backends[1].db}
+	fdb.backends[0].db, fdb.backends[1].db = primary, standby
+	exp := []*DataDBBackendStatus{
+		{Type: utils.MetaInternal, Address: "127.0.0.1:6379", Name: "10", Active: true, Available: true, Synced: true},
+		{Type: utils.MetaInternal, Address: "127.0.0.2:6379", Available: true},
+	}
+	if rcv := fdb.Status(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+
+	// the standby is synced on the first check
+	if err := fdb.SetAccountDrv(&Account{ID: "cgrates.org:1001"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := standby.GetAccountDrv("cgrates.org:1001"); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrNotFound, err)
+	}
+	fdb.checkBackends()
+	if _, err := standby.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Error(err)
+	}
+	// the writes are mirrored once synced
+	if err := fdb.SetAccountDrv(&Account{ID: "cgrates.org:1002"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := standby.GetAccountDrv("cgrates.org:1002"); err != nil {
+		t.Error(err)
+	}
+
+	// the standby is promoted when the primary fails the configured number of checks
+	primary.setDown(true)
+	if err := fdb.SetAccountDrv(&Account{ID: "cgrates.org:1003"}); err != errTestDataDBDown {
+		t.Fatalf("Expected: %v ,received: %v", errTestDataDBDown, err)
+	}
+	select { // the failed write triggers a check
+	case <-fdb.checkNow:
+	default:
+		t.Error("Expected a check to be triggered by the failed write")
+	}
+	fdb.checkBackends()
+	exp = []*DataDBBackendStatus{
+		{Type: utils.MetaInternal, Address: "127.0.0.1:6379", Name: "10", Active: true, Available: true, Synced: true, LastError: errTestDataDBDown.Error()},
+		{Type: utils.MetaInternal, Address: "127.0.0.2:6379", Available: true, Synced: true},
+	}
+	if rcv := fdb.Status(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	fdb.checkBackends()
+	exp = []*DataDBBackendStatus{
+		{Type: utils.MetaInternal, Address: "127.0.0.1:6379", Name: "10", LastError: errTestDataDBDown.Error()},
+		{Type: utils.MetaInternal, Address: "127.0.0.2:6379", Active: true, Available: true, Synced: true},
+	}
+	if rcv := fdb.Status(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	if err := fdb.SetAccountDrv(&Account{ID: "cgrates.org:1003"}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := fdb.GetAccountDrv("cgrates.org:1003"); err != nil {
+		t.Error(err)
+	}
+	if _, err := primary.GetAccountDrv("cgrates.org:1003"); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v ,received: %v", utils.ErrNotFound, err)
+	}
+
+	// the primary is resynced on recovery and becomes active again
+	primary.setDown(false)
+	fdb.checkBackends()
+	exp = []*DataDBBackendStatus{
+		{Type: utils.MetaInternal, Address: "127.0.0.1:6379", Name: "10", Active: true, Available: true, Synced: true},
+		{Type: utils.MetaInternal, Address: "127.0.0.2:6379", Available: true, Synced: true},
+	}
+	if rcv := fdb.Status(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+	for _, acntID := range []string{"cgrates.org:1001", "cgrates.org:1002", "cgrates.org:1003"} {
+		if _, err := primary.GetAccountDrv(acntID); err != nil {
+			t.Errorf("<%s> for account: %s", err, acntID)
+		}
+	}
+
+	// a standby failing a mirrored write is marked for resync
+	standby.setDown(true)
+	if err := fdb.SetAccountDrv(&Account{ID: "cgrates.org:1004"}); err != nil {
+		t.Fatal(err)
+	}
+	if sts := fdb.Status(); sts[1].Synced || sts[1].LastError != errTestDataDBDown.Error() {
+		t.Errorf("Unexpected status: %s", utils.ToJSON(sts))
+	}
+}
+
+func TestFailoverDataDBNoBackendAvailable(t *testing.T) {
+	dbCfg := config.NewDefaultCGRConfig().DataDbCfg().Clone()
+	dbCfg.Type = "unknown"
+	dbCfg.StandbyDBs = []*config.DataDbStandbyCfg{{Type: "unknown"}}
+	if _, err := NewFailoverDataDB(dbCfg, utils.MetaMSGPACK, dbCfg.Items); err == nil ||
+		err.Error() != "unsupported db_type <unknown>" {
+		t.Errorf("Expected error: %q ,received: %v", "unsupported db_type <unknown>", err)
+	}
+	// the standby can not be verified without the primary
+	dbCfg.StandbyDBs = []*config.DataDbStandbyCfg{{Type: utils.Internal}}
+	if _, err := NewFailoverDataDB(dbCfg, utils.MetaMSGPACK, dbCfg.Items); err == nil ||
+		err.Error() != "unsupported db_type <unknown>" {
+		t.Errorf("Expected error: %q ,received: %v", "unsupported db_type <unknown>", err)
+	}
+}
+
+func TestFailoverDataDBEpoch(t *testing.T) {
+	dbCfg := config.NewDefaultCGRConfig().DataDbCfg().Clone()
+	dbCfg.Type = utils.Internal
+	dbCfg.StandbyDBs = []*config.DataDbStandbyCfg{{Type: utils.Internal, Host: "127.0.0.2"}}
+	fdb, err := NewFailoverDataDB(dbCfg, utils.MetaMSGPACK, dbCfg.Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fdb.Close()
+	primary, standby := fdb.backends[0].db, fdb.backends[1].db
+
+	// a standby promoted before the restart is used as active
+	if err := setFailoverEpoch(standby, 2); err != nil {
+		t.Fatal(err)
+	}
+	if err := standby.SetAccountDrv(&Account{ID: "cgrates.org:1001"}); err != nil {
+		t.Fatal(err)
+	}
+	if err := fdb.selectActive(); err != nil {
+		t.Fatal(err)
+	}
+	exp := []*DataDBBackendStatus{
+		{Type: utils.MetaInternal, Address: "127.0.0.1:6379", Name: "10", Available: true},
+		{Type: utils.MetaInternal, Address: "127.0.0.2:", Active: true, Available: true, Synced: true},
+	}
+	if rcv := fdb.Status(); !reflect.DeepEqual(exp, rcv) {
+		t.Errorf("Expected: %s ,received: %s", utils.ToJSON(exp), utils.ToJSON(rcv))
+	}
+
+	// the primary is resynced from it and promoted back with a new epoch
+	fdb.checkBackends()
+	if fdb.active != 0 {
+		t.Errorf("Expected the primary to be active, received: %s", utils.ToJSON(fdb.Status()))
+	}
+	if _, err := primary.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Error(err)
+	}
+	for i, db := range []DataDB{primary, standby} {
+		if epoch, err := getFailoverEpoch(db); err != nil {
+			t.Error(err)
+		} else if epoch != 3 {
+			t.Errorf("Expected epoch 3 for backend %d, received: %d", i, epoch)
+		}
+	}
+	// the epoch is kept when overwriting the versions
+	if err := fdb.SetVersions(CurrentDataDBVersions(), true); err != nil {
+		t.Fatal(err)
+	}
+	if epoch, err := getFailoverEpoch(primary); err != nil {
+		t.Error(err)
+	} else if epoch != 3 {
+		t.Errorf("Expected epoch 3, received: %d", epoch)
+	}
+
+	// a backend holding newer data is never flushed
+	if err := setFailoverEpoch(standby, 5); err != nil {
+		t.Fatal(err)
+	}
+	fdb.backends[1].synced = false
+	fdb.checkBackends()
+	if sts := fdb.Status(); sts[1].Synced ||
+		sts[1].LastError != "failover epoch 5 newer than the active one 3, needs manual resync" {
+		t.Errorf("Unexpected status: %s", utils.ToJSON(sts))
+	}
+	if _, err := standby.GetAccountDrv("cgrates.org:1001"); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestFailoverDataDBLoop(t *testing.T) {
+	dbCfg := config.NewDefaultCGRConfig().DataDbCfg().Clone()
+	dbCfg.Type = utils.Internal
+	dbCfg.FailoverInterval = 5 * time.Millisecond
+	dbCfg.StandbyDBs = []*config.DataDbStandbyCfg{{Type: utils.Internal}}
+	d, err := NewDataDBFromCfg(dbCfg, utils.MetaMSGPACK, dbCfg.Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	fdb, canCast := d.(*FailoverDataDB)
+	if !canCast {
+		t.Fatalf("Expected FailoverDataDB, received: %T", d)
+	}
+	for i := 0; i < 100 && !fdb.Status()[1].Synced; i++ {
+		time.Sleep(5 * time.Millisecond)
+	}
+	if !fdb.Status()[1].Synced {
+		t.Errorf("Expected the standby to be synced by the loop, received: %s", utils.ToJSON(fdb.Status()))
+	}
+	fdb.Close()
+}
+
+// testBlockingFlushDataDB is a DataDB pausing on Flush until resumed
+type testBlockingFlushDataDB struct {
+	DataDB
+	flushing chan struct{}
+	resume   chan struct{}
+}
+
+func (db *testBlockingFlushDataDB) Flush(ignore string) error {
+	close(db.flushing)
+	<-db.resume
+	return db.DataDB.Flush(ignore)
+}
+
+func TestFailoverDataDBWritesDuringResync(t *testing.T) {
+	dbCfg := config.NewDefaultCGRConfig().DataDbCfg().Clone()
+	dbCfg.Type = utils.Internal
+	dbCfg.StandbyDBs = []*config.DataDbStandbyCfg{{Type: utils.Internal, Host: "127.0.0.2"}}
+	fdb, err := NewFailoverDataDB(dbCfg, utils.MetaMSGPACK, dbCfg.Items)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer fdb.Close()
+	standby := &testBlockingFlushDataDB{
+		DataDB:   fdb.backends[1].db,
+		flushing: make(chan struct{}),
+		resume:   make(chan struct{}),
+	}
+	fdb.backends[1].db = standby
+	if err := fdb.SetAccountDrv(&Account{ID: "cgrates.org:1001"}); err != nil {
+		t.Fatal(err)
+	}
+	checked := make(chan struct{})
+	go func() {
+		fdb.checkBackends()
+		close(checked)
+	}()
+	<-standby.flushing
+	// the writes are not paused while copying
+	written := make(chan error)
+	go func() {
+		written <- fdb.SetAccountDrv(&Account{ID: "cgrates.org:1002"})
+	}()
+	select {
+	case err := <-written:
+		if err != nil {
+			t.Fatal(err)
+		}
+	case <-time.After(time.Second):
+		t.Fatal("the write was paused during the resync")
+	}
+	close(standby.resume)
+	<-checked
+	if sts := fdb.Status(); !sts[1].Synced {
+		t.Errorf("Expected the standby to be synced, received: %s", utils.ToJSON(sts))
+	}
+	for _, acntID := range []string{"cgrates.org:1001", "cgrates.org:1002"} {
+		if _, err := standby.GetAccountDrv(acntID); err != nil {
+			t.Errorf("<%s> for account: %s", err, acntID)
+		}
+	}
+
+	// too many writes during the copy abort the resync
+	fdb.backends[1].resyncing = true
+	for i := 0; i <= maxResyncWrites; i++ {
+		fdb.queueResyncWrite(func(DataDB) error { return nil })
+	}
+	if fdb.backends[1].resyncing || fdb.backends[1].pending != nil {
+		t.Error("Expected the resync to be aborted")
+	}
+	expErr := "more than 10000 writes done during the copy"
+	if err := fdb.replayWrites(fdb.backends[1]); err == nil || err.Error() != expErr {
+		t.Errorf("Expected error: %q ,received: %v", expErr, err)
+	}
+}
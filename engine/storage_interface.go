@@ -144,6 +144,8 @@ type CdrStorage interface {
 	RemoveSMCost(*SMCost) error
 	RemoveSMCosts(qryFltr *utils.SMCostFilter) error
 	GetCDRs(*utils.CDRsFilter, bool) ([]*CDR, int64, error)
+	SetAuditRecord(*AuditRecord) error
+	GetAuditRecords(*utils.AuditRecordsFilter) ([]*AuditRecord, error)
 }
 
 type LoadStorage interface {
@@ -1468,3 +1468,67 @@ func (iDB *InternalDB) SetSMCost(smCost *SMCost) (err error) {
 		cacheCommit(utils.NonTransactional), utils.NonTransactional)
 	return err
 }
+
+// SetAuditRecord stores the audit record, indexed on the fields used for filtering
+func (iDB *InternalDB) SetAuditRecord(rec *AuditRecord) (err error) {
+	idxs := utils.NewStringSet([]string{
+		utils.ConcatenatedKey(utils.ItemType, rec.ItemType),
+		utils.ConcatenatedKey(utils.Tenant, rec.Tenant),
+		utils.ConcatenatedKey(utils.ItemID, rec.ItemID),
+		utils.ConcatenatedKey(utils.User, rec.User),
+		utils.ConcatenatedKey(utils.Action, rec.Action),
+	})
+	iDB.db.Set(utils.CacheAuditLogTBL, rec.ID, rec, idxs.AsSlice(),
+		cacheCommit(utils.NonTransactional), utils.NonTransactional)
+	return
+}
+
+// GetAuditRecords returns the audit records matching the filter, sorted on time
+func (iDB *InternalDB) GetAuditRecords(fltr *utils.AuditRecordsFilter) (recs []*AuditRecord, err error) {
+	var recIDs utils.StringSet
+	for _, fltrSlc := range []struct {
+		key  string
+		vals []string
+	}{
+		{utils.ItemType, fltr.ItemTypes},
+		{utils.Tenant, fltr.Tenants},
+		{utils.ItemID, fltr.ItemIDs},
+		{utils.User, fltr.Users},
+		{utils.Action, fltr.Actions},
+	} {
+		if len(fltrSlc.vals) == 0 {
+			continue
+		}
+		grpIDs := make(utils.StringSet)
+		for _, val := range fltrSlc.vals {
+			grpIDs.AddSlice(iDB.db.GetGroupItemIDs(utils.CacheAuditLogTBL, utils.ConcatenatedKey(fltrSlc.key, val)))
+		}
+		if recIDs == nil {
+			recIDs = grpIDs
+		} else {
+			recIDs.Intersect(grpIDs)
+		}
+		if recIDs.Size() == 0 {
+			return nil, utils.ErrNotFound
+		}
+	}
+	if recIDs == nil {
+		recIDs = utils.NewStringSet(iDB.db.GetItemIDs(utils.CacheAuditLogTBL, utils.EmptyString))
+	}
+	for id := range recIDs {
+		x, ok := iDB.db.Get(utils.CacheAuditLogTBL, id)
+		if !ok || x == nil {
+			continue
+		}
+		rec := x.(*AuditRecord)
+		if fltr.Time.Begin != nil && rec.Time.Before(*fltr.Time.Begin) ||
+			fltr.Time.End != nil && !rec.Time.Before(*fltr.Time.End) {
+			continue
+		}
+		recs = append(recs, rec)
+	}
+	if recs = paginateAuditRecords(recs, fltr.Paginator); len(recs) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	return
+}
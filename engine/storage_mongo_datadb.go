@@ -98,6 +98,12 @@ var (
 	UpdatedAtLow   = strings.ToLower(utils.UpdatedAt)
 	UsageLow       = strings.ToLower(utils.Usage)
 	DestinationLow = strings.ToLower(utils.Destination)
+	ItemTypeLow    = strings.ToLower(utils.ItemType)
+	ItemIDLow      = strings.ToLower(utils.ItemID)
+	UserLow        = strings.ToLower(utils.User)
+	ActionLow      = strings.ToLower(utils.Action)
+	TimeLow        = strings.ToLower(utils.Time)
+	IDLow          = strings.ToLower(utils.ID)
 	CostLow        = strings.ToLower(utils.Cost)
 	CostSourceLow  = strings.ToLower(utils.CostSource)
 
@@ -359,6 +365,14 @@ func (ms *MongoStorage) ensureIndexesForCol(col string) (err error) { // exporte
 			OriginIDLow); err != nil {
 			return
 		}
+	case utils.AuditLogTBL:
+		if err = ms.enusureIndex(col, false, TimeLow); err != nil {
+			return
+		}
+		if err = ms.enusureIndex(col, false, ItemTypeLow,
+			TenantLow, ItemIDLow); err != nil {
+			return
+		}
 	}
 	return
 }
@@ -389,7 +403,8 @@ func (ms *MongoStorage) EnsureIndexes(cols ...string) (err error) {
 			utils.TBLTPSharedGroups, utils.TBLTPActions,
 			utils.TBLTPActionPlans, utils.TBLTPActionTriggers,
 			utils.TBLTPStats, utils.TBLTPResources,
-			utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL} {
+			utils.TBLTPRatingProfiles, utils.CDRsTBL, utils.SessionCostsTBL,
+			utils.AuditLogTBL} {
 			if err = ms.ensureIndexesForCol(col); err != nil {
 				return
 			}
@@ -1600,3 +1600,49 @@ func (ms *MongoStorage) RemoveVersions(vrs Versions) (err error) {
 func (ms *MongoStorage) GetStorageType() string {
 	return utils.Mongo
 }
+
+// SetAuditRecord stores the audit record
+func (ms *MongoStorage) SetAuditRecord(rec *AuditRecord) error {
+	return ms.query(func(sctx mongo.SessionContext) (err error) {
+		_, err = ms.getCol(utils.AuditLogTBL).InsertOne(sctx, rec)
+		return err
+	})
+}
+
+// GetAuditRecords returns the audit records matching the filter, sorted on time
+func (ms *MongoStorage) GetAuditRecords(fltr *utils.AuditRecordsFilter) (recs []*AuditRecord, err error) {
+	filters := bson.M{
+		ItemTypeLow: bson.M{"$in": fltr.ItemTypes},
+		TenantLow:   bson.M{"$in": fltr.Tenants},
+		ItemIDLow:   bson.M{"$in": fltr.ItemIDs},
+		UserLow:     bson.M{"$in": fltr.Users},
+		ActionLow:   bson.M{"$in": fltr.Actions},
+		TimeLow:     bson.M{"$gte": fltr.Time.Begin, "$lt": fltr.Time.End},
+	}
+	ms.cleanEmptyFilters(filters)
+	fop := options.Find().SetSort(bson.D{{Key: TimeLow, Value: 1}, {Key: IDLow, Value: 1}})
+	if fltr.Paginator.Limit != nil {
+		fop = fop.SetLimit(int64(*fltr.Paginator.Limit))
+	}
+	if fltr.Paginator.Offset != nil {
+		fop = fop.SetSkip(int64(*fltr.Paginator.Offset))
+	}
+	err = ms.query(func(sctx mongo.SessionContext) (err error) {
+		cur, err := ms.getCol(utils.AuditLogTBL).Find(sctx, filters, fop)
+		if err != nil {
+			return err
+		}
+		for cur.Next(sctx) {
+			rec := new(AuditRecord)
+			if err = cur.Decode(rec); err != nil {
+				return err
+			}
+			recs = append(recs, rec)
+		}
+		if len(recs) == 0 {
+			return utils.ErrNotFound
+		}
+		return cur.Close(sctx)
+	})
+	return
+}
@@ -751,6 +751,67 @@ func (sqls *SQLStorage) SetSMCost(smc *SMCost) error {
 	return nil
 }
 
+// SetAuditRecord stores the audit record
+func (sqls *SQLStorage) SetAuditRecord(rec *AuditRecord) error {
+	return sqls.db.Create(&AuditLogSQL{
+		RecordID:   rec.ID,
+		CreatedAt:  rec.Time,
+		AuditUser:  rec.User,
+		Source:     rec.Source,
+		Action:     rec.Action,
+		ItemType:   rec.ItemType,
+		Tenant:     rec.Tenant,
+		ItemID:     rec.ItemID,
+		ItemBefore: string(rec.Before),
+		ItemAfter:  string(rec.After),
+	}).Error
+}
+
+// GetAuditRecords returns the audit records matching the filter, sorted on time
+func (sqls *SQLStorage) GetAuditRecords(fltr *utils.AuditRecordsFilter) ([]*AuditRecord, error) {
+	q := sqls.db.Table(utils.AuditLogTBL).Select("*")
+	if len(fltr.ItemTypes) != 0 {
+		q = q.Where("item_type in (?)", fltr.ItemTypes)
+	}
+	if len(fltr.Tenants) != 0 {
+		q = q.Where("tenant in (?)", fltr.Tenants)
+	}
+	if len(fltr.ItemIDs) != 0 {
+		q = q.Where("item_id in (?)", fltr.ItemIDs)
+	}
+	if len(fltr.Users) != 0 {
+		q = q.Where("audit_user in (?)", fltr.Users)
+	}
+	if len(fltr.Actions) != 0 {
+		q = q.Where("action in (?)", fltr.Actions)
+	}
+	if fltr.Time.Begin != nil {
+		q = q.Where("created_at >= ?", fltr.Time.Begin)
+	}
+	if fltr.Time.End != nil {
+		q = q.Where("created_at < ?", fltr.Time.End)
+	}
+	q = q.Order("created_at, record_id")
+	if fltr.Paginator.Limit != nil {
+		q = q.Limit(*fltr.Paginator.Limit)
+	}
+	if fltr.Paginator.Offset != nil {
+		q = q.Offset(*fltr.Paginator.Offset)
+	}
+	var results []*AuditLogSQL
+	if err := q.Find(&results).Error; err != nil {
+		return nil, err
+	}
+	if len(results) == 0 {
+		return nil, utils.ErrNotFound
+	}
+	recs := make([]*AuditRecord, len(results))
+	for i, result := range results {
+		recs[i] = result.AsAuditRecord()
+	}
+	return recs, nil
+}
+
 func (sqls *SQLStorage) RemoveSMCost(smc *SMCost) error {
 	tx := sqls.db.Begin()
 	var rmParam *SessionCostsSQL
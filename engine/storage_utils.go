@@ -80,6 +80,22 @@ func NewDataDBConn(dbType, host, port, name, user,
 	return
 }
 
+// NewDataDBFromCfg creates the DataDB out of the data_db config, failing over
+// to the standby DataDBs if they are configured
+func NewDataDBFromCfg(dbCfg *config.DataDbCfg, marshaler string,
+	itmsCfg map[string]*config.ItemOpt) (d DataDB, err error) {
+	if len(dbCfg.StandbyDBs) == 0 {
+		return NewDataDBConn(dbCfg.Type, dbCfg.Host, dbCfg.Port, dbCfg.Name,
+			dbCfg.User, dbCfg.Password, marshaler, dbCfg.Opts, itmsCfg)
+	}
+	var fdb *FailoverDataDB
+	if fdb, err = NewFailoverDataDB(dbCfg, marshaler, itmsCfg); err != nil {
+		return
+	}
+	fdb.StartLoop()
+	return fdb, nil
+}
+
 // NewStorDBConn returns a StorDB(implements Storage interface) based on dbType
 func NewStorDBConn(dbType, host, port, name, user, pass, marshaler string,
 	stringIndexedFields, prefixIndexedFields []string,
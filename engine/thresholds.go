@@ -49,6 +49,9 @@ type ThresholdProfile struct {
 	Weight             float64 // Weight to sort the thresholds
 	ActionIDs          []string
 	Async              bool
+	RaiseFilterIDs     []string // condition raising the alarm, the threshold becomes stateful if defined
+	ClearFilterIDs     []string // condition clearing the alarm, defaults to the raise condition not being met
+	ClearActionIDs     []string // actions executed when the alarm is cleared
 
 	lkID string // holds the reference towards guardian lock key
 }
@@ -58,6 +61,11 @@ func (tp *ThresholdProfile) TenantID() string {
 	return utils.ConcatenatedKey(tp.Tenant, tp.ID)
 }
 
+// isAlarm returns true if the profile raises and clears alarms
+func (tp *ThresholdProfile) isAlarm() bool {
+	return len(tp.RaiseFilterIDs) != 0
+}
+
 // thresholdProfileLockKey returns the ID used to lock a ThresholdProfile with guardian
 func thresholdProfileLockKey(tnt, id string) string {
 	return utils.ConcatenatedKey(utils.CacheThresholdProfiles, tnt, id)
@@ -96,10 +104,11 @@ type ThresholdWithAPIOpts struct {
 
 // Threshold is the unit matched by filters
 type Threshold struct {
-	Tenant string
-	ID     string
-	Hits   int       // number of hits for this threshold
-	Snooze time.Time // prevent threshold to run too early
+	Tenant   string
+	ID       string
+	Hits     int       // number of hits for this threshold
+	Snooze   time.Time // prevent threshold to run too early
+	RaisedAt time.Time // time when the alarm was raised, empty if not raised
 
 	lkID  string // ID of the lock used when matching the threshold
 	tPrfl *ThresholdProfile
@@ -144,12 +153,52 @@ func (t *Threshold) isLocked() bool {
 // ProcessEvent processes an ThresholdEvent
 // concurrentActions limits the number of simultaneous action sets executed
 func (t *Threshold) ProcessEvent(args *utils.CGREvent, dm *DataManager, fltrS *FilterS) (err error) {
+	if t.tPrfl.isAlarm() {
+		return t.processAlarm(args, fltrS)
+	}
 	if t.Snooze.After(time.Now()) || // snoozed, not executing actions
 		t.Hits < t.tPrfl.MinHits || // number of hits was not met, will not execute actions
 		(t.tPrfl.MaxHits != -1 &&
 			t.Hits > t.tPrfl.MaxHits) {
 		return
 	}
+	return t.executeActions(args, t.tPrfl.ActionIDs, fltrS)
+}
+
+// processAlarm raises the alarm when the raise condition is met and clears it
+// once the clear condition is met, executing the corresponding actions on each transition
+func (t *Threshold) processAlarm(args *utils.CGREvent, fltrS *FilterS) (err error) {
+	evNm := utils.MapStorage{
+		utils.MetaReq:  args.Event,
+		utils.MetaOpts: args.APIOpts,
+	}
+	var pass bool
+	if t.RaisedAt.IsZero() {
+		if t.Snooze.After(time.Now()) || // snoozed, not raising the alarm
+			t.Hits < t.tPrfl.MinHits {
+			return
+		}
+		if pass, err = fltrS.Pass(t.Tenant, t.tPrfl.RaiseFilterIDs, evNm); err != nil || !pass {
+			return
+		}
+		t.RaisedAt = time.Now()
+		t.Snooze = t.RaisedAt.Add(t.tPrfl.MinSleep)
+		return t.executeActions(args, t.tPrfl.ActionIDs, fltrS)
+	}
+	if len(t.tPrfl.ClearFilterIDs) != 0 {
+		pass, err = fltrS.Pass(t.Tenant, t.tPrfl.ClearFilterIDs, evNm)
+	} else if pass, err = fltrS.Pass(t.Tenant, t.tPrfl.RaiseFilterIDs, evNm); err == nil {
+		pass = !pass // no clear condition, clear when the raise one is no longer met
+	}
+	if err != nil || !pass {
+		return
+	}
+	t.RaisedAt = time.Time{}
+	return t.executeActions(args, t.tPrfl.ClearActionIDs, fltrS)
+}
+
+// executeActions executes the action sets with the event as extra data
+func (t *Threshold) executeActions(args *utils.CGREvent, actionIDs []string, fltrS *FilterS) (err error) {
 	var tntAcnt string
 	var acnt string
 	if utils.IfaceAsString(args.APIOpts[utils.MetaEventType]) == utils.AccountUpdate {
@@ -161,7 +210,7 @@ func (t *Threshold) ProcessEvent(args *utils.CGREvent, dm *DataManager, fltrS *F
 		tntAcnt = utils.ConcatenatedKey(args.Tenant, acnt)
 	}
 
-	for _, actionSetID := range t.tPrfl.ActionIDs {
+	for _, actionSetID := range actionIDs {
 		at := &ActionTiming{
 			Uuid:      utils.GenUUID(),
 			ActionsID: actionSetID,
@@ -460,7 +509,9 @@ func (tS *ThresholdService) processEvent(tnt string, args *utils.CGREvent) (thre
 			}
 			continue
 		}
-		t.Snooze = time.Now().Add(t.tPrfl.MinSleep)
+		if !t.tPrfl.isAlarm() { // alarms are snoozed only when raised
+			t.Snooze = time.Now().Add(t.tPrfl.MinSleep)
+		}
 		// recurrent threshold
 		*t.dirty = true // mark it to be saved
 		if tS.cgrcfg.ThresholdSCfg().StoreInterval == -1 {
@@ -591,3 +642,49 @@ func (tS *ThresholdService) V1ResetThreshold(tntID *utils.TenantID, rply *string
 	*rply = utils.OK
 	return
 }
+
+// ThresholdAlarm is one alarm raised by a threshold
+type ThresholdAlarm struct {
+	Tenant   string
+	ID       string
+	Hits     int
+	RaisedAt time.Time
+}
+
+// V1GetRaisedAlarms returns the alarms currently raised for a tenant, the oldest first
+func (tS *ThresholdService) V1GetRaisedAlarms(tenant string, reply *[]*ThresholdAlarm) (err error) {
+	if tenant == utils.EmptyString {
+		tenant = tS.cgrcfg.GeneralCfg().DefaultTenant
+	}
+	var tIDs []string
+	if err = tS.V1GetThresholdIDs(tenant, &tIDs); err != nil {
+		return
+	}
+	alarms := make([]*ThresholdAlarm, 0, len(tIDs))
+	for _, tID := range tIDs {
+		lkID := guardian.Guardian.GuardIDs(utils.EmptyString,
+			config.CgrConfig().GeneralCfg().LockingTimeout,
+			thresholdLockKey(tenant, tID))
+		var thd *Threshold
+		thd, err = tS.dm.GetThreshold(tenant, tID, true, true, utils.EmptyString)
+		if err == nil && !thd.RaisedAt.IsZero() {
+			alarms = append(alarms, &ThresholdAlarm{
+				Tenant:   thd.Tenant,
+				ID:       thd.ID,
+				Hits:     thd.Hits,
+				RaisedAt: thd.RaisedAt,
+			})
+		}
+		guardian.Guardian.UnguardIDs(lkID)
+		if err != nil {
+			if err == utils.ErrNotFound { // removed in the meantime
+				err = nil
+				continue
+			}
+			return
+		}
+	}
+	sort.Slice(alarms, func(i, j int) bool { return alarms[i].RaisedAt.Before(alarms[j].RaisedAt) })
+	*reply = alarms
+	return
+}
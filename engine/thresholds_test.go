@@ -2366,3 +2366,60 @@ func TestThresholdsStoreThresholdCacheSetErr(t *testing.T) {
 
 	utils.Logger.SetLogLevel(0)
 }
+
+func TestThresholdsProcessEventAlarm(t *testing.T) {
+	Cache.Clear(nil)
+	cfg := config.NewDefaultCGRConfig()
+	data := NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := NewDataManager(data, cfg.CacheCfg(), nil)
+	filterS := NewFilterS(cfg, nil, dm)
+	tS := NewThresholdService(dm, cfg, filterS)
+
+	thPrf := &ThresholdProfile{
+		Tenant:         "cgrates.org",
+		ID:             "TH_ASR",
+		FilterIDs:      []string{"*string:~*req.StatID:STAT_ASR"},
+		MaxHits:        -1,
+		RaiseFilterIDs: []string{"*lt:~*req.*asr:30"},
+		ClearFilterIDs: []string{"*gt:~*req.*asr:40"},
+	}
+	if err := dm.SetThresholdProfile(thPrf, true); err != nil {
+		t.Fatal(err)
+	}
+	if err := dm.SetThreshold(&Threshold{Tenant: "cgrates.org", ID: "TH_ASR"}); err != nil {
+		t.Fatal(err)
+	}
+	processASR := func(asr float64) {
+		if _, err := tS.processEvent("cgrates.org", &utils.CGREvent{
+			Tenant: "cgrates.org",
+			ID:     utils.GenUUID(),
+			Event: map[string]interface{}{
+				"StatID":      "STAT_ASR",
+				utils.MetaASR: asr,
+			},
+		}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	var alarms []*ThresholdAlarm
+	processASR(50)
+	if err := tS.V1GetRaisedAlarms(utils.EmptyString, &alarms); err != nil {
+		t.Fatal(err)
+	} else if len(alarms) != 0 {
+		t.Errorf("expected no alarms, received: %s", utils.ToJSON(alarms))
+	}
+	processASR(20)
+	processASR(35) // between the raise and clear conditions, the alarm remains raised
+	if err := tS.V1GetRaisedAlarms("cgrates.org", &alarms); err != nil {
+		t.Fatal(err)
+	} else if len(alarms) != 1 || alarms[0].ID != "TH_ASR" ||
+		alarms[0].Hits != 3 || alarms[0].RaisedAt.IsZero() {
+		t.Errorf("unexpected alarms: %s", utils.ToJSON(alarms))
+	}
+	processASR(45)
+	if err := tS.V1GetRaisedAlarms("cgrates.org", &alarms); err != nil {
+		t.Fatal(err)
+	} else if len(alarms) != 0 {
+		t.Errorf("expected no alarms, received: %s", utils.ToJSON(alarms))
+	}
+}
@@ -80,6 +80,12 @@ func NewTpReader(db DataDB, lr LoadReader, tpid, timezone string,
 	return tpr, nil
 }
 
+// WithAudit records into the audit log the changes done by user via source when writing or removing the data
+func (tpr *TpReader) WithAudit(user, source string) *TpReader {
+	tpr.dm = tpr.dm.WithAudit(user, source)
+	return tpr
+}
+
 func (tpr *TpReader) Init() {
 	tpr.actions = make(map[string][]*Action)
 	tpr.actionPlans = make(map[string]*ActionPlan)
@@ -6131,3 +6131,83 @@ func TestResourcesMatchingResourcesForEventFinalCacheSetErr(t *testing.T) {
 		t.Error("expected struct field \"lkID\" to be empty")
 	}
 }
+
+func TestResourceRateLimitAllocate(t *testing.T) {
+	r := &Resource{
+		Tenant: "cgrates.org",
+		ID:     "RL_CAPS",
+		Usages: make(map[string]*ResourceUsage),
+		rPrf: &ResourceProfile{
+			Tenant:       "cgrates.org",
+			ID:           "RL_CAPS",
+			Limit:        2,
+			Rate:         2,
+			RateInterval: time.Hour,
+		},
+	}
+	rs := Resources{r}
+	for i := 0; i < 2; i++ {
+		ru := &ResourceUsage{Tenant: "cgrates.org", ID: fmt.Sprintf("RU_%d", i), Units: 1}
+		if _, err := rs.allocateResource(ru, true); err != nil {
+			t.Error(err)
+		}
+		if alcMsg, err := rs.allocateResource(ru, false); err != nil {
+			t.Error(err)
+		} else if alcMsg != "RL_CAPS" {
+			t.Errorf("Expected %q, received %q", "RL_CAPS", alcMsg)
+		}
+	}
+	if len(r.Usages) != 0 {
+		t.Errorf("Expected no usage to be recorded, received: %s", utils.ToJSON(r.Usages))
+	}
+	ru := &ResourceUsage{Tenant: "cgrates.org", ID: "RU_2", Units: 1}
+	if _, err := rs.allocateResource(ru, true); err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected %v, received %v", utils.ErrResourceUnavailable, err)
+	}
+	// release does not give back the tokens
+	if err := rs.clearUsage("RU_0"); err != nil {
+		t.Error(err)
+	}
+	if _, err := rs.allocateResource(ru, false); err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected %v, received %v", utils.ErrResourceUnavailable, err)
+	}
+	// half of the interval passed so one unit is refilled
+	r.Bucket.Updated = r.Bucket.Updated.Add(-30 * time.Minute)
+	if _, err := rs.allocateResource(ru, false); err != nil {
+		t.Error(err)
+	}
+	if _, err := rs.allocateResource(ru, true); err != utils.ErrResourceUnavailable {
+		t.Errorf("Expected %v, received %v", utils.ErrResourceUnavailable, err)
+	}
+}
+
+func TestResourceBucketTokensAt(t *testing.T) {
+	rp := &ResourceProfile{
+		Limit:        10,
+		Rate:         5,
+		RateInterval: time.Second,
+	}
+	now := time.Now()
+	var rb *ResourceBucket
+	if tkns := rb.tokensAt(rp, now); tkns != 10 {
+		t.Errorf("Expected 10, received %v", tkns)
+	}
+	rb = &ResourceBucket{Tokens: -1, Updated: now}
+	if tkns := rb.tokensAt(rp, now.Add(time.Second)); tkns != 4 {
+		t.Errorf("Expected 4, received %v", tkns)
+	}
+	if tkns := rb.tokensAt(rp, now.Add(time.Minute)); tkns != 10 {
+		t.Errorf("Expected 10, received %v", tkns)
+	}
+	if tkns := rb.tokensAt(rp, now.Add(-time.Second)); tkns != -1 {
+		t.Errorf("Expected -1, received %v", tkns)
+	}
+	rb.Updated = now.Add(-time.Minute)
+	rwc := &ResourceWithConfig{
+		Resource: &Resource{Bucket: rb},
+		Config:   rp,
+	}
+	if avail := rwc.Available(); avail != 10 {
+		t.Errorf("Expected 10, received %v", avail)
+	}
+}
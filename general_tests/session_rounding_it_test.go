@@ -267,9 +267,10 @@ func testSesRndItLoadRating(t *testing.T) {
 		t.Error("Calling APIerSv1.LoadRatingPlan got reply: ", reply)
 	}
 
-	if err := sesRndRPC.Call(utils.APIerSv1LoadRatingProfile, &utils.TPRatingProfile{
-		TPid: utils.TestSQL, LoadId: utils.TestSQL,
-		Tenant: sesRndTenant, Category: utils.Call}, &reply); err != nil {
+	if err := sesRndRPC.Call(utils.APIerSv1LoadRatingProfile, &utils.TPRatingProfileWithAPIOpts{
+		TPRatingProfile: &utils.TPRatingProfile{
+			TPid: utils.TestSQL, LoadId: utils.TestSQL,
+			Tenant: sesRndTenant, Category: utils.Call}}, &reply); err != nil {
 		t.Error("Got error on APIerSv1.LoadRatingProfile: ", err.Error())
 	} else if reply != utils.OK {
 		t.Error("Calling APIerSv1.LoadRatingProfile got reply: ", reply)
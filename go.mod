@@ -40,6 +40,7 @@ require (
 	github.com/mitchellh/mapstructure v1.4.0
 	github.com/nats-io/nats.go v1.11.0
 	github.com/nyaruka/phonenumbers v1.0.75
+	github.com/oschwald/maxminddb-golang v1.8.0
 	github.com/peterh/liner v1.2.1
 	github.com/segmentio/kafka-go v0.4.8
 	github.com/streadway/amqp v1.0.0
@@ -45,6 +45,7 @@ func NewLoader(dm *engine.DataManager, cfg *config.LoaderSCfg,
 		enabled:       cfg.Enabled,
 		tenant:        cfg.Tenant,
 		dryRun:        cfg.DryRun,
+		transactional: cfg.Transactional,
 		ldrID:         cfg.ID,
 		tpInDir:       cfg.TpInDir,
 		tpOutDir:      cfg.TpOutDir,
@@ -88,6 +89,7 @@ type Loader struct {
 	enabled       bool
 	tenant        string
 	dryRun        bool
+	transactional bool
 	ldrID         string
 	tpInDir       string
 	tpOutDir      string
@@ -105,6 +107,12 @@ type Loader struct {
 	cacheConns    []string
 }
 
+// auditedDM returns the DataManager recording into the audit log the changes done by the loader
+// built on each write so the DataDB reconnects and the audit_log reloads are followed
+func (ldr *Loader) auditedDM() *engine.DataManager {
+	return ldr.dm.WithAudit(utils.EmptyString, utils.ConcatenatedKey(utils.LoaderS, ldr.ldrID))
+}
+
 func (ldr *Loader) ListenAndServe(stopChan chan struct{}) (err error) {
 	utils.Logger.Info(fmt.Sprintf("Starting <%s-%s>", utils.LoaderS, ldr.ldrID))
 	return ldr.serve(stopChan)
@@ -116,6 +124,9 @@ func (ldr *Loader) ProcessFolder(caching, loadOption string, stopOnError bool) (
 		return
 	}
 	defer ldr.unlockFolder()
+	if ldr.transactional && loadOption == utils.MetaStore {
+		return ldr.processTransaction(caching)
+	}
 	for ldrType := range ldr.rdrs {
 		if err = ldr.processFiles(ldrType, caching, loadOption); err != nil {
 			if stopOnError {
@@ -310,7 +321,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, apf.TenantID())
-				if err := ldr.dm.SetAttributeProfile(apf, true); err != nil {
+				if err := ldr.auditedDM().SetAttributeProfile(apf, true); err != nil {
 					return err
 				}
 			}
@@ -340,7 +351,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, res.TenantID())
-				if err := ldr.dm.SetResourceProfile(res, true); err != nil {
+				if err := ldr.auditedDM().SetResourceProfile(res, true); err != nil {
 					return err
 				}
 				cacheArgs[utils.CacheResourceProfiles] = ids
@@ -370,7 +381,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, fltrPrf.TenantID())
-				if err := ldr.dm.SetFilter(fltrPrf, true); err != nil {
+				if err := ldr.auditedDM().SetFilter(fltrPrf, true); err != nil {
 					return err
 				}
 				cacheArgs[utils.CacheFilters] = ids
@@ -399,7 +410,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, stsPrf.TenantID())
-				if err := ldr.dm.SetStatQueueProfile(stsPrf, true); err != nil {
+				if err := ldr.auditedDM().SetStatQueueProfile(stsPrf, true); err != nil {
 					return err
 				}
 				cacheArgs[utils.CacheStatQueueProfiles] = ids
@@ -429,7 +440,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, thPrf.TenantID())
-				if err := ldr.dm.SetThresholdProfile(thPrf, true); err != nil {
+				if err := ldr.auditedDM().SetThresholdProfile(thPrf, true); err != nil {
 					return err
 				}
 				cacheArgs[utils.CacheThresholdProfiles] = ids
@@ -460,7 +471,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, spPrf.TenantID())
-				if err := ldr.dm.SetRouteProfile(spPrf, true); err != nil {
+				if err := ldr.auditedDM().SetRouteProfile(spPrf, true); err != nil {
 					return err
 				}
 				cacheArgs[utils.CacheRouteProfiles] = ids
@@ -490,7 +501,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, cpp.TenantID())
-				if err := ldr.dm.SetChargerProfile(cpp, true); err != nil {
+				if err := ldr.auditedDM().SetChargerProfile(cpp, true); err != nil {
 					return err
 				}
 				cacheArgs[utils.CacheChargerProfiles] = ids
@@ -519,7 +530,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, dsp.TenantID())
-				if err := ldr.dm.SetDispatcherProfile(dsp, true); err != nil {
+				if err := ldr.auditedDM().SetDispatcherProfile(dsp, true); err != nil {
 					return err
 				}
 				cacheArgs[utils.CacheDispatcherProfiles] = ids
@@ -548,7 +559,7 @@ func (ldr *Loader) storeLoadedData(loaderType string,
 				}
 				// get IDs so we can reload in cache
 				ids = append(ids, dsp.TenantID())
-				if err := ldr.dm.SetDispatcherHost(dsp); err != nil {
+				if err := ldr.auditedDM().SetDispatcherHost(dsp); err != nil {
 					return err
 				}
 				cacheArgs[utils.CacheDispatcherHosts] = ids
@@ -629,8 +640,8 @@ func (ldr *Loader) removeContent(loaderType, caching string) (err error) {
 	return
 }
 
-//removeLoadedData will remove the data from database
-//since we remove we don't need to compose the struct we only need the Tenant and the ID of the profile
+// removeLoadedData will remove the data from database
+// since we remove we don't need to compose the struct we only need the Tenant and the ID of the profile
 func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderData, caching string) (err error) {
 	var ids []string
 	cacheArgs := make(map[string][]string)
@@ -647,7 +658,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveAttributeProfile(tntIDStruct.Tenant, tntIDStruct.ID,
+				if err := ldr.auditedDM().RemoveAttributeProfile(tntIDStruct.Tenant, tntIDStruct.ID,
 					true); err != nil {
 					return err
 				}
@@ -667,7 +678,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveResourceProfile(tntIDStruct.Tenant,
+				if err := ldr.auditedDM().RemoveResourceProfile(tntIDStruct.Tenant,
 					tntIDStruct.ID, true); err != nil {
 					return err
 				}
@@ -685,7 +696,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveFilter(tntIDStruct.Tenant, tntIDStruct.ID,
+				if err := ldr.auditedDM().RemoveFilter(tntIDStruct.Tenant, tntIDStruct.ID,
 					true); err != nil {
 					return err
 				}
@@ -703,7 +714,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveStatQueueProfile(tntIDStruct.Tenant,
+				if err := ldr.auditedDM().RemoveStatQueueProfile(tntIDStruct.Tenant,
 					tntIDStruct.ID, true); err != nil {
 					return err
 				}
@@ -722,7 +733,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveThresholdProfile(tntIDStruct.Tenant,
+				if err := ldr.auditedDM().RemoveThresholdProfile(tntIDStruct.Tenant,
 					tntIDStruct.ID, true); err != nil {
 					return err
 				}
@@ -741,7 +752,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveRouteProfile(tntIDStruct.Tenant,
+				if err := ldr.auditedDM().RemoveRouteProfile(tntIDStruct.Tenant,
 					tntIDStruct.ID, true); err != nil {
 					return err
 				}
@@ -759,7 +770,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveChargerProfile(tntIDStruct.Tenant,
+				if err := ldr.auditedDM().RemoveChargerProfile(tntIDStruct.Tenant,
 					tntIDStruct.ID, true); err != nil {
 					return err
 				}
@@ -777,7 +788,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveDispatcherProfile(tntIDStruct.Tenant,
+				if err := ldr.auditedDM().RemoveDispatcherProfile(tntIDStruct.Tenant,
 					tntIDStruct.ID, true); err != nil {
 					return err
 				}
@@ -794,7 +805,7 @@ func (ldr *Loader) removeLoadedData(loaderType string, lds map[string][]LoaderDa
 				tntIDStruct := utils.NewTenantID(tntID)
 				// get IDs so we can reload in cache
 				ids = append(ids, tntID)
-				if err := ldr.dm.RemoveDispatcherHost(tntIDStruct.Tenant,
+				if err := ldr.auditedDM().RemoveDispatcherHost(tntIDStruct.Tenant,
 					tntIDStruct.ID); err != nil {
 					return err
 				}
@@ -839,6 +850,9 @@ func (ldr *Loader) handleFolder(stopChan chan struct{}) {
 }
 
 func (ldr *Loader) processFile(_, itmID string) (err error) {
+	if ldr.transactional {
+		return ldr.processTxnTrigger(itmID)
+	}
 	loaderType := ldr.getLdrType(itmID)
 	if len(loaderType) == 0 {
 		return
@@ -881,6 +895,20 @@ func (ldr *Loader) processFile(_, itmID string) (err error) {
 	return
 }
 
+// processTxnTrigger starts the transactional load once the trigger file is created,
+// the files written before it being ignored so a partially copied set is never loaded
+func (ldr *Loader) processTxnTrigger(itmID string) (err error) {
+	if itmID != txnTriggerFile {
+		return
+	}
+	if err = ldr.lockFolder(); err != nil {
+		return
+	}
+	defer ldr.unlockFolder()
+	defer os.Remove(path.Join(ldr.tpInDir, txnTriggerFile))
+	return ldr.processTransaction(config.CgrConfig().GeneralCfg().DefaultCaching)
+}
+
 func (ldr *Loader) allFilesPresent(ldrType string) bool {
 	for _, rdr := range ldr.rdrs[ldrType] {
 		if rdr == nil {
@@ -0,0 +1,620 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"encoding/csv"
+	"fmt"
+	"io"
+	"os"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// txnLoaderTypes is the order the profiles are stored in so the referenced ones are written first
+var txnLoaderTypes = []string{utils.MetaFilters, utils.MetaDispatcherHosts,
+	utils.MetaThresholds, utils.MetaAttributes, utils.MetaResources, utils.MetaStats,
+	utils.MetaRoutes, utils.MetaChargers, utils.MetaDispatchers}
+
+// txnTriggerFile is created in TpInDir once all the files of a transactional load are there,
+// starting the load when watching the folder
+const txnTriggerFile = ".cgr.run"
+
+// txnCacheArgs are the cache partitions to reload and the indexes to clear per loader type
+var txnCacheArgs = map[string][2][]string{
+	utils.MetaAttributes:      {{utils.CacheAttributeProfiles}, {utils.CacheAttributeFilterIndexes}},
+	utils.MetaResources:       {{utils.CacheResourceProfiles, utils.CacheResources}, {utils.CacheResourceFilterIndexes}},
+	utils.MetaFilters:         {{utils.CacheFilters}, nil},
+	utils.MetaStats:           {{utils.CacheStatQueueProfiles, utils.CacheStatQueues}, {utils.CacheStatFilterIndexes}},
+	utils.MetaThresholds:      {{utils.CacheThresholdProfiles, utils.CacheThresholds}, {utils.CacheThresholdFilterIndexes}},
+	utils.MetaRoutes:          {{utils.CacheRouteProfiles}, {utils.CacheRouteFilterIndexes}},
+	utils.MetaChargers:        {{utils.CacheChargerProfiles}, {utils.CacheChargerFilterIndexes}},
+	utils.MetaDispatchers:     {{utils.CacheDispatcherProfiles}, {utils.CacheDispatcherFilterIndexes}},
+	utils.MetaDispatcherHosts: {{utils.CacheDispatcherHosts}, nil},
+}
+
+// LoadError is one problem found while parsing, validating or storing a transactional load
+type LoadError struct {
+	LoaderType string
+	FileName   string `json:",omitempty"`
+	Line       int    `json:",omitempty"`
+	TenantID   string `json:",omitempty"`
+	Error      string
+}
+
+// LoadReport is written in TpOutDir, together with the files of the run, when a transactional load is rejected
+type LoadReport struct {
+	LoaderID string
+	Time     time.Time
+	Files    []string
+	Errors   []*LoadError
+}
+
+// txnProfile is one profile out of a transactional load
+type txnProfile struct {
+	ldrType string
+	tenant  string
+	id      string
+	prf     interface{}
+}
+
+func (tp *txnProfile) tenantID() string {
+	return utils.ConcatenatedKey(tp.tenant, tp.id)
+}
+
+// processTransaction loads all the loader types having their files present in TpInDir as one unit:
+// the files are parsed and the profiles validated before storing anything, with one cache reload at the end
+// if any error is found nothing is stored and a LoadReport is written in TpOutDir
+func (ldr *Loader) processTransaction(caching string) (err error) {
+	var ldrTypes, files []string
+	for _, ldrType := range txnLoaderTypes {
+		if ldrFiles := ldr.presentFiles(ldrType); len(ldrFiles) != 0 {
+			ldrTypes = append(ldrTypes, ldrType)
+			files = append(files, ldrFiles...)
+		}
+	}
+	if len(ldrTypes) == 0 {
+		return
+	}
+	var prfs []*txnProfile
+	var ldErrs []*LoadError
+	loaded := make(map[string]utils.StringSet) // tenantIDs in this load, per loader type
+	for _, ldrType := range ldrTypes {
+		tntIDs, lds, parseErrs := ldr.parseTxnFiles(ldrType)
+		ldErrs = append(ldErrs, parseErrs...)
+		loaded[ldrType] = utils.NewStringSet(nil)
+		for _, tntID := range tntIDs {
+			tntPrfs, err := ldr.newTxnProfiles(ldrType, lds[tntID])
+			if err != nil {
+				ldErrs = append(ldErrs, &LoadError{LoaderType: ldrType, TenantID: tntID, Error: err.Error()})
+				continue
+			}
+			for _, tp := range tntPrfs {
+				loaded[ldrType].Add(tp.tenantID())
+			}
+			prfs = append(prfs, tntPrfs...)
+		}
+	}
+	for _, tp := range prfs {
+		if err := ldr.checkTxnProfile(tp, loaded); err != nil {
+			ldErrs = append(ldErrs, &LoadError{LoaderType: tp.ldrType, TenantID: tp.tenantID(), Error: err.Error()})
+		}
+	}
+	if len(ldErrs) == 0 {
+		if ldErr := ldr.storeTxnProfiles(prfs); ldErr != nil {
+			ldErrs = append(ldErrs, ldErr)
+		}
+	}
+	if len(ldErrs) != 0 {
+		if err = ldr.rejectTransaction(files, ldErrs); err != nil {
+			return
+		}
+		return fmt.Errorf("transactional load rejected with %d errors, first: %s",
+			len(ldErrs), ldErrs[0].Error)
+	}
+	if err = ldr.moveTxnFiles(files); err != nil {
+		return
+	}
+	if ldr.dryRun || len(ldr.cacheConns) == 0 {
+		return
+	}
+	cacheArgs := make(map[string][]string)
+	cacheIDs := utils.NewStringSet(nil)
+	for _, tp := range prfs {
+		for _, cacheID := range txnCacheArgs[tp.ldrType][0] {
+			cacheArgs[cacheID] = append(cacheArgs[cacheID], tp.tenantID())
+		}
+		cacheIDs.AddSlice(txnCacheArgs[tp.ldrType][1])
+	}
+	return engine.CallCache(ldr.connMgr, ldr.cacheConns, caching, cacheArgs, cacheIDs.AsSlice(), nil, false, ldr.tenant)
+}
+
+// presentFiles returns the files of the loaderType if all of them are present in TpInDir
+func (ldr *Loader) presentFiles(loaderType string) (files []string) {
+	for fName := range ldr.rdrs[loaderType] {
+		if _, err := os.Stat(path.Join(ldr.tpInDir, fName)); err != nil {
+			return nil
+		}
+		files = append(files, fName)
+	}
+	return
+}
+
+// parseTxnFiles reads all the rows of the loaderType files returning them grouped on tenantID, in the order they were read
+func (ldr *Loader) parseTxnFiles(loaderType string) (tntIDs []string, lds map[string][]LoaderData, ldErrs []*LoadError) {
+	lds = make(map[string][]LoaderData)
+	rdrs := make(map[string]*csv.Reader)
+	for fName := range ldr.rdrs[loaderType] {
+		rdr, err := os.Open(path.Join(ldr.tpInDir, fName))
+		if err != nil {
+			ldErrs = append(ldErrs, &LoadError{LoaderType: loaderType, FileName: fName, Error: err.Error()})
+			continue
+		}
+		defer rdr.Close()
+		csvReader := csv.NewReader(rdr)
+		csvReader.Comma = rune(ldr.fieldSep[0])
+		csvReader.Comment = '#'
+		rdrs[fName] = csvReader
+	}
+	if len(ldErrs) != 0 {
+		return
+	}
+	for {
+		var hasErrors bool
+		lData := make(LoaderData) // one row
+		for fName, csvRdr := range rdrs {
+			record, err := csvRdr.Read()
+			if err == io.EOF {
+				return
+			}
+			var lineNr int // the line within the file, the comments and the empty lines being skipped by the reader
+			if pErr, isParseErr := err.(*csv.ParseError); isParseErr {
+				lineNr = pErr.StartLine
+			} else if err == nil {
+				lineNr, _ = csvRdr.FieldPos(0)
+				err = lData.UpdateFromCSV(fName, record,
+					ldr.dataTpls[loaderType], ldr.tenant, ldr.filterS)
+			}
+			if err != nil {
+				hasErrors = true
+				ldErrs = append(ldErrs, &LoadError{LoaderType: loaderType,
+					FileName: fName, Line: lineNr, Error: err.Error()})
+			}
+		}
+		if hasErrors || len(lData) == 0 {
+			continue
+		}
+		tntID := lData.TenantID()
+		if _, has := lds[tntID]; !has {
+			tntIDs = append(tntIDs, tntID)
+		}
+		lds[tntID] = append(lds[tntID], lData)
+	}
+}
+
+// newTxnProfiles builds the profiles out of the rows read for one tenantID
+func (ldr *Loader) newTxnProfiles(loaderType string, lDataSet []LoaderData) (prfs []*txnProfile, err error) {
+	switch loaderType {
+	case utils.MetaAttributes:
+		mdls := make(engine.AttributeMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			mdls[i] = new(engine.AttributeMdl)
+			if err = utils.UpdateStructWithIfaceMap(mdls[i], ld); err != nil {
+				return
+			}
+		}
+		for _, tpPrf := range mdls.AsTPAttributes() {
+			var prf *engine.AttributeProfile
+			if prf, err = engine.APItoAttributeProfile(tpPrf, ldr.timezone); err != nil {
+				return
+			}
+			prfs = append(prfs, &txnProfile{loaderType, prf.Tenant, prf.ID, prf})
+		}
+	case utils.MetaResources:
+		mdls := make(engine.ResourceMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			mdls[i] = new(engine.ResourceMdl)
+			if err = utils.UpdateStructWithIfaceMap(mdls[i], ld); err != nil {
+				return
+			}
+		}
+		for _, tpPrf := range mdls.AsTPResources() {
+			var prf *engine.ResourceProfile
+			if prf, err = engine.APItoResource(tpPrf, ldr.timezone); err != nil {
+				return
+			}
+			prfs = append(prfs, &txnProfile{loaderType, prf.Tenant, prf.ID, prf})
+		}
+	case utils.MetaFilters:
+		mdls := make(engine.FilterMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			mdls[i] = new(engine.FilterMdl)
+			if err = utils.UpdateStructWithIfaceMap(mdls[i], ld); err != nil {
+				return
+			}
+		}
+		for _, tpPrf := range mdls.AsTPFilter() {
+			var prf *engine.Filter
+			if prf, err = engine.APItoFilter(tpPrf, ldr.timezone); err != nil {
+				return
+			}
+			prfs = append(prfs, &txnProfile{loaderType, prf.Tenant, prf.ID, prf})
+		}
+	case utils.MetaStats:
+		mdls := make(engine.StatMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			mdls[i] = new(engine.StatMdl)
+			if err = utils.UpdateStructWithIfaceMap(mdls[i], ld); err != nil {
+				return
+			}
+		}
+		for _, tpPrf := range mdls.AsTPStats() {
+			var prf *engine.StatQueueProfile
+			if prf, err = engine.APItoStats(tpPrf, ldr.timezone); err != nil {
+				return
+			}
+			prfs = append(prfs, &txnProfile{loaderType, prf.Tenant, prf.ID, prf})
+		}
+	case utils.MetaThresholds:
+		mdls := make(engine.ThresholdMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			mdls[i] = new(engine.ThresholdMdl)
+			if err = utils.UpdateStructWithIfaceMap(mdls[i], ld); err != nil {
+				return
+			}
+		}
+		for _, tpPrf := range mdls.AsTPThreshold() {
+			var prf *engine.ThresholdProfile
+			if prf, err = engine.APItoThresholdProfile(tpPrf, ldr.timezone); err != nil {
+				return
+			}
+			prfs = append(prfs, &txnProfile{loaderType, prf.Tenant, prf.ID, prf})
+		}
+	case utils.MetaRoutes:
+		mdls := make(engine.RouteMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			mdls[i] = new(engine.RouteMdl)
+			if err = utils.UpdateStructWithIfaceMap(mdls[i], ld); err != nil {
+				return
+			}
+		}
+		for _, tpPrf := range mdls.AsTPRouteProfile() {
+			var prf *engine.RouteProfile
+			if prf, err = engine.APItoRouteProfile(tpPrf, ldr.timezone); err != nil {
+				return
+			}
+			prfs = append(prfs, &txnProfile{loaderType, prf.Tenant, prf.ID, prf})
+		}
+	case utils.MetaChargers:
+		mdls := make(engine.ChargerMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			mdls[i] = new(engine.ChargerMdl)
+			if err = utils.UpdateStructWithIfaceMap(mdls[i], ld); err != nil {
+				return
+			}
+		}
+		for _, tpPrf := range mdls.AsTPChargers() {
+			var prf *engine.ChargerProfile
+			if prf, err = engine.APItoChargerProfile(tpPrf, ldr.timezone); err != nil {
+				return
+			}
+			prfs = append(prfs, &txnProfile{loaderType, prf.Tenant, prf.ID, prf})
+		}
+	case utils.MetaDispatchers:
+		mdls := make(engine.DispatcherProfileMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			mdls[i] = new(engine.DispatcherProfileMdl)
+			if err = utils.UpdateStructWithIfaceMap(mdls[i], ld); err != nil {
+				return
+			}
+		}
+		for _, tpPrf := range mdls.AsTPDispatcherProfiles() {
+			var prf *engine.DispatcherProfile
+			if prf, err = engine.APItoDispatcherProfile(tpPrf, ldr.timezone); err != nil {
+				return
+			}
+			prfs = append(prfs, &txnProfile{loaderType, prf.Tenant, prf.ID, prf})
+		}
+	case utils.MetaDispatcherHosts:
+		mdls := make(engine.DispatcherHostMdls, len(lDataSet))
+		for i, ld := range lDataSet {
+			mdls[i] = new(engine.DispatcherHostMdl)
+			if err = utils.UpdateStructWithIfaceMap(mdls[i], ld); err != nil {
+				return
+			}
+		}
+		var tpPrfs []*utils.TPDispatcherHost
+		if tpPrfs, err = mdls.AsTPDispatcherHosts(); err != nil {
+			return
+		}
+		for _, tpPrf := range tpPrfs {
+			prf := engine.APItoDispatcherHost(tpPrf)
+			prfs = append(prfs, &txnProfile{loaderType, prf.Tenant, prf.ID, prf})
+		}
+	}
+	return
+}
+
+// checkTxnProfile verifies that the filters and the profiles referenced by tp exist, either in this load or in DataDB
+func (ldr *Loader) checkTxnProfile(tp *txnProfile, loaded map[string]utils.StringSet) (err error) {
+	switch prf := tp.prf.(type) {
+	case *engine.Filter:
+		return engine.CheckFilter(prf)
+	case *engine.AttributeProfile:
+		if err = ldr.checkTxnFilters(tp.tenant, prf.FilterIDs, loaded); err != nil {
+			return
+		}
+		for _, attr := range prf.Attributes {
+			if err = ldr.checkTxnFilters(tp.tenant, attr.FilterIDs, loaded); err != nil {
+				return
+			}
+		}
+	case *engine.ResourceProfile:
+		if err = ldr.checkTxnFilters(tp.tenant, prf.FilterIDs, loaded); err != nil {
+			return
+		}
+		return ldr.checkTxnRefs(utils.MetaThresholds, tp.tenant, prf.ThresholdIDs, loaded)
+	case *engine.StatQueueProfile:
+		if err = ldr.checkTxnFilters(tp.tenant, prf.FilterIDs, loaded); err != nil {
+			return
+		}
+		return ldr.checkTxnRefs(utils.MetaThresholds, tp.tenant, prf.ThresholdIDs, loaded)
+	case *engine.ThresholdProfile:
+		for _, fltrIDs := range [][]string{prf.FilterIDs, prf.RaiseFilterIDs, prf.ClearFilterIDs} {
+			if err = ldr.checkTxnFilters(tp.tenant, fltrIDs, loaded); err != nil {
+				return
+			}
+		}
+		for _, actIDs := range [][]string{prf.ActionIDs, prf.ClearActionIDs} {
+			for _, actID := range actIDs {
+				if actID == utils.MetaNone {
+					continue
+				}
+				if _, err = ldr.dm.GetActions(actID, false, utils.NonTransactional); err != nil {
+					return fmt.Errorf("broken reference to actions: <%s>", actID)
+				}
+			}
+		}
+	case *engine.RouteProfile:
+		if err = ldr.checkTxnFilters(tp.tenant, prf.FilterIDs, loaded); err != nil {
+			return
+		}
+		for _, route := range prf.Routes {
+			if err = ldr.checkTxnFilters(tp.tenant, route.FilterIDs, loaded); err != nil {
+				return
+			}
+			if err = ldr.checkTxnRefs(utils.MetaResources, tp.tenant, route.ResourceIDs, loaded); err != nil {
+				return
+			}
+		}
+	case *engine.ChargerProfile:
+		if err = ldr.checkTxnFilters(tp.tenant, prf.FilterIDs, loaded); err != nil {
+			return
+		}
+		return ldr.checkTxnRefs(utils.MetaAttributes, tp.tenant, prf.AttributeIDs, loaded)
+	case *engine.DispatcherProfile:
+		if err = ldr.checkTxnFilters(tp.tenant, prf.FilterIDs, loaded); err != nil {
+			return
+		}
+		for _, host := range prf.Hosts {
+			if err = ldr.checkTxnFilters(tp.tenant, host.FilterIDs, loaded); err != nil {
+				return
+			}
+			if err = ldr.checkTxnRefs(utils.MetaDispatcherHosts, tp.tenant, []string{host.ID}, loaded); err != nil {
+				return
+			}
+		}
+	}
+	return
+}
+
+// checkTxnFilters verifies the inline filters and the existence of the filters referenced by ID
+func (ldr *Loader) checkTxnFilters(tnt string, fltrIDs []string, loaded map[string]utils.StringSet) (err error) {
+	for _, fltrID := range fltrIDs {
+		if strings.HasPrefix(fltrID, utils.Meta) {
+			var fltr *engine.Filter
+			if fltr, err = engine.NewFilterFromInline(tnt, fltrID); err != nil {
+				return fmt.Errorf("broken reference to filter: <%s>", fltrID)
+			}
+			if err = engine.CheckFilter(fltr); err != nil {
+				return
+			}
+			continue
+		}
+		if loaded[utils.MetaFilters].Has(utils.ConcatenatedKey(tnt, fltrID)) {
+			continue
+		}
+		if _, err = ldr.dm.GetFilter(tnt, fltrID, true, false, utils.NonTransactional); err != nil {
+			return fmt.Errorf("broken reference to filter: <%s>", fltrID)
+		}
+	}
+	return
+}
+
+// checkTxnRefs verifies the existence of the loaderType profiles referenced by ID, the inline and *none ones are not checked
+func (ldr *Loader) checkTxnRefs(loaderType, tnt string, ids []string, loaded map[string]utils.StringSet) (err error) {
+	for _, id := range ids {
+		if strings.HasPrefix(id, utils.Meta) ||
+			loaded[loaderType].Has(utils.ConcatenatedKey(tnt, id)) {
+			continue
+		}
+		var prf interface{}
+		if prf, err = ldr.getTxnProfile(loaderType, tnt, id); err != nil {
+			return
+		}
+		if prf == nil {
+			return fmt.Errorf("broken reference to %s: <%s>", loaderType, id)
+		}
+	}
+	return
+}
+
+// getTxnProfile returns the profile stored in DataDB or nil if it does not exist
+func (ldr *Loader) getTxnProfile(loaderType, tnt, id string) (prf interface{}, err error) {
+	switch loaderType {
+	case utils.MetaAttributes:
+		prf, err = ldr.dm.GetAttributeProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaResources:
+		prf, err = ldr.dm.GetResourceProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaFilters:
+		prf, err = ldr.dm.GetFilter(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaStats:
+		prf, err = ldr.dm.GetStatQueueProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaThresholds:
+		prf, err = ldr.dm.GetThresholdProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaRoutes:
+		prf, err = ldr.dm.GetRouteProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaChargers:
+		prf, err = ldr.dm.GetChargerProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaDispatchers:
+		prf, err = ldr.dm.GetDispatcherProfile(tnt, id, false, false, utils.NonTransactional)
+	case utils.MetaDispatcherHosts:
+		prf, err = ldr.dm.GetDispatcherHost(tnt, id, false, false, utils.NonTransactional)
+	}
+	if err == utils.ErrNotFound {
+		return nil, nil
+	}
+	return
+}
+
+func (ldr *Loader) setTxnProfile(prf interface{}) (err error) {
+	switch prf := prf.(type) {
+	case *engine.AttributeProfile:
+		return ldr.auditedDM().SetAttributeProfile(prf, true)
+	case *engine.ResourceProfile:
+		return ldr.auditedDM().SetResourceProfile(prf, true)
+	case *engine.Filter:
+		return ldr.auditedDM().SetFilter(prf, true)
+	case *engine.StatQueueProfile:
+		return ldr.auditedDM().SetStatQueueProfile(prf, true)
+	case *engine.ThresholdProfile:
+		return ldr.auditedDM().SetThresholdProfile(prf, true)
+	case *engine.RouteProfile:
+		return ldr.auditedDM().SetRouteProfile(prf, true)
+	case *engine.ChargerProfile:
+		return ldr.auditedDM().SetChargerProfile(prf, true)
+	case *engine.DispatcherProfile:
+		return ldr.auditedDM().SetDispatcherProfile(prf, true)
+	case *engine.DispatcherHost:
+		return ldr.auditedDM().SetDispatcherHost(prf)
+	}
+	return
+}
+
+func (ldr *Loader) removeTxnProfile(loaderType, tnt, id string) (err error) {
+	switch loaderType {
+	case utils.MetaAttributes:
+		return ldr.auditedDM().RemoveAttributeProfile(tnt, id, true)
+	case utils.MetaResources:
+		return ldr.auditedDM().RemoveResourceProfile(tnt, id, true)
+	case utils.MetaFilters:
+		return ldr.auditedDM().RemoveFilter(tnt, id, true)
+	case utils.MetaStats:
+		return ldr.auditedDM().RemoveStatQueueProfile(tnt, id, true)
+	case utils.MetaThresholds:
+		return ldr.auditedDM().RemoveThresholdProfile(tnt, id, true)
+	case utils.MetaRoutes:
+		return ldr.auditedDM().RemoveRouteProfile(tnt, id, true)
+	case utils.MetaChargers:
+		return ldr.auditedDM().RemoveChargerProfile(tnt, id, true)
+	case utils.MetaDispatchers:
+		return ldr.auditedDM().RemoveDispatcherProfile(tnt, id, true)
+	case utils.MetaDispatcherHosts:
+		return ldr.auditedDM().RemoveDispatcherHost(tnt, id)
+	}
+	return
+}
+
+// storeTxnProfiles writes the profiles in DataDB, on error restoring the ones already written to their previous version
+func (ldr *Loader) storeTxnProfiles(prfs []*txnProfile) (ldErr *LoadError) {
+	if ldr.dryRun {
+		for _, tp := range prfs {
+			utils.Logger.Info(
+				fmt.Sprintf("<%s-%s> DRY_RUN: %s: %s",
+					utils.LoaderS, ldr.ldrID, tp.ldrType, utils.ToJSON(tp.prf)))
+		}
+		return
+	}
+	oldPrfs := make([]interface{}, 0, len(prfs)) // previous versions of the profiles written so far
+	for _, tp := range prfs {
+		oldPrf, err := ldr.getTxnProfile(tp.ldrType, tp.tenant, tp.id)
+		if err == nil {
+			err = ldr.setTxnProfile(tp.prf)
+		}
+		if err == nil {
+			oldPrfs = append(oldPrfs, oldPrf)
+			continue
+		}
+		ldErr = &LoadError{LoaderType: tp.ldrType, TenantID: tp.tenantID(), Error: err.Error()}
+		for i := len(oldPrfs) - 1; i >= 0; i-- {
+			var rbErr error
+			if oldPrfs[i] == nil {
+				rbErr = ldr.removeTxnProfile(prfs[i].ldrType, prfs[i].tenant, prfs[i].id)
+			} else {
+				rbErr = ldr.setTxnProfile(oldPrfs[i])
+			}
+			if rbErr != nil {
+				utils.Logger.Warning(
+					fmt.Sprintf("<%s-%s> cannot rollback %s profile <%s>, err: %s",
+						utils.LoaderS, ldr.ldrID, prfs[i].ldrType, prfs[i].tenantID(), rbErr.Error()))
+			}
+		}
+		return
+	}
+	return
+}
+
+// rejectTransaction moves the files of a failed load together with its LoadReport into TpOutDir
+func (ldr *Loader) rejectTransaction(files []string, ldErrs []*LoadError) (err error) {
+	rpt := &LoadReport{
+		LoaderID: ldr.ldrID,
+		Time:     time.Now(),
+		Files:    files,
+		Errors:   ldErrs,
+	}
+	if ldr.tpOutDir == utils.EmptyString {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s-%s> transactional load rejected: %s",
+				utils.LoaderS, ldr.ldrID, utils.ToJSON(rpt)))
+		return
+	}
+	if err = os.WriteFile(path.Join(ldr.tpOutDir,
+		fmt.Sprintf("%s_%d%s", strings.TrimPrefix(ldr.ldrID, utils.Meta),
+			rpt.Time.UnixNano(), utils.JSNSuffix)), []byte(utils.ToIJSON(rpt)), 0644); err != nil {
+		return
+	}
+	return ldr.moveTxnFiles(files)
+}
+
+func (ldr *Loader) moveTxnFiles(files []string) (err error) {
+	if ldr.tpOutDir == utils.EmptyString {
+		return
+	}
+	for _, fName := range files {
+		if err = os.Rename(path.Join(ldr.tpInDir, fName),
+			path.Join(ldr.tpOutDir, fName)); err != nil {
+			return
+		}
+	}
+	return
+}
@@ -0,0 +1,202 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package loaders
+
+import (
+	"encoding/json"
+	"os"
+	"path"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+)
+
+func newTestTxnLoader(t *testing.T) (ldr *Loader, dm *engine.DataManager) {
+	cfg := config.NewDefaultCGRConfig()
+	ldrCfg := cfg.LoaderCfg()[0]
+	ldrCfg.Transactional = true
+	ldrCfg.TpInDir = t.TempDir()
+	ldrCfg.TpOutDir = t.TempDir()
+	dm = engine.NewDataManager(engine.NewInternalDB(nil, nil, false, cfg.DataDbCfg().Items), cfg.CacheCfg(), nil)
+	return NewLoader(dm, ldrCfg, "UTC", nil, nil, nil), dm
+}
+
+func writeTestTxnFiles(t *testing.T, dir string, files map[string]string) {
+	for fName, content := range files {
+		if err := os.WriteFile(path.Join(dir, fName), []byte(content), 0644); err != nil {
+			t.Fatal(err)
+		}
+	}
+}
+
+func TestLoaderProcessTransaction(t *testing.T) {
+	ldr, dm := newTestTxnLoader(t)
+	writeTestTxnFiles(t, ldr.tpInDir, map[string]string{
+		utils.FiltersCsv: `#Tenant,ID,Type,Element,Values,ActivationInterval
+cgrates.org,FLTR_ACNT_1001,*string,~*req.Account,1001,
+`,
+		utils.AttributesCsv: `#Tenant,ID,Contexts,FilterIDs,ActivationInterval,AttributeFilterIDs,Path,Type,Value,Blocker,Weight
+cgrates.org,ATTR_1001,*any,FLTR_ACNT_1001,,,*req.Subject,*constant,1002,false,10
+cgrates.org,ATTR_INLINE,*any,*string:~*req.Account:1003,,,*req.Subject,*constant,1004,false,10
+`,
+	})
+	if err := ldr.ProcessFolder(utils.MetaNone, utils.MetaStore, false); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.GetFilter("cgrates.org", "FLTR_ACNT_1001", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+	for _, id := range []string{"ATTR_1001", "ATTR_INLINE"} {
+		if _, err := dm.GetAttributeProfile("cgrates.org", id, false, false, utils.NonTransactional); err != nil {
+			t.Errorf("%s: %v", id, err)
+		}
+	}
+	for _, fName := range []string{utils.FiltersCsv, utils.AttributesCsv} {
+		if _, err := os.Stat(path.Join(ldr.tpOutDir, fName)); err != nil {
+			t.Error(err)
+		}
+	}
+}
+
+func TestLoaderProcessFileTransactionTrigger(t *testing.T) {
+	ldr, dm := newTestTxnLoader(t)
+	writeTestTxnFiles(t, ldr.tpInDir, map[string]string{
+		utils.FiltersCsv: `#Tenant,ID,Type,Element,Values,ActivationInterval
+cgrates.org,FLTR_ACNT_1001,*string,~*req.Account,1001,
+`,
+	})
+	// the created files are not loaded without the trigger
+	if err := ldr.processFile(ldr.tpInDir, utils.FiltersCsv); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.GetFilter("cgrates.org", "FLTR_ACNT_1001", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected: %v, received: %v", utils.ErrNotFound, err)
+	}
+	writeTestTxnFiles(t, ldr.tpInDir, map[string]string{txnTriggerFile: ""})
+	if err := ldr.processFile(ldr.tpInDir, txnTriggerFile); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := dm.GetFilter("cgrates.org", "FLTR_ACNT_1001", false, false, utils.NonTransactional); err != nil {
+		t.Error(err)
+	}
+	if _, err := os.Stat(path.Join(ldr.tpInDir, txnTriggerFile)); !os.IsNotExist(err) {
+		t.Errorf("Expected the trigger file to be removed, received: %v", err)
+	}
+}
+
+func TestLoaderProcessTransactionRejected(t *testing.T) {
+	ldr, dm := newTestTxnLoader(t)
+	writeTestTxnFiles(t, ldr.tpInDir, map[string]string{
+		utils.FiltersCsv: `#Tenant,ID,Type,Element,Values,ActivationInterval
+cgrates.org,FLTR_ACNT_1001,*string,~*req.Account,1001,
+`,
+		utils.AttributesCsv: `#Tenant,ID,Contexts,FilterIDs,ActivationInterval,AttributeFilterIDs,Path,Type,Value,Blocker,Weight
+cgrates.org,ATTR_1001,*any,FLTR_ACNT_1001,,,*req.Subject,*constant,1002,false,10
+cgrates.org,ATTR_1002,*any,FLTR_MISSING,,,*req.Subject,*constant,1003,false,10
+`,
+	})
+	if err := ldr.ProcessFolder(utils.MetaNone, utils.MetaStore, false); err == nil ||
+		!strings.HasPrefix(err.Error(), "transactional load rejected with 1 errors") {
+		t.Errorf("Expected rejected load, received: %v", err)
+	}
+	if _, err := dm.GetFilter("cgrates.org", "FLTR_ACNT_1001", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	if _, err := dm.GetAttributeProfile("cgrates.org", "ATTR_1001", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+	outFiles, err := os.ReadDir(ldr.tpOutDir)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var rpt *LoadReport
+	for _, f := range outFiles {
+		if strings.HasSuffix(f.Name(), utils.JSNSuffix) {
+			rptData, err := os.ReadFile(path.Join(ldr.tpOutDir, f.Name()))
+			if err != nil {
+				t.Fatal(err)
+			}
+			if err = json.Unmarshal(rptData, &rpt); err != nil {
+				t.Fatal(err)
+			}
+		}
+	}
+	if len(outFiles) != 3 || rpt == nil {
+		t.Fatalf("Expected the files and the report in TpOutDir, received: %+v", outFiles)
+	}
+	expErrs := []*LoadError{{
+		LoaderType: utils.MetaAttributes,
+		TenantID:   "cgrates.org:ATTR_1002",
+		Error:      "broken reference to filter: <FLTR_MISSING>",
+	}}
+	if !reflect.DeepEqual(expErrs, rpt.Errors) {
+		t.Errorf("Expected %s, received: %s", utils.ToJSON(expErrs), utils.ToJSON(rpt.Errors))
+	}
+}
+
+func TestLoaderParseTxnFilesErrors(t *testing.T) {
+	ldr, _ := newTestTxnLoader(t)
+	writeTestTxnFiles(t, ldr.tpInDir, map[string]string{
+		utils.AttributesCsv: `#Tenant,ID,Contexts,FilterIDs,ActivationInterval,AttributeFilterIDs,Path,Type,Value,Blocker,Weight
+cgrates.org,ATTR_1001,*any,,,,*req.Subject,*constant,1002,false,10
+
+# the empty line and the comments count as file lines
+cgrates.org,ATTR_1002,*any
+`,
+	})
+	tntIDs, _, ldErrs := ldr.parseTxnFiles(utils.MetaAttributes)
+	if exp := []string{"cgrates.org:ATTR_1001"}; !reflect.DeepEqual(exp, tntIDs) {
+		t.Errorf("Expected %v, received: %v", exp, tntIDs)
+	}
+	if len(ldErrs) != 1 || ldErrs[0].Line != 5 || ldErrs[0].FileName != utils.AttributesCsv {
+		t.Errorf("Expected error on line 5, received: %s", utils.ToJSON(ldErrs))
+	}
+}
+
+func TestLoaderStoreTxnProfilesRollback(t *testing.T) {
+	ldr, dm := newTestTxnLoader(t)
+	fltr := &engine.Filter{
+		Tenant: "cgrates.org",
+		ID:     "FLTR_1",
+		Rules: []*engine.FilterRule{{
+			Type:    utils.MetaString,
+			Element: "~*req.Account",
+			Values:  []string{"1001"},
+		}},
+	}
+	attr := &engine.AttributeProfile{
+		Tenant:    "cgrates.org",
+		ID:        "ATTR_1",
+		Contexts:  []string{utils.MetaAny},
+		FilterIDs: []string{"FLTR_MISSING"},
+	}
+	if ldErr := ldr.storeTxnProfiles([]*txnProfile{
+		{utils.MetaFilters, fltr.Tenant, fltr.ID, fltr},
+		{utils.MetaAttributes, attr.Tenant, attr.ID, attr},
+	}); ldErr == nil || ldErr.TenantID != "cgrates.org:ATTR_1" {
+		t.Errorf("Expected error for ATTR_1, received: %s", utils.ToJSON(ldErr))
+	}
+	if _, err := dm.GetFilter("cgrates.org", "FLTR_1", false, false, utils.NonTransactional); err != utils.ErrNotFound {
+		t.Errorf("Expected %v, received: %v", utils.ErrNotFound, err)
+	}
+}
@@ -0,0 +1,39 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package migrator
+
+import (
+	"log"
+
+	"github.com/cgrates/cgrates/engine"
+)
+
+// ExportDataDBSnapshot exports a snapshot of the input DataDB into the archive at path
+func (m *Migrator) ExportDataDBSnapshot(path string, tenants []string) (info *engine.DataDBSnapshotInfo, err error) {
+	return engine.ExportDataDBSnapshotToFile(m.dmIN.DataManager().DataDB(), path, tenants)
+}
+
+// RestoreDataDBSnapshot restores the archive at path into the output DataDB
+func (m *Migrator) RestoreDataDBSnapshot(path string, tenants []string, flush bool) (info *engine.DataDBSnapshotInfo, err error) {
+	if m.dryRun {
+		log.Print("Cannot dryRun RestoreDataDBSnapshot!")
+		return
+	}
+	return engine.RestoreDataDBSnapshotFromFile(m.dmOut.DataManager(), path, tenants, flush)
+}
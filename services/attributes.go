@@ -94,7 +94,10 @@ func (attrS *AttributeService) Start() (err error) {
 
 // Reload handles the change of config
 func (attrS *AttributeService) Reload() (err error) {
-	return // for the moment nothing to reload
+	attrS.Lock()
+	attrS.attrS.Reload()
+	attrS.Unlock()
+	return
 }
 
 // Shutdown stops the service
@@ -20,6 +20,7 @@ package services
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 	"time"
 
@@ -59,11 +60,8 @@ func (db *DataDBService) Start() (err error) {
 	db.Lock()
 	defer db.Unlock()
 	db.oldDBCfg = db.cfg.DataDbCfg().Clone()
-	d, err := engine.NewDataDBConn(db.cfg.DataDbCfg().Type,
-		db.cfg.DataDbCfg().Host, db.cfg.DataDbCfg().Port,
-		db.cfg.DataDbCfg().Name, db.cfg.DataDbCfg().User,
-		db.cfg.DataDbCfg().Password, db.cfg.GeneralCfg().DBDataEncoding,
-		db.cfg.DataDbCfg().Opts, db.cfg.DataDbCfg().Items)
+	d, err := engine.NewDataDBFromCfg(db.cfg.DataDbCfg(),
+		db.cfg.GeneralCfg().DBDataEncoding, db.cfg.DataDbCfg().Items)
 	if db.mandatoryDB() && err != nil { // Cannot configure getter database, show stopper
 		utils.Logger.Crit(fmt.Sprintf("Could not configure dataDb: %s exiting!", err))
 		return
@@ -94,7 +92,8 @@ func (db *DataDBService) Reload() (err error) {
 		db.oldDBCfg = db.cfg.DataDbCfg().Clone()
 		return
 	}
-	if db.cfg.DataDbCfg().Type == utils.Mongo {
+	if db.cfg.DataDbCfg().Type == utils.Mongo &&
+		len(db.cfg.DataDbCfg().StandbyDBs) == 0 { // with standby DataDBs the query timeout is applied on reconnect
 		var ttl time.Duration
 		if ttl, err = utils.IfaceAsDuration(db.cfg.DataDbCfg().Opts[utils.MongoQueryTimeoutCfg]); err != nil {
 			return
@@ -141,7 +140,8 @@ func (db *DataDBService) mandatoryDB() bool {
 	return db.cfg.RalsCfg().Enabled || db.cfg.SchedulerCfg().Enabled || db.cfg.ChargerSCfg().Enabled ||
 		db.cfg.AttributeSCfg().Enabled || db.cfg.ResourceSCfg().Enabled || db.cfg.StatSCfg().Enabled ||
 		db.cfg.ThresholdSCfg().Enabled || db.cfg.RouteSCfg().Enabled || db.cfg.DispatcherSCfg().Enabled ||
-		db.cfg.LoaderCfg().Enabled() || db.cfg.ApierCfg().Enabled || db.cfg.AnalyzerSCfg().Enabled
+		db.cfg.LoaderCfg().Enabled() || db.cfg.ApierCfg().Enabled || db.cfg.AnalyzerSCfg().Enabled ||
+		db.cfg.FraudSCfg().Enabled
 }
 
 // GetDM returns the DataManager
@@ -158,7 +158,14 @@ func (db *DataDBService) needsConnectionReload() bool {
 		db.oldDBCfg.Name != db.cfg.DataDbCfg().Name ||
 		db.oldDBCfg.Port != db.cfg.DataDbCfg().Port ||
 		db.oldDBCfg.User != db.cfg.DataDbCfg().User ||
-		db.oldDBCfg.Password != db.cfg.DataDbCfg().Password {
+		db.oldDBCfg.Password != db.cfg.DataDbCfg().Password ||
+		db.oldDBCfg.FailoverInterval != db.cfg.DataDbCfg().FailoverInterval ||
+		db.oldDBCfg.FailoverFailures != db.cfg.DataDbCfg().FailoverFailures ||
+		!reflect.DeepEqual(db.oldDBCfg.StandbyDBs, db.cfg.DataDbCfg().StandbyDBs) {
+		return true
+	}
+	if len(db.cfg.DataDbCfg().StandbyDBs) != 0 &&
+		db.oldDBCfg.Opts[utils.MongoQueryTimeoutCfg] != db.cfg.DataDbCfg().Opts[utils.MongoQueryTimeoutCfg] {
 		return true
 	}
 	if db.cfg.DataDbCfg().Type == utils.Internal { // in case of internal recreate the db using the new config
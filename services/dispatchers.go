@@ -87,6 +87,7 @@ func (dspS *DispatcherService) Start() (err error) {
 	defer dspS.Unlock()
 
 	dspS.dspS = dispatchers.NewDispatcherService(datadb, dspS.cfg, fltrS, dspS.connMgr)
+	dspS.dspS.StartLoop()
 
 	// for the moment we dispable Apier through dispatcher
 	// until we figured out a better sollution in case of gob server
@@ -97,6 +98,9 @@ func (dspS *DispatcherService) Start() (err error) {
 	dspS.server.RpcRegisterName(utils.ThresholdSv1,
 		v1.NewDispatcherThresholdSv1(dspS.dspS))
 
+	dspS.server.RpcRegisterName(utils.FraudSv1,
+		v1.NewDispatcherFraudSv1(dspS.dspS))
+
 	dspS.server.RpcRegisterName(utils.StatSv1,
 		v1.NewDispatcherStatSv1(dspS.dspS))
 
@@ -149,7 +153,10 @@ func (dspS *DispatcherService) Start() (err error) {
 
 // Reload handles the change of config
 func (dspS *DispatcherService) Reload() (err error) {
-	return // for the momment nothing to reload
+	dspS.Lock()
+	dspS.dspS.Reload()
+	dspS.Unlock()
+	return
 }
 
 // Shutdown stops the service
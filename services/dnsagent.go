@@ -20,6 +20,7 @@ package services
 
 import (
 	"fmt"
+	"reflect"
 	"sync"
 
 	"github.com/cgrates/cgrates/agents"
@@ -53,7 +54,7 @@ type DNSAgent struct {
 	connMgr *engine.ConnManager
 	srvDep  map[string]*sync.WaitGroup
 
-	oldListen string
+	oldCfg *config.DNSAgentCfg // the config the servers were started with
 }
 
 // Start should handle the sercive start
@@ -66,7 +67,7 @@ func (dns *DNSAgent) Start() (err error) {
 
 	dns.Lock()
 	defer dns.Unlock()
-	dns.oldListen = dns.cfg.DNSAgentCfg().Listen
+	dns.oldCfg = dns.cfg.DNSAgentCfg().Clone()
 	dns.dns, err = agents.NewDNSAgent(dns.cfg, filterS, dns.connMgr)
 	if err != nil {
 		utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.DNSAgent, err.Error()))
@@ -79,15 +80,15 @@ func (dns *DNSAgent) Start() (err error) {
 
 // Reload handles the change of config
 func (dns *DNSAgent) Reload() (err error) {
-	if dns.oldListen == dns.cfg.DNSAgentCfg().Listen {
-		return
-	}
 	dns.Lock()
 	defer dns.Unlock()
+	if !dnsServersChanged(dns.oldCfg, dns.cfg.DNSAgentCfg()) {
+		return
+	}
 	if err = dns.dns.Shutdown(); err != nil {
 		return
 	}
-	dns.oldListen = dns.cfg.DNSAgentCfg().Listen
+	dns.oldCfg = dns.cfg.DNSAgentCfg().Clone()
 	if err = dns.dns.Reload(); err != nil {
 		return
 	}
@@ -95,6 +96,19 @@ func (dns *DNSAgent) Reload() (err error) {
 	return
 }
 
+// dnsServersChanged returns true if the listeners or the upstream servers need to be restarted
+// the request processors are read from config on each request
+func dnsServersChanged(oldCfg, newCfg *config.DNSAgentCfg) bool {
+	return oldCfg == nil ||
+		oldCfg.Listen != newCfg.Listen ||
+		oldCfg.ListenNet != newCfg.ListenNet ||
+		!reflect.DeepEqual(oldCfg.Listeners, newCfg.Listeners) ||
+		!reflect.DeepEqual(oldCfg.UpstreamServers, newCfg.UpstreamServers) ||
+		oldCfg.UpstreamNet != newCfg.UpstreamNet ||
+		oldCfg.UpstreamTimeout != newCfg.UpstreamTimeout ||
+		oldCfg.UpstreamCacheLimit != newCfg.UpstreamCacheLimit
+}
+
 func (dns *DNSAgent) listenAndServe() (err error) {
 	if err = dns.dns.ListenAndServe(); err != nil {
 		utils.Logger.Err(fmt.Sprintf("<%s> error: <%s>", utils.DNSAgent, err.Error()))
@@ -203,7 +203,7 @@ func TestDNSAgentReload5(t *testing.T) {
 	if err != nil {
 		t.Fatalf("\nExpected <%+v>, \nReceived <%+v>", nil, err)
 	}
-	srv.(*DNSAgent).oldListen = "127.0.0.1:2093"
+	srv.(*DNSAgent).oldCfg.Listen = "127.0.0.1:2093"
 	time.Sleep(10 * time.Millisecond)
 	runtime.Gosched()
 	runtime.Gosched()
@@ -230,7 +230,7 @@ func TestDNSAgentReload6(t *testing.T) {
 	if err != nil {
 		t.Fatalf("\nExpected <%+v>, \nReceived <%+v>", nil, err)
 	}
-	srv.(*DNSAgent).oldListen = "127.0.0.1:2093"
+	srv.(*DNSAgent).oldCfg.Listen = "127.0.0.1:2093"
 	cfg.DNSAgentCfg().ListenNet = "tls"
 	cfg.TLSCfg().ServerCerificate = "bad_certificate"
 	cfg.TLSCfg().ServerKey = "bad_key"
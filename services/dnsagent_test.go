@@ -70,3 +70,31 @@ func TestDNSAgentCoverage(t *testing.T) {
 		t.Errorf("Expected service to be down")
 	}
 }
+
+func TestDNSServersChanged(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	oldCfg := cfg.DNSAgentCfg().Clone()
+	if dnsServersChanged(oldCfg, cfg.DNSAgentCfg()) {
+		t.Error("Expected the servers unchanged")
+	}
+	cfg.DNSAgentCfg().RequestProcessors = append(cfg.DNSAgentCfg().RequestProcessors,
+		&config.RequestProcessor{ID: "cgrates"})
+	if dnsServersChanged(oldCfg, cfg.DNSAgentCfg()) {
+		t.Error("Expected the servers unchanged when only the request processors change")
+	}
+	cfg.DNSAgentCfg().Listeners = append(cfg.DNSAgentCfg().Listeners,
+		&config.DNSListener{Address: "127.0.0.1:2054", Network: utils.TCP})
+	if !dnsServersChanged(oldCfg, cfg.DNSAgentCfg()) {
+		t.Error("Expected the servers changed on new listener")
+	}
+	oldCfg = cfg.DNSAgentCfg().Clone()
+	cfg.DNSAgentCfg().UpstreamServers = []string{"8.8.8.8:53"}
+	if !dnsServersChanged(oldCfg, cfg.DNSAgentCfg()) {
+		t.Error("Expected the servers changed on new upstream servers")
+	}
+	oldCfg = cfg.DNSAgentCfg().Clone()
+	cfg.DNSAgentCfg().UpstreamCacheLimit = 100
+	if !dnsServersChanged(oldCfg, cfg.DNSAgentCfg()) {
+		t.Error("Expected the servers changed on new upstream cache limit")
+	}
+}
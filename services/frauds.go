@@ -0,0 +1,126 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"sync"
+
+	v1 "github.com/cgrates/cgrates/apier/v1"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// NewFraudService returns the Fraud Service
+func NewFraudService(cfg *config.CGRConfig, dm *DataDBService,
+	filterSChan chan *engine.FilterS, server *cores.Server,
+	internalFraudSChan chan rpcclient.ClientConnector,
+	connMgr *engine.ConnManager, anz *AnalyzerService,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &FraudService{
+		connChan:    internalFraudSChan,
+		cfg:         cfg,
+		dm:          dm,
+		filterSChan: filterSChan,
+		server:      server,
+		connMgr:     connMgr,
+		anz:         anz,
+		srvDep:      srvDep,
+	}
+}
+
+// FraudService implements Service interface
+type FraudService struct {
+	sync.RWMutex
+	cfg         *config.CGRConfig
+	dm          *DataDBService
+	filterSChan chan *engine.FilterS
+	server      *cores.Server
+	connMgr     *engine.ConnManager
+
+	fS       *engine.FraudService
+	rpc      *v1.FraudSv1
+	connChan chan rpcclient.ClientConnector
+	anz      *AnalyzerService
+	srvDep   map[string]*sync.WaitGroup
+}
+
+// Start should handle the sercive start
+func (fS *FraudService) Start() (err error) {
+	if fS.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+	fS.srvDep[utils.DataDB].Add(1)
+
+	filterS := <-fS.filterSChan
+	fS.filterSChan <- filterS
+	// the actions executed on the accounts need the DataDB
+	dbchan := fS.dm.GetDMChan()
+	datadb := <-dbchan
+	dbchan <- datadb
+
+	fS.Lock()
+	defer fS.Unlock()
+	fS.fS = engine.NewFraudService(fS.cfg, filterS, fS.connMgr)
+
+	utils.Logger.Info(fmt.Sprintf("<%s> starting <%s> subsystem", utils.CoreS, utils.FraudS))
+	fS.rpc = v1.NewFraudSv1(fS.fS)
+	if !fS.cfg.DispatcherSCfg().Enabled {
+		fS.server.RpcRegister(fS.rpc)
+	}
+	fS.connChan <- fS.anz.GetInternalCodec(fS.rpc, utils.FraudS)
+	return
+}
+
+// Reload handles the change of config
+func (fS *FraudService) Reload() (err error) {
+	return // the rules are read out of config on each event
+}
+
+// Shutdown stops the service
+func (fS *FraudService) Shutdown() (err error) {
+	defer fS.srvDep[utils.DataDB].Done()
+	fS.Lock()
+	defer fS.Unlock()
+	fS.fS = nil
+	fS.rpc = nil
+	<-fS.connChan
+	return
+}
+
+// IsRunning returns if the service is running
+func (fS *FraudService) IsRunning() bool {
+	fS.RLock()
+	defer fS.RUnlock()
+	return fS != nil && fS.fS != nil
+}
+
+// ServiceName returns the service name
+func (fS *FraudService) ServiceName() string {
+	return utils.FraudS
+}
+
+// ShouldRun returns if the service should be running
+func (fS *FraudService) ShouldRun() bool {
+	return fS.cfg.FraudSCfg().Enabled
+}
@@ -0,0 +1,67 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package services
+
+import (
+	"reflect"
+	"sync"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// TestFraudSCoverage for cover testing
+func TestFraudSCoverage(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	filterSChan := make(chan *engine.FilterS, 1)
+	filterSChan <- nil
+	shdChan := utils.NewSyncedChan()
+	server := cores.NewServer(nil)
+	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
+	anz := NewAnalyzerService(cfg, server, filterSChan, shdChan, make(chan rpcclient.ClientConnector, 1), srvDep)
+	db := NewDataDBService(cfg, nil, srvDep)
+	fS := NewFraudService(cfg, db, filterSChan, server, make(chan rpcclient.ClientConnector, 1), nil, anz, srvDep)
+	if fS.IsRunning() {
+		t.Errorf("Expected service to be down")
+	}
+	fS2 := &FraudService{
+		cfg:         cfg,
+		dm:          db,
+		filterSChan: filterSChan,
+		server:      server,
+		fS:          engine.NewFraudService(cfg, &engine.FilterS{}, nil),
+		connChan:    make(chan rpcclient.ClientConnector, 1),
+		anz:         anz,
+		srvDep:      srvDep,
+	}
+	if !fS2.IsRunning() {
+		t.Errorf("Expected service to be running")
+	}
+	serviceName := fS2.ServiceName()
+	if !reflect.DeepEqual(serviceName, utils.FraudS) {
+		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.FraudS, serviceName)
+	}
+	shouldRun := fS2.ShouldRun()
+	if !reflect.DeepEqual(shouldRun, false) {
+		t.Errorf("\nExpecting <false>,\n Received <%+v>", shouldRun)
+	}
+}
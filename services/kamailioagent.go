@@ -32,22 +32,24 @@ import (
 )
 
 // NewKamailioAgent returns the Kamailio Agent
-func NewKamailioAgent(cfg *config.CGRConfig,
+func NewKamailioAgent(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
 	shdChan *utils.SyncedChan, connMgr *engine.ConnManager,
 	srvDep map[string]*sync.WaitGroup) servmanager.Service {
 	return &KamailioAgent{
-		cfg:     cfg,
-		shdChan: shdChan,
-		connMgr: connMgr,
-		srvDep:  srvDep,
+		cfg:         cfg,
+		filterSChan: filterSChan,
+		shdChan:     shdChan,
+		connMgr:     connMgr,
+		srvDep:      srvDep,
 	}
 }
 
 // KamailioAgent implements Agent interface
 type KamailioAgent struct {
 	sync.RWMutex
-	cfg     *config.CGRConfig
-	shdChan *utils.SyncedChan
+	cfg         *config.CGRConfig
+	filterSChan chan *engine.FilterS
+	shdChan     *utils.SyncedChan
 
 	kam     *agents.KamailioAgent
 	connMgr *engine.ConnManager
@@ -60,10 +62,14 @@ func (kam *KamailioAgent) Start() (err error) {
 		return utils.ErrServiceAlreadyRunning
 	}
 
+	filterS := <-kam.filterSChan
+	kam.filterSChan <- filterS
+
 	kam.Lock()
 	defer kam.Unlock()
 
-	kam.kam = agents.NewKamailioAgent(kam.cfg.KamAgentCfg(), kam.connMgr,
+	kam.kam = agents.NewKamailioAgent(kam.cfg.KamAgentCfg(), kam.connMgr, filterS,
+		kam.cfg.GeneralCfg().DefaultTenant,
 		utils.FirstNonEmpty(kam.cfg.KamAgentCfg().Timezone, kam.cfg.GeneralCfg().DefaultTimezone))
 
 	go func(k *agents.KamailioAgent) {
@@ -60,7 +60,7 @@ func TestKamailioAgentReload(t *testing.T) {
 	anz := NewAnalyzerService(cfg, server, filterSChan, shdChan, make(chan rpcclient.ClientConnector, 1), srvDep)
 	sS := NewSessionService(cfg, db, server, make(chan rpcclient.ClientConnector, 1),
 		shdChan, nil, anz, srvDep)
-	srv := NewKamailioAgent(cfg, shdChan, nil, srvDep)
+	srv := NewKamailioAgent(cfg, filterSChan, shdChan, nil, srvDep)
 	engine.NewConnManager(cfg, nil)
 	srvMngr.AddServices(srv, sS,
 		NewLoaderService(cfg, db, filterSChan, server, make(chan rpcclient.ClientConnector, 1), nil, anz, srvDep), db)
@@ -91,7 +91,7 @@ func TestKamailioAgentReload(t *testing.T) {
 		Timezone:      "Local",
 	}
 
-	srv.(*KamailioAgent).kam = agents.NewKamailioAgent(kaCfg, nil, "")
+	srv.(*KamailioAgent).kam = agents.NewKamailioAgent(kaCfg, nil, nil, "cgrates.org", "")
 
 	err := srv.Reload()
 	if err != nil {
@@ -114,7 +114,7 @@ func TestKamailioAgentReload2(t *testing.T) {
 	filterSChan <- nil
 	shdChan := utils.NewSyncedChan()
 	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
-	srv := NewKamailioAgent(cfg, shdChan, nil, srvDep)
+	srv := NewKamailioAgent(cfg, filterSChan, shdChan, nil, srvDep)
 	srvKam := &agents.KamailioAgent{}
 	if srv.IsRunning() {
 		t.Fatalf("Expected service to be down")
@@ -139,7 +139,7 @@ func TestKamailioAgentReload3(t *testing.T) {
 	filterSChan <- nil
 	shdChan := utils.NewSyncedChan()
 	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
-	srv := NewKamailioAgent(cfg, shdChan, nil, srvDep)
+	srv := NewKamailioAgent(cfg, filterSChan, shdChan, nil, srvDep)
 	srvKam := &agents.KamailioAgent{}
 	if srv.IsRunning() {
 		t.Fatalf("Expected service to be down")
@@ -43,7 +43,7 @@ func TestKamailioAgentCoverage(t *testing.T) {
 	cacheSChan := make(chan rpcclient.ClientConnector, 1)
 	cacheSChan <- chS
 	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
-	srv := NewKamailioAgent(cfg, shdChan, nil, srvDep)
+	srv := NewKamailioAgent(cfg, filterSChan, shdChan, nil, srvDep)
 	if srv.IsRunning() {
 		t.Errorf("Expected service to be down")
 	}
@@ -91,6 +91,7 @@ func (db *StorDBService) Reload() (err error) {
 		}
 		db.db.Close()
 		db.db = d
+		engine.SetCdrStorage(db.db)
 		db.oldDBCfg = db.cfg.StorDbCfg().Clone()
 		db.sync() // sync only if needed
 		return
@@ -0,0 +1,135 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+
+package services
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+
+	"github.com/cgrates/cgrates/agents"
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/servmanager"
+	"github.com/cgrates/cgrates/utils"
+)
+
+// NewWebSocketAgent returns the WebSocket Agent
+func NewWebSocketAgent(cfg *config.CGRConfig, filterSChan chan *engine.FilterS,
+	server *cores.Server, connMgr *engine.ConnManager,
+	srvDep map[string]*sync.WaitGroup) servmanager.Service {
+	return &WebSocketAgent{
+		cfg:         cfg,
+		filterSChan: filterSChan,
+		server:      server,
+		connMgr:     connMgr,
+		urls:        make(utils.StringSet),
+		srvDep:      srvDep,
+	}
+}
+
+// WebSocketAgent implements Agent interface
+type WebSocketAgent struct {
+	sync.RWMutex
+	cfg         *config.CGRConfig
+	filterSChan chan *engine.FilterS
+	server      *cores.Server
+
+	wsa     *agents.WebSocketAgent
+	connMgr *engine.ConnManager
+
+	// the handlers can not be removed from the HTTP server
+	// so keep the registered URLs and serve them only while running
+	urls   utils.StringSet
+	url    string
+	srvDep map[string]*sync.WaitGroup
+}
+
+// Start should handle the sercive start
+func (wa *WebSocketAgent) Start() (err error) {
+	if wa.IsRunning() {
+		return utils.ErrServiceAlreadyRunning
+	}
+
+	filterS := <-wa.filterSChan
+	wa.filterSChan <- filterS
+
+	wa.Lock()
+	defer wa.Unlock()
+	wa.wsa = agents.NewWebSocketAgent(wa.cfg, filterS, wa.connMgr)
+	wa.registerURL(wa.cfg.WebSocketAgentCfg().URL)
+	utils.Logger.Info(fmt.Sprintf("<%s> successfully started WebSocketAgent on <%s>",
+		utils.WebSocketAgent, wa.url))
+	return
+}
+
+// registerURL registers the handler on the HTTP server if not already done
+func (wa *WebSocketAgent) registerURL(url string) {
+	wa.url = url
+	if wa.urls.Has(url) {
+		return
+	}
+	wa.urls.Add(url)
+	wa.server.RegisterHttpHandler(url, http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		wa.RLock()
+		wsa := wa.wsa
+		active := wa.url == url
+		wa.RUnlock()
+		if wsa == nil || !active {
+			http.NotFound(w, r)
+			return
+		}
+		wsa.ServeHTTP(w, r)
+	}))
+}
+
+// Reload handles the change of config
+func (wa *WebSocketAgent) Reload() (err error) {
+	wa.Lock()
+	wa.registerURL(wa.cfg.WebSocketAgentCfg().URL)
+	wa.Unlock()
+	return // the rest of the config is read on each message
+}
+
+// Shutdown stops the service
+func (wa *WebSocketAgent) Shutdown() (err error) {
+	wa.Lock()
+	defer wa.Unlock()
+	err = wa.wsa.Shutdown()
+	wa.wsa = nil
+	return
+}
+
+// IsRunning returns if the service is running
+func (wa *WebSocketAgent) IsRunning() bool {
+	wa.RLock()
+	defer wa.RUnlock()
+	return wa != nil && wa.wsa != nil
+}
+
+// ServiceName returns the service name
+func (wa *WebSocketAgent) ServiceName() string {
+	return utils.WebSocketAgent
+}
+
+// ShouldRun returns if the service should be running
+func (wa *WebSocketAgent) ShouldRun() bool {
+	return wa.cfg.WebSocketAgentCfg().Enabled
+}
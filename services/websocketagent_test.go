@@ -0,0 +1,73 @@
+/*
+Real-time Online/Offline Charging System (OCS) for Telecom & ISP environments
+Copyright (C) ITsysCOM GmbH
+
+This program is free software: you can redistribute it and/or modify
+it under the terms of the GNU General Public License as published by
+the Free Software Foundation, either version 3 of the License, or
+(at your option) any later version.
+
+This program is distributed in the hope that it will be useful,
+but WITHOUT ANY WARRANTY; without even the implied warranty of
+MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+GNU General Public License for more details.
+
+You should have received a copy of the GNU General Public License
+along with this program.  If not, see <http://www.gnu.org/licenses/>
+*/
+package services
+
+import (
+	"sync"
+	"testing"
+
+	"github.com/cgrates/cgrates/config"
+	"github.com/cgrates/cgrates/cores"
+	"github.com/cgrates/cgrates/engine"
+	"github.com/cgrates/cgrates/utils"
+	"github.com/cgrates/rpcclient"
+)
+
+// TestWebSocketAgentCoverage for cover testing
+func TestWebSocketAgentCoverage(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	server := cores.NewServer(nil)
+	filterSChan := make(chan *engine.FilterS, 1)
+	filterSChan <- nil
+	srvDep := map[string]*sync.WaitGroup{utils.DataDB: new(sync.WaitGroup)}
+	rpcInternal := map[string]chan rpcclient.ClientConnector{}
+	cM := engine.NewConnManager(cfg, rpcInternal)
+	srv := NewWebSocketAgent(cfg, filterSChan, server, cM, srvDep)
+	if srv.IsRunning() {
+		t.Errorf("Expected service to be down")
+	}
+	if srv.ShouldRun() {
+		t.Errorf("Expected service to not run with the default config")
+	}
+	if srvName := srv.ServiceName(); srvName != utils.WebSocketAgent {
+		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.WebSocketAgent, srvName)
+	}
+	if err := srv.Start(); err != nil {
+		t.Fatal(err)
+	}
+	if !srv.IsRunning() {
+		t.Errorf("Expected service to be running")
+	}
+	if err := srv.Start(); err != utils.ErrServiceAlreadyRunning {
+		t.Errorf("\nExpecting <%+v>,\n Received <%+v>", utils.ErrServiceAlreadyRunning, err)
+	}
+	cfg.WebSocketAgentCfg().URL = "/ws"
+	if err := srv.Reload(); err != nil {
+		t.Error(err)
+	}
+	wsa := srv.(*WebSocketAgent)
+	if wsa.url != "/ws" || !wsa.urls.Has("/ws") || !wsa.urls.Has("/websocket_agent") {
+		t.Errorf("Unexpected URLs: %s, active: %s", utils.ToJSON(wsa.urls), wsa.url)
+	}
+	if err := srv.Shutdown(); err != nil {
+		t.Error(err)
+	}
+	if srv.IsRunning() {
+		t.Errorf("Expected service to be down")
+	}
+}
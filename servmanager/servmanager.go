@@ -192,6 +192,8 @@ func (srvMngr *ServiceManager) handleReload() {
 			go srvMngr.reloadService(utils.ChargerS)
 		case <-srvMngr.GetConfig().GetReloadChan(config.THRESHOLDS_JSON):
 			go srvMngr.reloadService(utils.ThresholdS)
+		case <-srvMngr.GetConfig().GetReloadChan(config.FraudSJson):
+			go srvMngr.reloadService(utils.FraudS)
 		case <-srvMngr.GetConfig().GetReloadChan(config.STATS_JSON):
 			go srvMngr.reloadService(utils.StatS)
 		case <-srvMngr.GetConfig().GetReloadChan(config.RESOURCES_JSON):
@@ -227,6 +229,8 @@ func (srvMngr *ServiceManager) handleReload() {
 			go srvMngr.reloadService(utils.DiameterAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.HttpAgentJson):
 			go srvMngr.reloadService(utils.HTTPAgent)
+		case <-srvMngr.GetConfig().GetReloadChan(config.WebSocketAgentJson):
+			go srvMngr.reloadService(utils.WebSocketAgent)
 		case <-srvMngr.GetConfig().GetReloadChan(config.LoaderJson):
 			go srvMngr.reloadService(utils.LoaderS)
 		case <-srvMngr.GetConfig().GetReloadChan(config.AnalyzerCfgJson):
@@ -20,6 +20,7 @@ package sessions
 
 import (
 	"errors"
+	"sort"
 	"strings"
 	"time"
 
@@ -299,3 +300,44 @@ func getDerivedMaxUsage(maxUsages map[string]time.Duration, derivedReply bool) (
 	out[utils.MetaRaw] = maxUsage
 	return
 }
+
+// getLowBalanceThresholds returns the low balance warning thresholds, sorted descending,
+// taking them from the first options containing *sessionsLowBalanceThresholds
+// the default(MinDurLowBalance) is used if none of the options have the thresholds
+func getLowBalanceThresholds(dflt time.Duration, opts ...engine.MapEvent) (thds []time.Duration, err error) {
+	var strThds []string
+	for _, opt := range opts {
+		thdsIface, has := opt[utils.OptsLowBalanceThresholds]
+		if !has {
+			continue
+		}
+		if thdsStr, canCast := thdsIface.(string); canCast {
+			strThds = strings.Split(thdsStr, utils.InfieldSep)
+		} else if strThds, err = utils.IfaceAsSliceString(thdsIface); err != nil {
+			return
+		}
+		break
+	}
+	if strThds == nil {
+		if dflt != 0 {
+			thds = []time.Duration{dflt}
+		}
+		return
+	}
+	thds = make([]time.Duration, 0, len(strThds))
+	for _, strThd := range strThds {
+		if strThd = strings.TrimSpace(strThd); strThd == utils.EmptyString {
+			continue
+		}
+		var thd time.Duration
+		if thd, err = utils.ParseDurationWithNanosecs(strThd); err != nil {
+			return nil, err
+		}
+		if thd <= 0 {
+			continue
+		}
+		thds = append(thds, thd)
+	}
+	sort.Slice(thds, func(i, j int) bool { return thds[i] > thds[j] })
+	return
+}
@@ -373,3 +373,43 @@ func TestGetDerivedMaxUsage(t *testing.T) {
 		t.Errorf("Expected %s received %s", utils.ToJSON(exp), utils.ToJSON(rply))
 	}
 }
+
+func TestGetLowBalanceThresholds(t *testing.T) {
+	if rply, err := getLowBalanceThresholds(0); err != nil {
+		t.Error(err)
+	} else if rply != nil {
+		t.Errorf("Expected no thresholds, received %v", rply)
+	}
+	exp := []time.Duration{time.Second}
+	if rply, err := getLowBalanceThresholds(time.Second, engine.MapEvent{}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %v received %v", exp, rply)
+	}
+	exp = []time.Duration{5 * time.Minute, time.Minute, 10 * time.Second}
+	if rply, err := getLowBalanceThresholds(time.Second,
+		engine.MapEvent{utils.OptsLowBalanceThresholds: "1m;10s;5m"},
+		engine.MapEvent{utils.OptsLowBalanceThresholds: "1h"}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %v received %v", exp, rply)
+	}
+	if rply, err := getLowBalanceThresholds(time.Second,
+		engine.MapEvent{},
+		engine.MapEvent{utils.OptsLowBalanceThresholds: []interface{}{"10s", "5m", "1m", "0s"}}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %v received %v", exp, rply)
+	}
+	exp = []time.Duration{}
+	if rply, err := getLowBalanceThresholds(time.Second,
+		engine.MapEvent{utils.OptsLowBalanceThresholds: []string{}}); err != nil {
+		t.Error(err)
+	} else if !reflect.DeepEqual(exp, rply) {
+		t.Errorf("Expected %v received %v", exp, rply)
+	}
+	if _, err := getLowBalanceThresholds(time.Second,
+		engine.MapEvent{utils.OptsLowBalanceThresholds: "1m;notADuration"}); err == nil {
+		t.Error("Expected error for invalid threshold")
+	}
+}
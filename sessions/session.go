@@ -264,6 +264,12 @@ type SRun struct {
 	LastUsage     time.Duration // last requested Duration
 	TotalUsage    time.Duration // sum of lastUsage
 	NextAutoDebit *time.Time
+
+	LowBalanceThresholds []time.Duration // low balance warnings not yet sent, sorted descending
+	LowBalanceCheckUsage time.Duration   // TotalUsage from which the remaining balance is queried again
+
+	lbTracked bool   // the debits of the account are counted for the low balance forecast
+	lbDebits  uint64 // debits of the account at the last low balance check
 }
 
 // Clone returns the cloned version of SRun
@@ -273,6 +279,8 @@ func (sr *SRun) Clone() (clsr *SRun) {
 		ExtraDuration: sr.ExtraDuration,
 		LastUsage:     sr.LastUsage,
 		TotalUsage:    sr.TotalUsage,
+
+		LowBalanceCheckUsage: sr.LowBalanceCheckUsage,
 	}
 	if sr.CD != nil {
 		clsr.CD = sr.CD.Clone()
@@ -283,6 +291,10 @@ func (sr *SRun) Clone() (clsr *SRun) {
 	if sr.NextAutoDebit != nil {
 		clsr.NextAutoDebit = utils.TimePointer(*sr.NextAutoDebit)
 	}
+	if sr.LowBalanceThresholds != nil {
+		clsr.LowBalanceThresholds = make([]time.Duration, len(sr.LowBalanceThresholds))
+		copy(clsr.LowBalanceThresholds, sr.LowBalanceThresholds)
+	}
 	return
 }
 
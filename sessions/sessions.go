@@ -23,6 +23,7 @@ import (
 	"fmt"
 	"math/rand"
 	"runtime"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -59,6 +60,7 @@ func NewSessionS(cgrCfg *config.CGRConfig,
 		pSessions:     make(map[string]*Session),
 		pSessionsIdx:  make(map[string]map[string]map[string]utils.StringSet),
 		pSessionsRIdx: make(map[string][]*riFieldNameVal),
+		lbDebits:      make(map[string]*lowBalanceDebits),
 	}
 }
 
@@ -91,6 +93,15 @@ type SessionS struct {
 	pSIMux        sync.RWMutex                                     // protects pSessionsIdx
 	pSessionsIdx  map[string]map[string]map[string]utils.StringSet // map[fieldName]map[fieldValue][cgrID]utils.StringSet[runID]sID
 	pSessionsRIdx map[string][]*riFieldNameVal                     // reverse indexes for passive sessions, used on remove
+
+	lbDbtsMux sync.Mutex                   // protects lbDebits
+	lbDebits  map[string]*lowBalanceDebits // debits of the accounts with low balance forecasts, indexed on tenant:account
+}
+
+// lowBalanceDebits counts the debits done on an account while session runs forecast its low balance
+type lowBalanceDebits struct {
+	debits uint64 // debits done by all the sessions of the account
+	sRuns  int    // session runs relying on the debits count
 }
 
 // ListenAndServe starts the service and binds it to the listen loop
@@ -483,6 +494,7 @@ func (sS *SessionS) debitSession(s *Session, sRunIdx int, dur time.Duration,
 			return 0, err
 		}
 	}
+	sS.countLowBalanceDebit(sr.CD.Tenant, sr.CD.Account)
 	sr.CD.TimeEnd = cc.GetEndTime() // set debited timeEnd
 	ccDuration := cc.GetDuration()
 	if ccDuration > rDur {
@@ -551,7 +563,10 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 			return
 		}
 		var maxDebit time.Duration
-		if maxDebit, err = sS.debitSession(s, sRunIdx, dbtIvl, nil); err != nil {
+		if maxDebit, err = sS.splitBalanceUsage(s, s.SRuns[sRunIdx], dbtIvl); err == nil {
+			maxDebit, err = sS.debitSession(s, sRunIdx, maxDebit, nil)
+		}
+		if err != nil {
 			utils.Logger.Warning(
 				fmt.Sprintf("<%s> could not complete debit operation on session: <%s>, error: <%s>",
 					utils.SessionS, s.cgrID(), err.Error()))
@@ -581,14 +596,7 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 		}
 		debitStop := s.debitStop // avoid concurrency with endSession
 		s.SRuns[sRunIdx].NextAutoDebit = utils.TimePointer(time.Now().Add(dbtIvl))
-		if maxDebit < dbtIvl && sS.cgrCfg.SessionSCfg().MinDurLowBalance != time.Duration(0) { // warn client for low balance
-			if sS.cgrCfg.SessionSCfg().MinDurLowBalance >= dbtIvl {
-				utils.Logger.Warning(fmt.Sprintf("<%s> can not run warning for the session: <%s> since the remaining time:<%s> is higher than the debit interval:<%s>.",
-					utils.SessionS, s.cgrID(), sS.cgrCfg.SessionSCfg().MinDurLowBalance, dbtIvl))
-			} else if maxDebit <= sS.cgrCfg.SessionSCfg().MinDurLowBalance {
-				go sS.warnSession(s.ClientConnID, s.EventStart.Clone())
-			}
-		}
+		sS.warnLowBalance(s, sRunIdx, maxDebit, dbtIvl, debitStop) // warn client for low balance
 		s.Unlock()
 		sS.replicateSessions(s.CGRID, false, sS.cgrCfg.SessionSCfg().ReplicationConns)
 		if maxDebit < dbtIvl { // disconnect faster
@@ -630,6 +638,159 @@ func (sS *SessionS) debitLoopSession(s *Session, sRunIdx int,
 	}
 }
 
+// lowBalanceLookAheadDebits is the number of debit intervals, on top of the highest threshold,
+// the remaining balance is queried for so the next queries are skipped while it is not reached
+const lowBalanceLookAheadDebits = 10
+
+// countLowBalanceDebit counts the debit of the account if session runs forecast its low balance
+func (sS *SessionS) countLowBalanceDebit(tnt, acnt string) {
+	sS.lbDbtsMux.Lock()
+	if lbDbts, has := sS.lbDebits[utils.ConcatenatedKey(tnt, acnt)]; has {
+		lbDbts.debits++
+	}
+	sS.lbDbtsMux.Unlock()
+}
+
+// onlyOwnLowBalanceDebit returns true if the account was debited only by the last debit of the session run
+// since its low balance forecast, so the forecast is still valid
+func (sS *SessionS) onlyOwnLowBalanceDebit(sr *SRun) (own bool) {
+	if !sr.lbTracked { // i.e. relocated session
+		return
+	}
+	sS.lbDbtsMux.Lock()
+	lbDbts := sS.lbDebits[utils.ConcatenatedKey(sr.CD.Tenant, sr.CD.Account)]
+	own = lbDbts.debits == sr.lbDebits+1
+	sr.lbDebits = lbDbts.debits
+	sS.lbDbtsMux.Unlock()
+	return
+}
+
+// trackLowBalanceDebits starts counting the debits of the session run account after a low balance forecast
+func (sS *SessionS) trackLowBalanceDebits(sr *SRun) {
+	key := utils.ConcatenatedKey(sr.CD.Tenant, sr.CD.Account)
+	sS.lbDbtsMux.Lock()
+	lbDbts, has := sS.lbDebits[key]
+	if !has {
+		lbDbts = new(lowBalanceDebits)
+		sS.lbDebits[key] = lbDbts
+	}
+	if !sr.lbTracked {
+		sr.lbTracked = true
+		lbDbts.sRuns++
+	}
+	sr.lbDebits = lbDbts.debits
+	sS.lbDbtsMux.Unlock()
+}
+
+// untrackLowBalanceDebits stops counting the debits of the session run account if no other session run needs them
+func (sS *SessionS) untrackLowBalanceDebits(sr *SRun) {
+	if !sr.lbTracked {
+		return
+	}
+	sr.lbTracked = false
+	key := utils.ConcatenatedKey(sr.CD.Tenant, sr.CD.Account)
+	sS.lbDbtsMux.Lock()
+	if lbDbts, has := sS.lbDebits[key]; has {
+		if lbDbts.sRuns--; lbDbts.sRuns <= 0 {
+			delete(sS.lbDebits, key)
+		}
+	}
+	sS.lbDbtsMux.Unlock()
+}
+
+// untrackLowBalance stops counting the debits for the low balance forecasts of the session
+// not thread-safe so the locks need to be done in a layer above
+func (sS *SessionS) untrackLowBalance(s *Session) {
+	for _, sr := range s.SRuns {
+		sS.untrackLowBalanceDebits(sr)
+	}
+}
+
+// warnLowBalance schedules the low balance warnings for the thresholds reached before the next debit
+// the remaining balance is queried over RALs only if the current debit was not limited
+// and the previous query expects it to get close to the next threshold, unless
+// another session debited the account meanwhile
+// not thread-safe so the locks need to be done in a layer above
+func (sS *SessionS) warnLowBalance(s *Session, sRunIdx int,
+	maxDebit, dbtIvl time.Duration, debitStop chan struct{}) {
+	sr := s.SRuns[sRunIdx]
+	if len(sr.LowBalanceThresholds) == 0 {
+		return
+	}
+	remaining := maxDebit
+	if maxDebit >= dbtIvl {
+		if !s.Chargeable || len(sS.cgrCfg.SessionSCfg().RALsConns) == 0 {
+			return
+		}
+		if sr.TotalUsage < sr.LowBalanceCheckUsage &&
+			sS.onlyOwnLowBalanceDebit(sr) {
+			return
+		}
+		lookAhead := sr.LowBalanceThresholds[0] + // the highest threshold not yet reached
+			lowBalanceLookAheadDebits*dbtIvl
+		cd := sr.CD.Clone()
+		cd.TimeStart = sr.CD.TimeEnd
+		cd.TimeEnd = cd.TimeStart.Add(lookAhead)
+		cd.DurationIndex += lookAhead
+		var maxUsage time.Duration
+		if err := sS.connMgr.Call(sS.cgrCfg.SessionSCfg().RALsConns, nil,
+			utils.ResponderGetMaxSessionTime,
+			&engine.CallDescriptorWithAPIOpts{
+				CallDescriptor: cd,
+				APIOpts:        s.OptsStart,
+			}, &maxUsage); err != nil {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> could not query the remaining balance for session: <%s>, error: <%s>",
+					utils.SessionS, s.cgrID(), err.Error()))
+			return
+		}
+		remaining = dbtIvl + maxUsage // at least this much when maxUsage reached the lookAhead
+	}
+	var i int
+	for ; i < len(sr.LowBalanceThresholds); i++ {
+		thd := sr.LowBalanceThresholds[i]
+		if remaining-thd >= dbtIvl { // will be checked on one of the next debits
+			break
+		}
+		go sS.sendLowBalanceWarning(s, sr, thd, remaining-thd, debitStop)
+	}
+	sr.LowBalanceThresholds = sr.LowBalanceThresholds[i:]
+	if len(sr.LowBalanceThresholds) == 0 {
+		sS.untrackLowBalanceDebits(sr)
+		return
+	}
+	// query again on the debit from which the next threshold can be reached
+	sr.LowBalanceCheckUsage = sr.TotalUsage + remaining - sr.LowBalanceThresholds[0] - dbtIvl
+	sS.trackLowBalanceDebits(sr)
+}
+
+// sendLowBalanceWarning sends the warning towards the client after the given delay
+// and records the threshold within the session run event so it will reach the CDR
+func (sS *SessionS) sendLowBalanceWarning(s *Session, sr *SRun, thd, after time.Duration,
+	debitStop chan struct{}) {
+	if after > 0 {
+		select {
+		case <-debitStop: // the session ended before reaching the threshold
+			return
+		case <-time.After(after):
+		}
+	}
+	s.Lock()
+	if s.debitStop == nil { // session already closed
+		s.Unlock()
+		return
+	}
+	warnings := sr.Event.GetStringIgnoreErrors(utils.LowBalanceWarnings)
+	if warnings != utils.EmptyString {
+		warnings += utils.InfieldSep
+	}
+	sr.Event[utils.LowBalanceWarnings] = warnings + thd.String()
+	ev := s.EventStart.Clone()
+	s.Unlock()
+	ev[utils.LowBalanceThreshold] = thd.String()
+	sS.warnSession(s.ClientConnID, ev)
+}
+
 // refundSession will refund the extra usage debitted by the end of session
 // not thread-safe so the locks need to be done in a layer above
 // rUsage represents the amount of usage to be refunded
@@ -846,6 +1007,9 @@ func (sS *SessionS) registerSession(s *Session, passive bool) {
 		sMp = sS.pSessions
 	}
 	sMux.Lock()
+	if _, has := sMp[s.CGRID]; !has && !passive {
+		engine.TenantSessionStarted(s.Tenant)
+	}
 	sMp[s.CGRID] = s
 	sMux.Unlock()
 	sS.indexSession(s, passive)
@@ -875,10 +1039,14 @@ func (sS *SessionS) unregisterSession(cgrID string, passive bool) bool {
 		sMp = sS.pSessions
 	}
 	sMux.Lock()
-	if _, has := sMp[cgrID]; !has {
+	s, has := sMp[cgrID]
+	if !has {
 		sMux.Unlock()
 		return false
 	}
+	if !passive {
+		engine.TenantSessionEnded(s.Tenant)
+	}
 	delete(sMp, cgrID)
 	sMux.Unlock()
 	sS.unindexSession(cgrID, passive)
@@ -1047,6 +1215,111 @@ func (sS *SessionS) getSessionIDsMatchingIndexes(fltrs map[string][]string,
 	return cgrIDs, matchingSessions
 }
 
+// accountSessions returns the number of active sessions of the account, ignoring the session with the given cgrID
+// the account needs to be indexed in order to be counted
+func (sS *SessionS) accountSessions(tnt, acnt, ignCGRID string) (n int) {
+	cgrIDs, _ := sS.getSessionIDsMatchingIndexes(map[string][]string{utils.AccountField: {acnt}}, false)
+	for _, s := range sS.getSessionsFromCGRIDs(false, cgrIDs...) {
+		if s != nil && s.CGRID != ignCGRID && s.Tenant == tnt {
+			n++
+		}
+	}
+	return
+}
+
+// checkAccountSessions returns error if one of the session accounts reached the max_account_sessions limit
+func (sS *SessionS) checkAccountSessions(s *Session) (err error) {
+	maxSessions := sS.cgrCfg.SessionSCfg().MaxAccountSessions
+	if maxSessions == 0 {
+		return
+	}
+	checked := make(utils.StringSet)
+	for _, sr := range s.SRuns {
+		acnt := sr.Event.GetStringIgnoreErrors(utils.AccountField)
+		if acnt == utils.EmptyString ||
+			sr.Event.GetStringIgnoreErrors(utils.RequestType) == utils.MetaNone ||
+			checked.Has(acnt) {
+			continue
+		}
+		checked.Add(acnt)
+		if sS.accountSessions(s.Tenant, acnt, s.CGRID) >= maxSessions {
+			utils.Logger.Warning(
+				fmt.Sprintf("<%s> session: <%s> rejected, account: <%s> reached the limit of %d concurrent sessions",
+					utils.SessionS, s.cgrID(), acnt, maxSessions))
+			return utils.ErrMaxAccountSessionsExceeded
+		}
+	}
+	return
+}
+
+// accountSessionsLockIDs returns the sorted lock IDs of the session accounts limited by max_account_sessions
+func (sS *SessionS) accountSessionsLockIDs(s *Session) (lkIDs []string) {
+	if sS.cgrCfg.SessionSCfg().MaxAccountSessions == 0 {
+		return
+	}
+	acnts := make(utils.StringSet)
+	for _, sr := range s.SRuns {
+		if acnt := sr.Event.GetStringIgnoreErrors(utils.AccountField); acnt != utils.EmptyString {
+			acnts.Add(utils.ConcatenatedKey(utils.SessionS, s.Tenant, acnt))
+		}
+	}
+	lkIDs = acnts.AsSlice()
+	sort.Strings(lkIDs) // same order for all the sessions to avoid deadlocks
+	return
+}
+
+// reserveTenantSession reserves one session within the sessions quota of the tenant
+// returns error if the tenant of the session reached its quota
+func (sS *SessionS) reserveTenantSession(s *Session) (reserved bool, err error) {
+	if reserved, err = engine.ReserveTenantSession(s.Tenant); err != nil {
+		utils.Logger.Warning(
+			fmt.Sprintf("<%s> session: <%s> rejected, %s",
+				utils.SessionS, s.cgrID(), err.Error()))
+	}
+	return
+}
+
+// balanceSplitFactor returns the number of sessions sharing the balance of the session run account
+// including the current session, 1 if balance_split is disabled
+func (sS *SessionS) balanceSplitFactor(s *Session, sr *SRun) time.Duration {
+	if !sS.cgrCfg.SessionSCfg().BalanceSplit {
+		return 1
+	}
+	acnt := sr.Event.GetStringIgnoreErrors(utils.AccountField)
+	if acnt == utils.EmptyString {
+		return 1
+	}
+	return time.Duration(sS.accountSessions(s.Tenant, acnt, s.CGRID) + 1)
+}
+
+// splitBalanceUsage returns the usage allowed for the session run out of the balance shared with the other concurrent sessions of the account
+// not thread-safe so the locks need to be done in a layer above
+func (sS *SessionS) splitBalanceUsage(s *Session, sr *SRun, usage time.Duration) (time.Duration, error) {
+	nSessions := sS.balanceSplitFactor(s, sr)
+	if nSessions == 1 || usage == 0 {
+		return usage, nil
+	}
+	cd := sr.CD.Clone()
+	if cd.LoopIndex > 0 {
+		cd.TimeStart = cd.TimeEnd
+	}
+	cd.TimeEnd = cd.TimeStart.Add(nSessions * usage)
+	cd.DurationIndex += nSessions * usage
+	var maxUsage time.Duration
+	if err := sS.connMgr.Call(sS.cgrCfg.SessionSCfg().RALsConns, nil,
+		utils.ResponderGetMaxSessionTime,
+		&engine.CallDescriptorWithAPIOpts{
+			CallDescriptor: cd,
+			APIOpts:        s.OptsStart,
+		}, &maxUsage); err != nil {
+		return 0, utils.NewErrRALs(err)
+	}
+	if maxUsage /= nSessions; maxUsage < usage {
+		usage = maxUsage
+	}
+	return usage, nil
+}
+
 // filterSessions will return a list of sessions in external format based on filters passed
 // is thread safe for the Sessions
 func (sS *SessionS) filterSessions(sf *utils.SessionFilter, psv bool) (aSs []*ExternalSession) {
@@ -1206,6 +1479,11 @@ func (sS *SessionS) newSession(cgrEv *utils.CGREvent, resID, clntConnID string,
 		if len(subject) == 0 {
 			subject = me.GetStringIgnoreErrors(utils.AccountField)
 		}
+		var lowBalThds []time.Duration
+		if lowBalThds, err = getLowBalanceThresholds(sS.cgrCfg.SessionSCfg().MinDurLowBalance,
+			chrgr.CGREvent.APIOpts, s.OptsStart); err != nil {
+			return
+		}
 		s.SRuns[i] = &SRun{
 			Event: me,
 			CD: &engine.CallDescriptor{
@@ -1222,6 +1500,7 @@ func (sS *SessionS) newSession(cgrEv *utils.CGREvent, resID, clntConnID string,
 				ExtraFields:   me.AsMapString(utils.MainCDRFields),
 				ForceDuration: forceDuration,
 			},
+			LowBalanceThresholds: lowBalThds,
 		}
 	}
 	return
@@ -1312,6 +1591,7 @@ func (sS *SessionS) transitSState(cgrID string, psv bool) (s *Session) {
 	} else { // transit from active with possible STerminator and DebitLoops
 		s.stopSTerminator()
 		s.stopDebitLoops()
+		sS.untrackLowBalance(s)
 	}
 	s.Unlock()
 	return
@@ -1460,20 +1740,32 @@ func (sS *SessionS) authEvent(cgrEv *utils.CGREvent, forceDuration bool) (usage
 	if s, err = sS.newSession(cgrEv, "", "", 0, forceDuration, true); err != nil {
 		return
 	}
+	if err = sS.checkAccountSessions(s); err != nil {
+		return
+	}
 	usage = make(map[string]time.Duration)
 	for _, sr := range s.SRuns {
 		var rplyMaxUsage time.Duration
 		if !authReqs.HasField(
 			sr.Event.GetStringIgnoreErrors(utils.RequestType)) {
 			rplyMaxUsage = eventUsage
-		} else if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().RALsConns, nil,
-			utils.ResponderGetMaxSessionTime,
-			&engine.CallDescriptorWithAPIOpts{
-				CallDescriptor: sr.CD,
-				APIOpts:        s.OptsStart,
-			}, &rplyMaxUsage); err != nil {
-			err = utils.NewErrRALs(err)
-			return
+		} else {
+			cd := sr.CD
+			nSessions := sS.balanceSplitFactor(s, sr)
+			if nSessions != 1 { // ask for the usage of all the sessions sharing the balance
+				cd = sr.CD.Clone()
+				cd.TimeEnd = cd.TimeStart.Add(nSessions * eventUsage)
+			}
+			if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().RALsConns, nil,
+				utils.ResponderGetMaxSessionTime,
+				&engine.CallDescriptorWithAPIOpts{
+					CallDescriptor: cd,
+					APIOpts:        s.OptsStart,
+				}, &rplyMaxUsage); err != nil {
+				err = utils.NewErrRALs(err)
+				return
+			}
+			rplyMaxUsage /= nSessions
 		}
 		if rplyMaxUsage > eventUsage {
 			rplyMaxUsage = eventUsage
@@ -1491,12 +1783,38 @@ func (sS *SessionS) initSession(cgrEv *utils.CGREvent, clntConnID,
 		return nil, err
 	}
 	if !isMsg {
+		if err = sS.registerNewSession(s); err != nil {
+			return nil, err
+		}
+	}
+	return
+}
+
+// registerNewSession checks the session limits and registers the new session
+// under the locks of its accounts so the parallel inits cannot exceed max_account_sessions
+func (sS *SessionS) registerNewSession(s *Session) (err error) {
+	register := func() (err error) {
+		if err = sS.checkAccountSessions(s); err != nil {
+			return
+		}
+		var reserved bool
+		if reserved, err = sS.reserveTenantSession(s); err != nil {
+			return
+		}
+		if reserved { // the registered session counts towards the quota from now on
+			defer engine.ReleaseTenantSession(s.Tenant)
+		}
 		s.Lock() // avoid endsession before initialising
 		sS.initSessionDebitLoops(s)
 		sS.registerSession(s, false)
 		s.Unlock()
+		return
 	}
-	return
+	lkIDs := sS.accountSessionsLockIDs(s)
+	if len(lkIDs) == 0 {
+		return register()
+	}
+	return guardian.Guardian.Guard(register, sS.cgrCfg.GeneralCfg().LockingTimeout, lkIDs...)
 }
 
 // updateSession will reset terminator, perform debits and replicate sessions
@@ -1539,11 +1857,15 @@ func (sS *SessionS) updateSession(s *Session, updtEv, opts engine.MapEvent, isMs
 			continue
 		}
 		var rplyMaxUsage time.Duration
-		if reqType != utils.MetaPrepaid || s.debitStop != nil {
+		if reqType != utils.MetaPrepaid {
 			rplyMaxUsage = reqMaxUsage
-		} else if rplyMaxUsage, err = sS.debitSession(s, i, reqMaxUsage,
-			updtEv.GetDurationPtrIgnoreErrors(utils.LastUsed)); err != nil {
+		} else if rplyMaxUsage, err = sS.splitBalanceUsage(s, sr, reqMaxUsage); err != nil {
 			return
+		} else if s.debitStop == nil { // the debit loop is debiting the session
+			if rplyMaxUsage, err = sS.debitSession(s, i, rplyMaxUsage,
+				updtEv.GetDurationPtrIgnoreErrors(utils.LastUsed)); err != nil {
+				return
+			}
 		}
 		maxUsage[sr.CD.RunID] = rplyMaxUsage
 	}
@@ -1570,6 +1892,7 @@ func (sS *SessionS) endSession(s *Session, tUsage, lastUsage *time.Duration,
 		sS.unregisterSession(s.CGRID, false)
 		s.stopSTerminator()
 		s.stopDebitLoops()
+		sS.untrackLowBalance(s)
 	}
 	for sRunIdx, sr := range s.SRuns {
 		sUsage := sr.TotalUsage
@@ -1768,6 +2091,7 @@ func (sS *SessionS) BiRPCv1SetPassiveSession(clnt rpcclient.ClientConnector,
 		sS.unregisterSession(s.CGRID, false)
 		aSs[0].stopSTerminator()
 		aSs[0].stopDebitLoops()
+		sS.untrackLowBalance(aSs[0])
 		aSs[0].Unlock()
 	}
 	sS.registerSession(s, true)
@@ -1836,6 +2160,7 @@ type V1AuthorizeArgs struct {
 	ForceDuration      bool
 	ProcessThresholds  bool
 	ProcessStats       bool
+	ProcessFraud       bool
 	GetRoutes          bool
 	RoutesMaxCost      string
 	RoutesIgnoreErrors bool
@@ -1856,6 +2181,8 @@ func (args *V1AuthorizeArgs) ParseFlags(flags, sep string) {
 			args.GetMaxUsage = true
 		case subsystem == utils.MetaResources:
 			args.AuthorizeResources = true
+		case subsystem == utils.MetaFrauds:
+			args.ProcessFraud = true
 		case subsystem == utils.MetaRoutes:
 			args.GetRoutes = true
 		case subsystem == utils.MetaRoutesIgnoreErrors:
@@ -1982,7 +2309,7 @@ func (sS *SessionS) BiRPCv1AuthorizeEvent(clnt rpcclient.ClientConnector,
 	// end of RPC caching
 
 	if !args.GetAttributes && !args.AuthorizeResources &&
-		!args.GetMaxUsage && !args.GetRoutes {
+		!args.GetMaxUsage && !args.GetRoutes && !args.ProcessFraud {
 		return utils.NewErrMandatoryIeMissing("subsystems")
 	}
 	if args.GetAttributes {
@@ -1994,6 +2321,16 @@ func (sS *SessionS) BiRPCv1AuthorizeEvent(clnt rpcclient.ClientConnector,
 			return utils.NewErrAttributeS(err)
 		}
 	}
+	if args.ProcessFraud {
+		if len(sS.cgrCfg.SessionSCfg().FraudSConns) == 0 {
+			return utils.NewErrNotConnected(utils.FraudS)
+		}
+		var reply string
+		if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().FraudSConns, nil, utils.FraudSv1AuthorizeEvent,
+			args.CGREvent, &reply); err != nil {
+			return utils.NewErrFraudS(err)
+		}
+	}
 	if args.GetMaxUsage {
 		var sRunsUsage map[string]time.Duration
 		if sRunsUsage, err = sS.authEvent(args.CGREvent, args.ForceDuration); err != nil {
@@ -2141,6 +2478,7 @@ type V1InitSessionArgs struct {
 	ForceDuration     bool
 	ProcessThresholds bool
 	ProcessStats      bool
+	ProcessFraud      bool
 	AttributeIDs      []string
 	ThresholdIDs      []string
 	StatIDs           []string
@@ -2157,6 +2495,8 @@ func (args *V1InitSessionArgs) ParseFlags(flags, sep string) {
 			args.InitSession = true
 		case subsystem == utils.MetaResources:
 			args.AllocateResources = true
+		case subsystem == utils.MetaFrauds:
+			args.ProcessFraud = true
 		case strings.HasPrefix(subsystem, utils.MetaAttributes):
 			args.GetAttributes = true
 			args.AttributeIDs = getFlagIDs(subsystem)
@@ -2268,7 +2608,8 @@ func (sS *SessionS) BiRPCv1InitiateSession(clnt rpcclient.ClientConnector,
 	}
 	// end of RPC caching
 
-	if !args.GetAttributes && !args.AllocateResources && !args.InitSession {
+	if !args.GetAttributes && !args.AllocateResources && !args.InitSession &&
+		!args.ProcessFraud {
 		return utils.NewErrMandatoryIeMissing("subsystems")
 	}
 	originID, _ := args.CGREvent.FieldAsString(utils.OriginID)
@@ -2281,6 +2622,19 @@ func (sS *SessionS) BiRPCv1InitiateSession(clnt rpcclient.ClientConnector,
 			return utils.NewErrAttributeS(err)
 		}
 	}
+	if args.ProcessFraud {
+		if len(sS.cgrCfg.SessionSCfg().FraudSConns) == 0 {
+			return utils.NewErrNotConnected(utils.FraudS)
+		}
+		if originID == "" {
+			return utils.NewErrMandatoryIeMissing(utils.OriginID)
+		}
+		var reply string
+		if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().FraudSConns, nil, utils.FraudSv1AllocateCall,
+			args.CGREvent, &reply); err != nil {
+			return utils.NewErrFraudS(err)
+		}
+	}
 	if args.AllocateResources {
 		if len(sS.cgrCfg.SessionSCfg().ResSConns) == 0 {
 			return utils.NewErrNotConnected(utils.ResourceS)
@@ -2582,6 +2936,7 @@ type V1TerminateSessionArgs struct {
 	ReleaseResources  bool
 	ProcessThresholds bool
 	ProcessStats      bool
+	ProcessFraud      bool
 	ThresholdIDs      []string
 	StatIDs           []string
 	*utils.CGREvent
@@ -2597,6 +2952,8 @@ func (args *V1TerminateSessionArgs) ParseFlags(flags, sep string) {
 			args.TerminateSession = true
 		case subsystem == utils.MetaResources:
 			args.ReleaseResources = true
+		case subsystem == utils.MetaFrauds:
+			args.ProcessFraud = true
 		case strings.Index(subsystem, utils.MetaThresholds) != -1:
 			args.ProcessThresholds = true
 			args.ThresholdIDs = getFlagIDs(subsystem)
@@ -2644,7 +3001,7 @@ func (sS *SessionS) BiRPCv1TerminateSession(clnt rpcclient.ClientConnector,
 			nil, true, utils.NonTransactional)
 	}
 	// end of RPC caching
-	if !args.TerminateSession && !args.ReleaseResources {
+	if !args.TerminateSession && !args.ReleaseResources && !args.ProcessFraud {
 		return utils.NewErrMandatoryIeMissing("subsystems")
 	}
 
@@ -2718,6 +3075,19 @@ func (sS *SessionS) BiRPCv1TerminateSession(clnt rpcclient.ClientConnector,
 			return utils.NewErrResourceS(err)
 		}
 	}
+	if args.ProcessFraud {
+		if len(sS.cgrCfg.SessionSCfg().FraudSConns) == 0 {
+			return utils.NewErrNotConnected(utils.FraudS)
+		}
+		if originID == "" {
+			return utils.NewErrMandatoryIeMissing(utils.OriginID)
+		}
+		var reply string
+		if err = sS.connMgr.Call(sS.cgrCfg.SessionSCfg().FraudSConns, nil, utils.FraudSv1ReleaseCall,
+			args.CGREvent, &reply); err != nil {
+			return utils.NewErrFraudS(err)
+		}
+	}
 	if args.ProcessThresholds {
 		_, err := sS.processThreshold(args.CGREvent, args.ThresholdIDs, true)
 		if err != nil &&
@@ -1950,6 +1950,7 @@ func TestNewSessionS(t *testing.T) {
 		pSessions:     make(map[string]*Session),
 		pSessionsIdx:  make(map[string]map[string]map[string]utils.StringSet),
 		pSessionsRIdx: make(map[string][]*riFieldNameVal),
+		lbDebits:      make(map[string]*lowBalanceDebits),
 	}
 	sS := NewSessionS(cgrCGF, nil, nil)
 	if !reflect.DeepEqual(sS, eOut) {
@@ -26,6 +26,7 @@ import (
 	"os"
 	"reflect"
 	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -1029,6 +1030,124 @@ func TestDebitLoopSessionDisconnectSession(t *testing.T) {
 	ss.debitStop <- struct{}{}
 }
 
+type testMockClientConnWarnSess struct {
+	warns chan map[string]interface{}
+}
+
+func (sT *testMockClientConnWarnSess) Call(method string, arg interface{}, rply interface{}) error {
+	if method == utils.SessionSv1WarnDisconnect {
+		sT.warns <- arg.(map[string]interface{})
+	}
+	return nil
+}
+
+func TestWarnLowBalance(t *testing.T) {
+	log.SetOutput(io.Discard)
+	engine.Cache.Clear(nil)
+	var queries int
+	testMock1 := &testMockClients{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.ResponderGetMaxSessionTime: func(args interface{}, reply interface{}) error {
+				queries++
+				*reply.(*time.Duration) = 30 * time.Second
+				return nil
+			},
+		},
+	}
+	sMock := make(chan rpcclient.ClientConnector, 1)
+	sMock <- testMock1
+	cfg := config.NewDefaultCGRConfig()
+	cfg.GeneralCfg().NodeID = "ClientConnID"
+	cfg.SessionSCfg().RALsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs)}
+	data := engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs): sMock})
+	sessions := NewSessionS(cfg, dm, connMgr)
+	sTestMock := &testMockClientConnWarnSess{warns: make(chan map[string]interface{}, 3)}
+	sessions.RegisterIntBiJConn(sTestMock, utils.EmptyString)
+
+	ss := &Session{
+		CGRID:        "CGRID",
+		Tenant:       "cgrates.org",
+		ClientConnID: "ClientConnID",
+		debitStop:    make(chan struct{}),
+		EventStart: engine.NewMapEvent(map[string]interface{}{
+			utils.OriginID: "sess1",
+		}),
+		SRuns: []*SRun{
+			{
+				Event:                engine.NewMapEvent(nil),
+				CD:                   &engine.CallDescriptor{},
+				LowBalanceThresholds: []time.Duration{5 * time.Minute, time.Minute, 10 * time.Millisecond},
+			},
+		},
+		Chargeable: true,
+	}
+
+	// the next interval has balance enough for 10s only so the first two thresholds are reached
+	ss.Lock()
+	sessions.warnLowBalance(ss, 0, 20*time.Second, 20*time.Second, ss.debitStop)
+	exp := []time.Duration{10 * time.Millisecond}
+	if !reflect.DeepEqual(exp, ss.SRuns[0].LowBalanceThresholds) {
+		t.Errorf("Expected %v, received %v", exp, ss.SRuns[0].LowBalanceThresholds)
+	}
+	ss.Unlock()
+	for i := 0; i < 2; i++ {
+		select {
+		case ev := <-sTestMock.warns:
+			if ev[utils.OriginID] != "sess1" {
+				t.Errorf("Unexpected warning event: %s", utils.ToJSON(ev))
+			}
+		case <-time.After(50 * time.Millisecond):
+			t.Fatal("Expected low balance warning")
+		}
+	}
+	ss.Lock()
+	if warns := ss.SRuns[0].Event.GetStringIgnoreErrors(utils.LowBalanceWarnings); warns != "5m0s;1m0s" &&
+		warns != "1m0s;5m0s" {
+		t.Errorf("Unexpected warnings recorded: %q", warns)
+	}
+
+	// the last threshold can not be reached within the next debit so the balance is not queried again
+	ss.SRuns[0].TotalUsage = 20 * time.Second
+	sessions.countLowBalanceDebit(ss.SRuns[0].CD.Tenant, ss.SRuns[0].CD.Account)
+	sessions.warnLowBalance(ss, 0, 20*time.Second, 20*time.Second, ss.debitStop)
+	if queries != 1 {
+		t.Errorf("Expected the balance queried once, received: %d", queries)
+	}
+	if exp := 50*time.Second - 10*time.Millisecond - 20*time.Second; ss.SRuns[0].LowBalanceCheckUsage != exp {
+		t.Errorf("Expected %v, received %v", exp, ss.SRuns[0].LowBalanceCheckUsage)
+	}
+
+	// another session debiting the account invalidates the forecast
+	ss.SRuns[0].TotalUsage = 25 * time.Second
+	sessions.countLowBalanceDebit(ss.SRuns[0].CD.Tenant, ss.SRuns[0].CD.Account)
+	sessions.countLowBalanceDebit(ss.SRuns[0].CD.Tenant, ss.SRuns[0].CD.Account)
+	sessions.warnLowBalance(ss, 0, 20*time.Second, 20*time.Second, ss.debitStop)
+	if queries != 2 {
+		t.Errorf("Expected the balance queried again, received: %d queries", queries)
+	}
+
+	// last debit limited to 15ms, the last threshold is reached after 5ms
+	sessions.warnLowBalance(ss, 0, 15*time.Millisecond, 20*time.Second, ss.debitStop)
+	if len(ss.SRuns[0].LowBalanceThresholds) != 0 {
+		t.Errorf("Expected no thresholds left, received %v", ss.SRuns[0].LowBalanceThresholds)
+	}
+	if len(sessions.lbDebits) != 0 {
+		t.Errorf("Expected the account debits no longer counted, received: %s", utils.ToJSON(sessions.lbDebits))
+	}
+	ss.Unlock()
+	select {
+	case ev := <-sTestMock.warns:
+		if ev[utils.LowBalanceThreshold] != "10ms" {
+			t.Errorf("Unexpected warning event: %s", utils.ToJSON(ev))
+		}
+	case <-time.After(50 * time.Millisecond):
+		t.Fatal("Expected low balance warning")
+	}
+}
+
 func TestStoreSCost(t *testing.T) {
 	log.SetOutput(io.Discard)
 	engine.Cache.Clear(nil)
@@ -4559,3 +4678,356 @@ func TestSyncSessionsSync(t *testing.T) {
 	//There are no sessions to be removed
 	sessions.terminateSyncSessions([]string{"no_sesssion"})
 }
+
+func TestCheckAccountSessions(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().SessionIndexes = utils.NewStringSet([]string{utils.AccountField})
+	cfg.SessionSCfg().MaxAccountSessions = 2
+	sessions := NewSessionS(cfg, nil, nil)
+	newAcntSession := func(cgrID, tnt, acnt string) *Session {
+		return &Session{
+			CGRID:  cgrID,
+			Tenant: tnt,
+			SRuns: []*SRun{{
+				Event: engine.MapEvent{
+					utils.AccountField: acnt,
+					utils.RequestType:  utils.MetaPrepaid,
+				},
+				CD: &engine.CallDescriptor{RunID: utils.MetaDefault},
+			}},
+		}
+	}
+	sessions.registerSession(newAcntSession("CGRID1", "cgrates.org", "1001"), false)
+	sessions.registerSession(newAcntSession("CGRID2", "cgrates.org", "1002"), false)
+	sessions.registerSession(newAcntSession("CGRID3", "itsyscom.com", "1001"), false)
+
+	if n := sessions.accountSessions("cgrates.org", "1001", utils.EmptyString); n != 1 {
+		t.Errorf("Expected 1 session, received %d", n)
+	}
+	if err := sessions.checkAccountSessions(newAcntSession("CGRID4", "cgrates.org", "1001")); err != nil {
+		t.Error(err)
+	}
+	sessions.registerSession(newAcntSession("CGRID4", "cgrates.org", "1001"), false)
+	if err := sessions.checkAccountSessions(newAcntSession("CGRID5", "cgrates.org", "1001")); err != utils.ErrMaxAccountSessionsExceeded {
+		t.Errorf("Expected %v, received %v", utils.ErrMaxAccountSessionsExceeded, err)
+	}
+	if err := sessions.checkAccountSessions(newAcntSession("CGRID5", "itsyscom.com", "1001")); err != nil {
+		t.Error(err)
+	}
+	cfg.SessionSCfg().MaxAccountSessions = 0
+	if err := sessions.checkAccountSessions(newAcntSession("CGRID5", "cgrates.org", "1001")); err != nil {
+		t.Error(err)
+	}
+}
+
+func TestRegisterNewSessionMaxAccountSessions(t *testing.T) {
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().SessionIndexes = utils.NewStringSet([]string{utils.AccountField})
+	cfg.SessionSCfg().MaxAccountSessions = 2
+	sessions := NewSessionS(cfg, nil, nil)
+	var wg sync.WaitGroup
+	var mux sync.Mutex
+	var rejected int
+	for i := 0; i < 20; i++ {
+		wg.Add(1)
+		go func(i int) {
+			defer wg.Done()
+			if err := sessions.registerNewSession(&Session{
+				CGRID:  fmt.Sprintf("CGRID%d", i),
+				Tenant: "cgrates.org",
+				SRuns: []*SRun{{
+					Event: engine.MapEvent{
+						utils.AccountField: "1001",
+						utils.RequestType:  utils.MetaPostpaid,
+					},
+					CD: &engine.CallDescriptor{RunID: utils.MetaDefault},
+				}},
+			}); err == utils.ErrMaxAccountSessionsExceeded {
+				mux.Lock()
+				rejected++
+				mux.Unlock()
+			} else if err != nil {
+				t.Error(err)
+			}
+		}(i)
+	}
+	wg.Wait()
+	if n := sessions.accountSessions("cgrates.org", "1001", utils.EmptyString); n != 2 {
+		t.Errorf("Expected 2 sessions, received %d", n)
+	}
+	if rejected != 18 {
+		t.Errorf("Expected 18 rejected sessions, received %d", rejected)
+	}
+}
+
+func TestReserveTenantSession(t *testing.T) {
+	defer config.SetCgrConfig(config.CgrConfig())
+	cfg := config.NewDefaultCGRConfig()
+	cfg.QuotasCfg().Enabled = true
+	cfg.QuotasCfg().Tenants["quotas.org"] = &config.TenantQuotaCfg{Sessions: 1}
+	config.SetCgrConfig(cfg)
+	sessions := NewSessionS(cfg, nil, nil)
+
+	s1 := &Session{CGRID: "CGRID1", Tenant: "quotas.org"}
+	expErr := utils.NewErrTenantQuotaExceeded(utils.SessionsCfg, "quotas.org").Error()
+	if reserved, err := sessions.reserveTenantSession(s1); err != nil {
+		t.Error(err)
+	} else if !reserved {
+		t.Error("Expected the session to be reserved")
+	}
+	// the reservation counts towards the quota until released
+	if _, err := sessions.reserveTenantSession(&Session{CGRID: "CGRID3", Tenant: "quotas.org"}); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %v, received %v", expErr, err)
+	}
+	engine.ReleaseTenantSession("quotas.org")
+	if err := sessions.registerNewSession(s1); err != nil {
+		t.Error(err)
+	}
+	sessions.registerSession(s1, false) // replacing the session does not count it twice
+	sessions.registerSession(&Session{CGRID: "CGRID2", Tenant: "quotas.org"}, true)
+	if err := sessions.registerNewSession(&Session{CGRID: "CGRID3", Tenant: "quotas.org"}); err == nil || err.Error() != expErr {
+		t.Errorf("Expected %v, received %v", expErr, err)
+	}
+	sessions.unregisterSession("CGRID1", false)
+	if err := sessions.registerNewSession(&Session{CGRID: "CGRID3", Tenant: "quotas.org"}); err != nil {
+		t.Error(err)
+	}
+	sessions.unregisterSession("CGRID3", false)
+}
+
+func TestSplitBalanceUsage(t *testing.T) {
+	engine.Cache.Clear(nil)
+	var rcvUsage time.Duration
+	testMock1 := &testMockClients{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.ResponderGetMaxSessionTime: func(args interface{}, reply interface{}) error {
+				rcvUsage = args.(*engine.CallDescriptorWithAPIOpts).GetDuration()
+				*reply.(*time.Duration) = 90 * time.Second
+				return nil
+			},
+		},
+	}
+	sMock := make(chan rpcclient.ClientConnector, 1)
+	sMock <- testMock1
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().SessionIndexes = utils.NewStringSet([]string{utils.AccountField})
+	cfg.SessionSCfg().RALsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs)}
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs): sMock})
+	sessions := NewSessionS(cfg, nil, connMgr)
+	for _, cgrID := range []string{"CGRID1", "CGRID2"} {
+		sessions.registerSession(&Session{
+			CGRID:  cgrID,
+			Tenant: "cgrates.org",
+			SRuns: []*SRun{{
+				Event: engine.MapEvent{utils.AccountField: "1001"},
+				CD:    &engine.CallDescriptor{RunID: utils.MetaDefault},
+			}},
+		}, false)
+	}
+	tStart := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	sr := &SRun{
+		Event: engine.MapEvent{utils.AccountField: "1001"},
+		CD: &engine.CallDescriptor{
+			Tenant:    "cgrates.org",
+			Account:   "1001",
+			TimeStart: tStart,
+			TimeEnd:   tStart,
+		},
+	}
+	s := &Session{
+		CGRID:  "CGRID3",
+		Tenant: "cgrates.org",
+		SRuns:  []*SRun{sr},
+	}
+	// balance_split disabled, the usage is not changed
+	if usage, err := sessions.splitBalanceUsage(s, sr, time.Minute); err != nil {
+		t.Error(err)
+	} else if usage != time.Minute {
+		t.Errorf("Expected %v, received %v", time.Minute, usage)
+	}
+	cfg.SessionSCfg().BalanceSplit = true
+	// the balance is enough for 90s so each of the three sessions gets 30s
+	if usage, err := sessions.splitBalanceUsage(s, sr, time.Minute); err != nil {
+		t.Error(err)
+	} else if usage != 30*time.Second {
+		t.Errorf("Expected %v, received %v", 30*time.Second, usage)
+	} else if rcvUsage != 3*time.Minute {
+		t.Errorf("Expected to ask for %v, received %v", 3*time.Minute, rcvUsage)
+	}
+	if usage, err := sessions.splitBalanceUsage(s, sr, 20*time.Second); err != nil {
+		t.Error(err)
+	} else if usage != 20*time.Second {
+		t.Errorf("Expected %v, received %v", 20*time.Second, usage)
+	}
+}
+
+func TestDebitLoopSessionBalanceSplit(t *testing.T) {
+	log.SetOutput(io.Discard)
+	engine.Cache.Clear(nil)
+	balance := time.Second // shared by the sessions of the account
+	var mux sync.Mutex
+	var debits []time.Duration
+	testMock1 := &testMockClients{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.ResponderGetMaxSessionTime: func(args interface{}, reply interface{}) error {
+				usage := args.(*engine.CallDescriptorWithAPIOpts).GetDuration()
+				if usage > balance {
+					usage = balance
+				}
+				*reply.(*time.Duration) = usage
+				return nil
+			},
+			utils.ResponderMaxDebit: func(args interface{}, reply interface{}) error {
+				cd := args.(*engine.CallDescriptorWithAPIOpts)
+				mux.Lock()
+				debits = append(debits, cd.GetDuration())
+				mux.Unlock()
+				*reply.(*engine.CallCost) = engine.CallCost{
+					Timespans: []*engine.TimeSpan{{TimeStart: cd.TimeStart, TimeEnd: cd.TimeEnd}},
+				}
+				return nil
+			},
+		},
+	}
+	sMock := make(chan rpcclient.ClientConnector, 1)
+	sMock <- testMock1
+	cfg := config.NewDefaultCGRConfig()
+	cfg.SessionSCfg().SessionIndexes = utils.NewStringSet([]string{utils.AccountField})
+	cfg.SessionSCfg().BalanceSplit = true
+	cfg.SessionSCfg().RALsConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs)}
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaRALs): sMock})
+	sessions := NewSessionS(cfg, nil, connMgr)
+	tStart := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	ss := make([]*Session, 2)
+	for i, cgrID := range []string{"CGRID1", "CGRID2"} {
+		ss[i] = &Session{
+			CGRID:      cgrID,
+			Tenant:     "cgrates.org",
+			EventStart: engine.MapEvent{},
+			Chargeable: true,
+			SRuns: []*SRun{{
+				Event: engine.MapEvent{
+					utils.AccountField: "1001",
+					utils.RequestType:  utils.MetaPrepaid,
+				},
+				CD: &engine.CallDescriptor{
+					RunID:     utils.MetaDefault,
+					Tenant:    "cgrates.org",
+					Account:   "1001",
+					TimeStart: tStart,
+					TimeEnd:   tStart,
+				},
+			}},
+			debitStop: make(chan struct{}),
+		}
+		sessions.registerSession(ss[i], false)
+	}
+	// with an active debit loop the update replies with the share of the balance
+	if maxUsage, err := sessions.updateSession(ss[0], engine.MapEvent{utils.Usage: time.Second},
+		nil, false); err != nil {
+		t.Error(err)
+	} else if maxUsage[utils.MetaDefault] != balance/2 {
+		t.Errorf("Expected %v, received %v", balance/2, maxUsage[utils.MetaDefault])
+	}
+	for _, s := range ss {
+		go sessions.debitLoopSession(s, 0, time.Second)
+	}
+	for i := 0; i < 100; i++ {
+		mux.Lock()
+		nDebits := len(debits)
+		mux.Unlock()
+		if nDebits == 2 {
+			break
+		}
+		time.Sleep(5 * time.Millisecond)
+	}
+	for _, s := range ss {
+		s.Lock()
+		s.stopDebitLoops()
+		s.stopSTerminator()
+		s.Unlock()
+	}
+	mux.Lock()
+	defer mux.Unlock()
+	// each of the concurrent sessions debits only its share of the balance
+	if exp := []time.Duration{balance / 2, balance / 2}; !reflect.DeepEqual(exp, debits) {
+		t.Errorf("Expected debits %v, received %v", exp, debits)
+	}
+}
+
+func TestBiRPCv1FraudSessions(t *testing.T) {
+	engine.Cache.Clear(nil)
+	var calls []string
+	testMock1 := &testMockClients{
+		calls: map[string]func(args interface{}, reply interface{}) error{
+			utils.FraudSv1AuthorizeEvent: func(args interface{}, reply interface{}) error {
+				calls = append(calls, utils.FraudSv1AuthorizeEvent)
+				return utils.ErrFraudBlocked
+			},
+			utils.FraudSv1AllocateCall: func(args interface{}, reply interface{}) error {
+				calls = append(calls, utils.FraudSv1AllocateCall)
+				*reply.(*string) = utils.OK
+				return nil
+			},
+			utils.FraudSv1ReleaseCall: func(args interface{}, reply interface{}) error {
+				calls = append(calls, utils.FraudSv1ReleaseCall)
+				*reply.(*string) = utils.OK
+				return nil
+			},
+		},
+	}
+	sMock := make(chan rpcclient.ClientConnector, 1)
+	sMock <- testMock1
+	cfg := config.NewDefaultCGRConfig()
+	data := engine.NewInternalDB(nil, nil, true, cfg.DataDbCfg().Items)
+	dm := engine.NewDataManager(data, cfg.CacheCfg(), nil)
+	connMgr := engine.NewConnManager(cfg, map[string]chan rpcclient.ClientConnector{
+		utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds): sMock})
+	sessions := NewSessionS(cfg, dm, connMgr)
+
+	cgrEv := &utils.CGREvent{
+		Tenant: "cgrates.org",
+		ID:     "TestBiRPCv1FraudSessions",
+		Event: map[string]interface{}{
+			utils.AccountField: "1001",
+			utils.OriginID:     "ORIGIN_ID",
+			utils.Destination:  "88234567",
+		},
+	}
+	authArgs := &V1AuthorizeArgs{CGREvent: cgrEv}
+	authArgs.ParseFlags(utils.MetaFrauds, utils.InfieldSep)
+	expected := "NOT_CONNECTED: FraudS"
+	if err := sessions.BiRPCv1AuthorizeEvent(nil, authArgs,
+		new(V1AuthorizeReply)); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+
+	sessions.cgrCfg.SessionSCfg().FraudSConns = []string{utils.ConcatenatedKey(utils.MetaInternal, utils.MetaFrauds)}
+	expected = "FRAUDS_ERROR:FRAUD_BLOCKED"
+	if err := sessions.BiRPCv1AuthorizeEvent(nil, authArgs,
+		new(V1AuthorizeReply)); err == nil || err.Error() != expected {
+		t.Errorf("Expected %+v, received %+v", expected, err)
+	}
+
+	initArgs := &V1InitSessionArgs{CGREvent: cgrEv}
+	initArgs.ParseFlags(utils.MetaFrauds, utils.InfieldSep)
+	if err := sessions.BiRPCv1InitiateSession(nil, initArgs,
+		new(V1InitSessionReply)); err != nil {
+		t.Error(err)
+	}
+
+	termArgs := &V1TerminateSessionArgs{CGREvent: cgrEv}
+	termArgs.ParseFlags(utils.MetaFrauds, utils.InfieldSep)
+	var reply string
+	if err := sessions.BiRPCv1TerminateSession(nil, termArgs, &reply); err != nil {
+		t.Error(err)
+	} else if reply != utils.OK {
+		t.Errorf("Expected %+v, received %+v", utils.OK, reply)
+	}
+	expCalls := []string{utils.FraudSv1AuthorizeEvent, utils.FraudSv1AllocateCall, utils.FraudSv1ReleaseCall}
+	if !reflect.DeepEqual(expCalls, calls) {
+		t.Errorf("Expected %+v, received %+v", expCalls, calls)
+	}
+}
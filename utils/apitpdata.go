@@ -247,6 +247,12 @@ type TPRatingProfile struct {
 	RatingPlanActivations []*TPRatingActivation // Activate rate profiles at specific time
 }
 
+// TPRatingProfileWithAPIOpts is used by the LoadRatingProfile API
+type TPRatingProfileWithAPIOpts struct {
+	*TPRatingProfile
+	APIOpts map[string]interface{}
+}
+
 // Used as key in nosql db (eg: redis)
 func (rpf *TPRatingProfile) KeyId() string {
 	return ConcatenatedKey(MetaOut,
@@ -320,6 +326,7 @@ type AttrSetDestination struct {
 	Id        string
 	Prefixes  []string
 	Overwrite bool
+	APIOpts   map[string]interface{}
 }
 
 type AttrTPRatingProfileIds struct {
@@ -420,6 +427,12 @@ type TPAccountActions struct {
 	Disabled         bool
 }
 
+// TPAccountActionsWithAPIOpts is used by the LoadAccountActions API
+type TPAccountActionsWithAPIOpts struct {
+	*TPAccountActions
+	APIOpts map[string]interface{}
+}
+
 // Returns the id used in some nosql dbs (eg: redis)
 func (aa *TPAccountActions) KeyId() string {
 	return ConcatenatedKey(aa.Tenant, aa.Account)
@@ -826,12 +839,14 @@ type AttrSetActions struct {
 	ActionsId string      // Actions id
 	Overwrite bool        // If previously defined, will be overwritten
 	Actions   []*TPAction // Set of actions this Actions profile will perform
+	APIOpts   map[string]interface{}
 }
 
 type AttrExecuteAction struct {
 	Tenant    string
 	Account   string
 	ActionsId string
+	APIOpts   map[string]interface{}
 }
 
 type AttrSetAccount struct {
@@ -841,12 +856,14 @@ type AttrSetAccount struct {
 	ActionTriggersID string
 	ExtraOptions     map[string]bool
 	ReloadScheduler  bool
+	APIOpts          map[string]interface{}
 }
 
 type AttrRemoveAccount struct {
 	Tenant          string
 	Account         string
 	ReloadScheduler bool
+	APIOpts         map[string]interface{}
 }
 
 type AttrGetCallCost struct {
@@ -862,12 +879,14 @@ type AttrSetBalance struct {
 	Balance         map[string]interface{}
 	ActionExtraData *map[string]interface{}
 	Cdrlog          bool
+	APIOpts         map[string]interface{}
 }
 
 type AttrSetBalances struct {
 	Tenant   string
 	Account  string
 	Balances []*AttrBalance
+	APIOpts  map[string]interface{}
 }
 
 type AttrBalance struct {
@@ -978,6 +997,7 @@ type TPStatProfile struct {
 	Weight             float64
 	MinItems           int
 	ThresholdIDs       []string
+	GroupBy            []string // templates building one child queue per distinct values
 }
 
 // TPThresholdProfile is used in APIs to manage remotely offline ThresholdProfile
@@ -994,6 +1014,9 @@ type TPThresholdProfile struct {
 	Weight             float64 // Weight to sort the thresholds
 	ActionIDs          []string
 	Async              bool
+	RaiseFilterIDs     []string // condition raising the alarm
+	ClearFilterIDs     []string // condition clearing the alarm
+	ClearActionIDs     []string // actions executed when the alarm is cleared
 }
 
 // TPFilterProfile is used in APIs to manage remotely offline FilterProfile
@@ -1154,6 +1177,17 @@ type SMCostFilter struct { //id cu litere mare
 	CreatedAt      TimeInterval
 }
 
+// AuditRecordsFilter is used to filter the audit records, the empty fields matching all
+type AuditRecordsFilter struct {
+	ItemTypes []string // the cache partitions of the items, e.g. *attribute_profiles
+	Tenants   []string
+	ItemIDs   []string
+	Users     []string
+	Actions   []string // *store or *remove
+	Time      TimeInterval
+	Paginator
+}
+
 func AppendToSMCostFilter(smcFilter *SMCostFilter, fieldType, fieldName string,
 	values []string, timezone string) (smcf *SMCostFilter, err error) {
 	switch fieldName {
@@ -47,7 +47,7 @@ var (
 		CacheTBLTPRatingPlans, CacheTBLTPRatingProfiles, CacheTBLTPSharedGroups, CacheTBLTPActions,
 		CacheTBLTPActionPlans, CacheTBLTPActionTriggers, CacheTBLTPAccountActions, CacheTBLTPResources,
 		CacheTBLTPStats, CacheTBLTPThresholds, CacheTBLTPFilters, CacheSessionCostsTBL, CacheCDRsTBL,
-		CacheTBLTPRoutes, CacheTBLTPAttributes, CacheTBLTPChargers, CacheTBLTPDispatchers,
+		CacheAuditLogTBL, CacheTBLTPRoutes, CacheTBLTPAttributes, CacheTBLTPChargers, CacheTBLTPDispatchers,
 		CacheTBLTPDispatcherHosts, CacheVersions})
 
 	// CachePartitions enables creation of cache partitions
@@ -136,6 +136,7 @@ var (
 		TBLTPFilters:          CacheTBLTPFilters,
 		SessionCostsTBL:       CacheSessionCostsTBL,
 		CDRsTBL:               CacheCDRsTBL,
+		AuditLogTBL:           CacheAuditLogTBL,
 		TBLTPRoutes:           CacheTBLTPRoutes,
 		TBLTPAttributes:       CacheTBLTPAttributes,
 		TBLTPChargers:         CacheTBLTPChargers,
@@ -171,6 +172,7 @@ const (
 	PseudoPrepaid            = "pseudoprepaid"
 	MetaPseudoPrepaid        = "*pseudoprepaid"
 	MetaRated                = "*rated"
+	MetaRate                 = "*rate"
 	MetaNone                 = "*none"
 	MetaNow                  = "*now"
 	MetaRoundingUp           = "*up"
@@ -204,6 +206,11 @@ const (
 	InitialOriginID          = "InitialOriginID"
 	OriginIDPrefix           = "OriginIDPrefix"
 	Source                   = "Source"
+	ItemType                 = "ItemType"
+	ItemID                   = "ItemID"
+	ItemBefore               = "Before"
+	ItemAfter                = "After"
+	APIOpts                  = "APIOpts"
 	OriginHost               = "OriginHost"
 	RequestType              = "RequestType"
 	Direction                = "Direction"
@@ -307,6 +314,7 @@ const (
 	TestSQL                   = "TEST_SQL"
 	MetaConstant              = "*constant"
 	MetaFiller                = "*filler"
+	MetaHTTPGet               = "*http_get"
 	MetaHTTPPost              = "*http_post"
 	MetaHTTPjsonCDR           = "*http_json_cdr"
 	MetaHTTPjsonMap           = "*http_json_map"
@@ -322,6 +330,8 @@ const (
 	MetaS3jsonMap             = "*s3_json_map"
 	ConfigPath                = "/etc/cgrates/"
 	DisconnectCause           = "DisconnectCause"
+	LowBalanceThreshold       = "LowBalanceThreshold"
+	LowBalanceWarnings        = "LowBalanceWarnings"
 	MetaRating                = "*rating"
 	NotAvailable              = "N/A"
 	Call                      = "call"
@@ -362,10 +372,13 @@ const (
 	MemoryUsage              = "MemoryUsage"
 	RunningSince             = "RunningSince"
 	GoVersion                = "GoVersion"
+	CapsAllocated            = "CapsAllocated"
+	DataDBBackends           = "DataDBBackends"
 	HandlerSubstractUsage    = "*substract_usage"
 	XML                      = "xml"
 	MetaGOB                  = "*gob"
 	MetaJSON                 = "*json"
+	MetaCGRateS              = "*cgrates"
 	MetaMSGPACK              = "*msgpack"
 	MetaDateTime             = "*datetime"
 	MetaMaskedDestination    = "*masked_destination"
@@ -492,6 +505,7 @@ const (
 	ResourceID            = "ResourceID"
 	TotalUsage            = "TotalUsage"
 	StatID                = "StatID"
+	ParentStatID          = "ParentStatID"
 	BalanceType           = "BalanceType"
 	BalanceID             = "BalanceID"
 	BalanceDestinationIds = "BalanceDestinationIds"
@@ -508,6 +522,12 @@ const (
 	BalanceUpdate         = "BalanceUpdate"
 	StatUpdate            = "StatUpdate"
 	ResourceUpdate        = "ResourceUpdate"
+	FraudAlert            = "FraudAlert"
+	FraudRuleID           = "FraudRuleID"
+	FraudRuleType         = "FraudRuleType"
+	FraudGroup            = "FraudGroup"
+	FraudCalls            = "FraudCalls"
+	BlockedUntil          = "BlockedUntil"
 	CDR                   = "CDR"
 	CDRs                  = "CDRs"
 	ExpiryTime            = "ExpiryTime"
@@ -540,6 +560,9 @@ const (
 	MetaQOS                  = "*qos"
 	MetaReas                 = "*reas"
 	MetaReds                 = "*reds"
+	MetaScore                = "*score"
+	MetaAsc                  = "*asc"
+	MetaDesc                 = "*desc"
 	Weight                   = "Weight"
 	Limit                    = "Limit"
 	UsageTTL                 = "UsageTTL"
@@ -601,6 +624,9 @@ const (
 	MaxHits                  = "MaxHits"
 	MinHits                  = "MinHits"
 	Async                    = "Async"
+	RaiseFilterIDs           = "RaiseFilterIDs"
+	ClearFilterIDs           = "ClearFilterIDs"
+	ClearActionIDs           = "ClearActionIDs"
 	Sorting                  = "Sorting"
 	SortingParameters        = "SortingParameters"
 	RouteAccountIDs          = "RouteAccountIDs"
@@ -614,6 +640,7 @@ const (
 	AttributeFilterIDs       = "AttributeFilterIDs"
 	QueueLength              = "QueueLength"
 	TTL                      = "TTL"
+	GroupBy                  = "GroupBy"
 	MinItems                 = "MinItems"
 	MetricIDs                = "MetricIDs"
 	MetricFilterIDs          = "MetricFilterIDs"
@@ -747,6 +774,7 @@ const (
 	EEs                     = "EEs"
 	Ratio                   = "Ratio"
 	Load                    = "Load"
+	Score                   = "Score"
 	Slash                   = "/"
 	UUID                    = "UUID"
 	Uuid                    = "Uuid"
@@ -916,6 +944,13 @@ const (
 	DNSTtl                = "Ttl"
 	DNSRdlength           = "Rdlength"
 	DNSData               = "Data"
+	DNSAAAA               = "AAAA"
+	DNSTarget             = "Target"
+	DNSPtr                = "Ptr"
+	DNSTxt                = "Txt"
+	DNSPriority           = "Priority"
+	DNSWeight             = "Weight"
+	DNSPort               = "Port"
 )
 
 // Migrator Action
@@ -942,6 +977,7 @@ const (
 	MetaChargerProfiles     = "*charger_profiles"
 	MetaSharedGroups        = "*shared_groups"
 	MetaThresholds          = "*thresholds"
+	MetaFrauds              = "*frauds"
 	MetaRoutes              = "*routes"
 	MetaAttributes          = "*attributes"
 	MetaLoadIDs             = "*load_ids"
@@ -971,6 +1007,7 @@ const (
 	StatService = "StatS"
 	FilterS     = "FilterS"
 	ThresholdS  = "ThresholdS"
+	FraudS      = "FraudS"
 	DispatcherS = "DispatcherS"
 	RegistrarC  = "RegistrarC"
 	LoaderS     = "LoaderS"
@@ -1053,6 +1090,8 @@ const (
 const (
 	MetaSetVersions         = "*set_versions"
 	MetaEnsureIndexes       = "*ensure_indexes"
+	MetaExportSnapshot      = "*export_snapshot"
+	MetaRestoreSnapshot     = "*restore_snapshot"
 	MetaTpRatingPlans       = "*tp_rating_plans"
 	MetaTpFilters           = "*tp_filters"
 	MetaTpDestinationRates  = "*tp_destination_rates"
@@ -1116,7 +1155,11 @@ const (
 	MetaRoundRobin     = "*round_robin"
 	MetaRatio          = "*ratio"
 	MetaDefaultRatio   = "*default_ratio"
+	MetaLeastLoad      = "*least_load"
+	MetaConsistentHash = "*consistent_hash"
+	MetaHashKey        = "*hash_key"
 	ThresholdSv1       = "ThresholdSv1"
+	FraudSv1           = "FraudSv1"
 	StatSv1            = "StatSv1"
 	ResourceSv1        = "ResourceSv1"
 	RouteSv1           = "RouteSv1"
@@ -1129,7 +1172,7 @@ const (
 	ArgDispatcherField = "ArgDispatcher"
 )
 
-//Filter types
+// Filter types
 const (
 	MetaNot                = "*not"
 	MetaString             = "*string"
@@ -1150,6 +1193,8 @@ const (
 	MetaAPIBan             = "*apiban"
 	MetaActivationInterval = "*ai"
 	MetaRegex              = "*regex"
+	MetaGeo                = "*geo"
+	MetaIPGeo              = "*ipgeo"
 
 	MetaNotString             = "*notstring"
 	MetaNotPrefix             = "*notprefix"
@@ -1166,6 +1211,8 @@ const (
 	MetaNotAPIBan             = "*notapiban"
 	MetaNotActivationInterval = "*notai"
 	MetaNotRegex              = "*notregex"
+	MetaNotGeo                = "*notgeo"
+	MetaNotIPGeo              = "*notipgeo"
 
 	MetaEC = "*ec"
 )
@@ -1288,6 +1335,7 @@ const (
 	APIerSv1RemoveFilter                      = "APIerSv1.RemoveFilter"
 	APIerSv1SetFilter                         = "APIerSv1.SetFilter"
 	APIerSv1GetFilterIDs                      = "APIerSv1.GetFilterIDs"
+	APIerSv1ExplainFilters                    = "APIerSv1.ExplainFilters"
 	APIerSv1GetRatingProfile                  = "APIerSv1.GetRatingProfile"
 	APIerSv1RemoveRatingProfile               = "APIerSv1.RemoveRatingProfile"
 	APIerSv1SetRatingProfile                  = "APIerSv1.SetRatingProfile"
@@ -1405,6 +1453,9 @@ const (
 	APIerSv1RemoveActionTiming                = "APIerSv1.RemoveActionTiming"
 	APIerSv1ComputeReverseDestinations        = "APIerSv1.ComputeReverseDestinations"
 	APIerSv1ComputeAccountActionPlans         = "APIerSv1.ComputeAccountActionPlans"
+	APIerSv1ExportDataDBSnapshot              = "APIerSv1.ExportDataDBSnapshot"
+	APIerSv1RestoreDataDBSnapshot             = "APIerSv1.RestoreDataDBSnapshot"
+	APIerSv1GetAuditRecords                   = "APIerSv1.GetAuditRecords"
 	APIerSv1SetDestination                    = "APIerSv1.SetDestination"
 	APIerSv1GetDataCost                       = "APIerSv1.GetDataCost"
 	APIerSv1ReplayFailedPosts                 = "APIerSv1.ReplayFailedPosts"
@@ -1439,12 +1490,17 @@ const (
 	APIerSv1GetTPTimingIds           = "APIerSv1.GetTPTimingIds"
 	APIerSv1LoadTariffPlanFromStorDb = "APIerSv1.LoadTariffPlanFromStorDb"
 	APIerSv1RemoveTPFromFolder       = "APIerSv1.RemoveTPFromFolder"
+	APIerSv1RemoveTPFromStorDB       = "APIerSv1.RemoveTPFromStorDB"
+	APIerSv1LoadDestination          = "APIerSv1.LoadDestination"
+	APIerSv1LoadSharedGroup          = "APIerSv1.LoadSharedGroup"
 )
 
 // APIerSv2 APIs
 const (
 	APIerSv2                           = "APIerSv2"
 	APIerSv2LoadTariffPlanFromFolder   = "APIerSv2.LoadTariffPlanFromFolder"
+	APIerSv2LoadRatingProfile          = "APIerSv2.LoadRatingProfile"
+	APIerSv2LoadAccountActions         = "APIerSv2.LoadAccountActions"
 	APIerSv2GetCDRs                    = "APIerSv2.GetCDRs"
 	APIerSv2GetAccount                 = "APIerSv2.GetAccount"
 	APIerSv2GetAccounts                = "APIerSv2.GetAccounts"
@@ -1489,6 +1545,16 @@ const (
 	ConfigSv1SetConfig         = "ConfigSv1.SetConfig"
 	ConfigSv1GetConfigAsJSON   = "ConfigSv1.GetConfigAsJSON"
 	ConfigSv1SetConfigFromJSON = "ConfigSv1.SetConfigFromJSON"
+	ConfigSv1SetAPIAuthRole    = "ConfigSv1.SetAPIAuthRole"
+	ConfigSv1RemoveAPIAuthRole = "ConfigSv1.RemoveAPIAuthRole"
+	ConfigSv1SetAPIAuthUser    = "ConfigSv1.SetAPIAuthUser"
+	ConfigSv1RemoveAPIAuthUser = "ConfigSv1.RemoveAPIAuthUser"
+)
+
+// APIAuthSv1 APIs
+const (
+	APIAuthSv1             = "APIAuthSv1"
+	APIAuthSv1Authenticate = "APIAuthSv1.Authenticate"
 )
 
 const (
@@ -1508,6 +1574,7 @@ const (
 	CoreSv1StopCPUProfiling     = "CoreSv1.StopCPUProfiling"
 	CoreSv1StartMemoryProfiling = "CoreSv1.StartMemoryProfiling"
 	CoreSv1StopMemoryProfiling  = "CoreSv1.StopMemoryProfiling"
+	CoreSv1GetTenantQuotas      = "CoreSv1.GetTenantQuotas"
 )
 
 // RouteS APIs
@@ -1516,6 +1583,7 @@ const (
 	RouteSv1GetRoutesList            = "RouteSv1.GetRoutesList"
 	RouteSv1GetRouteProfilesForEvent = "RouteSv1.GetRouteProfilesForEvent"
 	RouteSv1Ping                     = "RouteSv1.Ping"
+	RouteSv1ProcessFeedback          = "RouteSv1.ProcessFeedback"
 	APIerSv1GetRouteProfile          = "APIerSv1.GetRouteProfile"
 	APIerSv1GetRouteProfileIDs       = "APIerSv1.GetRouteProfileIDs"
 	APIerSv1RemoveRouteProfile       = "APIerSv1.RemoveRouteProfile"
@@ -1553,6 +1621,7 @@ const (
 	ThresholdSv1GetThresholdIDs       = "ThresholdSv1.GetThresholdIDs"
 	ThresholdSv1Ping                  = "ThresholdSv1.Ping"
 	ThresholdSv1GetThresholdsForEvent = "ThresholdSv1.GetThresholdsForEvent"
+	ThresholdSv1GetRaisedAlarms       = "ThresholdSv1.GetRaisedAlarms"
 	APIerSv1GetThresholdProfileIDs    = "APIerSv1.GetThresholdProfileIDs"
 	APIerSv1GetThresholdProfileCount  = "APIerSv1.GetThresholdProfileCount"
 	APIerSv1GetThresholdProfile       = "APIerSv1.GetThresholdProfile"
@@ -1560,6 +1629,20 @@ const (
 	APIerSv1SetThresholdProfile       = "APIerSv1.SetThresholdProfile"
 )
 
+// FraudS APIs
+const (
+	FraudSv1Ping               = "FraudSv1.Ping"
+	FraudSv1AuthorizeEvent     = "FraudSv1.AuthorizeEvent"
+	FraudSv1AllocateCall       = "FraudSv1.AllocateCall"
+	FraudSv1ReleaseCall        = "FraudSv1.ReleaseCall"
+	FraudSv1ProcessEvent       = "FraudSv1.ProcessEvent"
+	FraudSv1GetBlockedAccounts = "FraudSv1.GetBlockedAccounts"
+	FraudSv1UnblockAccount     = "FraudSv1.UnblockAccount"
+	MetaVelocity               = "*velocity"
+	MetaNewDestination         = "*new_destination"
+	MetaConcurrentCalls        = "*concurrent_calls"
+)
+
 // StatS APIs
 const (
 	StatSv1ProcessEvent            = "StatSv1.ProcessEvent"
@@ -1571,6 +1654,7 @@ const (
 	StatSv1GetStatQueue            = "StatSv1.GetStatQueue"
 	StatSv1V1GetQueueIDs           = "StatSv1.GetQueueIDs"
 	StatSv1ResetStatQueue          = "StatSv1.ResetStatQueue"
+	StatSv1GetQueueChildren        = "StatSv1.GetQueueChildren"
 	APIerSv1GetStatQueueProfile    = "APIerSv1.GetStatQueueProfile"
 	APIerSv1RemoveStatQueueProfile = "APIerSv1.RemoveStatQueueProfile"
 	APIerSv1SetStatQueueProfile    = "APIerSv1.SetStatQueueProfile"
@@ -1652,6 +1736,9 @@ const (
 	DispatcherSv1RemoteStatus        = "DispatcherSv1.RemoteStatus"
 	DispatcherSv1RemoteSleep         = "DispatcherSv1.RemoteSleep"
 	DispatcherSv1RemotePing          = "DispatcherSv1.RemotePing"
+	DispatcherSv1GetHostsHealth      = "DispatcherSv1.GetHostsHealth"
+	DispatcherSv1DrainHost           = "DispatcherSv1.DrainHost"
+	DispatcherSv1ResumeHost          = "DispatcherSv1.ResumeHost"
 )
 
 // RegistrarS APIs
@@ -1668,6 +1755,9 @@ const (
 	AnalyzerSv1            = "AnalyzerSv1"
 	AnalyzerSv1Ping        = "AnalyzerSv1.Ping"
 	AnalyzerSv1StringQuery = "AnalyzerSv1.StringQuery"
+	AnalyzerSv1Search      = "AnalyzerSv1.Search"
+	AnalyzerSv1Aggregate   = "AnalyzerSv1.Aggregate"
+	AnalyzerSv1Export      = "AnalyzerSv1.Export"
 )
 
 // LoaderS APIs
@@ -1799,6 +1889,7 @@ const (
 	TBLTPFilters          = "tp_filters"
 	SessionCostsTBL       = "session_costs"
 	CDRsTBL               = "cdrs"
+	AuditLogTBL           = "audit_log"
 	TBLTPRoutes           = "tp_routes"
 	TBLTPAttributes       = "tp_attributes"
 	TBLTPChargers         = "tp_chargers"
@@ -1879,6 +1970,7 @@ const (
 	CacheTBLTPFilters          = "*tp_filters"
 	CacheSessionCostsTBL       = "*session_costs"
 	CacheCDRsTBL               = "*cdrs"
+	CacheAuditLogTBL           = "*audit_log"
 	CacheTBLTPRoutes           = "*tp_routes"
 	CacheTBLTPAttributes       = "*tp_attributes"
 	CacheTBLTPChargers         = "*tp_chargers"
@@ -1908,6 +2000,7 @@ const (
 	AsteriskAgent   = "AsteriskAgent"
 	HTTPAgent       = "HTTPAgent"
 	SIPAgent        = "SIPAgent"
+	WebSocketAgent  = "WebSocketAgent"
 )
 
 // Google_API
@@ -1955,6 +2048,8 @@ const (
 	RSRSepCfg               = "rsr_separator"
 	MaxParallelConnsCfg     = "max_parallel_conns"
 	EEsConnsCfg             = "ees_conns"
+	AuditLogCfg             = "audit_log"
+	AuditEEsIDsCfg          = "audit_ees_ids"
 )
 
 // StorDbCfg
@@ -1994,6 +2089,9 @@ const (
 	ReplicationFilteredCfg     = "replication_filtered"
 	ReplicationCache           = "replication_cache"
 	RemoteConnIDCfg            = "remote_conn_id"
+	StandbyDBsCfg              = "standby_dbs"
+	FailoverIntervalCfg        = "failover_interval"
+	FailoverFailuresCfg        = "failover_failures"
 )
 
 // ItemOpt
@@ -2061,6 +2159,7 @@ const (
 	StatSConnsCfg     = "stats_conns"
 	ResourceSConnsCfg = "resources_conns"
 	ApierSConnsCfg    = "apiers_conns"
+	GeoIPDBCfg        = "geoip_db"
 )
 
 // RalsCfg
@@ -2118,6 +2217,8 @@ const (
 	TerminateAttemptsCfg   = "terminate_attempts"
 	AlterableFieldsCfg     = "alterable_fields"
 	MinDurLowBalanceCfg    = "min_dur_low_balance"
+	BalanceSplitCfg        = "balance_split"
+	MaxAccountSessionsCfg  = "max_account_sessions"
 	DefaultUsageCfg        = "default_usage"
 	STIRCfg                = "stir"
 
@@ -2152,6 +2253,7 @@ const (
 	EvapiConnsCfg = "evapi_conns"
 	TimezoneCfg   = "timezone"
 	TimezoneCfgC  = "Timezone"
+	ModeCfg       = "mode"
 
 	// AsteriskConnCfg
 	UserCf = "user"
@@ -2186,6 +2288,17 @@ const (
 	ClientSecretsCfg      = "client_secrets"
 	ClientDictionariesCfg = "client_dictionaries"
 
+	// DNSAgentCfg
+	ListenersCfg          = "listeners"
+	NetworkCfg            = "network"
+	UpstreamServersCfg    = "upstream_servers"
+	UpstreamNetCfg        = "upstream_net"
+	UpstreamTimeoutCfg    = "upstream_timeout"
+	UpstreamCacheLimitCfg = "upstream_cache_limit"
+
+	// WebSocketAgentCfg
+	AllowedOriginsCfg = "allowed_origins"
+
 	// AttributeSCfg
 	IndexedSelectsCfg           = "indexed_selects"
 	MetaProfileIDs              = "*profileIDs"
@@ -2194,12 +2307,16 @@ const (
 	MetaProfileIgnoreFiltersCfg = "*profileIgnoreFilters"
 	NestedFieldsCfg             = "nested_fields"
 	AnyContextCfg               = "any_context"
+	LookupsTimeoutCfg           = "lookups_timeout"
+	LookupsCacheTTLCfg          = "lookups_cache_ttl"
+	SQLConnsCfg                 = "sql_conns"
 
 	// ChargerSCfg
 	StoreIntervalCfg = "store_interval"
 
 	// StatSCfg
 	StoreUncompressedLimitCfg = "store_uncompressed_limit"
+	ChildQueuesTTLCfg         = "child_queues_ttl"
 
 	// Cache
 	PartitionsCfg = "partitions"
@@ -2214,13 +2331,30 @@ const (
 	AttributeIDsCfg      = "attribute_ids"
 
 	//LoaderSCfg
-	DryRunCfg       = "dry_run"
-	LockFilePathCfg = "lockfile_path"
-	TpInDirCfg      = "tp_in_dir"
-	TpOutDirCfg     = "tp_out_dir"
-	DataCfg         = "data"
+	DryRunCfg        = "dry_run"
+	TransactionalCfg = "transactional"
+	LockFilePathCfg  = "lockfile_path"
+	TpInDirCfg       = "tp_in_dir"
+	TpOutDirCfg      = "tp_out_dir"
+	DataCfg          = "data"
 
 	DefaultRatioCfg           = "default_ratio"
+	CircuitBreakersCfg        = "circuit_breakers"
+	FailuresCfg               = "failures"
+	FailureRatioCfg           = "failure_ratio"
+	WindowCfg                 = "window"
+	OpenPeriodCfg             = "open_period"
+	HalfOpenProbesCfg         = "half_open_probes"
+	RulesCfg                  = "rules"
+	GroupCfg                  = "group"
+	MaxCostCfg                = "max_cost"
+	MaxCallsCfg               = "max_calls"
+	MaxConcurrentCfg          = "max_concurrent"
+	BlockPeriodCfg            = "block_period"
+	ActionIDsCfg              = "action_ids"
+	EEsExporterIDsCfg         = "ees_exporter_ids"
+	MaxCallDurationCfg        = "max_call_duration"
+	FraudSConnsCfg            = "frauds_conns"
 	ReadersCfg                = "readers"
 	ExportersCfg              = "exporters"
 	PoolSize                  = "poolSize"
@@ -2268,7 +2402,10 @@ const (
 	MaxUsage      = "max_usage"
 
 	// DispatcherSCfg
-	AnySubsystemCfg = "any_subsystem"
+	AnySubsystemCfg        = "any_subsystem"
+	HealthCheckIntervalCfg = "health_check_interval"
+	HealthyThresholdCfg    = "healthy_threshold"
+	UnhealthyThresholdCfg  = "unhealthy_threshold"
 )
 
 // FC Template
@@ -2392,6 +2529,23 @@ const (
 	KeysCfg = "keys"
 )
 
+// QuotasCfg
+const (
+	APIRequestsCfg = "api_requests"
+	SessionsCfg    = "sessions"
+	CDRsRateCfg    = "cdrs_rate"
+	ObjectsCfg     = "objects"
+)
+
+// APIAuthCfg
+const (
+	RolesCfg   = "roles"
+	UsersCfg   = "users"
+	MethodsCfg = "methods"
+	TenantsCfg = "tenants"
+	KeyCfg     = "key"
+)
+
 // STIR/SHAKEN
 const (
 	STIRAlg = "ES256"
@@ -2423,14 +2577,14 @@ var CGROptionsSet = NewStringSet([]string{OptsSessionsTTL,
 	OptsSessionsTTLMaxDelay, OptsSessionsTTLLastUsed, OptsSessionsTTLLastUsage, OptsSessionsTTLUsage,
 	OptsDebitInterval, OptsStirATest, OptsStirPayloadMaxDuration, OptsStirIdentity,
 	OptsStirOriginatorTn, OptsStirOriginatorURI, OptsStirDestinationTn, OptsStirDestinationURI,
-	OptsStirPublicKeyPath, OptsStirPrivateKeyPath, OptsAPIKey, OptsRouteID, OptsContext,
+	OptsStirPublicKeyPath, OptsStirPrivateKeyPath, OptsAPIKey, OptsAPIUser, OptsRouteID, OptsContext,
 	OptsAttributesProcessRuns, OptsAttributesProfileIDs, OptsRoutesLimit, OptsRoutesOffset,
 	OptsRoutesIgnoreErrors, OptsRoutesMaxCost, OptsChargeable, RemoteHostOpt, CacheOpt,
 	OptsRoutesProfileCount, OptsDispatchersProfilesCount, OptsAttributesProfileRuns,
 	OptsAttributesProfileIgnoreFilters, OptsStatsProfileIDs, OptsStatsProfileIgnoreFilters,
 	OptsThresholdsProfileIDs, OptsThresholdsProfileIgnoreFilters, OptsResourcesUsageID, OptsResourcesUsageTTL,
-	OptsResourcesUnits, OptsAttributeS, OptsThresholdS, OptsChargerS, OptsStatS, OptsRALs, OptsRerate,
-	OptsRefund})
+	OptsResourcesUnits, OptsAttributeS, OptsThresholdS, OptsChargerS, OptsStatS, OptsFraudS, OptsRouteS, OptsRALs, OptsRerate,
+	OptsRefund, OptsLowBalanceThresholds})
 
 // EventExporter metrics
 const (
@@ -2459,6 +2613,7 @@ const (
 	OptsSessionsTTLUsage     = "*sessionsTTLUsage"
 	OptsDebitInterval        = "*sessionsDebitInterval"
 	OptsChargeable           = "*sessionsChargeable"
+	OptsLowBalanceThresholds = "*sessionsLowBalanceThresholds"
 	// STIR
 	OptsStirATest              = "*stirATest"
 	OptsStirPayloadMaxDuration = "*stirPayloadMaxDuration"
@@ -2471,6 +2626,8 @@ const (
 	OptsStirPrivateKeyPath     = "*stirPrivateKeyPath"
 	// DispatcherS
 	OptsAPIKey                   = "*apiKey"
+	OptsAPIUser                  = "*apiUser"
+	OptsAuthKey                  = "*authKey"
 	OptsRouteID                  = "*routeID"
 	OptsDispatchersProfilesCount = "*dispatchersProfilesCount"
 	// EEs
@@ -2496,6 +2653,8 @@ const (
 	OptsChargerS   = "*chargerS"
 	OptsStatS      = "*statS"
 	OptsThresholdS = "*thresholdS"
+	OptsFraudS     = "*fraudS"
+	OptsRouteS     = "*routeS"
 	OptsRALs       = "*ralS"
 	OptsRerate     = "*rerate"
 	OptsRefund     = "*refund"
@@ -2507,6 +2666,7 @@ const (
 	OptsAttributesProcessRuns          = "*attrProcessRuns"
 	OptsAttributesProfileRuns          = "*attrProfileRuns"
 	OptsAttributesProfileIgnoreFilters = "*attrProfileIgnoreFilters"
+	OptsExplain                        = "*explain"
 	MetaEventType                      = "*eventType"
 	EventType                          = "EventType"
 	SchedulerInit                      = "SchedulerInit"
@@ -2515,6 +2675,15 @@ const (
 	CacheOpt      = "*cache"
 )
 
+// Explain reasons for the profiles not selected
+const (
+	ExplainContextNotMatching = "CONTEXT_NOT_MATCHING"
+	ExplainNotActive          = "NOT_ACTIVE"
+	ExplainFiltersNotPassing  = "FILTERS_NOT_PASSING"
+	ExplainAlreadyProcessed   = "ALREADY_PROCESSED"
+	ExplainLowerWeight        = "LOWER_WEIGHT"
+)
+
 // Event Flags
 const (
 	MetaDerivedReply = "*derived_reply"
@@ -2679,13 +2848,20 @@ const (
 	MetaLeveldb = "*leveldb"
 	MetaMoss    = "*mossdb"
 
-	RequestStartTime = "RequestStartTime"
-	RequestDuration  = "RequestDuration"
-	RequestParams    = "RequestParams"
-	Reply            = "Reply"
-	ReplyError       = "ReplyError"
-	AnzDBDir         = "db"
-	Opts             = "Opts"
+	RequestStartTime   = "RequestStartTime"
+	RequestDuration    = "RequestDuration"
+	RequestParams      = "RequestParams"
+	RequestID          = "RequestID"
+	RequestMethod      = "RequestMethod"
+	RequestEncoding    = "RequestEncoding"
+	RequestSource      = "RequestSource"
+	RequestDestination = "RequestDestination"
+	Reply              = "Reply"
+	ReplyError         = "ReplyError"
+	AnzDBDir           = "db"
+	Opts               = "Opts"
+	MetaAPIMethod      = "*method"
+	MetaConnection     = "*connection"
 )
 
 //CMD constants
@@ -2747,9 +2923,26 @@ const (
 	CacheSAddress     = "caches_address"
 	SchedulerAddress  = "scheduler_address"
 	//Cgr migrator
-	CgrMigrator = "cgr-migrator"
-	ExecCgr     = "exec"
-)
+	CgrMigrator        = "cgr-migrator"
+	ExecCgr            = "exec"
+	SnapshotPathCgr    = "snapshot_path"
+	SnapshotTenantsCgr = "snapshot_tenants"
+	SnapshotFlushCgr   = "snapshot_flush"
+)
+
+// TenantQuotaObjects are the DataDB item types limited by the objects quota of a tenant
+var TenantQuotaObjects = StringSet{
+	MetaAccounts:            {},
+	CacheFilters:            {},
+	CacheThresholdProfiles:  {},
+	CacheStatQueueProfiles:  {},
+	CacheResourceProfiles:   {},
+	CacheRouteProfiles:      {},
+	CacheAttributeProfiles:  {},
+	CacheChargerProfiles:    {},
+	CacheDispatcherProfiles: {},
+	CacheDispatcherHosts:    {},
+}
 
 var AnzIndexType = StringSet{ // AnzIndexType are the analyzers possible index types
 	MetaScorch:   {},
@@ -76,6 +76,8 @@ var (
 	ErrMaxConcurentRPCExceeded       = errors.New("MAX_CONCURENT_RPC_EXCEEDED") // but the codec will rewrite it with this one to be sure that we corectly dealocate the request
 	ErrMaxIterationsReached          = errors.New("maximum iterations reached")
 	ErrNegative                      = errors.New("NEGATIVE")
+	ErrMaxAccountSessionsExceeded    = errors.New("MAX_ACCOUNT_SESSIONS_EXCEEDED")
+	ErrFraudBlocked                  = errors.New("FRAUD_BLOCKED")
 
 	ErrMap = map[string]error{
 		ErrNoMoreData.Error():              ErrNoMoreData,
@@ -158,6 +160,11 @@ func NewErrMandatoryIeMissing(fields ...string) error {
 	return fmt.Errorf("MANDATORY_IE_MISSING: %v", fields)
 }
 
+// NewErrTenantQuotaExceeded returns the error of a request rejected by the quota of its tenant
+func NewErrTenantQuotaExceeded(quota, tnt string) error {
+	return fmt.Errorf("TENANT_QUOTA_EXCEEDED: <%s> for tenant <%s>", quota, tnt)
+}
+
 func NewErrServerError(err error) error {
 	return fmt.Errorf("SERVER_ERROR: %s", err)
 }
@@ -202,6 +209,10 @@ func NewErrThresholdS(err error) error {
 	return fmt.Errorf("THRESHOLDS_ERROR:%s", err)
 }
 
+func NewErrFraudS(err error) error {
+	return fmt.Errorf("FRAUDS_ERROR:%s", err)
+}
+
 func NewErrDispatcherS(err error) error {
 	return fmt.Errorf("%s:%s", DispatcherErrorPrefix, err.Error())
 }
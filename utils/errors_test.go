@@ -119,6 +119,19 @@ func TestNewErrResourceS(t *testing.T) {
 	}
 }
 
+func TestNewErrFraudS(t *testing.T) {
+	if rcv := NewErrFraudS(ErrFraudBlocked); rcv.Error() != "FRAUDS_ERROR:FRAUD_BLOCKED" {
+		t.Errorf("Expecting: FRAUDS_ERROR:FRAUD_BLOCKED, received: %+v", rcv)
+	}
+}
+
+func TestNewErrTenantQuotaExceeded(t *testing.T) {
+	exp := "TENANT_QUOTA_EXCEEDED: <sessions> for tenant <cgrates.org>"
+	if rcv := NewErrTenantQuotaExceeded(SessionsCfg, "cgrates.org"); rcv.Error() != exp {
+		t.Errorf("Expecting: %s, received: %+v", exp, rcv)
+	}
+}
+
 func TestNewErrSupplierS(t *testing.T) {
 	cgrError := NewCGRError("context", "apiError", "shortError", "longError")
 	if rcv := NewErrRouteS(cgrError); rcv.Error() != "ROUTES_ERROR:shortError" {